@@ -37,6 +37,7 @@ func populateCardMaps() error {
 	var setErr, cardErr, cardRarityErr error
 	sets, setsToShort, setErr = populateSets()
 	cards, cardsToSets, cardErr = populateCardsTranslationMap(sets)
+	cardSearchIndex = buildSearchIndex(cards)
 	setsToCardsAndRarity, cardRarityErr = populateCardsRarityMap(sets)
 	if cardErr!=nil {
 		return cardErr
@@ -5,10 +5,93 @@ import(
 	"net/http"
 	"github.com/emicklei/go-restful"
 
+	"strconv"
+	"strings"
+	"time"
+
 	"./../../../common/priceDB"
 
 )
 
+// The window a price history covers when no start is requested
+const defaultHistoryRange time.Duration = 60 * 24 * time.Hour
+
+// The longest window a price history may cover at each granularity
+var maxHistoryRanges = map[string]time.Duration{
+	priceDB.Day: 366 * 24 * time.Hour,
+	priceDB.Week: 5 * 366 * 24 * time.Hour,
+}
+
+// Median prices of a printing over time, one series per source.
+type PriceHistory struct{
+	Name, Set, Granularity string
+	From, To priceDB.Timestamp
+	Series map[string]priceDB.Prices
+}
+
+// Parses the unix timestamps bounding a price history.
+//
+// to defaults to now and from to defaultHistoryRange before to. The
+// window must be ordered and no longer than granularity allows.
+func historyRange(fromRaw, toRaw, granularity string,
+	now time.Time) (time.Time, time.Time, bool) {
+
+	maxRange, ok:= maxHistoryRanges[granularity]
+	if !ok {
+		return time.Time{}, time.Time{}, false
+	}
+
+	to:= now
+	if toRaw != "" {
+		epoch, err:= strconv.ParseInt(toRaw, 10, 64)
+		if err!=nil {
+			return time.Time{}, time.Time{}, false
+		}
+		to = time.Unix(epoch, 0)
+	}
+
+	from:= to.Add(-defaultHistoryRange)
+	if fromRaw != "" {
+		epoch, err:= strconv.ParseInt(fromRaw, 10, 64)
+		if err!=nil {
+			return time.Time{}, time.Time{}, false
+		}
+		from = time.Unix(epoch, 0)
+	}
+
+	if !from.Before(to) || to.Sub(from) > maxRange {
+		return time.Time{}, time.Time{}, false
+	}
+
+	return from, to, true
+
+}
+
+// Parses a comma separated list of price sources, every source when
+// empty.
+func historySources(raw string) ([]string, bool) {
+
+	if raw == "" {
+		return priceDB.Sources, true
+	}
+
+	sources:= make([]string, 0)
+	seen:= make(map[string]bool)
+	for _, source:= range strings.Split(raw, ","){
+		source = strings.TrimSpace(source)
+		if !validPriceSources[source] {
+			return nil, false
+		}
+		if !seen[source] {
+			seen[source] = true
+			sources = append(sources, source)
+		}
+	}
+
+	return sources, true
+
+}
+
 // Register price data returning multiple points
 func (aService *PriceService) registerHistorical() {
 	
@@ -47,6 +130,89 @@ func (aService *PriceService) registerHistorical() {
 		Returns(http.StatusBadRequest, BadCardFilter, nil).
 		Returns(http.StatusOK, "All prices for a specific printing from DefaultPriceSource or specific price source at week granularity", nil))
 
+	priceService.Route(priceService.
+		GET("/Card/{cardName}/PriceHistory").
+		To(aService.getCardPriceHistory).
+		// Docs
+		Doc("Median prices for a printing of a card in each day or week between two times, for charting").
+		Operation("getCardPriceHistory").
+		Param(priceService.PathParameter("cardName",
+			"Name of a Magic: the Gathering card").DataType("string")).
+		Param(priceService.QueryParameter("set",
+			"Name of a Magic: the Gathering set").DataType("string")).
+		Param(priceService.QueryParameter("from",
+			"Unix timestamp the history starts at, 60 days before to when absent").DataType("int")).
+		Param(priceService.QueryParameter("to",
+			"Unix timestamp the history ends before, now when absent").DataType("int")).
+		Param(priceService.QueryParameter("granularity",
+			"day or week, day when absent").DataType("string")).
+		Param(priceService.QueryParameter("source",
+			"Comma separated price sources, every source when absent").DataType("string")).
+		Writes(PriceHistory{}).
+		Returns(http.StatusInternalServerError, "Price DB lookup failed", nil).
+		Returns(http.StatusBadRequest, BadCardFilter, nil).
+		Returns(http.StatusBadRequest, BadHistoryRange, nil).
+		Returns(http.StatusBadRequest, BadSource, nil).
+		Returns(http.StatusOK, "A series of median prices, oldest first, for each requested source", nil))
+
+}
+
+func (aService *PriceService) getCardPriceHistory(req *restful.Request,
+	resp *restful.Response) {
+
+	cardName:= req.PathParameter("cardName")
+	setName:= req.QueryParameter("set")
+	if !cards[cardName] {
+		resp.WriteErrorString(http.StatusBadRequest, BadCard)
+		return
+	}
+	if !sets[setName] {
+		resp.WriteErrorString(http.StatusBadRequest, BadSet)
+		return
+	}
+
+	granularity:= req.QueryParameter("granularity")
+	if granularity == "" {
+		granularity = priceDB.Day
+	}
+	from, to, ok:= historyRange(req.QueryParameter("from"),
+		req.QueryParameter("to"), granularity, time.Now())
+	if !ok {
+		resp.WriteErrorString(http.StatusBadRequest, BadHistoryRange)
+		return
+	}
+
+	sources, ok:= historySources(req.QueryParameter("source"))
+	if !ok {
+		resp.WriteErrorString(http.StatusBadRequest, BadSource)
+		return
+	}
+
+	history:= PriceHistory{
+		Name: cardName,
+		Set: setName,
+		Granularity: granularity,
+		From: priceDB.Timestamp(from),
+		To: priceDB.Timestamp(to),
+		Series: make(map[string]priceDB.Prices),
+	}
+	for _, source:= range sources{
+		series, err:= priceDB.GetCardHistoryRange(aService.pool,
+			cardName, setName, source,
+			history.From, history.To, granularity)
+		if err!=nil {
+			resp.WriteErrorString(http.StatusInternalServerError,
+				"Price DB lookup failed, ")
+			return
+		}
+
+		history.Series[source] = series
+	}
+
+	// Set cache header to reduce load.
+	setCacheHeader(resp)
+
+	resp.WriteEntity(history)
 }
 
 
@@ -0,0 +1,69 @@
+package ApiServices
+
+import(
+
+	"./../../../common/priceDB"
+
+	"strconv"
+
+	"testing"
+
+	"time"
+
+)
+
+func TestHistoryRange(t *testing.T) {
+
+	now:= time.Unix(1500000000, 0)
+
+	from, to, ok:= historyRange("", "", priceDB.Day, now)
+	if !ok || !to.Equal(now) || to.Sub(from) != defaultHistoryRange {
+		t.Fatal("incorrect default range", from, to)
+	}
+
+	year:= strconv.FormatInt(now.Add(-400 * 24 * time.Hour).Unix(), 10)
+	_, _, ok = historyRange(year, "", priceDB.Day, now)
+	if ok {
+		t.Fatal("daily history beyond a year accepted")
+	}
+	_, _, ok = historyRange(year, "", priceDB.Week, now)
+	if !ok {
+		t.Fatal("weekly history over a year refused")
+	}
+
+	for _, bad:= range [][3]string{
+		{"", "", "month"},
+		{"soon", "", priceDB.Day},
+		{"1500000000", "1400000000", priceDB.Day},
+	}{
+		_, _, ok = historyRange(bad[0], bad[1], bad[2], now)
+		if ok {
+			t.Fatal("illegible range accepted", bad)
+		}
+	}
+
+}
+
+func TestHistorySources(t *testing.T) {
+
+	validPriceSources = map[string]bool{"mtgprice": true, "mkm": true}
+	defer func() {
+		validPriceSources = make(map[string]bool)
+	}()
+
+	sources, ok:= historySources("")
+	if !ok || len(sources) != len(priceDB.Sources) {
+		t.Fatal("every source not defaulted to", sources)
+	}
+
+	sources, ok = historySources("mkm, mkm,mtgprice")
+	if !ok || len(sources) != 2 || sources[0] != "mkm" {
+		t.Fatal("sources misparsed", sources)
+	}
+
+	_, ok = historySources("mkm,ebay")
+	if ok {
+		t.Fatal("unknown source accepted")
+	}
+
+}
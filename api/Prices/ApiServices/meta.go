@@ -5,6 +5,8 @@ import(
 	"net/http"
 	"github.com/emicklei/go-restful"
 
+	"sort"
+
 )
 
 // Register non-price metadata endpoints
@@ -18,7 +20,7 @@ func (aService *PriceService) registerMeta() {
 		Doc("All available sets").
 		Operation("getSetList").
 		Writes([]string{}).
-		Returns(http.StatusOK, "All available sets", nil))
+		Returns(http.StatusOK, "All available sets, sorted by name", nil))
 
 	priceService.Route(priceService.
 		GET("/SetToShort").To(aService.getSetToShortMap).
@@ -47,6 +49,7 @@ func (aService *PriceService) getSetList(req *restful.Request,
 			setList = append(setList, aSet)	
 		}
 	}
+	sort.Strings(setList)
 
 	setCacheHeader(resp)
 
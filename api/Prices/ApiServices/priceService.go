@@ -20,6 +20,10 @@ const RemoteAPIError string = "Remote API query failed"
 const BadCard string = "Illegal Card Name"
 const BadSet string = "Illegal Set Name"
 const BadTime string = "Illegible time"
+const BadHistoryRange string = "Illegible history range, expected unix timestamps at most a year apart by day or five by week"
+const BadSource string = "Illegal price source"
+const BadSearch string = "Search query must be at least 2 characters"
+const BadPage string = "Illegible page, expected a non-negative offset and a limit of at most 100"
 const BadCardFilter string = BadCard + " || " + BadSet
 const BadCalculation string = "Failed Calculation"
 
@@ -30,6 +34,9 @@ type PriceService struct{
 	pool *pgx.ConnPool
 	Service *restful.WebService
 	logger *log.Logger
+
+	setCache *setLatestCache
+	ready readiness
 }
 
 // Returns a fresh PriceService ready to be hooked up to restful
@@ -51,6 +58,7 @@ func NewPriceService() *PriceService {
 	aService:= PriceService{
 		pool: pool,
 		logger: priceLogger,
+		setCache: newSetLatestCache(setCacheTTL),
 	}
 
 	// Register everything
@@ -59,6 +67,10 @@ func NewPriceService() *PriceService {
 		priceLogger.Fatalln("Failed to register PriceService, ", err)
 	}
 
+	// Traffic is held off by the readiness probe until this finishes
+	go warmCaches(aService.warmers(warmSetCount()), &aService.ready,
+		priceLogger)
+
 	return &aService
 
 }
@@ -91,6 +103,8 @@ func (aService *PriceService) register() error {
 	aService.registerClosest()
 	aService.registerSets()
 	aService.registerDecks()
+	aService.registerReady()
+	aService.registerSearch()
 
 	return nil
 
@@ -0,0 +1,199 @@
+package ApiServices
+
+import(
+
+	"net/http"
+	"github.com/emicklei/go-restful"
+
+	"./../../../common/mtgjson"
+
+	"sort"
+	"strconv"
+	"strings"
+
+)
+
+// How many cards a page of search results holds unless requested
+const defaultSearchLimit int = 20
+const maxSearchLimit int = 100
+
+// The shortest query searched for, anything shorter matches most cards
+const minSearchLength int = 2
+
+// A card matching a search with every valid set it was printed in.
+type CardRecord struct{
+	Name string
+	Sets []string
+}
+
+// A single page of cards matching a search.
+//
+// Total counts every match so clients can page through them all.
+type CardSearchResults struct{
+	Total int
+	Cards []CardRecord
+}
+
+// Every card name alongside its normalized form, sorted by name.
+//
+// Built with the card maps so searches needn't normalize every name.
+type searchIndex []indexedCard
+
+type indexedCard struct{
+	Name, normalized string
+}
+
+var cardSearchIndex searchIndex
+
+func buildSearchIndex(cards map[string]bool) searchIndex {
+
+	index:= make(searchIndex, 0, len(cards))
+	for aCardName:= range cards{
+		index = append(index, indexedCard{aCardName,
+			mtgjson.NormalizeCardName(aCardName)})
+	}
+
+	sort.Slice(index, func(i, j int) bool {
+		return index[i].Name < index[j].Name
+	})
+
+	return index
+
+}
+
+// Finds every card whose name contains query, ignoring case and
+// accents, printed in set when it is not empty.
+//
+// Exact matches come first, then names starting with the query, then
+// the rest. Each group is sorted by name.
+func (index searchIndex) search(query, set string,
+	cardsToSets map[string]map[string]bool) []string {
+
+	query = mtgjson.NormalizeCardName(strings.TrimSpace(query))
+
+	var exact, prefix, contains []string
+	for _, aCard:= range index{
+		if set != "" && !cardsToSets[aCard.Name][set] {
+			continue
+		}
+
+		switch {
+		case aCard.normalized == query:
+			exact = append(exact, aCard.Name)
+		case strings.HasPrefix(aCard.normalized, query):
+			prefix = append(prefix, aCard.Name)
+		case strings.Contains(aCard.normalized, query):
+			contains = append(contains, aCard.Name)
+		}
+	}
+
+	matches:= make([]string, 0, len(exact) + len(prefix) + len(contains))
+	matches = append(matches, exact...)
+	matches = append(matches, prefix...)
+
+	return append(matches, contains...)
+
+}
+
+// Parses the offset and limit of a page of results, applying defaults.
+func searchPage(offsetRaw, limitRaw string) (int, int, bool) {
+
+	offset, limit:= 0, defaultSearchLimit
+
+	var err error
+	if offsetRaw != "" {
+		offset, err = strconv.Atoi(offsetRaw)
+		if err!=nil || offset < 0 {
+			return 0, 0, false
+		}
+	}
+	if limitRaw != "" {
+		limit, err = strconv.Atoi(limitRaw)
+		if err!=nil || limit <= 0 || limit > maxSearchLimit {
+			return 0, 0, false
+		}
+	}
+
+	return offset, limit, true
+
+}
+
+// Register lookups against the card maps
+func (aService *PriceService) registerSearch() {
+
+	priceService:= aService.Service
+
+	priceService.Route(priceService.
+		GET("/Cards/Search").To(aService.searchCards).
+		// Docs
+		Doc("Cards whose names contain a query, ignoring case and accents, with the sets each was printed in").
+		Operation("searchCards").
+		Param(priceService.QueryParameter("q",
+			"Part of a card name, at least 2 characters").DataType("string")).
+		Param(priceService.QueryParameter("set",
+			"Name of a Magic: the Gathering set the cards must be printed in").DataType("string")).
+		Param(priceService.QueryParameter("offset",
+			"How many matches to skip, 0 when absent").DataType("int")).
+		Param(priceService.QueryParameter("limit",
+			"How many matches to return, at most 100 and 20 when absent").DataType("int")).
+		Writes(CardSearchResults{}).
+		Returns(http.StatusBadRequest, BadSearch, nil).
+		Returns(http.StatusBadRequest, BadSet, nil).
+		Returns(http.StatusBadRequest, BadPage, nil).
+		Returns(http.StatusOK, "Exact matches first, then names starting with the query, then the rest", nil))
+
+}
+
+func (aService *PriceService) searchCards(req *restful.Request,
+	resp *restful.Response) {
+
+	query:= req.QueryParameter("q")
+	if len([]rune(strings.TrimSpace(query))) < minSearchLength {
+		resp.WriteErrorString(http.StatusBadRequest, BadSearch)
+		return
+	}
+
+	setName:= req.QueryParameter("set")
+	if setName != "" && !sets[setName] {
+		resp.WriteErrorString(http.StatusBadRequest, BadSet)
+		return
+	}
+
+	offset, limit, ok:= searchPage(req.QueryParameter("offset"),
+		req.QueryParameter("limit"))
+	if !ok {
+		resp.WriteErrorString(http.StatusBadRequest, BadPage)
+		return
+	}
+
+	matches:= cardSearchIndex.search(query, setName, cardsToSets)
+
+	results:= CardSearchResults{
+		Total: len(matches),
+		Cards: make([]CardRecord, 0),
+	}
+	if offset < len(matches) {
+		matches = matches[offset:]
+		if len(matches) > limit {
+			matches = matches[:limit]
+		}
+
+		for _, aCardName:= range matches{
+			printings:= make([]string, 0, len(cardsToSets[aCardName]))
+			for aSet:= range cardsToSets[aCardName]{
+				printings = append(printings, aSet)
+			}
+			sort.Strings(printings)
+
+			results.Cards = append(results.Cards, CardRecord{
+				Name: aCardName,
+				Sets: printings,
+			})
+		}
+	}
+
+	setCacheHeader(resp)
+
+	resp.WriteEntity(results)
+
+}
@@ -0,0 +1,59 @@
+package ApiServices
+
+import(
+
+	"testing"
+
+)
+
+func TestSearch(t *testing.T) {
+
+	cards:= map[string]bool{"Æther Vial": true, "Vial Smasher the Fierce": true,
+		"Aether Burst": true, "Sol Ring": true}
+	cardsToSets:= map[string]map[string]bool{
+		"Æther Vial": map[string]bool{"Darksteel": true},
+		"Vial Smasher the Fierce": map[string]bool{"Commander 2016": true},
+		"Aether Burst": map[string]bool{"Tempest": true},
+		"Sol Ring": map[string]bool{"Alpha": true},
+	}
+	index:= buildSearchIndex(cards)
+
+	matches:= index.search("vial", "", cardsToSets)
+	if len(matches) != 2 || matches[0] != "Vial Smasher the Fierce" ||
+		matches[1] != "Æther Vial" {
+		t.Fatal("prefix matches not ranked first", matches)
+	}
+
+	matches = index.search(" aether ", "", cardsToSets)
+	if len(matches) != 2 || matches[0] != "Aether Burst" {
+		t.Fatal("accents not ignored", matches)
+	}
+
+	matches = index.search("aether vial", "", cardsToSets)
+	if len(matches) != 1 || matches[0] != "Æther Vial" {
+		t.Fatal("exact match missed", matches)
+	}
+
+	matches = index.search("aether", "Darksteel", cardsToSets)
+	if len(matches) != 1 || matches[0] != "Æther Vial" {
+		t.Fatal("set filter ignored", matches)
+	}
+
+}
+
+func TestSearchPage(t *testing.T) {
+
+	offset, limit, ok:= searchPage("", "")
+	if !ok || offset != 0 || limit != defaultSearchLimit {
+		t.Fatal("incorrect default page", offset, limit)
+	}
+
+	for _, bad:= range [][2]string{{"-1", ""}, {"", "0"}, {"x", ""},
+		{"", "101"}}{
+		_, _, ok = searchPage(bad[0], bad[1])
+		if ok {
+			t.Fatal("illegible page accepted", bad)
+		}
+	}
+
+}
@@ -80,8 +80,7 @@ func (aService *PriceService) getSetLatestPrices(req *restful.Request,
 		sourceName = DefaultPriceSource
 	}
 
-	cardPrices, err:= priceDB.GetSetLatest(aService.pool,
-		setName, sourceName)
+	cardPrices, err:= aService.getSetLatest(setName, sourceName)
 	if err!=nil {
 		resp.WriteErrorString(http.StatusInternalServerError,
 			"Price DB lookup failed, ")
@@ -109,8 +108,7 @@ func (aService *PriceService) getSetLatestBoxEV(req *restful.Request,
 		sourceName = DefaultPriceSource
 	}
 
-	cardPrices, err:= priceDB.GetSetLatest(aService.pool,
-		setName, sourceName)
+	cardPrices, err:= aService.getSetLatest(setName, sourceName)
 	if err!=nil {
 		resp.WriteErrorString(http.StatusInternalServerError,
 			"Price DB lookup failed, ")
@@ -0,0 +1,256 @@
+package ApiServices
+
+import(
+
+	"net/http"
+	"github.com/emicklei/go-restful"
+
+	"./../../../common/mtgjson"
+	"./../../../common/priceDB"
+
+	"log"
+	"os"
+	"strconv"
+
+	"sort"
+	"sync"
+	"time"
+
+)
+
+const NotReady string = "Warming caches, not ready for traffic"
+
+// How many of the most recently released sets have their latest
+// prices loaded before we report ready. Zero skips warming, which
+// is handy for quick development restarts.
+const warmSetsEnv string = "PRICES_WARM_SETS"
+const defaultWarmSets int = 10
+
+// Matches the lifetime we hand out in setCacheHeader
+const setCacheTTL time.Duration = 5 * time.Hour
+
+type setCacheKey struct{
+	set, source string
+}
+
+type setCacheEntry struct{
+	prices priceDB.Prices
+	fetched time.Time
+}
+
+// Latest prices for entire sets, these are the heaviest queries
+// we serve and the ones new sets hammer.
+type setLatestCache struct{
+	sync.RWMutex
+	entries map[setCacheKey]setCacheEntry
+	ttl time.Duration
+}
+
+func newSetLatestCache(ttl time.Duration) *setLatestCache {
+	return &setLatestCache{
+		entries: make(map[setCacheKey]setCacheEntry),
+		ttl: ttl,
+	}
+}
+
+func (c *setLatestCache) get(set, source string) (priceDB.Prices, bool) {
+
+	c.RLock()
+	defer c.RUnlock()
+
+	entry, ok:= c.entries[setCacheKey{set, source}]
+	if !ok || time.Since(entry.fetched) > c.ttl {
+		return nil, false
+	}
+
+	return entry.prices, true
+
+}
+
+func (c *setLatestCache) put(set, source string, prices priceDB.Prices) {
+
+	c.Lock()
+	defer c.Unlock()
+
+	c.entries[setCacheKey{set, source}] = setCacheEntry{
+		prices: prices,
+		fetched: time.Now(),
+	}
+
+}
+
+// Acquires the latest prices for a set, preferring our cache.
+func (aService *PriceService) getSetLatest(set,
+	source string) (priceDB.Prices, error) {
+
+	prices, ok:= aService.setCache.get(set, source)
+	if ok {
+		return prices, nil
+	}
+
+	prices, err:= priceDB.GetSetLatest(aService.pool, set, source)
+	if err!=nil {
+		return nil, err
+	}
+
+	aService.setCache.put(set, source, prices)
+
+	return prices, nil
+
+}
+
+// Whether an instance has finished warming and should receive traffic
+type readiness struct{
+	sync.RWMutex
+	ready bool
+}
+
+func (r *readiness) markReady() {
+	r.Lock()
+	defer r.Unlock()
+	r.ready = true
+}
+
+func (r *readiness) isReady() bool {
+	r.RLock()
+	defer r.RUnlock()
+	return r.ready
+}
+
+// Runs every warmer in turn then marks the instance ready.
+//
+// A failed warmer is logged but never holds the instance out of
+// rotation; a cold instance is better than none at all.
+func warmCaches(warmers []func() error, ready *readiness,
+	aLogger *log.Logger) {
+
+	for _, warm:= range warmers{
+		err:= warm()
+		if err!=nil {
+			aLogger.Println("failed to warm cache,", err)
+		}
+	}
+
+	ready.markReady()
+
+}
+
+func warmSetCount() int {
+
+	raw:= os.Getenv(warmSetsEnv)
+	if raw == "" {
+		return defaultWarmSets
+	}
+
+	count, err:= strconv.Atoi(raw)
+	if err!=nil || count < 0 {
+		return defaultWarmSets
+	}
+
+	return count
+
+}
+
+// Sorts sets by release date, most recent first
+type newestFirst []*mtgjson.Set
+
+func (s newestFirst) Len() int {
+	return len(s)
+}
+
+func (s newestFirst) Swap(i, j int) {
+	s[i], s[j] = s[j], s[i]
+}
+
+// Release dates are YYYY-MM-DD so they order as strings
+func (s newestFirst) Less(i, j int) bool {
+	return s[i].ReleaseDate > s[j].ReleaseDate
+}
+
+// Returns up to count of the most recently released sets we serve
+func recentSets(count int) ([]string, error) {
+
+	aSetMap, err:= mtgjson.AllSetsX()
+	if err!=nil {
+		return nil, err
+	}
+
+	served:= make(newestFirst, 0, len(aSetMap))
+	for _, aSet:= range aSetMap{
+		if sets[aSet.Name] {
+			served = append(served, aSet)
+		}
+	}
+
+	sort.Sort(served)
+
+	if len(served) > count {
+		served = served[:count]
+	}
+
+	names:= make([]string, len(served))
+	for i, aSet:= range served{
+		names[i] = aSet.Name
+	}
+
+	return names, nil
+
+}
+
+// Everything we preload before accepting traffic
+func (aService *PriceService) warmers(setCount int) []func() error {
+
+	if setCount == 0 {
+		return nil
+	}
+
+	warmRecentSets:= func() error {
+
+		names, err:= recentSets(setCount)
+		if err!=nil {
+			return err
+		}
+
+		var lastErr error
+		for _, name:= range names{
+			_, err = aService.getSetLatest(name, DefaultPriceSource)
+			if err!=nil {
+				lastErr = err
+			}
+		}
+
+		return lastErr
+
+	}
+
+	return []func() error{warmRecentSets}
+
+}
+
+// Register the readiness probe used by our load balancer
+func (aService *PriceService) registerReady() {
+
+	priceService:= aService.Service
+
+	priceService.Route(priceService.
+		GET("/Ready").To(aService.getReady).
+		// Docs
+		Doc("Whether this instance has warmed its caches").
+		Operation("getReady").
+		Writes("string").
+		Returns(http.StatusServiceUnavailable, NotReady, nil).
+		Returns(http.StatusOK, "Ready for traffic", nil))
+
+}
+
+func (aService *PriceService) getReady(req *restful.Request,
+	resp *restful.Response) {
+
+	if !aService.ready.isReady() {
+		resp.WriteErrorString(http.StatusServiceUnavailable, NotReady)
+		return
+	}
+
+	resp.WriteEntity("ready")
+
+}
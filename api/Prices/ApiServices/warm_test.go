@@ -0,0 +1,96 @@
+package ApiServices
+
+import(
+
+	"fmt"
+
+	"io/ioutil"
+	"log"
+
+	"testing"
+
+	"time"
+
+)
+
+// Readiness must only flip once every warmer has returned.
+func TestReadyAfterWarming(t *testing.T) {
+
+	var ready readiness
+	aLogger:= log.New(ioutil.Discard, "", 0)
+
+	release:= make(chan struct{})
+	warmers:= []func() error{
+		func() error { return nil },
+		func() error {
+			<-release
+			return nil
+		},
+	}
+
+	done:= make(chan struct{})
+	go func() {
+		warmCaches(warmers, &ready, aLogger)
+		close(done)
+	}()
+
+	time.Sleep(10 * time.Millisecond)
+	if ready.isReady() {
+		t.Fatal("ready before warming completed")
+	}
+
+	close(release)
+	<-done
+
+	if !ready.isReady() {
+		t.Fatal("not ready after warming completed")
+	}
+
+}
+
+func TestReadyAfterFailedWarming(t *testing.T) {
+
+	var ready readiness
+	aLogger:= log.New(ioutil.Discard, "", 0)
+
+	warmCaches([]func() error{
+		func() error { return fmt.Errorf("foo") },
+	}, &ready, aLogger)
+
+	if !ready.isReady() {
+		t.Fatal("failed warming held instance out of rotation")
+	}
+
+}
+
+func TestWarmingDisabled(t *testing.T) {
+
+	aService:= PriceService{}
+	if len(aService.warmers(0)) != 0 {
+		t.Fatal("warmers present with warming disabled")
+	}
+
+}
+
+func TestSetLatestCacheExpiry(t *testing.T) {
+
+	cache:= newSetLatestCache(time.Hour)
+	cache.put("foo", "bar", nil)
+
+	_, ok:= cache.get("foo", "bar")
+	if !ok {
+		t.Fatal("fresh entry missing")
+	}
+	_, ok = cache.get("foo", "baz")
+	if ok {
+		t.Fatal("entry shared across sources")
+	}
+
+	cache.ttl = 0
+	time.Sleep(time.Millisecond)
+	_, ok = cache.get("foo", "bar")
+	if ok {
+		t.Fatal("stale entry served")
+	}
+
+}
@@ -5,26 +5,46 @@ import(
 	"github.com/mailgun/mailgun-go"
 
 	"text/template"
+	htmlTemplate "html/template"
 
 	"encoding/json"
 	"io/ioutil"
+
+	"log"
+	"net/http"
+	"time"
 )
 
 // A mailgun compatiable, send only client.
 type Mailer struct{
 	gun mailgun.Mailgun
+	client *http.Client // Shared with gun so we control its timeouts
 	source string // The address this mailer sends from
 	Templates map[string]*template.Template
+	// Optional html alternatives to Templates, under the same ids
+	HtmlTemplates map[string]*htmlTemplate.Template
+
+	// Logs mail rather than sending it, for developing against a
+	// live mailgun account without mailing anyone
+	DryRun bool
+	// Where dry runs are logged, the standard logger when nil
+	Logger *log.Logger
 }
 
 // Creates a mailgun client that we can use.
 //
 // Priv and public are the keys handed out by mailgun.
 // Domain is the domain assigned to the keypair.
+//
+// Requests use the default timeouts until SetTimeouts is called.
 func GetMailer(priv, pub, domain, sendingAddress string) *Mailer {
 	gun:= mailgun.NewMailgun(domain, priv, pub)
 	templateContainer:= make(map[string]*template.Template)
-	return &Mailer{gun, sendingAddress, templateContainer}
+	mailer:= &Mailer{gun: gun, source: sendingAddress,
+		Templates: templateContainer,
+		HtmlTemplates: make(map[string]*htmlTemplate.Template)}
+	mailer.SetTimeouts(DefaultConnectTimeout, DefaultReadTimeout)
+	return mailer
 }
 
 // Acquires a mailer metadata from a file located on disk.
@@ -44,15 +64,25 @@ func GetMailerFromFile(loc string) (*Mailer, error) {
 
 	mailer:= GetMailer(meta.PrivateKey, meta.PublicKey,
 		meta.Domain, meta.SendingAddress)
+	mailer.SetTimeouts(time.Duration(meta.ConnectTimeout) * time.Second,
+		time.Duration(meta.ReadTimeout) * time.Second)
+	mailer.DryRun = meta.DryRun
 
 	// Make sure we prepare all templates whose location are encoded
-	// in the metadata.
+	// in the metadata so a malformed one fails here rather than
+	// when first sent.
 	for id, loc:= range meta.Templates{
 		err = mailer.Prepare(id, loc)
 		if err!=nil {
 			return nil, err
 		}
 	}
+	for id, loc:= range meta.HtmlTemplates{
+		err = mailer.PrepareHtml(id, loc)
+		if err!=nil {
+			return nil, err
+		}
+	}
 
 	return mailer, nil
 }
\ No newline at end of file
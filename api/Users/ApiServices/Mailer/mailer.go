@@ -3,6 +3,7 @@ package mailer
 import(
 	"text/template"
 	"bytes"
+	"log"
 )
 
 // Sends plaintext via mailgun.
@@ -10,47 +11,119 @@ import(
 // body must be plaintext, no html. Format as desired.
 // to should be form NAME <EMAIL>
 // subject should be succinct.
+//
+// Timeouts are returned as a TransientError.
+//
+// Dry runs log the mail and never reach mailgun.
 func (mailer *Mailer) Send(body, to, subject string) error {
+	return mailer.SendAlternatives(body, "", to, subject)
+}
+
+// Sends plaintext via mailgun with an html alternative, for clients
+// which prefer it.
+//
+// An empty html sends plaintext alone, as Send does.
+func (mailer *Mailer) SendAlternatives(body, html, to, subject string) error {
 	
 	var err error
 
+	if mailer.DryRun {
+		mailer.logDryRun(body, html, to, subject)
+		return nil
+	}
+
 	m:= mailer.gun.NewMessage(mailer.source, subject, body)
 	err = m.AddRecipient(to)
 	if err!=nil {
 		return err
 	}
+	if html != "" {
+		m.SetHtml(html)
+	}
 
 	_,_, err = mailer.gun.Send(m)
 	
-	return err
+	return classify(err)
+
+}
+
+// Logs mail as it would have been sent
+func (mailer *Mailer) logDryRun(body, html, to, subject string) {
+
+	logf:= log.Printf
+	if mailer.Logger != nil {
+		logf = mailer.Logger.Printf
+	}
+
+	logf("dry run mail from %s to %s, subject %q\n%s",
+		mailer.source, to, subject, body)
+	if html != "" {
+		logf("dry run html alternative\n%s", html)
+	}
 
 }
 
 // Sends plaintext via mailgun.
 //
 // This allows easy access to the prepared templates
-// associated with this mailer
+// associated with this mailer. When an html template is prepared
+// under the same id it is rendered and sent as an alternative,
+// otherwise the mail is plaintext alone.
+//
+// Returns the rendered plaintext body.
 func (mailer *Mailer) SendPrepared(templateId string, content interface{},
-	to, subject string) error {
+	to, subject string) (string, error) {
+
+	htmlBody:= mailer.HtmlTemplates[templateId]
+	if htmlBody == nil {
+		return mailer.SendTemplated(mailer.Templates[templateId], content,
+			to, subject)
+	}
 
-	return mailer.SendTemplated(mailer.Templates[templateId], content,
+	body, err:= render(mailer.Templates[templateId], content)
+	if err!=nil {
+		return "", err
+	}
+
+	var htmlBuffer bytes.Buffer
+	err = htmlBody.Execute(&htmlBuffer, content)
+	if err!=nil {
+		return "", err
+	}
+
+	return body, mailer.SendAlternatives(body, htmlBuffer.String(),
 		to, subject)
+
 }
 
 // Sends plaintext via mailgun.
 //
 // This one supports efficient text templating for the body.
+//
+// Returns the rendered body.
 func (mailer *Mailer) SendTemplated(bodyTemplate *template.Template,
 	content interface{},
-	to, subject string) error {
+	to, subject string) (string, error) {
 
-	var bodyBuffer bytes.Buffer 
-    err:= bodyTemplate.Execute(&bodyBuffer, content)
+    body, err:= render(bodyTemplate, content)
     if err!=nil {
-     	return err
+     	return "", err
      } 
-    body:= bodyBuffer.String()
 
-    return mailer.Send(body, to, subject)
+    return body, mailer.Send(body, to, subject)
 
-}
\ No newline at end of file
+}
+
+// Executes a plaintext template with content
+func render(bodyTemplate *template.Template,
+	content interface{}) (string, error) {
+
+	var bodyBuffer bytes.Buffer 
+	err:= bodyTemplate.Execute(&bodyBuffer, content)
+	if err!=nil {
+		return "", err
+	}
+
+	return bodyBuffer.String(), nil
+
+}
@@ -0,0 +1,124 @@
+package mailer
+
+import(
+
+	"text/template"
+	htmlTemplate "html/template"
+
+	"bytes"
+	"log"
+	"strings"
+
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"testing"
+
+)
+
+// Dry runs render the template and log it without reaching mailgun,
+// which is unreachable here.
+func TestDryRun(t *testing.T) {
+
+	var logged bytes.Buffer
+	mailer:= GetMailer("foo", "bar", "baz", "qux")
+	mailer.DryRun = true
+	mailer.Logger = log.New(&logged, "", 0)
+	mailer.Templates["reset"] = template.Must(
+		template.New("reset").Parse("Hi {{.Name}}, your code is {{.Code}}"))
+
+	content:= struct{
+		Name, Code string
+	}{"foo", "1234"}
+	to:= FormatAddress("foo", "foo@example.com")
+
+	body, err:= mailer.SendPrepared("reset", content, to, "Reset")
+	if err!=nil {
+		t.Fatal("dry run failed to send", err)
+	}
+	if body != "Hi foo, your code is 1234" {
+		t.Fatal("incorrect rendered body", body)
+	}
+
+	if !strings.Contains(logged.String(), to) ||
+		!strings.Contains(logged.String(), body) {
+		t.Fatal("dry run not logged", logged.String())
+	}
+
+}
+
+// Templates with an html alternative send both, the html escaped
+// as html.
+func TestDryRunAlternatives(t *testing.T) {
+
+	var logged bytes.Buffer
+	mailer:= GetMailer("foo", "bar", "baz", "qux")
+	mailer.DryRun = true
+	mailer.Logger = log.New(&logged, "", 0)
+	mailer.Templates["reset"] = template.Must(
+		template.New("reset").Parse("Hi {{.Name}}"))
+	mailer.HtmlTemplates["reset"] = htmlTemplate.Must(
+		htmlTemplate.New("reset").Parse("<p>Hi {{.Name}}</p>"))
+
+	content:= struct{
+		Name string
+	}{"<foo>"}
+
+	body, err:= mailer.SendPrepared("reset", content,
+		FormatAddress("foo", "foo@example.com"), "Reset")
+	if err!=nil {
+		t.Fatal("dry run failed to send", err)
+	}
+	if body != "Hi <foo>" {
+		t.Fatal("incorrect plaintext body", body)
+	}
+	if !strings.Contains(logged.String(), "<p>Hi &lt;foo&gt;</p>") {
+		t.Fatal("html alternative not logged escaped", logged.String())
+	}
+
+}
+
+func TestPrepareTemplates(t *testing.T) {
+
+	dir, err:= ioutil.TempDir("", "mailerTemplates")
+	if err!=nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	valid:= filepath.Join(dir, "valid.txt.template")
+	malformed:= filepath.Join(dir, "malformed.txt.template")
+	err = ioutil.WriteFile(valid, []byte("Hey {{.Name}}"), 0666)
+	if err!=nil {
+		t.Fatal(err)
+	}
+	err = ioutil.WriteFile(malformed, []byte("Hey {{.Name"), 0666)
+	if err!=nil {
+		t.Fatal(err)
+	}
+
+	mailer:= GetMailer("foo", "bar", "baz", "qux")
+	err = mailer.Prepare("valid", valid)
+	if err!=nil || !mailer.HasTemplate("valid") {
+		t.Fatal("valid template not prepared", err)
+	}
+
+	err = mailer.Prepare("malformed", malformed)
+	if err==nil || mailer.HasTemplate("malformed") {
+		t.Fatal("malformed template prepared")
+	}
+	if mailer.HasTemplate("missing") {
+		t.Fatal("missing template reported present")
+	}
+
+	err = mailer.PrepareHtml("valid", valid)
+	if err!=nil || mailer.HtmlTemplates["valid"] == nil {
+		t.Fatal("valid html template not prepared", err)
+	}
+	err = mailer.PrepareHtml("missing", valid)
+	if err==nil {
+		t.Fatal("html template prepared without plaintext")
+	}
+
+}
@@ -2,6 +2,8 @@ package mailer
 
 import(
 	"text/template"
+	htmlTemplate "html/template"
+	"fmt"
 )
 
 
@@ -14,6 +16,14 @@ type MailGunMeta struct{
 	PrivateKey, PublicKey string
 	Domain, SendingAddress string
 	Templates map[string]string
+	// Html alternatives, each id must also be in Templates
+	HtmlTemplates map[string]string
+
+	// Seconds, left zero for DefaultConnectTimeout and DefaultReadTimeout
+	ConnectTimeout, ReadTimeout int
+
+	// Logs mail rather than sending it
+	DryRun bool
 }
 
 func FetchTemplate(loc string) (*template.Template, error) {
@@ -26,10 +36,36 @@ func FetchTemplate(loc string) (*template.Template, error) {
 func (mailer *Mailer) Prepare(id, loc string) error {
 	template, err:= FetchTemplate(loc)
 	if err!=nil {
-		return err
+		return fmt.Errorf("failed to prepare template %s, %v", id, err)
 	}
 
 	mailer.Templates[id] = template
 
 	return nil
+}
+
+// Prepares an html alternative to the template of the same id,
+// given a location on disk.
+//
+// The plaintext template must be prepared first, mail always has a
+// plaintext part.
+func (mailer *Mailer) PrepareHtml(id, loc string) error {
+	if !mailer.HasTemplate(id) {
+		return fmt.Errorf("html template %s has no plaintext template", id)
+	}
+
+	template, err:= htmlTemplate.ParseFiles(loc)
+	if err!=nil {
+		return fmt.Errorf("failed to prepare html template %s, %v", id, err)
+	}
+
+	mailer.HtmlTemplates[id] = template
+
+	return nil
+}
+
+// Determines if a template has been prepared under name
+func (mailer *Mailer) HasTemplate(name string) bool {
+	_, ok:= mailer.Templates[name]
+	return ok
 }
\ No newline at end of file
@@ -0,0 +1,72 @@
+package mailer
+
+import(
+	"net"
+	"net/http"
+	"time"
+)
+
+// Used when MailGunMeta leaves a timeout unset
+const DefaultConnectTimeout time.Duration = 5 * time.Second
+const DefaultReadTimeout time.Duration = 10 * time.Second
+
+// Returned when mailgun could not be reached in time.
+//
+// Unlike a rejected message, these are worth retrying.
+type TransientError struct{
+	Err error
+}
+
+func (e TransientError) Error() string {
+	return "transient mailgun failure, " + e.Err.Error()
+}
+
+// Returns whether a send failed in a way that may succeed later
+func IsTransient(err error) bool {
+	_, ok:= err.(TransientError)
+	return ok
+}
+
+// Builds a client bounded by connect, for dialing and the handshake,
+// and read, for waiting on mailgun once connected.
+func newClient(connect, read time.Duration) *http.Client {
+	return &http.Client{
+		Transport: &http.Transport{
+			Proxy: http.ProxyFromEnvironment,
+			Dial: (&net.Dialer{Timeout: connect}).Dial,
+			TLSHandshakeTimeout: connect,
+			ResponseHeaderTimeout: read,
+		},
+		// Bounds reading the body as well
+		Timeout: connect + read,
+	}
+}
+
+// Sets the timeouts used for every request to mailgun.
+//
+// Zero values fall back to the defaults.
+func (mailer *Mailer) SetTimeouts(connect, read time.Duration) {
+
+	if connect == 0 {
+		connect = DefaultConnectTimeout
+	}
+	if read == 0 {
+		read = DefaultReadTimeout
+	}
+
+	mailer.client = newClient(connect, read)
+	mailer.gun.SetClient(mailer.client)
+
+}
+
+// Wraps timeouts as TransientErrors, leaving everything else alone
+func classify(err error) error {
+
+	netErr, ok:= err.(net.Error)
+	if ok && netErr.Timeout() {
+		return TransientError{err}
+	}
+
+	return err
+
+}
@@ -0,0 +1,68 @@
+package mailer
+
+import(
+
+	"net/http"
+	"net/http/httptest"
+
+	"fmt"
+
+	"testing"
+
+	"time"
+
+)
+
+// Requests made through the client handed to mailgun should give up
+// on a hung server and be classified as worth retrying.
+func TestClientTimeout(t *testing.T) {
+
+	release:= make(chan struct{})
+	slow:= httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			select{
+			case <-release:
+			case <-time.After(5 * time.Second):
+			}
+		}))
+	defer slow.Close()
+	defer close(release)
+
+	mailer:= GetMailer("foo", "bar", "baz", "qux")
+	mailer.SetTimeouts(time.Second, 50 * time.Millisecond)
+
+	start:= time.Now()
+	_, err:= mailer.client.Get(slow.URL)
+	if err == nil {
+		t.Fatal("hung request did not time out")
+	}
+	if !IsTransient(classify(err)) {
+		t.Fatal("timeout not reported as transient", err)
+	}
+	if time.Since(start) > 2 * time.Second {
+		t.Fatal("timeout did not fire promptly")
+	}
+
+}
+
+func TestClassifyPermanent(t *testing.T) {
+
+	if IsTransient(classify(fmt.Errorf("foo"))) {
+		t.Fatal("ordinary failure reported as transient")
+	}
+	if classify(nil) != nil {
+		t.Fatal("success reported as failure")
+	}
+
+}
+
+func TestSetTimeoutsDefaults(t *testing.T) {
+
+	mailer:= GetMailer("foo", "bar", "baz", "qux")
+	mailer.SetTimeouts(0, 0)
+
+	if mailer.client.Timeout != DefaultConnectTimeout + DefaultReadTimeout {
+		t.Fatal("unset timeouts not defaulted", mailer.client.Timeout)
+	}
+
+}
@@ -0,0 +1,497 @@
+package ApiServices
+
+import(
+
+	"./userDBHandler"
+	"./mailer"
+	"./../../../common/priceDB"
+
+	"github.com/emicklei/go-restful"
+
+	"fmt"
+	"os"
+	"strconv"
+	"sync"
+
+	"net/http"
+	"time"
+
+)
+
+// Minutes between runs over every alert, zero stops alerts firing.
+const alertIntervalEnv string = "USERS_ALERT_INTERVAL_MINUTES"
+
+const defaultAlertInterval time.Duration = 6 * time.Hour
+
+// How many alerts are evaluated at once, see alertWorkerCount.
+const alertWorkersEnv string = "USERS_ALERT_WORKERS"
+
+// The largest move in percent an alert may wait for
+const maxAlertPercent int32 = 1000
+
+// How many alerts are evaluated at once unless configured otherwise.
+//
+// Alert evaluation hits both databases so we stay well under the
+// connections either pool allows.
+const defaultAlertWorkers int = 4
+const maxAlertWorkers int = 20
+
+// A single alert to be evaluated on behalf of a user.
+type alertCheck struct{
+	User string
+
+	// Returns whether or not the alert should fire
+	Evaluate func() (bool, error)
+
+	// Sends the notification for a fired alert
+	Notify func() error
+}
+
+// Describes a single run over every alert.
+type AlertRunMetrics struct{
+	Evaluated, Fired, Failed, MailsSent int
+	Duration time.Duration
+}
+
+func (m AlertRunMetrics) String() string {
+	return fmt.Sprintf("evaluated %d, fired %d, failed %d, mailed %d in %v",
+		m.Evaluated, m.Fired, m.Failed, m.MailsSent, m.Duration)
+}
+
+func alertInterval() time.Duration {
+
+	raw:= os.Getenv(alertIntervalEnv)
+	if raw == "" {
+		return defaultAlertInterval
+	}
+
+	minutes, err:= strconv.Atoi(raw)
+	if err!=nil || minutes < 0 {
+		return defaultAlertInterval
+	}
+
+	return time.Duration(minutes) * time.Minute
+
+}
+
+// Clamps a configured worker count into what the pools can support.
+func alertWorkerCount(configured int) int {
+
+	if configured <= 0 {
+		return defaultAlertWorkers
+	}
+	if configured > maxAlertWorkers {
+		return maxAlertWorkers
+	}
+
+	return configured
+
+}
+
+// The outcome of evaluating a single alert.
+type alertOutcome struct{
+	fired, failed, mailed bool
+}
+
+// Evaluates a single alert, notifying if it fires.
+//
+// A panicking alert is recorded as failed rather than taking
+// down the whole run.
+func evaluateAlert(check alertCheck) (outcome alertOutcome) {
+
+	defer func() {
+		if r:= recover(); r != nil {
+			outcome.failed = true
+		}
+	}()
+
+	fire, err:= check.Evaluate()
+	if err!=nil {
+		outcome.failed = true
+		return
+	}
+	if !fire {
+		return
+	}
+	outcome.fired = true
+
+	err = check.Notify()
+	if err!=nil {
+		outcome.failed = true
+		return
+	}
+	outcome.mailed = true
+
+	return
+
+}
+
+// Evaluates every provided alert with at most workers running at once.
+//
+// A slow or failing alert only ever occupies its own worker so the
+// rest of the run proceeds around it.
+func runAlertChecks(checks []alertCheck, workers int) AlertRunMetrics {
+
+	start:= time.Now()
+	workers = alertWorkerCount(workers)
+
+	jobs:= make(chan alertCheck)
+	outcomes:= make(chan alertOutcome)
+
+	var wg sync.WaitGroup
+	for i:= 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for check:= range jobs{
+				outcomes<- evaluateAlert(check)
+			}
+		}()
+	}
+
+	go func() {
+		for _, check:= range checks{
+			jobs<- check
+		}
+		close(jobs)
+		wg.Wait()
+		close(outcomes)
+	}()
+
+	var metrics AlertRunMetrics
+	for outcome:= range outcomes{
+		metrics.Evaluated++
+		if outcome.fired {
+			metrics.Fired++
+		}
+		if outcome.failed {
+			metrics.Failed++
+		}
+		if outcome.mailed {
+			metrics.MailsSent++
+		}
+	}
+
+	metrics.Duration = time.Since(start)
+
+	return metrics
+
+}
+
+// Runs every provided alert and logs how the run went.
+func (aService *UserService) checkAlerts(checks []alertCheck,
+	workers int) AlertRunMetrics {
+
+	metrics:= runAlertChecks(checks, workers)
+	aService.logger.Println("alert run", metrics)
+
+	return metrics
+
+}
+
+// Returns the message describing why an alert can't be set, empty
+// when it can.
+//
+// An alert must wait on a move, a price or both.
+func alertProblem(percent, threshold int32) string {
+
+	if percent < 0 || threshold < 0 || percent > maxAlertPercent {
+		return BadAlert
+	}
+	if percent == 0 && threshold == 0 {
+		return BadAlert
+	}
+
+	return ""
+
+}
+
+// Determines if a card's price moving from before to after, in cents,
+// fires an alert.
+//
+// Crossing the threshold counts in either direction, as does moving
+// by more than the alert's percent.
+func alertMoved(a userDB.Alert, before, after int32) bool {
+
+	if a.Threshold > 0 && (before < a.Threshold) != (after < a.Threshold) {
+		return true
+	}
+
+	if a.Percent > 0 && before > 0 {
+		delta:= int64(after) - int64(before)
+		if delta < 0 {
+			delta = -delta
+		}
+		return delta * 100 > int64(a.Percent) * int64(before)
+	}
+
+	return false
+
+}
+
+// A card whose price moved enough to fire an alert, in cents of
+// defaultCurrency.
+type AlertedCard struct{
+	Name, Set string
+	Before, After int32
+	// Before and After formatted in defaultLocale
+	FormattedBefore, FormattedAfter string
+}
+
+// The contents of a price alert email formatted to match the template.
+type alertEmailContents struct{
+	Name, Collection string
+	Cards []AlertedCard
+}
+
+// Finds every card held in an alert's collection which moved enough
+// since the alert's Since to fire it.
+//
+// The price before is the market price closest to Since, fetched for
+// every card at once. Cards without a price either side are passed over.
+func (aService *UserService) alertMoves(a userDB.Alert) ([]AlertedCard, error) {
+
+	current, err:= userDB.GetCollectionContents(aService.db(),
+		nil, a.Owner, a.Collection)
+	if err!=nil {
+		return nil, err
+	}
+
+	latest:= aService.marketPrices(current, defaultCurrency)
+
+	priced:= make([]AlertedCard, 0)
+	requests:= make([]priceDB.PrintingAt, 0)
+	seen:= make(map[printing]bool)
+	for _, aCard:= range current{
+		key:= printing{aCard.Name, aCard.Set}
+		if aCard.Quantity <= 0 || seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		after, ok:= priceFor(aCard, latest)
+		if !ok {
+			continue
+		}
+
+		priced = append(priced, AlertedCard{Name: aCard.Name,
+			Set: aCard.Set, After: after})
+		requests = append(requests, priceDB.PrintingAt{
+			Printing: priceDB.Printing{Name: aCard.Name, Set: aCard.Set},
+			When: priceDB.Timestamp(a.Since),
+		})
+	}
+
+	befores:= aService.closestPrices(requests,
+		currencySources[defaultCurrency])
+
+	prefs:= valuationPrefs{defaultCurrency, defaultLocale}
+	moved:= make([]AlertedCard, 0)
+	for i, aCard:= range priced{
+		before, ok:= befores[i]
+		if !ok || !alertMoved(a, before.Price, aCard.After) {
+			continue
+		}
+
+		aCard.Before = before.Price
+		aCard.FormattedBefore = formatCents(int64(aCard.Before), prefs)
+		aCard.FormattedAfter = formatCents(int64(aCard.After), prefs)
+		moved = append(moved, aCard)
+	}
+
+	return moved, nil
+
+}
+
+// Mails the owner of a fired alert the cards which moved, measuring
+// the alert's next moves from now.
+//
+// The alert is reset even when the mail fails, it is dead lettered
+// rather than lost and repeating it every run would only add more.
+func (aService *UserService) sendAlert(a userDB.Alert,
+	moved []AlertedCard) error {
+
+	u, err:= userDB.GetUser(aService.db(), a.Owner)
+	if err!=nil {
+		return err
+	}
+
+	if u.Email != "" {
+		contents:= alertEmailContents{
+			Name: a.Owner,
+			Collection: a.Collection,
+			Cards: moved,
+		}
+		err = aService.sendMail("priceAlert", contents,
+			mailer.FormatAddress(a.Owner, u.Email), "Price Alert - Preorda.in")
+	}
+
+	markErr:= userDB.MarkAlertFired(aService.db(), a.ID, time.Now())
+	if err!=nil {
+		return err
+	}
+
+	return markErr
+
+}
+
+// Prepares every alert to be evaluated by runAlertChecks.
+func (aService *UserService) alertChecks(alerts []userDB.Alert) []alertCheck {
+
+	checks:= make([]alertCheck, len(alerts))
+	for i, a:= range alerts{
+		a:= a
+		var moved []AlertedCard
+		checks[i] = alertCheck{
+			User: a.Owner,
+			Evaluate: func() (bool, error) {
+				var err error
+				moved, err = aService.alertMoves(a)
+				return len(moved) > 0, err
+			},
+			Notify: func() error {
+				return aService.sendAlert(a, moved)
+			},
+		}
+	}
+
+	return checks
+
+}
+
+// Evaluates every alert each interval until stop is closed.
+//
+// A run in progress is finished before stopping.
+func (aService *UserService) watchAlerts(interval time.Duration,
+	workers int, stop <-chan struct{}) {
+
+	ticker:= time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+
+		alerts, err:= userDB.GetAllAlerts(aService.db())
+		if err!=nil {
+			aService.logger.Println("failed to get alerts", err)
+			continue
+		}
+
+		aService.checkAlerts(aService.alertChecks(alerts), workers)
+	}
+
+}
+
+// Starts evaluating alerts in the background unless disabled,
+// Shutdown stops it.
+func (aService *UserService) setupAlerts() {
+
+	interval:= alertInterval()
+	if interval == 0 {
+		aService.logger.Println("alerts disabled")
+		return
+	}
+
+	workers, _:= strconv.Atoi(os.Getenv(alertWorkersEnv))
+
+	aService.stopAlerts = make(chan struct{})
+	aService.goBackground(func() {
+		aService.watchAlerts(interval, workers, aService.stopAlerts)
+	})
+
+}
+
+// Sets a price alert on one of the authenticated user's collections.
+func (aService *UserService) addAlert(req *restful.Request,
+	resp *restful.Response) {
+
+	userName:= req.PathParameter("userName")
+
+	var alertContainer AlertAddBody
+	err:= req.ReadEntity(&alertContainer)
+	if err!=nil {
+		resp.WriteErrorString(http.StatusBadRequest, BodyReadFailure)
+		return
+	}
+
+	if alertContainer.SessionKey == nil {
+		resp.WriteErrorString(http.StatusBadRequest, BadCredentials)
+		return
+	}
+
+	problem:= alertProblem(alertContainer.Percent, alertContainer.Threshold)
+	if problem != "" {
+		resp.WriteErrorString(http.StatusBadRequest, problem)
+		return
+	}
+
+	a, err:= userDB.AddAlert(aService.db(), alertContainer.SessionKey,
+		userName, alertContainer.Collection,
+		alertContainer.Percent, alertContainer.Threshold)
+	if err == userDB.ErrAlertLimit {
+		resp.WriteErrorString(http.StatusForbidden, AlertLimit)
+		return
+	}
+	if err!=nil {
+		status, message:= collectionFailure(err, false)
+		resp.WriteErrorString(status, message)
+		return
+	}
+
+	resp.WriteEntity(a)
+
+}
+
+// Lists every alert of the authenticated user, oldest first.
+func (aService *UserService) getAlerts(req *restful.Request,
+	resp *restful.Response) {
+
+	userName:= req.PathParameter("userName")
+
+	sessionKey:= getSessionKeyHeader(req)
+	if sessionKey == nil {
+		resp.WriteErrorString(http.StatusBadRequest, BadCredentials)
+		return
+	}
+
+	alerts, err:= userDB.GetAlerts(aService.db(), sessionKey, userName)
+	if err!=nil {
+		resp.WriteErrorString(dbFailure(err, BadCredentials))
+		return
+	}
+
+	setPrivateHeader(resp)
+	resp.WriteEntity(alerts)
+
+}
+
+// Deletes a single alert of the authenticated user.
+func (aService *UserService) deleteAlert(req *restful.Request,
+	resp *restful.Response) {
+
+	userName:= req.PathParameter("userName")
+	alertID:= req.PathParameter("alertID")
+
+	sessionKey:= getSessionKeyHeader(req)
+	if sessionKey == nil {
+		resp.WriteErrorString(http.StatusBadRequest, BadCredentials)
+		return
+	}
+
+	err:= userDB.DeleteAlert(aService.db(), sessionKey, userName, alertID)
+	if err == userDB.ErrNoSuchAlert {
+		resp.WriteErrorString(http.StatusNotFound, NoSuchAlert)
+		return
+	}
+	if err!=nil {
+		resp.WriteErrorString(dbFailure(err, BadCredentials))
+		return
+	}
+
+	resp.WriteEntity(true)
+
+}
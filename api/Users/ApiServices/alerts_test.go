@@ -0,0 +1,179 @@
+package ApiServices
+
+import(
+
+	"./userDBHandler"
+
+	"fmt"
+	"sync"
+	"bytes"
+	"strings"
+	"text/template"
+
+	"testing"
+
+	"time"
+
+)
+
+// Runs many slow alerts and ensures no more than the configured
+// number ever run at once while every alert still gets evaluated.
+func TestAlertConcurrencyBounded(t *testing.T) {
+
+	const count int = 50
+	const workers int = 3
+
+	var lock sync.Mutex
+	running:= 0
+	mostRunning:= 0
+
+	checks:= make([]alertCheck, count)
+	for i:= range checks{
+		fire:= i % 2 == 0
+		checks[i] = alertCheck{
+			User: fmt.Sprint(i),
+			Evaluate: func() (bool, error) {
+				lock.Lock()
+				running++
+				if running > mostRunning {
+					mostRunning = running
+				}
+				lock.Unlock()
+
+				time.Sleep(2 * time.Millisecond)
+
+				lock.Lock()
+				running--
+				lock.Unlock()
+
+				return fire, nil
+			},
+			Notify: func() error { return nil },
+		}
+	}
+
+	metrics:= runAlertChecks(checks, workers)
+
+	if mostRunning > workers {
+		t.Fatal("concurrency exceeded workers", mostRunning)
+	}
+	if metrics.Evaluated != count {
+		t.Fatal("not every alert was evaluated", metrics)
+	}
+	if metrics.Fired != count / 2 || metrics.MailsSent != count / 2 {
+		t.Fatal("incorrect fired or mailed counts", metrics)
+	}
+
+}
+
+// Ensures failing and panicking alerts are counted without
+// stopping the rest of the run.
+func TestAlertFailuresIsolated(t *testing.T) {
+
+	checks:= []alertCheck{
+		alertCheck{
+			Evaluate: func() (bool, error) {
+				return false, fmt.Errorf("foo")
+			},
+		},
+		alertCheck{
+			Evaluate: func() (bool, error) {
+				panic("bar")
+			},
+		},
+		alertCheck{
+			Evaluate: func() (bool, error) { return true, nil },
+			Notify: func() error { return fmt.Errorf("baz") },
+		},
+		alertCheck{
+			Evaluate: func() (bool, error) { return true, nil },
+			Notify: func() error { return nil },
+		},
+	}
+
+	metrics:= runAlertChecks(checks, 1)
+
+	if metrics.Evaluated != 4 || metrics.Failed != 3 ||
+		metrics.Fired != 2 || metrics.MailsSent != 1 {
+		t.Fatal("incorrect metrics for failing alerts", metrics)
+	}
+
+}
+
+func TestAlertWorkerCount(t *testing.T) {
+
+	if alertWorkerCount(0) != defaultAlertWorkers {
+		t.Fatal("unconfigured workers not defaulted")
+	}
+	if alertWorkerCount(maxAlertWorkers + 1) != maxAlertWorkers {
+		t.Fatal("workers not clamped to maximum")
+	}
+	if alertWorkerCount(2) != 2 {
+		t.Fatal("configured workers not respected")
+	}
+
+}
+
+func TestAlertProblem(t *testing.T) {
+
+	for _, ok:= range [][2]int32{{10, 0}, {0, 500}, {maxAlertPercent, 1}}{
+		if alertProblem(ok[0], ok[1]) != "" {
+			t.Fatal("sane alert refused", ok)
+		}
+	}
+
+	for _, bad:= range [][2]int32{{0, 0}, {-5, 0}, {0, -1},
+		{maxAlertPercent + 1, 0}}{
+		if alertProblem(bad[0], bad[1]) != BadAlert {
+			t.Fatal("insane alert accepted", bad)
+		}
+	}
+
+}
+
+// Moves must exceed the percent and thresholds fire crossing either way
+func TestAlertMoved(t *testing.T) {
+
+	byPercent:= userDB.Alert{Percent: 10}
+	if alertMoved(byPercent, 1000, 1100) || !alertMoved(byPercent, 1000, 1101) ||
+		!alertMoved(byPercent, 1000, 899) {
+		t.Fatal("percent moves misjudged")
+	}
+	if alertMoved(byPercent, 0, 500) {
+		t.Fatal("move from no price fired")
+	}
+
+	byThreshold:= userDB.Alert{Threshold: 1000}
+	if !alertMoved(byThreshold, 999, 1000) || !alertMoved(byThreshold, 1000, 999) ||
+		alertMoved(byThreshold, 1000, 5000) || alertMoved(byThreshold, 10, 999) {
+		t.Fatal("threshold crossings misjudged")
+	}
+
+}
+
+// The shipped alert template must render every moved card.
+func TestPriceAlertTemplate(t *testing.T) {
+
+	body, err:= template.ParseFiles("../templates/priceAlert.txt.template")
+	if err!=nil {
+		t.Fatal("failed to parse template", err)
+	}
+
+	var rendered bytes.Buffer
+	err = body.Execute(&rendered, alertEmailContents{
+		Name: "foo",
+		Collection: "Binder",
+		Cards: []AlertedCard{AlertedCard{Name: "Griselbrand",
+			Set: "Avacyn Restored", FormattedBefore: "$15.00",
+			FormattedAfter: "$30.00"}},
+	})
+	if err!=nil {
+		t.Fatal("failed to render template", err)
+	}
+
+	if !strings.Contains(rendered.String(),
+		"Griselbrand (Avacyn Restored) went from $15.00 to $30.00") {
+		t.Fatal("moved card missing from alert", rendered.String())
+	}
+
+}
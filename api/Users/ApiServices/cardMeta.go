@@ -6,43 +6,123 @@ import(
 
 	"strings"
 	"sort"
+	"sync"
 
 )
 
-// A global map used to ensure we only search for valid sets
-var sets = make(map[string]bool)
-
-// To ensure we only search for and allow into trades cards
-// that are actual magic cards. These can be cards that aren't in the list of
-// valid sets but we whitelist input to be within the domain of all cards
-// ever printed
-var cards = make(map[string]bool)
+// Every map built from the card data.
+//
+// A cardMaps is never modified once loaded, reloads build a fresh one
+// and swap it in whole. Holding onto one gives a consistent view of
+// the cards for as long as it is held.
+type cardMaps struct{
+	// Used to ensure we only search for valid sets
+	sets map[string]bool
+
+	// To ensure we only search for and allow into trades cards
+	// that are actual magic cards. These can be cards that aren't in the list of
+	// valid sets but we whitelist input to be within the domain of all cards
+	// ever printed
+	cards map[string]bool
+
+	cardsToSets map[string]map[string]bool
+
+	// Normalized card names mapped to their proper name so users
+	// can omit accents when adding trades
+	normalizedCards map[string]string
+
+	// This is specifically geared towards being capable of providing per-set
+	// data
+	setsToCardsAndRarity SetsToCards
+
+	// Upper case set codes mapped to the names of valid sets so imports
+	// may name a set as deck lists usually do
+	setCodes map[string]string
+}
 
-var cardsToSets = make(map[string]map[string]bool)
+func emptyCardMaps() *cardMaps {
+	return &cardMaps{
+		sets: make(map[string]bool),
+		cards: make(map[string]bool),
+		cardsToSets: make(map[string]map[string]bool),
+		normalizedCards: make(map[string]string),
+		setsToCardsAndRarity: make(SetsToCards),
+		setCodes: make(map[string]string),
+	}
+}
 
-// This is specifically geared towards being capable of providing per-set
-// data
-var setsToCardsAndRarity = make(SetsToCards)
+// The card maps currently served, only ever replaced under cardMapsLock
+var currentCards = emptyCardMaps()
+var cardMapsLock sync.RWMutex
 
-// Populates the setToCardMap and the cards map
+// Acquires the card maps currently served.
 //
-// Pass a influxdbClient
-func populateCardMaps() error {
-	
-	var setErr, cardErr, cardRarityErr error
-	sets, setErr = populateSets()
-	cards, cardsToSets, cardErr = populateCardsTranslationMap(sets)
-	setsToCardsAndRarity, cardRarityErr = populateCardsRarityMap(sets)
-	if cardErr!=nil {
-		return cardErr
+// Anything checking several cards together should acquire them once
+// so a reload part way through can't mix old and new cards.
+func loadedCards() *cardMaps {
+
+	cardMapsLock.RLock()
+	defer cardMapsLock.RUnlock()
+
+	return currentCards
+
+}
+
+// Serves maps from now on, returning those previously served.
+func swapCardMaps(maps *cardMaps) *cardMaps {
+
+	cardMapsLock.Lock()
+	defer cardMapsLock.Unlock()
+
+	previous:= currentCards
+	currentCards = maps
+
+	return previous
+
+}
+
+// Builds a fresh set of card maps from the set list and mtgjson data
+// on disk.
+func loadCardMaps() (*cardMaps, error) {
+
+	maps:= &cardMaps{}
+
+	var err error
+	maps.sets, err = populateSets()
+	if err!=nil {
+		return nil, err
+	}
+	maps.cards, maps.cardsToSets, err = populateCardsTranslationMap(maps.sets)
+	if err!=nil {
+		return nil, err
 	}
-	if setErr!=nil {
-		return setErr
+	maps.normalizedCards = populateNormalizedCards(maps.cards)
+	maps.setsToCardsAndRarity, err = populateCardsRarityMap(maps.sets)
+	if err!=nil {
+		return nil, err
 	}
-	if cardRarityErr!=nil {
-		return cardRarityErr
+	maps.setCodes, err = populateSetCodes(maps.sets)
+	if err!=nil {
+		return nil, err
+	}
+
+	return maps, nil
+
+}
+
+// Populates the card maps from disk, safe to call while serving.
+//
+// The maps are only swapped in once every one has loaded, on failure
+// those already served are kept.
+func populateCardMaps() error {
+
+	maps, err:= loadCardMaps()
+	if err!=nil {
+		return err
 	}
 
+	swapCardMaps(maps)
+
 	return nil
 
 }
@@ -113,6 +193,47 @@ func populateCardsTranslationMap(validSets map[string]bool) (map[string]bool,
 
 }
 
+func populateNormalizedCards(cards map[string]bool) map[string]string {
+
+	normalized:= make(map[string]string)
+	for aCardName:= range cards{
+		normalized[mtgjson.NormalizeCardName(aCardName)] = aCardName
+	}
+
+	return normalized
+
+}
+
+// Resolves a user provided card name to the proper name of the card.
+//
+// Exact matches are preferred, otherwise case and accents are ignored.
+func (maps *cardMaps) canonicalCardName(name string) (string, bool) {
+
+	if maps.cards[name] {
+		return name, true
+	}
+
+	proper, ok:= maps.normalizedCards[mtgjson.NormalizeCardName(name)]
+	return proper, ok
+
+}
+
+// Determines if a card by its proper name was printed in the set.
+func (maps *cardMaps) knownCard(name, set string) bool {
+
+	validSets, ok:= maps.cardsToSets[name]
+	return ok && validSets[set]
+
+}
+
+// Determines if a card was printed in the set by the maps currently
+// served.
+//
+// Handed to userDB so trades are checked however they arrive.
+func knownCard(name, set string) bool {
+	return loadedCards().knownCard(name, set)
+}
+
 type cardMap map[string]card
 
 type card struct{
@@ -147,6 +268,28 @@ func populateCardsRarityMap(validSets map[string]bool) (SetsToCards, error) {
 
 }
 
+func populateSetCodes(validSets map[string]bool) (map[string]string, error) {
+
+	codes:= make(map[string]string)
+
+	setMap, err:= mtgjson.AllSetsX()
+	if err!=nil {
+		return codes, err
+	}
+
+	for _, aSet:= range setMap{
+		_, ok:= validSets[aSet.Name]
+		if !ok || aSet.Code == "" {
+			continue
+		}
+
+		codes[strings.ToUpper(aSet.Code)] = aSet.Name
+	}
+
+	return codes, nil
+
+}
+
 type setMap map[string]set
 type set struct{
 	Name string
@@ -0,0 +1,88 @@
+package ApiServices
+
+import(
+
+	"testing"
+
+)
+
+func TestCanonicalCardName(t *testing.T) {
+
+	maps:= emptyCardMaps()
+	maps.cards = map[string]bool{
+		"Æther Vial": true,
+		"Séance": true,
+		"Jötun Grunt": true,
+		"Griselbrand": true,
+	}
+	maps.normalizedCards = populateNormalizedCards(maps.cards)
+
+	typed:= map[string]string{
+		"Æther Vial": "Æther Vial",
+		"AEther Vial": "Æther Vial",
+		"aether vial": "Æther Vial",
+		"Seance": "Séance",
+		"Jotun Grunt": "Jötun Grunt",
+		"griselbrand": "Griselbrand",
+	}
+
+	for name, expected:= range typed{
+		proper, ok:= maps.canonicalCardName(name)
+		if !ok || proper != expected {
+			t.Fatal("failed to resolve", name, proper)
+		}
+	}
+
+	_, ok:= maps.canonicalCardName("Grizelbrand")
+	if ok {
+		t.Fatal("resolved a card which does not exist")
+	}
+
+}
+
+// A reload that fails must leave the maps already served in place.
+func TestPopulateCardMapsFailureKeepsMaps(t *testing.T) {
+
+	trade, reset:= validationFixture(1)
+	defer reset()
+
+	served:= loadedCards()
+
+	// No set list sits beside the tests so loading must fail
+	err:= populateCardMaps()
+	if err == nil {
+		t.Fatal("loaded card maps without a set list")
+	}
+
+	if loadedCards() != served {
+		t.Fatal("failed reload replaced the served maps")
+	}
+
+	_, problem:= validateTrade(trade, 1)
+	if problem != "" {
+		t.Fatal("trade rejected after failed reload", problem)
+	}
+
+}
+
+// Maps acquired before a reload must keep validating against the
+// cards they held.
+func TestCardMapsSnapshot(t *testing.T) {
+
+	trade, reset:= validationFixture(1)
+	defer reset()
+
+	snapshot:= loadedCards()
+	swapCardMaps(emptyCardMaps())
+
+	_, problem:= validateTradeCard(snapshot, trade[0])
+	if problem != "" {
+		t.Fatal("snapshot changed by reload", problem)
+	}
+
+	_, problem = validateTrade(trade, 1)
+	if problem != BadTradeContents {
+		t.Fatal("reloaded maps not served", problem)
+	}
+
+}
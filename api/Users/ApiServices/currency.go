@@ -0,0 +1,374 @@
+package ApiServices
+
+import(
+
+	"./userDBHandler"
+	"./../../../common/priceDB"
+
+	"github.com/emicklei/go-restful"
+
+	"net/http"
+
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+)
+
+// Used whenever neither the request nor the user specifies otherwise
+const defaultCurrency string = "USD"
+const defaultLocale string = "en-US"
+
+// Where prices in each currency are recorded, only magiccardmarket
+// keeps prices in EUR.
+var currencySources = map[string]string{
+	"USD": defaultPriceSource,
+	"EUR": priceDB.Magiccardmarket,
+}
+
+// Buylist prices are only recorded in this currency
+const sellCurrency string = "USD"
+
+// Every currency values may be shown in, whether priced natively or
+// converted at a rate.
+var currencySymbols = map[string]string{
+	"USD": "$",
+	"EUR": "€",
+	"GBP": "£",
+	"JPY": "¥",
+	"CAD": "CA$",
+	"AUD": "A$",
+	"CHF": "CHF",
+	"SEK": "kr",
+}
+
+// How a locale separates and groups digits and where the
+// currency symbol goes.
+type localeFormat struct{
+	Decimal, Group string
+	SymbolAfter bool
+}
+
+var localeFormats = map[string]localeFormat{
+	"en-US": localeFormat{".", ",", false},
+	"en-GB": localeFormat{".", ",", false},
+	"de-DE": localeFormat{",", ".", true},
+	"fr-FR": localeFormat{",", " ", true},
+}
+
+// The currency values are given in and the locale they are shown for.
+type valuationPrefs struct{
+	Currency, Locale string
+}
+
+// Determines the preferences for a valuation.
+//
+// Overrides from the request win, followed by what the user has stored,
+// then the system defaults. ok is false only for an unsupported override,
+// stored preferences that are no longer supported are ignored.
+func resolvePrefs(currency, locale string,
+	u *userDB.User) (prefs valuationPrefs, ok bool) {
+
+	prefs = valuationPrefs{defaultCurrency, defaultLocale}
+	if u != nil {
+		if _, supported:= currencySources[u.Currency]; supported {
+			prefs.Currency = u.Currency
+		}
+		if _, supported:= localeFormats[u.Locale]; supported {
+			prefs.Locale = u.Locale
+		}
+	}
+
+	if currency != "" {
+		if _, supported:= currencySources[currency]; !supported {
+			return prefs, false
+		}
+		prefs.Currency = currency
+	}
+	if locale != "" {
+		if _, supported:= localeFormats[locale]; !supported {
+			return prefs, false
+		}
+		prefs.Locale = locale
+	}
+
+	return prefs, true
+
+}
+
+// Fills in a currency and basis the request left empty from the
+// defaults stored on a collection.
+//
+// Stored defaults which are no longer supported are ignored.
+func withCollectionDefaults(currency, basis string,
+	meta *userDB.Collection) (string, string) {
+
+	if meta == nil {
+		return currency, basis
+	}
+
+	if currency == "" {
+		if _, supported:= currencySources[meta.Currency]; supported {
+			currency = meta.Currency
+		}
+	}
+	if basis == "" {
+		if _, ok:= parseBasis(meta.Basis); ok {
+			basis = meta.Basis
+		}
+	}
+
+	return currency, basis
+
+}
+
+// Resolves the preferences for valuing a collection owned by userName
+// from a request.
+//
+// currency overrides the owner's preference when set, it should already
+// reflect any collection default.
+func (aService *UserService) requestPrefs(req *restful.Request,
+	userName, currency string) (valuationPrefs, bool) {
+
+	// An owner we can't find simply has no preferences
+	owner, err:= userDB.GetUser(aService.db(), userName)
+	if err!=nil {
+		owner = nil
+	}
+
+	return resolvePrefs(currency, req.QueryParameter("locale"), owner)
+
+}
+
+// Displayed values are rounded to a multiple of this many cents.
+//
+// Totals are always summed exactly in cents, only their display
+// is ever rounded.
+var displayRounding int64 = 1
+
+// Policies which may be set via valueRoundingEnv
+var roundingSteps = map[string]int64{
+	"cent": 1,
+	"dime": 10,
+	"whole": 100,
+}
+
+// Rounds cents to the nearest multiple of step, halves away from zero.
+func roundCents(cents, step int64) int64 {
+
+	if step <= 1 {
+		return cents
+	}
+
+	half:= step / 2
+	if cents < 0 {
+		return -((-cents + half) / step * step)
+	}
+
+	return (cents + half) / step * step
+
+}
+
+// Formats an amount of cents in the currency and locale preferred.
+//
+// The amount is rounded according to displayRounding.
+func formatCents(cents int64, prefs valuationPrefs) string {
+
+	cents = roundCents(cents, displayRounding)
+
+	format:= localeFormats[prefs.Locale]
+	symbol:= currencySymbols[prefs.Currency]
+
+	sign:= ""
+	if cents < 0 {
+		sign = "-"
+		cents = -cents
+	}
+
+	whole:= strconv.FormatInt(cents / 100, 10)
+	fraction:= cents % 100
+
+	// Group from the right in threes
+	var grouped []string
+	for len(whole) > 3 {
+		grouped = append([]string{whole[len(whole) - 3:]}, grouped...)
+		whole = whole[:len(whole) - 3]
+	}
+	grouped = append([]string{whole}, grouped...)
+
+	amount:= strings.Join(grouped, format.Group) + format.Decimal
+	if fraction < 10 {
+		amount+= "0"
+	}
+	amount+= strconv.FormatInt(fraction, 10)
+
+	if format.SymbolAfter {
+		return sign + amount + " " + symbol
+	}
+	return sign + symbol + amount
+
+}
+
+// Rates converting an amount in defaultCurrency, which prices are
+// natively recorded in, into currencies without prices of their own.
+//
+// Replaced at startup when currencyRatesEnv is set.
+var conversionRates = map[string]float64{
+	defaultCurrency: 1,
+}
+
+// Parses a rate table of the form 'GBP:0.79,JPY:150', each rate
+// being how much of a currency one unit of defaultCurrency buys.
+//
+// Only currencies with a symbol may have a rate. Those with prices of
+// their own in currencySources are always valued from them, so a rate
+// for one is refused rather than silently ignored.
+func parseRates(raw string) (map[string]float64, error) {
+
+	rates:= make(map[string]float64)
+	for _, entry:= range strings.Split(raw, ","){
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		parts:= strings.SplitN(entry, ":", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("malformed rate %s", entry)
+		}
+
+		code:= strings.ToUpper(strings.TrimSpace(parts[0]))
+		rate, err:= strconv.ParseFloat(strings.TrimSpace(parts[1]), 64)
+		if code == "" || err!=nil || rate <= 0 {
+			return nil, fmt.Errorf("malformed rate %s", entry)
+		}
+		if _, ok:= currencySymbols[code]; !ok {
+			return nil, fmt.Errorf("unsupported currency %s", code)
+		}
+		if _, native:= currencySources[code]; native && code != defaultCurrency {
+			return nil, fmt.Errorf("%s is priced natively, not by rate", code)
+		}
+
+		rates[code] = rate
+	}
+
+	// Native prices never need converting
+	rates[defaultCurrency] = 1
+
+	return rates, nil
+
+}
+
+// Converts cents of defaultCurrency into currency at the provided
+// rates, rounding to the nearest cent.
+//
+// ok is false when there is no rate for currency.
+func convertCents(cents int64, currency string,
+	rates map[string]float64) (converted int64, ok bool) {
+
+	rate, ok:= rates[currency]
+	if !ok {
+		return 0, false
+	}
+
+	return int64(math.Floor(float64(cents) * rate + 0.5)), true
+
+}
+
+// Rewrites prices so Price holds the amount in currency, dropping
+// any which were not recorded in it.
+func inCurrency(prices map[printing]priceDB.PrintingPrice,
+	currency string) map[printing]priceDB.PrintingPrice {
+
+	if currency != "EUR" {
+		return prices
+	}
+
+	converted:= make(map[printing]priceDB.PrintingPrice, len(prices))
+	for p, price:= range prices{
+		price.Nonfoil.Price = price.Nonfoil.Euro
+		price.HasNonfoil = price.HasNonfoil && price.Nonfoil.Euro > 0
+		price.Foil.Price = price.Foil.Euro
+		price.HasFoil = price.HasFoil && price.Foil.Euro > 0
+
+		if price.HasNonfoil || price.HasFoil {
+			converted[p] = price
+		}
+	}
+
+	return converted
+
+}
+
+// Acquires market prices for cards in currency.
+func (aService *UserService) marketPrices(cards []userDB.Card,
+	currency string) map[printing]priceDB.PrintingPrice {
+
+	prices:= aService.latestPrices(cards, currencySources[currency])
+
+	return inCurrency(prices, currency)
+
+}
+
+// Acquires sell prices for cards in currency.
+//
+// Buylist prices only exist in sellCurrency, others receive none and
+// so fall back to market prices.
+func (aService *UserService) sellPrices(cards []userDB.Card,
+	currency string) map[printing]priceDB.PrintingPrice {
+
+	if currency != sellCurrency {
+		return make(map[printing]priceDB.PrintingPrice)
+	}
+
+	return aService.latestPrices(cards, sellPriceSource)
+
+}
+
+// Stores the currency and locale an authenticated user prefers
+// their collections be valued in.
+func (aService *UserService) setPreferences(req *restful.Request,
+	resp *restful.Response) {
+
+	userName:= req.PathParameter("userName")
+
+	var prefsContainer PreferencesBody
+	err:= req.ReadEntity(&prefsContainer)
+	if err!=nil {
+		resp.WriteErrorString(http.StatusBadRequest, BodyReadFailure)
+		return
+	}
+
+	if prefsContainer.SessionKey == nil {
+		resp.WriteErrorString(http.StatusBadRequest, BadCredentials)
+		return
+	}
+
+	// Empty values clear a preference so they're always allowed
+	_, supported:= currencySources[prefsContainer.Currency]
+	if prefsContainer.Currency != "" && !supported {
+		resp.WriteErrorString(http.StatusBadRequest, BadPreferences)
+		return
+	}
+	_, supported = localeFormats[prefsContainer.Locale]
+	if prefsContainer.Locale != "" && !supported {
+		resp.WriteErrorString(http.StatusBadRequest, BadPreferences)
+		return
+	}
+
+	err = userDB.SetPreferences(aService.db(), prefsContainer.SessionKey,
+		userName, prefsContainer.Currency, prefsContainer.Locale)
+	if err == userDB.ErrBadSession {
+		resp.WriteErrorString(http.StatusUnauthorized, BadCredentials)
+		return
+	}
+	if err!=nil {
+		logAt(aService.logger, levelError, requestContext(req), err)
+		resp.WriteErrorString(dbFailure(err, DBWriteFailure))
+		return
+	}
+
+	resp.WriteEntity(true)
+
+}
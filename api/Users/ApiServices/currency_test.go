@@ -0,0 +1,198 @@
+package ApiServices
+
+import(
+
+	"./userDBHandler"
+	"./../../../common/priceDB"
+
+	"testing"
+
+)
+
+// Without an override the stored preference decides, falling back
+// to the defaults when nothing usable is stored.
+func TestResolvePrefs(t *testing.T) {
+
+	stored:= &userDB.User{Currency: "EUR", Locale: "de-DE"}
+
+	prefs, ok:= resolvePrefs("", "", stored)
+	if !ok || prefs.Currency != "EUR" || prefs.Locale != "de-DE" {
+		t.Fatal("stored preference not used", prefs)
+	}
+
+	prefs, ok = resolvePrefs("USD", "", stored)
+	if !ok || prefs.Currency != "USD" || prefs.Locale != "de-DE" {
+		t.Fatal("override not preferred over stored preference", prefs)
+	}
+
+	prefs, ok = resolvePrefs("", "", &userDB.User{Currency: "XYZ"})
+	if !ok || prefs.Currency != defaultCurrency ||
+		prefs.Locale != defaultLocale {
+		t.Fatal("unusable preference not defaulted", prefs)
+	}
+
+	prefs, ok = resolvePrefs("", "", nil)
+	if !ok || prefs.Currency != defaultCurrency {
+		t.Fatal("missing user not defaulted", prefs)
+	}
+
+	_, ok = resolvePrefs("XYZ", "", stored)
+	if ok {
+		t.Fatal("unsupported override accepted")
+	}
+
+}
+
+func TestFormatCents(t *testing.T) {
+
+	formatted:= map[valuationPrefs]string{
+		valuationPrefs{"USD", "en-US"}: "$1,234,567.05",
+		valuationPrefs{"EUR", "de-DE"}: "1.234.567,05 €",
+		valuationPrefs{"EUR", "fr-FR"}: "1 234 567,05 €",
+		valuationPrefs{"GBP", "en-GB"}: "£1,234,567.05",
+	}
+	for prefs, expected:= range formatted{
+		if actual:= formatCents(123456705, prefs); actual != expected {
+			t.Fatal("incorrectly formatted", prefs, actual)
+		}
+	}
+
+	us:= valuationPrefs{"USD", "en-US"}
+	if formatCents(5, us) != "$0.05" || formatCents(-1500, us) != "-$15.00" {
+		t.Fatal("small or negative amounts incorrectly formatted")
+	}
+
+}
+
+// EUR valuation should use euro prices, dropping those without one.
+func TestValueCardsInCurrency(t *testing.T) {
+
+	cards:= []userDB.Card{
+		userDB.Card{Name: "Griselbrand", Set: "Avacyn Restored", Quantity: 2},
+		userDB.Card{Name: "Unknown Card", Set: "Avacyn Restored", Quantity: 1},
+	}
+	prices:= map[printing]priceDB.PrintingPrice{
+		printing{"Griselbrand", "Avacyn Restored"}: priceDB.PrintingPrice{
+			Nonfoil: priceDB.Price{Price: 1500, Euro: 1300},
+			HasNonfoil: true,
+		},
+		printing{"Unknown Card", "Avacyn Restored"}: nonfoilPrice(100),
+	}
+
+	valued, unpriced:= valueCards(cards, inCurrency(prices, "EUR"))
+	if len(valued) != 1 || valued[0].Value != 2600 {
+		t.Fatal("not valued at euro prices", valued)
+	}
+	if len(unpriced) != 1 {
+		t.Fatal("card without a euro price not unpriced", unpriced)
+	}
+
+	valued, _ = valueCards(cards, inCurrency(prices, "USD"))
+	if len(valued) != 2 || valued[0].Value != 3000 {
+		t.Fatal("not valued at dollar prices", valued)
+	}
+
+}
+
+func TestRoundCents(t *testing.T) {
+
+	rounded:= map[int64]int64{
+		12345: 12300,
+		12350: 12400,
+		-12350: -12400,
+		-12349: -12300,
+		0: 0,
+	}
+	for cents, expected:= range rounded{
+		if actual:= roundCents(cents, 100); actual != expected {
+			t.Fatal("incorrectly rounded", cents, actual)
+		}
+	}
+
+	if roundCents(12345, 1) != 12345 || roundCents(12345, 10) != 12350 {
+		t.Fatal("incorrectly rounded to smaller steps")
+	}
+
+	previous:= displayRounding
+	defer func() { displayRounding = previous }()
+
+	displayRounding = roundingSteps["whole"]
+	us:= valuationPrefs{"USD", "en-US"}
+	if actual:= formatCents(123450, us); actual != "$1,235.00" {
+		t.Fatal("display rounding not applied", actual)
+	}
+
+}
+
+// Collection defaults sit between request overrides and the owner's
+// preferences.
+func TestCollectionDefaults(t *testing.T) {
+
+	owner:= &userDB.User{Currency: "USD"}
+	meta:= &userDB.Collection{Currency: "EUR", Basis: sellBasis}
+
+	currency, basis:= withCollectionDefaults("", "", meta)
+	prefs, ok:= resolvePrefs(currency, "", owner)
+	if !ok || prefs.Currency != "EUR" || basis != sellBasis {
+		t.Fatal("collection default not applied", prefs, basis)
+	}
+
+	currency, basis = withCollectionDefaults("USD", marketBasis, meta)
+	if currency != "USD" || basis != marketBasis {
+		t.Fatal("request override not preferred", currency, basis)
+	}
+
+	currency, basis = withCollectionDefaults("", "",
+		&userDB.Collection{Currency: "XYZ", Basis: "bogus"})
+	prefs, ok = resolvePrefs(currency, "", owner)
+	if !ok || prefs.Currency != "USD" || basis != "" {
+		t.Fatal("unsupported collection default applied", prefs, basis)
+	}
+
+}
+
+func TestParseRates(t *testing.T) {
+
+	rates, err:= parseRates(" jpy:150, GBP:0.25,,USD:3")
+	if err!=nil {
+		t.Fatal(err)
+	}
+	if rates["JPY"] != 150 || rates["GBP"] != 0.25 {
+		t.Fatal("rates not parsed", rates)
+	}
+	if rates[defaultCurrency] != 1 {
+		t.Fatal("native currency converts to something else", rates)
+	}
+
+	// EUR is priced natively and XYZ couldn't be displayed
+	for _, raw:= range []string{"GBP", "GBP:foo", "GBP:-1", ":0.5",
+		"EUR:0.9", "XYZ:2"}{
+		_, err = parseRates(raw)
+		if err == nil {
+			t.Fatal("malformed rates accepted", raw)
+		}
+	}
+
+}
+
+func TestConvertCents(t *testing.T) {
+
+	rates:= map[string]float64{defaultCurrency: 1, "GBP": 0.915}
+
+	converted, ok:= convertCents(1000, "GBP", rates)
+	if !ok || converted != 915 {
+		t.Fatal("incorrect conversion", converted)
+	}
+
+	// 0.915 * 15 = 13.725 rounds up
+	converted, _ = convertCents(15, "GBP", rates)
+	if converted != 14 {
+		t.Fatal("conversion not rounded to the nearest cent", converted)
+	}
+
+	_, ok = convertCents(1000, "JPY", rates)
+	if ok {
+		t.Fatal("converted without a rate")
+	}
+
+}
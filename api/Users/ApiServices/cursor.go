@@ -0,0 +1,70 @@
+package ApiServices
+
+import(
+
+	"crypto/hmac"
+	"crypto/sha256"
+
+	"encoding/base64"
+	"encoding/binary"
+
+	"fmt"
+
+)
+
+// Cursors carry the last event id seen alongside a truncated mac so
+// clients can't forge positions, they are otherwise opaque.
+const cursorIDLength int = 8
+const cursorMacLength int = 16
+
+var ErrBadCursor error = fmt.Errorf("cursor is malformed or tampered with")
+
+// Derives the key cursors are signed with from the admin key so every
+// node sharing configuration accepts the others' cursors.
+func deriveCursorKey(adminKey []byte) []byte {
+
+	mac:= hmac.New(sha256.New, adminKey)
+	mac.Write([]byte("cursor"))
+
+	return mac.Sum(nil)
+
+}
+
+func cursorMac(key, payload []byte) []byte {
+
+	mac:= hmac.New(sha256.New, key)
+	mac.Write(payload)
+
+	return mac.Sum(nil)[:cursorMacLength]
+
+}
+
+// Encodes the id of the last event a client has seen as a cursor.
+func encodeCursor(key []byte, id int64) string {
+
+	payload:= make([]byte, cursorIDLength)
+	binary.BigEndian.PutUint64(payload, uint64(id))
+
+	token:= append(payload, cursorMac(key, payload)...)
+
+	return base64.RawURLEncoding.EncodeToString(token)
+
+}
+
+// Recovers the event id from a cursor, rejecting any cursor we
+// did not produce with ErrBadCursor.
+func decodeCursor(key []byte, cursor string) (int64, error) {
+
+	token, err:= base64.RawURLEncoding.DecodeString(cursor)
+	if err!=nil || len(token) != cursorIDLength + cursorMacLength {
+		return 0, ErrBadCursor
+	}
+
+	payload:= token[:cursorIDLength]
+	if !hmac.Equal(token[cursorIDLength:], cursorMac(key, payload)) {
+		return 0, ErrBadCursor
+	}
+
+	return int64(binary.BigEndian.Uint64(payload)), nil
+
+}
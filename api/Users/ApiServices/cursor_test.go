@@ -0,0 +1,48 @@
+package ApiServices
+
+import(
+
+	"testing"
+
+)
+
+func TestCursorRoundTrip(t *testing.T) {
+
+	key:= deriveCursorKey([]byte("foo"))
+
+	for _, id:= range []int64{1, 42, 1 << 40} {
+		decoded, err:= decodeCursor(key, encodeCursor(key, id))
+		if err!=nil || decoded != id {
+			t.Fatal("cursor did not round trip", id, decoded, err)
+		}
+	}
+
+}
+
+func TestCursorTampered(t *testing.T) {
+
+	key:= deriveCursorKey([]byte("foo"))
+	cursor:= encodeCursor(key, 42)
+
+	// Flip a character within the id
+	tampered:= []byte(cursor)
+	if tampered[3] == 'A' {
+		tampered[3] = 'B'
+	}else{
+		tampered[3] = 'A'
+	}
+
+	candidates:= []string{
+		string(tampered),
+		cursor[:len(cursor) - 2],
+		"not a cursor",
+		encodeCursor(deriveCursorKey([]byte("bar")), 42),
+	}
+	for _, candidate:= range candidates{
+		_, err:= decodeCursor(key, candidate)
+		if err != ErrBadCursor {
+			t.Fatal("tampered cursor accepted", candidate)
+		}
+	}
+
+}
@@ -0,0 +1,310 @@
+package ApiServices
+
+import(
+
+	"./userDBHandler"
+	"./mailer"
+
+	"github.com/emicklei/go-restful"
+
+	"net/http"
+
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"time"
+
+)
+
+// How many times a mail is attempted before it is dead lettered.
+//
+// Only transient failures are retried, a rejected message is dead
+// lettered immediately.
+const maxMailAttempts int = 3
+
+const mailRetryDelay time.Duration = time.Second
+
+// Templates whose contents carry credentials and so are never kept
+// when dead lettered. Their recipients can always request a fresh code.
+var sensitiveTemplates = map[string]bool{
+	"reset": true,
+	"verifyEmail": true,
+	"confirmEmailChange": true,
+	"welcome": true,
+}
+
+type outboundMail struct{
+	Template string
+	Content interface{}
+	To, Subject string
+}
+
+// Attempts send until it succeeds, fails permanently or runs out of
+// attempts, backing off between transient failures.
+//
+// Returns how many attempts were made alongside the final error.
+func sendWithRetry(send func() error,
+	attempts int, delay time.Duration) (int, error) {
+
+	var err error
+	for attempt:= 0; attempt < attempts; attempt++ {
+
+		if attempt > 0 {
+			time.Sleep(delay)
+			delay*= 2
+		}
+
+		err = send()
+		if err == nil || !mailer.IsTransient(err) {
+			return attempt + 1, err
+		}
+
+	}
+
+	return attempts, err
+
+}
+
+// Builds the dead letter recording mail we gave up on.
+func deadLetterFor(mail outboundMail, attempts int,
+	err error) userDB.DeadLetter {
+
+	letter:= userDB.DeadLetter{
+		Recipient: mail.To,
+		Template: mail.Template,
+		Subject: mail.Subject,
+		LastError: err.Error(),
+		Attempts: int32(attempts),
+	}
+
+	if !sensitiveTemplates[mail.Template] {
+		content, marshalErr:= json.Marshal(mail.Content)
+		if marshalErr == nil {
+			letter.Content = string(content)
+		}
+	}
+
+	return letter
+
+}
+
+// Delivers mail via send, handing it to store as a dead letter when
+// every attempt fails.
+//
+// The send error is returned either way so callers can still report it.
+func deliverMail(send func(outboundMail) error,
+	store func(userDB.DeadLetter) error,
+	mail outboundMail, attempts int, delay time.Duration) error {
+
+	made, err:= sendWithRetry(func() error {
+		return send(mail)
+	}, attempts, delay)
+	if err == nil {
+		return nil
+	}
+
+	return giveUpMail(store, mail, made, err)
+
+}
+
+// Hands mail which failed with err after made attempts to store as
+// a dead letter.
+//
+// The send error is returned either way so callers can still report it.
+func giveUpMail(store func(userDB.DeadLetter) error,
+	mail outboundMail, made int, err error) error {
+
+	storeErr:= store(deadLetterFor(mail, made, err))
+	if storeErr!=nil {
+		return fmt.Errorf("%v, failed to dead letter, %v", err, storeErr)
+	}
+
+	return err
+
+}
+
+// Sends a prepared template once.
+func (aService *UserService) sendOutbound(mail outboundMail) error {
+
+	// A missing template would otherwise panic deep in the mailer
+	if !aService.mailer.HasTemplate(mail.Template) {
+		return fmt.Errorf("no such template %s", mail.Template)
+	}
+
+	_, err:= aService.mailer.SendPrepared(mail.Template, mail.Content,
+		mail.To, mail.Subject)
+
+	return err
+
+}
+
+func (aService *UserService) storeDeadLetter(letter userDB.DeadLetter) error {
+
+	id, err:= userDB.AddDeadLetter(aService.db(), letter)
+	if err!=nil {
+		return err
+	}
+
+	aService.logger.Println("dead lettered mail", id, "using template",
+		letter.Template)
+
+	return nil
+
+}
+
+// Sends a prepared template, retrying transient failures and dead
+// lettering the mail should it never get through.
+//
+// Only the first attempt is made by the caller, so rejections are
+// still reported. Retries back off on a background worker rather than
+// holding up the handler, a nil error then means mail is on its way.
+func (aService *UserService) sendMail(templateId string, content interface{},
+	to, subject string) error {
+
+	mail:= outboundMail{
+		Template: templateId,
+		Content: content,
+		To: to,
+		Subject: subject,
+	}
+
+	err:= aService.sendOutbound(mail)
+	if err == nil {
+		return nil
+	}
+
+	if mailer.IsTransient(err) && aService.queueBackground(func() {
+		aService.retryMail(mail)
+	}) {
+		return nil
+	}
+
+	aService.metrics.mailFailed()
+
+	return giveUpMail(aService.storeDeadLetter, mail, 1, err)
+
+}
+
+// Makes the remaining attempts at mail whose first attempt failed
+// transiently, dead lettering it should they fail too.
+func (aService *UserService) retryMail(mail outboundMail) {
+
+	time.Sleep(mailRetryDelay)
+
+	err:= deliverMail(aService.sendOutbound,
+		func(letter userDB.DeadLetter) error {
+			// Count the first attempt, made by sendMail
+			letter.Attempts++
+			return aService.storeDeadLetter(letter)
+		}, mail, maxMailAttempts - 1, 2 * mailRetryDelay)
+	if err == nil {
+		return
+	}
+
+	aService.metrics.mailFailed()
+	aService.logger.Println("failed to send mail using template",
+		mail.Template, err)
+
+}
+
+// Lists dead lettered mail, oldest first.
+func (aService *UserService) adminDeadLetters(req *restful.Request,
+	resp *restful.Response) {
+
+	var adminContainer DeadLetterBody
+	err:= req.ReadEntity(&adminContainer)
+	if err!=nil {
+		resp.WriteErrorString(http.StatusBadRequest, BodyReadFailure)
+		return
+	}
+
+	if !aService.adminAuth(adminContainer.AdminKey) {
+		resp.WriteErrorString(http.StatusUnauthorized, BadCredentials)
+		return
+	}
+
+	letters, err:= userDB.GetDeadLetters(aService.db())
+	if err!=nil {
+		logAt(aService.logger, levelError, requestContext(req), err)
+		resp.WriteErrorString(http.StatusInternalServerError, DBfailure)
+		return
+	}
+
+	setPrivateHeader(resp)
+
+	resp.WriteEntity(letters)
+
+}
+
+// Sends a dead letter once more, forgetting it when it gets through.
+func (aService *UserService) adminRetryDeadLetter(req *restful.Request,
+	resp *restful.Response) {
+
+	id, err:= strconv.ParseInt(req.PathParameter("letterID"), 10, 64)
+	if err!=nil {
+		resp.WriteErrorString(http.StatusBadRequest, NoSuchDeadLetter)
+		return
+	}
+
+	var adminContainer DeadLetterBody
+	err = req.ReadEntity(&adminContainer)
+	if err!=nil {
+		resp.WriteErrorString(http.StatusBadRequest, BodyReadFailure)
+		return
+	}
+
+	if !aService.adminAuth(adminContainer.AdminKey) {
+		resp.WriteErrorString(http.StatusUnauthorized, BadCredentials)
+		return
+	}
+
+	letter, err:= userDB.GetDeadLetter(aService.db(), id)
+	if err == userDB.ErrNoSuchDeadLetter {
+		resp.WriteErrorString(http.StatusNotFound, NoSuchDeadLetter)
+		return
+	}
+	if err!=nil {
+		logAt(aService.logger, levelError, requestContext(req), err)
+		resp.WriteErrorString(http.StatusInternalServerError, DBfailure)
+		return
+	}
+
+	if letter.Content == "" {
+		resp.WriteErrorString(http.StatusConflict, DeadLetterNotKept)
+		return
+	}
+
+	// Templates only ever need to look up fields by name
+	var content map[string]interface{}
+	err = json.Unmarshal([]byte(letter.Content), &content)
+	if err!=nil {
+		resp.WriteErrorString(http.StatusConflict, DeadLetterNotKept)
+		return
+	}
+
+	err = aService.sendOutbound(outboundMail{
+		Template: letter.Template,
+		Content: content,
+		To: letter.Recipient,
+		Subject: letter.Subject,
+	})
+	if err!=nil {
+		recordErr:= userDB.RecordDeadLetterFailure(aService.db(),
+			id, err.Error())
+		if recordErr!=nil {
+			logAt(aService.logger, levelError, requestContext(req), recordErr)
+		}
+		resp.WriteErrorString(http.StatusBadGateway, DeadLetterFailed)
+		return
+	}
+
+	err = userDB.RemoveDeadLetter(aService.db(), id)
+	if err!=nil {
+		// Sent regardless, the worst case is a duplicate later
+		logAt(aService.logger, levelError, requestContext(req), err)
+	}
+
+	resp.WriteEntity(true)
+
+}
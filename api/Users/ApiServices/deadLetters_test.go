@@ -0,0 +1,141 @@
+package ApiServices
+
+import(
+
+	"./userDBHandler"
+	"./mailer"
+
+	"fmt"
+
+	"testing"
+
+)
+
+type deadLetterFixture struct{
+	sends int
+	letters []userDB.DeadLetter
+}
+
+func (f *deadLetterFixture) store(letter userDB.DeadLetter) error {
+	f.letters = append(f.letters, letter)
+	return nil
+}
+
+// A message mailgun rejects should be dead lettered on the first
+// attempt with everything needed to send it again.
+func TestDeadLetterPermanentFailure(t *testing.T) {
+
+	mail:= outboundMail{
+		Template: "subSuccess",
+		Content: subEmailContents{Name: "foo", Plan: "Preordain"},
+		To: mailer.FormatAddress("foo", "foo@bar.baz"),
+		Subject: "bar",
+	}
+
+	var fixture deadLetterFixture
+	send:= func(outboundMail) error {
+		fixture.sends++
+		return fmt.Errorf("rejected")
+	}
+
+	err:= deliverMail(send, fixture.store, mail, 3, 0)
+	if err == nil {
+		t.Fatal("failed send reported as success")
+	}
+
+	if fixture.sends != 1 {
+		t.Fatal("permanent failure retried", fixture.sends)
+	}
+	if len(fixture.letters) != 1 {
+		t.Fatal("failed send not dead lettered", fixture.letters)
+	}
+
+	letter:= fixture.letters[0]
+	if letter.Template != "subSuccess" || letter.Subject != "bar" ||
+		letter.Recipient != "foo<foo@bar.baz>" ||
+		letter.LastError != "rejected" || letter.Attempts != 1 {
+		t.Fatal("dead letter missing details", letter)
+	}
+	if letter.Content != `{"Name":"foo","Plan":"Preordain"}` {
+		t.Fatal("dead letter contents not kept", letter.Content)
+	}
+
+}
+
+func TestDeadLetterTransientRetried(t *testing.T) {
+
+	mail:= outboundMail{
+		Template: "subSuccess",
+		Content: subEmailContents{Name: "foo", Plan: "Preordain"},
+		To: mailer.FormatAddress("foo", "foo@bar.baz"),
+		Subject: "bar",
+	}
+
+	var fixture deadLetterFixture
+	send:= func(outboundMail) error {
+		fixture.sends++
+		return mailer.TransientError{Err: fmt.Errorf("timeout")}
+	}
+
+	deliverMail(send, fixture.store, mail, 3, 0)
+
+	if fixture.sends != 3 {
+		t.Fatal("transient failure not retried", fixture.sends)
+	}
+	if len(fixture.letters) != 1 || fixture.letters[0].Attempts != 3 {
+		t.Fatal("exhausted retries not dead lettered", fixture.letters)
+	}
+
+}
+
+func TestDeadLetterRecovered(t *testing.T) {
+
+	mail:= outboundMail{
+		Template: "subSuccess",
+		Content: subEmailContents{Name: "foo", Plan: "Preordain"},
+		To: mailer.FormatAddress("foo", "foo@bar.baz"),
+		Subject: "bar",
+	}
+
+	var fixture deadLetterFixture
+	send:= func(outboundMail) error {
+		fixture.sends++
+		if fixture.sends == 1 {
+			return mailer.TransientError{Err: fmt.Errorf("timeout")}
+		}
+		return nil
+	}
+
+	err:= deliverMail(send, fixture.store, mail, 3, 0)
+	if err!=nil || len(fixture.letters) != 0 {
+		t.Fatal("recovered send dead lettered", err, fixture.letters)
+	}
+
+}
+
+// Reset and verification codes must never be written out alongside
+// the failure.
+func TestDeadLetterSensitiveContents(t *testing.T) {
+
+	send:= func(outboundMail) error {
+		return fmt.Errorf("rejected")
+	}
+
+	for _, template:= range []string{"reset", "verifyEmail",
+		"confirmEmailChange"}{
+		mail:= outboundMail{
+			Template: template,
+			Content: subEmailContents{Name: "foo", Plan: "Preordain"},
+			To: mailer.FormatAddress("foo", "foo@bar.baz"),
+			Subject: "bar",
+		}
+
+		var fixture deadLetterFixture
+		deliverMail(send, fixture.store, mail, 3, 0)
+
+		if len(fixture.letters) != 1 || fixture.letters[0].Content != "" {
+			t.Fatal("sensitive contents kept", template, fixture.letters)
+		}
+	}
+
+}
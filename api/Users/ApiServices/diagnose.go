@@ -0,0 +1,127 @@
+package ApiServices
+
+import(
+
+	"./userDBHandler"
+
+	"github.com/emicklei/go-restful"
+
+	"net/http"
+
+	"strings"
+	"time"
+
+)
+
+// Administrative GETs carry the admin key in this header as they
+// have no body to carry it in.
+const adminKeyHeader string = "X-Admin-Key"
+
+// How many of a user's latest events a diagnosis includes
+const diagnosisEvents int = 20
+
+// How many trailing characters of an identifier survive masking
+const maskedTail int = 4
+
+// Everything support needs to look into a user's issue in one place.
+//
+// Password hashes, nonces and session keys are never included and
+// billing identifiers are masked.
+type UserDiagnosis struct{
+	Name, Email string
+	Verified bool
+
+	Plan string
+	PlanStart time.Time
+	CustomerID, SubID string
+
+	MaxCollections int32
+	ForceRehashOnNextLogin bool
+
+	userDB.Footprint
+
+	RecentEvents []userDB.Event
+}
+
+// Hides all but the tail of an identifier so support can match
+// it against what a user reads them without learning it.
+func maskIdentifier(id string) string {
+
+	if len(id) <= maskedTail {
+		return strings.Repeat("*", len(id))
+	}
+
+	return strings.Repeat("*", len(id) - maskedTail) + id[len(id) - maskedTail:]
+
+}
+
+// Assembles a diagnosis, leaving out everything sensitive.
+//
+// sub may be nil for users without a subscription.
+func diagnose(u *userDB.User, sub *userDB.Subscription,
+	f *userDB.Footprint, events []userDB.Event) UserDiagnosis {
+
+	d:= UserDiagnosis{
+		Name: u.Name,
+		Email: u.Email,
+		Verified: u.Verified,
+		MaxCollections: u.MaxCollections,
+		ForceRehashOnNextLogin: u.ForceRehashOnNextLogin,
+		Footprint: *f,
+		RecentEvents: events,
+	}
+
+	if sub != nil {
+		d.Plan = sub.Plan
+		d.PlanStart = sub.StartTime
+		d.CustomerID = maskIdentifier(sub.CustomerID)
+		d.SubID = maskIdentifier(sub.SubID)
+	}
+
+	return d
+
+}
+
+// Acquires a consolidated view of a user for support.
+func (aService *UserService) adminDiagnose(req *restful.Request,
+	resp *restful.Response) {
+
+	if !aService.adminAuth(req.HeaderParameter(adminKeyHeader)) {
+		resp.WriteErrorString(http.StatusUnauthorized, BadCredentials)
+		return
+	}
+
+	userName:= req.PathParameter("userName")
+
+	u, err:= userDB.GetUser(aService.db(), userName)
+	if err!=nil {
+		resp.WriteErrorString(dbFailure(err, BadUserName))
+		return
+	}
+
+	f, err:= userDB.GetFootprint(aService.db(), userName)
+	if err!=nil {
+		logAt(aService.logger, levelError, requestContext(req), err)
+		resp.WriteErrorString(http.StatusInternalServerError, DBfailure)
+		return
+	}
+
+	events, err:= userDB.GetRecentEvents(aService.db(),
+		userName, diagnosisEvents)
+	if err!=nil {
+		logAt(aService.logger, levelError, requestContext(req), err)
+		resp.WriteErrorString(http.StatusInternalServerError, DBfailure)
+		return
+	}
+
+	// Users who never subscribed simply have no plan
+	sub, err:= userDB.GetSub(aService.db(), userName, nil)
+	if err!=nil {
+		sub = nil
+	}
+
+	setPrivateHeader(resp)
+
+	resp.WriteEntity(diagnose(u, sub, f, events))
+
+}
@@ -0,0 +1,93 @@
+package ApiServices
+
+import(
+
+	"./userDBHandler"
+
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+
+	"testing"
+	"time"
+
+)
+
+func TestDiagnose(t *testing.T) {
+
+	u:= &userDB.User{
+		Name: "foo",
+		Email: "foo@example.com",
+		PassHash: []byte("supersecrethash"),
+		Nonce: []byte("supersecretnonce"),
+		MaxCollections: 5,
+		VerifyToken: []byte("supersecrettoken"),
+	}
+	sub:= &userDB.Subscription{
+		Name: "foo",
+		Plan: "premium",
+		CustomerID: "cus_secretcustomer1234",
+		SubID: "sub_secretsubscription5678",
+		StartTime: time.Now(),
+	}
+	f:= &userDB.Footprint{
+		Sessions: 2,
+		Collections: 3,
+		ContentRows: 40,
+		HistoryRows: 50,
+		LastActivity: time.Now(),
+	}
+	events:= []userDB.Event{
+		userDB.Event{ID: 1, Kind: userDB.EventCollectionCreated,
+			Collection: "bar"},
+	}
+
+	raw, err:= json.Marshal(diagnose(u, sub, f, events))
+	if err!=nil {
+		t.Fatal(err)
+	}
+
+	var dump map[string]interface{}
+	err = json.Unmarshal(raw, &dump)
+	if err!=nil {
+		t.Fatal(err)
+	}
+
+	expected:= []string{"Name", "Email", "Verified", "Plan", "PlanStart",
+		"CustomerID", "SubID", "MaxCollections", "Sessions",
+		"Collections", "ContentRows", "HistoryRows", "LastActivity",
+		"RecentEvents"}
+	for _, field:= range expected{
+		if _, ok:= dump[field]; !ok {
+			t.Fatal("diagnosis missing field", field, string(raw))
+		}
+	}
+
+	if dump["Plan"] != "premium" || dump["Sessions"] != float64(2) {
+		t.Fatal("diagnosis has incorrect values", string(raw))
+	}
+
+	secrets:= []string{"supersecret", "secretcustomer", "secretsubscription",
+		base64.StdEncoding.EncodeToString(u.PassHash),
+		base64.StdEncoding.EncodeToString(u.Nonce),
+		base64.StdEncoding.EncodeToString(u.VerifyToken)}
+	for _, secret:= range secrets{
+		if strings.Contains(string(raw), secret) {
+			t.Fatal("diagnosis leaks a secret", secret, string(raw))
+		}
+	}
+
+	if dump["CustomerID"] != "******************1234" {
+		t.Fatal("billing identifier not masked", dump["CustomerID"])
+	}
+
+}
+
+func TestDiagnoseUnsubscribed(t *testing.T) {
+
+	d:= diagnose(&userDB.User{Name: "foo"}, nil, &userDB.Footprint{}, nil)
+	if d.Plan != "" || d.CustomerID != "" {
+		t.Fatal("unsubscribed user has a plan", d)
+	}
+
+}
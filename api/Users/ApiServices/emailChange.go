@@ -0,0 +1,142 @@
+package ApiServices
+
+import(
+
+	"./userDBHandler"
+	"./mailer"
+
+	"github.com/emicklei/go-restful"
+
+	"net/http"
+
+)
+
+// The contents of an email change confirmation formatted to match
+// the template, this is sent to the new address.
+type confirmEmailChangeContents struct{
+	Name, Email, ChangeCode string
+}
+
+// The contents of the notice sent to the previous address once a
+// change away from it is confirmed.
+type emailChangedContents struct{
+	Name, NewEmail string
+}
+
+// Starts changing the email of an authenticated user, the new
+// address is mailed a code to confirm the change with.
+func (aService *UserService) requestEmailChange(req *restful.Request,
+	resp *restful.Response) {
+
+	userName:= req.PathParameter("userName")
+
+	var changeContainer EmailChangeBody
+	err:= req.ReadEntity(&changeContainer)
+	if err!=nil {
+		resp.WriteErrorString(http.StatusBadRequest, BodyReadFailure)
+		return
+	}
+	if changeContainer.SessionKey == nil {
+		resp.WriteErrorString(http.StatusBadRequest, BadCredentials)
+		return
+	}
+
+	email, err:= userDB.NormalizeEmail(changeContainer.Email)
+	if err!=nil {
+		resp.WriteErrorString(http.StatusBadRequest, emailFailure(err))
+		return
+	}
+
+	code, err:= userDB.RequestEmailChange(aService.db(),
+		changeContainer.SessionKey, userName, email)
+	if err == userDB.ErrBadSession {
+		resp.WriteErrorString(http.StatusUnauthorized, BadCredentials)
+		return
+	}
+	if err == userDB.ErrBadEncoding {
+		resp.WriteErrorString(http.StatusBadRequest, BadEncoding)
+		return
+	}
+	if err == userDB.ErrEmailUsed {
+		resp.WriteErrorString(http.StatusConflict, EmailUsed)
+		return
+	}
+	if err!=nil {
+		logAt(aService.logger, levelError, requestContext(req), err)
+		resp.WriteErrorString(dbFailure(err, DBWriteFailure))
+		return
+	}
+
+	contents:= confirmEmailChangeContents{
+		Name: userName,
+		Email: email,
+		ChangeCode: code,
+	}
+
+	// The change can be requested again if this never arrives
+	err = aService.sendMail("confirmEmailChange", contents,
+		mailer.FormatAddress(userName, email),
+		"Confirm your new email - Preorda.in")
+	if err!=nil {
+		logAt(aService.logger, levelError, requestContext(req),
+			"failed to send email change", err)
+	}
+
+	resp.WriteEntity(true)
+
+}
+
+// Completes an email change given the code mailed to the new address.
+//
+// The previous address is told of the change so an owner who never
+// asked for it knows their account has been taken over.
+func (aService *UserService) confirmEmailChange(req *restful.Request,
+	resp *restful.Response) {
+
+	userName:= req.PathParameter("userName")
+
+	var confirmContainer EmailChangeConfirmBody
+	err:= req.ReadEntity(&confirmContainer)
+	if err!=nil {
+		resp.WriteErrorString(http.StatusBadRequest, BodyReadFailure)
+		return
+	}
+
+	previous, err:= userDB.ConfirmEmailChange(aService.db(), userName,
+		confirmContainer.ChangeCode)
+	if err == userDB.ErrBadEmailChangeToken {
+		resp.WriteErrorString(http.StatusBadRequest, BadEmailChangeCode)
+		return
+	}
+	if err == userDB.ErrEmailUsed {
+		resp.WriteErrorString(http.StatusConflict, EmailUsed)
+		return
+	}
+	if err!=nil {
+		logAt(aService.logger, levelError, requestContext(req), err)
+		resp.WriteErrorString(http.StatusInternalServerError, DBWriteFailure)
+		return
+	}
+
+	u, err:= userDB.GetUser(aService.db(), userName)
+	if err!=nil {
+		logAt(aService.logger, levelError, requestContext(req), err)
+		resp.WriteEntity(true)
+		return
+	}
+
+	contents:= emailChangedContents{
+		Name: userName,
+		NewEmail: u.Email,
+	}
+	err = aService.sendMail("emailChanged", contents,
+		mailer.FormatAddress(userName, previous),
+		"Your email has been changed - Preorda.in")
+	if err!=nil {
+		logAt(aService.logger, levelError, requestContext(req),
+			"failed to send email change notice", err)
+	}
+
+	resp.WriteEntity(true)
+
+}
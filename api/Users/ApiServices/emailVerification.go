@@ -0,0 +1,127 @@
+package ApiServices
+
+import(
+
+	"./userDBHandler"
+	"./mailer"
+
+	"github.com/emicklei/go-restful"
+
+	"net/http"
+
+)
+
+// Set on successful logins by users yet to verify their email, they
+// may still use the service but some actions are refused.
+const emailUnverifiedHeader string = "X-Email-Unverified"
+
+// The contents of a verification email formatted to match the template.
+type verifyEmailContents struct{
+	Name, VerifyCode string
+}
+
+// Issues a fresh verification code and mails it to the user.
+func (aService *UserService) sendVerification(userName,
+	email string) error {
+
+	code, err:= userDB.IssueVerification(aService.db(), userName)
+	if err!=nil {
+		return err
+	}
+
+	contents:= verifyEmailContents{
+		Name: userName,
+		VerifyCode: code,
+	}
+
+	return aService.sendMail("verifyEmail", contents,
+		mailer.FormatAddress(userName, email),
+		"Verify your email - Preorda.in")
+
+}
+
+// Flags a login response when the user has yet to verify their email.
+func (aService *UserService) flagUnverified(req *restful.Request,
+	resp *restful.Response, userName string) {
+
+	u, err:= userDB.GetUser(aService.db(), userName)
+	if err!=nil {
+		logAt(aService.logger, levelError, requestContext(req), err)
+		return
+	}
+
+	if !u.Verified {
+		resp.AddHeader(emailUnverifiedHeader, "true")
+	}
+
+}
+
+// Marks the user's email as verified given the code they were mailed.
+func (aService *UserService) verifyEmail(req *restful.Request,
+	resp *restful.Response) {
+
+	userName:= req.PathParameter("userName")
+
+	var verifyContainer VerifyEmailBody
+	err:= req.ReadEntity(&verifyContainer)
+	if err!=nil {
+		resp.WriteErrorString(http.StatusBadRequest, BodyReadFailure)
+		return
+	}
+
+	err = userDB.VerifyEmail(aService.db(), userName,
+		verifyContainer.VerifyCode)
+	if err == userDB.ErrBadVerifyToken {
+		resp.WriteErrorString(http.StatusBadRequest, BadVerifyCode)
+		return
+	}
+	if err!=nil {
+		logAt(aService.logger, levelError, requestContext(req), err)
+		resp.WriteErrorString(http.StatusInternalServerError, DBWriteFailure)
+		return
+	}
+
+	resp.WriteEntity(true)
+
+}
+
+// Mails an authenticated user a fresh verification code, the
+// previous one stops working.
+func (aService *UserService) resendVerification(req *restful.Request,
+	resp *restful.Response) {
+
+	userName, sessionKey, err:= getUserNameAndSessionKey(req)
+	if err!=nil {
+		resp.WriteErrorString(http.StatusBadRequest, BodyReadFailure)
+		return
+	}
+	if sessionKey == nil {
+		resp.WriteErrorString(http.StatusBadRequest, BadCredentials)
+		return
+	}
+
+	err = userDB.SessionAuth(aService.db(), userName, sessionKey)
+	if err!=nil {
+		resp.WriteErrorString(dbFailure(err, BadCredentials))
+		return
+	}
+
+	u, err:= userDB.GetUser(aService.db(), userName)
+	if err!=nil {
+		resp.WriteErrorString(dbFailure(err, BadUserName))
+		return
+	}
+
+	err = aService.sendVerification(userName, u.Email)
+	if err == userDB.ErrBadVerifyToken {
+		resp.WriteErrorString(http.StatusBadRequest, BadVerifyCode)
+		return
+	}
+	if err!=nil {
+		logAt(aService.logger, levelError, requestContext(req),
+			"failed to send verification", err)
+	}
+
+	resp.WriteEntity(true)
+
+}
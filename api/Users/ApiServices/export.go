@@ -0,0 +1,173 @@
+package ApiServices
+
+import(
+
+	"./userDBHandler"
+	"./../../../common/priceDB"
+
+	"github.com/emicklei/go-restful"
+
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"mime"
+	"strconv"
+
+	"net/http"
+
+)
+
+// The formats a collection may be exported in
+const (
+	exportCSV string = "csv"
+	exportJSON string = "json"
+)
+
+// The columns of a csv export, in order
+var exportColumns = []string{"name", "set", "quantity", "quality",
+	"lang", "comment", "price"}
+
+// A single card held by an exported collection.
+type ExportedCard struct{
+	Name, Set string
+	Quantity int32
+	Quality, Lang, Comment string
+	// The market price of a single copy in cents of the export's
+	// currency, zero with Priced unset when there is none
+	Price int32
+	Priced bool
+}
+
+// A collection as exported in json.
+type CollectionExport struct{
+	Collection string
+	Currency string
+	Cards []ExportedCard
+}
+
+// Pairs each held card with its market price.
+func exportedCards(current []userDB.Card,
+	prices map[printing]priceDB.PrintingPrice) []ExportedCard {
+
+	cards:= make([]ExportedCard, 0, len(current))
+	for _, aCard:= range current{
+		price, ok:= priceFor(aCard, prices)
+		cards = append(cards, ExportedCard{
+			Name: aCard.Name,
+			Set: aCard.Set,
+			Quantity: aCard.Quantity,
+			Quality: aCard.Quality,
+			Lang: aCard.Lang,
+			Comment: aCard.Comment,
+			Price: price,
+			Priced: ok,
+		})
+	}
+
+	return cards
+
+}
+
+// Serializes an export in format, returning it with its content type.
+//
+// Unpriced cards leave the csv price column empty rather than
+// pretending they are worth nothing.
+func encodeExport(export CollectionExport,
+	format string) ([]byte, string, error) {
+
+	switch format{
+	case exportJSON:
+		data, err:= json.Marshal(export)
+		return data, restful.MIME_JSON, err
+	case exportCSV:
+	default:
+		return nil, "", fmt.Errorf("unknown export format %q", format)
+	}
+
+	var buf bytes.Buffer
+	w:= csv.NewWriter(&buf)
+	w.Write(exportColumns)
+	for _, aCard:= range export.Cards{
+		price:= ""
+		if aCard.Priced {
+			price = strconv.Itoa(int(aCard.Price))
+		}
+
+		w.Write([]string{aCard.Name, aCard.Set,
+			strconv.Itoa(int(aCard.Quantity)),
+			aCard.Quality, aCard.Lang, aCard.Comment, price})
+	}
+	w.Flush()
+
+	return buf.Bytes(), "text/csv; charset=utf-8", w.Error()
+
+}
+
+// Exports the current contents of a collection as a download.
+//
+// Without a session key only collections the public may view are
+// exported and comments only when they are public too, as with
+// getCollectionPublic. Prices are market prices in defaultCurrency.
+func (aService *UserService) exportCollection(req *restful.Request,
+	resp *restful.Response) {
+
+	userName:= req.PathParameter("userName")
+	collectionName:= req.PathParameter("collectionName")
+
+	format:= req.QueryParameter("format")
+	if format == "" {
+		format = exportCSV
+	}
+	if format != exportCSV && format != exportJSON {
+		resp.WriteErrorString(http.StatusBadRequest, BadExportFormat)
+		return
+	}
+
+	sessionKey:= getSessionKeyHeader(req)
+	public:= sessionKey == nil
+
+	// Missing and private collections must look identical to the public
+	meta, err:= userDB.GetCollectionMeta(aService.db(),
+		sessionKey, userName, collectionName)
+	if err!=nil {
+		status, message:= collectionFailure(err, public)
+		resp.WriteErrorString(status, message)
+		return
+	}
+	if public && meta.Privacy == "Private" {
+		status, message:= collectionFailure(userDB.ErrBadSession, true)
+		resp.WriteErrorString(status, message)
+		return
+	}
+
+	current, err:= userDB.GetCollectionContents(aService.db(),
+		nil, userName, collectionName)
+	if err!=nil {
+		resp.WriteErrorString(dbFailure(err, NoSuchCollection))
+		return
+	}
+	if public && !meta.PublicComments {
+		current = withoutComments(current)
+	}
+
+	export:= CollectionExport{
+		Collection: meta.Name,
+		Currency: defaultCurrency,
+		Cards: exportedCards(current,
+			aService.marketPrices(current, defaultCurrency)),
+	}
+	data, contentType, err:= encodeExport(export, format)
+	if err!=nil {
+		logAt(aService.logger, levelError, requestContext(req),
+			"failed to encode export,", err)
+		resp.WriteErrorString(http.StatusInternalServerError, ExportFailure)
+		return
+	}
+
+	resp.AddHeader("Content-Type", contentType)
+	resp.AddHeader("Content-Disposition", mime.FormatMediaType("attachment",
+		map[string]string{"filename": meta.Name + "." + format}))
+	resp.Write(data)
+
+}
@@ -0,0 +1,83 @@
+package ApiServices
+
+import(
+
+	"./userDBHandler"
+	"./../../../common/priceDB"
+
+	"encoding/json"
+	"strings"
+
+	"testing"
+
+)
+
+// Unpriced cards must leave the price empty and fields needing
+// quotes must survive a round trip.
+func TestEncodeExportCSV(t *testing.T) {
+
+	current:= []userDB.Card{
+		userDB.Card{Name: "Griselbrand", Set: "Avacyn Restored",
+			Quantity: 2, Quality: "NM", Lang: "EN", Comment: "binder, page 3"},
+		userDB.Card{Name: "Unknown Card", Set: "Avacyn Restored",
+			Quantity: 1, Quality: "LP", Lang: "EN"},
+	}
+	prices:= map[printing]priceDB.PrintingPrice{
+		printing{"Griselbrand", "Avacyn Restored"}: nonfoilPrice(1500),
+	}
+	export:= CollectionExport{
+		Collection: "EDH",
+		Currency: defaultCurrency,
+		Cards: exportedCards(current, prices),
+	}
+
+	data, contentType, err:= encodeExport(export, exportCSV)
+	if err!=nil || !strings.HasPrefix(contentType, "text/csv") {
+		t.Fatal("failed to export csv", contentType, err)
+	}
+
+	expected:= "name,set,quantity,quality,lang,comment,price\n" +
+		"Griselbrand,Avacyn Restored,2,NM,EN,\"binder, page 3\",1500\n" +
+		"Unknown Card,Avacyn Restored,1,LP,EN,,\n"
+	if string(data) != expected {
+		t.Fatal("incorrect csv export", string(data))
+	}
+
+}
+
+func TestEncodeExportJSON(t *testing.T) {
+
+	current:= []userDB.Card{
+		userDB.Card{Name: "Griselbrand", Set: "Avacyn Restored", Quantity: 2},
+		userDB.Card{Name: "Unknown Card", Set: "Avacyn Restored", Quantity: 1},
+	}
+	prices:= map[printing]priceDB.PrintingPrice{
+		printing{"Griselbrand", "Avacyn Restored"}: nonfoilPrice(1500),
+	}
+	export:= CollectionExport{
+		Collection: "EDH",
+		Currency: defaultCurrency,
+		Cards: exportedCards(current, prices),
+	}
+
+	data, _, err:= encodeExport(export, exportJSON)
+	if err!=nil {
+		t.Fatal(err)
+	}
+
+	var decoded CollectionExport
+	err = json.Unmarshal(data, &decoded)
+	if err!=nil || decoded.Collection != "EDH" || len(decoded.Cards) != 2 {
+		t.Fatal("incorrect json export", decoded, err)
+	}
+	if !decoded.Cards[0].Priced || decoded.Cards[0].Price != 1500 ||
+		decoded.Cards[1].Priced {
+		t.Fatal("prices lost in json export", decoded.Cards)
+	}
+
+	_, _, err = encodeExport(export, "xml")
+	if err == nil {
+		t.Fatal("unknown format exported")
+	}
+
+}
@@ -0,0 +1,102 @@
+package ApiServices
+
+import(
+
+	"./userDBHandler"
+
+	"github.com/emicklei/go-restful"
+
+	"net/http"
+
+	"strconv"
+
+)
+
+// How many events a feed page holds without an explicit limit and
+// the most it will ever hold.
+const defaultFeedPage int = 25
+const maxFeedPage int = 100
+
+// A page of a user's activity feed, most recent first.
+//
+// When More is set, passing Next as the cursor requests the
+// following page.
+type FeedResponse struct{
+	Events []userDB.Event
+	More bool
+	Next string
+}
+
+// Reads the cursor and limit from a request, ok is false when
+// either is present but invalid.
+func (aService *UserService) parseFeedPage(req *restful.Request) (before int64,
+	limit int, ok bool) {
+
+	before = userDB.FeedStart
+	limit = defaultFeedPage
+
+	if raw:= req.QueryParameter("cursor"); raw != "" {
+		decoded, err:= decodeCursor(aService.cursorKey, raw)
+		if err!=nil {
+			return 0, 0, false
+		}
+		before = decoded
+	}
+
+	if raw:= req.QueryParameter("limit"); raw != "" {
+		parsed, err:= strconv.Atoi(raw)
+		if err!=nil || parsed <= 0 || parsed > maxFeedPage {
+			return 0, 0, false
+		}
+		limit = parsed
+	}
+
+	return before, limit, true
+
+}
+
+// Builds a page from events acquired with one more than limit
+// requested, the extra only signals that another page exists.
+func feedPage(cursorKey []byte, events []userDB.Event, limit int) FeedResponse {
+
+	page:= FeedResponse{Events: events}
+	if len(events) > limit {
+		page.Events = events[:limit]
+		page.More = true
+		page.Next = encodeCursor(cursorKey, page.Events[limit - 1].ID)
+	}
+
+	return page
+
+}
+
+// Acquires a page of recent activity for an authenticated user
+func (aService *UserService) getFeed(req *restful.Request,
+	resp *restful.Response) {
+
+	userName:= req.PathParameter("userName")
+
+	sessionKey:= getSessionKeyHeader(req)
+	if sessionKey == nil {
+		resp.WriteErrorString(http.StatusBadRequest, BadCredentials)
+		return
+	}
+
+	before, limit, ok:= aService.parseFeedPage(req)
+	if !ok {
+		resp.WriteErrorString(http.StatusBadRequest, BadFeedPage)
+		return
+	}
+
+	events, err:= userDB.GetFeed(aService.db(), sessionKey,
+		userName, before, limit + 1)
+	if err!=nil {
+		logAt(aService.logger, levelError, requestContext(req), err)
+		resp.WriteErrorString(dbFailure(err, DBfailure))
+		return
+	}
+
+	setPrivateHeader(resp)
+	resp.WriteEntity(feedPage(aService.cursorKey, events, limit))
+
+}
@@ -0,0 +1,38 @@
+package ApiServices
+
+import(
+
+	"./userDBHandler"
+
+	"testing"
+
+)
+
+func TestFeedPage(t *testing.T) {
+
+	key:= deriveCursorKey([]byte("foo"))
+
+	events:= []userDB.Event{
+		userDB.Event{ID: 9, Kind: userDB.EventPermissionsChanged},
+		userDB.Event{ID: 7, Kind: userDB.EventTradesAdded},
+		userDB.Event{ID: 3, Kind: userDB.EventCollectionCreated},
+	}
+
+	page:= feedPage(key, events, 2)
+	if len(page.Events) != 2 || !page.More {
+		t.Fatal("incorrect page with more events", page)
+	}
+	if page.Events[0].Kind != userDB.EventPermissionsChanged {
+		t.Fatal("page reordered events", page)
+	}
+	before, err:= decodeCursor(key, page.Next)
+	if err!=nil || before != 7 {
+		t.Fatal("next cursor does not follow the last event", before, err)
+	}
+
+	page = feedPage(key, events, 3)
+	if len(page.Events) != 3 || page.More || page.Next != "" {
+		t.Fatal("incorrect final page", page)
+	}
+
+}
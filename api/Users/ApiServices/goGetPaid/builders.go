@@ -31,6 +31,7 @@ func GetMerchantFromFile(loc string) (*Merch, error) {
 	}
 
 	merch:= GetMerchant(meta.PrivateKey)
+	merch.webhookSecret = meta.WebhookSecret
 
 	return merch, nil
 }
@@ -39,4 +40,8 @@ type MerchMeta struct{
 
 	PrivateKey string
 
+	// Signs the webhooks stripe sends us, without it every webhook
+	// is refused
+	WebhookSecret string
+
 }
\ No newline at end of file
@@ -4,13 +4,91 @@ import(
 	"github.com/stripe/stripe-go"
 	"github.com/stripe/stripe-go/sub"
 	"github.com/stripe/stripe-go/customer"
+	"github.com/stripe/stripe-go/coupon"
+
+	"net/http"
+	"sync"
+	"time"
 )
 
+// The stripe calls made on subscriptions, replaceable so tests can
+// observe what reaches stripe.
+var getSub = sub.Get
+var updateSub = sub.Update
+var cancelSub = sub.Cancel
+
+// Identifies a customer's subscription on stripe.
+//
+// Both ids are strings so they are named to avoid passing them
+// in the wrong order.
+type SubRef struct{
+	CustomerID, SubID string
+}
+
+// The billing state of a subscription as stripe sees it.
+type SubDetails struct{
+	// When the current period ends and the next payment is due
+	PeriodEnd time.Time
+	// The subscription ends with the period rather than renewing
+	CancelAtPeriodEnd bool
+	// One of stripe's statuses, such as active or past_due
+	Status string
+}
+
+// How long a coupon stripe found valid is trusted without asking again
+const couponCacheDuration = 10 * time.Minute
+
 // A merchant that allows us the ability to charge
 // users.
 //
 // Mostly just a dummy that allows us to retain state as an object.
-type Merch struct{}
+type Merch struct{
+	// map[code]when the coupon was last found valid
+	validCoupons map[string]time.Time
+	couponLock sync.Mutex
+
+	webhookSecret string
+}
+
+// Determines if a coupon exists and can still be redeemed.
+//
+// Valid coupons are remembered for couponCacheDuration so repeated
+// use doesn't cost a round trip each. Coupons stripe doesn't know
+// are invalid rather than an error.
+func (merch *Merch) ValidateCoupon(code string) (bool, error) {
+
+	now:= time.Now()
+
+	merch.couponLock.Lock()
+	checked, ok:= merch.validCoupons[code]
+	merch.couponLock.Unlock()
+	if ok && now.Sub(checked) < couponCacheDuration {
+		return true, nil
+	}
+
+	c, err:= coupon.Get(code, nil)
+	if stripeErr, ok:= err.(*stripe.Error); ok &&
+		stripeErr.HTTPStatusCode == http.StatusNotFound {
+		return false, nil
+	}
+	if err!=nil {
+		return false, err
+	}
+
+	if !c.Valid {
+		return false, nil
+	}
+
+	merch.couponLock.Lock()
+	if merch.validCoupons == nil {
+		merch.validCoupons = make(map[string]time.Time)
+	}
+	merch.validCoupons[code] = now
+	merch.couponLock.Unlock()
+
+	return true, nil
+
+}
 
 // Subscribes a given customer to a plan.
 //
@@ -34,17 +112,35 @@ func (merch *Merch) SubCustomer(customer, plan string) (string, error) {
 
 }
 
-// Updates a given customer to the provided plan.
-//
-// Requires the customer's id, sub id, and the plan id
-func (merch *Merch) UpdateSubCustomer(customerID, subID, plan string) error {
+// Acquires the billing state of a customer's subscription.
+func (merch *Merch) GetSubCustomer(ref SubRef) (SubDetails, error) {
+
+	subParams:= &stripe.SubParams{
+		Customer: ref.CustomerID,
+	}
+
+	s, err:= getSub(ref.SubID, subParams)
+	if err!=nil {
+		return SubDetails{}, err
+	}
+
+	return SubDetails{
+		PeriodEnd: time.Unix(s.PeriodEnd, 0),
+		CancelAtPeriodEnd: s.EndCancel,
+		Status: string(s.Status),
+	}, nil
+
+}
+
+// Updates a given customer's subscription to the provided plan.
+func (merch *Merch) UpdateSubCustomer(ref SubRef, plan string) error {
 	
 	subParams:= &stripe.SubParams{
-		Customer: customerID,
+		Customer: ref.CustomerID,
 		Plan: plan,
 	}
 
-	_, err := sub.Update(subID, subParams)
+	_, err := updateSub(ref.SubID, subParams)
 
 	return err
 
@@ -57,13 +153,13 @@ func (merch *Merch) UpdateSubCustomer(customerID, subID, plan string) error {
 //
 // Requires both the customer's id and their accompanying
 // subscription id
-func (merch *Merch) UnSubCustomer(subID, customerID string) error {
+func (merch *Merch) UnSubCustomer(ref SubRef) error {
 
 	subParams:= &stripe.SubParams{
-		Customer: customerID,
+		Customer: ref.CustomerID,
 	}
 
-	return sub.Cancel(subID, subParams)
+	return cancelSub(ref.SubID, subParams)
 }
 
 // Adds a new customer with a given email and payment token.
@@ -106,4 +202,28 @@ func (merch *Merch) UpdateCustomer(customerID, token string) error {
 
 	return err
 
+}
+
+// Determines if stripe declined the customer's card.
+func Declined(err error) bool {
+
+	stripeErr, ok:= err.(*stripe.Error)
+
+	return ok && (string(stripeErr.Type) == "card_error" ||
+		stripeErr.HTTPStatusCode == http.StatusPaymentRequired)
+
+}
+
+// Determines if stripe refused a call over what the customer gave us,
+// such as a declined card, an unusable payment token or a bad coupon.
+//
+// Anything else, stripe being unreachable included, is a failure on
+// stripe's side or ours which the customer can't fix.
+func Refused(err error) bool {
+
+	stripeErr, ok:= err.(*stripe.Error)
+
+	return Declined(err) ||
+		(ok && stripeErr.HTTPStatusCode == http.StatusBadRequest)
+
 }
\ No newline at end of file
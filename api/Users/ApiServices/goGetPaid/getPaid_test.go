@@ -0,0 +1,104 @@
+package getPaid
+
+import(
+	"github.com/stripe/stripe-go"
+
+	"fmt"
+	"net/http"
+
+	"testing"
+)
+
+// Each id must reach stripe in its own place, a subscription id
+// passed as the customer cancels nothing.
+func TestSubRefReachesStripe(t *testing.T) {
+
+	ref:= SubRef{CustomerID: "cus_123", SubID: "sub_456"}
+	merch:= &Merch{}
+
+	var gotID, gotCustomer, gotPlan string
+	cancelSub = func(id string, params *stripe.SubParams) error {
+		gotID, gotCustomer = id, params.Customer
+		return nil
+	}
+	updateSub = func(id string,
+		params *stripe.SubParams) (*stripe.Sub, error) {
+		gotID, gotCustomer, gotPlan = id, params.Customer, params.Plan
+		return &stripe.Sub{ID: id}, nil
+	}
+
+	err:= merch.UnSubCustomer(ref)
+	if err!=nil {
+		t.Fatal(err)
+	}
+	if gotID != ref.SubID || gotCustomer != ref.CustomerID {
+		t.Fatal("cancel received swapped ids", gotID, gotCustomer)
+	}
+
+	err = merch.UpdateSubCustomer(ref, "plan_pro")
+	if err!=nil {
+		t.Fatal(err)
+	}
+	if gotID != ref.SubID || gotCustomer != ref.CustomerID ||
+		gotPlan != "plan_pro" {
+		t.Fatal("update received swapped ids", gotID, gotCustomer, gotPlan)
+	}
+
+}
+
+func TestGetSubCustomer(t *testing.T) {
+
+	ref:= SubRef{CustomerID: "cus_123", SubID: "sub_456"}
+	merch:= &Merch{}
+
+	getSub = func(id string, params *stripe.SubParams) (*stripe.Sub, error) {
+		if id != ref.SubID || params.Customer != ref.CustomerID {
+			t.Fatal("get received swapped ids", id, params.Customer)
+		}
+		return &stripe.Sub{
+			ID: id,
+			EndCancel: true,
+			Status: "past_due",
+			PeriodEnd: 1500000000,
+		}, nil
+	}
+
+	details, err:= merch.GetSubCustomer(ref)
+	if err!=nil {
+		t.Fatal(err)
+	}
+	if !details.CancelAtPeriodEnd || details.Status != "past_due" ||
+		details.PeriodEnd.Unix() != 1500000000 {
+		t.Fatal("incorrect details", details)
+	}
+
+}
+
+// Only failures the customer caused may be reported as theirs
+func TestRefused(t *testing.T) {
+
+	declined:= &stripe.Error{Type: "card_error",
+		HTTPStatusCode: http.StatusPaymentRequired}
+	if !Declined(declined) || !Refused(declined) {
+		t.Fatal("declined card not refused")
+	}
+
+	badToken:= &stripe.Error{Type: "invalid_request_error",
+		HTTPStatusCode: http.StatusBadRequest}
+	if Declined(badToken) || !Refused(badToken) {
+		t.Fatal("bad token misreported", Declined(badToken))
+	}
+
+	for _, err:= range []error{
+		&stripe.Error{Type: "api_error",
+			HTTPStatusCode: http.StatusInternalServerError},
+		&stripe.Error{Type: "invalid_request_error",
+			HTTPStatusCode: http.StatusUnauthorized},
+		fmt.Errorf("connection reset"),
+	}{
+		if Refused(err) {
+			t.Fatal("failure not of the customer's making refused", err)
+		}
+	}
+
+}
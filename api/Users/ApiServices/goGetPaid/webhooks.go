@@ -0,0 +1,132 @@
+package getPaid
+
+import(
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// How far a webhook's signed timestamp may be from now, bounding how
+// long a captured delivery could be replayed.
+const webhookTolerance = 5 * time.Minute
+
+// The parts of a stripe event we act upon.
+type WebhookEvent struct{
+	ID, Type string
+
+	// The customer and subscription the event concerns, empty when
+	// it concerns neither
+	CustomerID, SubID string
+}
+
+// The subset of a stripe event's json we read, the object differs
+// by the type of event.
+type rawWebhookEvent struct{
+	ID string `json:"id"`
+	Type string `json:"type"`
+	Data struct{
+		Object struct{
+			ID string `json:"id"`
+			Object string `json:"object"`
+			Customer string `json:"customer"`
+			Subscription string `json:"subscription"`
+		} `json:"object"`
+	} `json:"data"`
+}
+
+// Parses a webhook stripe sent us at now.
+//
+// signature must be the Stripe-Signature header sent alongside the
+// payload. Payloads are only parsed once their signature is verified.
+func (merch *Merch) ParseWebhook(payload []byte, signature string,
+	now time.Time) (WebhookEvent, error) {
+
+	err:= verifySignature(payload, signature, merch.webhookSecret, now)
+	if err!=nil {
+		return WebhookEvent{}, err
+	}
+
+	var raw rawWebhookEvent
+	err = json.Unmarshal(payload, &raw)
+	if err!=nil {
+		return WebhookEvent{}, fmt.Errorf("failed to parse webhook, %v", err)
+	}
+
+	event:= WebhookEvent{
+		ID: raw.ID,
+		Type: raw.Type,
+		CustomerID: raw.Data.Object.Customer,
+	}
+
+	// Invoices refer to their subscription, subscriptions are it
+	switch raw.Data.Object.Object {
+	case "subscription":
+		event.SubID = raw.Data.Object.ID
+	case "invoice":
+		event.SubID = raw.Data.Object.Subscription
+	}
+
+	return event, nil
+
+}
+
+// Ensures a payload was signed by stripe with secret recently
+// enough to trust.
+//
+// The header carries the signing time as t and one or more v1
+// signatures, each the hex of HMAC-SHA256(secret, t + "." + payload).
+func verifySignature(payload []byte, header, secret string,
+	now time.Time) error {
+
+	if secret == "" {
+		return fmt.Errorf("no webhook secret configured")
+	}
+
+	var timestamp string
+	var signatures []string
+	for _, part:= range strings.Split(header, ","){
+		pair:= strings.SplitN(strings.TrimSpace(part), "=", 2)
+		if len(pair) != 2 {
+			continue
+		}
+		switch pair[0] {
+		case "t":
+			timestamp = pair[1]
+		case "v1":
+			signatures = append(signatures, pair[1])
+		}
+	}
+
+	signed, err:= strconv.ParseInt(timestamp, 10, 64)
+	if err!=nil {
+		return fmt.Errorf("webhook signature has no timestamp")
+	}
+	age:= now.Sub(time.Unix(signed, 0))
+	if age > webhookTolerance || age < -webhookTolerance {
+		return fmt.Errorf("webhook signed outside tolerance, %v", age)
+	}
+
+	mac:= hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "."))
+	mac.Write(payload)
+	expected:= mac.Sum(nil)
+
+	for _, aSignature:= range signatures{
+		candidate, err:= hex.DecodeString(aSignature)
+		if err!=nil {
+			continue
+		}
+		if hmac.Equal(candidate, expected) {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("webhook signature does not match")
+
+}
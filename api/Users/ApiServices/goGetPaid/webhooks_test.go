@@ -0,0 +1,91 @@
+package getPaid
+
+import(
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+
+	"fmt"
+	"testing"
+	"time"
+)
+
+// Signs a payload as stripe would at signed.
+func signWebhook(payload []byte, secret string, signed time.Time) string {
+
+	timestamp:= fmt.Sprintf("%d", signed.Unix())
+	mac:= hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "."))
+	mac.Write(payload)
+
+	return "t=" + timestamp + ",v1=" + hex.EncodeToString(mac.Sum(nil))
+
+}
+
+func TestParseWebhook(t *testing.T) {
+
+	merch:= &Merch{webhookSecret: "whsec_test"}
+	now:= time.Now()
+
+	payload:= []byte(`{"id":"evt_1","type":"invoice.payment_failed",
+		"data":{"object":{"id":"in_1","object":"invoice",
+		"customer":"cus_1","subscription":"sub_1"}}}`)
+
+	event, err:= merch.ParseWebhook(payload,
+		signWebhook(payload, "whsec_test", now), now)
+	if err!=nil {
+		t.Fatal("failed to parse signed webhook", err)
+	}
+	if event.ID != "evt_1" || event.Type != "invoice.payment_failed" ||
+		event.CustomerID != "cus_1" || event.SubID != "sub_1" {
+		t.Fatal("incorrect event parsed", event)
+	}
+
+	payload = []byte(`{"id":"evt_2","type":"customer.subscription.deleted",
+		"data":{"object":{"id":"sub_2","object":"subscription",
+		"customer":"cus_2"}}}`)
+	event, err = merch.ParseWebhook(payload,
+		signWebhook(payload, "whsec_test", now), now)
+	if err!=nil {
+		t.Fatal(err)
+	}
+	if event.SubID != "sub_2" || event.CustomerID != "cus_2" {
+		t.Fatal("subscription not identified", event)
+	}
+
+}
+
+func TestParseWebhookRefused(t *testing.T) {
+
+	merch:= &Merch{webhookSecret: "whsec_test"}
+	now:= time.Now()
+	payload:= []byte(`{"id":"evt_1","type":"invoice.payment_failed"}`)
+
+	cases:= map[string]string{
+		"wrong secret": signWebhook(payload, "whsec_other", now),
+		"stale": signWebhook(payload, "whsec_test",
+			now.Add(-2 * webhookTolerance)),
+		"unsigned": "",
+		"garbage": "t=abc,v1=zz",
+	}
+	for name, signature:= range cases{
+		_, err:= merch.ParseWebhook(payload, signature, now)
+		if err == nil {
+			t.Fatal("accepted webhook that is", name)
+		}
+	}
+
+	_, err:= merch.ParseWebhook([]byte(`{"id":"evt_1","type":"x"}`),
+		signWebhook(payload, "whsec_test", now), now)
+	if err == nil {
+		t.Fatal("accepted signature for another payload")
+	}
+
+	unconfigured:= &Merch{}
+	_, err = unconfigured.ParseWebhook(payload,
+		signWebhook(payload, "", now), now)
+	if err == nil {
+		t.Fatal("accepted webhook without a secret configured")
+	}
+
+}
@@ -0,0 +1,109 @@
+package ApiServices
+
+import(
+
+	"github.com/emicklei/go-restful"
+
+	"net/http"
+
+)
+
+// What each component of a readiness check reports
+const (
+	componentOK string = "ok"
+	componentUnavailable string = "unavailable"
+	componentMissing string = "missing"
+)
+
+// Summarizes component statuses, ready only when every one is ok.
+func readiness(components map[string]string) (int, HealthStatus) {
+
+	status:= HealthStatus{
+		Status: componentOK,
+		Components: components,
+	}
+	for _, s:= range components{
+		if s != componentOK {
+			status.Status = componentUnavailable
+			return http.StatusServiceUnavailable, status
+		}
+	}
+
+	return http.StatusOK, status
+
+}
+
+// Checks everything a request may need, cheaply enough to be polled.
+//
+// Mail is only checked to be configured, reaching mailgun on every
+// poll would cost more than the check is worth. The card filter isn't
+// checked at all, register populates it before anything is served.
+func (aService *UserService) components() map[string]string {
+
+	components:= map[string]string{
+		"db": componentOK,
+		"recaptcha": componentOK,
+		"mail": componentOK,
+	}
+
+	var one int
+	err:= aService.db().QueryRow("SELECT 1").Scan(&one)
+	if err!=nil {
+		components["db"] = componentUnavailable
+	}
+
+	if aService.validator == nil {
+		components["recaptcha"] = componentMissing
+	}
+	if aService.mailer == nil {
+		components["mail"] = componentMissing
+	}
+
+	return components
+
+}
+
+// Reports that the process is up, whether or not it can serve.
+func (aService *UserService) getHealth(req *restful.Request,
+	resp *restful.Response) {
+
+	resp.WriteEntity(HealthStatus{Status: componentOK})
+
+}
+
+// Reports whether requests can be served, with 503 until they can.
+func (aService *UserService) getReady(req *restful.Request,
+	resp *restful.Response) {
+
+	resp.WriteHeaderAndEntity(readiness(aService.components()))
+
+}
+
+// Builds the unauthenticated routes load balancers poll.
+func (aService *UserService) registerHealth() {
+
+	health:= new(restful.WebService)
+	health.
+		Path("/api").
+		Produces(restful.MIME_JSON)
+
+	health.Route(health.
+		GET("/health").To(aService.getHealth).
+		// Docs
+		Doc("Liveness, succeeds whenever the process is serving").
+		Operation("getHealth").
+		Writes(HealthStatus{}).
+		Returns(http.StatusOK, "Alive", HealthStatus{}))
+
+	health.Route(health.
+		GET("/ready").To(aService.getReady).
+		// Docs
+		Doc("Readiness, fails until the database, recaptcha and mail are ready").
+		Operation("getReady").
+		Writes(HealthStatus{}).
+		Returns(http.StatusOK, "Ready", HealthStatus{}).
+		Returns(http.StatusServiceUnavailable, "Not ready", HealthStatus{}))
+
+	aService.Health = health
+
+}
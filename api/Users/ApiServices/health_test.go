@@ -0,0 +1,32 @@
+package ApiServices
+
+import(
+
+	"net/http"
+
+	"testing"
+
+)
+
+func TestReadiness(t *testing.T) {
+
+	code, status:= readiness(map[string]string{
+		"db": componentOK,
+		"mail": componentOK,
+	})
+	if code != http.StatusOK || status.Status != componentOK {
+		t.Fatal("ready components reported unready", code, status)
+	}
+
+	// Unready while any component is, such as an unconfigured mailer
+	code, status = readiness(map[string]string{
+		"db": componentOK,
+		"mail": componentMissing,
+	})
+	if code != http.StatusServiceUnavailable ||
+		status.Status != componentUnavailable ||
+		status.Components["mail"] != componentMissing {
+		t.Fatal("missing mailer reported ready", code, status)
+	}
+
+}
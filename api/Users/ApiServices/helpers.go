@@ -2,15 +2,26 @@ package ApiServices
 
 import(
 
+	"./userDBHandler"
+	"./goGetPaid"
+
 	"io/ioutil"
 	"strings"
+	"strconv"
+	"unicode"
+	"unicode/utf8"
+	"encoding/base64"
+	"encoding/json"
 
 	"io"
 	"log"
 	"os"
 	"fmt"
+	"sync"
+	"time"
 
 	"github.com/emicklei/go-restful"
+	"github.com/jackc/pgx"
 
 	"net/http"
 
@@ -47,6 +58,60 @@ func getUserNameAndSessionKey(req *restful.Request) (userName string,
 
 }
 
+// Determines if a signup's email confirmation agrees with its email.
+//
+// A provided confirmation must always match, ignoring case and
+// surrounding whitespace, while an absent one is only accepted
+// when confirmation is not required.
+func emailConfirmed(email, confirmation string, required bool) bool {
+
+	if confirmation == "" {
+		return !required
+	}
+
+	return strings.EqualFold(strings.TrimSpace(email),
+		strings.TrimSpace(confirmation))
+
+}
+
+// Authenticated GETs carry the session key in a header, base64
+// encoded just as it is within JSON bodies.
+const sessionKeyHeader string = "X-Session-Key"
+
+// Acquires the session key from a request's header, nil if it is
+// absent or malformed.
+func getSessionKeyHeader(req *restful.Request) []byte {
+
+	sessionKey, err:= base64.StdEncoding.DecodeString(
+		req.HeaderParameter(sessionKeyHeader))
+	if err!=nil || len(sessionKey) == 0 {
+		return nil
+	}
+
+	return sessionKey
+
+}
+
+// Builds the ETag for a given version of a resource.
+func versionETag(version int64) string {
+	return fmt.Sprintf("\"%d\"", version)
+}
+
+// Determines if an If-None-Match header already names etag, in
+// which case the client's copy is current.
+func etagMatches(ifNoneMatch, etag string) bool {
+
+	for _, candidate:= range strings.Split(ifNoneMatch, ","){
+		candidate = strings.TrimSpace(candidate)
+		if candidate == etag || candidate == "*" {
+			return true
+		}
+	}
+
+	return false
+
+}
+
 // Sets a cache header of 5 hours to a given request.
 func setCacheHeader(resp *restful.Response) {
 	resp.Header().Set("Cache-Control", "max-age=18000,s-maxage=18000")
@@ -98,39 +163,255 @@ func GetLogger(fName, name string) (aLogger *log.Logger) {
 	return
 }
 
-const passwordMinLength int = 10
-const passwordMaxLength int = 256
+// A logger writing a json object per line to stdout, for aggregation
+// rather than reading by eye.
+//
+// Plain Println and friends are logged at info level, use logAt to
+// log at another level or about a particular request or user.
+func GetJSONLogger(name string) *log.Logger {
+	return log.New(newJSONLineWriter(os.Stdout, name), "", 0)
+}
+
+// How severe a logged line is
+type logLevel string
+
+const (
+	levelInfo logLevel = "info"
+	levelWarn logLevel = "warn"
+	levelError logLevel = "error"
+)
+
+// Who a logged line is about, either may be empty.
+type logContext struct{
+	RequestID, User string
+}
+
+// A single line as written by GetJSONLogger
+type logEntry struct{
+	Timestamp time.Time `json:"timestamp"`
+	Level logLevel `json:"level"`
+	Logger string `json:"logger"`
+	RequestID string `json:"requestId,omitempty"`
+	User string `json:"user,omitempty"`
+	Message string `json:"message"`
+}
+
+// Writes everything it's given as a logEntry.
+type jsonLineWriter struct{
+	// Guards out, as entries may be written without a log.Logger
+	lock sync.Mutex
+	out io.Writer
+
+	name string
+	now func() time.Time
+}
+
+func newJSONLineWriter(out io.Writer, name string) *jsonLineWriter {
+	return &jsonLineWriter{out: out, name: name, now: time.Now}
+}
+
+// Writes p, a single line from a log.Logger, at info level.
+func (w *jsonLineWriter) Write(p []byte) (int, error) {
+
+	entry:= logEntry{
+		Level: levelInfo,
+		Message: strings.TrimSuffix(string(p), "\n"),
+	}
+	err:= w.writeEntry(entry)
+	if err!=nil {
+		return 0, err
+	}
+
+	return len(p), nil
+
+}
+
+// Writes an entry as a line, stamped with when and who wrote it.
+func (w *jsonLineWriter) writeEntry(entry logEntry) error {
+
+	entry.Timestamp = w.now().UTC()
+	entry.Logger = w.name
+
+	serial, err:= json.Marshal(entry)
+	if err!=nil {
+		return err
+	}
+
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	_, err = w.out.Write(append(serial, '\n'))
+
+	return err
+
+}
+
+// Logs at a level about a request or user.
+//
+// JSON loggers record each as its own field, text loggers prefix
+// the line with them so they're still there when reading by eye.
+func logAt(aLogger *log.Logger, level logLevel, context logContext,
+	v ...interface{}) {
+
+	message:= strings.TrimSuffix(fmt.Sprintln(v...), "\n")
+
+	if w, ok:= aLogger.Writer().(*jsonLineWriter); ok {
+		w.writeEntry(logEntry{
+			Level: level,
+			RequestID: context.RequestID,
+			User: context.User,
+			Message: message,
+		})
+		return
+	}
+
+	prefix:= strings.ToUpper(string(level))
+	if context.RequestID != "" {
+		prefix += " request=" + context.RequestID
+	}
+	if context.User != "" {
+		prefix += " user=" + context.User
+	}
+
+	aLogger.Output(2, prefix + " " + message)
+
+}
+
+// Determines how a failure from the database is reported.
+//
+// Rows which don't exist mean the client asked for something that
+// isn't there so are refused with message, as are bad sessions. An
+// unreachable database is 503 so clients retry, anything else is a
+// 500 as the fault is ours rather than the client's.
+func dbFailure(err error, message string) (int, string) {
+
+	switch {
+	case err == pgx.ErrNoRows:
+		return http.StatusBadRequest, message
+	case err == userDB.ErrBadSession:
+		return http.StatusUnauthorized, BadCredentials
+	case userDB.IsUnavailable(err):
+		return http.StatusServiceUnavailable, DBUnavailable
+	}
+
+	return http.StatusInternalServerError, DBfailure
+
+}
+
+// Determines how a failed stripe call is reported.
+//
+// Only what the customer gave us, such as a declined card or a bad
+// token, is theirs to fix. Stripe failing or being unreachable is a
+// 502 as we are the gateway to it.
+func stripeFailure(err error, message string) (int, string) {
+
+	switch {
+	case getPaid.Declined(err):
+		return http.StatusPaymentRequired, message
+	case getPaid.Refused(err):
+		return http.StatusBadRequest, message
+	}
 
-const alphas string = "abcdefghijklmnopqrstuvwxyz"
-const numerics string = "1234567890"
-const additionals string = "!@#$^&*()-_=+[{]}|;:'\",<.>/?"
+	return http.StatusBadGateway, message
 
-// Ignoring additionals for now.
-var characterSets = [...]string{alphas, numerics, /*additionals*/}
+}
 
-// Returns if the password meets the length and complexity requirements
-func passwordMeetsRequirements(password string) bool {
+// Determines if a password is acceptable, when it isn't the message
+// spells out every requirement it fails.
+func passwordFeedback(password string) (string, bool) {
 
-	complexity := true
-	length := true
+	_, reasons:= userDB.PasswordStrength(password)
+	if len(reasons) > 0 {
+		return BadPassword + ": " + strings.Join(reasons, ", "), false
+	}
 
-	if len(password) >= passwordMinLength &&
-		len(password) < passwordMaxLength {
-		length = true
-	} else {
-		//prevent potentially costly attempts to match
-		//the password complexity requirements
-		return false
+	return "", true
+
+}
+
+// Describes why an email was refused by userDB.NormalizeEmail.
+func emailFailure(err error) string {
+
+	if err == userDB.ErrDisposableEmail {
+		return DisposableEmail
 	}
 
-	/* Let the client enforce complexity rules.
-	for _, charSet := range characterSets {
-		if !strings.ContainsAny(charSet, password) {
-			complexity = false
+	return BadEmail
+
+}
+
+// The most characters user supplied text of each kind may hold,
+// replaced at startup from the environment.
+var commentMaxLength int = 256
+var collectionNameMaxLength int = 64
+var collectionTagMaxLength int = 32
+
+// User supplied text is stored as users.standardText, which holds
+// fewer than 280 characters, so no limit may be set beyond this.
+const maxTextLength int = 279
+
+// Parses a limit on user supplied text, refusing any which couldn't
+// be stored.
+func parseTextLimit(raw string) (int, bool) {
+
+	limit, err:= strconv.Atoi(strings.TrimSpace(raw))
+	if err!=nil || limit <= 0 || limit > maxTextLength {
+		return 0, false
+	}
+
+	return limit, true
+
+}
+
+// The most tags a single collection may carry
+const maxCollectionTags int = 10
+
+// Characters which could form markup when echoed to other viewers.
+const markupCharacters string = "<>"
+
+// Cleans text supplied by a user which may later be shown to others,
+// such as card comments and collection names.
+//
+// Control characters are stripped and surrounding whitespace trimmed.
+// Text that is not valid utf8, is longer than maxLen characters once
+// cleaned or contains markup is rejected rather than altered further.
+func sanitizeUserText(text string, maxLen int) (string, error) {
+
+	if !utf8.ValidString(text) {
+		return "", fmt.Errorf("text is not valid utf8")
+	}
+
+	stripped:= strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) {
+			return -1
+		}
+		return r
+	}, text)
+	stripped = strings.TrimSpace(stripped)
+
+	if utf8.RuneCountInString(stripped) > maxLen {
+		return "", fmt.Errorf("text exceeds %d characters", maxLen)
+	}
+
+	if strings.ContainsAny(stripped, markupCharacters) {
+		return "", fmt.Errorf("text contains markup")
+	}
+
+	return stripped, nil
+
+}
+
+// Returns whether or not every provided string is valid utf8.
+//
+// Invalid encodings would corrupt our json output so they are
+// rejected at the boundary rather than stored.
+func validEncoding(texts ...string) bool {
+
+	for _, text:= range texts{
+		if !utf8.ValidString(text) {
+			return false
 		}
 	}
-	*/
 
-	return complexity && length
+	return true
 
-}
\ No newline at end of file
+}
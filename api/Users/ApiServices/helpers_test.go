@@ -0,0 +1,270 @@
+package ApiServices
+
+import(
+
+	"./userDBHandler"
+
+	"github.com/jackc/pgx"
+	"github.com/stripe/stripe-go"
+
+	"fmt"
+	"net/http"
+	"strings"
+	"bytes"
+	"log"
+	"time"
+	"encoding/json"
+
+	"testing"
+
+)
+
+func TestSanitizeUserTextClean(t *testing.T) {
+
+	cleaned, err:= sanitizeUserText("  near mint, \tfoil\x00\x1b  ", 64)
+	if err!=nil {
+		t.Fatal(err)
+	}
+
+	if cleaned != "near mint, foil" {
+		t.Fatal("control characters or whitespace not stripped", cleaned)
+	}
+
+}
+
+func TestSanitizeUserTextOversized(t *testing.T) {
+
+	_, err:= sanitizeUserText(strings.Repeat("a", 65), 64)
+	if err == nil {
+		t.Fatal("oversized text accepted")
+	}
+
+	// Length is measured in characters rather than bytes
+	_, err = sanitizeUserText(strings.Repeat("Æ", 64), 64)
+	if err!=nil {
+		t.Fatal("multibyte text at limit rejected", err)
+	}
+
+}
+
+// Limits must fit within what the database stores.
+func TestParseTextLimit(t *testing.T) {
+
+	limit, ok:= parseTextLimit(" 128 ")
+	if !ok || limit != 128 {
+		t.Fatal("valid limit refused", limit)
+	}
+
+	limit, ok = parseTextLimit("279")
+	if !ok || limit != maxTextLength {
+		t.Fatal("longest storable limit refused", limit)
+	}
+
+	for _, raw:= range []string{"280", "0", "-5", "foo"}{
+		if _, ok:= parseTextLimit(raw); ok {
+			t.Fatal("unstorable limit accepted", raw)
+		}
+	}
+
+}
+
+func TestSanitizeUserTextMalicious(t *testing.T) {
+
+	malicious:= []string{
+		"<script>alert(1)</script>",
+		"<img src=x onerror=alert(1)>",
+		"foo\x00<b>",
+		"\xff\xfe",
+	}
+
+	for _, text:= range malicious{
+		_, err:= sanitizeUserText(text, commentMaxLength)
+		if err == nil {
+			t.Fatal("malicious text accepted", text)
+		}
+	}
+
+}
+
+func TestValidEncoding(t *testing.T) {
+
+	if !validEncoding("Griselbrand", "Æther Vial", "") {
+		t.Fatal("valid utf8 rejected")
+	}
+
+	invalid:= []string{
+		"\xff",
+		"Grisel\xc3brand",
+		// Overlong encoding of '/'
+		"\xc0\xaf",
+		// Lone surrogate half
+		"\xed\xa0\x80",
+	}
+	for _, text:= range invalid{
+		if validEncoding("Griselbrand", text) {
+			t.Fatal("invalid utf8 accepted", []byte(text))
+		}
+	}
+
+}
+
+func TestEtagMatches(t *testing.T) {
+
+	etag:= versionETag(4)
+
+	if !etagMatches(etag, etag) {
+		t.Fatal("identical ETag not matched")
+	}
+	if !etagMatches(`"3", ` + etag, etag) {
+		t.Fatal("ETag within a list not matched")
+	}
+	if etagMatches(versionETag(3), etag) || etagMatches("", etag) {
+		t.Fatal("stale or absent ETag matched")
+	}
+
+}
+
+func TestParseReservedNames(t *testing.T) {
+
+	names:= parseReservedNames(" Get, Archive,,Stats ")
+	if len(names) != 3 || names[0] != "Get" ||
+		names[1] != "Archive" || names[2] != "Stats" {
+		t.Fatal("reserved names misparsed", names)
+	}
+
+}
+
+func TestEmailConfirmed(t *testing.T) {
+
+	if emailConfirmed("foo@bar.baz", "foo@bar.bz", false) ||
+		emailConfirmed("foo@bar.baz", "foo@bar.bz", true) {
+		t.Fatal("mismatched confirmation accepted")
+	}
+
+	if !emailConfirmed("foo@bar.baz", " Foo@Bar.baz", true) {
+		t.Fatal("matching confirmation rejected")
+	}
+
+	if !emailConfirmed("foo@bar.baz", "", false) {
+		t.Fatal("absent confirmation rejected when optional")
+	}
+	if emailConfirmed("foo@bar.baz", "", true) {
+		t.Fatal("absent confirmation accepted when required")
+	}
+
+}
+
+func TestPasswordFeedback(t *testing.T) {
+
+	feedback, ok:= passwordFeedback("password")
+	if ok || !strings.HasPrefix(feedback, BadPassword) ||
+		!strings.Contains(feedback, "too short") ||
+		!strings.Contains(feedback, "too common") {
+		t.Fatal("feedback does not explain rejection", feedback)
+	}
+
+	_, ok = passwordFeedback("griselbrand7")
+	if !ok {
+		t.Fatal("acceptable password rejected")
+	}
+
+}
+
+// Ensure plain log lines and logAt both become entries with every field
+func TestJSONLogger(t *testing.T) {
+
+	var out bytes.Buffer
+	w:= newJSONLineWriter(&out, "testLog")
+	stamp:= time.Date(2016, 3, 1, 12, 0, 0, 0, time.UTC)
+	w.now = func() time.Time { return stamp }
+	aLogger:= log.New(w, "", 0)
+
+	aLogger.Println("plain", 1)
+	logAt(aLogger, levelWarn, logContext{RequestID: "abc", User: "bob"},
+		"refused login,", "bad password")
+
+	lines:= strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatal("expected a line per entry", lines)
+	}
+
+	var plain, context logEntry
+	if err:= json.Unmarshal([]byte(lines[0]), &plain); err!=nil {
+		t.Fatal("plain line not json", err)
+	}
+	if err:= json.Unmarshal([]byte(lines[1]), &context); err!=nil {
+		t.Fatal("logAt line not json", err)
+	}
+
+	if plain.Level != levelInfo || plain.Message != "plain 1" ||
+		plain.Logger != "testLog" || !plain.Timestamp.Equal(stamp) {
+		t.Fatal("plain line malformed", plain)
+	}
+	if context.Level != levelWarn || context.RequestID != "abc" ||
+		context.User != "bob" ||
+		context.Message != "refused login, bad password" {
+		t.Fatal("logAt line malformed", context)
+	}
+
+}
+
+// Ensure text loggers keep the level and context readable
+func TestLogAtText(t *testing.T) {
+
+	var out bytes.Buffer
+	aLogger:= log.New(&out, "", 0)
+
+	logAt(aLogger, levelError, logContext{User: "bob"}, "failed to send email")
+
+	if out.String() != "ERROR user=bob failed to send email\n" {
+		t.Fatal("text line malformed", out.String())
+	}
+
+}
+
+// Ensure only failures of the client's making are reported as 4xx
+func TestDBFailure(t *testing.T) {
+
+	cases:= []struct{
+		err error
+		status int
+		message string
+	}{
+		{pgx.ErrNoRows, http.StatusBadRequest, BadUserName},
+		{userDB.ErrBadSession, http.StatusUnauthorized, BadCredentials},
+		{userDB.ErrUnavailable, http.StatusServiceUnavailable, DBUnavailable},
+		{fmt.Errorf("syntax error"), http.StatusInternalServerError, DBfailure},
+	}
+	for _, c:= range cases{
+		status, message:= dbFailure(c.err, BadUserName)
+		if status != c.status || message != c.message {
+			t.Fatal("misreported failure", c.err, status, message)
+		}
+	}
+
+}
+
+func TestStripeFailure(t *testing.T) {
+
+	cases:= []struct{
+		err error
+		status int
+	}{
+		{&stripe.Error{Type: "card_error",
+			HTTPStatusCode: http.StatusPaymentRequired},
+			http.StatusPaymentRequired},
+		{&stripe.Error{Type: "invalid_request_error",
+			HTTPStatusCode: http.StatusBadRequest}, http.StatusBadRequest},
+		{&stripe.Error{Type: "api_error",
+			HTTPStatusCode: http.StatusInternalServerError},
+			http.StatusBadGateway},
+		{fmt.Errorf("connection reset"), http.StatusBadGateway},
+	}
+	for _, c:= range cases{
+		status, message:= stripeFailure(c.err, StripeSubFailure)
+		if status != c.status || message != StripeSubFailure {
+			t.Fatal("misreported stripe failure", c.err, status, message)
+		}
+	}
+
+}
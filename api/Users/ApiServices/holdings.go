@@ -0,0 +1,206 @@
+package ApiServices
+
+import(
+
+	"./userDBHandler"
+	"./../../../common/priceDB"
+
+	"github.com/emicklei/go-restful"
+
+	"time"
+
+)
+
+// The net quantity held of a card alongside what it cost and is worth.
+//
+// Prices are in cents of defaultCurrency. No purchase prices are
+// recorded so AverageCost is the average market price when copies
+// were traded in, HasCost unset when none of those were priced or the
+// trades aren't visible to the caller.
+// Price is the current market price, Priced unset when there is none.
+type Holding struct{
+	Name, Set, Quality, Lang string
+	Quantity int32
+	AverageCost int32
+	HasCost bool
+	Price int32
+	Priced bool
+	// Price times Quantity
+	Value int64
+}
+
+// Reduces the trades of a collection into holdings as netQuantities
+// does, averaging the cost of every copy traded in.
+//
+// costOf provides the price a trade was made at, if known.
+func holdings(trades []userDB.Card,
+	prices map[printing]priceDB.PrintingPrice,
+	costOf func(userDB.Card) (int32, bool)) []Holding {
+
+	type cost struct{
+		total, quantity int64
+	}
+
+	costs:= make(map[CardQuantity]cost)
+	for _, aCard:= range trades{
+		if aCard.Quantity <= 0 {
+			continue
+		}
+
+		price, ok:= costOf(aCard)
+		if !ok {
+			continue
+		}
+
+		key:= CardQuantity{
+			Name: aCard.Name,
+			Set: aCard.Set,
+			Quality: aCard.Quality,
+			Lang: aCard.Lang,
+		}
+		c:= costs[key]
+		c.total+= int64(price) * int64(aCard.Quantity)
+		c.quantity+= int64(aCard.Quantity)
+		costs[key] = c
+	}
+
+	held:= netQuantities(trades)
+	result:= make([]Holding, len(held))
+	for i, aQuantity:= range held{
+		h:= Holding{
+			Name: aQuantity.Name,
+			Set: aQuantity.Set,
+			Quality: aQuantity.Quality,
+			Lang: aQuantity.Lang,
+			Quantity: aQuantity.Quantity,
+		}
+
+		aQuantity.Quantity = 0
+		if c, ok:= costs[aQuantity]; ok {
+			h.AverageCost = int32(c.total / c.quantity)
+			h.HasCost = true
+		}
+
+		h.Price, h.Priced = priceFor(userDB.Card{Name: h.Name, Set: h.Set},
+			prices)
+		h.Value = int64(h.Price) * int64(h.Quantity)
+
+		result[i] = h
+	}
+
+	return result
+
+}
+
+// Provides the market price of each traded card closest to when it
+// was traded, looking each printing up once a day with a single query.
+func (aService *UserService) tradeCosts(
+	trades []userDB.Card) func(userDB.Card) (int32, bool) {
+
+	type lookup struct{
+		name, set string
+		day time.Time
+	}
+	keyOf:= func(aCard userDB.Card) lookup {
+		return lookup{aCard.Name, aCard.Set,
+			aCard.LastUpdate.UTC().Truncate(24 * time.Hour)}
+	}
+
+	positions:= make(map[lookup]int)
+	requests:= make([]priceDB.PrintingAt, 0)
+	for _, aCard:= range trades{
+		key:= keyOf(aCard)
+		if _, ok:= positions[key]; ok || aCard.Quantity <= 0 {
+			continue
+		}
+
+		positions[key] = len(requests)
+		requests = append(requests, priceDB.PrintingAt{
+			Printing: priceDB.Printing{Name: key.name, Set: key.set},
+			When: priceDB.Timestamp(key.day),
+		})
+	}
+
+	costs:= aService.closestPrices(requests, currencySources[defaultCurrency])
+
+	return func(aCard userDB.Card) (int32, bool) {
+
+		position, ok:= positions[keyOf(aCard)]
+		if !ok {
+			return 0, false
+		}
+
+		price, ok:= costs[position]
+		return price.Price, ok
+
+	}
+
+}
+
+// Determines if what holdings cost may be shown, only to the owner,
+// who is authenticated whenever sessionKey is set, or when the history
+// they are drawn from is public.
+func costsVisible(meta *userDB.Collection, sessionKey []byte) bool {
+	return sessionKey != nil || meta.Privacy == "History"
+}
+
+// Provides no trade prices, so holdings report no cost.
+func noTradeCosts(userDB.Card) (int32, bool) {
+	return 0, false
+}
+
+// Acquires the holdings of a collection with what they cost and are
+// currently worth.
+//
+// A nil sessionKey acquires them as the public, refused for private
+// collections as getQuantities is. What copies cost is drawn from
+// when they were traded, so the public only sees it for collections
+// whose history is public too.
+func (aService *UserService) GetHoldings(userName, collectionName string,
+	sessionKey []byte) ([]Holding, error) {
+
+	meta, err:= userDB.GetCollectionMeta(aService.db(),
+		sessionKey, userName, collectionName)
+	if err!=nil {
+		return nil, err
+	}
+	if sessionKey == nil && meta.Privacy == "Private" {
+		return nil, userDB.ErrBadSession
+	}
+
+	trades, err:= userDB.GetCollectionHistory(aService.db(),
+		nil, userName, collectionName)
+	if err!=nil {
+		return nil, err
+	}
+
+	costOf:= noTradeCosts
+	if costsVisible(meta, sessionKey) {
+		costOf = aService.tradeCosts(trades)
+	}
+
+	return holdings(trades, aService.marketPrices(trades, defaultCurrency),
+		costOf), nil
+
+}
+
+// Acquires the net holdings of a collection, valued.
+func (aService *UserService) getHoldings(req *restful.Request,
+	resp *restful.Response) {
+
+	userName:= req.PathParameter("userName")
+	collectionName:= req.PathParameter("collectionName")
+
+	sessionKey:= getSessionKeyHeader(req)
+
+	// Missing and private collections must look identical to the public
+	held, err:= aService.GetHoldings(userName, collectionName, sessionKey)
+	if err!=nil {
+		status, message:= collectionFailure(err, sessionKey == nil)
+		resp.WriteErrorString(status, message)
+		return
+	}
+
+	resp.WriteEntity(held)
+
+}
@@ -0,0 +1,84 @@
+package ApiServices
+
+import(
+
+	"./userDBHandler"
+	"./../../../common/priceDB"
+
+	"testing"
+
+)
+
+// Costs average over copies traded in only and current prices value
+// what is still held.
+func TestHoldings(t *testing.T) {
+
+	trades:= []userDB.Card{
+		userDB.Card{Name: "Griselbrand", Set: "Avacyn Restored",
+			Quantity: 2, Quality: "NM", Comment: "100"},
+		userDB.Card{Name: "Griselbrand", Set: "Avacyn Restored",
+			Quantity: 2, Quality: "NM", Comment: "400"},
+		userDB.Card{Name: "Griselbrand", Set: "Avacyn Restored",
+			Quantity: -1, Quality: "NM", Comment: "900"},
+		userDB.Card{Name: "Unknown Card", Set: "Avacyn Restored",
+			Quantity: 1},
+		userDB.Card{Name: "Gone Card", Set: "Avacyn Restored",
+			Quantity: 1, Comment: "100"},
+		userDB.Card{Name: "Gone Card", Set: "Avacyn Restored",
+			Quantity: -1, Comment: "100"},
+	}
+	prices:= map[printing]priceDB.PrintingPrice{
+		printing{"Griselbrand", "Avacyn Restored"}: nonfoilPrice(1500),
+	}
+
+	// The fixture carries each trade's cost in its comment
+	costs:= map[string]int32{"100": 100, "400": 400, "900": 900}
+	costOf:= func(aCard userDB.Card) (int32, bool) {
+		price, ok:= costs[aCard.Comment]
+		return price, ok
+	}
+
+	held:= holdings(trades, prices, costOf)
+	if len(held) != 2 {
+		t.Fatal("incorrect holdings", held)
+	}
+
+	if held[0].Name != "Griselbrand" || held[0].Quantity != 3 ||
+		!held[0].HasCost || held[0].AverageCost != 250 ||
+		!held[0].Priced || held[0].Value != 4500 {
+		t.Fatal("incorrect holding", held[0])
+	}
+	if held[1].Name != "Unknown Card" || held[1].HasCost ||
+		held[1].Priced || held[1].Value != 0 {
+		t.Fatal("unknown prices guessed at", held[1])
+	}
+
+}
+
+// What copies cost reveals when they were traded, which the public
+// may only see for collections with public history.
+func TestCostsVisible(t *testing.T) {
+
+	visible:= map[string]bool{
+		"Private": false,
+		"Contents": false,
+		"History": true,
+	}
+	for privacy, expected:= range visible{
+		meta:= &userDB.Collection{Privacy: privacy}
+		if costsVisible(meta, nil) != expected {
+			t.Fatal("incorrect public cost visibility", privacy)
+		}
+		if !costsVisible(meta, []byte("key")) {
+			t.Fatal("costs hidden from the owner", privacy)
+		}
+	}
+
+	held:= holdings([]userDB.Card{
+		userDB.Card{Name: "Griselbrand", Set: "Avacyn Restored", Quantity: 1},
+	}, nil, noTradeCosts)
+	if len(held) != 1 || held[0].HasCost || held[0].AverageCost != 0 {
+		t.Fatal("hidden costs reported", held)
+	}
+
+}
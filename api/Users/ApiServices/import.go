@@ -0,0 +1,283 @@
+package ApiServices
+
+import(
+
+	"./userDBHandler"
+
+	"github.com/emicklei/go-restful"
+
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"net/http"
+
+)
+
+// The formats a collection may be imported from
+const (
+	// MTGO and Arena deck lists, '4 Lightning Bolt' with an optional
+	// '(M10) 146' naming the printing as Arena exports it
+	importDec string = "dec"
+	// Plain lists, '1x Lightning Bolt (M10)' with the x and set optional
+	importList string = "list"
+)
+
+// The most lines a single import may hold
+const maxImportLines int = 5000
+
+var ErrBadImportFormat error = fmt.Errorf("unknown import format")
+var ErrBadImportSize error = fmt.Errorf("import holds too many lines")
+
+var decLine = regexp.MustCompile(`^(\d+)\s+(.+?)(?:\s+\(([^)]+)\)(?:\s+\S+)?)?$`)
+var listLine = regexp.MustCompile(`^(\d+)[xX]?\s+(.+?)(?:\s+\(([^)]+)\))?$`)
+
+// Section headers Arena places between the parts of a deck
+var decSections = map[string]bool{
+	"deck": true,
+	"sideboard": true,
+	"commander": true,
+	"companion": true,
+	"maybeboard": true,
+}
+
+// Determines if a line carries nothing to import and may be passed
+// over without being reported.
+func ignoredImportLine(line, format string) bool {
+
+	if line == "" || strings.HasPrefix(line, "//") ||
+		strings.HasPrefix(line, "#") {
+		return true
+	}
+
+	return format == importDec && decSections[strings.ToLower(line)]
+
+}
+
+// Splits a single line of an import into the card it names.
+//
+// The set is left as written, empty when the line names none.
+func parseImportLine(line, format string) (userDB.Card, bool) {
+
+	pattern:= listLine
+	if format == importDec {
+		pattern = decLine
+
+		// MTGO marks sideboard cards, they belong to the collection all the same
+		if len(line) > 3 && strings.EqualFold(line[:3], "SB:") {
+			line = strings.TrimSpace(line[3:])
+		}
+	}
+
+	parts:= pattern.FindStringSubmatch(line)
+	if parts == nil {
+		return userDB.Card{}, false
+	}
+
+	quantity, err:= strconv.ParseInt(parts[1], 10, 32)
+	if err!=nil || quantity <= 0 {
+		return userDB.Card{}, false
+	}
+
+	return userDB.Card{
+		Name: strings.TrimSpace(parts[2]),
+		Set: strings.TrimSpace(parts[3]),
+		Quantity: int32(quantity),
+	}, true
+
+}
+
+// Resolves the set an import named for a card by its proper name to
+// the name of a set it was printed in.
+//
+// Sets may be named by code or in full. When none is named the first
+// non-foil printing by name is taken so repeated imports agree.
+func (maps *cardMaps) resolveImportSet(name,
+	given string) (string, bool) {
+
+	printings:= maps.cardsToSets[name]
+
+	if given == "" {
+		names:= make([]string, 0, len(printings))
+		for aSet:= range printings{
+			if !strings.HasSuffix(aSet, " Foil") {
+				names = append(names, aSet)
+			}
+		}
+		if len(names) == 0 {
+			return "", false
+		}
+		sort.Strings(names)
+		return names[0], true
+	}
+
+	if printings[given] {
+		return given, true
+	}
+
+	aSet, ok:= maps.setCodes[strings.ToUpper(given)]
+	return aSet, ok && printings[aSet]
+
+}
+
+// Parses an import into the cards it holds, validated as a trade is.
+//
+// Lines naming the same printing are combined. Lines which can't be
+// parsed or name unknown cards are returned as skipped rather than
+// failing the import.
+func parseImport(format string, data []byte) ([]userDB.Card, []string, error) {
+
+	if format != importDec && format != importList {
+		return nil, nil, ErrBadImportFormat
+	}
+
+	lines:= strings.Split(string(data), "\n")
+	if len(lines) > maxImportLines {
+		return nil, nil, ErrBadImportSize
+	}
+
+	// Every line must see the same cards, even across a reload
+	maps:= loadedCards()
+
+	imported:= make([]userDB.Card, 0)
+	skipped:= make([]string, 0)
+	seen:= make(map[printing]int)
+	for _, line:= range lines{
+		line = strings.TrimSpace(line)
+		if ignoredImportLine(line, format) {
+			continue
+		}
+
+		aCard, ok:= parseImportLine(line, format)
+		if !ok {
+			skipped = append(skipped, line)
+			continue
+		}
+
+		name, ok:= maps.canonicalCardName(aCard.Name)
+		if !ok {
+			skipped = append(skipped, line)
+			continue
+		}
+		aCard.Name = name
+
+		aCard.Set, ok = maps.resolveImportSet(name, aCard.Set)
+		if !ok {
+			skipped = append(skipped, line)
+			continue
+		}
+
+		aCard, problem:= validateTradeCard(maps, aCard)
+		if problem != "" {
+			skipped = append(skipped, line)
+			continue
+		}
+
+		key:= printing{aCard.Name, aCard.Set}
+		if i, ok:= seen[key]; ok {
+			imported[i].Quantity+= aCard.Quantity
+			continue
+		}
+		seen[key] = len(imported)
+		imported = append(imported, aCard)
+	}
+
+	return imported, skipped, nil
+
+}
+
+// Imports a deck list in format into an existing collection as a
+// single trade.
+//
+// Returns how many distinct printings were imported along with the
+// lines which were skipped. Nothing is added when every line is
+// skipped. version is checked as with AddTrades.
+func (aService *UserService) ImportCollection(userName, collectionName string,
+	sessionKey []byte, format string, data []byte,
+	version int64) (int, []string, error) {
+
+	imported, skipped, err:= parseImport(format, data)
+	if err!=nil || len(imported) == 0 {
+		return 0, skipped, err
+	}
+
+	err = userDB.AddTrades(aService.db(), sessionKey,
+		userName, collectionName,
+		[][]userDB.Card{imported}, version)
+	if err!=nil {
+		return 0, skipped, err
+	}
+
+	return len(imported), skipped, nil
+
+}
+
+// Imports a deck list into a collection, reporting the lines skipped.
+func (aService *UserService) importCollection(req *restful.Request,
+	resp *restful.Response) {
+
+	userName:= req.PathParameter("userName")
+	collectionName:= req.PathParameter("collectionName")
+
+	var importContainer CollectionImportBody
+	err:= req.ReadEntity(&importContainer)
+	if err!=nil {
+		resp.WriteErrorString(http.StatusBadRequest, BodyReadFailure)
+		return
+	}
+
+	if importContainer.SessionKey == nil {
+		resp.WriteErrorString(http.StatusBadRequest, BadCredentials)
+		return
+	}
+
+	imported, skipped, err:= aService.ImportCollection(userName,
+		collectionName, importContainer.SessionKey,
+		importContainer.Format, []byte(importContainer.Data),
+		importContainer.Version)
+	if err == ErrBadImportFormat {
+		resp.WriteErrorString(http.StatusBadRequest, BadImportFormat)
+		return
+	}
+	if err == ErrBadImportSize {
+		resp.WriteErrorString(http.StatusBadRequest, BadImportSize)
+		return
+	}
+	if badCard, ok:= err.(userDB.BadCardError); ok {
+		resp.WriteErrorString(http.StatusBadRequest,
+			BadTradeContents + ": " + badCard.Name)
+		return
+	}
+	if err == userDB.ErrCollectionLocked {
+		resp.WriteErrorString(http.StatusConflict, CollectionLocked)
+		return
+	}
+	if err == userDB.ErrCollectionConflict {
+		resp.WriteErrorString(http.StatusConflict, CollectionConflict)
+		return
+	}
+	if err == userDB.ErrCollectionOverLimit {
+		resp.WriteErrorString(http.StatusForbidden, CollectionOverLimit)
+		return
+	}
+	if err!=nil {
+		logAt(aService.logger, levelError, requestContext(req), err)
+		resp.WriteErrorString(http.StatusBadRequest, BadCredentials)
+		return
+	}
+
+	if imported > 0 {
+		// Receivers are notified without holding up the response
+		aService.queueBackground(func() {
+			aService.notifyWebhooks(userName, collectionName, "trade")
+		})
+	}
+
+	resp.WriteEntity(CollectionImportResponse{
+		Imported: imported,
+		Skipped: skipped,
+	})
+
+}
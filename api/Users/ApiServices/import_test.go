@@ -0,0 +1,80 @@
+package ApiServices
+
+import(
+
+	"testing"
+
+)
+
+func TestParseImportDec(t *testing.T) {
+
+	maps:= emptyCardMaps()
+	maps.cards = map[string]bool{"Lightning Bolt": true, "Griselbrand": true}
+	maps.cardsToSets = map[string]map[string]bool{
+		"Lightning Bolt": map[string]bool{"Magic 2010": true,
+			"Magic 2010 Foil": true, "Alpha": true},
+		"Griselbrand": map[string]bool{"Avacyn Restored": true},
+	}
+	maps.normalizedCards = populateNormalizedCards(maps.cards)
+	maps.setCodes = map[string]string{"M10": "Magic 2010",
+		"AVR": "Avacyn Restored"}
+	defer swapCardMaps(swapCardMaps(maps))
+
+	data:= "Deck\n4 Lightning Bolt (M10) 146\n\n// the fatty\n" +
+		"1 griselbrand\nSideboard\nSB: 2 Lightning Bolt (M10) 146\n" +
+		"3 Not A Card\n"
+	imported, skipped, err:= parseImport(importDec, []byte(data))
+	if err!=nil {
+		t.Fatal(err)
+	}
+	if len(imported) != 2 {
+		t.Fatal("incorrect cards imported", imported)
+	}
+	if imported[0].Name != "Lightning Bolt" ||
+		imported[0].Set != "Magic 2010" || imported[0].Quantity != 6 {
+		t.Fatal("sideboard not combined with main deck", imported[0])
+	}
+	if imported[1].Name != "Griselbrand" ||
+		imported[1].Set != "Avacyn Restored" {
+		t.Fatal("card without a set not given its printing", imported[1])
+	}
+	if len(skipped) != 1 || skipped[0] != "3 Not A Card" {
+		t.Fatal("unknown card not skipped", skipped)
+	}
+
+}
+
+func TestParseImportList(t *testing.T) {
+
+	maps:= emptyCardMaps()
+	maps.cards = map[string]bool{"Lightning Bolt": true, "Griselbrand": true}
+	maps.cardsToSets = map[string]map[string]bool{
+		"Lightning Bolt": map[string]bool{"Magic 2010": true,
+			"Magic 2010 Foil": true, "Alpha": true},
+		"Griselbrand": map[string]bool{"Avacyn Restored": true},
+	}
+	maps.normalizedCards = populateNormalizedCards(maps.cards)
+	maps.setCodes = map[string]string{"M10": "Magic 2010",
+		"AVR": "Avacyn Restored"}
+	defer swapCardMaps(swapCardMaps(maps))
+
+	data:= "1x Lightning Bolt (Alpha)\r\n2x Griselbrand (M10)\r\n" +
+		"Lightning Bolt\r\n0x Griselbrand\r\n1X Lightning Bolt\r\n"
+	imported, skipped, err:= parseImport(importList, []byte(data))
+	if err!=nil {
+		t.Fatal(err)
+	}
+	if len(imported) != 1 || imported[0].Set != "Alpha" ||
+		imported[0].Quantity != 2 {
+		t.Fatal("incorrect cards imported", imported)
+	}
+	if len(skipped) != 3 {
+		t.Fatal("bad lines not skipped", skipped)
+	}
+
+	_, _, err = parseImport("mwdeck", []byte(data))
+	if err != ErrBadImportFormat {
+		t.Fatal("unknown format parsed", err)
+	}
+
+}
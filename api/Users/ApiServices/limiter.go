@@ -0,0 +1,86 @@
+package ApiServices
+
+import(
+
+	"github.com/emicklei/go-restful"
+
+	"net/http"
+	"os"
+	"strconv"
+
+	"time"
+
+)
+
+// Maximum concurrent requests to expensive endpoints, as specified by
+// the USERS_HEAVY_CONCURRENCY environment variable.
+const heavyConcurrencyEnv string = "USERS_HEAVY_CONCURRENCY"
+const defaultHeavyConcurrency int = 8
+
+// How long clients shed from a saturated endpoint are asked to wait.
+const heavyRetryAfter time.Duration = 5 * time.Second
+
+// Bounds how many requests may run concurrently through the
+// routes it filters, shedding the excess.
+type concurrencyLimiter struct{
+	slots chan struct{}
+	retryAfter time.Duration
+}
+
+func newConcurrencyLimiter(max int, retryAfter time.Duration) *concurrencyLimiter {
+
+	if max <= 0 {
+		max = defaultHeavyConcurrency
+	}
+
+	return &concurrencyLimiter{
+		slots: make(chan struct{}, max),
+		retryAfter: retryAfter,
+	}
+
+}
+
+// Builds the limiter for expensive routes from the environment.
+func heavyLimiter() *concurrencyLimiter {
+
+	max, err:= strconv.Atoi(os.Getenv(heavyConcurrencyEnv))
+	if err!=nil {
+		max = defaultHeavyConcurrency
+	}
+
+	return newConcurrencyLimiter(max, heavyRetryAfter)
+
+}
+
+// Attempts to take a slot without blocking.
+func (limiter *concurrencyLimiter) acquire() bool {
+
+	select {
+	case limiter.slots<- struct{}{}:
+		return true
+	default:
+		return false
+	}
+
+}
+
+func (limiter *concurrencyLimiter) release() {
+	<-limiter.slots
+}
+
+// A restful filter which rejects requests with a 503 when saturated
+// rather than letting them queue and degrade everything else.
+func (limiter *concurrencyLimiter) filter(req *restful.Request,
+	resp *restful.Response, chain *restful.FilterChain) {
+
+	if !limiter.acquire() {
+		seconds:= int(limiter.retryAfter / time.Second)
+		resp.AddHeader("Retry-After", strconv.Itoa(seconds))
+		resp.WriteErrorString(http.StatusServiceUnavailable, Overloaded)
+		return
+	}
+	defer limiter.release()
+
+	chain.ProcessFilter(req, resp)
+
+}
@@ -0,0 +1,77 @@
+package ApiServices
+
+import(
+
+	"sync"
+
+	"testing"
+
+	"time"
+
+)
+
+// Fires more concurrent requests than there are slots and ensures
+// exactly the excess is shed.
+func TestConcurrencyLimiterSheds(t *testing.T) {
+
+	const max int = 3
+	const requests int = 10
+
+	limiter:= newConcurrencyLimiter(max, time.Second)
+
+	var lock sync.Mutex
+	admitted:= 0
+	shed:= 0
+
+	// Admitted requests hold their slot until every request has tried
+	var holding sync.WaitGroup
+	holding.Add(1)
+
+	var wg sync.WaitGroup
+	var tried sync.WaitGroup
+	for i:= 0; i < requests; i++ {
+		wg.Add(1)
+		tried.Add(1)
+		go func() {
+			defer wg.Done()
+
+			ok:= limiter.acquire()
+			lock.Lock()
+			if ok {
+				admitted++
+			} else {
+				shed++
+			}
+			lock.Unlock()
+			tried.Done()
+
+			if ok {
+				holding.Wait()
+				limiter.release()
+			}
+		}()
+	}
+
+	tried.Wait()
+	holding.Done()
+	wg.Wait()
+
+	if admitted != max || shed != requests - max {
+		t.Fatal("incorrect requests shed", admitted, shed)
+	}
+
+	// Slots are returned once requests complete
+	if !limiter.acquire() {
+		t.Fatal("slot not released after request completed")
+	}
+
+}
+
+func TestConcurrencyLimiterDefault(t *testing.T) {
+
+	limiter:= newConcurrencyLimiter(0, time.Second)
+	if cap(limiter.slots) != defaultHeavyConcurrency {
+		t.Fatal("unconfigured limit not defaulted")
+	}
+
+}
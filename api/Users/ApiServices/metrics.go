@@ -0,0 +1,285 @@
+package ApiServices
+
+import(
+
+	"./userDBHandler"
+
+	"github.com/emicklei/go-restful"
+
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+)
+
+// Upper bounds of the request latency histogram, in seconds
+var latencyBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5,
+	1, 2.5, 5, 10}
+
+// Requests are counted by the operation served and the status written
+type requestKey struct{
+	operation string
+	code int
+}
+
+// Latencies of a single operation, bucket i counting those within
+// latencyBuckets[i].
+type latencyHistogram struct{
+	buckets []uint64
+	count uint64
+	sum float64
+}
+
+// Stripe calls are counted by call and whether they succeeded
+type stripeKey struct{
+	call string
+	failed bool
+}
+
+// Everything measured about the service, exported in the Prometheus
+// text format.
+//
+// Counts only ever grow so rates are left to prometheus.
+type serviceMetrics struct{
+	lock sync.Mutex
+
+	requests map[requestKey]uint64
+	latencies map[string]*latencyHistogram
+	stripe map[stripeKey]uint64
+	mailFailures uint64
+
+	// Operation names by method and route path, set once at
+	// registration so unlocked reads are fine
+	operations map[string]string
+}
+
+func newServiceMetrics() *serviceMetrics {
+	return &serviceMetrics{
+		requests: make(map[requestKey]uint64),
+		latencies: make(map[string]*latencyHistogram),
+		stripe: make(map[stripeKey]uint64),
+		operations: make(map[string]string),
+	}
+}
+
+// Learns the operation of every route so requests are labelled with
+// the names their docs use.
+func (m *serviceMetrics) learnOperations(routes []restful.Route) {
+
+	for _, r:= range routes{
+		m.operations[r.Method + " " + r.Path] = r.Operation
+	}
+
+}
+
+// Returns the operation served by a request to path.
+//
+// Routes registered elsewhere are labelled with their path as they
+// have no operation we know of.
+func (m *serviceMetrics) operation(method, path string) string {
+
+	if operation, ok:= m.operations[method + " " + path]; ok {
+		return operation
+	}
+
+	return path
+
+}
+
+// Records a request having been served.
+func (m *serviceMetrics) observe(operation string, code int,
+	took time.Duration) {
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.requests[requestKey{operation, code}]++
+
+	h, ok:= m.latencies[operation]
+	if !ok {
+		h = &latencyHistogram{buckets: make([]uint64, len(latencyBuckets))}
+		m.latencies[operation] = h
+	}
+
+	seconds:= took.Seconds()
+	for i, bound:= range latencyBuckets{
+		if seconds <= bound {
+			h.buckets[i]++
+		}
+	}
+	h.count++
+	h.sum += seconds
+
+}
+
+// Records the outcome of a call to stripe.
+func (m *serviceMetrics) stripeCall(call string, err error) {
+
+	m.lock.Lock()
+	m.stripe[stripeKey{call, err != nil}]++
+	m.lock.Unlock()
+
+}
+
+// Records mail which never got through.
+func (m *serviceMetrics) mailFailed() {
+
+	m.lock.Lock()
+	m.mailFailures++
+	m.lock.Unlock()
+
+}
+
+// A restful filter which times every request it passes along.
+func (m *serviceMetrics) filter(req *restful.Request,
+	resp *restful.Response, chain *restful.FilterChain) {
+
+	start:= time.Now()
+
+	chain.ProcessFilter(req, resp)
+
+	m.observe(m.operation(req.Request.Method, req.SelectedRoutePath()),
+		resp.StatusCode(), time.Since(start))
+
+}
+
+// Writes every metric, alongside gauges sampled by the caller, in the
+// Prometheus text format.
+//
+// Series are sorted so output is stable between scrapes.
+func (m *serviceMetrics) write(w io.Writer, gauges map[string]float64) {
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	fmt.Fprintln(w, "# HELP users_requests_total Requests served by operation and status.")
+	fmt.Fprintln(w, "# TYPE users_requests_total counter")
+	requests:= make([]requestKey, 0, len(m.requests))
+	for key:= range m.requests{
+		requests = append(requests, key)
+	}
+	sort.Slice(requests, func(i, j int) bool {
+		if requests[i].operation != requests[j].operation {
+			return requests[i].operation < requests[j].operation
+		}
+		return requests[i].code < requests[j].code
+	})
+	for _, key:= range requests{
+		fmt.Fprintf(w, "users_requests_total{operation=%q,code=\"%d\"} %d\n",
+			key.operation, key.code, m.requests[key])
+	}
+
+	fmt.Fprintln(w, "# HELP users_request_duration_seconds Time taken serving requests by operation.")
+	fmt.Fprintln(w, "# TYPE users_request_duration_seconds histogram")
+	operations:= make([]string, 0, len(m.latencies))
+	for operation:= range m.latencies{
+		operations = append(operations, operation)
+	}
+	sort.Strings(operations)
+	for _, operation:= range operations{
+		h:= m.latencies[operation]
+		for i, bound:= range latencyBuckets{
+			fmt.Fprintf(w, "users_request_duration_seconds_bucket{operation=%q,le=%q} %d\n",
+				operation, strconv.FormatFloat(bound, 'g', -1, 64), h.buckets[i])
+		}
+		fmt.Fprintf(w, "users_request_duration_seconds_bucket{operation=%q,le=\"+Inf\"} %d\n",
+			operation, h.count)
+		fmt.Fprintf(w, "users_request_duration_seconds_sum{operation=%q} %g\n",
+			operation, h.sum)
+		fmt.Fprintf(w, "users_request_duration_seconds_count{operation=%q} %d\n",
+			operation, h.count)
+	}
+
+	fmt.Fprintln(w, "# HELP users_stripe_calls_total Calls made to stripe by call and outcome.")
+	fmt.Fprintln(w, "# TYPE users_stripe_calls_total counter")
+	calls:= make([]stripeKey, 0, len(m.stripe))
+	for key:= range m.stripe{
+		calls = append(calls, key)
+	}
+	sort.Slice(calls, func(i, j int) bool {
+		if calls[i].call != calls[j].call {
+			return calls[i].call < calls[j].call
+		}
+		return !calls[i].failed && calls[j].failed
+	})
+	for _, key:= range calls{
+		outcome:= "success"
+		if key.failed {
+			outcome = "failure"
+		}
+		fmt.Fprintf(w, "users_stripe_calls_total{call=%q,outcome=%q} %d\n",
+			key.call, outcome, m.stripe[key])
+	}
+
+	fmt.Fprintln(w, "# HELP users_mail_failures_total Mail which was dead lettered after every attempt failed.")
+	fmt.Fprintln(w, "# TYPE users_mail_failures_total counter")
+	fmt.Fprintf(w, "users_mail_failures_total %d\n", m.mailFailures)
+
+	names:= make([]string, 0, len(gauges))
+	for name:= range gauges{
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name:= range names{
+		fmt.Fprintf(w, "# TYPE %s gauge\n", name)
+		fmt.Fprintf(w, "%s %g\n", name, gauges[name])
+	}
+
+}
+
+// Samples the gauges which are cheaper to read at scrape time than to
+// keep up to date.
+//
+// A gauge which can't be read is left out rather than reported as zero.
+func (aService *UserService) gauges() map[string]float64 {
+
+	stat:= aService.db().Stat()
+	gauges:= map[string]float64{
+		"users_db_connections_max": float64(stat.MaxConnections),
+		"users_db_connections_current": float64(stat.CurrentConnections),
+		"users_db_connections_available": float64(stat.AvailableConnections),
+	}
+
+	sessions, err:= userDB.CountActiveSessions(aService.db())
+	if err == nil {
+		gauges["users_active_sessions"] = float64(sessions)
+	}
+
+	return gauges
+
+}
+
+// Exports every metric for prometheus to scrape.
+func (aService *UserService) getMetrics(req *restful.Request,
+	resp *restful.Response) {
+
+	resp.AddHeader("Content-Type", "text/plain; version=0.0.4")
+	aService.metrics.write(resp, aService.gauges())
+
+}
+
+// Builds the route prometheus scrapes, learning the operation of
+// every route registered on the users service first.
+func (aService *UserService) registerMetrics() {
+
+	aService.metrics.learnOperations(aService.Service.Routes())
+
+	metrics:= new(restful.WebService)
+	metrics.
+		Path("/metrics").
+		Produces("text/plain")
+
+	metrics.Route(metrics.
+		GET("").To(aService.getMetrics).
+		// Docs
+		Doc("Request, stripe and mail metrics in the Prometheus text format").
+		Operation("getMetrics").
+		Returns(http.StatusOK, "Metrics", nil))
+
+	aService.Metrics = metrics
+
+}
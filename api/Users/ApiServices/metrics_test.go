@@ -0,0 +1,63 @@
+package ApiServices
+
+import(
+
+	"github.com/emicklei/go-restful"
+
+	"bytes"
+	"fmt"
+	"strings"
+	"time"
+
+	"testing"
+
+)
+
+// Ensure requests are labelled by the operation their route documents
+func TestMetricsOperation(t *testing.T) {
+
+	m:= newServiceMetrics()
+	m.learnOperations([]restful.Route{
+		{Method: "POST", Path: "/api/Users/{userName}/Sub", Operation: "addSubUser"},
+	})
+
+	if m.operation("POST", "/api/Users/{userName}/Sub") != "addSubUser" {
+		t.Fatal("route not labelled by its operation")
+	}
+	if m.operation("GET", "/api/Users/{userName}/Sub") != "/api/Users/{userName}/Sub" {
+		t.Fatal("unknown route not labelled by its path")
+	}
+
+}
+
+func TestMetricsWrite(t *testing.T) {
+
+	m:= newServiceMetrics()
+	m.observe("addSubUser", 200, 20 * time.Millisecond)
+	m.observe("addSubUser", 400, 2 * time.Second)
+	m.stripeCall("subCustomer", nil)
+	m.stripeCall("subCustomer", fmt.Errorf("card declined"))
+	m.mailFailed()
+
+	var out bytes.Buffer
+	m.write(&out, map[string]float64{"users_active_sessions": 3})
+	exported:= out.String()
+
+	for _, series:= range []string{
+		`users_requests_total{operation="addSubUser",code="200"} 1`,
+		`users_requests_total{operation="addSubUser",code="400"} 1`,
+		`users_request_duration_seconds_bucket{operation="addSubUser",le="0.025"} 1`,
+		`users_request_duration_seconds_bucket{operation="addSubUser",le="2.5"} 2`,
+		`users_request_duration_seconds_bucket{operation="addSubUser",le="+Inf"} 2`,
+		`users_request_duration_seconds_count{operation="addSubUser"} 2`,
+		`users_stripe_calls_total{call="subCustomer",outcome="success"} 1`,
+		`users_stripe_calls_total{call="subCustomer",outcome="failure"} 1`,
+		`users_mail_failures_total 1`,
+		`users_active_sessions 3`,
+	}{
+		if !strings.Contains(exported, series + "\n") {
+			t.Fatal("missing series", series, exported)
+		}
+	}
+
+}
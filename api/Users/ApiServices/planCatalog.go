@@ -0,0 +1,185 @@
+package ApiServices
+
+import(
+
+	"./userDBHandler"
+
+	"github.com/emicklei/go-restful"
+
+	"io/ioutil"
+	"encoding/json"
+	"fmt"
+
+)
+
+// A paid tier billed at an interval, what a user actually subscribes to.
+type planChoice struct{
+	Plan, Interval string
+}
+
+// A plan as configured in the plan meta, what clients are shown
+// alongside what stripe bills it as.
+type planMeta struct{
+	Plan
+	// Empty for the free plan, which stripe never bills
+	StripePlan string
+}
+
+// Every plan which may be advertised, in the order they are configured.
+type planCatalog []planMeta
+
+// Parses a plan catalog from its json form.
+//
+// Every plan must be a known tier billed at a known interval and
+// chosen at most once. Paid plans need a stripe plan to be billed
+// as and the free plan must be among them, so the catalog always
+// describes every plan a user can be on. A tier allows the same
+// collections at every interval as its effects don't depend on billing.
+func parsePlanCatalog(raw []byte) (planCatalog, error) {
+
+	var catalog planCatalog
+	err:= json.Unmarshal(raw, &catalog)
+	if err!=nil {
+		return nil, err
+	}
+
+	seen:= make(map[planChoice]bool)
+	caps:= make(map[string]int)
+	hasFree:= false
+	for _, p:= range catalog{
+
+		if !stringIn(p.Name, userDB.SubTiers) {
+			return nil, fmt.Errorf("unknown plan %q", p.Name)
+		}
+		if !stringIn(p.Interval, userDB.BillingIntervals) {
+			return nil, fmt.Errorf("unknown interval %q for plan %q",
+				p.Interval, p.Name)
+		}
+		if p.Price < 0 {
+			return nil, fmt.Errorf("negative price for plan %q", p.Name)
+		}
+		if p.Collections < 1 {
+			return nil, fmt.Errorf("no collections for plan %q", p.Name)
+		}
+		if limit, ok:= caps[p.Name]; ok && limit != p.Collections {
+			return nil, fmt.Errorf("plan %q allows differing collections",
+				p.Name)
+		}
+		caps[p.Name] = p.Collections
+
+		choice:= planChoice{p.Name, p.Interval}
+		if seen[choice] {
+			return nil, fmt.Errorf("plan %q duplicated at interval %q",
+				p.Name, p.Interval)
+		}
+		seen[choice] = true
+
+		if p.Name == userDB.DefaultSubLevel {
+			hasFree = true
+			continue
+		}
+		if p.StripePlan == "" {
+			return nil, fmt.Errorf("no stripe plan for %q", p.Name)
+		}
+	}
+
+	if !hasFree {
+		return nil, fmt.Errorf("free plan %q missing",
+			userDB.DefaultSubLevel)
+	}
+
+	return catalog, nil
+
+}
+
+// Returns the stripe plan a tier is billed as at an interval and
+// whether that combination may be subscribed to.
+//
+// An empty interval is monthly, as every plan was before annual
+// billing existed.
+func (catalog planCatalog) stripePlan(plan,
+	interval string) (string, string, bool) {
+
+	if interval == "" {
+		interval = userDB.MonthlyInterval
+	}
+
+	for _, p:= range catalog{
+		if p.Name == plan && p.Interval == interval && p.StripePlan != "" {
+			return p.StripePlan, interval, true
+		}
+	}
+
+	return "", interval, false
+
+}
+
+// Returns how many collections each tier in the catalog may own.
+func (catalog planCatalog) collectionCaps() map[string]int {
+
+	caps:= make(map[string]int)
+	for _, p:= range catalog{
+		caps[p.Name] = p.Collections
+	}
+
+	return caps
+
+}
+
+// Returns every plan as clients are shown them.
+func (catalog planCatalog) advertised() []Plan {
+
+	plans:= make([]Plan, len(catalog))
+	for i, p:= range catalog{
+		plans[i] = p.Plan
+	}
+
+	return plans
+
+}
+
+// Determines if a string is among a list of them
+func stringIn(s string, list []string) bool {
+
+	for _, candidate:= range list{
+		if s == candidate {
+			return true
+		}
+	}
+
+	return false
+
+}
+
+// Readies the plans users may subscribe to.
+func (aService *UserService) setupPlans(loc string) {
+
+	metaRaw, err:= ioutil.ReadFile(loc)
+	if err!=nil {
+		aService.logger.Fatalln("Failed to read plan meta", err)
+	}
+
+	catalog, err:= parsePlanCatalog(metaRaw)
+	if err!=nil {
+		aService.logger.Fatalln("Failed to parse plan meta", err)
+	}
+
+	aService.plans = catalog
+
+	// Tiers left out of the catalog keep their built in caps. Set
+	// once before serving, these only reach a user as their plan
+	// changes so the cap stored against them is what's enforced.
+	for tier, limit:= range catalog.collectionCaps(){
+		userDB.SubTiersToCollections[tier] = limit
+	}
+
+}
+
+// Returns every plan available, the free plan included, so clients
+// needn't hardcode them.
+func (aService *UserService) getPlans(req *restful.Request,
+	resp *restful.Response) {
+
+	resp.WriteEntity(aService.plans.advertised())
+
+}
@@ -0,0 +1,104 @@
+package ApiServices
+
+import(
+
+	"./userDBHandler"
+
+	"testing"
+
+)
+
+const testPlanMeta string = `[
+	{"Name": "Peek", "Interval": "month", "Price": 0, "Collections": 1,
+		"Features": {"publicCollections": true}},
+	{"Name": "Preordain", "Interval": "month", "Price": 300,
+		"Collections": 4, "StripePlan": "Preordain"},
+	{"Name": "Preordain", "Interval": "year", "Price": 3000,
+		"Collections": 4, "StripePlan": "Preordain Annual"}
+]`
+
+func TestParsePlanCatalog(t *testing.T) {
+
+	catalog, err:= parsePlanCatalog([]byte(testPlanMeta))
+	if err!=nil {
+		t.Fatal("failed to parse plans", err)
+	}
+
+	plans:= catalog.advertised()
+	if len(plans) != 3 || plans[0].Name != userDB.DefaultSubLevel ||
+		!plans[0].Features["publicCollections"] ||
+		plans[2].Price != 3000 || plans[2].Interval != userDB.AnnualInterval {
+		t.Fatal("incorrect advertised plans", plans)
+	}
+
+	caps:= catalog.collectionCaps()
+	if len(caps) != 2 || caps[userDB.DefaultSubLevel] != 1 ||
+		caps["Preordain"] != 4 {
+		t.Fatal("incorrect collection caps", caps)
+	}
+
+	bad:= []string{
+		// Missing the free plan
+		`[{"Name": "Preordain", "Interval": "month", "Collections": 4,
+			"StripePlan": "P"}]`,
+		// Unknown tier
+		`[{"Name": "Peek", "Interval": "month", "Collections": 1},
+			{"Name": "Counterspell", "Interval": "month", "Collections": 4,
+				"StripePlan": "C"}]`,
+		// Unknown interval
+		`[{"Name": "Peek", "Interval": "week", "Collections": 1}]`,
+		// Paid without a stripe plan
+		`[{"Name": "Peek", "Interval": "month", "Collections": 1},
+			{"Name": "Preordain", "Interval": "month", "Collections": 4}]`,
+		// Duplicated
+		`[{"Name": "Peek", "Interval": "month", "Collections": 1},
+			{"Name": "Peek", "Interval": "month", "Collections": 1}]`,
+		// Without any collections
+		`[{"Name": "Peek", "Interval": "month"}]`,
+		// Collections depending on interval
+		`[{"Name": "Peek", "Interval": "month", "Collections": 1},
+			{"Name": "Preordain", "Interval": "month", "Collections": 4,
+				"StripePlan": "P"},
+			{"Name": "Preordain", "Interval": "year", "Collections": 8,
+				"StripePlan": "PA"}]`,
+		`not json`,
+	}
+	for _, raw:= range bad{
+		_, err = parsePlanCatalog([]byte(raw))
+		if err==nil {
+			t.Fatal("accepted invalid plans", raw)
+		}
+	}
+
+}
+
+func TestStripePlan(t *testing.T) {
+
+	catalog, err:= parsePlanCatalog([]byte(testPlanMeta))
+	if err!=nil {
+		t.Fatal("failed to parse plans", err)
+	}
+
+	id, interval, ok:= catalog.stripePlan("Preordain", "")
+	if !ok || id != "Preordain" || interval != userDB.MonthlyInterval {
+		t.Fatal("empty interval not monthly", id, interval, ok)
+	}
+
+	id, interval, ok = catalog.stripePlan("Preordain", userDB.AnnualInterval)
+	if !ok || id != "Preordain Annual" || interval != userDB.AnnualInterval {
+		t.Fatal("incorrect annual plan", id, interval, ok)
+	}
+
+	bad:= []planChoice{
+		{userDB.DefaultSubLevel, userDB.MonthlyInterval},
+		{"Preordain", "week"},
+		{"Sensei's Top", userDB.MonthlyInterval},
+	}
+	for _, choice:= range bad{
+		_, _, ok = catalog.stripePlan(choice.Plan, choice.Interval)
+		if ok {
+			t.Fatal("accepted invalid plan", choice)
+		}
+	}
+
+}
@@ -1,26 +1,119 @@
-// Provides validation for recaptcha 2.0
+// Provides validation for recaptcha 2.0 and scored 3.0 responses
 package recaptcha
 
 import(
 
+	"net"
 	"net/url"
 	"net/http"
 
 	"io/ioutil"
 	"encoding/json"
 
+	"fmt"
+	"strings"
+	"time"
+
 )
 
 const verifyEndpoint string = "https://www.google.com/recaptcha/api/siteverify"
 
+// Used when recaptchaMeta leaves a timeout unset
+const DefaultConnectTimeout time.Duration = 3 * time.Second
+const DefaultReadTimeout time.Duration = 5 * time.Second
+
+// Returned when google refuses a response, or it was issued for
+// another action.
+var ErrInvalidToken error = fmt.Errorf("invalid recaptcha token")
+
+// Returned when a scored response falls below the Validator's MinScore.
+var ErrLowScore error = fmt.Errorf("recaptcha score too low")
+
+// Returned when a response was solved on a hostname outside the
+// Validator's Hostnames, such as a token farmed on another site.
+type HostnameError struct{
+	Hostname string
+}
+
+func (e HostnameError) Error() string {
+	return "recaptcha solved on unexpected hostname " + e.Hostname
+}
+
 // A recaptcha 2.0 validator.
 type Validator struct{
 	priv string
+	endpoint string
+	client *http.Client
+
+	// Scored responses below this are refused, zero accepts any
+	MinScore float64
+	// Where responses may be solved, empty accepts any
+	Hostnames []string
 }
 
 // Builds a recaptcha validator using a provided private key
+//
+// Requests use the default timeouts until SetTimeouts is called.
 func GetValidator(priv string) *Validator {
-	return &Validator{priv}
+	validator:= &Validator{priv: priv, endpoint: verifyEndpoint}
+	validator.SetTimeouts(DefaultConnectTimeout, DefaultReadTimeout)
+	return validator
+}
+
+// Sets the timeouts used when verifying with google.
+//
+// connect bounds dialing and the handshake, read bounds waiting
+// on a response once connected. Zero values fall back to the defaults.
+func (validator *Validator) SetTimeouts(connect, read time.Duration) {
+
+	if connect == 0 {
+		connect = DefaultConnectTimeout
+	}
+	if read == 0 {
+		read = DefaultReadTimeout
+	}
+
+	validator.client = &http.Client{
+		Transport: &http.Transport{
+			Proxy: http.ProxyFromEnvironment,
+			Dial: (&net.Dialer{Timeout: connect}).Dial,
+			TLSHandshakeTimeout: connect,
+			ResponseHeaderTimeout: read,
+		},
+		// Bounds reading the body as well
+		Timeout: connect + read,
+	}
+
+}
+
+// Returned when google could not be reached in time.
+//
+// The response may well be valid so callers should ask the
+// user to retry rather than reject them.
+type TransientError struct{
+	Err error
+}
+
+func (e TransientError) Error() string {
+	return "transient recaptcha failure, " + e.Err.Error()
+}
+
+// Returns whether validation failed in a way that may succeed later
+func IsTransient(err error) bool {
+	_, ok:= err.(TransientError)
+	return ok
+}
+
+// Wraps timeouts as TransientErrors, leaving everything else alone
+func classify(err error) error {
+
+	netErr, ok:= err.(net.Error)
+	if ok && netErr.Timeout() {
+		return TransientError{err}
+	}
+
+	return err
+
 }
 
 // Builds a recaptcha validator from a json file of structure recaptchaMeta
@@ -36,41 +129,114 @@ func GetValidatorFromFile(loc string) (*Validator, error) {
 		return nil, err	
 	}
 
-	return GetValidator(meta.Private), nil
+	validator:= GetValidator(meta.Private)
+	validator.SetTimeouts(time.Duration(meta.ConnectTimeout) * time.Second,
+		time.Duration(meta.ReadTimeout) * time.Second)
+	validator.MinScore = meta.MinScore
+	validator.Hostnames = meta.Hostnames
+
+	return validator, nil
 }
 
 type recaptchaMeta struct{
 	Private string
+
+	// Seconds, left zero for DefaultConnectTimeout and DefaultReadTimeout
+	ConnectTimeout, ReadTimeout int
+
+	// Between 0 and 1, the lowest score accepted from scored responses
+	MinScore float64
+
+	// Hostnames our captchas are served on
+	Hostnames []string
 }
 
-// Returns whether or not a recaptcha response was valid.
+// Returns nil when a recaptcha response is valid for the action.
+//
+// remoteIP is the client's address, passed along to google when set.
+// Responses carrying an action must match the expected one and those
+// carrying a score must meet MinScore. Unscored responses, as 2.0
+// gives, are judged on success alone.
+//
+// Responses solved outside Hostnames are refused with a HostnameError.
 //
-// Defaults to the ip as localhost so we need not tie a domain name to this.
-func (validator *Validator) Validate(response string) (bool, error) {
-	resp, err:= http.PostForm(verifyEndpoint, url.Values{
+// Refused responses are ErrInvalidToken or ErrLowScore, timeouts are
+// returned as a TransientError.
+func (validator *Validator) Validate(response, remoteIP,
+	action string) error {
+
+	form:= url.Values{
 		"secret": {validator.priv},
 		"response": {response},
-		})
+	}
+	if remoteIP != "" {
+		form.Set("remoteip", remoteIP)
+	}
+
+	resp, err:= validator.client.PostForm(validator.endpoint, form)
 	if err!=nil{
-		return false, err
+		return classify(err)
 	}
 
 	defer resp.Body.Close()
 
 	respData, err:= ioutil.ReadAll(resp.Body)
 	if err!=nil {
-		return false, err
+		return classify(err)
 	}
 
 	var result RecaptchaResponse
 	err = json.Unmarshal(respData, &result)
 	if err!=nil {
-		return false, err
+		return err
 	}
 
-	return result.Success, nil
+	return validator.judge(result, action)
+}
+
+// Decides whether a response from google is acceptable for the action.
+func (validator *Validator) judge(result RecaptchaResponse,
+	action string) error {
+
+	if !result.Success {
+		return ErrInvalidToken
+	}
+	if result.Action != "" && result.Action != action {
+		return ErrInvalidToken
+	}
+	if !validator.allowedHostname(result.Hostname) {
+		return HostnameError{result.Hostname}
+	}
+	if result.Score != nil && *result.Score < validator.MinScore {
+		return ErrLowScore
+	}
+
+	return nil
+
+}
+
+// Determines if a response solved on hostname is acceptable
+func (validator *Validator) allowedHostname(hostname string) bool {
+
+	if len(validator.Hostnames) == 0 {
+		return true
+	}
+
+	for _, allowed:= range validator.Hostnames{
+		if strings.EqualFold(allowed, hostname) {
+			return true
+		}
+	}
+
+	return false
+
 }
 
 type RecaptchaResponse struct{
 	Success bool
+	// Only present for scored responses
+	Score *float64
+	Action string
+	// Where the captcha was solved
+	Hostname string
 }
\ No newline at end of file
@@ -0,0 +1,120 @@
+package recaptcha
+
+import(
+
+	"net/http"
+	"net/http/httptest"
+
+	"testing"
+
+	"time"
+
+)
+
+// Google hanging on us should surface as a transient failure well
+// before the slow response would have arrived.
+func TestValidateTimeout(t *testing.T) {
+
+	release:= make(chan struct{})
+	slow:= httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			select{
+			case <-release:
+			case <-time.After(5 * time.Second):
+			}
+			w.Write([]byte(`{"success": true}`))
+		}))
+	defer slow.Close()
+	defer close(release)
+
+	validator:= GetValidator("foo")
+	validator.endpoint = slow.URL
+	validator.SetTimeouts(time.Second, 50 * time.Millisecond)
+
+	start:= time.Now()
+	err:= validator.Validate("bar", "", "signup")
+	if !IsTransient(err) {
+		t.Fatal("timeout not reported as transient", err)
+	}
+	if time.Since(start) > 2 * time.Second {
+		t.Fatal("timeout did not fire promptly")
+	}
+
+}
+
+func TestValidateResponse(t *testing.T) {
+
+	var remoteIP string
+	fast:= httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			remoteIP = r.FormValue("remoteip")
+			w.Write([]byte(`{"success": true}`))
+		}))
+	defer fast.Close()
+
+	validator:= GetValidator("foo")
+	validator.endpoint = fast.URL
+
+	err:= validator.Validate("bar", "127.0.0.1", "signup")
+	if err!=nil {
+		t.Fatal("valid response rejected", err)
+	}
+	if remoteIP != "127.0.0.1" {
+		t.Fatal("remote ip not passed along", remoteIP)
+	}
+
+}
+
+func TestJudgeResponse(t *testing.T) {
+
+	validator:= GetValidator("foo")
+	validator.MinScore = 0.5
+
+	low, high:= 0.1, 0.9
+	cases:= []struct{
+		result RecaptchaResponse
+		err error
+	}{
+		{RecaptchaResponse{Success: false}, ErrInvalidToken},
+		// Unscored responses, as 2.0 gives
+		{RecaptchaResponse{Success: true}, nil},
+		{RecaptchaResponse{Success: true, Score: &high,
+			Action: "signup"}, nil},
+		{RecaptchaResponse{Success: true, Score: &low,
+			Action: "signup"}, ErrLowScore},
+		{RecaptchaResponse{Success: true, Score: &high,
+			Action: "login"}, ErrInvalidToken},
+	}
+	for _, c:= range cases{
+		err:= validator.judge(c.result, "signup")
+		if err != c.err {
+			t.Fatal("incorrect judgement", c.result, err)
+		}
+	}
+
+}
+
+func TestJudgeHostname(t *testing.T) {
+
+	validator:= GetValidator("foo")
+
+	farmed:= RecaptchaResponse{Success: true, Hostname: "farm.example.com"}
+	err:= validator.judge(farmed, "signup")
+	if err!=nil {
+		t.Fatal("hostname checked without an allowlist", err)
+	}
+
+	validator.Hostnames = []string{"preorda.in"}
+	err = validator.judge(farmed, "signup")
+	hostErr, ok:= err.(HostnameError)
+	if !ok || hostErr.Hostname != "farm.example.com" {
+		t.Fatal("farmed token accepted", err)
+	}
+
+	err = validator.judge(RecaptchaResponse{Success: true,
+		Hostname: "Preorda.in"}, "signup")
+	if err!=nil {
+		t.Fatal("allowed hostname rejected", err)
+	}
+
+}
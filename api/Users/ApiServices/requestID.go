@@ -0,0 +1,94 @@
+package ApiServices
+
+import(
+
+	"github.com/emicklei/go-restful"
+
+	"crypto/rand"
+	"encoding/hex"
+
+)
+
+// Carries the ID correlating everything a request caused, read from
+// clients which already have one and returned on every response so
+// clients can report it.
+const requestIDHeader string = "X-Request-ID"
+
+// Where the request ID is kept among the request's attributes
+const requestIDAttribute string = "requestID"
+
+// The longest client provided ID accepted, longer ones are replaced.
+const maxRequestIDLength int = 64
+
+// A restful filter which assigns each request an ID, keeping the one
+// a client provided when it is sane.
+func requestIDFilter(req *restful.Request,
+	resp *restful.Response, chain *restful.FilterChain) {
+
+	id:= req.HeaderParameter(requestIDHeader)
+	if !validRequestID(id) {
+		id = newRequestID()
+	}
+
+	req.SetAttribute(requestIDAttribute, id)
+	resp.AddHeader(requestIDHeader, id)
+
+	chain.ProcessFilter(req, resp)
+
+}
+
+// Returns the ID assigned to a request by requestIDFilter.
+func requestID(req *restful.Request) string {
+
+	id, _:= req.Attribute(requestIDAttribute).(string)
+
+	return id
+
+}
+
+// Returns who and what a request is for logging about it.
+func requestContext(req *restful.Request) logContext {
+	return logContext{
+		RequestID: requestID(req),
+		User: req.PathParameter("userName"),
+	}
+}
+
+// Determines if a client provided ID is safe to log and echo back.
+//
+// Only letters, digits, '-', '_' and '.' are allowed so an ID can't
+// forge log lines or headers.
+func validRequestID(id string) bool {
+
+	if len(id) == 0 || len(id) > maxRequestIDLength {
+		return false
+	}
+
+	for _, r:= range id{
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+		case r == '-', r == '_', r == '.':
+		default:
+			return false
+		}
+	}
+
+	return true
+
+}
+
+// Generates a fresh random request ID.
+//
+// Failing to read randomness leaves the request without an ID rather
+// than failing it.
+func newRequestID() string {
+
+	raw:= make([]byte, 16)
+	_, err:= rand.Read(raw)
+	if err!=nil {
+		return ""
+	}
+
+	return hex.EncodeToString(raw)
+
+}
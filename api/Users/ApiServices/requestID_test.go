@@ -0,0 +1,42 @@
+package ApiServices
+
+import(
+
+	"strings"
+
+	"testing"
+
+)
+
+func TestValidRequestID(t *testing.T) {
+
+	for _, id:= range []string{"abc", "5f2b-41c9_a.1",
+		strings.Repeat("a", maxRequestIDLength)}{
+		if !validRequestID(id) {
+			t.Fatal("sane request ID refused", id)
+		}
+	}
+
+	for _, id:= range []string{"", "has space", "forged\nline",
+		"<script>", strings.Repeat("a", maxRequestIDLength + 1)}{
+		if validRequestID(id) {
+			t.Fatal("unsafe request ID accepted", id)
+		}
+	}
+
+}
+
+// Ensure generated IDs are accepted as if a client sent them and
+// don't repeat.
+func TestNewRequestID(t *testing.T) {
+
+	first:= newRequestID()
+	if !validRequestID(first) {
+		t.Fatal("generated request ID is invalid", first)
+	}
+
+	if first == newRequestID() {
+		t.Fatal("generated request IDs repeat")
+	}
+
+}
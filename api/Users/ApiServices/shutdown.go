@@ -0,0 +1,123 @@
+package ApiServices
+
+import(
+
+	"context"
+
+)
+
+// How many jobs may wait on a background worker and how many
+// workers run them.
+//
+// Once the queue is full further jobs are dropped rather than piling
+// up goroutines behind slow receivers.
+const backgroundQueueSize int = 256
+const backgroundWorkers int = 8
+
+// Runs f in the background, tracked so Shutdown waits for it.
+//
+// Only long lived loops belong here, work a handler leaves running
+// once it responds should be queued with queueBackground.
+func (aService *UserService) goBackground(f func()) {
+
+	aService.background.Add(1)
+	go func() {
+		defer aService.background.Done()
+		f()
+	}()
+
+}
+
+// Starts the fixed pool of workers which run queued jobs.
+func (aService *UserService) startBackground() {
+
+	queue:= make(chan func(), backgroundQueueSize)
+	aService.backgroundQueue = queue
+	for i:= 0; i < backgroundWorkers; i++ {
+		aService.goBackground(func() {
+			for job:= range queue{
+				job()
+			}
+		})
+	}
+
+}
+
+// Queues f to be run by a background worker so it neither holds up
+// the response nor is cut short by a deploy.
+//
+// Returns false and drops f when the queue is full or the service is
+// shutting down.
+func (aService *UserService) queueBackground(f func()) bool {
+
+	aService.queueLock.RLock()
+	defer aService.queueLock.RUnlock()
+
+	// A nil queue is never ready so this also covers shutting down
+	select {
+	case aService.backgroundQueue <- f:
+		return true
+	default:
+		aService.logger.Println("background queue full, dropping job")
+		return false
+	}
+
+}
+
+// Stops accepting queued jobs, workers exit once those already
+// queued have run.
+func (aService *UserService) stopBackground() {
+
+	aService.queueLock.Lock()
+	defer aService.queueLock.Unlock()
+
+	if aService.backgroundQueue != nil {
+		close(aService.backgroundQueue)
+		aService.backgroundQueue = nil
+	}
+
+}
+
+// Waits for background work to finish, or ctx to be done first.
+func (aService *UserService) waitBackground(ctx context.Context) error {
+
+	done:= make(chan struct{})
+	go func() {
+		aService.background.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+}
+
+// Releases everything the service holds once its work is done.
+//
+// Call once the server has stopped accepting requests and those in
+// flight have finished, so nothing fresh is started. Background work
+// such as webhooks, reset mail and any alert run is given until ctx
+// is done, the pools are closed last regardless so the database sees
+// us leave.
+func (aService *UserService) Shutdown(ctx context.Context) error {
+
+	if aService.stopAlerts != nil {
+		close(aService.stopAlerts)
+	}
+	aService.stopBackground()
+
+	err:= aService.waitBackground(ctx)
+	if err!=nil {
+		aService.logger.Println("background work outlived shutdown", err)
+	}
+
+	aService.pricePool.Close()
+	aService.keeper.Close()
+
+	return err
+
+}
@@ -0,0 +1,106 @@
+package ApiServices
+
+import(
+
+	"context"
+	"io/ioutil"
+	"log"
+	"sync/atomic"
+	"time"
+
+	"testing"
+
+)
+
+// Ensure background work is waited upon before shutdown proceeds
+func TestWaitBackground(t *testing.T) {
+
+	var aService UserService
+	finished:= false
+	aService.goBackground(func() {
+		time.Sleep(10 * time.Millisecond)
+		finished = true
+	})
+
+	err:= aService.waitBackground(context.Background())
+	if err!=nil || !finished {
+		t.Fatal("shutdown proceeded before background work finished", err)
+	}
+
+}
+
+// Ensure stuck background work can't hold up shutdown past its deadline
+func TestWaitBackgroundDeadline(t *testing.T) {
+
+	var aService UserService
+	stuck:= make(chan struct{})
+	defer close(stuck)
+	aService.goBackground(func() {
+		<-stuck
+	})
+
+	ctx, cancel:= context.WithTimeout(context.Background(),
+		10 * time.Millisecond)
+	defer cancel()
+
+	err:= aService.waitBackground(ctx)
+	if err != context.DeadlineExceeded {
+		t.Fatal("stuck background work held up shutdown", err)
+	}
+
+}
+
+// Ensure queued jobs run and those queued before shutdown are
+// finished before it proceeds
+func TestQueueBackground(t *testing.T) {
+
+	aService:= UserService{logger: log.New(ioutil.Discard, "", 0)}
+	aService.startBackground()
+
+	var ran int32
+	for i:= 0; i < backgroundWorkers * 2; i++ {
+		if !aService.queueBackground(func() {
+			time.Sleep(time.Millisecond)
+			atomic.AddInt32(&ran, 1)
+		}) {
+			t.Fatal("job dropped with room in the queue")
+		}
+	}
+
+	aService.stopBackground()
+	err:= aService.waitBackground(context.Background())
+	if err!=nil || atomic.LoadInt32(&ran) != int32(backgroundWorkers * 2) {
+		t.Fatal("queued jobs not run before shutdown", ran, err)
+	}
+
+	if aService.queueBackground(func() {}) {
+		t.Fatal("job queued after shutdown")
+	}
+
+}
+
+// Ensure jobs are dropped rather than waiting once the queue is full
+func TestQueueBackgroundFull(t *testing.T) {
+
+	aService:= UserService{logger: log.New(ioutil.Discard, "", 0)}
+	aService.startBackground()
+
+	// Each worker holds at most one stuck job, so one more than
+	// they and the queue can hold must be dropped
+	stuck:= make(chan struct{})
+	dropped:= false
+	for i:= 0; i <= backgroundWorkers + backgroundQueueSize; i++ {
+		if !aService.queueBackground(func() { <-stuck }) {
+			dropped = true
+		}
+	}
+	close(stuck)
+
+	if !dropped {
+		t.Fatal("full queue accepted every job")
+	}
+
+	aService.stopBackground()
+	aService.waitBackground(context.Background())
+
+}
@@ -0,0 +1,163 @@
+package ApiServices
+
+import(
+
+	"./userDBHandler"
+	"./goGetPaid"
+	"./mailer"
+
+	"github.com/emicklei/go-restful"
+	"github.com/jackc/pgx"
+
+	"net/http"
+
+	"io"
+	"io/ioutil"
+
+	"time"
+
+)
+
+// The header stripe signs webhooks with
+const stripeSignatureHeader string = "Stripe-Signature"
+
+// The largest webhook body read, stripe events are far smaller
+const maxStripeWebhookSize int64 = 1 << 16
+
+// Stripe events acted upon, every other type is acknowledged and
+// ignored.
+const (
+	stripePaymentFailed string = "invoice.payment_failed"
+	stripeSubDeleted string = "customer.subscription.deleted"
+)
+
+// Receives the events stripe sends as subscriptions change on its end,
+// such as when payment fails or a subscription is cancelled for it.
+//
+// Each event is acted upon at most once, redeliveries and replays
+// are acknowledged without effect. Failing to act on an event
+// responds with an error so stripe delivers it again.
+func (aService *UserService) stripeWebhook(req *restful.Request,
+	resp *restful.Response) {
+
+	payload, err:= ioutil.ReadAll(io.LimitReader(req.Request.Body,
+		maxStripeWebhookSize))
+	if err!=nil {
+		resp.WriteErrorString(http.StatusBadRequest, BodyReadFailure)
+		return
+	}
+
+	event, err:= aService.merch.ParseWebhook(payload,
+		req.HeaderParameter(stripeSignatureHeader), time.Now())
+	if err!=nil {
+		logAt(aService.logger, levelWarn, requestContext(req),
+			"refused stripe webhook, ", err)
+		resp.WriteErrorString(http.StatusBadRequest, BadWebhookSignature)
+		return
+	}
+
+	if event.Type != stripePaymentFailed && event.Type != stripeSubDeleted {
+		resp.WriteEntity(true)
+		return
+	}
+
+	fresh, err:= userDB.RecordStripeEvent(aService.db(), event.ID)
+	if err!=nil {
+		logAt(aService.logger, levelError, requestContext(req), err)
+		resp.WriteErrorString(http.StatusInternalServerError, DBWriteFailure)
+		return
+	}
+	if !fresh {
+		resp.WriteEntity(true)
+		return
+	}
+
+	err = aService.handleStripeEvent(event)
+	if err!=nil {
+		logAt(aService.logger, levelError, requestContext(req),
+			"failed to handle stripe event", event.ID, event.Type, err)
+
+		// Let stripe's redelivery be handled
+		forgetErr:= userDB.ForgetStripeEvent(aService.db(), event.ID)
+		if forgetErr!=nil {
+			logAt(aService.logger, levelError, requestContext(req), forgetErr)
+		}
+
+		resp.WriteErrorString(http.StatusInternalServerError, DBWriteFailure)
+		return
+	}
+
+	resp.WriteEntity(true)
+
+}
+
+// Brings our record of a subscription in line with an event from stripe.
+func (aService *UserService) handleStripeEvent(event getPaid.WebhookEvent) error {
+
+	// Every user without a customer shares DefaultID
+	if event.CustomerID == "" || event.CustomerID == userDB.DefaultID {
+		return nil
+	}
+
+	sub, err:= userDB.GetSubByCustomer(aService.db(), event.CustomerID)
+	if err == pgx.ErrNoRows {
+		// Nothing of ours to bring in line
+		aService.logger.Println("stripe event for unknown customer",
+			event.ID, event.CustomerID)
+		return nil
+	}
+	if err!=nil {
+		return err
+	}
+
+	// Events about a subscription since replaced no longer apply
+	if event.SubID != sub.SubID {
+		return nil
+	}
+
+	switch event.Type {
+	case stripeSubDeleted:
+		if sub.Plan == userDB.DefaultSubLevel {
+			return nil
+		}
+
+		// Set dummy sub ID but hold onto that customerID
+		return userDB.ModSub(aService.db(), sub.Name, userDB.DefaultSubLevel,
+			userDB.MonthlyInterval,
+			sub.CustomerID, userDB.DefaultID, nil)
+
+	case stripePaymentFailed:
+		aService.sendPaymentFailed(sub)
+	}
+
+	return nil
+
+}
+
+// Lets a user know their payment failed so they can update their
+// payment method before stripe gives up on it.
+func (aService *UserService) sendPaymentFailed(sub *userDB.Subscription) {
+
+	u, err:= userDB.GetUser(aService.db(), sub.Name)
+	if err!=nil {
+		aService.logger.Println("failed to get user for payment failure",
+			sub.Name, err)
+		return
+	}
+	if u.Email == "" {
+		// Deleted users have nowhere to be mailed
+		return
+	}
+
+	contents:= subEmailContents{
+		Name: sub.Name,
+		Plan: sub.Plan,
+	}
+	targetAddress:= mailer.FormatAddress(sub.Name, u.Email)
+	err = aService.sendMail("paymentFailed", contents,
+		targetAddress, "Payment failed - Preorda.in")
+	if err!=nil {
+		aService.logger.Println("failed to send email", err)
+	}
+
+}
@@ -0,0 +1,118 @@
+package ApiServices
+
+import(
+
+	"./userDBHandler"
+
+	"os"
+	"runtime"
+	"strconv"
+	"sync"
+
+)
+
+// How many workers validate a single large trade, as specified by
+// the USERS_VALIDATION_CONCURRENCY environment variable.
+//
+// Defaults to the number of CPUs available.
+const validationConcurrencyEnv string = "USERS_VALIDATION_CONCURRENCY"
+
+// Trades smaller than this are validated serially, spinning up
+// workers costs more than it saves.
+const parallelValidationThreshold int = 256
+
+// Returns how many workers validate a trade.
+func validationWorkerCount() int {
+
+	workers, err:= strconv.Atoi(os.Getenv(validationConcurrencyEnv))
+	if err!=nil || workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+
+	return workers
+
+}
+
+// Ensures a single card is a valid Magic card inside a set it was
+// printed in, returning it with its name made canonical and its
+// comment cleaned.
+//
+// Returns the message describing why it is invalid otherwise.
+//
+// Only reads from maps so it is safe to run concurrently.
+func validateTradeCard(maps *cardMaps,
+	aCard userDB.Card) (userDB.Card, string) {
+
+	if !validEncoding(aCard.Name, aCard.Set,
+		aCard.Quality, aCard.Lang, aCard.Comment) {
+		return aCard, BadEncoding
+	}
+
+	name, validCard:= maps.canonicalCardName(aCard.Name)
+	if !validCard {
+		return aCard, BadTradeContents
+	}
+	aCard.Name = name
+
+	if !maps.knownCard(name, aCard.Set) {
+		return aCard, BadTradeContents
+	}
+
+	// Comments may be visible to the public, clean them before storing
+	comment, err:= sanitizeUserText(aCard.Comment, commentMaxLength)
+	if err!=nil {
+		return aCard, BadUserText
+	}
+	aCard.Comment = comment
+
+	return aCard, ""
+
+}
+
+// Validates every card in a trade across up to workers goroutines.
+//
+// Returns the cleaned trade in its original order or, when any card
+// is invalid, the message for the earliest invalid card so the
+// result never depends on scheduling.
+//
+// Every card is checked against the same card maps, even should they
+// be reloaded part way through.
+func validateTrade(trade []userDB.Card, workers int) ([]userDB.Card, string) {
+
+	maps:= loadedCards()
+
+	cleaned:= make([]userDB.Card, len(trade))
+	problems:= make([]string, len(trade))
+
+	if workers <= 1 || len(trade) < parallelValidationThreshold {
+		for i, aCard:= range trade{
+			cleaned[i], problems[i] = validateTradeCard(maps, aCard)
+			if problems[i] != "" {
+				return nil, problems[i]
+			}
+		}
+		return cleaned, ""
+	}
+
+	// Each worker strides over the trade, writing only its own indices
+	var wg sync.WaitGroup
+	for w:= 0; w < workers; w++ {
+		wg.Add(1)
+		go func(start int) {
+			defer wg.Done()
+			for i:= start; i < len(trade); i+= workers {
+				cleaned[i], problems[i] = validateTradeCard(maps, trade[i])
+			}
+		}(w)
+	}
+	wg.Wait()
+
+	for _, problem:= range problems{
+		if problem != "" {
+			return nil, problem
+		}
+	}
+
+	return cleaned, ""
+
+}
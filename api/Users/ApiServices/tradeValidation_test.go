@@ -0,0 +1,105 @@
+package ApiServices
+
+import(
+
+	"./userDBHandler"
+
+	"fmt"
+
+	"testing"
+
+)
+
+// Populates the card maps with count cards each printed in a
+// single set, restoring them afterwards.
+func validationFixture(count int) ([]userDB.Card, func()) {
+
+	maps:= emptyCardMaps()
+
+	trade:= make([]userDB.Card, count)
+	for i:= range trade{
+		name:= fmt.Sprintf("Card %d", i)
+		maps.cards[name] = true
+		maps.cardsToSets[name] = map[string]bool{"Avacyn Restored": true}
+
+		trade[i] = userDB.Card{Name: name, Set: "Avacyn Restored",
+			Comment: fmt.Sprintf("  comment %d ", i), Quantity: 1}
+	}
+	maps.normalizedCards = populateNormalizedCards(maps.cards)
+
+	previous:= swapCardMaps(maps)
+
+	return trade, func() {
+		swapCardMaps(previous)
+	}
+
+}
+
+func TestValidateTradeParallel(t *testing.T) {
+
+	trade, reset:= validationFixture(parallelValidationThreshold * 4)
+	defer reset()
+
+	serial, serialProblem:= validateTrade(trade, 1)
+	parallel, parallelProblem:= validateTrade(trade, 8)
+	if serialProblem != "" || parallelProblem != "" {
+		t.Fatal("valid trade rejected", serialProblem, parallelProblem)
+	}
+	if len(serial) != len(parallel) {
+		t.Fatal("parallel validation changed trade length")
+	}
+	for i:= range serial{
+		if serial[i] != parallel[i] {
+			t.Fatal("parallel validation differs from serial", i,
+				serial[i], parallel[i])
+		}
+	}
+	if parallel[0].Comment != "comment 0" {
+		t.Fatal("comments not cleaned", parallel[0])
+	}
+
+}
+
+// The earliest invalid card decides the message regardless of
+// which worker found it first.
+func TestValidateTradeParallelProblem(t *testing.T) {
+
+	trade, reset:= validationFixture(parallelValidationThreshold * 4)
+	defer reset()
+
+	trade[10].Set = "Not A Set"
+	trade[len(trade) - 1].Comment = "<b>markup</b>"
+
+	for i:= 0; i < 20; i++ {
+		_, problem:= validateTrade(trade, 8)
+		if problem != BadTradeContents {
+			t.Fatal("incorrect problem reported", problem)
+		}
+	}
+
+	_, problem:= validateTrade(trade, 1)
+	if problem != BadTradeContents {
+		t.Fatal("serial validation reported a different problem", problem)
+	}
+
+}
+
+func benchmarkValidateTrade(b *testing.B, workers int) {
+
+	trade, reset:= validationFixture(10000)
+	defer reset()
+
+	b.ResetTimer()
+	for i:= 0; i < b.N; i++ {
+		validateTrade(trade, workers)
+	}
+
+}
+
+func BenchmarkValidateTradeSerial(b *testing.B) {
+	benchmarkValidateTrade(b, 1)
+}
+
+func BenchmarkValidateTradeParallel(b *testing.B) {
+	benchmarkValidateTrade(b, validationWorkerCount())
+}
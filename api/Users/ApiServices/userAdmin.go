@@ -0,0 +1,298 @@
+package ApiServices
+
+import(
+
+	"./userDBHandler"
+	"./mailer"
+
+	"github.com/emicklei/go-restful"
+
+	"net/http"
+
+	"crypto/subtle"
+
+	"io/ioutil"
+	"encoding/json"
+	"fmt"
+
+)
+
+// The most users that can be imported in a single request.
+const maxBulkUsers int = 500
+
+// Metadata for administrative access, stored as json on disk.
+type adminMeta struct{
+	Key string
+}
+
+// The outcome of importing a single user, suitable for sending over
+// the wire.
+type BulkAddUserResult struct{
+	Name string
+	Success bool
+	Error string
+}
+
+// The contents of a welcome email formatted to match the template.
+type welcomeEmailContents struct{
+	Name, ResetCode string
+}
+
+// Readies the key required for administrative endpoints.
+func (aService *UserService) setupAdmin(loc string) {
+
+	metaRaw, err:= ioutil.ReadFile(loc)
+	if err!=nil {
+		aService.logger.Fatalln("Failed to read admin meta", err)
+	}
+
+	var meta adminMeta
+	err = json.Unmarshal(metaRaw, &meta)
+	if err!=nil {
+		aService.logger.Fatalln("Failed to parse admin meta", err)
+	}
+
+	if meta.Key == "" {
+		aService.logger.Fatalln("Admin meta has no key")
+	}
+
+	aService.adminKey = []byte(meta.Key)
+	aService.cursorKey = deriveCursorKey(aService.adminKey)
+
+}
+
+// Returns whether or not the provided key grants administrative access.
+func (aService *UserService) adminAuth(key string) bool {
+
+	if len(aService.adminKey) == 0 {
+		return false
+	}
+
+	return subtle.ConstantTimeCompare(aService.adminKey, []byte(key)) == 1
+
+}
+
+// Creates many users at once for migrations.
+//
+// This is a trusted path so no captcha is required. Each user
+// receives a welcome email with a code to set their password.
+func (aService *UserService) bulkAddUsers(req *restful.Request,
+	resp *restful.Response) {
+
+	var bulkContainer BulkAddBody
+	err:= req.ReadEntity(&bulkContainer)
+	if err!=nil {
+		resp.WriteErrorString(http.StatusBadRequest, BodyReadFailure)
+		return
+	}
+
+	if !aService.adminAuth(bulkContainer.AdminKey) {
+		resp.WriteErrorString(http.StatusUnauthorized, BadCredentials)
+		return
+	}
+
+	if len(bulkContainer.Users) > maxBulkUsers {
+		resp.WriteErrorString(http.StatusBadRequest, BadBulkSize)
+		return
+	}
+
+	// Users with unacceptable passwords or emails are reported without
+	// ever reaching the database.
+	results:= make([]BulkAddUserResult, len(bulkContainer.Users))
+	var valid []userDB.NewUserSpec
+	var validIndices []int
+	for i, spec:= range bulkContainer.Users{
+		results[i].Name = spec.Name
+
+		if !validEncoding(spec.Name, spec.Email) {
+			results[i].Error = BadEncoding
+			continue
+		}
+
+		email, err:= userDB.NormalizeEmail(spec.Email)
+		if err!=nil {
+			results[i].Error = emailFailure(err)
+			continue
+		}
+		spec.Email = email
+
+		if spec.Password != "" {
+			if feedback, ok:= passwordFeedback(spec.Password); !ok {
+				results[i].Error = feedback
+				continue
+			}
+		}
+
+		valid = append(valid, spec)
+		validIndices = append(validIndices, i)
+	}
+
+	if len(valid) > 0 {
+
+		added, err:= userDB.BulkAddUsers(aService.db(), valid)
+		if err!=nil {
+			resp.WriteErrorString(http.StatusBadRequest, SignupFailure)
+			return
+		}
+
+		for j, result:= range added{
+			i:= validIndices[j]
+
+			if result.Err != nil {
+				logAt(aService.logger, levelError, requestContext(req),
+					"bulk add failed for", result.Name, result.Err)
+				results[i].Error = SignupFailure
+				continue
+			}
+			results[i].Success = true
+
+			err = aService.sendWelcome(valid[j], result.ResetCode)
+			if err!=nil {
+				logAt(aService.logger, levelError, requestContext(req),
+					"failed to send welcome to", result.Name, err)
+			}
+		}
+
+	}
+
+	resp.WriteEntity(results)
+
+}
+
+// Mails a freshly imported user a code they can set their password with.
+//
+// Falls back to the reset template when no welcome template is available.
+func (aService *UserService) sendWelcome(spec userDB.NewUserSpec,
+	resetCode string) error {
+
+	templateId:= "welcome"
+	if !aService.mailer.HasTemplate(templateId) {
+		templateId = "reset"
+	}
+	if !aService.mailer.HasTemplate(templateId) {
+		return fmt.Errorf("no template available for welcome email")
+	}
+
+	contents:= welcomeEmailContents{
+		Name: spec.Name,
+		ResetCode: resetCode,
+	}
+	targetAddress:= mailer.FormatAddress(spec.Name, spec.Email)
+
+	return aService.sendMail(templateId, contents,
+		targetAddress, "Welcome - Preorda.in")
+
+}
+
+// Flags a user to have their password rehashed with a fresh nonce
+// when they next login, for use after a suspected compromise.
+func (aService *UserService) adminForceRehash(req *restful.Request,
+	resp *restful.Response) {
+
+	var rehashContainer ForceRehashBody
+	err:= req.ReadEntity(&rehashContainer)
+	if err!=nil {
+		resp.WriteErrorString(http.StatusBadRequest, BodyReadFailure)
+		return
+	}
+
+	if !aService.adminAuth(rehashContainer.AdminKey) {
+		resp.WriteErrorString(http.StatusUnauthorized, BadCredentials)
+		return
+	}
+
+	_, err = userDB.GetUser(aService.db(), rehashContainer.UserName)
+	if err!=nil {
+		resp.WriteErrorString(dbFailure(err, BadUserName))
+		return
+	}
+
+	err = userDB.SetForceRehash(aService.db(), rehashContainer.UserName)
+	if err!=nil {
+		logAt(aService.logger, levelError, requestContext(req), err)
+		resp.WriteErrorString(http.StatusInternalServerError, DBWriteFailure)
+		return
+	}
+
+	resp.WriteEntity(true)
+
+}
+
+// Lets a user's next plan change skip the cooldown, for when support
+// needs to correct a plan sooner.
+func (aService *UserService) adminWaivePlanCooldown(req *restful.Request,
+	resp *restful.Response) {
+
+	var waiverContainer PlanCooldownWaiverBody
+	err:= req.ReadEntity(&waiverContainer)
+	if err!=nil {
+		resp.WriteErrorString(http.StatusBadRequest, BodyReadFailure)
+		return
+	}
+
+	if !aService.adminAuth(waiverContainer.AdminKey) {
+		resp.WriteErrorString(http.StatusUnauthorized, BadCredentials)
+		return
+	}
+
+	_, err = userDB.GetUser(aService.db(), waiverContainer.UserName)
+	if err!=nil {
+		resp.WriteErrorString(dbFailure(err, BadUserName))
+		return
+	}
+
+	err = userDB.WaivePlanCooldown(aService.db(), waiverContainer.UserName)
+	if err!=nil {
+		logAt(aService.logger, levelError, requestContext(req), err)
+		resp.WriteErrorString(http.StatusInternalServerError, DBWriteFailure)
+		return
+	}
+
+	resp.WriteEntity(true)
+
+}
+
+// The size of the card maps swapped in by a reload.
+type CardReloadResult struct{
+	Sets, Cards int
+}
+
+// Reloads the card maps from disk, so a new set's cards may be traded
+// without restarting the service.
+//
+// Trades being validated keep the maps they started with, those kept
+// when the reload fails.
+func (aService *UserService) adminReloadCards(req *restful.Request,
+	resp *restful.Response) {
+
+	var reloadContainer CardReloadBody
+	err:= req.ReadEntity(&reloadContainer)
+	if err!=nil {
+		resp.WriteErrorString(http.StatusBadRequest, BodyReadFailure)
+		return
+	}
+
+	if !aService.adminAuth(reloadContainer.AdminKey) {
+		resp.WriteErrorString(http.StatusUnauthorized, BadCredentials)
+		return
+	}
+
+	err = populateCardMaps()
+	if err!=nil {
+		logAt(aService.logger, levelError, requestContext(req),
+			"failed to reload card maps", err)
+		resp.WriteErrorString(http.StatusInternalServerError, CardReloadFailure)
+		return
+	}
+
+	maps:= loadedCards()
+	result:= CardReloadResult{
+		Sets: len(maps.sets),
+		Cards: len(maps.cards),
+	}
+	logAt(aService.logger, levelInfo, requestContext(req),
+		"reloaded card maps", result.Sets, "sets", result.Cards, "cards")
+
+	resp.WriteEntity(result)
+
+}
@@ -9,6 +9,7 @@ import(
 	"net/http"
 
 	"fmt"
+	"sort"
 
 )
 
@@ -16,41 +17,110 @@ import(
 func (aService *UserService) getCollection(req *restful.Request,
 	resp *restful.Response) {
 
-	userName, sessionKey, err:= getUserNameAndSessionKey(req)
+	userName:= req.PathParameter("userName")
+	collectionName:= req.PathParameter("collectionName")
+
+	var getContainer CollectionGetBody
+	err:= req.ReadEntity(&getContainer)
 	if err!=nil {
 		resp.WriteErrorString(http.StatusBadRequest, BodyReadFailure)
 		return
 	}
-	collectionName:= req.PathParameter("collectionName")
+	sessionKey:= getContainer.SessionKey
 	
 	if sessionKey == nil {
 		resp.WriteErrorString(http.StatusBadRequest, BadCredentials)
 		return
 	}
 
-	history, err:= userDB.GetCollectionHistory(aService.pool,
+	// Authenticate once and ensure the collection exists
+	meta, err:= userDB.GetCollectionMeta(aService.db(),
 		sessionKey, userName, collectionName)
 	if err!=nil {
-		resp.WriteErrorString(http.StatusBadRequest, BadCredentials)
+		status, message:= collectionFailure(err, false)
+		resp.WriteErrorString(status, message)
 		return
 	}
 
-	current, err:= userDB.GetCollectionContents(aService.pool,
-		sessionKey, userName, collectionName)
+	preferred, _:= withCollectionDefaults("", "", meta)
+	prefs, ok:= aService.requestPrefs(req, userName, preferred)
+	if !ok {
+		resp.WriteErrorString(http.StatusBadRequest, BadPreferences)
+		return
+	}
+
+	currency, ok:= conversionCurrency(getContainer.Currency,
+		prefs.Currency, conversionRates)
+	if !ok {
+		resp.WriteErrorString(http.StatusBadRequest, BadPreferences)
+		return
+	}
+
+	history, err:= userDB.GetCollectionHistory(aService.db(),
+		nil, userName, collectionName)
 	if err!=nil {
-		resp.WriteErrorString(http.StatusBadRequest, BadCredentials)
+		resp.WriteErrorString(http.StatusInternalServerError, DBfailure)
 		return
 	}
 
-	aColl:= CollectionContents{
-		Current: current,
-		Historical: history,
+	current, err:= userDB.GetCollectionContents(aService.db(),
+		nil, userName, collectionName)
+	if err!=nil {
+		resp.WriteErrorString(http.StatusInternalServerError, DBfailure)
+		return
 	}
 
+	aColl:= ValuedCollectionContents{
+		CollectionContents: CollectionContents{
+			Current: current,
+			Historical: history,
+			Tags: meta.Tags,
+		},
+		Version: meta.Version,
+		Currency: currency,
+	}
+
+	aColl.TotalValue, aColl.Unpriced = totalValue(current,
+		aService.marketPrices(current, defaultCurrency))
+	aColl.ConvertedValue = aService.convertedValue(current,
+		aColl.TotalValue, currency, conversionRates)
+
+	prefs.Currency = currency
+	aColl.FormattedValue = formatCents(aColl.ConvertedValue, prefs)
+
 	resp.WriteEntity(aColl)
 
 }
 
+// Determines how a failure to acquire a collection is reported.
+//
+// Authenticated owners are told whether a collection is missing or
+// they are forbidden from it. Public callers receive the same response
+// either way so collections cannot be enumerated.
+func collectionFailure(err error, public bool) (int, string) {
+
+	// Missing and private collections must look identical to the
+	// public, any other failure hides nothing so is reported as one
+	if public {
+		if err == userDB.ErrNoSuchCollection || err == userDB.ErrBadSession {
+			return http.StatusBadRequest, BadCredentials
+		}
+		return dbFailure(err, BadCredentials)
+	}
+
+	switch err{
+	case userDB.ErrNoSuchCollection:
+		return http.StatusNotFound, NoSuchCollection
+	case userDB.ErrBadSession:
+		return http.StatusUnauthorized, BadCredentials
+	case userDB.ErrCollectionConflict:
+		return http.StatusConflict, CollectionConflict
+	}
+
+	return dbFailure(err, NoSuchCollection)
+
+}
+
 // Acquires a collection if and only if it is publicly available to view.
 func (aService *UserService) getCollectionPublic(req *restful.Request,
 	resp *restful.Response) {
@@ -58,45 +128,180 @@ func (aService *UserService) getCollectionPublic(req *restful.Request,
 	userName:= req.PathParameter("userName")
 	collectionName:= req.PathParameter("collectionName")
 	
-	meta, err:= userDB.GetCollectionMeta(aService.pool,
+	// Missing and private collections must look identical to the public
+	meta, err:= userDB.GetCollectionMeta(aService.db(),
 		nil, userName, collectionName)
 	if err!=nil {
-		resp.WriteErrorString(http.StatusBadRequest, BadCredentials)
+		status, message:= collectionFailure(err, true)
+		resp.WriteErrorString(status, message)
 		return
 	}
 
-	fmt.Println(meta)
-
 	if meta.Privacy == "Private" {
-		resp.WriteErrorString(http.StatusBadRequest, BadCredentials)
+		status, message:= collectionFailure(userDB.ErrBadSession, true)
+		resp.WriteErrorString(status, message)
 		return	
 	}
 
 	var history []userDB.Card
 	if meta.Privacy == "History" {
-		history, err = userDB.GetCollectionHistory(aService.pool,
+		history, err = userDB.GetCollectionHistory(aService.db(),
 		nil, userName, collectionName)
 		if err!=nil {
-			resp.WriteErrorString(http.StatusBadRequest, BadCredentials)
+			status, message:= collectionFailure(err, true)
+			resp.WriteErrorString(status, message)
 			return
 		}	
 	}
 
-	fmt.Println(history)
-
-	current, err:= userDB.GetCollectionContents(aService.pool,
+	current, err:= userDB.GetCollectionContents(aService.db(),
 		nil, userName, collectionName)
+	if err!=nil {
+		status, message:= collectionFailure(err, true)
+		resp.WriteErrorString(status, message)
+		return
+	}
+
+	aColl, err:= publicView(meta, current, history)
 	if err!=nil {
 		resp.WriteErrorString(http.StatusBadRequest, BadCredentials)
 		return
 	}
 
-	aColl:= CollectionContents{
+	resp.WriteEntity(aColl)
+
+}
+
+// Restricts the contents of a collection to what its permission bits
+// allow the public to see.
+//
+// History is only included for 'History' collections and comments are
+// only included when the collection has public comments enabled.
+func publicView(meta *userDB.Collection,
+	current, history []userDB.Card) (CollectionContents, error) {
+
+	if meta.Privacy == "Private" {
+		return CollectionContents{}, fmt.Errorf("collection is private")
+	}
+
+	if meta.Privacy != "History" {
+		history = nil
+	}
+
+	if !meta.PublicComments {
+		current = withoutComments(current)
+		history = withoutComments(history)
+	}
+
+	return CollectionContents{
 		Current: current,
 		Historical: history,
+		Tags: meta.Tags,
+	}, nil
+
+}
+
+// Returns a copy of the provided cards with their comments removed.
+func withoutComments(cards []userDB.Card) []userDB.Card {
+
+	if cards == nil {
+		return nil
 	}
 
-	resp.WriteEntity(aColl)
+	stripped:= make([]userDB.Card, len(cards))
+	for i, aCard:= range cards{
+		aCard.Comment = ""
+		stripped[i] = aCard
+	}
+
+	return stripped
+
+}
+
+// Reduces the trades of a collection into the net quantity held of
+// each card, keyed by its name and attributes.
+//
+// Positive quantities are cards traded in and negative are cards traded
+// out. Cards with nothing held are omitted. The result is sorted by name,
+// set, quality, then language.
+func netQuantities(trades []userDB.Card) []CardQuantity {
+
+	net:= make(map[CardQuantity]int32)
+	for _, aCard:= range trades{
+		key:= CardQuantity{
+			Name: aCard.Name,
+			Set: aCard.Set,
+			Quality: aCard.Quality,
+			Lang: aCard.Lang,
+		}
+		net[key]+= aCard.Quantity
+	}
+
+	held:= make(byAttributes, 0, len(net))
+	for key, quantity:= range net{
+		if quantity <= 0 {
+			continue
+		}
+		key.Quantity = quantity
+		held = append(held, key)
+	}
+
+	sort.Sort(held)
+
+	return held
+
+}
+
+// Sorts card quantities by name, set, quality, then language.
+type byAttributes []CardQuantity
+
+func (q byAttributes) Len() int { return len(q) }
+func (q byAttributes) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+func (q byAttributes) Less(i, j int) bool {
+	if q[i].Name != q[j].Name {
+		return q[i].Name < q[j].Name
+	}
+	if q[i].Set != q[j].Set {
+		return q[i].Set < q[j].Set
+	}
+	if q[i].Quality != q[j].Quality {
+		return q[i].Quality < q[j].Quality
+	}
+	return q[i].Lang < q[j].Lang
+}
+
+// Acquires the net holdings of a public collection as derived
+// from every trade it has seen.
+func (aService *UserService) getQuantities(req *restful.Request,
+	resp *restful.Response) {
+
+	userName:= req.PathParameter("userName")
+	collectionName:= req.PathParameter("collectionName")
+
+	meta, err:= userDB.GetCollectionMeta(aService.db(),
+		nil, userName, collectionName)
+	if err!=nil {
+		status, message:= collectionFailure(err, true)
+		resp.WriteErrorString(status, message)
+		return
+	}
+
+	// Holdings reveal no more than the contents do so
+	// the history is only used internally.
+	if meta.Privacy == "Private" {
+		resp.WriteErrorString(http.StatusBadRequest, BadCredentials)
+		return
+	}
+
+	trades, err:= userDB.GetCollectionHistory(aService.db(),
+		nil, userName, collectionName)
+	if err!=nil {
+		status, message:= collectionFailure(err, true)
+		resp.WriteErrorString(status, message)
+		return
+	}
+
+	resp.WriteEntity(netQuantities(trades))
 
 }
 
@@ -124,30 +329,326 @@ func (aService *UserService) addTrade(req *restful.Request,
 
 	// Ensure we have received a trade consisting of valid Magic cards
 	// inside their specific sets
-	for _, aCard:= range tradeContainer.Trade{
-		validSets, validCard:= cardsToSets[aCard.Name]
-		if !validCard {
-			resp.WriteErrorString(http.StatusBadRequest, BadTradeContents)
-			return
-		}
-		_, validSet:= validSets[aCard.Set]
-		if !validSet {
-			resp.WriteErrorString(http.StatusBadRequest, BadTradeContents)
-			return
-		}
-
+	trade, problem:= validateTrade(tradeContainer.Trade,
+		aService.validationWorkers)
+	if problem != "" {
+		resp.WriteErrorString(http.StatusBadRequest, problem)
+		return
 	}
 
-	err = userDB.AddCards(aService.pool,
+	err = userDB.AddCards(aService.db(),
 		tradeContainer.SessionKey,
 		userName, collectionName,
-		tradeContainer.Trade)
+		trade, tradeContainer.Version)
+	if badCard, ok:= err.(userDB.BadCardError); ok {
+		resp.WriteErrorString(http.StatusBadRequest,
+			BadTradeContents + ": " + badCard.Name)
+		return
+	}
+	if err == userDB.ErrCollectionLocked {
+		resp.WriteErrorString(http.StatusConflict, CollectionLocked)
+		return
+	}
+	if err == userDB.ErrCollectionConflict {
+		resp.WriteErrorString(http.StatusConflict, CollectionConflict)
+		return
+	}
+	if err == userDB.ErrCollectionOverLimit {
+		resp.WriteErrorString(http.StatusForbidden, CollectionOverLimit)
+		return
+	}
 	if err!=nil {
-		aService.logger.Println(err)
+		logAt(aService.logger, levelError, requestContext(req), err)
 		resp.WriteErrorString(http.StatusBadRequest, BadCredentials)
 		return
 	}
 
+	// Receivers are notified without holding up the response
+	aService.queueBackground(func() {
+		aService.notifyWebhooks(userName, collectionName, "trade")
+	})
+
 	resp.WriteEntity(true)
 
-}
\ No newline at end of file
+}
+
+// The most trades a single bulk add may carry
+const maxBulkTrades int = 100
+
+// Adds many trades to a collection at once, such as when importing.
+//
+// Every trade is validated before any are added. When some fail the
+// failures are returned and nothing is added, the client can fix
+// just those and send the lot again. An empty list means every
+// trade was added.
+func (aService *UserService) addTrades(req *restful.Request,
+	resp *restful.Response) {
+
+	userName:= req.PathParameter("userName")
+	collectionName:= req.PathParameter("collectionName")
+
+	var bulkContainer TradeBulkAddBody
+	err:= req.ReadEntity(&bulkContainer)
+	if err!=nil {
+		resp.WriteErrorString(http.StatusBadRequest, BodyReadFailure)
+		return
+	}
+
+	if bulkContainer.SessionKey == nil {
+		resp.WriteErrorString(http.StatusBadRequest, BadCredentials)
+		return
+	}
+
+	if len(bulkContainer.Trades) > maxBulkTrades {
+		resp.WriteErrorString(http.StatusBadRequest, BadBulkTradeSize)
+		return
+	}
+
+	trades:= make([][]userDB.Card, len(bulkContainer.Trades))
+	failures:= make([]BulkTradeFailure, 0)
+	for i, trade:= range bulkContainer.Trades{
+		cleaned, problem:= validateTrade(trade, aService.validationWorkers)
+		if problem != "" {
+			failures = append(failures, BulkTradeFailure{i, problem})
+			continue
+		}
+		trades[i] = cleaned
+	}
+
+	if len(failures) > 0 {
+		resp.WriteEntity(failures)
+		return
+	}
+
+	err = userDB.AddTrades(aService.db(),
+		bulkContainer.SessionKey,
+		userName, collectionName,
+		trades, bulkContainer.Version)
+	if badCard, ok:= err.(userDB.BadCardError); ok {
+		resp.WriteErrorString(http.StatusBadRequest,
+			BadTradeContents + ": " + badCard.Name)
+		return
+	}
+	if err == userDB.ErrCollectionLocked {
+		resp.WriteErrorString(http.StatusConflict, CollectionLocked)
+		return
+	}
+	if err == userDB.ErrCollectionConflict {
+		resp.WriteErrorString(http.StatusConflict, CollectionConflict)
+		return
+	}
+	if err == userDB.ErrCollectionOverLimit {
+		resp.WriteErrorString(http.StatusForbidden, CollectionOverLimit)
+		return
+	}
+	if err!=nil {
+		logAt(aService.logger, levelError, requestContext(req), err)
+		resp.WriteErrorString(http.StatusBadRequest, BadCredentials)
+		return
+	}
+
+	// Receivers are notified without holding up the response
+	aService.queueBackground(func() {
+		aService.notifyWebhooks(userName, collectionName, "trade")
+	})
+
+	resp.WriteEntity(failures)
+
+}
+
+func (aService *UserService) copyTrade(req *restful.Request,
+	resp *restful.Response) {
+
+	userName:= req.PathParameter("userName")
+	collectionName:= req.PathParameter("collectionName")
+	tradeID:= req.PathParameter("tradeID")
+
+	var copyContainer TradeCopyBody
+	err:= req.ReadEntity(&copyContainer)
+	if err!=nil {
+		resp.WriteErrorString(http.StatusBadRequest, BodyReadFailure)
+		return
+	}
+
+	if copyContainer.SessionKey == nil {
+		resp.WriteErrorString(http.StatusBadRequest, BadCredentials)
+		return
+	}
+
+	trade, err:= userDB.CopyTrade(aService.db(), copyContainer.SessionKey,
+		userName, collectionName, copyContainer.Destination, tradeID)
+	if err == userDB.ErrNoSuchTrade {
+		resp.WriteErrorString(http.StatusNotFound, NoSuchTrade)
+		return
+	}
+	if err == userDB.ErrCollectionLocked {
+		resp.WriteErrorString(http.StatusConflict, CollectionLocked)
+		return
+	}
+	if err == userDB.ErrCollectionOverLimit {
+		resp.WriteErrorString(http.StatusForbidden, CollectionOverLimit)
+		return
+	}
+	if err!=nil {
+		status, message:= collectionFailure(err, false)
+		resp.WriteErrorString(status, message)
+		return
+	}
+
+	// Receivers are notified without holding up the response
+	aService.queueBackground(func() {
+		aService.notifyWebhooks(userName, trade.Collection, "trade")
+	})
+
+	resp.WriteEntity(trade)
+
+}
+
+// Removes a trade from a collection by recording its reversal.
+func (aService *UserService) removeTrade(req *restful.Request,
+	resp *restful.Response) {
+
+	userName:= req.PathParameter("userName")
+	collectionName:= req.PathParameter("collectionName")
+	tradeID:= req.PathParameter("tradeID")
+
+	sessionKey:= getSessionKeyHeader(req)
+	if sessionKey == nil {
+		resp.WriteErrorString(http.StatusBadRequest, BadCredentials)
+		return
+	}
+
+	reversal, err:= userDB.RemoveTrade(aService.db(), sessionKey,
+		userName, collectionName, tradeID)
+	switch err{
+	case nil:
+	case userDB.ErrNoSuchTrade:
+		resp.WriteErrorString(http.StatusNotFound, NoSuchTrade)
+		return
+	case userDB.ErrTradeReversed:
+		resp.WriteErrorString(http.StatusConflict, TradeReversed)
+		return
+	case userDB.ErrCollectionLocked:
+		resp.WriteErrorString(http.StatusConflict, CollectionLocked)
+		return
+	case userDB.ErrCollectionOverLimit:
+		resp.WriteErrorString(http.StatusForbidden, CollectionOverLimit)
+		return
+	default:
+		status, message:= collectionFailure(err, false)
+		if status == http.StatusInternalServerError {
+			logAt(aService.logger, levelError, requestContext(req), err)
+		}
+		resp.WriteErrorString(status, message)
+		return
+	}
+
+	aService.queueBackground(func() {
+		aService.notifyWebhooks(userName, collectionName, "trade")
+	})
+
+	resp.WriteEntity(reversal)
+
+}
+
+// Acquires every trade made to a collection of an authenticated user,
+// oldest first.
+func (aService *UserService) getTradeHistory(req *restful.Request,
+	resp *restful.Response) {
+
+	userName, sessionKey, err:= getUserNameAndSessionKey(req)
+	if err!=nil {
+		resp.WriteErrorString(http.StatusBadRequest, BodyReadFailure)
+		return
+	}
+	collectionName:= req.PathParameter("collectionName")
+
+	if sessionKey == nil {
+		resp.WriteErrorString(http.StatusBadRequest, BadCredentials)
+		return
+	}
+
+	// Authenticate once and ensure the collection exists
+	_, err = userDB.GetCollectionMeta(aService.db(),
+		sessionKey, userName, collectionName)
+	if err!=nil {
+		status, message:= collectionFailure(err, false)
+		resp.WriteErrorString(status, message)
+		return
+	}
+
+	trades, err:= userDB.GetTradeHistory(aService.db(),
+		nil, userName, collectionName)
+	if err!=nil {
+		logAt(aService.logger, levelError, requestContext(req), err)
+		resp.WriteErrorString(http.StatusInternalServerError, DBfailure)
+		return
+	}
+
+	resp.WriteEntity(trades)
+
+}
+
+// Acquires every trade made to a collection if and only if its
+// history is publicly available to view.
+func (aService *UserService) getTradeHistoryPublic(req *restful.Request,
+	resp *restful.Response) {
+
+	userName:= req.PathParameter("userName")
+	collectionName:= req.PathParameter("collectionName")
+
+	// Missing and private collections must look identical to the public
+	meta, err:= userDB.GetCollectionMeta(aService.db(),
+		nil, userName, collectionName)
+	if err!=nil {
+		status, message:= collectionFailure(err, true)
+		resp.WriteErrorString(status, message)
+		return
+	}
+
+	if meta.Privacy != "History" {
+		status, message:= collectionFailure(userDB.ErrBadSession, true)
+		resp.WriteErrorString(status, message)
+		return
+	}
+
+	trades, err:= userDB.GetTradeHistory(aService.db(),
+		nil, userName, collectionName)
+	if err!=nil {
+		status, message:= collectionFailure(err, true)
+		resp.WriteErrorString(status, message)
+		return
+	}
+
+	trades, err = publicTrades(meta, trades)
+	if err!=nil {
+		resp.WriteErrorString(http.StatusBadRequest, BadCredentials)
+		return
+	}
+
+	resp.WriteEntity(trades)
+
+}
+
+// Restricts trades to what a collection's permission bits allow the
+// public to see, as publicView does for contents.
+func publicTrades(meta *userDB.Collection,
+	trades []userDB.Trade) ([]userDB.Trade, error) {
+
+	if meta.Privacy != "History" {
+		return nil, fmt.Errorf("collection history is private")
+	}
+
+	if meta.PublicComments {
+		return trades, nil
+	}
+
+	stripped:= make([]userDB.Trade, len(trades))
+	for i, aTrade:= range trades{
+		aTrade.Cards = withoutComments(aTrade.Cards)
+		stripped[i] = aTrade
+	}
+
+	return stripped, nil
+
+}
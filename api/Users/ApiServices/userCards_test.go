@@ -0,0 +1,266 @@
+package ApiServices
+
+import(
+
+	"./userDBHandler"
+
+	"net/http"
+
+	"fmt"
+
+	"testing"
+
+)
+
+func TestPublicViewPrivate(t *testing.T) {
+
+	current:= []userDB.Card{
+		userDB.Card{Name: "Griselbrand", Set: "Avacyn Restored",
+			Comment: "foo", Quantity: 1},
+	}
+	history:= []userDB.Card{
+		userDB.Card{Name: "Griselbrand", Set: "Avacyn Restored",
+			Comment: "bar", Quantity: 1},
+	}
+
+	meta:= userDB.Collection{Privacy: "Private", PublicComments: true}
+
+	_, err:= publicView(&meta, current, history)
+	if err == nil {
+		t.Fatal("private collection publicly viewable")
+	}
+
+}
+
+func TestPublicViewHistory(t *testing.T) {
+
+	current:= []userDB.Card{
+		userDB.Card{Name: "Griselbrand", Set: "Avacyn Restored",
+			Comment: "foo", Quantity: 1},
+	}
+	history:= []userDB.Card{
+		userDB.Card{Name: "Griselbrand", Set: "Avacyn Restored",
+			Comment: "bar", Quantity: 1},
+	}
+
+	meta:= userDB.Collection{Privacy: "Contents"}
+
+	aColl, err:= publicView(&meta, current, history)
+	if err!=nil {
+		t.Fatal(err)
+	}
+	if len(aColl.Current) != 1 {
+		t.Fatal("contents missing from public view")
+	}
+	if len(aColl.Historical) != 0 {
+		t.Fatal("history present without history permission")
+	}
+
+	meta.Privacy = "History"
+
+	aColl, err = publicView(&meta, current, history)
+	if err!=nil {
+		t.Fatal(err)
+	}
+	if len(aColl.Current) != 1 || len(aColl.Historical) != 1 {
+		t.Fatal("contents or history missing with history permission")
+	}
+
+}
+
+func TestPublicViewComments(t *testing.T) {
+
+	current:= []userDB.Card{
+		userDB.Card{Name: "Griselbrand", Set: "Avacyn Restored",
+			Comment: "foo", Quantity: 1},
+	}
+	history:= []userDB.Card{
+		userDB.Card{Name: "Griselbrand", Set: "Avacyn Restored",
+			Comment: "bar", Quantity: 1},
+	}
+
+	meta:= userDB.Collection{Privacy: "History"}
+
+	aColl, err:= publicView(&meta, current, history)
+	if err!=nil {
+		t.Fatal(err)
+	}
+	if aColl.Current[0].Comment != "" ||
+		aColl.Historical[0].Comment != "" {
+		t.Fatal("comments present without comment permission")
+	}
+
+	// Stripping should never touch the stored cards
+	if current[0].Comment != "foo" || history[0].Comment != "bar" {
+		t.Fatal("stripping comments modified source cards")
+	}
+
+	meta.PublicComments = true
+
+	aColl, err = publicView(&meta, current, history)
+	if err!=nil {
+		t.Fatal(err)
+	}
+	if aColl.Current[0].Comment != "foo" ||
+		aColl.Historical[0].Comment != "bar" {
+		t.Fatal("comments missing with comment permission")
+	}
+
+}
+
+func TestNetQuantities(t *testing.T) {
+
+	trades:= []userDB.Card{
+		userDB.Card{Name: "Griselbrand", Set: "Avacyn Restored",
+			Quality: "NM", Lang: "EN", Quantity: 4},
+		userDB.Card{Name: "Griselbrand", Set: "Avacyn Restored",
+			Quality: "NM", Lang: "EN", Quantity: -1},
+		// Differing attributes are held separately
+		userDB.Card{Name: "Griselbrand", Set: "Avacyn Restored",
+			Quality: "LP", Lang: "EN", Quantity: 2},
+		userDB.Card{Name: "Griselbrand", Set: "Avacyn Restored",
+			Quality: "NM", Lang: "JA", Quantity: 1},
+		// Entirely traded away
+		userDB.Card{Name: "Abrupt Decay", Set: "Return to Ravnica",
+			Quality: "NM", Lang: "EN", Quantity: 3},
+		userDB.Card{Name: "Abrupt Decay", Set: "Return to Ravnica",
+			Quality: "NM", Lang: "EN", Quantity: -3},
+	}
+
+	held:= netQuantities(trades)
+
+	expected:= []CardQuantity{
+		CardQuantity{"Griselbrand", "Avacyn Restored", "LP", "EN", 2},
+		CardQuantity{"Griselbrand", "Avacyn Restored", "NM", "EN", 3},
+		CardQuantity{"Griselbrand", "Avacyn Restored", "NM", "JA", 1},
+	}
+	if len(held) != len(expected) {
+		t.Fatal("incorrect number of holdings", held)
+	}
+	for i, q:= range expected{
+		if held[i] != q {
+			t.Fatal("incorrect holding", held[i], q)
+		}
+	}
+
+}
+
+func TestCollectionFailureOwnerMissing(t *testing.T) {
+
+	status, _:= collectionFailure(userDB.ErrNoSuchCollection, false)
+	if status != http.StatusNotFound {
+		t.Fatal("missing collection not reported to owner", status)
+	}
+
+}
+
+func TestCollectionFailureOwnerForbidden(t *testing.T) {
+
+	status, _:= collectionFailure(userDB.ErrBadSession, false)
+	if status != http.StatusUnauthorized {
+		t.Fatal("forbidden collection not reported to owner", status)
+	}
+
+}
+
+// Clients must be able to tell a lost race apart so they refetch
+// and retry
+func TestCollectionFailureConflict(t *testing.T) {
+
+	status, message:= collectionFailure(userDB.ErrCollectionConflict, false)
+	if status != http.StatusConflict || message != CollectionConflict {
+		t.Fatal("conflict not reported to owner", status, message)
+	}
+
+}
+
+func TestCollectionFailureAnonymousForbidden(t *testing.T) {
+
+	missingStatus, missingMessage:= collectionFailure(
+		userDB.ErrNoSuchCollection, true)
+	forbiddenStatus, forbiddenMessage:= collectionFailure(
+		userDB.ErrBadSession, true)
+
+	if missingStatus != forbiddenStatus ||
+		missingMessage != forbiddenMessage {
+		t.Fatal("public callers can distinguish missing from forbidden")
+	}
+
+}
+
+func TestPublicTrades(t *testing.T) {
+
+	current:= []userDB.Card{
+		userDB.Card{Name: "Griselbrand", Set: "Avacyn Restored",
+			Comment: "foo", Quantity: 1},
+	}
+	history:= []userDB.Card{
+		userDB.Card{Name: "Griselbrand", Set: "Avacyn Restored",
+			Comment: "bar", Quantity: 1},
+	}
+	trades:= []userDB.Trade{
+		userDB.Trade{ID: "foo", Cards: current},
+		userDB.Trade{ID: "bar", Cards: history},
+	}
+
+	for _, privacy:= range []string{"Private", "Contents"}{
+		meta:= userDB.Collection{Privacy: privacy, PublicComments: true}
+		_, err:= publicTrades(&meta, trades)
+		if err == nil {
+			t.Fatal("trades public without history permission", privacy)
+		}
+	}
+
+	meta:= userDB.Collection{Privacy: "History"}
+
+	public, err:= publicTrades(&meta, trades)
+	if err!=nil {
+		t.Fatal(err)
+	}
+	if len(public) != len(trades) || public[1].ID != "bar" {
+		t.Fatal("trades missing or reordered", public)
+	}
+	if public[0].Cards[0].Comment != "" ||
+		public[1].Cards[0].Comment != "" {
+		t.Fatal("comments present without comment permission")
+	}
+	if current[0].Comment != "foo" || history[0].Comment != "bar" {
+		t.Fatal("stripping comments modified source trades")
+	}
+
+	meta.PublicComments = true
+
+	public, err = publicTrades(&meta, trades)
+	if err!=nil {
+		t.Fatal(err)
+	}
+	if public[0].Cards[0].Comment != "foo" {
+		t.Fatal("comments missing with comment permission")
+	}
+
+}
+
+// Outages are the database's fault, never the session's, whoever asks.
+func TestCollectionFailureUnavailable(t *testing.T) {
+
+	for _, public:= range []bool{false, true} {
+		status, _:= collectionFailure(userDB.ErrUnavailable, public)
+		if status != http.StatusServiceUnavailable {
+			t.Fatal("outage not reported as one", public, status)
+		}
+	}
+
+}
+
+// Failures unrelated to a collection's visibility reveal nothing about
+// it so are reported as failures rather than as missing
+func TestCollectionFailurePublicInternal(t *testing.T) {
+
+	for _, public:= range []bool{false, true} {
+		status, _:= collectionFailure(fmt.Errorf("foo"), public)
+		if status != http.StatusInternalServerError {
+			t.Fatal("internal failure not reported as one", public, status)
+		}
+	}
+
+}
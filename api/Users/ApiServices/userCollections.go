@@ -7,9 +7,78 @@ import(
 	"github.com/emicklei/go-restful"
 
 	"net/http"
+	"strings"
 
 )
 
+// How many collections a page holds without an explicit limit and
+// the most it will ever hold.
+const defaultCollectionPage int = 50
+const maxCollectionPage int = 200
+
+// A page of a user's collections ordered by name, Total counts
+// every collection they have.
+type CollectionListPage struct{
+	Collections []string
+	Offset, Limit int
+	Total int64
+}
+
+// Returns why a collection name is unacceptable or nothing if it
+// is fine to create.
+func collectionNameProblem(collectionName string) string {
+
+	if !validEncoding(collectionName) {
+		return BadEncoding
+	}
+
+	// Names are shown publicly so we require they be clean to begin with
+	cleanName, err:= sanitizeUserText(collectionName,
+		collectionNameMaxLength)
+	if err!=nil || cleanName != collectionName || cleanName == "" {
+		return BadUserText
+	}
+
+	return ""
+
+}
+
+// Cleans the tags a collection is labelled with, returning why they
+// are unacceptable if they are.
+//
+// Tags are trimmed and those repeating another, regardless of case,
+// are dropped so the first spelling given is kept.
+func cleanCollectionTags(tags []string) ([]string, string) {
+
+	cleaned:= make([]string, 0, len(tags))
+	seen:= make(map[string]bool)
+	for _, tag:= range tags{
+		if !validEncoding(tag) {
+			return nil, BadEncoding
+		}
+
+		// Tags are shown publicly alongside the collection
+		clean, err:= sanitizeUserText(tag, collectionTagMaxLength)
+		if err!=nil || clean == "" {
+			return nil, BadCollectionTags
+		}
+
+		folded:= strings.ToLower(clean)
+		if seen[folded] {
+			continue
+		}
+		seen[folded] = true
+		cleaned = append(cleaned, clean)
+	}
+
+	if len(cleaned) > maxCollectionTags {
+		return nil, BadCollectionTags
+	}
+
+	return cleaned, ""
+
+}
+
 // Creates a new collection for the named user
 func (aService *UserService) newCollection(req *restful.Request,
 	resp *restful.Response)  {
@@ -26,11 +95,29 @@ func (aService *UserService) newCollection(req *restful.Request,
 		return
 	}
 
-	err = userDB.AddCollection(aService.pool,
+	if problem:= collectionNameProblem(collectionName); problem != "" {
+		resp.WriteErrorString(http.StatusBadRequest, problem)
+		return
+	}
+
+	err = userDB.AddCollection(aService.db(),
 		sessionKey,
 		userName, collectionName)
+	if err == userDB.ErrReservedCollectionName {
+		resp.WriteErrorString(http.StatusBadRequest, ReservedCollectionName)
+		return
+	}
+	if err == userDB.ErrCollectionLimit {
+		resp.WriteErrorString(http.StatusForbidden, CollectionLimit)
+		return
+	}
+	if err == userDB.ErrCollectionExists {
+		resp.WriteErrorString(http.StatusConflict, CollectionExists)
+		return
+	}
 	if err!=nil {
-		resp.WriteErrorString(http.StatusBadRequest, BadCredentials)
+		logAt(aService.logger, levelError, requestContext(req), err)
+		resp.WriteErrorString(dbFailure(err, BadCredentials))
 		return
 	}
 
@@ -38,6 +125,56 @@ func (aService *UserService) newCollection(req *restful.Request,
 
 }
 
+// Creates a collection for the named user if it doesn't exist,
+// succeeding either way.
+func (aService *UserService) ensureCollection(req *restful.Request,
+	resp *restful.Response)  {
+
+	userName, sessionKey, err:= getUserNameAndSessionKey(req)
+	if err!=nil {
+		resp.WriteErrorString(http.StatusBadRequest, BodyReadFailure)
+		return
+	}
+	collectionName:= req.PathParameter("collectionName")
+
+	if sessionKey == nil {
+		resp.WriteErrorString(http.StatusBadRequest, BadCredentials)
+		return
+	}
+
+	if problem:= collectionNameProblem(collectionName); problem != "" {
+		resp.WriteErrorString(http.StatusBadRequest, problem)
+		return
+	}
+
+	existed, err:= userDB.EnsureCollection(aService.db(),
+		sessionKey,
+		userName, collectionName)
+	if err == userDB.ErrReservedCollectionName {
+		resp.WriteErrorString(http.StatusBadRequest, ReservedCollectionName)
+		return
+	}
+	if err == userDB.ErrCollectionLimit {
+		resp.WriteErrorString(http.StatusForbidden, CollectionLimit)
+		return
+	}
+	if err!=nil {
+		logAt(aService.logger, levelError, requestContext(req), err)
+		resp.WriteErrorString(dbFailure(err, BadCredentials))
+		return
+	}
+
+	resp.WriteEntity(EnsureCollectionResponse{AlreadyExisted: existed})
+
+}
+
+// Every privacy a collection may be given
+var validPrivacy = map[string]bool{
+	"Private": true,
+	"Contents": true,
+	"History": true,
+}
+
 // Set the viewing levels for a collection under a user
 func (aService *UserService) setCollectionPermissions(req *restful.Request,
 	resp *restful.Response) {
@@ -56,16 +193,243 @@ func (aService *UserService) setCollectionPermissions(req *restful.Request,
 		resp.WriteErrorString(http.StatusBadRequest, BadCredentials)
 		return
 	}
+
+	if !validPrivacy[permissionsContainer.Privacy] {
+		resp.WriteErrorString(http.StatusBadRequest, BadPrivacy)
+		return
+	}
 	
-	err = userDB.SetCollectionPrivacy(aService.pool,
+	err = userDB.SetCollectionPrivacy(aService.db(),
 		permissionsContainer.SessionKey,
 		userName, collectionName,
-		permissionsContainer.Privacy)
+		permissionsContainer.Privacy,
+		permissionsContainer.PublicComments,
+		permissionsContainer.Version)
+	if err!=nil {
+		status, message:= collectionFailure(err, false)
+		if status == http.StatusInternalServerError {
+			logAt(aService.logger, levelError, requestContext(req), err)
+		}
+		resp.WriteErrorString(status, message)
+		return
+	}
+
+	resp.WriteEntity(true)
+
+}
+
+// Replaces the tags a collection is labelled with.
+func (aService *UserService) setCollectionTags(req *restful.Request,
+	resp *restful.Response) {
+
+	userName:= req.PathParameter("userName")
+	collectionName:= req.PathParameter("collectionName")
+
+	var tagsContainer CollectionTagsBody
+	err:= req.ReadEntity(&tagsContainer)
+	if err!=nil {
+		resp.WriteErrorString(http.StatusBadRequest, BodyReadFailure)
+		return
+	}
+
+	if tagsContainer.SessionKey == nil {
+		resp.WriteErrorString(http.StatusBadRequest, BadCredentials)
+		return
+	}
+
+	tags, problem:= cleanCollectionTags(tagsContainer.Tags)
+	if problem != "" {
+		resp.WriteErrorString(http.StatusBadRequest, problem)
+		return
+	}
+
+	err = userDB.SetCollectionTags(aService.db(),
+		tagsContainer.SessionKey,
+		userName, collectionName,
+		tags, tagsContainer.Version)
+	if err!=nil {
+		status, message:= collectionFailure(err, false)
+		if status == http.StatusInternalServerError {
+			logAt(aService.logger, levelError, requestContext(req), err)
+		}
+		resp.WriteErrorString(status, message)
+		return
+	}
+
+	resp.WriteEntity(true)
+
+}
+
+// Locks or unlocks a collection, locked collections reject trades
+// but may still be viewed.
+func (aService *UserService) setCollectionLock(req *restful.Request,
+	resp *restful.Response) {
+
+	userName:= req.PathParameter("userName")
+	collectionName:= req.PathParameter("collectionName")
+
+	var lockContainer LockChangeBody
+	err:= req.ReadEntity(&lockContainer)
+	if err!=nil {
+		resp.WriteErrorString(http.StatusBadRequest, BodyReadFailure)
+		return
+	}
+
+	if lockContainer.SessionKey == nil {
+		resp.WriteErrorString(http.StatusBadRequest, BadCredentials)
+		return
+	}
+
+	err = userDB.SetCollectionLock(aService.db(),
+		lockContainer.SessionKey,
+		userName, collectionName,
+		lockContainer.Locked)
+	if err!=nil {
+		status, message:= collectionFailure(err, false)
+		resp.WriteErrorString(status, message)
+		return
+	}
+
+	resp.WriteEntity(true)
+
+}
+
+// Deletes a collection and its contents.
+func (aService *UserService) deleteCollection(req *restful.Request,
+	resp *restful.Response) {
+
+	userName:= req.PathParameter("userName")
+	collectionName:= req.PathParameter("collectionName")
+
+	sessionKey:= getSessionKeyHeader(req)
+	if sessionKey == nil {
+		resp.WriteErrorString(http.StatusBadRequest, BadCredentials)
+		return
+	}
+
+	err:= userDB.DeleteCollection(aService.db(), sessionKey,
+		userName, collectionName)
+	if err == userDB.ErrCollectionLocked {
+		resp.WriteErrorString(http.StatusConflict, CollectionLocked)
+		return
+	}
+	if err!=nil {
+		status, message:= collectionFailure(err, false)
+		if status == http.StatusInternalServerError {
+			logAt(aService.logger, levelError, requestContext(req), err)
+		}
+		resp.WriteErrorString(status, message)
+		return
+	}
+
+	resp.WriteEntity(true)
+
+}
+
+// Renames a collection, keeping its contents, history and permissions.
+func (aService *UserService) renameCollection(req *restful.Request,
+	resp *restful.Response) {
+
+	userName:= req.PathParameter("userName")
+	collectionName:= req.PathParameter("collectionName")
+
+	var renameContainer CollectionRenameBody
+	err:= req.ReadEntity(&renameContainer)
+	if err!=nil {
+		resp.WriteErrorString(http.StatusBadRequest, BodyReadFailure)
+		return
+	}
+
+	if renameContainer.SessionKey == nil {
+		resp.WriteErrorString(http.StatusBadRequest, BadCredentials)
+		return
+	}
+
+	if problem:= collectionNameProblem(renameContainer.Name); problem != "" {
+		resp.WriteErrorString(http.StatusBadRequest, problem)
+		return
+	}
+
+	err = userDB.RenameCollection(aService.db(),
+		renameContainer.SessionKey,
+		userName, collectionName,
+		renameContainer.Name, renameContainer.Version)
+	switch err{
+	case nil:
+	case userDB.ErrReservedCollectionName:
+		resp.WriteErrorString(http.StatusBadRequest, ReservedCollectionName)
+		return
+	case userDB.ErrCollectionExists:
+		resp.WriteErrorString(http.StatusConflict, CollectionExists)
+		return
+	case userDB.ErrCollectionLocked:
+		resp.WriteErrorString(http.StatusConflict, CollectionLocked)
+		return
+	case userDB.ErrCollectionOverLimit:
+		resp.WriteErrorString(http.StatusForbidden, CollectionOverLimit)
+		return
+	default:
+		status, message:= collectionFailure(err, false)
+		if status == http.StatusInternalServerError {
+			logAt(aService.logger, levelError, requestContext(req), err)
+		}
+		resp.WriteErrorString(status, message)
+		return
+	}
+
+	resp.WriteEntity(true)
+
+}
+
+// Stores the currency and basis a collection is valued in when
+// a request doesn't ask for one.
+func (aService *UserService) setCollectionValuation(req *restful.Request,
+	resp *restful.Response) {
+
+	userName:= req.PathParameter("userName")
+	collectionName:= req.PathParameter("collectionName")
+
+	var valuationContainer CollectionValuationBody
+	err:= req.ReadEntity(&valuationContainer)
 	if err!=nil {
+		resp.WriteErrorString(http.StatusBadRequest, BodyReadFailure)
+		return
+	}
+
+	if valuationContainer.SessionKey == nil {
 		resp.WriteErrorString(http.StatusBadRequest, BadCredentials)
 		return
 	}
 
+	// Empty values clear a default so they're always allowed
+	_, supported:= currencySources[valuationContainer.Currency]
+	if valuationContainer.Currency != "" && !supported {
+		resp.WriteErrorString(http.StatusBadRequest, BadPreferences)
+		return
+	}
+	if _, ok:= parseBasis(valuationContainer.Basis); !ok {
+		resp.WriteErrorString(http.StatusBadRequest, BadBasis)
+		return
+	}
+
+	err = userDB.SetCollectionValuation(aService.db(),
+		valuationContainer.SessionKey,
+		userName, collectionName,
+		valuationContainer.Currency, valuationContainer.Basis)
+	if err == userDB.ErrCollectionLocked {
+		resp.WriteErrorString(http.StatusConflict, CollectionLocked)
+		return
+	}
+	if err == userDB.ErrCollectionOverLimit {
+		resp.WriteErrorString(http.StatusForbidden, CollectionOverLimit)
+		return
+	}
+	if err!=nil {
+		status, message:= collectionFailure(err, false)
+		resp.WriteErrorString(status, message)
+		return
+	}
+
 	resp.WriteEntity(true)
 
 }
@@ -88,11 +452,12 @@ func (aService *UserService) getCollectionPermissions(req *restful.Request,
 		return
 	}
 	
-	meta, err:= userDB.GetCollectionMeta(aService.pool,
+	meta, err:= userDB.GetCollectionMeta(aService.db(),
 		sessionKey,
 		userName, collectionName)
 	if err!=nil {
-		resp.WriteErrorString(http.StatusBadRequest, BadCredentials)
+		status, message:= collectionFailure(err, false)
+		resp.WriteErrorString(status, message)
 		return
 	}
 
@@ -106,9 +471,10 @@ func (aService *UserService) getUserPublicCollections(req *restful.Request,
 	
 	userName:= req.PathParameter("userName")
 
-	collections, err:= userDB.GetCollectionList(aService.pool, userName)
+	collections, err:= userDB.GetCollectionList(aService.db(), userName)
 	if err!=nil {
-		resp.WriteErrorString(http.StatusBadRequest, BadCredentials)
+		logAt(aService.logger, levelError, requestContext(req), err)
+		resp.WriteErrorString(dbFailure(err, BadCredentials))
 		return
 	}
 
@@ -123,39 +489,72 @@ func (aService *UserService) getUserPublicCollections(req *restful.Request,
 
 }
 
-// Acquire all collections for a named and authenticated user
+// Validates a requested page of collections, an absent limit
+// requests defaultCollectionPage of them.
+func collectionPageBounds(offset, limit int) (int, int, bool) {
+
+	if limit == 0 {
+		limit = defaultCollectionPage
+	}
+
+	if offset < 0 || limit < 0 || limit > maxCollectionPage {
+		return 0, 0, false
+	}
+
+	return offset, limit, true
+
+}
+
+// Acquire a page of collections for a named and authenticated user
 func (aService *UserService) getUserCollections(req *restful.Request,
 	resp *restful.Response) {
 
-	userName, sessionKey, err:= getUserNameAndSessionKey(req)
+	userName:= req.PathParameter("userName")
+
+	var listContainer CollectionListBody
+	err:= req.ReadEntity(&listContainer)
 	if err!=nil {
-		aService.logger.Println(err)
+		logAt(aService.logger, levelError, requestContext(req), err)
 		resp.WriteErrorString(http.StatusBadRequest, BodyReadFailure)
 		return
 	}
 
-	if sessionKey == nil {
+	if listContainer.SessionKey == nil {
 		resp.WriteErrorString(http.StatusBadRequest, BadCredentials)
 		return
 	}
 
-	err = userDB.SessionAuth(aService.pool, userName, sessionKey)
+	offset, limit, ok:= collectionPageBounds(listContainer.Offset,
+		listContainer.Limit)
+	if !ok {
+		resp.WriteErrorString(http.StatusBadRequest, BadCollectionPage)
+		return
+	}
+
+	err = userDB.SessionAuth(aService.db(), userName, listContainer.SessionKey)
 	if err!=nil {
-		resp.WriteErrorString(http.StatusUnauthorized, BadCredentials)
+		resp.WriteErrorString(dbFailure(err, BadCredentials))
 		return
 	}
 
-	collections, err:= userDB.GetCollectionList(aService.pool, userName)
+	collections, total, err:= userDB.GetCollectionListPage(aService.db(),
+		userName, listContainer.Tag, offset, limit)
 	if err!=nil {
-		resp.WriteErrorString(http.StatusBadRequest, BadCredentials)
+		logAt(aService.logger, levelError, requestContext(req), err)
+		resp.WriteErrorString(dbFailure(err, DBfailure))
 		return
 	}
 
-	all:= make([]string, 0)
+	page:= CollectionListPage{
+		Collections: make([]string, 0, len(collections)),
+		Offset: offset,
+		Limit: limit,
+		Total: total,
+	}
 	for _, c:= range collections{
-		all = append(all, c.Name)
+		page.Collections = append(page.Collections, c.Name)
 	}
 
-	resp.WriteEntity(all)
+	resp.WriteEntity(page)
 
 }
\ No newline at end of file
@@ -0,0 +1,61 @@
+package ApiServices
+
+import(
+
+	"strings"
+	"testing"
+
+)
+
+func TestCollectionPageBounds(t *testing.T) {
+
+	offset, limit, ok:= collectionPageBounds(0, 0)
+	if !ok || offset != 0 || limit != defaultCollectionPage {
+		t.Fatal("absent bounds not defaulted", offset, limit)
+	}
+
+	offset, limit, ok = collectionPageBounds(100, 10)
+	if !ok || offset != 100 || limit != 10 {
+		t.Fatal("valid bounds altered", offset, limit)
+	}
+
+	invalid:= [][2]int{{-1, 10}, {0, -1}, {0, maxCollectionPage + 1}}
+	for _, bounds:= range invalid{
+		_, _, ok = collectionPageBounds(bounds[0], bounds[1])
+		if ok {
+			t.Fatal("invalid bounds accepted", bounds)
+		}
+	}
+
+}
+
+// Ensure tags are trimmed and deduplicated, keeping the first spelling
+func TestCleanCollectionTags(t *testing.T) {
+
+	tags, problem:= cleanCollectionTags([]string{" EDH ", "Trade Binder",
+		"edh"})
+	if problem != "" || len(tags) != 2 ||
+		tags[0] != "EDH" || tags[1] != "Trade Binder" {
+		t.Fatal("tags not cleaned", tags, problem)
+	}
+
+	tags, problem = cleanCollectionTags(nil)
+	if problem != "" || len(tags) != 0 {
+		t.Fatal("clearing tags refused", tags, problem)
+	}
+
+	tooMany:= make([]string, maxCollectionTags + 1)
+	for i:= range tooMany{
+		tooMany[i] = strings.Repeat("a", i + 1)
+	}
+
+	invalid:= [][]string{{""}, {"  "}, {"<b>"},
+		{strings.Repeat("a", collectionTagMaxLength + 1)}, tooMany}
+	for _, tags:= range invalid{
+		_, problem = cleanCollectionTags(tags)
+		if problem == "" {
+			t.Fatal("invalid tags accepted", tags)
+		}
+	}
+
+}
@@ -0,0 +1,168 @@
+package userDB
+
+import(
+
+	"github.com/jackc/pgx"
+
+	"encoding/hex"
+	"time"
+
+)
+
+// A price alert on a collection.
+//
+// It fires when any card moves by more than Percent or crosses
+// Threshold, in cents, since Since. Either is zero when unused. Since
+// starts as Created and moves on whenever the alert fires.
+type Alert struct{
+	ID, Owner, Collection string
+	Percent, Threshold int32
+	Since, Created time.Time
+}
+
+const alertIDLength int = 12
+
+// Sets a price alert on a collection only if the user has fewer alerts
+// than their plan allows.
+//
+// The user's sub is locked while the alert is added, so concurrent
+// additions can't both slip under the limit.
+func AddAlert(pool *pgx.ConnPool, sessionKey []byte,
+	user, collection string, percent, threshold int32) (Alert, error) {
+
+	// Authenticates and ensures the collection exists
+	_, err:= GetCollectionMeta(pool, sessionKey, user, collection)
+	if err!=nil {
+		return Alert{}, err
+	}
+
+	raw, err:= getArrayOfRandBytes(alertIDLength)
+	if err!=nil {
+		return Alert{}, err
+	}
+
+	a:= Alert{
+		ID: hex.EncodeToString(raw),
+		Owner: user,
+		Collection: collection,
+		Percent: percent,
+		Threshold: threshold,
+	}
+	err = withTx(pool, func(tx *pgx.Tx) error {
+
+		var plan string
+		err:= tx.QueryRow("lockSub", user).Scan(&plan)
+		if err!=nil {
+			return errorHandle(err, "failed to fetch sub")
+		}
+
+		err = tx.QueryRow("addAlert", a.ID, user, collection,
+			percent, threshold, SubTiersToAlerts[plan]).Scan(
+			&a.Since, &a.Created)
+		if err == pgx.ErrNoRows {
+			return ErrAlertLimit
+		}
+		if err!=nil {
+			return errorHandle(err, ScanError)
+		}
+
+		return nil
+
+	})
+	if err!=nil {
+		return Alert{}, err
+	}
+
+	return a, nil
+
+}
+
+// Acquires every alert an authenticated user has set, oldest first.
+func GetAlerts(pool *pgx.ConnPool, sessionKey []byte,
+	user string) ([]Alert, error) {
+
+	// Authenticate the request
+	err:= SessionAuth(pool, user, sessionKey)
+	if err!=nil{
+		return nil, err
+	}
+
+	return getAlerts(pool, user)
+
+}
+
+func getAlerts(pool *pgx.ConnPool, user string) ([]Alert, error) {
+
+	rows, err := pool.Query("getAlerts", user)
+	if err!=nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanAlerts(rows)
+
+}
+
+// Acquires every alert of every user so they may be evaluated.
+func GetAllAlerts(pool *pgx.ConnPool) ([]Alert, error) {
+
+	rows, err := pool.Query("getAllAlerts")
+	if err!=nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanAlerts(rows)
+
+}
+
+func scanAlerts(rows *pgx.Rows) ([]Alert, error) {
+
+	alerts:= make([]Alert, 0)
+	for rows.Next(){
+		a:= Alert{}
+		err:= rows.Scan(&a.ID, &a.Owner, &a.Collection,
+			&a.Percent, &a.Threshold, &a.Since, &a.Created)
+		if err!=nil {
+			return nil, errorHandle(err, ScanError)
+		}
+
+		alerts = append(alerts, a)
+	}
+
+	return alerts, rows.Err()
+
+}
+
+// Deletes a single alert of an authenticated user, returning
+// ErrNoSuchAlert when they have none with that ID.
+func DeleteAlert(pool *pgx.ConnPool, sessionKey []byte,
+	user, id string) error {
+
+	// Authenticate the request
+	err:= SessionAuth(pool, user, sessionKey)
+	if err!=nil{
+		return err
+	}
+
+	tag, err:= pool.Exec("removeAlert", user, id)
+	if err!=nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrNoSuchAlert
+	}
+
+	return nil
+
+}
+
+// Records that an alert fired at when, so it next fires only on moves
+// from the prices it fired at.
+func MarkAlertFired(pool *pgx.ConnPool, id string, when time.Time) error {
+
+	_, err:= pool.Exec("markAlertFired", id, when)
+
+	return err
+
+}
@@ -0,0 +1,131 @@
+package userDB
+
+import(
+
+	"testing"
+
+	"sync"
+	"time"
+
+)
+
+// Sets alerts up to the free plan's limit, ensures they follow their
+// collection through a rename and go with it when deleted.
+func TestAlerts(t *testing.T) {
+	t.Parallel()
+
+	user:= randString(int(randByte()) % 31)
+	key, err:= AddUser(pool, user, "bar", "foo")
+	if err!=nil {
+		t.Fatal("failed to add user ", err)
+	}
+
+	time.Sleep(testSleepTime)
+
+	collection:= randString(10)
+	err = AddCollection(pool, key, user, collection)
+	if err!=nil {
+		t.Fatal(err)
+	}
+
+	_, err = AddAlert(pool, key, user, randString(10), 10, 0)
+	if err != ErrNoSuchCollection {
+		t.Fatal("alert set on a missing collection", err)
+	}
+
+	limit:= SubTiersToAlerts[DefaultSubLevel]
+	for i:= 0; i < limit; i++ {
+		_, err = AddAlert(pool, key, user, collection, int32(i + 1), 0)
+		if err!=nil {
+			t.Fatal("failed to set alert within limit", err)
+		}
+	}
+
+	_, err = AddAlert(pool, key, user, collection, 0, 500)
+	if err != ErrAlertLimit {
+		t.Fatal("set alert beyond limit", err)
+	}
+
+	renamed:= randString(10)
+	err = RenameCollection(pool, key, user, collection, renamed, 0)
+	if err!=nil {
+		t.Fatal("failed to rename collection", err)
+	}
+
+	_, err = GetAlerts(pool, []byte("baz"), user)
+	if err != ErrBadSession {
+		t.Fatal("alerts fetched without a session", err)
+	}
+
+	alerts, err:= GetAlerts(pool, key, user)
+	if err!=nil || len(alerts) != limit {
+		t.Fatal("failed to get alerts", alerts, err)
+	}
+	if alerts[0].Collection != renamed || alerts[0].Percent != 1 {
+		t.Fatal("alert didn't follow its collection", alerts[0])
+	}
+
+	err = DeleteAlert(pool, []byte("baz"), user, alerts[0].ID)
+	if err != ErrBadSession {
+		t.Fatal("alert deleted without a session", err)
+	}
+
+	err = DeleteAlert(pool, key, user, alerts[0].ID)
+	if err!=nil {
+		t.Fatal("failed to delete alert", err)
+	}
+	err = DeleteAlert(pool, key, user, alerts[0].ID)
+	if err != ErrNoSuchAlert {
+		t.Fatal("deleted alert twice", err)
+	}
+
+	err = DeleteCollection(pool, key, user, renamed)
+	if err!=nil {
+		t.Fatal("failed to delete collection", err)
+	}
+
+	alerts, err = GetAlerts(pool, key, user)
+	if err!=nil || len(alerts) != 0 {
+		t.Fatal("alerts outlived their collection", alerts, err)
+	}
+
+}
+
+// Alerts added at once must not slip past the limit together.
+func TestAlertLimitConcurrent(t *testing.T) {
+	t.Parallel()
+
+	user:= randString(int(randByte()) % 31)
+	key, err:= AddUser(pool, user, "bar", "foo")
+	if err!=nil {
+		t.Fatal("failed to add user ", err)
+	}
+
+	time.Sleep(testSleepTime)
+
+	collection:= randString(10)
+	err = AddCollection(pool, key, user, collection)
+	if err!=nil {
+		t.Fatal(err)
+	}
+
+	limit:= SubTiersToAlerts[DefaultSubLevel]
+	var wg sync.WaitGroup
+	for i:= 0; i < limit * 3; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			AddAlert(pool, key, user, collection, int32(i + 1), 0)
+		}(i)
+	}
+	wg.Wait()
+
+	alerts, err:= GetAlerts(pool, key, user)
+	if err!=nil {
+		t.Fatal(err)
+	}
+	if len(alerts) > limit {
+		t.Fatal("concurrent alerts exceeded limit", len(alerts))
+	}
+
+}
@@ -1,27 +1,97 @@
 // Code generated by go-bindata.
 // sources:
+// sql\addAlert.sql
 // sql\addCard.sql
 // sql\addCardHistorical.sql
 // sql\addCollection.sql
+// sql\addDeadLetter.sql
+// sql\addEvent.sql
 // sql\addReset.sql
 // sql\addSession.sql
 // sql\addUser.sql
-// sql\getAllResets.sql
+// sql\addWebhook.sql
+// sql\archiveUserHistory.sql
+// sql\bumpCollectionVersion.sql
+// sql\bumpSessionsVersion.sql
+// sql\changeEmail.sql
+// sql\clearLoginFailures.sql
+// sql\consumeReset.sql
+// sql\countActiveSessions.sql
+// sql\countActiveWebhooks.sql
+// sql\countCollections.sql
+// sql\countEmailUsers.sql
+// sql\countTradeReversals.sql
+// sql\disableUserWebhooks.sql
+// sql\extendSession.sql
+// sql\forgetStripeEvent.sql
+// sql\getAlerts.sql
+// sql\getAllAlerts.sql
 // sql\getCard.sql
 // sql\getCollectionContents.sql
 // sql\getCollectionHistory.sql
 // sql\getCollectionList.sql
 // sql\getCollectionMeta.sql
+// sql\getCollectionPage.sql
+// sql\getDeadLetter.sql
+// sql\getDeadLetters.sql
+// sql\getEvents.sql
+// sql\getFootprint.sql
+// sql\getPendingEmail.sql
+// sql\getRefreshableSessions.sql
 // sql\getReset.sql
 // sql\getSessions.sql
+// sql\getSessionsVersion.sql
 // sql\getSub.sql
+// sql\getSubByCustomer.sql
+// sql\getTrade.sql
+// sql\getTradeHistory.sql
 // sql\getUser.sql
+// sql\getWebhooks.sql
+// sql\listSessions.sql
+// sql\lockCollection.sql
+// sql\lockEmail.sql
+// sql\lockSub.sql
+// sql\markAlertFired.sql
+// sql\markResetSent.sql
 // sql\modSub.sql
+// sql\recordDeadLetterFailure.sql
+// sql\recordLoginFailure.sql
+// sql\recordStripeEvent.sql
+// sql\recordWebhookFailure.sql
+// sql\recordWebhookSuccess.sql
+// sql\removeAlert.sql
+// sql\removeAllSessions.sql
+// sql\removeCollection.sql
+// sql\removeCollectionContents.sql
+// sql\removeDeadLetter.sql
+// sql\removeOtherSessions.sql
 // sql\removeSession.sql
+// sql\removeUserCollections.sql
+// sql\removeUserContents.sql
+// sql\removeUserEvents.sql
+// sql\removeUserResets.sql
+// sql\renameCollection.sql
+// sql\renameCollectionHistory.sql
+// sql\revokeSession.sql
+// sql\scrubUser.sql
+// sql\setCollectionLock.sql
 // sql\setCollectionPermissions.sql
+// sql\setCollectionTags.sql
+// sql\setCollectionValuation.sql
+// sql\setCollectionsOverLimit.sql
+// sql\setEmailChange.sql
+// sql\setForceRehash.sql
+// sql\setLoginLockout.sql
 // sql\setMaxCollections.sql
 // sql\setPassword.sql
+// sql\setPreferences.sql
 // sql\setSubEffects.sql
+// sql\setSubInterval.sql
+// sql\setVerifyToken.sql
+// sql\touchSession.sql
+// sql\trimEvents.sql
+// sql\verifyEmail.sql
+// sql\waivePlanCooldown.sql
 // DO NOT EDIT!
 
 package userDB
@@ -90,442 +160,1842 @@ func (fi bindataFileInfo) Sys() interface{} {
 	return nil
 }
 
+var _sqlAddalertSql = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x7d\x52\x41\x6e\xdb\x30\x10\x3c\x87\x80\xfe\xb0\x07\x03\xb1\x0d\xc5\x41\xd3\x34\x87\xa0\x3d\x05\x6a\x6b\xa0\x75\x00\x45\x41\xcf\x0c\xb9\xae\x88\xc8\xa4\xc1\x5d\xc5\x48\x5f\xdf\x25\xe9\x20\x72\x0f\x3d\x10\x90\xc4\x99\xd9\x99\x59\x5d\x2e\x2b\xf5\x80\x4c\xa0\x61\x1f\x9d\x41\xd0\x03\x46\x86\xe0\xe5\x83\x09\xc3\x80\x86\x9d\xbc\x1c\x7a\xf4\xc0\x3d\x42\x38\x78\x8c\xd0\x6b\x82\x2d\x1e\xe4\x89\x7b\xed\x61\x70\x3b\xc7\x95\xca\x54\x5a\xc1\x9d\x16\x5e\x24\x18\x82\x79\x7e\x27\x9d\x13\xd0\xf8\x04\x5b\x17\x89\x6b\xa0\x20\xf2\xde\x8c\x31\xa2\x67\xd0\xd6\xba\x34\x87\x2a\x65\xb4\x3f\x67\x78\x0a\xdc\x03\x0d\x6e\x0f\xa3\xb7\x79\x0c\x96\x29\xab\x4a\x55\xaa\xd3\xcf\x48\xb7\x95\x3a\x73\x16\x2e\x80\x38\x3a\xff\xbb\x06\x67\x45\xca\x6d\x1d\x52\x86\x97\x20\x1c\xc0\x49\xba\xec\x40\x08\xc5\xfe\x3b\x27\x01\x47\x2a\x39\x38\xa1\x12\xd7\x89\x8d\xb3\x49\xf8\x53\xf8\xb4\x15\xcd\xa6\x47\x2b\xe8\x3d\x46\x93\x82\x5c\x80\xf3\x5c\x70\xbb\xf0\x82\xf2\x06\x6f\x57\x79\x82\xa4\x17\x7b\x4e\x20\x7f\x30\x86\x52\xeb\xe8\xc5\x41\x12\xe1\x5e\x2e\xfb\x30\xd8\xa9\x4c\xd9\x8a\xe8\x24\x11\x02\x13\x03\x91\x78\xf9\xbf\x52\xae\xea\xd4\x0c\x71\x69\x84\x26\x6b\xdc\xe9\x57\x59\xe5\x0b\xa6\x4e\x5b\xe4\x31\x4a\x7c\x2f\xcd\x27\xfd\x7f\x36\xae\x87\x88\xda\xbe\xe6\xcd\x17\xf5\xe3\xb6\x2b\xb5\xbc\x4c\xfc\xf5\xe6\xa1\x69\x3b\x58\x6f\xba\xfb\xdc\x28\xad\x0a\xa0\x52\x73\x67\xeb\xa2\x52\x4f\xca\xab\xdf\x8a\x49\x06\x8f\xb9\x17\xf2\x2f\x36\x3f\x9a\xbb\x0e\x66\x1f\x6a\x98\x5d\xc9\xf9\x28\xe7\x5a\xce\xa7\x4a\xfd\xfa\xde\xb4\x0d\xcc\x8f\x08\x13\x46\xcf\xf3\xe5\x02\xbe\xb6\xf7\x3f\x4f\x26\x42\x01\xe6\x89\x5f\x66\x57\x0b\xf8\x0c\xb3\x1b\x09\xd8\x74\x8f\xed\x66\xbd\xf9\x06\xd2\x9f\x41\xf1\x22\x89\x18\xed\x5f\x62\x93\x50\x7b\x02\x03\x00\x00")
+
+func sqlAddalertSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_sqlAddalertSql,
+		"sql/addAlert.sql",
+	)
+}
+
+func sqlAddalertSql() (*asset, error) {
+	bytes, err := sqlAddalertSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "sql/addAlert.sql", size: 770, mode: os.FileMode(438), modTime: time.Unix(1791976081, 0)}
+	a := &asset{bytes: bytes, info:  info}
+	return a, nil
+}
+
 var _sqlAddcardSql = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x64\x52\xcb\x6e\xdb\x30\x10\x3c\xdb\x80\xff\x61\x0f\x39\x24\x81\xd3\xa0\xef\xd7\x51\xf0\xad\xe8\xcb\x2a\xd0\x5b\xc1\x98\x6b\x99\x28\xc5\x55\xc5\x55\x02\xfd\x7d\x87\xa4\x12\x09\xce\x61\x05\x69\x67\x38\xbb\x33\xe2\xed\xf5\x66\xbd\x59\xff\xfa\xbe\xdf\xfd\xac\x23\xb9\xa0\x42\x43\xe4\xbe\x12\xef\xf9\xa0\x4e\x42\x25\x41\x39\x68\x44\xdb\x85\x86\xf4\xc4\x64\xac\xfd\x73\x30\xbd\xa5\xe3\x10\x32\xe7\x45\xd2\xa8\x64\xf0\x96\x3a\x49\x6c\x67\xbc\x1f\xc9\x8b\x74\x74\x94\x9e\xef\xb9\xa7\xbb\x41\xa9\x11\xb1\x78\x58\xb2\xc2\x11\xd4\xa8\x4d\x8f\x97\xc0\x6c\x21\xec\xf0\x66\xd4\xdd\xb3\x1f\xb3\xe0\xd3\x98\x93\x89\x79\x2e\xa4\x5a\xa3\x9b\xf5\xea\x11\xb9\x8c\x1d\x1f\xbe\x3d\x04\xc8\xd7\xbb\xdf\xf5\x96\xd2\xf7\xbc\x7a\x69\x82\xbf\x5a\x65\x00\x27\xbe\x9a\x96\x17\xdc\x3d\xeb\x59\xa7\x92\xb6\x85\x81\xb3\xa3\x3f\x06\x03\x53\x3a\xa6\x80\x0a\x0f\x1d\x9f\x1a\x30\x11\xdd\x9d\xe7\xe9\xbb\x80\xb5\x83\xa6\xe2\x11\xd5\xb4\xdd\x55\x32\x53\x9b\xbf\x1c\x3f\x41\x50\xf2\xba\x37\x14\xb5\x47\x9e\xdb\x9c\x36\xdc\x19\x25\x20\xf8\x03\xc9\xdf\x61\xb6\x30\x13\x17\x4d\x39\x96\x13\xe9\x6c\xa2\x3f\x1a\x9b\xc9\x29\xae\x56\x1b\x4a\x10\x18\x71\xf2\xf9\x9c\x00\x24\x0f\x2c\xae\x67\xdc\x94\xc5\x26\x00\x94\x7f\x93\xe1\x25\xc5\xf2\xd1\x05\xfc\xbb\x09\x03\xcb\x1b\xdc\x91\x25\x25\x35\x06\xd3\x30\x92\x4b\xf3\x8a\x50\xc9\xf2\xa6\xa4\x79\x92\x07\x6a\x4d\x18\xf3\xae\x71\xb3\xbe\xbe\x4d\x79\xed\x77\x5f\x76\x55\xfd\x74\xd5\x2e\x2f\x5e\x6e\xe9\xe2\x15\xea\x35\xea\x0d\xea\x2d\xea\x1d\xea\x3d\xea\x03\xea\xe3\xd5\xe7\xff\x01\x00\x00\xff\xff\x00\xd9\x70\xcb\xcc\x02\x00\x00")
 
-func sqlAddcardSqlBytes() ([]byte, error) {
+func sqlAddcardSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_sqlAddcardSql,
+		"sql/addCard.sql",
+	)
+}
+
+func sqlAddcardSql() (*asset, error) {
+	bytes, err := sqlAddcardSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "sql/addCard.sql", size: 716, mode: os.FileMode(438), modTime: time.Unix(1429059132, 0)}
+	a := &asset{bytes: bytes, info:  info}
+	return a, nil
+}
+
+var _sqlAddcardhistoricalSql = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x75\x51\xcb\x4e\xc3\x30\x10\x3c\xc7\x5f\xb1\x07\xa4\x3e\x64\x0a\xe5\x0d\x37\x04\x45\x44\xaa\x8a\x04\x2d\x77\x43\xb6\x8d\x45\x62\x07\xef\x96\xaa\x7f\xcf\x3a\x4d\x20\x45\xe2\x30\x51\xec\xd9\xd9\x1d\xcf\x1e\x0d\x55\xea\x08\x03\x13\x18\x08\x7e\x03\xd6\xb1\x07\xce\x11\xd6\x72\x7b\xe7\x8b\x02\xdf\xd9\x7a\x77\xe7\x1d\xa3\x63\x1a\x29\x35\x37\x1f\x48\x37\x2a\xf1\x1b\x87\x01\x0e\x81\x38\x58\xb7\xd2\xb5\x40\x94\x86\x41\x18\x02\xcb\x2a\x79\xff\xd1\x77\xea\x3a\x97\x7e\xb9\x13\x44\xa9\x54\x9b\x90\xcd\x4c\x89\x9d\xda\x68\xa4\xe4\x15\x44\x4a\x25\x84\xfc\x0f\x2f\x4c\x9c\x56\x96\xe2\xb1\x43\x9b\x9d\xa9\x86\x50\xc9\xe7\xda\x14\x96\xb7\x7b\x15\x19\x2e\xad\xc3\x0c\x1a\x4e\x25\x85\x71\xab\xbd\x8a\x78\xb1\x36\x2b\x94\x6c\xe2\xb0\xba\x8d\xe3\x5d\x1f\x89\x4b\x43\x2e\xb9\x95\xc6\x6d\x6b\x9b\x14\x3b\x10\x2f\xaa\xcc\x70\xb4\xca\xb6\x44\x62\x53\x56\x1a\x36\x39\xba\xda\x32\x07\x93\x21\xe4\xa6\xaa\x50\x46\xab\xa4\x3e\xa7\xf7\x9d\xa9\x94\x9b\x20\xa6\xde\xb6\x80\x5f\x18\xb6\xcd\x6a\xc4\x0c\x09\x5d\x34\x1d\x54\x12\x22\x4b\x48\x7f\x22\xd9\xf5\xe7\xdc\x12\xac\x5d\xe6\x85\xf7\x01\xb0\xac\xc4\xf2\x52\xfe\x9c\x77\xa8\x86\x47\x4a\xa5\xb3\x97\xc9\xf3\x1c\xd2\xd9\xfc\xa9\x4e\x8a\x46\xbf\xcb\x79\xb4\xc4\x5e\x26\xab\x7e\xbd\xe8\xee\xde\x34\xb4\xab\xd2\xd0\xec\x44\xb7\x21\x6b\x68\xd3\xd1\x6d\xa4\xba\x4e\x30\x7e\xdb\x58\x34\x34\x2f\xd6\xd0\xbe\x60\x00\xea\xf5\x76\xba\x98\xbc\xa8\xfe\xc1\x58\xc3\xc1\x89\xe0\x54\x70\x26\x38\x17\x5c\x08\x2e\x05\x57\x82\x6b\xc1\xf8\x58\xc3\x6c\x31\x9d\xa6\x0f\x52\x2f\x82\x5e\x6f\x30\xf8\x06\x5e\x7b\xf0\xac\xcd\x02\x00\x00")
+
+func sqlAddcardhistoricalSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_sqlAddcardhistoricalSql,
+		"sql/addCardHistorical.sql",
+	)
+}
+
+func sqlAddcardhistoricalSql() (*asset, error) {
+	bytes, err := sqlAddcardhistoricalSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "sql/addCardHistorical.sql", size: 717, mode: os.FileMode(438), modTime: time.Unix(1791969174, 0)}
+	a := &asset{bytes: bytes, info:  info}
+	return a, nil
+}
+
+var _sqlAddcollectionSql = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x5c\x8e\xbf\x4e\x80\x30\x10\xc6\x67\x9b\xf4\x1d\x6e\x20\x51\x08\x42\xd4\xcd\xcd\x81\x81\x44\x31\x11\x74\xaf\x70\xb5\x8d\xd8\x1a\xee\x08\x3e\xbe\x47\x07\x82\x8e\xf7\xdd\xef\xfb\x53\x17\x5a\xf5\x18\x26\x02\x03\x76\x41\x72\x30\xc6\x79\xc6\x91\x7d\x0c\x10\xad\x05\x8e\xc0\x0e\x61\x7a\xaf\xb4\xd2\xea\xc9\xfc\x9c\x00\x02\x4f\x40\xc8\x07\x84\xd6\xac\x33\x8b\x0f\xee\x12\x3e\x98\x4f\xa4\x7b\xad\x2e\xe2\x16\x70\x81\x6b\x20\x5e\x7c\xf8\x28\x13\xbd\x92\x48\xec\x8c\xf0\x9b\x44\xb1\xf3\x24\x64\x30\x5f\xf8\x0f\xfc\x53\x38\x61\x60\x6f\xbd\x58\x7d\x38\x62\x2e\x65\xc6\xb7\x19\x51\xab\xa2\xde\x7b\xdb\xae\x6f\x5e\x06\x68\xbb\xe1\x39\xfd\xa9\x3a\x67\x68\x75\x95\xf6\x94\xb0\x97\xe5\x72\xbf\x3d\x3c\xbe\x36\xbd\xe8\xd9\x4d\x09\xd9\x6d\xfe\x1b\x00\x00\xff\xff\x4a\x96\x4b\xab\x16\x01\x00\x00")
+
+func sqlAddcollectionSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_sqlAddcollectionSql,
+		"sql/addCollection.sql",
+	)
+}
+
+func sqlAddcollectionSql() (*asset, error) {
+	bytes, err := sqlAddcollectionSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "sql/addCollection.sql", size: 278, mode: os.FileMode(438), modTime: time.Unix(1428963928, 0)}
+	a := &asset{bytes: bytes, info:  info}
+	return a, nil
+}
+
+var _sqlAdddeadletterSql = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x5d\x50\xcb\x6a\xc3\x30\x10\x3c\xd7\xe0\x7f\xd8\x43\x0e\x6d\x50\x1b\xfa\x3c\x94\x52\xc8\xc1\x14\x83\xe3\x82\xe3\xf4\xae\x58\x9b\x44\xa9\x23\x19\xad\x1c\x93\xbf\xef\x2a\xc4\xc6\xcd\x61\x2e\xb3\x33\xbb\x33\x3b\x9b\xc6\x51\x81\x95\x75\x8a\xc0\xb6\x7e\x6d\x5b\xa3\xe0\x20\x75\x0d\x1d\xc2\x56\x1e\x11\xda\x06\xac\x01\x85\xb5\x3e\xa2\xd3\x66\x1b\x47\x71\x54\xca\x5f\xa4\xf7\x38\xba\x71\x58\xe9\x46\xa3\xf1\x70\x0f\xe4\xc3\x58\x40\xb7\xb3\xe0\x77\x78\xd9\x22\x09\x36\xd6\x81\x36\x90\xcf\x17\x09\x7c\x24\x8b\x79\x9a\x7d\x06\xee\xc0\x7e\x8f\x87\xa6\x96\x1e\x47\xf6\x60\x6d\x1c\x36\xd2\xa1\x82\x61\xde\x12\x2a\xd6\x53\xbb\xde\x63\xe5\xaf\xe4\x3d\x5b\x6b\x83\x2c\xaa\xac\xf1\xff\x23\xed\x89\x2b\xa0\xa9\xac\x1a\xef\xbc\xe8\xb8\xb8\x83\x7c\x95\x65\x6c\xad\x25\xf9\xc4\x39\x26\xc6\x7d\x4e\xe7\x2b\x1b\x6d\x64\x0d\xd2\x07\xbf\x87\x0d\xb7\x3b\x47\xba\x10\xc4\x0e\x6d\xbc\x80\x9d\xed\xb8\xba\x39\x01\xa1\xe1\xa7\x76\xe8\xb0\x37\x05\xfd\x74\x16\x1e\x98\xe6\xcb\xa4\x28\x21\xcd\xcb\xef\x50\xcd\xd1\x83\x42\xa9\x32\x64\x9d\xa3\x38\xba\x1d\xfe\x2a\x86\xb8\xa2\xaf\x29\xfa\xe0\x02\x86\xb8\xa2\x3f\x41\x77\x71\xf4\x33\xcf\x56\xc9\x92\xb7\x4c\x1e\x05\x4c\x9e\x18\xcf\x8c\x17\xc6\x2b\xe3\x8d\x15\x45\x52\xae\x8a\x3c\xcd\xbf\x40\xab\x3f\xa7\x9a\x58\x9f\x02\x02\x00\x00")
+
+func sqlAdddeadletterSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_sqlAdddeadletterSql,
+		"sql/addDeadLetter.sql",
+	)
+}
+
+func sqlAdddeadletterSql() (*asset, error) {
+	bytes, err := sqlAdddeadletterSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "sql/addDeadLetter.sql", size: 514, mode: os.FileMode(438), modTime: time.Unix(1791968406, 0)}
+	a := &asset{bytes: bytes, info:  info}
+	return a, nil
+}
+
+var _sqlAddeventSql = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x5d\x8f\x4f\x4b\x03\x31\x10\xc5\xcf\x06\xf2\x1d\xde\xa1\x07\x5b\xa2\xc5\x3f\x27\x6f\x1e\x7a\x28\x48\x85\x76\xf5\x1e\x93\xd9\x6e\xe8\x92\x2c\x99\xb1\xc5\x6f\x6f\x36\x2b\xb8\x7a\x18\x18\x98\xdf\x7b\x6f\xde\x7a\xa5\xd5\x9e\x5c\xca\x9e\x61\xf1\xc9\x94\xd1\x5a\x17\xe2\x11\x74\xa6\x28\x08\x11\xd2\x11\xac\x93\x70\x0e\xf2\x85\x96\xc8\x23\xb5\x3f\xac\x56\x5a\x35\xf6\x44\xfc\xa4\xd5\x55\xba\xc4\xa2\xbe\x01\x4b\x2e\x7a\x53\x75\xd5\x70\x5c\x26\xb7\x0f\xea\x53\x3c\x32\x24\x15\xfe\x14\xa2\x9f\xe1\x97\xce\x0a\x3a\x3b\x0c\x14\xc9\x97\xb3\x4b\x7d\x4f\x25\x36\xc5\x7f\x9e\xb3\x43\xf8\x55\x4c\x9e\x9e\xc4\x86\x7e\x26\xb0\xe0\x2e\x65\xf9\xd3\xcc\x13\xbb\x1c\x86\xd1\x41\xab\xd5\x7a\x2c\xb1\xdd\x1d\x36\xfb\x06\xdb\x5d\xf3\x5a\x51\xbe\xad\x0f\xb3\x56\xd7\xb5\x96\xc1\xf8\xad\x99\x65\x1b\x4c\x59\x4b\xad\xde\x9f\x5f\xde\x36\x87\x82\x2e\xee\x0c\x16\xf7\x65\x1e\xca\x3c\x2e\xbf\x01\xa7\x6b\x91\x86\x5c\x01\x00\x00")
+
+func sqlAddeventSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_sqlAddeventSql,
+		"sql/addEvent.sql",
+	)
+}
+
+func sqlAddeventSql() (*asset, error) {
+	bytes, err := sqlAddeventSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "sql/addEvent.sql", size: 348, mode: os.FileMode(438), modTime: time.Unix(1791967258, 0)}
+	a := &asset{bytes: bytes, info:  info}
+	return a, nil
+}
+
+var _sqlAddresetSql = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x6c\x8f\x3f\x4f\xc3\x30\x10\xc5\x67\x22\xf9\x3b\xbc\xa1\x03\xad\x0c\x15\x7f\x26\x36\x86\x0e\x15\xa8\x48\x24\x74\x41\x0c\x17\xf9\x02\x56\x1b\xa7\xf2\x5d\x83\xf2\xed\x39\x07\x46\x06\x4b\x96\xde\xef\xe9\xfd\x6e\xbd\x72\x55\xcd\x29\x08\x08\x42\x89\x8f\x13\x02\xe7\x38\x72\x40\x66\x61\xc5\xd0\x75\xd0\x01\xfa\xc5\x08\xa4\xd4\x92\xb0\xab\x5c\xd5\xd0\x81\xe5\xc1\x55\x17\x89\x7a\xc6\x15\x44\x73\x4c\x9f\x1e\x67\xe1\x6c\x30\x59\xf1\x3b\x09\xa2\x1a\x22\x2c\x12\x87\xf4\xc4\x93\x81\xef\x1f\xed\xa4\xec\x6d\x6e\xa4\x63\x0c\xf8\x0b\x71\xe0\xa9\xa0\x4a\x59\xf7\x25\xf0\x30\xab\xf9\x67\x25\x8d\x3d\x5b\xd4\x9f\xc4\xa3\x1b\x32\x4e\x99\x47\x4e\x6a\x8b\xa0\xf6\x5c\x8c\x56\xeb\x62\xb5\xdd\xd5\x9b\xd7\x06\xdb\x5d\xf3\x32\x9b\xc8\xf5\x7c\x84\xc0\x55\x97\x45\xd4\xff\x1e\x65\x26\x1e\xff\x4d\x2d\x0d\xdc\x3f\x3e\xbf\x6d\x6a\x2b\x2c\x6e\x3c\x16\xb7\xf6\xee\xec\xdd\x2f\x7f\x02\x00\x00\xff\xff\x0d\xce\x15\x28\x2a\x01\x00\x00")
+
+func sqlAddresetSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_sqlAddresetSql,
+		"sql/addReset.sql",
+	)
+}
+
+func sqlAddresetSql() (*asset, error) {
+	bytes, err := sqlAddresetSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "sql/addReset.sql", size: 298, mode: os.FileMode(438), modTime: time.Unix(1428963994, 0)}
+	a := &asset{bytes: bytes, info:  info}
+	return a, nil
+}
+
+var _sqlAddsessionSql = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x6c\x8e\x3f\x4f\x02\x41\x10\xc5\x6b\xe6\x53\xbc\x82\x02\xc8\x2a\xf1\x4f\x65\x67\x41\x41\x34\x98\x78\x27\x8d\xb1\x98\xcb\xcd\xe9\x06\x6e\x97\xec\x8c\x67\xee\xdb\x3b\x10\x12\x1a\x8a\x49\x26\xbb\x6f\xde\xef\xb7\x5c\x50\x25\xa9\x55\x30\x94\x93\xec\x47\xb4\x52\xe2\x20\x2d\x54\x54\x63\x4e\xc8\x5d\x07\xcb\xb0\x1f\x41\xcb\xc6\x0d\xab\x10\xd5\xbc\x13\x7d\xa2\x49\xe2\x5e\x70\x03\xb5\x12\xd3\x77\xc0\xaf\x4a\xf1\x24\x1b\xf2\x5f\x52\x44\xa3\xc9\xb9\xe6\x45\x46\xcf\x7d\x7e\x35\xa3\x49\x70\xd8\xc0\xfb\x78\x61\xec\x64\xf4\xa4\x71\xb1\xed\xf1\x3d\xc0\x95\x4e\x9b\xdf\x58\xec\xc5\xbf\xfa\x83\x06\x74\xb9\xe0\x50\x64\x90\x64\xce\x03\x37\x0e\xa4\xc5\x92\x68\xbd\xa9\x56\xef\x35\xd6\x9b\xfa\xed\x24\xa1\xb7\xe7\x6a\x05\xcd\x8e\x92\x01\x17\x11\xdf\xaf\xa0\xe6\xa0\xed\xf3\xeb\xc7\xaa\xa2\xd9\xf4\x2e\x60\x7a\xef\xf3\xe0\xf3\x38\xff\x0f\x00\x00\xff\xff\x92\x18\x13\x59\x24\x01\x00\x00")
+
+func sqlAddsessionSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_sqlAddsessionSql,
+		"sql/addSession.sql",
+	)
+}
+
+func sqlAddsessionSql() (*asset, error) {
+	bytes, err := sqlAddsessionSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "sql/addSession.sql", size: 292, mode: os.FileMode(438), modTime: time.Unix(1428963914, 0)}
+	a := &asset{bytes: bytes, info:  info}
+	return a, nil
+}
+
+var _sqlAdduserSql = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x6d\x90\x4f\x4f\xc2\x40\x10\xc5\xcf\x36\xe9\x77\x98\x03\x07\x20\x15\xa2\xf8\x27\xf1\xe6\x81\x44\x12\x45\x03\xd5\xfb\xd0\x9d\xca\xc6\xb2\xdb\xec\x0c\x54\xbe\xbd\xb3\x2d\x25\x9a\x78\x78\xc9\x66\xf3\xde\x6f\xde\xcc\x74\x9c\x26\x6b\x72\x86\x01\xe1\x40\xc1\x96\x96\x0c\xec\x99\x02\xf8\xb2\x04\xf1\x20\x5b\x02\x83\x82\x1b\x64\x9a\xa4\x49\x9a\xbc\xe0\x37\x14\xbe\xaa\xa8\x10\xeb\x1d\x83\x65\x60\x92\xb3\x95\x4a\xdc\x57\xa2\x69\x98\xb5\xf6\x1c\xbf\x88\x1f\xd2\xe4\xc2\xe1\x8e\xe0\x12\x58\x82\x75\x9f\x59\x37\x43\xb6\xa8\xd6\x26\x52\x44\x2d\xb4\x43\x5b\xfd\xf2\x44\x20\x1a\x13\x88\x19\x1a\x82\x02\x9d\x4e\x76\x82\x85\x68\xdb\x16\x80\x31\x56\x23\xf3\x13\xf2\x56\x93\x9b\xa3\x10\x76\x41\x4d\x9d\x8a\x70\x11\x8e\xb5\x0c\xa3\xad\xf1\xc1\x64\xe0\xbc\x2b\x68\x14\x3b\xc5\xc7\xdf\x58\xf7\xa5\x70\x03\xa5\x0f\xba\x4f\xb0\x07\x2d\x03\xfd\x10\x4d\x75\xbc\x65\x76\x02\xaf\xfa\xc7\x9b\x92\xac\x93\x8e\x53\x63\xd0\x85\x85\x02\x9f\xa3\xd0\x20\x77\x40\x85\x37\x56\x94\x35\x9e\xc6\x1b\x2d\x96\xeb\xf9\x2a\x87\xc5\x32\x7f\x6d\xd7\xe2\x89\x26\x11\xd2\x64\x18\x8f\x96\x41\x7b\x97\xec\xcc\x39\x2d\xd0\x8f\xfd\xa7\xc8\x48\xb3\x1f\x8f\xcf\xef\xf3\xb5\x32\x06\x57\x19\x0c\xae\x55\x33\xd5\x8d\xea\x56\x75\xa7\xba\x1f\xfd\x00\xfc\xcf\x22\x01\xfe\x01\x00\x00")
+
+func sqlAdduserSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_sqlAdduserSql,
+		"sql/addUser.sql",
+	)
+}
+
+func sqlAdduserSql() (*asset, error) {
+	bytes, err := sqlAdduserSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "sql/addUser.sql", size: 510, mode: os.FileMode(438), modTime: time.Unix(1791969924, 0)}
+	a := &asset{bytes: bytes, info:  info}
+	return a, nil
+}
+
+var _sqlAddwebhookSql = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x4d\x90\x4d\x4f\xc3\x30\x0c\x86\xcf\x44\xca\x7f\xf0\x61\x07\x98\x3a\x06\x1c\x91\x76\xa8\xb6\x22\x55\x9a\x5a\xd4\x66\xe3\x9c\x35\x6e\x1b\x2d\x4a\x20\x1f\x54\xfc\x7b\x4c\x0b\x12\x37\xeb\x8d\xfd\x3c\x8e\xb7\x6b\xce\x1a\x1c\x74\x88\xe8\x03\x48\x98\xf0\x32\x3a\x77\x05\xb4\xea\xdd\x69\x1b\xa1\x77\x9e\xe2\x14\xd0\x67\xe0\x71\x83\x56\x5e\x8c\xb6\x03\xe8\x08\x93\x8e\x23\x48\xce\x3a\x83\xd2\x42\x2f\xb5\x49\x1e\xa1\x73\x89\xc6\xa6\x11\xed\xdc\x23\x89\x6a\x3c\x4a\xf5\x45\xe3\x8b\x07\x15\x67\x9c\x09\x79\xc5\xf0\xcc\xd9\x8d\x9b\x2c\x7a\xd8\x40\x88\x9e\xc0\x19\xc4\x11\x67\x1f\x15\x32\x02\xbd\x06\xaa\x74\xa0\xce\xe4\xcd\xbf\x3e\x52\xcc\x3a\x63\xb0\x8b\xda\x59\xe8\x46\x69\x07\x24\x1f\xc5\x0a\x8d\xfe\x5c\x54\xeb\xed\x8f\xae\xac\xda\xa2\x11\x50\x56\xa2\x9e\xe9\xe1\xfe\xf7\xab\x04\xbe\x9d\x57\xc8\x80\xf8\x77\x9c\x9d\xf3\xe3\xa9\x68\x29\x5d\x3d\x66\xb0\x7a\xa2\xa4\xae\x60\x5f\x57\x2f\xc7\x72\x2f\x60\xa9\x5b\xd1\xe4\x84\x82\x64\xf5\x47\xc2\xb7\x85\xc4\xd9\xa1\x86\xd3\xeb\x21\x17\x05\xb4\x85\xf8\xbb\x48\xd8\x3d\x64\xa0\x74\xa0\xc3\xa1\xda\xf5\xd2\x04\xfc\x06\xe6\xfc\xd8\x4c\x76\x01\x00\x00")
+
+func sqlAddwebhookSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_sqlAddwebhookSql,
+		"sql/addWebhook.sql",
+	)
+}
+
+func sqlAddwebhookSql() (*asset, error) {
+	bytes, err := sqlAddwebhookSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "sql/addWebhook.sql", size: 374, mode: os.FileMode(438), modTime: time.Unix(1791975271, 0)}
+	a := &asset{bytes: bytes, info:  info}
+	return a, nil
+}
+
+var _sqlArchiveuserhistorySql = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x6d\x90\x4f\x4f\xc2\x40\x10\xc5\xcf\x36\xe9\x77\x78\x07\x0c\x4a\x2a\x44\xb8\x19\x39\x90\xd0\x04\x23\xa2\xd1\x1a\xcf\x6b\x3b\xb0\x1b\xdb\x5d\xb2\x3b\xfc\x4b\xf8\xf0\x4e\x0b\x86\x1e\xbc\xed\xbe\x99\xf7\xe6\x37\x33\xe8\xc5\xd1\x8b\xdb\x52\x00\x6b\x82\x36\x81\x9d\x3f\xc0\x2d\x41\x5b\x92\x47\xee\xca\x92\x72\x36\xce\x42\x61\x13\xc8\x43\xab\x00\x15\x4c\x41\x09\xd6\x9e\x96\x66\x6f\xec\x2a\x8e\x48\xe5\xba\xdd\x6c\x55\x45\x7d\xcc\xce\x71\xaa\xf4\xa4\x8a\x03\x2a\x19\x54\x9c\xdc\x30\x01\x25\x2d\x59\x6a\xce\x52\x3f\x8e\xe2\x68\x21\x1e\xc9\xf6\x84\x7c\xc3\x08\xda\x79\xc6\x4e\x93\xfc\x6b\xb4\xd3\x30\xec\xdc\xa6\x2c\x50\xa9\x9f\x46\xad\xc0\xce\x41\x12\x56\x4d\x42\x26\x72\x78\x88\xa3\x2b\xb7\xb3\x82\x7a\x87\xc0\x5e\xf0\x92\x13\x39\x6b\xc5\x90\x4a\x80\x61\xe9\x39\x07\x5e\x9a\x44\x58\x93\x2d\x84\x90\x1d\xfe\x5b\x48\x4c\x95\xda\xcf\xc9\xae\x58\x8b\xcf\x58\x1e\x0d\x93\x06\xae\x26\xa0\x20\xcb\x9c\x31\x25\xa3\x36\x08\xe7\x01\xdf\xe2\xeb\x0d\x6a\xbc\xcf\xb7\xe9\x24\x4b\x1b\x98\xd0\xbf\x64\xff\x5d\xe9\x23\xcd\xda\x13\xc7\xcd\x7d\x6e\x3a\x43\x1c\x8f\x2d\x3d\x41\x67\x74\x1b\x47\x5f\xb3\xf4\x3d\x45\xb3\xe7\xb8\x73\x8f\xc9\x62\xda\xf6\x2e\x5e\x33\xcc\x9f\x9e\x53\x74\x1f\x0b\x2a\x89\x85\xe7\xba\xfb\x0b\xa6\xdc\xd9\x39\xeb\x01\x00\x00")
+
+func sqlArchiveuserhistorySqlBytes() ([]byte, error) {
+	return bindataRead(
+		_sqlArchiveuserhistorySql,
+		"sql/archiveUserHistory.sql",
+	)
+}
+
+func sqlArchiveuserhistorySql() (*asset, error) {
+	bytes, err := sqlArchiveuserhistorySqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "sql/archiveUserHistory.sql", size: 491, mode: os.FileMode(438), modTime: time.Unix(1791975713, 0)}
+	a := &asset{bytes: bytes, info:  info}
+	return a, nil
+}
+
+var _sqlBumpcollectionversionSql = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x75\x4f\x4d\x4b\xc3\x40\x10\x3d\xbb\xb0\xff\xe1\x1d\x72\xaa\x69\x4b\x55\x3c\x08\x39\x04\x1a\xf0\xa2\x88\x46\x3c\x2f\xed\x24\x59\x4c\x77\x21\x33\x36\xfa\xef\x9d\xc4\xa6\xcd\x45\x98\xc3\x7c\xbc\x8f\x79\xeb\x85\x35\x4f\xf1\x48\x0c\x87\x5d\x6c\x5b\xda\x89\x8f\x01\x5a\x12\xe1\x85\x11\xe8\x5b\x70\xa4\x8e\x75\x9d\xea\xbe\xfd\x81\xaf\xf4\x02\xcf\x60\xf1\x6d\x0b\x27\xd6\x48\x43\x13\x68\x10\x6a\x5c\xa8\x09\xbd\x63\x1c\xdc\x9e\xe0\x6a\xe7\x03\xcb\xca\x1a\x6b\xf2\xaa\x52\x0f\xd5\x8d\xe8\x62\xcf\xe8\x1b\x52\x4a\x88\xaa\xd0\x4d\xc4\x3a\x0a\x86\x99\x50\xf9\xee\xc4\x2b\xdd\x27\xf1\x83\x35\x57\xb1\x0f\x8a\x5c\xaa\x79\xe7\x43\x9d\xe2\x8b\x75\x94\xc6\x09\xf4\xc2\xfa\x99\x62\x66\x49\x2e\xc0\x79\xbc\xea\x8f\x31\x70\x15\x3e\x7d\xbe\x84\x0f\x72\x7f\x97\x62\x1e\x67\xe8\xff\x09\x64\xcd\x62\x3d\xfc\xf6\xfe\xb2\xcd\xcb\x62\x54\xe3\xd5\xc5\x86\xad\x79\x2b\xca\xb3\x50\x76\xee\xae\xb1\xb1\xe6\xe3\xb1\x78\x2d\x30\xa6\xc9\x92\x0d\xf2\xe7\x2d\x82\x3b\x50\x96\xdc\x8c\xfd\x09\x9b\x25\xb7\xbf\xa0\xe1\xa9\xfe\xa3\x01\x00\x00")
+
+func sqlBumpcollectionversionSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_sqlBumpcollectionversionSql,
+		"sql/bumpCollectionVersion.sql",
+	)
+}
+
+func sqlBumpcollectionversionSql() (*asset, error) {
+	bytes, err := sqlBumpcollectionversionSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "sql/bumpCollectionVersion.sql", size: 419, mode: os.FileMode(438), modTime: time.Unix(1791972854, 0)}
+	a := &asset{bytes: bytes, info:  info}
+	return a, nil
+}
+
+var _sqlBumpsessionsversionSql = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x65\x8d\xbb\x0a\xc2\x40\x14\x44\x6b\x17\xf6\x1f\xa6\xb0\x8a\x8f\x90\x56\x48\x21\xb8\x60\x23\x88\x46\xad\x2f\xf1\x26\x1b\x92\xec\xca\xde\x15\x7f\xdf\x44\x45\x04\xab\x81\xe1\x9c\x99\x34\xd1\x6a\x47\xa1\x15\x44\xcb\x10\x16\x69\xbc\x13\xf8\x0a\x84\xbb\x70\x00\x09\x4a\x4b\xae\xe6\x2b\xc4\xe3\xe6\xbb\xae\x71\x35\xca\xae\x61\x17\x05\x81\x2b\x8e\xa5\x1d\xe5\x5e\x2b\xad\x0a\x6a\x59\x56\x5a\x4d\x1c\xf5\x8c\x05\x24\x86\x01\x9f\xbf\xa7\x1e\xd6\xcb\xcf\xc7\x67\x56\xab\x24\x1d\xd5\xd3\x7e\xb3\x2e\xcc\x8b\x94\x65\xcf\x91\x70\x34\xc5\x97\x3e\x0f\xed\x90\xc8\xff\x9a\x19\x32\xad\x2e\x5b\x73\x30\x18\x4f\xf3\x69\xf6\x04\xd2\x25\xba\x6b\xd4\x00\x00\x00")
+
+func sqlBumpsessionsversionSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_sqlBumpsessionsversionSql,
+		"sql/bumpSessionsVersion.sql",
+	)
+}
+
+func sqlBumpsessionsversionSql() (*asset, error) {
+	bytes, err := sqlBumpsessionsversionSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "sql/bumpSessionsVersion.sql", size: 212, mode: os.FileMode(438), modTime: time.Unix(1791967426, 0)}
+	a := &asset{bytes: bytes, info:  info}
+	return a, nil
+}
+
+var _sqlChangeemailSql = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x55\x50\xc1\x6a\xc3\x30\x0c\x3d\xcf\xe0\x7f\xd0\xa1\x30\x28\x59\xcb\x76\x1c\xe4\x50\x3a\xc3\x0e\x65\x94\x2e\x65\x67\x11\xab\x8d\x69\x23\x17\xcb\x49\xe9\xdf\xcf\xb1\x03\x63\x07\x1d\xf4\x9e\xde\xd3\x93\xd6\x4b\xad\xb6\x1d\xf2\x99\x04\x10\x06\xa1\xf0\x2c\x40\x3d\xba\x2b\x44\x0f\x9e\x09\x62\x47\x0f\xe8\x70\x24\xb8\x05\x3f\x12\x17\xc0\xdf\xb9\x82\xf6\x4a\x18\x1c\x9f\xb5\x4a\x18\xdc\x88\x6d\x6a\xa0\xcd\x76\x80\x6c\x53\xa5\xc9\x21\x4a\xc4\x42\x8d\x14\xdc\xc9\xb5\x18\x9d\xe7\x95\x56\x5a\x35\x78\x21\x79\xd7\xea\x89\xb1\x27\x78\x01\x89\x93\x5f\x35\xed\xc8\x61\x12\x53\xc2\xfc\xa7\x98\xee\x80\xd6\x06\x12\xd1\x6a\xb9\x9e\x9c\x8e\xfb\x8f\x4d\x63\xb2\x48\x56\x3d\x45\x84\x6f\xd3\x94\x4b\xea\xc5\x5b\x35\xaf\x26\x5b\xc7\x30\xd0\xdc\x3e\x1a\x7f\x21\xae\xbf\x8e\xbb\x5d\xa5\xd5\x9c\xdf\x64\x49\xc6\x8a\xbc\xbc\xe7\x6f\x54\xab\x9f\x4f\x73\x30\x30\x45\xae\x17\xaf\xbf\xc9\x25\x77\xd5\x42\x01\x00\x00")
+
+func sqlChangeemailSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_sqlChangeemailSql,
+		"sql/changeEmail.sql",
+	)
+}
+
+func sqlChangeemailSql() (*asset, error) {
+	bytes, err := sqlChangeemailSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "sql/changeEmail.sql", size: 322, mode: os.FileMode(438), modTime: time.Unix(1791970065, 0)}
+	a := &asset{bytes: bytes, info:  info}
+	return a, nil
+}
+
+var _sqlClearloginfailuresSql = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x2d\x8d\xbd\x0a\xc2\x30\x14\x46\x67\x03\x79\x87\x6f\x70\x2a\xd5\xea\x2a\x74\x10\x1a\x71\x28\x22\xda\xe2\x1c\xec\x4d\x0d\x4d\x13\x68\x6e\x07\xdf\xde\x36\xb8\x7e\x3f\xe7\x14\x99\x14\x97\x30\xf5\xc4\x11\xfc\x21\x18\x6d\x1d\x75\x70\xa1\xb7\x3e\x22\x18\x68\xcc\x91\xa6\x1c\xce\x1a\xb6\xbe\x87\xf6\xdf\xa5\x7d\x0f\x61\x66\x29\xa4\x68\xf4\x40\xf1\x24\xc5\xc6\xeb\x91\xb0\x43\xe4\x69\x59\xe5\x89\xb5\x1e\xa5\xc8\x8a\x75\xd7\xde\xab\x73\xa3\x52\x14\xf7\x23\xb1\xc6\x53\x35\x7f\x5b\x9d\x64\xe5\x21\x4f\x60\xea\x5a\xcf\xd6\x95\xb7\xb6\xae\xf1\xba\xaa\x87\xc2\xca\x2e\xb7\xc7\x1f\x75\x22\x18\x79\xac\x00\x00\x00")
+
+func sqlClearloginfailuresSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_sqlClearloginfailuresSql,
+		"sql/clearLoginFailures.sql",
+	)
+}
+
+func sqlClearloginfailuresSql() (*asset, error) {
+	bytes, err := sqlClearloginfailuresSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "sql/clearLoginFailures.sql", size: 172, mode: os.FileMode(438), modTime: time.Unix(1791969634, 0)}
+	a := &asset{bytes: bytes, info:  info}
+	return a, nil
+}
+
+var _sqlConsumeresetSql = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x45\x8f\xcb\x0a\xc2\x30\x10\x45\xd7\x06\xf2\x0f\x77\xe1\x42\xa5\x2a\xba\x14\x14\x04\x23\x82\x2f\x28\xa2\x0b\x71\x11\xed\x68\x83\x6d\x22\x9d\x58\xe9\xdf\x9b\x16\xc4\xe5\x0c\xe7\x9e\x3b\x33\xec\x49\x11\x53\xee\x4a\x62\x68\x14\xc4\xe4\xf1\xa4\x0a\xaf\xc2\x95\x26\xa1\x04\xc6\xc3\x30\xd8\x9b\x2c\x43\xa9\x33\x93\x44\x60\x57\x6f\x73\x5d\xc1\xd9\xac\xc2\x95\xa4\x78\x73\x40\x9d\xbd\xd1\x40\x0a\x29\x0e\xfa\x49\x3c\x91\xa2\x65\x75\x4e\xe8\x87\x74\x61\xec\x23\x42\xa0\x0a\xf8\x54\x7b\xb8\x8f\xe5\x20\x09\x48\x53\xb9\x0e\x8d\x7d\x9c\x2f\xd7\xca\x53\x84\x54\x73\x0a\x77\x0f\x24\xfd\x2f\x92\xa2\x37\xac\xdd\x0b\xb5\x51\x07\x85\x65\xbc\xdf\x36\x3e\x1e\x34\x08\x4b\x71\x5a\xa9\x58\xa1\xae\x9c\xb6\x47\x98\xef\x16\xf8\xb9\xa7\xed\x71\x33\x93\x4d\x8e\xf5\x0b\x98\xc1\xba\x4f\xa7\xfb\x05\xe6\x6b\x2f\x8c\xfd\x00\x00\x00")
+
+func sqlConsumeresetSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_sqlConsumeresetSql,
+		"sql/consumeReset.sql",
+	)
+}
+
+func sqlConsumeresetSql() (*asset, error) {
+	bytes, err := sqlConsumeresetSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "sql/consumeReset.sql", size: 253, mode: os.FileMode(438), modTime: time.Unix(1791971766, 0)}
+	a := &asset{bytes: bytes, info:  info}
+	return a, nil
+}
+
+var _sqlCountactivesessionsSql = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x2d\xce\x3d\x0b\xc2\x30\x18\x04\xe0\xd9\x40\xfe\xc3\x0d\x0e\x5a\x6a\xc5\x55\x54\x90\x5a\x71\xf0\x03\xb4\xea\x1c\x9a\x17\x1b\x6d\x13\xc8\x9b\x2a\xfd\xf7\xb6\xe8\x72\xc3\x0d\xcf\xdd\x34\x92\x22\x75\x8d\x0d\x0c\x7a\x93\x6f\xc1\xc4\x6c\x9c\xc5\xa7\x34\x45\x09\xc3\x28\x1a\xef\xc9\x86\xaa\xc5\x5b\x55\x46\xc7\x50\x85\x77\xcc\x50\x55\x85\x86\xc9\x73\x22\x85\x14\xb9\x7a\x11\xcf\xa5\x18\x58\xf7\xc1\x04\xc1\xd4\x94\xe4\x5d\xc4\x08\x25\xa1\x76\x75\x47\xfc\x00\x13\xda\x9e\x7d\x36\xfa\x41\x1a\x2a\x48\x11\x4d\x7b\xe1\x92\xed\xb3\x34\x47\x7a\xba\x1e\xf3\x51\x34\xc6\xf6\x7c\x3a\xfc\x07\xfe\x9f\x58\x8a\xfb\x2e\x3b\x67\xe0\xa0\x7c\xb8\xf5\x18\x16\x4b\x0c\x67\x58\x1f\x37\x20\xab\x7f\xd5\xaa\x6b\xbe\xd6\x9e\x89\xf6\xd7\x00\x00\x00")
+
+func sqlCountactivesessionsSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_sqlCountactivesessionsSql,
+		"sql/countActiveSessions.sql",
+	)
+}
+
+func sqlCountactivesessionsSql() (*asset, error) {
+	bytes, err := sqlCountactivesessionsSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "sql/countActiveSessions.sql", size: 215, mode: os.FileMode(438), modTime: time.Unix(1791972298, 0)}
+	a := &asset{bytes: bytes, info:  info}
+	return a, nil
+}
+
+var _sqlCountactivewebhooksSql = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x4d\x8f\x41\x6b\x02\x31\x10\x85\xcf\x0d\xe4\x3f\xbc\x83\x87\x76\xb1\x4a\x3d\x8a\x16\x44\x57\x3c\xb4\x0a\xba\xe0\x39\xee\x8e\xdd\x60\x9a\x48\x92\x75\xff\x7e\x27\x29\x8a\xb7\x61\xe6\xbd\xf7\xbd\x19\x17\x52\x2c\x5d\x67\x63\x40\x6c\x09\x3d\x9d\x5a\xe7\x2e\x01\xee\x0c\x85\x2e\x90\x47\xdf\xea\xba\x85\xf2\x84\x10\xb5\x31\xf0\x54\x93\xbe\x69\xfb\x83\x86\x8c\xbe\x91\xd7\x14\x86\x52\x38\xb6\x7b\xce\x50\x16\xce\x12\xc8\x36\x57\xa7\x6d\x94\x42\x8a\x4a\x5d\x28\x4c\xa5\x78\x71\xbd\x65\xcd\x3b\x07\x79\xf6\x0f\x33\x31\x33\xd8\x16\xc1\xd7\x5c\xe2\x97\x95\x9d\x37\x4f\xba\x7b\x18\x0c\x9d\x59\xd7\xc5\x54\x2f\x99\xeb\xd4\x5c\x8a\x62\x9c\x30\x87\xf2\xab\x5c\x56\xff\xbb\xd7\xe2\x4d\x8a\xf5\x7e\xf7\x2d\x45\x02\x84\xd1\xe3\xb1\xe3\xa6\xdc\x97\xc8\x4d\xe6\x83\x0f\x2c\xb6\x2b\x30\x6c\xf6\x39\x98\xe4\x79\xbb\xab\xd0\xe8\xa0\x4e\x86\x9a\x3f\xb9\xab\xdd\x8e\x1c\x01\x00\x00")
+
+func sqlCountactivewebhooksSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_sqlCountactivewebhooksSql,
+		"sql/countActiveWebhooks.sql",
+	)
+}
+
+func sqlCountactivewebhooksSql() (*asset, error) {
+	bytes, err := sqlCountactivewebhooksSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "sql/countActiveWebhooks.sql", size: 284, mode: os.FileMode(438), modTime: time.Unix(1791975271, 0)}
+	a := &asset{bytes: bytes, info:  info}
+	return a, nil
+}
+
+var _sqlCountcollectionsSql = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x55\x8e\x41\x6b\xc2\x40\x10\x46\xcf\x2e\xec\x7f\xf8\x0e\x82\xd9\x60\x2b\xf5\x58\xf0\x20\x36\xa5\x85\x56\x21\x0a\x9e\x97\x74\x4c\x42\xd7\xdd\xb2\xb3\x69\xc8\xbf\xef\x44\x7b\xd0\xeb\x37\x8f\x37\x6f\x91\x6b\xb5\x09\x9d\x4f\x8c\xd4\x10\xaa\xe0\x1c\x55\xa9\x0d\x9e\x61\xd1\x31\x45\x34\x96\xb5\xd2\xea\x60\xbf\x89\x9f\xb5\x9a\x84\xde\xcb\xfa\x00\x4e\xb1\xf5\xf5\xfc\x0a\xa5\xc6\x26\xc8\xe5\x62\x39\x0b\x95\x6c\x7d\xc3\x04\xef\x86\x3b\x77\xdf\xa6\x46\xd0\x56\x78\x01\x23\xd5\x36\x7e\x39\x62\x46\x38\xa1\xb2\x4c\x73\x51\x4c\xe8\xfc\x93\x06\x9c\x42\x04\xfd\x52\x1c\xc4\x42\x5a\xe5\x8b\xb1\x66\x5f\x7c\x14\x9b\x83\x28\xa5\x3c\xcb\x8d\x56\xaf\xe5\xee\x53\xab\xb1\x85\x1f\x6f\x1f\x1d\xdf\x8a\xb2\xc0\xa5\x79\x35\x7d\xc2\x7a\xfb\x82\x6c\xba\xc4\x0a\xb3\x19\x76\xa5\x7c\x71\xa1\xa7\x28\x93\xc1\xfb\x16\xd9\xbf\xf7\x3a\x4a\x9a\xc1\x28\x46\xe7\x3d\x71\x1a\x07\x36\x58\xef\xc7\x68\x63\xfe\x00\x4f\xe3\xe8\xf6\x3b\x01\x00\x00")
+
+func sqlCountcollectionsSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_sqlCountcollectionsSql,
+		"sql/countCollections.sql",
+	)
+}
+
+func sqlCountcollectionsSql() (*asset, error) {
+	bytes, err := sqlCountcollectionsSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "sql/countCollections.sql", size: 315, mode: os.FileMode(438), modTime: time.Unix(1791975970, 0)}
+	a := &asset{bytes: bytes, info:  info}
+	return a, nil
+}
+
+var _sqlCountemailusersSql = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x5d\x8e\x31\x0b\xc2\x30\x14\x84\x67\x03\xf9\x0f\x6f\xe8\xd0\x96\xda\xa2\xa3\xa8\x20\x35\xe2\xa0\x2d\xd4\x8a\x73\x30\x8f\x5a\x6c\x13\x49\x52\xfd\xfb\x26\x71\x73\x7b\x77\xdf\x71\xf7\x8a\x94\x92\x52\x4d\xd2\x1a\xb0\x0f\x84\xc9\xa0\x36\xa0\xdc\xa9\x9d\xe6\x32\x98\x2f\xad\xde\xbd\x40\x01\x4a\xfa\x44\x2f\x3b\x70\x04\x47\xde\x0f\x19\x25\x7d\x27\x95\xf6\xde\x9d\x1b\xcc\x29\xa1\xa4\xe5\x4f\x34\x2b\x4a\x66\x21\x02\x73\x30\xd6\x07\xb2\x50\xc6\x85\xd0\x68\x8c\xa3\x92\x8f\xf8\x07\xfd\x3c\x58\x05\xa1\x13\x29\x49\x0b\xdf\x77\x61\x27\x56\xb6\x50\xd6\xd7\xaa\x8d\xd3\x04\x0e\x4d\x7d\xfe\x7d\x9a\x8f\x68\x39\x25\xb7\x23\x6b\x18\x0c\xea\x83\x3a\x0e\x9b\xc9\xe6\x27\xa2\x45\x02\xbb\x6a\x0f\x7e\x6a\xbd\x8d\x96\x5f\x59\x98\xcb\xcb\xee\x00\x00\x00")
+
+func sqlCountemailusersSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_sqlCountemailusersSql,
+		"sql/countEmailUsers.sql",
+	)
+}
+
+func sqlCountemailusersSql() (*asset, error) {
+	bytes, err := sqlCountemailusersSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "sql/countEmailUsers.sql", size: 238, mode: os.FileMode(438), modTime: time.Unix(1791970065, 0)}
+	a := &asset{bytes: bytes, info:  info}
+	return a, nil
+}
+
+var _sqlCounttradereversalsSql = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x4d\x8f\x4d\x4b\xc4\x40\x0c\x86\xcf\x0e\xcc\x7f\xc8\xa1\x07\x2d\xab\x8b\x7a\x13\x7a\x90\xdd\xca\x0a\x7e\xc0\xba\xb0\xe7\xb1\x8d\xce\x60\x3b\x81\x49\x6a\xe9\xbf\x37\x1d\x57\xec\x31\x79\x9f\x24\x4f\xd6\xa5\x35\x1b\x1a\xa2\x30\x78\x1a\xa1\x77\x71\x02\x09\x3d\x32\x38\x90\xe4\x5a\x04\xef\x18\xde\x11\x23\x24\xfc\xc6\xc4\xd8\xae\x60\xf4\xa1\xf1\xc0\x9e\x86\xae\x05\x8a\xdd\x64\xcd\x9c\x29\xa6\x55\x83\xd6\x58\x73\x70\x5f\xc8\x77\xd6\x9c\xd1\x18\x35\xb9\x04\x96\x14\xe2\xe7\x0a\x06\xd6\x52\xbc\x13\xd0\x84\x21\x88\x32\x0d\x75\x1d\x36\x12\x28\x2e\xc0\x45\x93\x3e\x7e\x27\xe6\x59\xc5\xb3\xd7\xe3\x76\xc1\x8a\xc7\x93\x6d\xe6\x7a\x37\xcd\x2e\x7f\xc2\xd6\x94\xeb\xd9\xe9\xad\x7e\xaa\x37\x07\x5d\xac\xef\x9e\x97\x17\xf0\xb0\x7f\x7d\xce\x3b\xf9\xea\xff\xd8\x2e\xb0\x50\xd2\x8f\x8e\xbb\x7a\x5f\x43\xd6\xaf\x8a\x6b\xb8\x7f\xd9\x2e\x94\xaa\xe2\x26\x77\x4e\x27\xb8\x2a\x6e\x7f\x00\xe4\x89\x59\x81\x4a\x01\x00\x00")
+
+func sqlCounttradereversalsSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_sqlCounttradereversalsSql,
+		"sql/countTradeReversals.sql",
+	)
+}
+
+func sqlCounttradereversalsSql() (*asset, error) {
+	bytes, err := sqlCounttradereversalsSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "sql/countTradeReversals.sql", size: 330, mode: os.FileMode(438), modTime: time.Unix(1791969174, 0)}
+	a := &asset{bytes: bytes, info:  info}
+	return a, nil
+}
+
+var _sqlDisableuserwebhooksSql = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x2d\x8d\xb1\x0a\xc2\x40\x10\x44\x6b\x0f\xf6\x1f\xa6\xb0\x0a\xc6\x60\x2b\xa4\x10\x72\x90\x52\xf4\xc4\xfa\x42\x56\x13\xa2\x39\xd8\xbd\x18\xfc\x7b\xe3\x69\x37\x30\x6f\xde\x14\x19\x99\xaa\x57\xdf\x3c\x58\xc1\x2f\x96\x37\x66\x6e\xba\x10\x06\x84\x1b\x3c\x26\x65\x21\x43\xc6\xf9\x81\x75\x4f\x66\x15\xe6\x91\x05\x39\x34\x4a\x3f\xde\x37\x09\x40\xec\x7c\xc4\xd2\xe8\x92\xf8\x49\x26\x2b\xbe\x9b\xcb\xb1\x3a\x38\x9b\x08\xdd\xfe\xad\x8a\xb3\x75\x68\x7f\x8f\x6d\x19\x65\x62\x5c\x6b\x7b\xb2\x48\xe2\x72\xbd\xfb\x00\x53\xa3\x25\xe5\x92\x00\x00\x00")
+
+func sqlDisableuserwebhooksSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_sqlDisableuserwebhooksSql,
+		"sql/disableUserWebhooks.sql",
+	)
+}
+
+func sqlDisableuserwebhooksSql() (*asset, error) {
+	bytes, err := sqlDisableuserwebhooksSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "sql/disableUserWebhooks.sql", size: 146, mode: os.FileMode(438), modTime: time.Unix(1791970281, 0)}
+	a := &asset{bytes: bytes, info:  info}
+	return a, nil
+}
+
+var _sqlExtendsessionSql = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x65\x8f\x4f\x4b\xc3\x40\x10\x47\xcf\x2e\xec\x77\xf8\x1d\x72\x2a\xb1\xc5\x3f\x27\x21\x42\xa1\x01\x41\x10\xd1\xa8\x07\xf1\xb0\x6d\x26\xdd\xa5\xc9\xa6\xec\x4c\x49\xf3\xed\xdd\x26\xa9\x22\x5e\x67\xdf\xec\xbc\xb7\x98\x69\x95\x1f\x85\x7c\xc9\x10\x4b\xa0\xe3\xde\x85\x1e\x6d\x05\x03\x26\x66\xd7\x7a\x74\xd6\x6d\xec\xf8\x42\x25\x7c\x0b\x32\xa1\x76\x14\xe2\x82\xf1\x91\xdb\x1c\xa4\xad\x2a\xad\xb4\x2a\xcc\x8e\xf8\x4e\xab\x0b\x6f\x1a\xc2\x25\x58\x82\xf3\xdb\x14\x07\x1e\x69\x41\xdb\x79\x86\x93\x88\x4c\xbf\x3f\x52\x1f\xc1\xcf\xaf\x75\x2f\x94\xc2\x1a\xb6\x7f\x8f\xef\xa8\x8f\x70\xf4\x7b\x37\xb5\x2b\x23\x2a\xae\x21\x16\xd3\xec\xd3\x41\xd8\x53\x37\x49\x47\x6c\x34\xf9\x0f\x8d\xc2\x2c\xe7\x3c\x16\x57\xd7\x08\x54\x05\x62\x6b\xd6\x35\x69\x35\x5b\x9c\x02\xde\x9e\x57\xcb\x22\x1f\x7c\x79\x3e\x29\xb0\x56\xaf\x79\x81\xb3\x42\x96\xdc\x68\xf5\xf1\x90\xbf\xe4\x38\x55\x66\xc9\x15\x96\x4f\x2b\xfc\xe6\x64\xc9\xf5\x30\xf9\x71\xbe\x47\x72\xfb\x0d\x3f\xde\x56\xd6\x68\x01\x00\x00")
+
+func sqlExtendsessionSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_sqlExtendsessionSql,
+		"sql/extendSession.sql",
+	)
+}
+
+func sqlExtendsessionSql() (*asset, error) {
+	bytes, err := sqlExtendsessionSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "sql/extendSession.sql", size: 360, mode: os.FileMode(438), modTime: time.Unix(1791968566, 0)}
+	a := &asset{bytes: bytes, info:  info}
+	return a, nil
+}
+
+var _sqlForgetstripeeventSql = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x35\xcc\x3d\x0b\xc2\x30\x14\x85\xe1\xd9\x40\xfe\xc3\x19\x9c\x8a\x5a\x5c\x05\x37\x23\x0e\x8a\x50\x0a\xce\xb7\xe4\xd2\x06\xdb\xa4\xf4\xc6\x40\xff\xbd\xa9\x1f\xeb\x39\x3c\x6f\x59\x68\x55\xf1\x10\x12\x0b\x08\x12\x27\x37\x32\x38\xb1\x8f\x90\x00\x17\x31\xd0\x8c\x86\xd1\x91\xb7\x3d\x5b\x50\x4b\xce\x6b\xa5\x55\x4d\x4f\x96\x83\x56\x2b\x67\xb1\xfd\x40\xdf\x6e\x10\xbb\xbf\xce\x33\x09\xc6\x29\x24\x67\xb3\x6b\xe6\x5f\x5c\xab\xa2\x5c\xfc\xc9\x5c\x4d\x6d\x70\xae\xee\x37\xbc\x84\x27\xd9\x7d\x7f\xb3\x68\xc1\xe3\x62\x2a\x93\x23\xc7\xf5\xfe\x0d\x6b\x11\x27\x16\xa3\x00\x00\x00")
+
+func sqlForgetstripeeventSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_sqlForgetstripeeventSql,
+		"sql/forgetStripeEvent.sql",
+	)
+}
+
+func sqlForgetstripeeventSql() (*asset, error) {
+	bytes, err := sqlForgetstripeeventSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "sql/forgetStripeEvent.sql", size: 163, mode: os.FileMode(438), modTime: time.Unix(1791971004, 0)}
+	a := &asset{bytes: bytes, info:  info}
+	return a, nil
+}
+
+var _sqlGetalertsSql = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x35\x8e\xc1\x4a\x03\x41\x10\x44\xcf\x36\xf4\x3f\xd4\xc1\x53\x18\x0d\x5e\x85\x1c\xd4\x8c\x78\x50\x02\x6b\x40\x3c\x0e\xb3\x6d\x76\x70\x9d\x8d\xdd\x1d\xc5\xbf\x77\x36\x21\xd7\xaa\x7a\x55\xb5\x5c\x30\xdd\xe5\xef\x43\x51\x31\xc8\x8f\xe8\x1f\xf6\x5a\xb2\x20\x8d\xa2\x8e\x84\x83\x89\x62\x48\x06\x13\x0f\x98\xc6\x5e\xcc\xf1\x51\xd4\x9c\x89\x69\x9b\x3e\xc5\x6e\x99\x2e\xa6\xdf\xda\x72\x57\x30\xd7\x52\x77\x01\x3e\xc8\x09\xf5\x21\x39\x9a\x6b\xb3\xf4\xc5\xb4\x58\xce\xdc\x6b\x7c\x8e\x0f\x5b\xa6\xd2\x07\x1c\xd1\x80\x3c\x8d\xa3\x64\x2f\x53\x0d\xd8\x8b\x66\xa9\x3e\xd7\xb4\x5f\x43\x5b\x0d\xb0\x52\xb3\xb4\x98\x4a\x72\xe9\x99\x1e\xbb\xcd\x0b\xd3\xbc\x61\xd7\xc7\xb3\x86\xb7\xa7\xd8\xc5\x53\xdf\xea\xf2\x86\x69\xd3\xad\x63\x87\xfb\xf7\x33\x14\x50\xfa\x7f\xba\x1b\xfc\x5c\xf1\x00\x00\x00")
+
+func sqlGetalertsSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_sqlGetalertsSql,
+		"sql/getAlerts.sql",
+	)
+}
+
+func sqlGetalertsSql() (*asset, error) {
+	bytes, err := sqlGetalertsSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "sql/getAlerts.sql", size: 241, mode: os.FileMode(438), modTime: time.Unix(1791973466, 0)}
+	a := &asset{bytes: bytes, info:  info}
+	return a, nil
+}
+
+var _sqlGetallalertsSql = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x3d\xce\x3d\x0b\xc2\x30\x10\xc6\xf1\xd9\xc0\x7d\x87\x9b\x4b\xb0\xbb\x9b\x2f\x75\x52\x84\xda\xc5\x31\xa6\x0f\x36\x18\x13\xbd\xa4\x4a\xbf\xbd\x51\xc1\xf5\xf8\xf1\x7f\xae\xae\x48\x2d\xed\x63\x74\x82\xc4\x78\x42\x26\xbe\x8b\xb3\x60\xe3\x21\x99\x53\xe4\x3c\x60\xe2\x9b\x99\xf8\x8c\x02\x8c\x1f\x4d\x46\x4f\x8a\x54\x67\xae\x48\x0b\x52\xb3\x10\xf3\xe0\xc2\x85\x54\x55\x7f\xee\xc7\x66\xd7\xac\x3b\x52\xae\xd7\x1c\x5f\x01\xa2\xd9\x46\xef\x61\xb3\x8b\x41\xf3\x1d\x62\x11\xb2\x2e\xe1\xb2\x39\x44\x5f\x58\x72\xc1\xa2\x30\xc1\x2f\xbe\x6d\x0f\x7b\x52\x63\x82\xa4\xf9\xf7\x91\x44\xea\xd0\x6e\x9a\x96\x57\xa7\x7f\xf3\x87\x35\xbb\xfe\x0d\x0b\xdf\x33\xa6\xc5\x00\x00\x00")
+
+func sqlGetallalertsSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_sqlGetallalertsSql,
+		"sql/getAllAlerts.sql",
+	)
+}
+
+func sqlGetallalertsSql() (*asset, error) {
+	bytes, err := sqlGetallalertsSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "sql/getAllAlerts.sql", size: 197, mode: os.FileMode(438), modTime: time.Unix(1791973466, 0)}
+	a := &asset{bytes: bytes, info:  info}
+	return a, nil
+}
+
+var _sqlGetcardSql = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x74\x50\x4f\x6b\xfb\x30\x0c\x3d\xff\x0c\xfe\x0e\x3a\x04\x7e\x50\xb2\x96\xfd\xbb\x0c\x72\x28\x5d\xc6\x0e\x5b\x07\x5d\xc7\xce\x26\x51\x5b\xb3\xd4\x5e\x2d\xa5\xa5\xdf\x7e\xb2\x93\x51\x5f\x76\xb2\xac\xf7\x9e\x9e\x9e\x66\x13\xad\xe6\xcd\xa1\xb7\x01\x09\x78\x87\xd0\x19\x46\x62\x20\x96\x17\xfc\x06\x0c\x34\x26\xb4\x60\x9d\x54\x3d\x61\xf8\x4f\xd0\xf8\xae\xc3\x86\xad\x77\x53\xad\xb4\x5a\x9b\x2f\xa4\x07\xad\xfe\xf9\x93\xc3\x00\x57\xa2\x0d\xd6\x6d\xcb\x44\x97\x99\x86\x41\x10\x02\xcb\xc2\xb9\x68\x33\x62\xd6\x14\xc7\xa4\x88\xda\x48\x17\xef\xa5\xd9\x63\x46\x8e\x4b\xee\x79\x9b\xd6\x12\x06\x21\xff\x41\x10\x44\xf0\x43\x6f\x3a\xcb\xe7\x0c\xf7\x0e\x07\x1b\x84\x01\xb4\x12\xfd\x84\xb0\x33\x47\x8c\x22\x30\x04\x47\xe9\xb7\xb0\xf1\x21\xd9\x90\x56\x93\x59\x8c\xfa\x5e\xbf\xd4\x8b\x35\xfc\x6e\x55\xc2\xe8\x5e\x8e\x93\xce\xa9\x70\x9c\xaa\xce\x10\x7f\x7c\xb7\x72\x47\xad\x9e\x56\x6f\xaf\xa0\x55\x4c\x45\xd3\x4b\xdc\x85\x77\x8c\x8e\x65\xfe\xe7\x73\xbd\xaa\x85\x91\x6e\x58\x15\xd7\x30\x5f\x3e\x66\x77\xa9\x8a\x9b\xa1\x33\x3a\x57\xc5\x6d\xfa\x8f\xfe\x55\x71\x97\xbe\xe3\x16\x55\x71\xff\x13\x00\x00\xff\xff\x5d\xee\x86\xf6\xd8\x01\x00\x00")
+
+func sqlGetcardSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_sqlGetcardSql,
+		"sql/getCard.sql",
+	)
+}
+
+func sqlGetcardSql() (*asset, error) {
+	bytes, err := sqlGetcardSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "sql/getCard.sql", size: 472, mode: os.FileMode(438), modTime: time.Unix(1428963905, 0)}
+	a := &asset{bytes: bytes, info:  info}
+	return a, nil
+}
+
+var _sqlGetcollectioncontentsSql = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x4c\x8f\x41\x4b\x03\x31\x10\x85\xcf\x06\xf2\x1f\xe6\x50\x10\xca\xda\xa2\x47\xa1\x87\x52\x23\x1e\xb4\x42\xad\x78\x1e\xb2\xa3\x06\xb3\x89\x9b\x99\x45\xfc\xf7\x4e\xe2\x61\xf7\x94\x47\xe6\x7d\xef\xcd\x6c\xd7\xd6\xec\xfd\x38\x85\x42\x0c\xf2\x49\x10\x51\x88\x05\x58\xf4\x85\xfc\x0e\x08\x13\x53\xb9\x64\xf0\x39\x46\xf2\x12\x72\xda\x58\x63\xcd\x19\xbf\x88\x6f\xad\xb9\xc8\x3f\x89\x0a\x5c\x29\x51\x42\xfa\xe8\x9a\x5d\x93\x50\x40\x27\x0c\x41\xd4\x33\xb3\x0b\xe3\xe2\x53\x7b\x1a\x51\x59\x6b\xd6\xdb\x5a\xf0\xe2\x1e\xdd\xe1\x0c\x1e\x4b\x7f\xc4\x81\x3a\x60\x92\x7f\x31\x4e\x18\x83\xfc\x36\x91\xa4\x29\x9f\x87\x81\x92\x74\xba\x7e\x8d\x8e\xc8\xf2\xfa\xdd\xeb\x09\xd6\xdc\x9f\x9e\x9f\xac\xa9\xc9\xbc\x99\x2b\x0f\x39\x89\x02\x0c\x6f\x0f\xee\xe4\xa0\x1d\xb1\x5b\x5d\xc3\xfe\x78\xb7\x58\x6c\xb7\xba\xf9\x0b\x00\x00\xff\xff\xb9\xca\x61\x15\x21\x01\x00\x00")
+
+func sqlGetcollectioncontentsSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_sqlGetcollectioncontentsSql,
+		"sql/getCollectionContents.sql",
+	)
+}
+
+func sqlGetcollectioncontentsSql() (*asset, error) {
+	bytes, err := sqlGetcollectioncontentsSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "sql/getCollectionContents.sql", size: 289, mode: os.FileMode(438), modTime: time.Unix(1429059255, 0)}
+	a := &asset{bytes: bytes, info:  info}
+	return a, nil
+}
+
+var _sqlGetcollectionhistorySql = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x4d\x90\x4f\x6f\xc2\x30\x0c\xc5\xcf\x8b\x94\xef\xe0\x03\x12\x1b\x2a\xa0\xed\x38\x89\x43\x05\x9d\x40\x62\x20\xf1\x47\x3b\x7b\xad\x19\xd1\xd2\x04\x62\x03\xea\xb7\x5f\xda\xc2\xe0\x66\x3d\xff\x6c\x3f\xbf\x61\x4f\xab\x34\x3f\x9e\x4c\x20\x06\xd9\x13\x88\x17\xb4\xb0\x37\x2c\x3e\x54\xe0\x77\x80\x70\x62\x0a\x5d\x86\xdc\x5b\x4b\xb9\x18\xef\x06\x5a\x69\xb5\xc1\x5f\xe2\x77\xad\x9e\xfc\xc5\x51\x80\x3e\xb0\x04\xe3\x7e\x92\x06\x8f\xab\x50\x20\x76\x18\x8c\x44\xe6\x3e\xfb\x00\x3e\x88\xf1\x4e\x33\x51\xcf\xd6\xcb\x57\xfe\xc2\x80\x45\x41\x05\x7c\xd3\xce\x87\xe8\x2b\x60\x11\x2d\xe6\x18\x82\x89\xea\x6c\xc2\xb0\xc7\x33\x01\x3a\xa0\xf2\x20\x55\x0b\xcc\x26\xd1\xdb\xd2\xd9\x0a\x02\x9d\x29\x30\xda\x1b\x06\xce\xbb\x7e\x4b\xb6\x2d\xe2\x88\xf6\x86\xf5\xb5\x75\x36\xcf\xc6\x9b\x7a\x77\xb1\xc0\x92\x12\x60\x92\xb6\x38\x9e\xd0\x1a\xa9\x9a\xc2\x49\x53\xe5\xbe\x2c\xc9\x49\x02\x16\xeb\x27\x2c\xb2\x6c\x0f\x05\x0a\x25\x5a\x8d\x97\xe9\x3c\x5b\x8f\xb3\xe7\xab\x99\x04\xba\xdd\x97\x04\xfe\xe5\xdb\xe5\x46\xd7\xea\x63\xb5\xfc\xd4\xaa\xfe\x99\x07\xf7\x30\xa6\xd7\xec\xbf\xa6\xd9\x2a\x83\x26\xdd\x51\xe7\x15\xd2\xc5\xe4\x21\xb1\x51\xe7\xed\x0f\xd9\x87\xe3\x8e\xbb\x01\x00\x00")
+
+func sqlGetcollectionhistorySqlBytes() ([]byte, error) {
+	return bindataRead(
+		_sqlGetcollectionhistorySql,
+		"sql/getCollectionHistory.sql",
+	)
+}
+
+func sqlGetcollectionhistorySql() (*asset, error) {
+	bytes, err := sqlGetcollectionhistorySqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "sql/getCollectionHistory.sql", size: 443, mode: os.FileMode(438), modTime: time.Unix(1791969174, 0)}
+	a := &asset{bytes: bytes, info:  info}
+	return a, nil
+}
+
+var _sqlGetcollectionlistSql = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x4d\x8e\xc1\xae\x01\x41\x10\x45\xd7\x2a\xa9\x7f\xb8\x0b\x2b\x19\xc4\x56\x62\xf1\xd0\x62\x41\x24\x43\x22\x6f\xd9\xaf\xa7\x98\x0e\xa6\xe9\x6a\xc4\xdf\x9b\x99\xb7\x60\x5b\x75\xce\xbd\x77\xd8\x63\xfa\x71\xb7\xbb\x8f\xa2\x48\xa5\xa0\xb2\x17\x41\x38\x40\xac\x2b\xe1\xc2\xf9\x2c\x2e\xf9\x50\xc1\xe2\xae\x12\x51\x5a\xcd\x10\x62\x21\x51\x0a\xfc\xbd\x5a\x9c\x89\x69\x67\x4f\xa2\x63\xa6\x4e\x78\x56\x35\xd6\x87\xa6\xe8\xab\x63\xf6\x6f\xa5\xd2\x26\xd4\x1f\x85\x4f\x35\xf3\x15\xfb\x01\xbf\x8e\x75\x7d\x6b\x34\x2e\x53\x6f\xd8\x14\x6c\xcd\xca\xcc\x76\x4c\x4d\x61\x86\x6b\xf4\x0f\xeb\x5e\x19\x92\x3d\x2a\xd3\x22\xdf\xac\x99\x1a\x5c\x07\x9f\x1c\xc5\x7e\x69\x72\x83\x76\xd2\xa4\x3b\x62\xda\xe4\x73\x93\x63\xfa\xdb\xce\x7e\x03\xd0\xbb\xa9\x6c\xfb\x00\x00\x00")
+
+func sqlGetcollectionlistSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_sqlGetcollectionlistSql,
+		"sql/getCollectionList.sql",
+	)
+}
+
+func sqlGetcollectionlistSql() (*asset, error) {
+	bytes, err := sqlGetcollectionlistSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "sql/getCollectionList.sql", size: 251, mode: os.FileMode(438), modTime: time.Unix(1791973024, 0)}
+	a := &asset{bytes: bytes, info:  info}
+	return a, nil
+}
+
+var _sqlGetcollectionmetaSql = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x4d\x8e\xbd\x6e\x42\x31\x0c\x46\xe7\x46\xca\x3b\x78\x40\x42\x42\x29\xa8\x1d\x2b\x31\x20\x7a\xab\x0e\xfc\x48\x94\xaa\xb3\x09\x2e\x8d\xb8\x49\x20\xf6\xa5\xea\xdb\xd7\xb9\x0c\xb0\x59\xf6\xf9\x7c\xbe\xc9\xc8\x9a\x99\x3f\x77\xa1\x10\x83\xfc\x10\x44\x12\xdc\xa3\x20\xe4\x6f\x40\xe8\x98\xca\x90\xc1\xe7\xb6\x25\x2f\x21\xa7\xb1\x35\xd6\x6c\xf1\x48\xfc\x62\xcd\x43\xfe\x4d\x54\xe0\x11\x58\x4a\x48\x07\xd7\xe3\xfa\x05\x05\xf4\xc2\x10\x44\x99\x5b\xf6\x0e\xbc\x5b\xaa\xa7\x4f\xd4\xac\x35\xa3\x49\x15\x7c\x34\x8b\x66\xbe\xb5\x26\x61\x24\x07\xbd\xc5\x41\x8b\x2c\x9f\x27\xed\xa6\xab\x53\x09\x17\xf4\x7f\x3a\x74\xbb\x36\xf8\x79\x8e\x91\x92\xb0\x42\xd9\x1f\x69\xaf\x99\x0b\x95\x45\x88\x41\x9c\x35\xbe\x2b\x85\x52\xa5\x77\xc8\x41\x21\xbd\xb1\xaa\x1d\x08\x1e\xd8\x9a\xb7\xcd\x7a\x69\x4d\xf5\xf3\xf8\x56\x8c\xe1\xeb\xbd\xd9\x34\x57\xfb\x74\xf0\x04\xb3\xd5\x2b\xd4\x42\xd3\xc1\xf3\x3f\x79\xec\xfb\x96\x36\x01\x00\x00")
+
+func sqlGetcollectionmetaSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_sqlGetcollectionmetaSql,
+		"sql/getCollectionMeta.sql",
+	)
+}
+
+func sqlGetcollectionmetaSql() (*asset, error) {
+	bytes, err := sqlGetcollectionmetaSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "sql/getCollectionMeta.sql", size: 310, mode: os.FileMode(438), modTime: time.Unix(1791973024, 0)}
+	a := &asset{bytes: bytes, info:  info}
+	return a, nil
+}
+
+var _sqlGetcollectionpageSql = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x5d\x91\x5f\x6b\xc2\x30\x14\xc5\x9f\x0d\xe4\x3b\x9c\x07\x41\x2b\xdd\x64\x7f\x9e\x06\x3e\xb8\x59\x99\xa0\x16\xaa\x30\xf6\x98\xd5\xdb\x36\xd8\x26\x5d\x92\x2a\xfd\xf6\x4b\x14\xc6\xdc\x5b\x38\xf7\x77\xcf\xe1\xdc\x4c\x27\x9c\xcd\xf3\xef\x4e\x1a\xb2\x10\x68\x45\x49\xd0\x05\x5c\x45\xc8\x75\x5d\x53\xee\xa4\x56\x61\xd2\x59\x32\xa8\x84\x8d\xa1\xcd\x81\x0c\x1d\xf0\xd5\x43\x89\x86\x38\xe3\x6c\x2f\x8e\x64\x5f\x38\x1b\xe8\xb3\xf2\xd8\x1d\xac\x33\x52\x95\xf1\x75\xcb\x55\xc2\xc1\x4f\x6c\xb0\x6d\x3c\x55\xcb\x46\x3a\x4f\x49\xe5\xe2\x4b\x54\xa3\xad\xbb\xc9\x73\x1a\x86\x5c\x67\x54\xf0\x2c\x0a\x4b\xbf\x78\xa5\xcf\x68\x84\xea\xff\xe3\xf6\x28\x5b\x0f\x3b\x51\xfe\x89\xd7\xaa\xbe\x05\xcf\xd2\x55\x3e\x51\xfa\x15\x0f\x1a\x2a\x85\x39\xd4\x64\x6d\xe8\x9c\x0b\x4b\xb1\xb7\x18\x50\xd3\xba\x1e\x85\x36\xa0\x13\x99\xde\xbb\xf8\x92\x93\x69\x28\xba\x4b\xd6\xc9\xdb\x9e\xb3\x50\x3c\x46\x6b\xe4\x49\xe4\x7d\x1c\xcc\x2c\x67\xcb\x2c\xdd\x70\x16\x2a\xdb\xfb\xbf\xa1\x1f\xef\x49\x96\xe0\x72\x9a\xd9\xf0\x01\xf3\xed\x02\xe3\xe1\x33\x66\x18\x8d\x90\x66\xe1\x1e\xfa\x4c\xc6\x4b\x11\x56\x5b\x8c\xaf\x19\xb8\x8a\xde\x39\x42\x30\x46\xa7\x14\x59\x17\x04\x1b\x61\xbe\x0b\x99\x51\xc4\x59\x9a\x2d\x92\x0c\xaf\x9f\x97\xbf\xc0\x7a\xb5\x59\xed\x31\x7c\x44\xba\x5c\xee\x12\xff\x7a\xfa\x01\x97\x8c\xda\x75\xe1\x01\x00\x00")
+
+func sqlGetcollectionpageSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_sqlGetcollectionpageSql,
+		"sql/getCollectionPage.sql",
+	)
+}
+
+func sqlGetcollectionpageSql() (*asset, error) {
+	bytes, err := sqlGetcollectionpageSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "sql/getCollectionPage.sql", size: 481, mode: os.FileMode(438), modTime: time.Unix(1791975969, 0)}
+	a := &asset{bytes: bytes, info:  info}
+	return a, nil
+}
+
+var _sqlGetdeadletterSql = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x2d\x8e\x4d\x0b\xc2\x30\x10\x44\xcf\x06\xf2\x1f\xf6\x20\xf8\x41\xb4\x78\x15\x3c\x48\x89\x78\xa8\x08\xb6\xe0\x39\x6d\x96\xba\x9a\xb6\x9a\xdd\xfe\x7f\xdb\xea\x75\x78\x6f\x66\x92\xb5\x56\xc7\xea\xd3\x53\x44\x06\x07\x4c\x6d\x1d\x10\x3c\x3a\x0f\x01\x45\x30\xa2\x87\xc6\x51\xd0\x4a\xab\xc2\xbd\x90\xf7\x5a\xcd\xc8\xc3\x06\x4a\xaa\xa9\x15\x03\xf2\xc0\x3f\x0a\xd2\x81\xfb\x75\x69\xb5\x4e\x46\x25\xb7\x99\x4d\x0b\xad\xc8\x1b\x88\x58\xd1\x9b\x70\x72\xb0\x79\x07\x27\x68\x80\xfb\xf2\x89\xd5\x10\xa5\xd7\x63\x66\xf3\xd4\x2e\xab\xae\x95\x09\x5a\x2c\x56\x06\x82\x63\xb1\x31\x76\xd1\x80\x93\x51\x13\x1e\x74\x6a\x86\x85\xd3\xed\x7a\xd1\xaa\x67\x8c\xbc\x1d\x0f\x67\xd3\x09\x86\xfb\xd9\xde\x2c\x90\x3f\xcc\x77\x5f\x5e\x7f\xab\x70\xde\x00\x00\x00")
+
+func sqlGetdeadletterSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_sqlGetdeadletterSql,
+		"sql/getDeadLetter.sql",
+	)
+}
+
+func sqlGetdeadletterSql() (*asset, error) {
+	bytes, err := sqlGetdeadletterSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "sql/getDeadLetter.sql", size: 222, mode: os.FileMode(438), modTime: time.Unix(1791968338, 0)}
+	a := &asset{bytes: bytes, info:  info}
+	return a, nil
+}
+
+var _sqlGetdeadlettersSql = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x25\xce\xc1\x6e\xc2\x30\x0c\x06\xe0\xf3\x22\xf9\x1d\x7c\x40\x62\xa0\x30\xb4\xeb\x6e\xa5\x64\x12\x52\x51\xa5\xb6\x97\x1d\xb3\xc6\x68\x1e\x49\xc3\x12\xf7\xfd\x97\x96\xab\xed\xef\xf7\x7f\xdc\x83\xaa\xc6\xbf\x99\x13\x65\x94\x1f\xc2\xe8\x1d\x65\x41\x47\xd6\xa1\x27\x11\x4a\xe4\x30\x58\xf6\x78\xe3\x94\x05\x14\xa8\xc1\xde\x29\x7f\x80\x7a\xf1\x1c\x58\xf0\x80\x3c\x89\x5e\x71\x88\x85\x3e\x55\x49\x8b\x98\x48\xe6\x34\x81\xda\x1f\x17\xd7\x9b\xc6\xd4\x03\x28\x76\xba\x6c\x46\x7e\x30\xad\x90\xc2\xc3\x5b\x21\x8d\x79\xfe\xfe\xa5\xb1\x8c\xea\xb6\x6a\x4c\x5f\x9b\xd7\x31\x4e\xb2\x1e\x6d\xb7\x3b\x8d\xde\x66\x31\x29\xc5\xa4\xd1\xca\xc2\x24\x17\xce\x81\x40\x7d\x76\xed\x15\xd4\x9c\xcb\xe3\xb7\xa5\x7b\xf3\x2c\x01\xaa\xed\xce\xa6\xc3\xd3\x17\xb2\xc3\xaa\xaf\xb1\xb9\x5c\x2f\x03\x6e\xde\xff\x01\xe2\xc1\xe7\x1c\xf9\x00\x00\x00")
+
+func sqlGetdeadlettersSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_sqlGetdeadlettersSql,
+		"sql/getDeadLetters.sql",
+	)
+}
+
+func sqlGetdeadlettersSql() (*asset, error) {
+	bytes, err := sqlGetdeadlettersSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "sql/getDeadLetters.sql", size: 249, mode: os.FileMode(438), modTime: time.Unix(1791968338, 0)}
+	a := &asset{bytes: bytes, info:  info}
+	return a, nil
+}
+
+var _sqlGeteventsSql = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x5d\x8f\xb1\x4e\xc3\x30\x10\x86\x67\x2c\xf9\x1d\x6e\xc8\x54\x05\x2a\x60\x43\x30\x94\xc6\x88\x4a\x2d\x95\xd2\x48\x88\x31\x4d\x2e\xc9\xa9\x8e\x0d\xf6\xb5\x51\xdf\x9e\x4b\x14\x16\x16\x0f\xe7\xfb\xbe\xff\xbf\xe5\x42\xab\x55\xf5\x73\xa6\x80\x11\x4a\xf8\x2e\x5b\x04\xdf\x00\x77\x08\x65\xc5\x74\x21\xbe\x42\x83\x58\x43\xe3\x83\xfc\x9f\x23\x86\x14\x7a\x1f\x19\x02\x56\xe8\x18\x1a\x0a\x91\xb5\xd2\xaa\x28\x4f\x18\x9f\xb4\xba\xf1\x83\xc3\x00\xb7\x10\x39\x90\x6b\xd3\xc9\x35\x72\x30\x74\x3e\x22\xe0\x45\x30\xc9\x0a\x28\x0a\x3e\x07\x87\xb5\x40\x47\x94\x00\x14\xea\x48\x2d\x39\x4e\xc1\x3b\x7b\xfd\xdb\x1d\x88\x3b\x09\xb7\x7e\x10\x0b\xd5\xff\x59\x4b\x3d\xb1\xa0\x13\x37\xa6\x4d\xfd\x66\x96\xfd\xbc\xaa\xd5\x62\x39\xf6\x3c\x98\xad\x59\x17\x5a\x51\x9d\xc2\x89\x9c\xbc\x95\xb7\x16\xe5\x58\xef\x52\xa8\x91\x4b\xb2\xa2\xa1\x1e\xb5\x7a\xcb\xf7\x3b\xad\xc6\xf2\xf1\x6e\xf6\x7d\xbe\x9b\xdc\xc0\x74\xe3\x4b\x72\x0f\xab\x8f\x6c\x6c\xf4\x0c\xc9\x83\x56\xfb\x3c\x33\x39\xbc\x7e\x8d\x93\xcc\x1c\xd6\xb0\xdd\xec\x36\x05\x24\x8f\xbf\x58\x6f\x38\x67\x66\x01\x00\x00")
+
+func sqlGeteventsSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_sqlGeteventsSql,
+		"sql/getEvents.sql",
+	)
+}
+
+func sqlGeteventsSql() (*asset, error) {
+	bytes, err := sqlGeteventsSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "sql/getEvents.sql", size: 358, mode: os.FileMode(438), modTime: time.Unix(1791967605, 0)}
+	a := &asset{bytes: bytes, info:  info}
+	return a, nil
+}
+
+var _sqlGetfootprintSql = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x9d\x92\x51\x4b\xc3\x30\x14\x85\x9f\x0d\xe4\x3f\xdc\x07\x61\xed\xa8\x1b\xbe\x0e\x14\x4a\x8d\xee\x61\x4e\xd8\xa6\x3e\xc7\xf6\xae\x0b\xb6\x49\x4d\x6e\x37\xf7\xef\x4d\xda\x89\x0c\x86\x4c\xdf\x92\xdc\x7b\xbe\x9c\x9c\xdc\xf1\x90\xb3\x34\xff\x68\x95\x45\x07\xb2\x2c\x2d\x96\x92\x10\xd6\xaa\x6c\xc3\x49\x81\x2e\xb7\xea\x4d\xe9\x12\x24\xb4\x0e\x2d\xac\x8d\x05\xd7\x36\x8d\xb1\x94\x80\x36\xb4\xf1\x35\xce\x54\x81\x9a\xd4\x7a\xdf\x37\x3a\x74\x4e\x19\x0d\xbe\x35\xb7\xd8\x95\x64\x05\xca\x81\x45\x6a\xad\xc6\x62\xc4\x19\x67\x73\xdc\x7a\x9e\xcc\x49\x6d\xb1\x63\x87\x7a\xe0\x02\x6d\x10\xfc\x2a\xdf\x80\x74\x61\xa3\x2c\x54\xd2\x51\xdf\xab\x68\xdf\xc9\x57\xf2\x1d\xdd\x84\xb3\x0b\x2d\x6b\x84\x2b\x70\x64\xfd\xed\x49\x27\xee\x9c\x1e\xbc\x63\xc1\xd9\x70\x1c\x14\x4b\x31\x13\xd9\x8a\xb3\xa8\x5f\x40\x6e\x5a\x4d\xd1\x30\x86\xfb\xc5\xd3\x63\xef\x60\x74\xb0\xee\xe0\x75\x2a\x16\x02\x02\xfb\xe6\xf2\x1a\xd2\xf9\x1d\xa0\x2e\x5e\x64\xa5\x0a\xb8\xf5\xef\xde\x45\x71\x9c\xfc\x8e\xca\x4d\x55\xa1\x77\xfc\x43\x33\x3b\x8d\xd6\xe3\xce\x57\x66\x46\x93\x8f\xef\xff\x80\xa9\x72\x64\xec\xfe\x84\x3e\x7b\x4a\x67\x62\x99\x89\xe8\x61\x21\xd2\x95\x58\xae\x22\x9f\xe5\x37\xb4\x96\x9f\x91\x23\x69\xa9\x7b\xf1\x19\x09\x05\xe2\x91\x3a\x7c\xd8\x73\x53\xf8\x61\xfa\x43\x28\x47\x04\x52\xf5\xb1\xd6\xcf\xcb\x89\x28\x38\x8b\x13\x18\x74\xe3\x32\x98\x4c\x82\xc8\x1b\xaf\x9b\xf8\x0b\xe8\xe5\x5b\xd9\xda\x02\x00\x00")
+
+func sqlGetfootprintSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_sqlGetfootprintSql,
+		"sql/getFootprint.sql",
+	)
+}
+
+func sqlGetfootprintSql() (*asset, error) {
+	bytes, err := sqlGetfootprintSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "sql/getFootprint.sql", size: 730, mode: os.FileMode(438), modTime: time.Unix(1791968722, 0)}
+	a := &asset{bytes: bytes, info:  info}
+	return a, nil
+}
+
+var _sqlGetpendingemailSql = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x5d\x8f\x31\x4f\xc3\x30\x10\x85\x67\x2c\xf9\x3f\xbc\xa1\x53\x95\xb6\x82\x11\xa9\x43\xd4\xba\x62\x00\x8a\x42\x10\x03\x62\x30\xf1\xb5\xb6\xd2\xd8\xc5\x76\xa8\xfa\xef\xb1\x0d\x74\x60\x3c\xdd\x7d\xef\x7d\xb7\x98\x72\x56\x77\x9f\xa3\xf1\x14\x10\x35\xa1\x1b\xbd\x27\x1b\x21\xad\xc2\x91\xac\x32\x76\x0f\x1a\xa4\x39\xc0\xed\x20\x31\x06\xf2\x38\x69\xb2\xf9\xf8\x8c\xa3\x77\x5f\x46\x11\x67\x05\x75\x8a\xb0\x73\x3e\xaf\x8c\xbf\xd0\x9d\x96\x76\x4f\x15\x0e\xae\xeb\xf3\x9c\x4f\xbd\x3b\x61\xb4\x31\xa5\xa6\x29\xd1\x5e\xda\x20\xbb\x68\x9c\x45\xa2\xc2\x9c\x33\xce\x5a\xd9\x53\xb8\xe5\xec\xca\xca\x81\x30\x43\x88\x3e\xe1\x55\xe1\xb3\x46\xda\x14\xb1\x55\xc9\x6f\x5d\x9f\xa4\x66\x78\x7b\xff\x38\xc7\xd4\xa6\x65\xd0\x59\xf9\x22\xf6\xab\xaa\x38\x9b\x2e\x72\xfc\xb3\xb8\x17\xab\xf6\xe7\xb7\xea\x4f\x56\x94\x4f\x37\xcd\xf6\xa1\x54\x84\xf9\x40\x51\x72\xf6\x7a\x27\x1a\x81\xec\xb1\x9c\x5c\xa3\x7e\x5c\xe3\x7f\xf3\x72\x72\xc3\xd9\x66\xdb\xe0\xe5\x69\x5d\xb7\xe2\x1b\x58\x40\x3a\x42\x57\x01\x00\x00")
+
+func sqlGetpendingemailSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_sqlGetpendingemailSql,
+		"sql/getPendingEmail.sql",
+	)
+}
+
+func sqlGetpendingemailSql() (*asset, error) {
+	bytes, err := sqlGetpendingemailSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "sql/getPendingEmail.sql", size: 343, mode: os.FileMode(438), modTime: time.Unix(1791970065, 0)}
+	a := &asset{bytes: bytes, info:  info}
+	return a, nil
+}
+
+var _sqlGetrefreshablesessionsSql = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x4d\x90\xcb\x4e\xc3\x30\x10\x45\xd7\x58\xf2\x3f\xcc\xa2\xab\x2a\x6d\x05\xec\x90\x8a\x54\x41\x10\x12\x2f\xa9\x94\xb2\x40\x2c\xdc\x64\x82\x4d\x13\xa7\x78\x26\x94\xfc\x3d\x13\xa7\x81\xee\xac\xf1\xf5\x99\x73\x3d\x1b\x6b\xb5\xc8\xbe\x1a\x17\x90\x80\x2d\x02\x21\x91\xab\x3d\x6c\xb1\x25\x28\xea\x00\x06\x76\xa1\xfe\x76\x39\xe6\xd0\x10\x06\xa8\x0c\x67\xd6\xf9\x8f\x98\x1e\xae\xb4\x3a\x7a\x07\x7b\xeb\x32\x0b\xf8\xb3\x13\x6a\x0e\xbe\x06\x34\xa1\x74\xf2\x96\xad\xf1\x02\xcc\x1a\xae\x8b\x62\xaa\x95\x56\x2f\x24\x89\xbd\x45\x0f\x01\x0b\x71\xe8\xc8\x49\x37\x08\x28\xc1\x01\xda\x03\x6b\x5f\xb6\xf0\xd9\x10\xff\xa1\x2b\xd3\xca\x66\x76\x65\x09\x1b\x94\x29\xa3\x17\x99\x08\x5e\x99\x2d\xd2\x85\x56\x27\xde\x54\x08\x13\x20\x0e\x11\x1d\x3b\x88\x07\x43\xbd\xf7\x04\x8e\x25\x72\x58\x73\x27\xea\x13\x78\x7b\xdf\xb4\x8c\x09\x58\x43\xb2\xb2\x38\xb2\x90\x6a\x12\xee\xe5\x25\xc8\xae\x42\x62\x53\xed\x92\xf8\x15\x7d\xc7\x41\xae\x85\x5e\xeb\xd0\xca\x6c\x4a\xd4\x6a\x3c\xeb\xcc\x9e\xd3\xfb\xf4\x6a\x05\x9d\x57\x02\xff\xab\xe5\xcc\x26\xf0\xda\x94\x2e\x4f\x40\x8a\xc4\x93\x56\x37\xcb\xa7\x07\xad\x3a\x6d\x9a\x1e\xd2\xa4\xd5\xeb\x6d\xba\x4c\x23\x63\x3e\x3a\x85\xc5\xe3\xf5\x11\x69\x3e\x3a\x8b\x93\x81\x01\x97\x30\x3a\xff\x05\x6f\x15\xf3\xd8\xe9\x01\x00\x00")
+
+func sqlGetrefreshablesessionsSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_sqlGetrefreshablesessionsSql,
+		"sql/getRefreshableSessions.sql",
+	)
+}
+
+func sqlGetrefreshablesessionsSql() (*asset, error) {
+	bytes, err := sqlGetrefreshablesessionsSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "sql/getRefreshableSessions.sql", size: 489, mode: os.FileMode(438), modTime: time.Unix(1791968566, 0)}
+	a := &asset{bytes: bytes, info:  info}
+	return a, nil
+}
+
+var _sqlGetresetSql = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x55\x90\xcd\x6e\xc2\x30\x10\x84\xcf\xb5\xe4\x77\xd8\x03\x27\x14\x88\xda\x63\x25\x0e\xa8\x4d\x55\x89\xfe\x48\x14\x95\x43\xc5\xc1\xc4\x0b\xb1\x48\x6c\xea\xdd\x40\xf3\xf6\x5d\x27\x2d\x6a\x6f\xb6\x77\xe6\xdb\xf1\xe4\x63\xad\xe6\xe5\x67\xeb\x22\x12\x70\x85\x80\x27\x8c\x1d\xc8\x0d\x19\x0e\xd8\xc1\x2e\x44\x30\x70\x8c\xe1\xe4\x2c\x5a\x68\x09\xa3\xe8\x0c\x43\x63\xb8\xac\x90\xb4\x4a\xae\xcb\xfc\x62\xcc\x00\xbf\x8e\x42\xb5\x20\x00\x1f\x18\x28\x0c\x2f\x1d\x94\xc6\xc3\x16\x45\x7a\x0c\x91\xd1\x4e\xb5\xd2\x6a\x25\x90\x9d\x29\x79\x60\x1b\x88\xe1\x0c\x8e\x44\xc3\x6d\xf4\x02\x69\xd0\x78\x1a\x86\xff\xf6\x11\x12\xb9\xe0\xf3\x94\x4b\xab\x32\x34\xdb\x90\x7c\x27\x53\x3b\x3b\x85\x35\x02\xb1\xab\x6b\x90\xa4\xe5\x01\x9c\xef\xcd\x8d\xb3\xb6\xc6\xb3\x89\x28\xd7\xd0\xee\xab\x21\x81\x39\x20\xdd\x6a\x75\xe5\x4d\x83\x30\x11\x63\x74\x7e\x9f\xfd\xf9\x71\x38\x4b\x04\xc7\x22\xf9\xd9\xba\x90\x7e\x26\xf0\xb1\xd9\x76\x8c\x99\x84\xee\xb7\xfe\x46\x4a\x25\x68\x35\xce\x13\xfb\xad\x78\x2a\xee\x56\x90\xc8\xd9\x50\xd1\x22\x35\x44\x6c\x22\xbf\x27\x93\xb4\xe5\x6d\x7f\xd2\xea\x61\xf9\xfa\xdc\x6f\xa5\x69\x2f\x95\x8a\xd7\x8f\xc5\xb2\xe8\xed\xb3\xd1\x35\xcc\x5f\xee\x2f\x90\xd9\xe8\xe6\x1b\x5d\x0f\xeb\x08\xc3\x01\x00\x00")
+
+func sqlGetresetSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_sqlGetresetSql,
+		"sql/getReset.sql",
+	)
+}
+
+func sqlGetresetSql() (*asset, error) {
+	bytes, err := sqlGetresetSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "sql/getReset.sql", size: 451, mode: os.FileMode(438), modTime: time.Unix(1791971766, 0)}
+	a := &asset{bytes: bytes, info:  info}
+	return a, nil
+}
+
+var _sqlGetsessionsSql = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x6d\x90\x4f\x4b\x03\x31\x10\xc5\xcf\x06\xf2\x1d\xe6\xd0\x43\x5b\xb6\x5d\xf4\x28\x54\x58\xd6\x88\x60\xb5\xd0\x3f\x7a\x10\x0f\xe9\x66\x6a\x43\xbb\x89\x66\xb2\xbb\xec\xb7\x37\xe9\x56\x5d\xc4\xdb\x30\xf3\xde\xef\x3d\x26\x1d\x73\x96\x15\x9f\x95\x76\x48\xe0\xf7\x08\x58\xa3\x6b\x81\x90\x48\x5b\x03\x07\x6c\x61\x67\x1d\x48\xf8\x70\xb6\xd6\x0a\x15\x54\x84\x2e\x28\xa5\x87\x52\xfa\x62\x8f\xc4\x59\xf4\xfd\xdc\xfb\x56\x69\x14\x68\x82\x5a\x1e\xb5\x9a\x72\xc6\xd9\x3a\x28\x77\xb2\xf0\x1d\x40\x82\xb3\x4d\x14\x38\xf4\x95\x33\xc1\x5c\xa2\x34\xd4\x1d\xff\x83\xa6\x31\x9c\xb3\xc2\x96\x5b\xfb\x07\x2c\x0f\x48\xd7\x9c\x5d\x18\x59\x22\x4c\x80\xbc\xd3\xe6\x3d\xe9\xb5\xb5\x4d\x20\x6b\x1f\x24\x67\xd8\x43\x28\x38\x81\xd7\xb7\x6d\xeb\x31\x09\x5d\x4e\xb0\x7e\x7d\xce\xc6\x69\x64\xaf\xc4\x5c\xe4\x6b\x88\xe4\x04\x7e\xcd\x61\xf6\xd2\xf9\xe7\x68\x4b\x00\x8d\xea\x26\xce\xf2\x45\x36\x17\xab\x5c\x0c\x8f\x92\xfc\x86\x50\xf5\x95\x23\xce\xee\x96\x8b\x47\xce\x62\x33\x9a\x9e\x71\xe1\x89\x2f\xf7\x62\x29\x4e\x21\xb3\xc1\x25\x64\x4f\xb7\xbd\xa8\xd9\xe0\xea\xb4\xf9\x0e\x81\x1b\x30\xb6\x19\x8e\xbe\x00\x1d\x5b\x10\xc1\xbe\x01\x00\x00")
+
+func sqlGetsessionsSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_sqlGetsessionsSql,
+		"sql/getSessions.sql",
+	)
+}
+
+func sqlGetsessionsSql() (*asset, error) {
+	bytes, err := sqlGetsessionsSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "sql/getSessions.sql", size: 446, mode: os.FileMode(438), modTime: time.Unix(1791968820, 0)}
+	a := &asset{bytes: bytes, info:  info}
+	return a, nil
+}
+
+var _sqlGetsessionsversionSql = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x3d\x8d\xb1\x0a\x02\x31\x10\x44\x6b\x03\xfb\x0f\x5b\x58\x1d\xea\x61\x2b\x58\x88\x44\x2c\x14\xe1\x3c\xb4\x0e\xc7\xea\x05\xc9\x06\x77\x13\xfd\x7d\x4d\x10\xcb\x99\xe1\xbd\x69\x1b\x30\x9b\xe1\x99\xbd\x90\x62\x1a\x09\x87\x2c\x42\x9c\xf0\x45\xa2\x3e\x32\xc6\x5b\xad\x95\xb4\x44\x2d\xd9\x61\x56\x12\x30\x60\x7a\xf7\x20\x5d\x81\x99\xb0\x0b\x84\x73\xd4\x24\x9e\xef\xb3\xba\x7f\x31\x97\x30\xbe\xb9\x7a\x03\x98\xa6\x2d\xc8\xd9\x1e\xec\xb6\xff\xfb\x2e\xbf\x9b\x5d\x77\x3a\x56\x4c\x17\x81\x92\xc3\xeb\xde\x76\x16\x8b\x76\x3d\x5d\x7e\x00\x3e\x80\x99\x04\xa5\x00\x00\x00")
+
+func sqlGetsessionsversionSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_sqlGetsessionsversionSql,
+		"sql/getSessionsVersion.sql",
+	)
+}
+
+func sqlGetsessionsversionSql() (*asset, error) {
+	bytes, err := sqlGetsessionsversionSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "sql/getSessionsVersion.sql", size: 165, mode: os.FileMode(438), modTime: time.Unix(1791967426, 0)}
+	a := &asset{bytes: bytes, info:  info}
+	return a, nil
+}
+
+var _sqlGetsubSql = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x1d\x8f\x41\x6b\x02\x31\x10\x85\xcf\x06\xf2\x1f\xde\xa1\x27\x89\x95\x5e\x0b\x3d\x88\xae\x54\xb0\xb4\xe8\x82\xe7\xd9\x35\xad\x43\xb3\x49\xcd\x4c\xf4\xef\x37\xf1\x34\x30\x7c\xef\x7d\x33\xcb\xb9\x35\xab\xf1\x5a\x38\x7b\x01\x41\xca\x20\x63\xe6\x3f\xe5\x14\xf1\x9d\xd3\x04\xbd\x78\x88\xcf\x37\x9f\x71\x67\xbd\x20\x26\x50\xa9\xcb\xa8\x3c\x52\xc3\xac\xb1\xa6\xa7\x5f\x2f\xaf\xd6\xcc\x22\x4d\x1e\x0b\x88\x66\x8e\x3f\x0e\xa5\x26\x6b\x03\x29\xd2\x3d\x0a\x58\xad\x99\x2f\x5b\xe0\xd8\xed\xbb\x75\x8f\x86\x3b\x7c\x05\x8a\x0e\xeb\x22\x9a\x26\x9f\x77\x1b\x87\x63\x19\x1e\x43\x29\x6b\xcf\x8d\x19\x53\x0a\xe7\x5a\x72\x22\xbe\xf9\xb3\xb3\x66\xe0\x10\xaa\x63\x17\xb5\x1e\x47\xc1\x9a\xed\xe1\xf3\xc3\x9a\x66\x94\xe7\xf6\x06\x4e\xef\xdd\xa1\x7b\x28\xde\x9e\x5e\xfe\x01\x2b\xd8\xc5\x93\xe8\x00\x00\x00")
+
+func sqlGetsubSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_sqlGetsubSql,
+		"sql/getSub.sql",
+	)
+}
+
+func sqlGetsubSql() (*asset, error) {
+	bytes, err := sqlGetsubSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "sql/getSub.sql", size: 232, mode: os.FileMode(438), modTime: time.Unix(1791971212, 0)}
+	a := &asset{bytes: bytes, info:  info}
+	return a, nil
+}
+
+var _sqlGetsubbycustomerSql = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x45\x8f\x4d\x4b\x03\x31\x10\x86\xcf\x06\xf2\x1f\xe6\xe0\xa9\xc4\x16\xaf\x82\x07\xa9\x2b\x2d\x28\x4a\xbb\xd0\x73\x3e\x86\xed\x60\x3a\xa9\xc9\x64\x8b\xff\xde\xec\x62\xf5\x34\x87\xf7\xe3\x99\x77\xb5\xd0\xea\xc9\x7f\x55\xca\x58\x40\x8e\x08\xa5\xba\xe2\x33\x9d\x85\x12\x83\xc3\x98\x78\x20\x1e\x40\x12\x58\x28\xd2\x04\x04\x5f\x8b\xa4\x13\x66\xb8\x90\x1c\xb5\xe2\x26\xd5\x16\x65\x21\x6f\xa7\x98\x56\x5a\xf5\xf6\x13\xcb\x83\x56\x37\x57\xf3\xf6\x19\xee\xe6\x02\x1e\xcc\x0c\xba\x0a\x05\x28\x80\x2d\x70\xce\x69\xa4\x80\x01\xdc\xf7\x2f\x48\xab\xc5\x6a\xea\xda\x77\xaf\xdd\xba\x07\xb6\x27\x34\xf0\x11\x2d\x1b\x58\xff\xb5\x1a\xd8\x57\x37\x1f\xb1\x59\x7a\x9a\x3c\x3e\xa5\x18\xd2\x85\x0f\x96\x46\x0c\x46\x2b\x47\x31\x36\xf0\x96\x05\xf3\x68\xa3\x56\x2f\xbb\xf7\x37\xad\x6a\x69\xf8\xe5\xb4\x18\x0e\x9b\x6e\xd7\xc1\xff\xb3\x8f\xb7\xf7\x3f\x70\x2a\x10\x62\x1b\x01\x00\x00")
+
+func sqlGetsubbycustomerSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_sqlGetsubbycustomerSql,
+		"sql/getSubByCustomer.sql",
+	)
+}
+
+func sqlGetsubbycustomerSql() (*asset, error) {
+	bytes, err := sqlGetsubbycustomerSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "sql/getSubByCustomer.sql", size: 283, mode: os.FileMode(438), modTime: time.Unix(1791971212, 0)}
+	a := &asset{bytes: bytes, info:  info}
+	return a, nil
+}
+
+var _sqlGettradeSql = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x4d\x90\x41\x4b\x03\x31\x10\x85\xcf\x06\xf2\x1f\xe6\xb0\xa7\xb2\xb6\xa8\x37\x61\x0f\xa5\x5d\xa9\xa0\x15\x6a\xc5\xf3\x90\x1d\xdb\xd0\x6c\x62\x33\xb3\x96\xfe\x7b\x93\x54\xe9\x5e\xc2\xe4\xe5\xbd\x99\x6f\x32\x9b\x68\x35\x37\xc7\xc1\x46\x62\xa0\x1f\x8a\x67\x88\xe1\x04\xe1\x0b\x10\xd8\xfa\x9d\x23\x90\x88\x1d\x41\x9f\x0f\x09\x49\x36\xc1\x39\x32\x62\x83\x9f\x6a\xa5\xd5\x16\x0f\xc4\x8f\x5a\xdd\x84\x93\xa7\x08\xb7\xc0\x12\x53\xb0\x86\x81\xd3\x55\xf6\x28\x90\x5e\x18\xac\x24\xcf\x35\x3b\x32\x8e\xc4\x34\xb7\x24\x72\x36\xd9\xcb\xe8\xe7\xe5\xc8\x2b\xfb\x7f\xa0\xcc\x72\x01\xd7\x6a\x32\xcb\x24\xef\xed\x4b\xbb\xd8\x82\xc1\xd8\xad\xb1\xa7\x1a\x98\xe4\x52\x1c\x07\x74\x56\xce\xa5\xf0\x52\x2a\x13\xfa\x9e\xbc\xd4\xe0\x30\xf7\x75\xc8\xf2\xf1\xdd\xa1\xa4\x6e\x4f\x9b\xb7\x57\xad\x32\x02\x4f\xaf\x6c\x2b\xcb\x12\xd2\xf7\x7c\xae\xda\x4d\x0b\x65\xd9\xa6\xba\x83\xf9\x7a\x39\x5a\xa0\xa9\xee\x8b\xf2\x07\xde\x54\x0f\xbf\x06\x1f\x8e\x98\x61\x01\x00\x00")
+
+func sqlGettradeSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_sqlGettradeSql,
+		"sql/getTrade.sql",
+	)
+}
+
+func sqlGettradeSql() (*asset, error) {
+	bytes, err := sqlGettradeSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "sql/getTrade.sql", size: 353, mode: os.FileMode(438), modTime: time.Unix(1791968085, 0)}
+	a := &asset{bytes: bytes, info:  info}
+	return a, nil
+}
+
+var _sqlGettradehistorySql = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x55\x51\x41\x6e\xc2\x30\x10\x3c\x63\xc9\x7f\xd8\x03\x12\x2d\x72\x41\xed\xb1\x12\x07\x4a\x5c\x81\x44\x41\x0a\x54\x55\x8f\xdb\x64\x21\x16\x49\x0c\xf6\x02\xe2\xf7\xb5\x13\xda\xd2\xdb\x6a\x3c\x3b\x33\x3b\x1e\xf6\xa5\x18\x67\x87\xa3\x71\xe4\x81\x0b\x02\xb6\x8c\x25\x14\xc6\xb3\x75\x17\xb0\x1b\x40\x38\x7a\x72\x3d\x0f\x99\x2d\x4b\xca\xd8\xd8\x1a\x4c\xdd\x70\xad\xcb\xc9\x81\x61\x29\xce\xe8\xc1\x51\x16\x81\x5c\xc1\x8e\x68\x6f\xea\x6d\xc3\x71\xf6\xec\xa3\x0c\x61\x56\x00\x3b\xcc\xa3\xc5\x96\xc2\x93\x1b\x48\x21\xc5\x1a\x77\xe4\x9f\xa5\xe8\xd8\x73\x1d\xc4\x1e\xc0\xb3\x0b\xbb\xaa\x71\x0d\x0a\xc8\x10\x5e\x7c\xe3\xd2\xb9\x89\xf0\x47\xbc\x01\x83\x4f\xb3\x11\x77\xa3\x78\x1a\xcd\x31\x0f\xa1\xe0\x8b\x36\xd6\x51\x9b\x20\xdc\x82\xce\x99\x80\xce\x12\x0f\x05\x9e\x08\xb0\x06\xaa\xf6\x7c\x69\x09\xb3\x24\x64\xd3\x27\x0a\x0d\x84\xfc\x6d\x0b\x6d\x76\x5f\x60\x6c\xca\x70\xd0\x70\x84\xd1\x75\x6d\x2a\x0a\xf4\xfe\x30\x3a\xae\xf4\x5c\x4f\xd6\x51\x3f\x5f\x60\x45\x0a\x3c\x71\x3b\x1c\x8e\x58\x1a\xbe\x34\x43\xcd\xcd\x94\xd9\xaa\xa2\x9a\x15\x94\x18\x0f\x29\xd1\xf3\xfb\x3e\x47\x26\x25\xc5\x64\x39\x9e\xeb\xd5\x44\xdf\x5d\x03\x29\xe8\xf5\xee\x15\xfc\xc2\x8e\x42\x3c\x4f\xfe\x8a\xdf\x86\x91\xe2\x35\x5d\xbe\x49\x11\x5b\xf0\x83\xbf\x7a\xa6\xd7\x4f\xfd\x98\xea\x54\x43\xd3\xf7\xa8\xfb\x08\xe3\x45\x72\xd3\xe1\xa8\xfb\x24\xc5\x32\x4d\x74\x0a\x2f\x9f\xff\x64\xd5\x4f\x37\xdf\xcf\x2b\x56\x90\x34\x02\x00\x00")
+
+func sqlGettradehistorySqlBytes() ([]byte, error) {
+	return bindataRead(
+		_sqlGettradehistorySql,
+		"sql/getTradeHistory.sql",
+	)
+}
+
+func sqlGettradehistorySql() (*asset, error) {
+	bytes, err := sqlGettradehistorySqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "sql/getTradeHistory.sql", size: 564, mode: os.FileMode(438), modTime: time.Unix(1791969267, 0)}
+	a := &asset{bytes: bytes, info:  info}
+	return a, nil
+}
+
+var _sqlGetuserSql = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x6d\x90\x4d\x4f\x02\x31\x10\x86\xcf\x36\xe9\x7f\x98\x83\x09\x4a\xaa\xc4\x2b\x89\x07\x42\x96\x78\x40\x31\x0b\xc6\xf3\x58\x66\xd9\x86\x7e\xac\x6d\x17\xdc\x7f\xef\x14\xa2\x5c\xb8\xcd\xbc\x33\xcf\x3b\x1f\x93\xb1\x14\x33\xfd\xdd\x9b\x48\x09\x10\xfa\x44\x11\x9a\x18\x1c\xe4\x96\x80\x93\x03\xe7\x47\x93\x5b\xf0\x01\xb0\x67\xd1\x67\xa3\x31\x9b\xe0\xa5\x90\x62\x83\x7b\x4a\x53\x29\x6e\x3c\x3a\x82\x07\x48\x39\x1a\xbf\x53\x67\x9b\xdc\x62\x86\x70\xf4\x09\x4c\x96\x62\x3c\x29\xc0\xba\x5a\x56\xf3\x0d\x94\x76\x05\xe4\xd0\x58\x05\x1d\xa6\xd4\x62\x6a\x15\xcf\xf0\x9a\x75\x87\x3f\x3a\x58\x4b\xba\x8c\x49\x0a\x6c\xf0\x3b\x4a\xf9\x60\xe8\xa8\xa0\x09\x51\x53\x4d\x27\x40\x8a\xa4\xe3\xd0\xe5\x37\x05\xe7\xa0\xfe\x0b\xde\xb9\xa6\xfb\x18\xc9\xeb\xa1\x18\x68\xb4\x6c\xcc\xc7\x98\xc6\xd0\x56\xc1\x7c\x35\x5b\x56\xeb\x79\x75\x77\x92\x86\x4d\xd8\x93\x57\x30\x1a\x4d\xa7\x5f\x43\x26\xbc\x67\xbc\xe1\xe5\x68\xbb\x0c\x3b\x53\x76\xf8\x07\x2c\xa6\xbc\xb8\x94\x18\xa2\x2e\xe8\x96\xc9\x6c\x1c\x6f\x89\xae\x2b\xf4\xa5\x3f\xe8\x3d\x6d\x3f\xf8\x6d\xf6\x6a\xaf\x14\x8b\x7a\xf5\x2a\x45\x79\x59\x7a\x74\x94\x11\x3e\x5f\xaa\xba\x3a\xfd\xe8\xf9\xf6\xe9\x17\xbe\xe6\x22\xd1\xa1\x01\x00\x00")
+
+func sqlGetuserSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_sqlGetuserSql,
+		"sql/getUser.sql",
+	)
+}
+
+func sqlGetuserSql() (*asset, error) {
+	bytes, err := sqlGetuserSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "sql/getUser.sql", size: 417, mode: os.FileMode(438), modTime: time.Unix(1791969924, 0)}
+	a := &asset{bytes: bytes, info:  info}
+	return a, nil
+}
+
+var _sqlGetwebhooksSql = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x2d\x8e\x31\x0b\xc2\x30\x14\x84\x67\x03\xf9\x0f\x6f\x70\x2a\xd1\xe2\x2a\x38\x88\x44\x1c\x14\xa1\x16\x9c\x53\xfb\x6c\x42\x63\xa2\x79\x89\xc5\x7f\x6f\x53\x5d\xef\xee\xbb\xbb\xb2\xe0\x6c\x7b\x7b\x25\x13\x90\x00\xdf\x18\x3e\x30\x60\xa3\xbd\xef\x01\x5d\xfb\xf4\xc6\x45\x50\x90\x08\x03\x68\x45\x10\xb0\x33\x14\x31\x60\xcb\x19\x67\xb5\xea\x91\xd6\x9c\xcd\xfc\xe0\xc6\xc0\x02\x28\x06\xe3\x3a\x01\x51\xe3\x8f\x89\x5a\x45\x18\x5d\xca\xd2\x83\xb3\xa2\xcc\xdc\x45\x1e\xe5\xae\xe6\x6c\xc2\x04\xa4\x60\x05\xdc\x95\xb1\x69\x3c\x21\xa0\x35\xa4\x1a\x9b\x17\xf6\xd5\xf9\xc4\x59\x2e\xa2\xe5\xff\x15\xc1\xf5\x20\x2b\x09\x13\xba\x99\xaf\xbe\xca\xf1\x03\x50\xc0\x00\x00\x00")
+
+func sqlGetwebhooksSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_sqlGetwebhooksSql,
+		"sql/getWebhooks.sql",
+	)
+}
+
+func sqlGetwebhooksSql() (*asset, error) {
+	bytes, err := sqlGetwebhooksSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "sql/getWebhooks.sql", size: 192, mode: os.FileMode(438), modTime: time.Unix(1791966897, 0)}
+	a := &asset{bytes: bytes, info:  info}
+	return a, nil
+}
+
+var _sqlListsessionsSql = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x4d\x8e\x5d\x4b\xc3\x30\x14\x86\xaf\x0d\xe4\x3f\xbc\x17\x5e\xcc\x51\x37\xbc\x15\x14\x6a\x17\x11\x9c\x16\xba\xa9\x78\x19\xcd\x69\x1b\x96\x25\x98\x93\xad\xf4\xdf\xdb\x96\xf9\x71\x97\x03\x79\x9e\xf7\x59\xce\xa5\xc8\x3f\xbf\x0e\x36\x12\x23\xb5\x84\x56\x73\x4b\x06\x3b\xea\x11\x6a\xd0\x91\x62\x0f\x4e\xd6\x39\x1c\xb5\xb3\x06\x4c\xcc\x36\x78\xd4\x21\x42\xe3\xc0\x14\xa5\xd0\x2e\xf8\x86\xad\x21\x74\x2d\x79\xd8\x84\x0f\x6a\xb4\xcf\x40\xde\x30\xb4\x37\xe8\x34\xc3\x69\x4e\x23\x60\x32\x04\x67\x68\x38\x6a\x1b\x39\x2d\xa4\x90\x62\xab\x77\xc4\xd7\x52\x9c\x79\xbd\x27\x5c\x0e\x8b\xd1\xfa\x26\x9b\xfc\x43\x96\x4e\x08\x9d\x9f\x02\xf7\x52\xcc\x97\x23\xb2\x51\x6b\x55\x6c\x7f\x7a\x1e\xa9\xcf\x06\x4a\xc7\xf4\x3a\x66\x4e\xd3\xa7\x57\x51\xe6\x6b\xb5\x29\xd4\x6c\x0c\x78\x99\xf6\xff\x3e\x5e\x48\x71\x5f\x95\x4f\x52\x8c\x4b\xbc\x38\xd9\x58\x8a\xb7\x07\x55\x29\x8c\x39\x37\xe7\x57\xc8\x9f\x57\xbf\x46\xdc\xc2\x87\x6e\x36\x90\x65\xb5\x52\x15\xee\xde\xff\xf9\xbe\x01\x45\x57\x2b\xed\x50\x01\x00\x00")
+
+func sqlListsessionsSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_sqlListsessionsSql,
+		"sql/listSessions.sql",
+	)
+}
+
+func sqlListsessionsSql() (*asset, error) {
+	bytes, err := sqlListsessionsSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "sql/listSessions.sql", size: 336, mode: os.FileMode(438), modTime: time.Unix(1791968820, 0)}
+	a := &asset{bytes: bytes, info:  info}
+	return a, nil
+}
+
+var _sqlLockcollectionSql = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x4d\x8f\xb1\x4e\xc3\x30\x14\x45\x67\x2c\xf9\x1f\xee\xd0\xa9\x2a\xad\x60\x44\xea\x10\xd1\x20\x86\x42\x51\x08\x62\x76\x9d\xd7\xc6\x6a\xfc\x2c\xfc\x5c\x22\xfe\x1e\x3b\x1d\xda\xd1\xf6\x39\xf7\x5e\xaf\xe6\x5a\x55\xf6\xe7\xec\x22\x09\xc6\x9e\x52\x4f\x11\x06\x36\x0c\x03\xd9\xe4\x02\xc3\x9b\x3f\xec\x09\x3e\x74\xee\xe0\xa8\x5b\x60\x08\xf6\xe4\xf8\x08\x97\x04\x31\x8c\x38\x73\x72\x83\x56\xd9\x44\x8a\x86\xc5\x5c\x3c\xe2\x4e\x20\x01\x4c\x6e\x0a\xb5\x86\x61\x7b\xc3\x47\x82\x63\x14\xda\x93\xc9\xaa\xa7\xa5\x56\x5a\xb5\xe6\x44\xf2\xa4\xd5\x5d\x18\x39\xd3\xf7\x90\x14\x73\xcb\x02\x67\xc9\xc7\xd4\x9b\x84\xfc\x22\xb9\x35\x33\x37\xf3\xae\xe0\xcd\x65\x38\x5c\x8c\xe2\x6a\x35\x5f\x95\x82\xcf\x7a\x5b\x3f\xb7\xd3\xfa\xf2\x8b\xf0\x4b\x71\xeb\x7c\x89\x7b\x69\x76\x6f\x5a\x15\x56\x96\xd7\x10\xc1\xf7\x6b\xdd\xd4\x98\xf6\xac\x67\x0f\xa8\xde\x37\x60\xe3\x69\x3d\x7b\xcc\xce\xae\xc1\xd7\xc7\xa6\x6a\xeb\x7f\x90\x5d\xed\x96\x42\x01\x00\x00")
+
+func sqlLockcollectionSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_sqlLockcollectionSql,
+		"sql/lockCollection.sql",
+	)
+}
+
+func sqlLockcollectionSql() (*asset, error) {
+	bytes, err := sqlLockcollectionSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "sql/lockCollection.sql", size: 322, mode: os.FileMode(438), modTime: time.Unix(1791975143, 0)}
+	a := &asset{bytes: bytes, info:  info}
+	return a, nil
+}
+
+var _sqlLockemailSql = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x4d\xcc\x31\x0f\x82\x30\x10\x86\xe1\xd9\x26\xfd\x0f\x37\x38\x00\x41\x89\xab\xab\x61\x73\x83\xbd\xb9\xb4\x17\xb8\x50\x5a\xd3\xab\x8a\xfe\x7a\xad\x93\xeb\x97\xf7\xf9\xba\x46\xab\x81\x12\xa3\xe7\x37\x09\xe4\x84\x41\xd0\x66\x8e\x41\xc0\x7a\xe4\x95\xc3\x04\x18\x80\x56\x64\xdf\x02\x4f\x21\xa6\x32\x59\x14\x6a\xe1\x1e\x32\x7b\xc8\x33\x69\xf5\x27\x81\x82\x93\xa3\x56\x5a\x8d\xb8\x90\x9c\xb5\xda\xfd\x38\x1c\x40\x72\xd1\x6d\x21\x80\xce\x25\x12\xd1\xaa\xe9\x4a\x3b\xf4\xd7\xfe\x32\xc2\x6d\x32\xe8\x1e\x2c\x31\xbd\xcc\xf6\xfd\x33\x3e\xda\xa5\x9a\x51\xe6\x4c\x5b\xae\x7c\x7c\x52\xaa\xf6\xa7\xba\xae\x3f\x76\x0d\xb1\x75\xbb\x00\x00\x00")
+
+func sqlLockemailSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_sqlLockemailSql,
+		"sql/lockEmail.sql",
+	)
+}
+
+func sqlLockemailSql() (*asset, error) {
+	bytes, err := sqlLockemailSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "sql/lockEmail.sql", size: 187, mode: os.FileMode(438), modTime: time.Unix(1791970065, 0)}
+	a := &asset{bytes: bytes, info:  info}
+	return a, nil
+}
+
+var _sqlLocksubSql = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x1d\x8e\x41\x4b\xc3\x40\x10\x85\xcf\x2e\xec\x7f\x78\x07\x41\x28\xb5\xc5\xab\xe0\xa1\xd4\x15\x0f\x4a\x25\x46\x3c\x4f\x37\x63\x3b\x24\x9d\xd4\x9d\x09\xfe\x7d\xb3\xb9\xce\x37\xef\xbd\x6f\xbb\x8a\x61\x97\x7f\x27\x29\x6c\xf0\x33\xe3\x3a\x90\x62\xfc\x01\x61\x32\x2e\x6b\x0c\x63\xee\x45\x4f\x95\x49\x81\x4d\x47\xcb\x45\xae\x2e\xa3\x62\x52\x97\xa1\x82\x18\xbc\x90\x1a\xe5\xe5\xcc\xda\x19\x6c\xc4\x20\x17\x71\x43\x3e\x73\xee\xb9\x03\x9d\x48\xd4\x1c\xe2\xc8\xa4\x77\x8e\x23\xa3\x50\xe6\x6e\x13\x43\x0c\x2d\xf5\x6c\x8f\x31\xdc\x28\x5d\x18\xf7\x30\x2f\xf3\xea\x7a\x91\x98\x27\xc8\x31\xfe\xa9\xcd\xe1\x18\x56\xdb\x1a\xf8\x4c\x6f\x69\xdf\x2e\xba\x31\xbc\x34\x87\xf7\x18\xea\xaf\x6d\xaa\x22\xbe\x5f\x53\x93\x50\xbb\x9e\x6e\x1f\x66\x7e\x68\xf0\xf5\xf1\xbc\x6b\xd3\x3f\x4d\xd1\x9a\x8a\xf0\x00\x00\x00")
+
+func sqlLocksubSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_sqlLocksubSql,
+		"sql/lockSub.sql",
+	)
+}
+
+func sqlLocksubSql() (*asset, error) {
+	bytes, err := sqlLocksubSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "sql/lockSub.sql", size: 240, mode: os.FileMode(438), modTime: time.Unix(1791975270, 0)}
+	a := &asset{bytes: bytes, info:  info}
+	return a, nil
+}
+
+var _sqlMarkalertfiredSql = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x2d\x8d\xb1\x0a\xc2\x30\x18\x84\x67\x03\x79\x87\x1b\x3a\x95\x6a\xd1\x51\xe8\x20\x18\x70\x94\x5a\x71\x0e\xcd\x5f\x0d\x35\x89\xe4\x4f\xf5\xf5\x4d\xab\xeb\x7d\xf7\xdd\xd5\xa5\x14\x2d\xf5\x21\x1a\x46\x7a\xe8\x04\xed\xa1\x9f\x14\x13\x06\x1b\xc9\x54\x70\xe1\x4d\x0c\x1d\x09\x8e\x34\x4f\x39\xc3\x10\x83\xcb\x65\xf2\x08\x5e\x0a\x29\x3a\x3d\x12\xef\xa5\x58\x59\x83\x35\x38\x45\xeb\xef\xd5\x5c\xf8\x2d\x65\xc0\xd6\xf7\x94\x59\xb2\x8e\x38\x69\xf7\xaa\xf0\x99\x7d\xfb\xbf\x91\xa2\xac\xe7\xa5\xeb\xf9\x78\xe8\x14\x26\xa6\xc8\x9b\x45\x66\x5c\x54\x87\xc5\x6f\x8a\x1d\x6e\x27\xd5\x2a\x58\xd3\x14\xdb\x2f\x1f\x7a\x43\xd2\xba\x00\x00\x00")
+
+func sqlMarkalertfiredSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_sqlMarkalertfiredSql,
+		"sql/markAlertFired.sql",
+	)
+}
+
+func sqlMarkalertfiredSql() (*asset, error) {
+	bytes, err := sqlMarkalertfiredSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "sql/markAlertFired.sql", size: 186, mode: os.FileMode(438), modTime: time.Unix(1791973466, 0)}
+	a := &asset{bytes: bytes, info:  info}
+	return a, nil
+}
+
+var _sqlMarkresetsentSql = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x55\x8f\x4d\x4b\xc3\x40\x14\x45\xd7\x0e\xcc\x7f\xb8\x8b\x2e\xb4\xf4\x03\x75\x27\x76\x51\x68\x40\xa1\x54\x49\x52\xba\x9e\x26\x2f\x26\x98\xcc\x94\x99\x57\x83\xff\xde\x97\x89\x22\xd9\x5e\xce\x3d\xef\xbe\xf5\x5c\xab\x94\x0a\xe7\xcb\x00\x03\x4f\x81\x18\x67\x6a\xec\x07\x02\x59\x06\x3b\x49\xaf\x81\xfc\x02\x17\xef\xbe\x9a\x92\x4a\x58\x67\x09\xbd\x09\x23\x11\x1a\x5b\x90\x56\x5c\x13\x8a\x2b\xbb\xaa\x5a\x69\xa5\xd5\xc1\xc1\xbb\x5e\x94\x9e\x60\xaa\x8a\x0a\x96\x62\x5f\x93\xc5\x00\x0e\x42\xd4\x62\x38\x93\x24\x51\xf3\x77\x9b\x9d\x34\xa9\x90\xa8\xfd\x8e\xa6\xdc\x7c\x52\x78\xd2\xea\xc6\x9a\x8e\xb0\x44\x60\x2f\xeb\x16\xa3\x83\x6b\xc3\x70\xbd\x0d\x68\x58\x90\x68\x5a\x82\x9b\x8e\x02\x9b\xee\xb2\xf8\x3f\x39\xda\x9b\x71\xb4\xa0\xe3\xd6\x29\x1c\x99\xdf\xb7\x4c\xc5\xd1\x2f\x0d\xae\xbd\x63\x6e\xe5\x11\xeb\x44\xe5\xb5\x9a\xaf\x87\x65\xc7\xf7\xdd\x36\x4f\xe2\x90\xb0\xea\x88\x0d\xb2\x24\x47\x6b\x02\xa7\x83\x28\x13\xcd\x66\xf6\xa0\xd5\xe9\x25\x49\x13\x0c\xf3\x37\xb3\x7b\x6c\x0f\x3b\xdc\x4e\x20\xbc\x66\x38\x1c\xf7\x7b\xbc\xa5\xd3\x36\x9e\x37\x98\x3d\xde\xfd\x00\x7d\xa1\xb2\x29\xa3\x01\x00\x00")
+
+func sqlMarkresetsentSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_sqlMarkresetsentSql,
+		"sql/markResetSent.sql",
+	)
+}
+
+func sqlMarkresetsentSql() (*asset, error) {
+	bytes, err := sqlMarkresetsentSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "sql/markResetSent.sql", size: 419, mode: os.FileMode(438), modTime: time.Unix(1791971815, 0)}
+	a := &asset{bytes: bytes, info:  info}
+	return a, nil
+}
+
+var _sqlModsubSql = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x74\x51\x41\x6f\xea\x30\x0c\x3e\x3f\x24\xfe\x83\x0f\x48\x05\xd4\x07\x7a\x6f\xdb\x65\x1c\x19\x87\x49\x3b\x4c\x6b\x77\x9b\x34\xa5\xc4\x94\x88\x34\xae\x6a\x07\xc4\xbf\x9f\x13\x40\x9a\x34\xed\x90\xc6\x76\xbe\xef\xb3\xfd\x75\x39\x1f\x8f\xc6\xa3\xf7\xd7\x6a\xf3\x56\x33\xb8\x20\x04\x91\x71\xe0\x05\xc7\x86\x35\x74\xa1\x05\xd9\x23\x74\x64\x3f\xb5\x04\xbb\x18\xb6\xe2\x28\x2c\x12\x6d\x4d\xd1\x5b\xe8\x49\x30\x88\x33\xde\x9f\xc1\x13\xf5\xb0\xa3\x01\x8f\x38\x40\x13\x05\x5a\x22\xab\x1f\x0b\x96\x90\x15\xca\xd2\x0e\x1a\x04\x44\xab\xba\x4e\x23\x23\xee\x88\xfe\x9c\x05\x6f\x5d\xf6\x86\x73\x57\x55\xea\x8c\x8c\x47\x7f\xae\x0f\xd3\x22\x09\x7b\xd3\x16\x25\x14\x15\x06\x46\xf7\x51\x30\xd4\xd4\x6b\x21\xd0\xa9\x54\x68\xc1\xd4\xe1\x3a\xb2\xe8\x35\xd4\x74\xc0\x90\xc0\xa9\x58\xc5\xe6\x92\xcf\x56\xa9\x59\x6d\x0e\xc8\x8f\xca\x08\xa6\x43\xf8\x0b\x2c\x83\x6e\x5b\xe6\xfd\xb5\xbb\x11\xa0\x53\x50\x4f\x52\xff\xde\x9b\xa0\x10\x9d\x9f\x5d\xe3\x93\x52\x99\x07\xcc\xf5\xcc\x4f\x59\x66\x5a\x64\xa7\x2b\x2a\x49\x5c\xd6\x4d\x17\x8b\xe9\xfa\x0b\xc5\x1b\xd1\x14\xb6\x7b\x13\x5a\x54\xd4\xf6\x3a\xea\xf3\xd3\xb7\x19\x12\xf0\xf6\xa0\x23\x58\x50\x43\xfa\x81\x8e\xce\xaa\x6f\xcd\x39\xe3\xfa\xc4\x56\x53\x7e\x10\x93\x83\xbf\x53\xe6\xcb\xb4\x7c\xb5\x79\xd9\xac\xeb\xdb\x6f\x9d\x4e\xfe\x95\x30\xf9\xaf\xe7\x4e\xcf\xbd\x9e\x87\xd9\xea\x2b\x00\x00\xff\xff\x23\x93\xa7\xaf\x1b\x02\x00\x00")
+
+func sqlModsubSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_sqlModsubSql,
+		"sql/modSub.sql",
+	)
+}
+
+func sqlModsubSql() (*asset, error) {
+	bytes, err := sqlModsubSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "sql/modSub.sql", size: 539, mode: os.FileMode(438), modTime: time.Unix(1431382801, 0)}
+	a := &asset{bytes: bytes, info:  info}
+	return a, nil
+}
+
+var _sqlRecorddeadletterfailureSql = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x45\x8e\x41\x0b\xc2\x30\x0c\x85\xcf\x16\xfa\x1f\x72\xf0\x34\xa7\xa2\x47\x61\x07\xc1\x82\x07\x11\xd1\x89\xe7\x4a\xe3\x56\xdc\x5a\x69\x22\xe2\xbf\x37\x4e\xa7\xb7\xbc\xe4\xbd\xef\x65\x9a\x69\xb5\x8d\x8c\x04\x36\x44\xae\x31\xc1\xc5\xfa\x06\x1d\x58\x66\x6c\x6f\x0c\x1c\x81\x30\x88\x06\x87\xd6\x41\x83\xb2\x4f\x72\x6f\xc5\xa6\x95\x56\xa5\xbd\x22\x2d\xb4\x1a\x78\x07\x63\x38\xfb\xca\x07\xce\x41\x48\x5f\xab\x8c\x96\x7f\xd0\xca\xfa\x20\xde\xc6\x12\x9b\x94\x62\x92\x08\x71\xf2\xa1\xca\xe1\x51\x3f\xbb\x58\x5f\xfc\x89\x68\x95\x4d\xdf\x35\xc7\xdd\x6a\x59\x1a\xb8\x13\x26\x9a\xbc\x3f\xd9\x74\x74\xd2\xea\x60\x4a\xf8\xf3\x0a\x18\xce\xf3\x9e\x41\x22\x7f\xe3\x08\x66\x5a\x9d\xd6\x66\x6f\xc0\xbb\x62\x38\x7b\x01\xd2\xe8\xc7\x4d\xfa\x00\x00\x00")
+
+func sqlRecorddeadletterfailureSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_sqlRecorddeadletterfailureSql,
+		"sql/recordDeadLetterFailure.sql",
+	)
+}
+
+func sqlRecorddeadletterfailureSql() (*asset, error) {
+	bytes, err := sqlRecorddeadletterfailureSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "sql/recordDeadLetterFailure.sql", size: 250, mode: os.FileMode(438), modTime: time.Unix(1791968331, 0)}
+	a := &asset{bytes: bytes, info:  info}
+	return a, nil
+}
+
+var _sqlRecordloginfailureSql = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x5d\x50\xc9\x6e\xc2\x30\x10\x3d\xd7\x92\xff\xe1\x1d\x38\xd1\x14\x44\x7b\xab\x94\x4a\x08\xdc\x45\x42\x51\x05\x41\x3d\xbb\x64\x20\x56\x13\x1b\xd9\x4e\xf3\xfb\x1d\x07\x5a\x44\x4f\x5e\xe6\x6d\xf3\xa6\x63\x29\x16\xae\xb3\x31\x40\x63\xaf\x4d\x43\x15\x1a\x77\x30\x36\x43\x88\xda\x47\x63\x0f\x88\x35\x61\x97\x30\xd0\x7b\x4f\xa1\x46\x5f\x93\x1d\x7e\x1b\x1d\xa2\x14\x89\xd6\x79\x82\x09\x88\xce\xc1\x35\x15\xf6\xce\x33\x60\xf8\xc0\x67\x62\xdb\x40\xbb\x2e\x9a\x6f\x9a\x48\x21\x45\xa9\xbf\x28\x3c\x4a\x71\x63\x75\x4b\xb8\x63\x2b\xcf\x46\xd9\xa0\xd9\x05\xf2\x3c\xe9\x8d\xad\x5c\xbf\x49\x19\x18\x10\x4d\x4b\x9c\xa7\x3d\x66\x38\xbb\x05\xd6\x65\x17\x3a\xd9\x68\xbe\xf0\xeb\xe0\x62\x24\x9b\x74\x5d\x7f\xcd\x3a\x47\x66\xe8\x6f\xda\x5a\x1f\x8f\x64\xa9\x4a\x79\xd6\x14\x3b\x6f\xc3\x79\xd3\xbf\xac\x17\x2f\x63\x77\x4d\x57\x9d\xca\x60\x0d\x67\xd3\x1e\xe3\x69\xe2\x6e\xdf\x97\xf3\x52\x0d\xb1\xc3\xa4\xa5\xa8\xb1\x51\xe5\xa9\x14\xaa\x56\xa9\xca\x80\x1c\x8b\xf9\x46\xe1\xe3\x55\x15\x43\x67\xcf\x97\x21\x9e\x30\xba\x47\x99\x26\x57\x94\x5b\xcc\xa0\x56\x4c\xe2\xa3\x58\x66\x52\xfc\xe7\xe5\x18\x3d\x48\xc1\x92\x6b\x85\x54\x63\x3e\x9a\xf1\x22\xaa\xdc\xae\x8b\xb7\xe2\xe5\x4a\xec\x07\x9f\x4d\x86\xb3\xe5\x01\x00\x00")
+
+func sqlRecordloginfailureSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_sqlRecordloginfailureSql,
+		"sql/recordLoginFailure.sql",
+	)
+}
+
+func sqlRecordloginfailureSql() (*asset, error) {
+	bytes, err := sqlRecordloginfailureSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "sql/recordLoginFailure.sql", size: 485, mode: os.FileMode(438), modTime: time.Unix(1791969634, 0)}
+	a := &asset{bytes: bytes, info:  info}
+	return a, nil
+}
+
+var _sqlRecordstripeeventSql = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x6d\x8d\x4d\x8b\xc2\x30\x14\x45\xd7\x06\xf2\x1f\xee\xc2\x85\x8a\x33\xd2\xad\x30\x2b\x89\x58\x70\x5a\x68\x03\x33\xdb\xe8\x7b\xda\xa0\xa4\x92\x97\x69\xf1\xdf\x5b\x3f\x66\xe7\xf6\x1e\xce\xb9\x8b\x99\x56\x15\xef\xdb\x48\x02\x07\x49\xd1\x5f\x18\xdc\x71\x48\x70\x82\xc6\x05\x3a\x33\xcd\xe1\x0e\x07\xde\x27\x1f\x8e\x08\x2d\x62\xdb\x0b\xfa\x86\x03\x7c\xd2\xca\x9d\x23\x3b\xba\xa2\x77\xa2\x95\x56\xd6\x9d\x58\x96\x5a\x8d\x3c\xe1\xe3\x11\x0c\xc7\x39\x52\xf3\x5f\x1d\xe6\x21\x7c\x89\x6d\xe7\x89\x09\xbb\xeb\xeb\x54\xab\xd9\xe2\xee\xe7\x45\x6d\x2a\x8b\xbc\xb0\x25\xfe\x84\xa3\x7c\x3e\xb9\xb9\xdb\x82\x89\xa7\xa9\x56\xb5\xd9\x9a\x95\xc5\x38\xd3\xea\x67\x63\x2a\x83\xa2\xb4\x30\xbf\x79\x6d\x6b\x4c\x5e\x30\xc3\xba\x2a\xbf\xdf\x35\x9e\x8a\xa7\xaf\x71\x36\xbd\x01\xd3\x4e\x95\xc1\x00\x01\x00\x00")
+
+func sqlRecordstripeeventSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_sqlRecordstripeeventSql,
+		"sql/recordStripeEvent.sql",
+	)
+}
+
+func sqlRecordstripeeventSql() (*asset, error) {
+	bytes, err := sqlRecordstripeeventSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "sql/recordStripeEvent.sql", size: 256, mode: os.FileMode(438), modTime: time.Unix(1791971004, 0)}
+	a := &asset{bytes: bytes, info:  info}
+	return a, nil
+}
+
+var _sqlRecordwebhookfailureSql = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x5d\x91\x4d\x4f\xc3\x30\x0c\x86\xcf\x44\xca\x7f\xf0\xa1\x07\xd8\x3a\xa6\x6d\x37\xa4\x4e\x9a\xb4\xf2\x71\x29\xa8\x74\xe2\x9c\xb6\x1e\x89\xd6\xc6\x28\x49\x29\xfc\x7b\xbc\xaa\xac\x88\x53\x2c\xfb\x7d\x6d\x3f\xce\x72\x26\x45\x8e\x15\xb9\xda\x83\x82\xa3\x32\x0d\xd6\x50\x63\x63\x3e\xd1\x7d\x43\x20\x4e\xf6\x58\x6a\xa2\x53\x0c\xb5\xf1\xaa\x6c\x8c\x7d\x07\x13\x80\x6c\x85\xe7\x57\x2b\x2f\xc5\xe8\x0b\x44\xd0\x2a\xcb\x3e\xd3\xa2\x07\x63\xd9\xed\xa8\x97\x42\x8a\x42\x9d\xd0\xdf\x49\x71\x45\xbd\x45\x07\x0b\xf0\xc1\x71\xa7\x18\x82\x46\xe8\x3c\xa7\x82\x56\xdc\xb5\xb7\xec\x0b\xac\xeb\x5c\xf3\x4f\x85\xb6\xfe\x20\x63\x03\xf4\xda\x54\x7a\xdc\x95\x95\xad\xfa\xba\xe7\xb8\x73\x3c\x72\xc1\x43\xc3\x66\x1d\x43\x45\xd6\x63\xd5\x05\xe6\x18\x94\x43\xb5\xc4\x23\x39\x9c\x38\xa4\x98\x2d\xcf\xcb\x1d\x5e\xf6\xbb\x22\x1d\xd6\xf0\xb7\x23\x2e\x53\xbd\xa6\xc5\xe4\x4d\xa6\x70\x0e\xab\xdf\x63\x30\x74\x02\xd7\x7f\x2b\xb0\x4d\x20\xda\xdc\x48\xf1\xf6\x98\xe6\x29\x0c\xb8\x49\xb4\x82\x5d\xb6\x07\x66\x4a\xa2\xf5\x10\x66\xcf\xc5\xa5\x05\xff\x40\x5a\x1c\xf2\xec\x29\x7b\xb8\xe4\x7e\x00\xd7\xf8\xde\xc3\x98\x01\x00\x00")
+
+func sqlRecordwebhookfailureSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_sqlRecordwebhookfailureSql,
+		"sql/recordWebhookFailure.sql",
+	)
+}
+
+func sqlRecordwebhookfailureSql() (*asset, error) {
+	bytes, err := sqlRecordwebhookfailureSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "sql/recordWebhookFailure.sql", size: 408, mode: os.FileMode(438), modTime: time.Unix(1791966897, 0)}
+	a := &asset{bytes: bytes, info:  info}
+	return a, nil
+}
+
+var _sqlRecordwebhooksuccessSql = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x5d\x8e\x31\x0b\xc2\x30\x10\x46\x67\x03\xf9\x0f\x37\x74\x12\xb5\xea\x28\x74\x28\xb6\xe0\x24\xa2\x15\xe7\xd8\x5e\x4c\x68\x48\x24\x97\x58\xfc\xf7\xc6\xaa\x8b\xe3\x1d\x1f\xef\xbd\x7c\xca\xd9\xd6\xa0\xf0\x04\x41\x21\x48\xa1\x4d\xf4\x08\xad\x8b\x36\x80\x93\x20\x60\xc0\xab\x72\xae\x07\x21\x03\xfa\x74\x53\x6c\x5b\x24\x92\xd1\x40\x87\x46\x3f\xd0\x3f\x39\xe3\xac\x11\x3d\xd2\x86\xb3\x89\x1b\x6c\xda\xcd\x81\x82\xd7\xf6\x36\x1b\xb1\x91\xd2\x2b\x28\x91\x90\x83\x25\xd0\x21\xed\xa2\x37\x7f\x2b\xb4\xdd\xdd\xe9\xe4\x1d\x94\x6e\xd5\x47\x84\x1d\x76\x9c\x4d\xf3\xb7\xe2\x7c\xa8\xca\xa6\x1e\x61\xb4\xf8\x66\x11\x67\xa7\xba\xf9\x75\x13\x14\xb0\xe4\xec\xb2\xab\x8f\x35\x8c\x21\x45\xb6\x82\x72\x5f\x41\xb2\x15\xd9\xfa\x05\x0d\x99\x2f\xc3\xee\x00\x00\x00")
+
+func sqlRecordwebhooksuccessSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_sqlRecordwebhooksuccessSql,
+		"sql/recordWebhookSuccess.sql",
+	)
+}
+
+func sqlRecordwebhooksuccessSql() (*asset, error) {
+	bytes, err := sqlRecordwebhooksuccessSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "sql/recordWebhookSuccess.sql", size: 238, mode: os.FileMode(438), modTime: time.Unix(1791966897, 0)}
+	a := &asset{bytes: bytes, info:  info}
+	return a, nil
+}
+
+var _sqlRemovealertSql = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x5d\x8d\x3d\x0b\xc2\x30\x14\x45\xe7\x06\xf2\x1f\xee\xd0\xa9\xa8\x45\x47\xa1\x83\x90\x14\x07\x3f\xa0\x14\x9c\x83\x7d\xd8\xd0\x50\x4b\xde\x13\xff\xbe\x31\x38\xb9\xde\x7b\xee\xb9\x75\xa5\x95\xa1\x40\x42\x0c\x07\xf6\xf3\x23\x10\x96\xe8\xef\x04\x17\x28\x8a\x56\x5a\xf5\x6e\x22\xde\x6b\x55\x3c\xdf\x33\x45\xac\xc1\x12\x13\xb8\x82\x8c\x84\x17\xa7\x48\x46\x27\x48\x2d\xc3\xa7\x45\xe1\x87\x3f\xe8\xa7\xaa\xea\xaf\xce\xd8\x93\xed\x2d\xda\xee\x7a\xce\x6b\xde\xe4\x9a\x71\x3b\xda\xce\x22\x9f\x34\xe5\x16\x87\x8b\x81\x1f\x9a\x72\xf7\x01\x5a\x6f\xb5\xb6\xa4\x00\x00\x00")
+
+func sqlRemovealertSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_sqlRemovealertSql,
+		"sql/removeAlert.sql",
+	)
+}
+
+func sqlRemovealertSql() (*asset, error) {
+	bytes, err := sqlRemovealertSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "sql/removeAlert.sql", size: 164, mode: os.FileMode(438), modTime: time.Unix(1791973466, 0)}
+	a := &asset{bytes: bytes, info:  info}
+	return a, nil
+}
+
+var _sqlRemoveallsessionsSql = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x2d\x8c\xb1\x0a\xc2\x30\x14\x45\x67\x03\xef\x1f\xee\xe0\x54\xd4\xe2\x2a\xb8\xf9\x8a\x83\x22\x84\x82\x73\x86\xa7\x16\x49\x02\xb9\xb1\xe2\xdf\x6b\x8b\xdb\x81\x7b\xee\x69\x1b\x71\xde\x62\x1e\x8d\xb0\xd1\xca\x07\x34\x72\xc8\x09\xf9\x86\x80\x17\xad\x88\x13\xd7\x87\xa7\x71\x27\x6e\x91\x42\x34\xac\xc1\x5a\x86\x74\x5f\xcd\x3b\xea\x23\x54\xe4\x77\xe2\x8f\x2c\x8a\x6b\xda\xe9\x72\xd0\x93\xf6\x8a\xce\x5f\xce\xb3\xc6\xcd\xbf\x4c\x5c\x8f\xea\x15\x53\x6a\xbf\xdc\x7e\x01\x4e\xbf\xd4\xe1\x82\x00\x00\x00")
+
+func sqlRemoveallsessionsSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_sqlRemoveallsessionsSql,
+		"sql/removeAllSessions.sql",
+	)
+}
+
+func sqlRemoveallsessionsSql() (*asset, error) {
+	bytes, err := sqlRemoveallsessionsSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "sql/removeAllSessions.sql", size: 130, mode: os.FileMode(438), modTime: time.Unix(1791968877, 0)}
+	a := &asset{bytes: bytes, info:  info}
+	return a, nil
+}
+
+var _sqlRemovecollectionSql = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x4d\x8d\x3d\x0b\xc2\x40\x0c\x86\x67\x0f\xee\x3f\x64\xe8\x54\xaa\x45\x47\xa1\x83\xd0\x13\x07\x3f\xa0\x14\x9c\xcf\x1a\xed\x61\x7b\x07\x97\x54\xff\xbe\x69\x41\xec\x96\x37\x79\xf3\x3c\x79\xaa\x55\x85\x7d\x78\x23\x81\x85\x26\x74\x1d\x36\xec\x82\xcf\xc0\x31\x49\xf6\x8c\x5e\x86\x7e\x20\x86\x1b\x42\x9c\xaa\x77\x78\xb8\x48\xac\x95\x56\xb5\x7d\x21\x6d\xb5\x5a\x84\x8f\xc7\x08\x4b\x20\x8e\xce\x3f\x33\x18\x48\x22\xb7\x96\x41\x2e\x24\x34\xe9\x78\xdb\xe3\xac\xc2\x2d\xce\x8c\x82\x97\xed\xcf\xa0\x55\x9a\x8f\xfc\xd2\x1c\x4d\x6d\x60\x5f\x5d\x4e\x13\x92\x56\xff\x0f\x82\xeb\xc1\x54\x06\x26\x75\x91\xac\x61\x77\x2e\x61\x74\x14\xc9\xe6\x0b\x28\xfd\x48\x03\xd8\x00\x00\x00")
+
+func sqlRemovecollectionSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_sqlRemovecollectionSql,
+		"sql/removeCollection.sql",
+	)
+}
+
+func sqlRemovecollectionSql() (*asset, error) {
+	bytes, err := sqlRemovecollectionSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "sql/removeCollection.sql", size: 216, mode: os.FileMode(438), modTime: time.Unix(1791969097, 0)}
+	a := &asset{bytes: bytes, info:  info}
+	return a, nil
+}
+
+var _sqlRemovecollectioncontentsSql = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x4d\x4e\x3d\x0b\xc2\x30\x10\x9d\x0d\xe4\x3f\xbc\xa1\x53\x51\x8b\x8e\x42\x07\xb1\x11\x07\x3f\xa0\x14\x9c\x6b\x3d\x6d\xb1\x26\x90\xbb\xea\xdf\x37\x06\xa4\x4e\xc7\xbb\xf7\x99\xa5\x5a\x95\xf4\x74\x2f\x62\x48\x4b\x68\x06\xef\xc9\x0a\x1a\x67\x25\x5c\x86\xbb\xa1\x0e\xa8\xef\xa9\x91\xce\x59\xad\xb4\xaa\xea\x07\xf1\x4a\xab\x89\x7b\x5b\xf2\x98\x81\xc5\x77\xf6\x3e\xc5\xc0\x01\x4a\x5b\x0b\x02\xc3\xe8\x24\x68\x46\xeb\x9f\x30\x36\x8d\xc4\x85\xc2\x17\x3e\xce\xb8\x6a\x95\x66\xdf\x96\xc2\xec\x4d\x65\xb0\x2d\x4f\x87\x18\xcc\xf3\xd1\xb1\xf9\xad\x3b\xef\x4c\x69\x10\x77\xe4\xc9\x02\xeb\x63\xf1\x97\x9b\x27\xcb\x0f\x19\x58\xc8\xf7\xdf\x00\x00\x00")
+
+func sqlRemovecollectioncontentsSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_sqlRemovecollectioncontentsSql,
+		"sql/removeCollectionContents.sql",
+	)
+}
+
+func sqlRemovecollectioncontentsSql() (*asset, error) {
+	bytes, err := sqlRemovecollectioncontentsSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "sql/removeCollectionContents.sql", size: 223, mode: os.FileMode(438), modTime: time.Unix(1791969097, 0)}
+	a := &asset{bytes: bytes, info:  info}
+	return a, nil
+}
+
+var _sqlRemovedeadletterSql = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x2d\x8d\xb1\x0a\xc2\x30\x14\x45\x67\x03\xf9\x87\x3b\x38\x15\xb5\xb8\x0a\x6e\xa6\x38\x54\x84\x52\x70\x4e\xcd\xa5\x7d\xd8\x36\x90\x3c\xfb\xfd\x5a\x71\x3e\xe7\xdc\x5b\x16\xd6\x54\x31\xf5\xd4\x0c\x8f\x40\x1f\x30\x52\x95\x89\x01\x93\x97\x11\x71\x7e\x12\xa2\x18\x7c\x46\x47\xce\x5f\x67\x94\x65\xe5\xd6\x58\xd3\xfa\x17\xf3\xc9\x9a\x8d\x04\xec\xd1\x49\x2f\xb3\xee\xa0\x03\xff\x2b\xd0\x88\xc4\x29\x2e\xb4\xa6\x28\xd7\xe2\xe2\x6a\xd7\x3a\x54\xcd\xfd\x86\x77\x66\xca\x87\xf5\xb4\xfe\xd9\x19\x8f\xab\x6b\x1c\x24\x9c\xb7\xc7\x0f\x2f\x90\x80\x48\x9a\x00\x00\x00")
+
+func sqlRemovedeadletterSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_sqlRemovedeadletterSql,
+		"sql/removeDeadLetter.sql",
+	)
+}
+
+func sqlRemovedeadletterSql() (*asset, error) {
+	bytes, err := sqlRemovedeadletterSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "sql/removeDeadLetter.sql", size: 154, mode: os.FileMode(438), modTime: time.Unix(1791968331, 0)}
+	a := &asset{bytes: bytes, info:  info}
+	return a, nil
+}
+
+var _sqlRemoveothersessionsSql = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x45\xce\xbd\x0a\xc2\x40\x10\x04\xe0\xda\x83\x7b\x87\x29\x52\x49\x34\x68\x29\x2a\x08\x39\x11\xfc\x83\x20\x58\x88\xc5\x29\xab\x09\x21\x77\x21\x7b\x46\xf3\xf6\x5e\x34\x68\xb5\x53\x7c\xcc\x6c\xd4\x97\x22\xa1\xc2\xd6\xc4\xa0\x9a\xaa\x06\x4c\xcc\x99\x35\xb0\x37\x68\x3c\x98\x2a\xd0\xeb\x4a\xa5\x83\x35\x24\x85\x14\x07\x9d\x13\x4f\xa4\xe8\x19\x5d\x10\x06\x60\x57\x65\xe6\x1e\x7e\xa9\x4b\xb5\x87\x4f\xc3\x3e\x51\xe1\x51\xd7\xb6\xa6\xc6\xd3\xd3\xf9\xd2\x38\x0a\x91\x6a\x4e\xdb\x7e\x6f\x7e\x73\xb9\x17\xce\xfa\x43\xa5\x14\xfd\xa8\x5d\x8a\xd5\x46\x1d\x14\x96\xc9\x7e\xfb\x69\xe7\x61\x87\x19\xc7\x95\x4a\x14\xda\x0f\x66\xc1\x08\x8b\x5d\x8c\xff\xd0\x74\x1e\x8c\xdf\x2f\x2c\x68\x12\xd7\x00\x00\x00")
+
+func sqlRemoveothersessionsSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_sqlRemoveothersessionsSql,
+		"sql/removeOtherSessions.sql",
+	)
+}
+
+func sqlRemoveothersessionsSql() (*asset, error) {
+	bytes, err := sqlRemoveothersessionsSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "sql/removeOtherSessions.sql", size: 215, mode: os.FileMode(438), modTime: time.Unix(1791968877, 0)}
+	a := &asset{bytes: bytes, info:  info}
+	return a, nil
+}
+
+var _sqlRemovesessionSql = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x45\x8d\xcd\x0a\x82\x40\x14\x46\xd7\x0d\xcc\x3b\xdc\x85\x2b\xb1\x24\xa1\x16\x81\x8b\xc0\x89\xa0\x3f\x10\xa1\x45\xb4\x98\xf0\x9a\x83\x39\x13\x73\x27\xc3\xb7\x6f\x14\xa1\xed\xf7\x1d\xce\x89\x43\xce\x72\x6c\x4d\x87\x04\x12\xde\xd6\x74\xaa\xc4\x12\x08\x89\x94\xd1\x50\x19\xeb\xe7\x0f\xa1\xe5\x8c\xb3\x42\x36\x48\x1b\xce\x66\x5a\xb6\x08\x73\x20\x67\x95\x7e\x46\xe3\x0f\xae\x96\x0e\xcc\x57\x13\x28\xe7\x91\xc9\x70\xc0\xde\x83\xb7\xfb\xa3\x77\x18\x01\xd5\x32\x59\xad\xc1\x54\x5e\xda\xc9\x97\xfa\x87\x1a\xec\x39\x0b\xe3\xa1\x92\x89\xa3\x28\x04\xec\xf2\xcb\x69\x34\xd3\x62\x82\x08\xae\x7b\x91\x0b\x18\xea\x69\xb0\x84\xed\x39\x83\x7f\x26\x0d\x92\x1f\x12\x47\xcb\x51\xcd\x00\x00\x00")
+
+func sqlRemovesessionSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_sqlRemovesessionSql,
+		"sql/removeSession.sql",
+	)
+}
+
+func sqlRemovesessionSql() (*asset, error) {
+	bytes, err := sqlRemovesessionSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "sql/removeSession.sql", size: 205, mode: os.FileMode(438), modTime: time.Unix(1791975461, 0)}
+	a := &asset{bytes: bytes, info:  info}
+	return a, nil
+}
+
+var _sqlRemoveusercollectionsSql = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x45\x8e\xb1\x0a\xc2\x30\x14\x45\x67\x03\xf9\x87\x3b\x38\x95\x6a\x71\x15\xdc\x8c\x38\x28\x42\x28\x38\xc7\xfa\xb4\xc1\x26\x81\xbc\xd7\x8a\x7f\x6f\x5b\x07\xe7\x73\xcf\xe1\x56\x85\x56\x96\x42\x1a\x88\x41\x03\xe5\x0f\x9a\xd4\x75\xd4\x88\x4f\x11\x0e\x3d\x53\x46\xeb\xb8\x84\xb4\xe4\xf3\x08\xa3\x50\x14\x46\xe8\x59\x70\x23\xad\xf2\x2c\xdf\xf1\xf0\x99\x45\x2b\xad\x6a\xf7\x22\xde\x6a\xb5\x48\xef\x38\xca\x2b\xb0\x64\x1f\x9f\xe5\xaf\x25\xad\x13\x8c\x84\xa7\x60\xd0\xaa\xa8\x26\x67\x6f\x4e\xa6\x36\x38\xd8\xcb\x79\x9e\xf1\xfa\xff\x82\x71\x3d\x1a\x6b\x30\xe7\x76\xcb\xcd\x17\xc5\x20\xc2\xbb\xb2\x00\x00\x00")
+
+func sqlRemoveusercollectionsSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_sqlRemoveusercollectionsSql,
+		"sql/removeUserCollections.sql",
+	)
+}
+
+func sqlRemoveusercollectionsSql() (*asset, error) {
+	bytes, err := sqlRemoveusercollectionsSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "sql/removeUserCollections.sql", size: 178, mode: os.FileMode(438), modTime: time.Unix(1791970280, 0)}
+	a := &asset{bytes: bytes, info:  info}
+	return a, nil
+}
+
+var _sqlRemoveusercontentsSql = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x45\x8d\x31\x0b\xc2\x30\x14\x84\x67\x03\xf9\x0f\x37\x38\x15\xb5\xb8\x0a\x4e\x1a\x71\x50\x84\x50\x70\x0e\xe5\x69\x4a\xdb\x04\xf2\x9e\x15\xff\xbd\x31\x22\xae\x77\xdf\x7d\x57\x57\x5a\x59\x1a\xe3\x44\x0c\xf1\x84\x36\x06\xa1\x20\x8c\x78\x03\x4d\x94\x5e\x39\x19\x06\x6a\xa5\x8b\x01\x0e\x0f\xa6\x04\xef\x58\x2b\xad\x1a\xd7\x13\x6f\xb4\x9a\xc5\x67\xc8\xe9\x12\x2c\xa9\x0b\xf7\xc5\x17\x12\xef\x04\xb9\x29\xda\x51\xab\xaa\xfe\x6c\xf6\xe6\x64\x1a\x83\x83\xbd\x9c\x0b\xc6\xab\xbf\x7e\xf7\xbb\xbe\x1e\x8d\x35\x28\xd6\xed\x7c\xfd\x06\xff\xef\x2e\x62\xa2\x00\x00\x00")
+
+func sqlRemoveusercontentsSqlBytes() ([]byte, error) {
 	return bindataRead(
-		_sqlAddcardSql,
-		"sql/addCard.sql",
+		_sqlRemoveusercontentsSql,
+		"sql/removeUserContents.sql",
 	)
 }
 
-func sqlAddcardSql() (*asset, error) {
-	bytes, err := sqlAddcardSqlBytes()
+func sqlRemoveusercontentsSql() (*asset, error) {
+	bytes, err := sqlRemoveusercontentsSqlBytes()
 	if err != nil {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "sql/addCard.sql", size: 716, mode: os.FileMode(438), modTime: time.Unix(1429059132, 0)}
+	info := bindataFileInfo{name: "sql/removeUserContents.sql", size: 162, mode: os.FileMode(438), modTime: time.Unix(1791970280, 0)}
 	a := &asset{bytes: bytes, info:  info}
 	return a, nil
 }
 
-var _sqlAddcardhistoricalSql = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x74\x50\xcb\x6e\xc2\x30\x10\x3c\x67\xbf\x62\x0f\x1c\x00\xb9\xa0\xbe\x1f\xb7\x0a\x21\x15\xa9\xa2\x52\x81\xde\x2d\xb2\x04\xab\x89\xdd\xda\x1b\xa1\xfc\x7d\xd7\x4e\xd2\x86\x43\x0f\xe3\xc3\xce\x8c\x77\x76\xe6\x53\x58\xd9\x40\x9e\x03\x6a\xf4\xee\x84\xc6\xb2\x43\x3e\x12\xd6\x32\x5d\xb8\xb2\xa4\x3d\x1b\x67\x17\xce\x32\x59\x0e\x33\x80\xad\xfe\xa4\xf0\x04\x99\x3b\x59\xf2\x78\x81\x81\xbd\xb1\x85\x4a\x06\x71\x6a\x46\x61\x02\x1a\x86\x6c\xff\xeb\x1f\xe8\x06\x43\x77\x68\x0d\xd1\x2a\x6a\xed\xf3\xb5\xae\x68\xa0\x8d\x41\x2a\x2e\x30\x52\x90\x05\xe2\x7f\x78\x61\xe2\xb6\xaa\x92\x8c\x03\x5a\xb7\xa1\x3a\x02\xb2\xef\x5a\x97\x86\x9b\x33\x45\x4e\x07\x63\x29\xc7\x8e\x83\xac\xd4\xb6\x38\x53\xc4\x41\xad\x0b\x92\x6e\xe2\xb2\xf4\x8d\xe5\xf6\x1f\xa9\x4b\xe1\x51\x7a\xab\xb4\x6d\x52\xcc\x00\xd3\x39\xc0\x6a\xbd\x59\xbe\x6f\x71\xb5\xde\xbe\xa5\x0c\x61\xf6\x77\xf6\x8b\x09\xec\x7c\x83\x30\x4e\x15\x0e\x1b\x51\xd8\x97\xa0\xb0\xbb\x56\xf5\xf1\x15\xf6\x7b\x55\x1f\x56\xa5\x6c\xf1\x0d\xbc\xfb\xca\x35\xd3\x04\xe1\xe3\xf9\x75\xb7\xdc\xc0\x78\x74\xa9\x70\x74\x25\xb8\x16\xdc\x08\x6e\x05\x77\x82\x7b\xc1\x83\xe0\x71\xf2\x13\x00\x00\xff\xff\xf0\x77\x5b\x0e\xfe\x01\x00\x00")
+var _sqlRemoveusereventsSql = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\xd3\xd7\xe2\xe5\x0a\x4a\xcd\xcd\x2f\x4b\x2d\x56\x48\x2d\x4b\x2d\xaa\x04\x91\x79\x25\x0a\xf9\x69\x0a\x89\x0a\xa5\xc5\xa9\x45\xbc\x5c\xbc\x5c\x21\x89\xd9\xa9\xc5\x56\xbc\x5c\x9c\xf9\xe5\x79\xa9\x45\x0a\xba\x0a\xc5\x25\x45\x99\x79\xe9\x3a\x60\x05\x0a\x25\x19\x89\x40\xf5\xe5\x79\xc5\x40\x56\x6a\x2e\x2f\x97\x96\x3e\x48\x8f\x8b\xab\x8f\x6b\x88\xab\x82\x5b\x90\xbf\x2f\x58\x59\xb1\x1e\xd8\xe0\x62\x85\x70\x0f\xd7\x20\x57\x05\xb0\x49\xb6\x2a\x86\x00\xfa\x99\x59\xfd\x80\x00\x00\x00")
 
-func sqlAddcardhistoricalSqlBytes() ([]byte, error) {
+func sqlRemoveusereventsSqlBytes() ([]byte, error) {
 	return bindataRead(
-		_sqlAddcardhistoricalSql,
-		"sql/addCardHistorical.sql",
+		_sqlRemoveusereventsSql,
+		"sql/removeUserEvents.sql",
 	)
 }
 
-func sqlAddcardhistoricalSql() (*asset, error) {
-	bytes, err := sqlAddcardhistoricalSqlBytes()
+func sqlRemoveusereventsSql() (*asset, error) {
+	bytes, err := sqlRemoveusereventsSqlBytes()
 	if err != nil {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "sql/addCardHistorical.sql", size: 510, mode: os.FileMode(438), modTime: time.Unix(1429058997, 0)}
+	info := bindataFileInfo{name: "sql/removeUserEvents.sql", size: 128, mode: os.FileMode(438), modTime: time.Unix(1791970280, 0)}
 	a := &asset{bytes: bytes, info:  info}
 	return a, nil
 }
 
-var _sqlAddcollectionSql = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x5c\x8e\xbf\x4e\x80\x30\x10\xc6\x67\x9b\xf4\x1d\x6e\x20\x51\x08\x42\xd4\xcd\xcd\x81\x81\x44\x31\x11\x74\xaf\x70\xb5\x8d\xd8\x1a\xee\x08\x3e\xbe\x47\x07\x82\x8e\xf7\xdd\xef\xfb\x53\x17\x5a\xf5\x18\x26\x02\x03\x76\x41\x72\x30\xc6\x79\xc6\x91\x7d\x0c\x10\xad\x05\x8e\xc0\x0e\x61\x7a\xaf\xb4\xd2\xea\xc9\xfc\x9c\x00\x02\x4f\x40\xc8\x07\x84\xd6\xac\x33\x8b\x0f\xee\x12\x3e\x98\x4f\xa4\x7b\xad\x2e\xe2\x16\x70\x81\x6b\x20\x5e\x7c\xf8\x28\x13\xbd\x92\x48\xec\x8c\xf0\x9b\x44\xb1\xf3\x24\x64\x30\x5f\xf8\x0f\xfc\x53\x38\x61\x60\x6f\xbd\x58\x7d\x38\x62\x2e\x65\xc6\xb7\x19\x51\xab\xa2\xde\x7b\xdb\xae\x6f\x5e\x06\x68\xbb\xe1\x39\xfd\xa9\x3a\x67\x68\x75\x95\xf6\x94\xb0\x97\xe5\x72\xbf\x3d\x3c\xbe\x36\xbd\xe8\xd9\x4d\x09\xd9\x6d\xfe\x1b\x00\x00\xff\xff\x4a\x96\x4b\xab\x16\x01\x00\x00")
+var _sqlRemoveuserresetsSql = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\xd3\xd7\xe2\xe5\x0a\x4a\xcd\xcd\x2f\x4b\x2d\x56\x48\x2d\x4b\x2d\xaa\x54\x28\x4a\x2d\x4e\x2d\x51\xc8\x4f\x53\x48\x54\x28\x2d\x4e\x2d\xe2\xe5\xe2\xe5\x0a\x49\xcc\x4e\x2d\xb6\xe2\xe5\xe2\xcc\x4b\xcc\x4d\x55\xd0\x55\x28\x2e\x29\xca\xcc\x4b\xd7\x01\xcb\x2b\x94\x64\x24\x02\x95\x97\xe7\x15\x03\x59\xa9\xb9\xbc\x5c\x5a\xfa\x20\x2d\x2e\xae\x3e\xae\x21\xae\x0a\x6e\x41\xfe\xbe\x60\x65\xc5\x7a\x60\x73\x8b\x15\xc2\x3d\x5c\x83\x5c\x15\x40\x06\xd9\xaa\x18\x02\x00\xa0\x37\x4a\xf9\x7e\x00\x00\x00")
 
-func sqlAddcollectionSqlBytes() ([]byte, error) {
+func sqlRemoveuserresetsSqlBytes() ([]byte, error) {
 	return bindataRead(
-		_sqlAddcollectionSql,
-		"sql/addCollection.sql",
+		_sqlRemoveuserresetsSql,
+		"sql/removeUserResets.sql",
 	)
 }
 
-func sqlAddcollectionSql() (*asset, error) {
-	bytes, err := sqlAddcollectionSqlBytes()
+func sqlRemoveuserresetsSql() (*asset, error) {
+	bytes, err := sqlRemoveuserresetsSqlBytes()
 	if err != nil {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "sql/addCollection.sql", size: 278, mode: os.FileMode(438), modTime: time.Unix(1428963928, 0)}
+	info := bindataFileInfo{name: "sql/removeUserResets.sql", size: 126, mode: os.FileMode(438), modTime: time.Unix(1791970280, 0)}
 	a := &asset{bytes: bytes, info:  info}
 	return a, nil
 }
 
-var _sqlAddresetSql = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x6c\x8f\x3f\x4f\xc3\x30\x10\xc5\x67\x22\xf9\x3b\xbc\xa1\x03\xad\x0c\x15\x7f\x26\x36\x86\x0e\x15\xa8\x48\x24\x74\x41\x0c\x17\xf9\x02\x56\x1b\xa7\xf2\x5d\x83\xf2\xed\x39\x07\x46\x06\x4b\x96\xde\xef\xe9\xfd\x6e\xbd\x72\x55\xcd\x29\x08\x08\x42\x89\x8f\x13\x02\xe7\x38\x72\x40\x66\x61\xc5\xd0\x75\xd0\x01\xfa\xc5\x08\xa4\xd4\x92\xb0\xab\x5c\xd5\xd0\x81\xe5\xc1\x55\x17\x89\x7a\xc6\x15\x44\x73\x4c\x9f\x1e\x67\xe1\x6c\x30\x59\xf1\x3b\x09\xa2\x1a\x22\x2c\x12\x87\xf4\xc4\x93\x81\xef\x1f\xed\xa4\xec\x6d\x6e\xa4\x63\x0c\xf8\x0b\x71\xe0\xa9\xa0\x4a\x59\xf7\x25\xf0\x30\xab\xf9\x67\x25\x8d\x3d\x5b\xd4\x9f\xc4\xa3\x1b\x32\x4e\x99\x47\x4e\x6a\x8b\xa0\xf6\x5c\x8c\x56\xeb\x62\xb5\xdd\xd5\x9b\xd7\x06\xdb\x5d\xf3\x32\x9b\xc8\xf5\x7c\x84\xc0\x55\x97\x45\xd4\xff\x1e\x65\x26\x1e\xff\x4d\x2d\x0d\xdc\x3f\x3e\xbf\x6d\x6a\x2b\x2c\x6e\x3c\x16\xb7\xf6\xee\xec\xdd\x2f\x7f\x02\x00\x00\xff\xff\x0d\xce\x15\x28\x2a\x01\x00\x00")
+var _sqlRenamecollectionSql = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x55\x8e\xb1\x0a\xc2\x30\x14\x45\x67\x03\xf9\x87\x37\x74\x2a\xd5\xa2\x6e\x42\x87\x42\x03\x4e\x45\x6a\xc5\x39\xc6\x57\x2d\xd6\x04\x92\x94\xe2\xdf\xfb\x12\x87\xd6\x2d\x8f\x7b\xee\xcd\xc9\x53\xce\x1a\xd4\xf2\x8d\x0e\x24\x28\x33\x0c\xa8\x7c\x6f\x74\x06\xbd\x77\x74\x6b\x8f\x9a\x1e\x1d\x05\x66\x82\xdb\x07\x94\x74\x4a\xde\x91\x33\xce\x5a\xf9\x42\x77\xe0\x6c\x65\x26\x8d\x16\xd6\xe0\xbc\xed\xf5\x23\x83\xd1\xd1\xe9\x9f\xd2\x03\x25\x8e\x96\x88\x99\xa7\x17\xa0\x1a\xad\xa5\x7d\x08\xff\x83\xe9\xa8\x83\x0b\x07\x6a\x69\x9c\xea\x90\xcd\x95\x88\xfe\x73\xe0\x83\x08\x67\x69\x1e\xac\x2e\xa7\xaa\x6c\x45\x74\x70\x9b\x19\xa2\xfc\x2c\xda\x5f\xbd\x80\x64\xcf\xd9\xf5\x28\x1a\x01\xd1\xbd\x48\xb6\x50\xd6\x55\x4c\x8b\x64\xf7\x05\xce\x03\x04\xe7\x15\x01\x00\x00")
 
-func sqlAddresetSqlBytes() ([]byte, error) {
+func sqlRenamecollectionSqlBytes() ([]byte, error) {
 	return bindataRead(
-		_sqlAddresetSql,
-		"sql/addReset.sql",
+		_sqlRenamecollectionSql,
+		"sql/renameCollection.sql",
 	)
 }
 
-func sqlAddresetSql() (*asset, error) {
-	bytes, err := sqlAddresetSqlBytes()
+func sqlRenamecollectionSql() (*asset, error) {
+	bytes, err := sqlRenamecollectionSqlBytes()
 	if err != nil {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "sql/addReset.sql", size: 298, mode: os.FileMode(438), modTime: time.Unix(1428963994, 0)}
+	info := bindataFileInfo{name: "sql/renameCollection.sql", size: 277, mode: os.FileMode(438), modTime: time.Unix(1791968985, 0)}
 	a := &asset{bytes: bytes, info:  info}
 	return a, nil
 }
 
-var _sqlAddsessionSql = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x6c\x8e\x3f\x4f\x02\x41\x10\xc5\x6b\xe6\x53\xbc\x82\x02\xc8\x2a\xf1\x4f\x65\x67\x41\x41\x34\x98\x78\x27\x8d\xb1\x98\xcb\xcd\xe9\x06\x6e\x97\xec\x8c\x67\xee\xdb\x3b\x10\x12\x1a\x8a\x49\x26\xbb\x6f\xde\xef\xb7\x5c\x50\x25\xa9\x55\x30\x94\x93\xec\x47\xb4\x52\xe2\x20\x2d\x54\x54\x63\x4e\xc8\x5d\x07\xcb\xb0\x1f\x41\xcb\xc6\x0d\xab\x10\xd5\xbc\x13\x7d\xa2\x49\xe2\x5e\x70\x03\xb5\x12\xd3\x77\xc0\xaf\x4a\xf1\x24\x1b\xf2\x5f\x52\x44\xa3\xc9\xb9\xe6\x45\x46\xcf\x7d\x7e\x35\xa3\x49\x70\xd8\xc0\xfb\x78\x61\xec\x64\xf4\xa4\x71\xb1\xed\xf1\x3d\xc0\x95\x4e\x9b\xdf\x58\xec\xc5\xbf\xfa\x83\x06\x74\xb9\xe0\x50\x64\x90\x64\xce\x03\x37\x0e\xa4\xc5\x92\x68\xbd\xa9\x56\xef\x35\xd6\x9b\xfa\xed\x24\xa1\xb7\xe7\x6a\x05\xcd\x8e\x92\x01\x17\x11\xdf\xaf\xa0\xe6\xa0\xed\xf3\xeb\xc7\xaa\xa2\xd9\xf4\x2e\x60\x7a\xef\xf3\xe0\xf3\x38\xff\x0f\x00\x00\xff\xff\x92\x18\x13\x59\x24\x01\x00\x00")
+var _sqlRenamecollectionhistorySql = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x55\x8f\x41\x4b\xc3\x40\x10\x85\xcf\x2e\xec\x7f\x78\x87\x9c\x4a\xb5\xa8\x37\x21\x87\x42\x03\xbd\xb4\x88\x46\x3c\xaf\x61\xec\x2e\x8d\x3b\x25\x33\x49\xf1\xdf\x3b\x89\x60\xe2\x71\x78\xdf\xc7\x7b\xb3\x59\x79\x77\xe0\x81\x04\x1a\x09\x31\x89\x72\xf7\x0d\xfe\x44\x40\xc3\x6d\x4b\x8d\x26\xce\x50\x46\x52\x41\xa6\x2b\x72\xf8\xa2\x35\x32\x6b\x4c\xf9\x04\x6a\x85\xbc\x0b\x1f\xdc\xeb\x9f\x4c\x03\x75\x68\x62\xc8\x27\x12\xef\xbc\xab\xc3\x99\xe4\xc9\xbb\x1b\xbe\x66\x4b\x6e\x21\xda\x99\xbb\x46\x2f\x76\x6a\x0c\x0a\x4b\xc4\x1a\x8c\x59\x94\xce\xe0\xa5\xa3\x21\x71\x2f\x53\xf9\x38\x6e\xdc\x3a\x93\xa6\xd9\xb2\xe3\x98\xcd\xce\x84\xfe\xe7\xec\x0d\x3e\x7b\xb7\xda\x8c\xab\xde\x9e\x77\xdb\xba\x9a\x36\xc8\xdd\xcc\xec\x7f\x9f\xf0\xee\xb5\xaa\x97\x6a\x89\xe2\xd1\xbb\xf7\x7d\xf5\x52\x61\xfa\xa3\x2c\xee\xb1\x3d\xee\x16\x4c\x59\x3c\xfc\x00\xe3\x86\x3a\x13\x4e\x01\x00\x00")
 
-func sqlAddsessionSqlBytes() ([]byte, error) {
+func sqlRenamecollectionhistorySqlBytes() ([]byte, error) {
 	return bindataRead(
-		_sqlAddsessionSql,
-		"sql/addSession.sql",
+		_sqlRenamecollectionhistorySql,
+		"sql/renameCollectionHistory.sql",
 	)
 }
 
-func sqlAddsessionSql() (*asset, error) {
-	bytes, err := sqlAddsessionSqlBytes()
+func sqlRenamecollectionhistorySql() (*asset, error) {
+	bytes, err := sqlRenamecollectionhistorySqlBytes()
 	if err != nil {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "sql/addSession.sql", size: 292, mode: os.FileMode(438), modTime: time.Unix(1428963914, 0)}
+	info := bindataFileInfo{name: "sql/renameCollectionHistory.sql", size: 334, mode: os.FileMode(438), modTime: time.Unix(1791968985, 0)}
 	a := &asset{bytes: bytes, info:  info}
 	return a, nil
 }
 
-var _sqlAdduserSql = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x54\x8f\x4d\x4f\x32\x31\x10\xc7\xcf\x4f\x93\x7e\x87\x39\x70\x00\xb2\x0f\x44\xf1\xe4\xcd\x03\x89\x24\x8a\x89\xa0\xf7\xa1\x9d\x95\xc6\xdd\x96\x74\x06\x56\xbe\xbd\xd3\x5d\x21\x7a\x98\xa4\x69\x7e\xff\xb7\xf9\xd4\x9a\x0d\x45\xcf\x80\x70\xa2\x1c\xea\x40\x1e\x8e\x4c\x19\x52\x5d\x83\x24\x90\x3d\x81\x47\xc1\x1d\x32\xcd\xac\xb1\xe6\x19\xbf\xc0\xa5\xa6\x21\x27\x21\x45\x86\xc0\xc0\x24\x57\x94\x6a\x3c\x36\xa2\x6a\x58\xf4\xf8\x16\x3f\x89\xef\xad\xf9\x17\xb1\x25\xf8\x0f\x2c\x39\xc4\x8f\x6a\xc8\x90\x3d\x2a\xda\x15\x17\x51\x84\x5a\x0c\xcd\x2f\xa6\x18\xa2\xf7\x99\x98\xa1\x23\x70\x18\x35\x39\x0a\x3a\xd1\xb6\xbd\x01\x16\xd9\x01\x99\x1f\x91\xf7\xaa\xdc\x9d\x85\x70\x10\xaa\xea\xa7\x08\xbb\x7c\x3e\xc8\xb8\x60\x5d\xca\xbe\x82\x98\xa2\xa3\x49\xe9\x54\x1e\x7f\x65\xc3\x97\x9a\x7b\xa8\x53\xd6\x3d\x39\x9c\xb4\x0c\x5c\x42\xac\x99\xce\xcb\xae\xd5\x7a\xb3\x7c\xdd\xc2\x6a\xbd\x7d\xe9\xab\xf0\xac\x25\x41\xb0\x66\x5c\x86\x56\xd0\x6f\xa9\xae\xb2\x4b\xa8\x02\xef\x0f\x4f\x6f\xcb\x8d\x82\xa3\x9b\x0a\x46\xb7\x7a\x0b\xbd\xbb\xc9\x77\x00\x00\x00\xff\xff\x4f\x6f\x3b\x5c\x8b\x01\x00\x00")
+var _sqlRevokesessionSql = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x5d\x90\x4d\x4b\xc3\x40\x10\x86\xcf\x2e\xec\x7f\x78\x0f\x39\x68\x49\x2d\x6d\x6f\x42\x0f\x42\x22\x8a\x55\x21\x14\x3c\x88\x87\x0d\x99\x64\x97\x9a\xdd\xb2\xb3\x69\xc8\xbf\x77\xf3\xe1\xc5\xeb\xcc\xfb\xf1\xcc\x6c\x56\x52\x14\xd4\xba\x2b\x31\x82\x26\x30\x31\x1b\x67\xe1\x6a\x28\x74\x4c\x1e\xbd\x76\x4c\xd0\x8a\x35\x55\x38\xd3\x80\x92\x1a\x63\x19\xbd\x09\x1a\x4a\x8a\x8b\x77\x57\x53\xc5\xdd\x4b\x26\x85\x14\x27\x75\x26\x7e\x90\xe2\xc6\xaa\x96\xb0\x06\x07\x6f\x6c\x93\xce\x59\x41\xab\x00\xd7\x47\xb7\x09\x51\x62\xaa\x28\xf8\xfa\x2e\x87\x40\xe9\xd4\xfe\x43\xaa\x8a\x6a\x8c\x13\x9e\x19\x96\xe2\x3f\xae\x08\x30\x19\x8f\x64\x9b\xd8\xbf\x86\xb1\x61\xbf\x4b\xa1\x5d\x8f\x56\xd9\xe1\x5f\x44\x1b\x69\xd0\x5d\xa0\xec\x84\xb7\xda\x8c\x88\x59\x7e\xcc\x4f\x39\x9e\x8a\x8f\xb7\x09\x8b\xef\x97\x74\x96\xe2\xf3\x39\x2f\x72\x8c\xec\x87\x64\x8b\xc7\xf7\x0c\xdc\x95\xf3\x0d\xb7\x8b\xea\x35\xfe\xa0\xf6\xae\xc5\x16\xb5\xf3\x48\xf6\x77\x87\x64\xf7\x0b\xce\x31\xf5\xbf\x48\x01\x00\x00")
 
-func sqlAdduserSqlBytes() ([]byte, error) {
+func sqlRevokesessionSqlBytes() ([]byte, error) {
 	return bindataRead(
-		_sqlAdduserSql,
-		"sql/addUser.sql",
+		_sqlRevokesessionSql,
+		"sql/revokeSession.sql",
 	)
 }
 
-func sqlAdduserSql() (*asset, error) {
-	bytes, err := sqlAdduserSqlBytes()
+func sqlRevokesessionSql() (*asset, error) {
+	bytes, err := sqlRevokesessionSqlBytes()
 	if err != nil {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "sql/addUser.sql", size: 395, mode: os.FileMode(438), modTime: time.Unix(1428963868, 0)}
+	info := bindataFileInfo{name: "sql/revokeSession.sql", size: 328, mode: os.FileMode(438), modTime: time.Unix(1791975583, 0)}
 	a := &asset{bytes: bytes, info:  info}
 	return a, nil
 }
 
-var _sqlGetallresetsSql = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x3c\x8d\xbd\x6a\xc3\x30\x14\x46\xe7\x0a\xf4\x0e\xdf\xd0\xa1\x35\xaa\x4d\xd7\x42\x0b\xa6\x55\x09\xe4\x0f\x1c\x93\xcc\x22\xba\x49\x84\x13\x29\x91\x64\x1b\xbf\x7d\x6c\x05\xb2\x5d\x2e\xe7\x9c\xaf\xc8\x38\x2b\xf7\xb7\xd6\x78\x0a\x88\x27\x02\x75\xe4\x07\x74\xea\x6c\x34\xc6\x1f\x45\x34\x34\xe0\xe0\x3c\x14\xae\xde\x75\x46\x93\x46\x1b\xc8\xe7\x9c\x71\x56\xab\x86\xc2\x17\x67\x2f\x56\x5d\x08\x1f\x08\xd1\x1b\x7b\x14\x09\x18\x73\x2a\xc2\xf5\x36\xc0\x44\xce\xb2\x62\x12\x36\x72\x21\x7f\x6b\x4c\xb8\x78\xf4\xe7\x34\x88\xd1\x53\x3e\x6e\xa7\x51\x01\xb2\x3a\x5d\x9c\xfd\x57\xeb\x65\x4a\x85\x3c\xa1\x81\xb3\xdd\x4c\x56\x32\xe9\xdf\xaf\x9f\x28\x57\x7f\x4f\x1c\x3f\xb0\xae\x7f\x7b\xbf\x07\x00\x00\xff\xff\xc7\x94\x70\x4a\xd2\x00\x00\x00")
+var _sqlScrubuserSql = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x4d\x90\x41\x6b\x02\x41\x0c\x85\xcf\x1d\x98\xff\x90\x43\x41\x10\xab\xed\xb5\xb0\x07\x69\x17\x3c\x48\x29\xba\xd2\x73\xba\x13\x77\x07\x67\x33\x32\x89\x8a\xff\xbe\xb3\x63\x5b\xbc\xcd\xbc\xbc\x7c\x2f\xc9\x62\x6a\xcd\x56\x93\x3f\x0a\x20\x38\x0a\xa4\xe4\xe0\x24\x94\xc0\xc5\x0b\x83\xc6\x2c\x6b\x1c\xbe\x45\x23\x13\x24\xca\x95\xb3\xe7\x0e\xb4\x27\x9f\x80\x71\xa0\xb9\x35\xd6\x34\x3d\x01\x0d\x47\xbd\xc2\x11\x45\x56\x28\x3d\x30\x9d\x33\x65\x40\x6d\x7b\xba\xc1\x93\x3f\x67\xf8\x68\xb8\xc4\xe4\x40\xe2\x48\x29\x61\xd6\xb4\xc8\xbf\x1d\x21\x76\x9e\x01\x3b\xf4\x7c\x43\xe3\x81\xe4\xd5\x9a\x87\x31\x0c\x9e\x40\xf2\xb4\xdc\xcd\xee\x7a\xa7\x8b\xd1\xb7\xfb\x7c\x5f\x36\x75\x91\x64\x3e\x90\x22\x6c\xeb\x26\x0f\x85\x3e\x54\x93\xc9\xec\x7f\xb0\xf2\xe1\xc8\x2d\x8d\x2f\x6b\x72\xa6\xdf\x7b\x72\xd5\x1e\x83\xd0\x0c\xca\xff\xda\xc4\x03\x71\xf5\xb1\x5b\xaf\x73\x27\xb1\xcb\x91\x75\x41\xdd\xa4\x82\x7d\xeb\x91\x3b\xba\x73\xe6\x35\x4e\x29\x11\xb7\xd7\x12\x12\x62\x8b\xa1\xa4\xc0\x3e\xdb\xc9\xad\xc7\xd5\xa4\x7a\x2e\xa5\x03\xb9\x1d\xeb\x1f\xd1\x9a\x80\xa2\x9b\x7c\x5f\xdd\x12\x6b\x11\xad\xf9\x5a\xd5\x9b\xba\x5c\xb9\x7a\x7c\xf9\x01\xfb\x32\x3b\xbd\xab\x01\x00\x00")
 
-func sqlGetallresetsSqlBytes() ([]byte, error) {
+func sqlScrubuserSqlBytes() ([]byte, error) {
 	return bindataRead(
-		_sqlGetallresetsSql,
-		"sql/getAllResets.sql",
+		_sqlScrubuserSql,
+		"sql/scrubUser.sql",
 	)
 }
 
-func sqlGetallresetsSql() (*asset, error) {
-	bytes, err := sqlGetallresetsSqlBytes()
+func sqlScrubuserSql() (*asset, error) {
+	bytes, err := sqlScrubuserSqlBytes()
 	if err != nil {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "sql/getAllResets.sql", size: 210, mode: os.FileMode(438), modTime: time.Unix(1433183741, 0)}
+	info := bindataFileInfo{name: "sql/scrubUser.sql", size: 427, mode: os.FileMode(438), modTime: time.Unix(1791971815, 0)}
 	a := &asset{bytes: bytes, info:  info}
 	return a, nil
 }
 
-var _sqlGetcardSql = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x74\x50\x4f\x6b\xfb\x30\x0c\x3d\xff\x0c\xfe\x0e\x3a\x04\x7e\x50\xb2\x96\xfd\xbb\x0c\x72\x28\x5d\xc6\x0e\x5b\x07\x5d\xc7\xce\x26\x51\x5b\xb3\xd4\x5e\x2d\xa5\xa5\xdf\x7e\xb2\x93\x51\x5f\x76\xb2\xac\xf7\x9e\x9e\x9e\x66\x13\xad\xe6\xcd\xa1\xb7\x01\x09\x78\x87\xd0\x19\x46\x62\x20\x96\x17\xfc\x06\x0c\x34\x26\xb4\x60\x9d\x54\x3d\x61\xf8\x4f\xd0\xf8\xae\xc3\x86\xad\x77\x53\xad\xb4\x5a\x9b\x2f\xa4\x07\xad\xfe\xf9\x93\xc3\x00\x57\xa2\x0d\xd6\x6d\xcb\x44\x97\x99\x86\x41\x10\x02\xcb\xc2\xb9\x68\x33\x62\xd6\x14\xc7\xa4\x88\xda\x48\x17\xef\xa5\xd9\x63\x46\x8e\x4b\xee\x79\x9b\xd6\x12\x06\x21\xff\x41\x10\x44\xf0\x43\x6f\x3a\xcb\xe7\x0c\xf7\x0e\x07\x1b\x84\x01\xb4\x12\xfd\x84\xb0\x33\x47\x8c\x22\x30\x04\x47\xe9\xb7\xb0\xf1\x21\xd9\x90\x56\x93\x59\x8c\xfa\x5e\xbf\xd4\x8b\x35\xfc\x6e\x55\xc2\xe8\x5e\x8e\x93\xce\xa9\x70\x9c\xaa\xce\x10\x7f\x7c\xb7\x72\x47\xad\x9e\x56\x6f\xaf\xa0\x55\x4c\x45\xd3\x4b\xdc\x85\x77\x8c\x8e\x65\xfe\xe7\x73\xbd\xaa\x85\x91\x6e\x58\x15\xd7\x30\x5f\x3e\x66\x77\xa9\x8a\x9b\xa1\x33\x3a\x57\xc5\x6d\xfa\x8f\xfe\x55\x71\x97\xbe\xe3\x16\x55\x71\xff\x13\x00\x00\xff\xff\x5d\xee\x86\xf6\xd8\x01\x00\x00")
+var _sqlSetcollectionlockSql = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x4d\x4f\xb1\x0e\x82\x30\x14\x9c\x6d\xd2\x7f\xb8\x81\xc9\x80\x44\xdd\x4c\x18\x48\x20\x71\x30\xc6\x28\xc6\xb9\xc2\x53\x2a\xd8\x26\x6d\x09\xbf\x6f\x41\x8c\x6c\xf7\xee\xdd\xbd\xbb\x17\x2f\x39\x3b\xe8\xb2\xb1\xd0\x06\x9d\x6a\x47\x28\x50\xea\xb6\xa5\xd2\x49\xad\x42\x0c\x1c\x55\x33\xca\xc2\xd0\xcb\x43\x50\x25\x9d\xe5\x8c\xb3\x42\x34\x64\x77\x9c\x2d\x74\xaf\xc8\x20\x82\x75\x46\xaa\x67\x88\xce\xfa\xd1\xd5\xc2\xc1\x6f\x2c\xa4\xf3\x9a\xff\xa1\x99\x70\x46\xea\xc7\xd7\x31\x78\xbd\x7c\x8a\x8f\x70\xd7\xba\x0d\xd1\xd7\xe4\x6a\x7f\x74\xcc\x86\x30\x34\x95\xa1\x8a\xb3\x65\x3c\x94\xb9\x9e\xb2\xb4\xc8\x47\xbb\x5d\xcd\x5a\x73\x76\xc9\x8b\xdf\x37\x09\x82\x2d\x67\xb7\x7d\x7e\xce\x31\x96\x4e\x82\x35\xd2\x63\x06\x25\xde\x94\x04\x9b\x0f\x5a\xa5\xce\x59\x18\x01\x00\x00")
 
-func sqlGetcardSqlBytes() ([]byte, error) {
+func sqlSetcollectionlockSqlBytes() ([]byte, error) {
 	return bindataRead(
-		_sqlGetcardSql,
-		"sql/getCard.sql",
+		_sqlSetcollectionlockSql,
+		"sql/setCollectionLock.sql",
 	)
 }
 
-func sqlGetcardSql() (*asset, error) {
-	bytes, err := sqlGetcardSqlBytes()
+func sqlSetcollectionlockSql() (*asset, error) {
+	bytes, err := sqlSetcollectionlockSqlBytes()
 	if err != nil {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "sql/getCard.sql", size: 472, mode: os.FileMode(438), modTime: time.Unix(1428963905, 0)}
+	info := bindataFileInfo{name: "sql/setCollectionLock.sql", size: 280, mode: os.FileMode(438), modTime: time.Unix(1791966801, 0)}
 	a := &asset{bytes: bytes, info:  info}
 	return a, nil
 }
 
-var _sqlGetcollectioncontentsSql = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x4c\x8f\x41\x4b\x03\x31\x10\x85\xcf\x06\xf2\x1f\xe6\x50\x10\xca\xda\xa2\x47\xa1\x87\x52\x23\x1e\xb4\x42\xad\x78\x1e\xb2\xa3\x06\xb3\x89\x9b\x99\x45\xfc\xf7\x4e\xe2\x61\xf7\x94\x47\xe6\x7d\xef\xcd\x6c\xd7\xd6\xec\xfd\x38\x85\x42\x0c\xf2\x49\x10\x51\x88\x05\x58\xf4\x85\xfc\x0e\x08\x13\x53\xb9\x64\xf0\x39\x46\xf2\x12\x72\xda\x58\x63\xcd\x19\xbf\x88\x6f\xad\xb9\xc8\x3f\x89\x0a\x5c\x29\x51\x42\xfa\xe8\x9a\x5d\x93\x50\x40\x27\x0c\x41\xd4\x33\xb3\x0b\xe3\xe2\x53\x7b\x1a\x51\x59\x6b\xd6\xdb\x5a\xf0\xe2\x1e\xdd\xe1\x0c\x1e\x4b\x7f\xc4\x81\x3a\x60\x92\x7f\x31\x4e\x18\x83\xfc\x36\x91\xa4\x29\x9f\x87\x81\x92\x74\xba\x7e\x8d\x8e\xc8\xf2\xfa\xdd\xeb\x09\xd6\xdc\x9f\x9e\x9f\xac\xa9\xc9\xbc\x99\x2b\x0f\x39\x89\x02\x0c\x6f\x0f\xee\xe4\xa0\x1d\xb1\x5b\x5d\xc3\xfe\x78\xb7\x58\x6c\xb7\xba\xf9\x0b\x00\x00\xff\xff\xb9\xca\x61\x15\x21\x01\x00\x00")
+var _sqlSetcollectionpermissionsSql = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x5d\x8f\xcd\x6a\xc3\x40\x0c\x84\xcf\x35\xf8\x1d\xe6\xe0\x53\x70\x13\xfa\x73\x2a\xf8\x10\x1a\x43\x4f\x25\xb4\x4e\x7b\x96\x1d\x11\x8b\xac\xbd\x66\xb5\xb1\xc9\xdb\x57\x6e\x42\x5b\x0a\x8b\x0e\x9a\x99\xd5\x37\xab\x45\x9a\xec\x86\x3d\x45\x56\x10\x1a\xef\x1c\x37\x51\x7c\x0f\x7b\xb1\x65\x98\x42\x35\x29\x23\x7a\xb4\x34\xf2\x65\xc9\x2a\x81\xf7\x18\x38\x74\xa2\x6a\x76\x4d\x93\x34\xa9\xe8\xc8\xfa\x94\x26\x37\x3d\x75\x8c\x5b\x68\x0c\xd2\x1f\x72\x9c\x94\x83\xe5\x28\xc2\x4f\xbd\x42\xa2\x59\x86\x53\xed\xa4\xf9\x10\x9e\xcc\xf2\xc7\x4b\x18\xc9\x89\x7d\x1d\x64\xa4\xe6\x0c\xe5\x18\x4d\xf8\x49\x3c\xfb\xae\xe3\x3e\xaa\x45\x6a\xef\x5d\x8e\xa9\x65\x43\x0a\x86\x7e\x15\x28\x30\x46\x51\xa9\x1d\xe3\x92\x71\xe7\x99\x6e\xb1\x9a\xe7\x6e\xbb\x59\x57\xe5\x37\x92\x2e\x7f\xeb\x1a\xff\x7b\x59\x61\x7b\xbd\x5a\x20\x7b\xc8\xf1\xef\xa2\x2d\x1f\xd3\xe4\xf3\xa5\x7c\x2b\xe7\x22\x1c\x8a\xec\x0e\xeb\xd7\x0d\xe6\xba\x45\x76\xff\x05\x4e\x21\x8d\x52\x4b\x01\x00\x00")
 
-func sqlGetcollectioncontentsSqlBytes() ([]byte, error) {
+func sqlSetcollectionpermissionsSqlBytes() ([]byte, error) {
 	return bindataRead(
-		_sqlGetcollectioncontentsSql,
-		"sql/getCollectionContents.sql",
+		_sqlSetcollectionpermissionsSql,
+		"sql/setCollectionPermissions.sql",
 	)
 }
 
-func sqlGetcollectioncontentsSql() (*asset, error) {
-	bytes, err := sqlGetcollectioncontentsSqlBytes()
+func sqlSetcollectionpermissionsSql() (*asset, error) {
+	bytes, err := sqlSetcollectionpermissionsSqlBytes()
 	if err != nil {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "sql/getCollectionContents.sql", size: 289, mode: os.FileMode(438), modTime: time.Unix(1429059255, 0)}
+	info := bindataFileInfo{name: "sql/setCollectionPermissions.sql", size: 331, mode: os.FileMode(438), modTime: time.Unix(1791965841, 0)}
 	a := &asset{bytes: bytes, info:  info}
 	return a, nil
 }
 
-var _sqlGetcollectionhistorySql = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x4c\x8f\x41\x4b\x03\x31\x14\x84\xcf\x06\xf2\x1f\xe6\x50\x10\xca\xda\xa2\x47\xa1\x87\xa2\x2b\x3d\x68\x85\x5a\xf1\xfc\x48\x9f\x36\x98\x4d\x6c\xde\x5b\xa4\xff\xde\x4d\x2c\xec\xde\x86\x64\x66\xbe\x37\xcb\xb9\x35\x6b\x77\xea\x7d\x66\x81\x1e\x19\x9a\x94\x02\x8e\x5e\x34\xe5\x33\xd2\x27\x08\xbd\x70\xbe\x16\xb8\x14\x02\x3b\xf5\x29\x2e\xac\xb1\x66\x4f\xdf\x2c\xf7\xd6\x5c\xa5\xdf\xc8\x19\x37\x10\xcd\x3e\x7e\x35\xd5\x3e\x54\x91\x62\xf8\x11\x78\x1d\x3c\x63\x76\x62\x9c\x3c\x0e\x9c\x9a\x28\x59\x6b\xe6\xcb\x02\x78\x6b\x9f\xdb\x87\x3d\x1c\xe5\xc3\x96\x3a\x6e\x20\xac\xff\xe2\xd4\x53\xf0\x7a\xae\x22\x6a\x55\x2e\x75\x1d\x47\x6d\x10\xa8\x54\x07\x12\x7d\xff\x39\x90\xb2\x35\x4f\xbb\xd7\x17\x6b\x4a\xb3\x2c\x46\xe4\xe6\xb2\xf0\x63\xd3\xee\x5a\xd4\x0d\xab\xd9\x2d\xd6\xdb\xc7\xc9\x5d\xab\xd9\xdd\x5f\x00\x00\x00\xff\xff\x79\xc1\x1c\x3d\x21\x01\x00\x00")
+var _sqlSetcollectiontagsSql = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x4d\x8f\xbb\x0a\xc2\x40\x10\x45\x6b\x17\xf6\x1f\x6e\x91\x2a\xf8\x40\xed\x84\x14\x01\x03\x56\x22\x31\x62\x21\x16\x6b\x1c\xcd\x62\xdc\x95\xcc\x6a\xf0\xef\xdd\x24\x48\x52\xde\x99\x73\x87\x33\xb3\x50\x8a\x94\x5e\xa5\xca\x89\xe1\x0a\x82\x53\x77\x86\x42\x6e\xcb\x92\x72\xa7\xad\x81\x66\x94\xea\x42\x3e\x5f\x51\x6b\x57\x48\x21\x45\xa6\x1e\xc4\x2b\x29\x46\xb6\x36\x54\x61\x02\x76\x95\x36\xf7\x31\xde\xec\xa3\x2b\x94\x83\xdf\x30\xb4\xf3\xcc\xe0\x56\x0f\x0e\x86\xf6\xd6\x35\x9a\xae\xc7\x5b\x83\x09\x4e\xe7\x3f\x4a\x1f\xaa\xbe\x8d\x58\x2b\x38\x28\x1a\x5b\xa3\x50\x2c\x45\x38\x6b\xa4\x0e\xbb\x75\x9c\x25\xed\x19\x9e\xf6\x98\xdf\xef\x93\xac\x7b\x2c\x42\xb0\x94\xe2\xb8\x49\xd2\x04\xad\x7a\x14\xcc\x11\x6f\xd7\x30\xea\x49\x51\xb0\xf8\x01\x5d\xfc\x2a\x50\x0f\x01\x00\x00")
 
-func sqlGetcollectionhistorySqlBytes() ([]byte, error) {
+func sqlSetcollectiontagsSqlBytes() ([]byte, error) {
 	return bindataRead(
-		_sqlGetcollectionhistorySql,
-		"sql/getCollectionHistory.sql",
+		_sqlSetcollectiontagsSql,
+		"sql/setCollectionTags.sql",
 	)
 }
 
-func sqlGetcollectionhistorySql() (*asset, error) {
-	bytes, err := sqlGetcollectionhistorySqlBytes()
+func sqlSetcollectiontagsSql() (*asset, error) {
+	bytes, err := sqlSetcollectiontagsSqlBytes()
 	if err != nil {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "sql/getCollectionHistory.sql", size: 289, mode: os.FileMode(438), modTime: time.Unix(1429059247, 0)}
+	info := bindataFileInfo{name: "sql/setCollectionTags.sql", size: 271, mode: os.FileMode(438), modTime: time.Unix(1791973024, 0)}
 	a := &asset{bytes: bytes, info:  info}
 	return a, nil
 }
 
-var _sqlGetcollectionlistSql = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x4c\x8e\x4f\x8b\xc2\x30\x10\xc5\xcf\x3b\x30\xdf\x61\x0e\x7b\x2a\xdd\x2d\x7b\x5d\xf0\x20\x12\xf1\xa0\x08\xb5\xe0\x39\x84\xd1\x04\x6b\xa2\x99\x54\xf1\xdb\x9b\xd4\x43\x7b\x7d\xef\xf7\xfe\x34\x15\xc2\xd2\xdc\x07\x17\x59\x28\x59\x26\xaf\xaf\x4c\xe1\x44\xac\x8d\x25\x13\xfa\x9e\x4d\x72\xc1\x93\xa6\x41\x38\x92\xd5\x82\x80\xd0\xe9\x0b\xcb\x3f\xc2\x57\x78\xfa\xac\xfe\x90\xa4\xe8\xfc\xb9\xfe\x40\xc9\xea\x44\xd9\x11\x72\x29\x33\xb3\x96\x09\x9c\x89\x79\x6d\x4c\x94\x2c\x42\xd5\x94\x81\x83\xda\xaa\x55\x87\x50\xee\xd4\x74\x8b\xee\xa1\xcd\x0b\x61\xdd\xee\x77\x08\x05\x94\xdf\xa9\x41\xe8\xb8\x51\xad\xa2\xf1\xcc\xe2\xfb\xef\x1d\x00\x00\xff\xff\x68\xd5\xb8\xc7\xd5\x00\x00\x00")
+var _sqlSetcollectionvaluationSql = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x6d\x90\x31\x6f\xc2\x30\x10\x85\x67\x2c\xf9\x3f\xbc\x21\x13\x0a\xa0\xd2\x4e\x95\x32\x20\x11\x89\xa9\xaa\xda\xa0\xce\x47\x72\x01\x8b\xc4\x46\xb6\xd3\x8a\x7f\x5f\xdb\x81\x86\xa1\x9b\xcf\xf7\xee\xdd\xf7\x6e\x35\x97\xe2\x93\xbd\x83\x3f\x31\xea\xc1\x5a\xd6\xf5\x15\xa4\x1b\x1c\xc8\x29\x07\x42\x6d\xba\x8e\x6b\xaf\x8c\x46\xa8\xbf\xa9\x1b\xb8\x81\xd2\x38\x5c\xd1\x70\x4b\x43\xe7\xa5\x90\xa2\xa2\x33\xbb\x57\x29\x66\xe6\x47\xb3\xc5\x02\xce\x5b\xa5\x8f\x39\x06\x17\x4a\x7f\x22\x8f\xd0\x71\x50\x41\x3d\x7b\xb0\x9c\x84\x0f\x9f\xa6\x1d\x27\xe2\x6c\x94\xdf\xb1\x26\x71\xa4\xbd\x6d\xff\xa3\xce\xc1\xfd\xc5\x27\x2a\xb6\x21\x90\x49\xaa\xc4\x13\x4c\xc6\x38\xff\x3b\xc4\x50\x94\x36\x27\xd5\xdd\xa8\x35\x16\x3d\xd9\x33\x07\xe6\xf9\x2a\xa6\xdc\xbf\x6f\x37\x55\x99\xb8\xdc\x72\x02\x76\xe1\x86\x65\x35\x9d\xaf\x40\xf6\x9c\xdf\x0e\x18\xde\x2f\x52\x7c\xed\xca\x8f\x72\x64\x29\xb2\x27\x6c\xde\xb6\xd0\xd4\x73\x91\xad\x7f\x01\x05\x2f\xb1\x2b\x81\x01\x00\x00")
 
-func sqlGetcollectionlistSqlBytes() ([]byte, error) {
+func sqlSetcollectionvaluationSqlBytes() ([]byte, error) {
 	return bindataRead(
-		_sqlGetcollectionlistSql,
-		"sql/getCollectionList.sql",
+		_sqlSetcollectionvaluationSql,
+		"sql/setCollectionValuation.sql",
 	)
 }
 
-func sqlGetcollectionlistSql() (*asset, error) {
-	bytes, err := sqlGetcollectionlistSqlBytes()
+func sqlSetcollectionvaluationSql() (*asset, error) {
+	bytes, err := sqlSetcollectionvaluationSqlBytes()
 	if err != nil {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "sql/getCollectionList.sql", size: 213, mode: os.FileMode(438), modTime: time.Unix(1429212792, 0)}
+	info := bindataFileInfo{name: "sql/setCollectionValuation.sql", size: 385, mode: os.FileMode(438), modTime: time.Unix(1791968628, 0)}
 	a := &asset{bytes: bytes, info:  info}
 	return a, nil
 }
 
-var _sqlGetcollectionmetaSql = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x4c\x8e\x4f\x4b\xc4\x40\x0c\xc5\xcf\x06\xf2\x1d\x72\x58\x10\x96\xba\x8b\x1e\x85\x3d\x2c\x3a\xe2\xc1\x3f\x50\x2b\x9e\xc3\x18\x75\xb0\x9d\xa9\x93\x54\xf1\xdb\x3b\x53\x0f\xed\xf5\xe5\xfd\xde\x2f\xfb\x2d\xc2\xd1\x7f\x4d\x21\x8b\x92\x7d\x08\x0d\x62\xfc\xca\xc6\x94\xde\x88\x69\x52\xc9\xa7\x4a\x3e\xf5\xbd\x78\x0b\x29\xee\x10\x10\x3a\xfe\x14\xbd\x44\x38\x49\x3f\x51\x32\x9d\x91\x5a\x0e\xf1\xbd\x99\xeb\x65\x85\x8d\xca\x45\x29\x58\xe9\x2c\xec\xaa\xb8\x0a\x8b\x67\x26\x2a\x8b\xb0\xdd\x57\xc1\x93\xbb\x73\x57\x1d\x42\xe4\x41\x1a\x9a\x2d\x0d\xf5\xac\xf6\x3c\x96\xdf\x4a\x34\xe6\xf0\xcd\xfe\x17\xe1\xa6\x7d\xbc\x47\xa8\xac\xee\x96\x51\xa5\x97\x5b\xd7\xba\x7f\xf2\xb0\x39\xa7\xe3\xc3\x35\xd5\xb1\xc3\xe6\xe2\x2f\x00\x00\xff\xff\xac\xf3\xba\xe2\xf2\x00\x00\x00")
+var _sqlSetcollectionsoverlimitSql = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x6d\x90\x4b\x6b\x02\x41\x10\x84\xcf\x59\xd8\xff\x50\x07\x21\x89\xf8\x20\x39\x06\x3c\x24\x3a\x21\x82\x8f\xa0\x2b\x21\xc7\x76\x6d\xb3\x83\xf3\x58\x66\x46\xcd\xfe\xfb\xcc\x8e\x17\x85\x1c\xbb\xab\xbf\xaa\xee\x1e\x76\xf3\x6c\x4e\xee\xe0\xc1\x54\x56\xb0\x7b\x10\x8e\x9e\xdd\xbd\x47\x69\x95\xe2\x32\x48\x6b\x3c\xb6\xdc\x58\xb3\x43\xa8\x58\x3a\xd4\x8a\x4c\xab\x53\x0d\xf2\xb0\x27\x76\x79\x16\x15\x28\xa9\x65\xe8\xa1\x54\x4c\x4e\x9a\x1f\xc8\x80\xbd\xb3\xba\xa5\xe0\xd8\x87\x41\x9e\xe5\x59\x11\x0b\x6d\x7d\x88\x9d\x92\x4d\x50\x0d\x8e\xf5\x8e\x02\xef\x6e\xf2\xc8\x71\xc2\xac\x61\x8f\x03\xd7\x01\x67\x27\x03\x6d\x15\x5f\x4c\xe8\xc0\xfe\x25\xcf\xee\xec\xd9\xb0\x43\x1f\x3e\xb4\x89\xbd\xb4\x7a\x04\x29\x20\x2a\xbe\xb5\xd0\x71\x4a\xd3\xef\xb5\x79\x1f\xd2\xc4\x3d\x2b\x7b\x86\x26\xd3\xdc\x04\x27\x36\xb9\x68\x6a\x50\xd1\x89\xf3\xac\x3b\x6c\x33\x37\x9f\x93\xd7\x42\x24\xcd\x0f\xae\x91\xb5\x28\xd2\x13\x66\xed\xf9\x18\xc1\x90\x66\x2c\x96\x05\xa6\x0b\x3c\xc4\xf4\xb5\x98\x89\x71\x71\x69\xbf\xaf\x96\xf3\x7f\x2c\xbe\x3e\xc4\x4a\x20\x1d\x33\xea\x3c\x45\x66\xb9\x9a\x88\x15\xde\xbe\xa1\xc8\x87\x4d\x7a\x10\x26\x62\x3d\xee\x25\x9b\x38\x30\x9b\xce\xa7\x05\x3a\xcf\x8f\x79\x76\x0b\xff\x01\xa0\xc0\xc4\xb3\xd1\x01\x00\x00")
 
-func sqlGetcollectionmetaSqlBytes() ([]byte, error) {
+func sqlSetcollectionsoverlimitSqlBytes() ([]byte, error) {
 	return bindataRead(
-		_sqlGetcollectionmetaSql,
-		"sql/getCollectionMeta.sql",
+		_sqlSetcollectionsoverlimitSql,
+		"sql/setCollectionsOverLimit.sql",
 	)
 }
 
-func sqlGetcollectionmetaSql() (*asset, error) {
-	bytes, err := sqlGetcollectionmetaSqlBytes()
+func sqlSetcollectionsoverlimitSql() (*asset, error) {
+	bytes, err := sqlSetcollectionsoverlimitSqlBytes()
 	if err != nil {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "sql/getCollectionMeta.sql", size: 242, mode: os.FileMode(438), modTime: time.Unix(1429212867, 0)}
+	info := bindataFileInfo{name: "sql/setCollectionsOverLimit.sql", size: 465, mode: os.FileMode(438), modTime: time.Unix(1791971433, 0)}
 	a := &asset{bytes: bytes, info:  info}
 	return a, nil
 }
 
-var _sqlGetresetSql = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x54\x90\x4f\x4b\x03\x31\x10\xc5\xcf\x06\xf2\x1d\xe6\xd0\x83\x96\x6d\x8b\x1e\x85\x0a\x45\x57\x04\xff\x41\x2d\xf6\x20\x1e\xa6\x9b\x69\x1b\x76\x37\xd1\x24\xbb\xcb\x7e\x7b\x27\x59\xdb\xea\x2d\x43\xde\xef\xbd\x37\x33\x1b\x4b\xb1\x28\xbe\x1b\xed\xc8\x43\xd8\x13\x50\x4b\xae\x07\x9e\x28\x40\x49\x3d\x6c\xad\x03\x84\x2f\x67\x5b\xad\x48\x41\xe3\xc9\xb1\x0e\x03\xd4\x18\x8a\x3d\x79\x29\x22\x75\xfc\x3f\x81\x68\x14\x68\x0f\x2d\x56\x5a\x4d\xa5\x90\x62\xc5\xba\x2d\x16\x61\xc0\x11\x9c\xed\xa2\xc0\x51\x68\x9c\x61\xb4\x26\x34\x7e\xf8\xfc\x67\xe9\xc9\x7b\x6d\xcd\x2c\x46\x4b\x51\xd8\x7a\x63\x4f\xc6\xb0\x26\xf0\x41\x57\x15\x70\x99\xa2\x04\x6d\x12\x5c\x6b\xa5\x2a\xea\xd0\x11\x8f\xb6\xd9\xed\x87\x06\x58\x92\xbf\x96\xe2\xcc\x60\x4d\x30\x61\xd0\x69\xb3\xcb\xfe\x2c\x65\x3b\xae\xa0\x03\x4b\x7e\x53\x1f\x79\x93\x09\x7c\x7c\x6e\xfa\x40\x19\x97\x4e\xa9\x87\x4a\x71\x4f\x29\xc6\xb3\xe8\xfd\x96\x3f\xe5\xb7\x2b\x88\xce\xd9\x70\x05\x46\x33\x8e\x40\x17\xde\x23\x94\x01\x19\x95\x5e\x52\xdc\x2f\x5f\x9f\x53\xaa\x9f\x26\x29\x5f\x71\xfd\x90\x2f\xf3\x84\xcf\x47\x97\xb0\x78\xb9\x3b\x9a\xcc\x47\x57\x69\x3e\xe0\x70\x03\xc6\x76\xe7\x17\x3f\x01\x00\x00\xff\xff\xc3\xa7\x47\xc9\xbb\x01\x00\x00")
+var _sqlSetemailchangeSql = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x5d\x8f\x31\x4f\xc3\x30\x14\x84\x67\x2c\xf9\x3f\xdc\x90\xa9\x0a\xad\x0a\x1b\x52\x86\x0a\x2c\x31\x22\x1a\xc4\x50\x31\x3c\xe2\xd7\xc4\x6a\x63\x57\xb6\xd3\xaa\xff\x1e\xdb\x6d\x25\x60\xfd\xde\xdd\xbb\xbb\xc5\x4c\x8a\x75\xa4\x9e\x03\x08\xdd\x40\xb6\x67\xb8\x2d\x78\x24\xb3\xc7\xd6\xf9\x44\xa7\xc0\x1e\x93\x8d\x09\xc4\x81\xd1\x39\xcd\xc8\x67\xd6\x88\x2e\x23\x29\x2c\x9f\x40\x5a\x7b\x0e\x01\x26\xe0\xe0\xdd\xd1\x68\xd6\x35\x3c\x1f\xf6\xd4\x19\xdb\x83\xec\x39\x71\x3e\x1a\x37\x25\x01\x5b\x9d\xe1\x25\x70\x2e\x85\x14\x2d\xed\x38\x3c\x49\x71\x67\x69\x64\xdc\x23\x44\x9f\x14\x75\x89\xcc\x0d\xd2\xe5\xea\x52\xa5\xdb\x5f\xc5\x2d\x3c\x15\xba\x8e\x88\x2e\x39\xca\x8c\xe7\x02\x5a\xb7\x63\x9b\x5c\x9b\xaf\xef\x73\xe4\x1a\x03\x85\x21\x2f\xbd\x4d\x92\x62\xb6\xc8\x3d\x3e\xde\x5e\x56\xad\x2a\x91\x61\x3e\x72\x24\xac\x55\x8b\xdf\xd1\x4d\xf5\x50\xe3\xff\xe7\xa6\x7a\x94\xe2\xf3\x55\xbd\x2b\xe4\x01\x4d\xb5\xfc\x01\xb4\x3c\xd1\x18\x5a\x01\x00\x00")
 
-func sqlGetresetSqlBytes() ([]byte, error) {
+func sqlSetemailchangeSqlBytes() ([]byte, error) {
 	return bindataRead(
-		_sqlGetresetSql,
-		"sql/getReset.sql",
+		_sqlSetemailchangeSql,
+		"sql/setEmailChange.sql",
 	)
 }
 
-func sqlGetresetSql() (*asset, error) {
-	bytes, err := sqlGetresetSqlBytes()
+func sqlSetemailchangeSql() (*asset, error) {
+	bytes, err := sqlSetemailchangeSqlBytes()
 	if err != nil {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "sql/getReset.sql", size: 443, mode: os.FileMode(438), modTime: time.Unix(1428964012, 0)}
+	info := bindataFileInfo{name: "sql/setEmailChange.sql", size: 346, mode: os.FileMode(438), modTime: time.Unix(1791970065, 0)}
 	a := &asset{bytes: bytes, info:  info}
 	return a, nil
 }
 
-var _sqlGetsessionsSql = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x6c\x90\x4f\x4b\xf3\x40\x10\xc6\xcf\xef\xc2\x7e\x87\xe7\xd0\xc3\x6b\x49\x5b\xf4\x28\x54\x28\x1a\x11\xfc\x07\xb5\xe8\x41\x3c\x4c\x93\xa9\x5d\x6a\x76\x75\x67\x93\x90\x6f\xef\x6e\x5a\x35\x88\xb7\x61\xe7\xf9\xfd\xe6\x61\x67\x63\xad\x16\xc5\x47\x6d\x3c\x0b\xc2\x96\xc1\x0d\xfb\x0e\xc2\x22\xc6\x59\xec\xb8\xc3\xc6\x79\x10\xde\xbd\x6b\x4c\xc9\x25\x6a\x61\x1f\x93\x14\x50\x51\x28\xb6\x2c\x5a\x25\xee\x7b\x3f\x44\xc9\x96\x30\x82\x86\xde\x4c\x39\xd5\x4a\xab\x55\x4c\x6e\xa8\x08\x7b\x01\xc1\xbb\x36\x05\x3c\x87\xda\xdb\x08\x57\x4c\x56\xf6\xcb\xbf\xa4\xb3\x74\x5c\xab\xc2\x55\x6b\xf7\x4b\x4c\x3b\x96\x53\xad\xfe\x59\xaa\x18\x13\x48\xf0\xc6\xbe\x66\x83\xb6\xae\x8d\x66\x13\x62\xe4\x20\xbb\x8e\x05\x27\x78\x7e\x59\x77\x81\xb3\xd8\xa5\x97\x0d\xeb\x6b\x35\x9e\x25\xf7\x43\x7e\x93\x9f\xaf\x90\xcc\x19\x7e\xe0\x38\x07\xf2\xe1\x31\x61\x19\xd8\x96\xfd\xa4\xd5\xe5\xf2\xfe\x56\xab\x74\x58\xa6\x87\x74\xfc\xa3\xa7\xab\x7c\x99\xf7\x8e\xf9\xe8\x18\x8b\xbb\x8b\x81\x69\x3e\x3a\xe9\x5f\xbe\x1c\x38\x83\x75\xed\xff\xa3\xcf\x00\x00\x00\xff\xff\x33\xa1\xf1\x1e\x9d\x01\x00\x00")
+var _sqlSetforcerehashSql = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x2d\x8e\x3d\x0b\xc2\x40\x10\x44\x6b\x0f\xee\x3f\x4c\x61\x25\x7e\x60\x2b\x58\x08\x46\x2c\x45\x23\xd6\x4b\xdc\xe4\x0e\x93\x3b\xb9\xdd\x18\xf3\xef\x4d\x82\xf5\xbc\x99\x79\x9b\x85\x35\xa7\x9a\x2a\x01\xa1\x15\x4e\xd0\x08\x47\x1f\x86\x3a\xf6\x09\x6f\x12\xe9\x62\x7a\x22\xb1\x23\x71\xfc\x44\xe7\xd5\x0d\x6c\x99\x58\x1c\x42\x0c\x05\x5b\x33\xb0\x08\xfc\x55\xa8\x6f\xa6\x66\x0f\x69\x8b\x82\x45\xca\xb6\xae\x7b\xd4\xb1\xf2\xc1\x1a\x6b\x72\x7a\xb1\xec\xac\x99\x05\x1a\xc0\x15\x44\x93\x0f\xd5\xf2\xff\xec\x48\x11\xbb\x20\xf0\x6a\xcd\x62\x33\x16\xee\x97\xe3\x21\xcf\xa6\x5c\xd6\x0d\x2b\x59\x73\xcb\x72\x94\x31\x15\x7c\x9d\x94\xb0\x87\xa6\x76\x90\x78\x9c\xb3\x6b\x66\xcd\xb8\xbc\x9f\x6f\x7f\x5b\xf2\x9f\x9b\xd8\x00\x00\x00")
 
-func sqlGetsessionsSqlBytes() ([]byte, error) {
+func sqlSetforcerehashSqlBytes() ([]byte, error) {
 	return bindataRead(
-		_sqlGetsessionsSql,
-		"sql/getSessions.sql",
+		_sqlSetforcerehashSql,
+		"sql/setForceRehash.sql",
 	)
 }
 
-func sqlGetsessionsSql() (*asset, error) {
-	bytes, err := sqlGetsessionsSqlBytes()
+func sqlSetforcerehashSql() (*asset, error) {
+	bytes, err := sqlSetforcerehashSqlBytes()
 	if err != nil {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "sql/getSessions.sql", size: 413, mode: os.FileMode(438), modTime: time.Unix(1428963981, 0)}
+	info := bindataFileInfo{name: "sql/setForceRehash.sql", size: 216, mode: os.FileMode(438), modTime: time.Unix(1791967498, 0)}
 	a := &asset{bytes: bytes, info:  info}
 	return a, nil
 }
 
-var _sqlGetsubSql = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x1c\x8e\xc1\x4a\x43\x31\x10\x45\xd7\x06\xf2\x0f\x77\xe1\xaa\x44\x8b\x5b\xc1\x85\xd4\x88\x82\xa2\xb4\x0f\x5c\x4f\x1f\xa3\x1d\x34\x89\xce\x4c\xec\xef\xf7\xa5\xab\x81\xe1\x9c\xc3\x5d\xaf\x62\xb8\x9f\xff\xba\x28\x1b\x08\xd6\xf7\x36\xab\xfc\xba\xb4\x8a\x4f\x6d\x05\x7e\x60\x18\xeb\x3f\x2b\x8e\xe2\x07\xd4\x06\xea\xcb\xb3\xba\xcc\x34\xb0\x18\x62\x98\xe8\x9b\xed\x36\x86\x8b\x4a\x85\x71\x05\x73\x95\xfa\x95\xd0\x17\x73\x29\x90\xa3\x1d\xab\x41\x3c\x86\xd5\x7a\x08\xbb\xfc\x92\x37\x13\x06\x9e\xf0\xfe\x43\x35\x61\xd3\xcd\x5b\x61\x7d\x7e\x48\xd8\xf5\xfd\xf9\x38\xa9\x4f\x52\x38\x86\xc7\xed\xdb\x6b\x0c\xa3\x67\xd7\x63\x24\x3e\x9e\xf2\x36\x9f\x03\x77\x97\x37\xa7\x00\x00\x00\xff\xff\x60\x75\xd1\x8c\xc6\x00\x00\x00")
+var _sqlSetloginlockoutSql = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x4d\x8e\x3d\x0b\xc2\x30\x14\x45\x67\x03\xf9\x0f\x6f\xe8\x54\xac\x45\x47\xa1\x83\x60\xc0\x51\x6a\x8b\xf3\xc3\xbe\xa6\xa1\xf9\x90\x24\xb5\x7f\xdf\x34\x38\x38\xde\xf7\x38\xe7\xde\xba\xe4\xac\xa5\x71\x09\x14\x40\x3b\xa9\x6c\x80\xd1\x79\x40\x48\x17\x0f\x8b\x8d\x4a\xa7\x20\xd5\x87\x2c\x44\x65\x88\x33\xce\x3a\x9c\x29\x9c\x39\xdb\x59\x34\x04\x15\x84\xe8\x95\x95\x7b\x88\x13\x65\x2c\x7d\xb4\x7b\xcd\x34\xf4\x19\xaf\x32\x18\x22\x9a\xf7\x1e\xd6\x29\x89\x7e\x45\xe8\x09\x50\x6b\xb7\xd2\x00\x28\x51\x59\xce\xca\x7a\x2b\xe8\xef\xd7\x4b\x27\xb2\x2b\x1c\x0c\x45\x84\x87\xe8\xe0\xcf\xd9\x14\x27\x78\xde\x44\x2b\x60\x9b\xd0\x14\xc7\x2f\x89\x10\xbf\x86\xc7\x00\x00\x00")
 
-func sqlGetsubSqlBytes() ([]byte, error) {
+func sqlSetloginlockoutSqlBytes() ([]byte, error) {
 	return bindataRead(
-		_sqlGetsubSql,
-		"sql/getSub.sql",
+		_sqlSetloginlockoutSql,
+		"sql/setLoginLockout.sql",
 	)
 }
 
-func sqlGetsubSql() (*asset, error) {
-	bytes, err := sqlGetsubSqlBytes()
+func sqlSetloginlockoutSql() (*asset, error) {
+	bytes, err := sqlSetloginlockoutSqlBytes()
 	if err != nil {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "sql/getSub.sql", size: 198, mode: os.FileMode(438), modTime: time.Unix(1431384383, 0)}
+	info := bindataFileInfo{name: "sql/setLoginLockout.sql", size: 199, mode: os.FileMode(438), modTime: time.Unix(1791969634, 0)}
 	a := &asset{bytes: bytes, info:  info}
 	return a, nil
 }
 
-var _sqlGetuserSql = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x24\x8d\x31\x4b\x04\x31\x10\x46\x6b\x03\xf9\x0f\x5f\x61\x75\x44\x0f\x5b\xc1\x42\x64\xc5\x42\x11\xce\x03\xeb\x21\x8c\x97\xc1\xcd\x44\x77\x66\x6f\xfd\xf9\x26\x5a\x7e\x8f\x99\xf7\xf6\xbb\x18\xee\xf3\xf7\x2a\x0b\x1b\x08\xab\xf1\x82\x8f\xa5\x55\x78\x61\xf4\x71\xee\x7b\x13\x2f\xd0\x06\x5a\x3b\x54\x97\x4c\x2e\x4d\x63\x88\xe1\x48\x9f\x6c\xb7\x31\x5c\x28\x55\xc6\x15\xcc\x17\xd1\x53\xfa\xd7\x78\x21\x47\xdb\xd4\x20\x1e\xc3\x6e\x3f\x1e\xde\xa6\xe7\xe9\xe1\x88\x71\x9e\xc0\x95\x64\x4e\xf8\x22\xb3\x42\x56\x52\x6f\x68\xee\xbc\xd2\x4f\x6e\xf3\xcc\x79\x64\x2c\x61\x6e\x7a\x62\xf3\xb3\xf0\x16\xc3\xe3\xe1\xf5\x25\x86\x11\xb0\xeb\xca\x4e\x78\x7f\x9a\x0e\xd3\x9f\xf1\xee\xf2\xe6\x37\x00\x00\xff\xff\xd6\xc1\x75\xc2\xcf\x00\x00\x00")
+var _sqlSetmaxcollectionsSql = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x5c\x8e\x41\x6b\x83\x40\x10\x85\xcf\x5d\xd8\xff\x30\x07\xa1\x20\x5a\xa9\xbd\x15\x3c\x94\x76\xa1\xc7\x90\x28\x39\x4f\x74\x88\x4b\xdc\x5d\x71\x26\x31\x3f\x3f\xeb\x9e\x42\xae\xf3\xbd\xf7\xbd\xa9\x72\xad\xba\x79\x40\x21\x06\x84\x2b\xd3\xf2\xce\x30\x23\xf3\x1a\x96\x01\x82\x07\x19\x09\x22\xc6\x13\x32\x69\xa5\x55\x8b\x17\xe2\x6f\xad\xde\x3c\x3a\x82\x12\x58\x16\xeb\xcf\x45\xaa\xc6\x30\x0a\x84\xd5\x33\x58\x89\x11\x87\xf7\xdf\x30\x4d\xd4\x8b\x0d\xf1\x56\x82\xf5\xf2\x55\x17\xc9\xe9\x02\x0b\xf4\x4f\x34\x75\x93\xa5\x47\x0f\x23\xde\xe2\x5c\x5e\x6d\x93\xdd\xee\xef\xa7\x35\x89\xf1\x87\x23\x41\xad\x0e\xa6\x85\x17\x7b\x03\x59\xad\xd5\xf1\xdf\xec\x8d\x56\xdb\x73\x4d\xf6\xf9\x08\x00\x00\xff\xff\x18\xde\x0b\x19\xde\x00\x00\x00")
 
-func sqlGetuserSqlBytes() ([]byte, error) {
+func sqlSetmaxcollectionsSqlBytes() ([]byte, error) {
 	return bindataRead(
-		_sqlGetuserSql,
-		"sql/getUser.sql",
+		_sqlSetmaxcollectionsSql,
+		"sql/setMaxCollections.sql",
 	)
 }
 
-func sqlGetuserSql() (*asset, error) {
-	bytes, err := sqlGetuserSqlBytes()
+func sqlSetmaxcollectionsSql() (*asset, error) {
+	bytes, err := sqlSetmaxcollectionsSqlBytes()
 	if err != nil {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "sql/getUser.sql", size: 207, mode: os.FileMode(438), modTime: time.Unix(1431477671, 0)}
+	info := bindataFileInfo{name: "sql/setMaxCollections.sql", size: 222, mode: os.FileMode(438), modTime: time.Unix(1429033740, 0)}
 	a := &asset{bytes: bytes, info:  info}
 	return a, nil
 }
 
-var _sqlModsubSql = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x74\x51\x41\x6f\xea\x30\x0c\x3e\x3f\x24\xfe\x83\x0f\x48\x05\xd4\x07\x7a\x6f\xdb\x65\x1c\x19\x87\x49\x3b\x4c\x6b\x77\x9b\x34\xa5\xc4\x94\x88\x34\xae\x6a\x07\xc4\xbf\x9f\x13\x40\x9a\x34\xed\x90\xc6\x76\xbe\xef\xb3\xfd\x75\x39\x1f\x8f\xc6\xa3\xf7\xd7\x6a\xf3\x56\x33\xb8\x20\x04\x91\x71\xe0\x05\xc7\x86\x35\x74\xa1\x05\xd9\x23\x74\x64\x3f\xb5\x04\xbb\x18\xb6\xe2\x28\x2c\x12\x6d\x4d\xd1\x5b\xe8\x49\x30\x88\x33\xde\x9f\xc1\x13\xf5\xb0\xa3\x01\x8f\x38\x40\x13\x05\x5a\x22\xab\x1f\x0b\x96\x90\x15\xca\xd2\x0e\x1a\x04\x44\xab\xba\x4e\x23\x23\xee\x88\xfe\x9c\x05\x6f\x5d\xf6\x86\x73\x57\x55\xea\x8c\x8c\x47\x7f\xae\x0f\xd3\x22\x09\x7b\xd3\x16\x25\x14\x15\x06\x46\xf7\x51\x30\xd4\xd4\x6b\x21\xd0\xa9\x54\x68\xc1\xd4\xe1\x3a\xb2\xe8\x35\xd4\x74\xc0\x90\xc0\xa9\x58\xc5\xe6\x92\xcf\x56\xa9\x59\x6d\x0e\xc8\x8f\xca\x08\xa6\x43\xf8\x0b\x2c\x83\x6e\x5b\xe6\xfd\xb5\xbb\x11\xa0\x53\x50\x4f\x52\xff\xde\x9b\xa0\x10\x9d\x9f\x5d\xe3\x93\x52\x99\x07\xcc\xf5\xcc\x4f\x59\x66\x5a\x64\xa7\x2b\x2a\x49\x5c\xd6\x4d\x17\x8b\xe9\xfa\x0b\xc5\x1b\xd1\x14\xb6\x7b\x13\x5a\x54\xd4\xf6\x3a\xea\xf3\xd3\xb7\x19\x12\xf0\xf6\xa0\x23\x58\x50\x43\xfa\x81\x8e\xce\xaa\x6f\xcd\x39\xe3\xfa\xc4\x56\x53\x7e\x10\x93\x83\xbf\x53\xe6\xcb\xb4\x7c\xb5\x79\xd9\xac\xeb\xdb\x6f\x9d\x4e\xfe\x95\x30\xf9\xaf\xe7\x4e\xcf\xbd\x9e\x87\xd9\xea\x2b\x00\x00\xff\xff\x23\x93\xa7\xaf\x1b\x02\x00\x00")
+var _sqlSetpasswordSql = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x55\x90\x4b\x4f\xc3\x30\x10\x84\xcf\x58\xf2\x7f\xd8\x43\x24\xa0\x0a\x54\x3c\x0f\x48\x3e\x20\x11\xa9\x27\x54\x85\x54\x3d\x6f\xe3\x0d\x89\x28\x4e\xe4\x75\x89\xf2\xef\x59\xe7\x51\xc4\xcd\x8f\x9d\x6f\x66\x67\xbd\xd2\x6a\xd7\x59\x0c\xc4\x80\x70\x62\xf2\x97\x0c\x1d\x32\xf7\xad\xb7\xd0\x3a\x08\x35\x81\x7c\xe3\x01\x99\x52\x60\x0c\x0d\x57\x43\xe3\x3e\x01\xdd\xa0\x55\x47\xce\xc6\x4b\xd5\xfa\x92\x2c\x78\xaa\x91\x6b\xad\xb4\x2a\xf0\x8b\xf8\x45\xab\x0b\x87\xdf\x04\x37\xc0\xc1\xcb\x5c\x3a\x3a\x08\x13\x03\xb4\xbd\x63\x68\x82\x8c\x44\xbb\x8d\xe8\x64\xec\x30\x04\xc2\x74\x34\xf5\xc4\xa7\xa3\x8c\x55\xc0\xa5\x1f\xba\x70\xb5\xa4\x4a\xc1\xb5\xae\xa4\xeb\x08\x8f\x87\xff\xb2\xe9\x49\x6c\x6c\x0c\x05\x96\x7c\xf3\x13\x13\x2e\x26\xa2\x9a\x78\xef\xe9\x0c\xce\x97\xc3\x56\x48\x8d\x0b\x13\xa7\x43\x2f\xc9\x03\x79\x3e\x4b\xa1\x47\x9e\x80\x02\xef\x9b\x20\xac\xd5\x3a\x2e\xbb\xdb\xbe\xbd\x16\xd9\xb8\x1b\xdf\x8a\x08\xb5\xfa\xc8\x8a\x3f\x9d\x81\xe4\x7e\x4e\x6d\x92\x87\x74\x6a\x2b\x1f\xbb\x32\x15\x1e\xa5\x58\xad\xe6\x50\x26\x79\x3c\xe7\x32\xc9\xd3\x39\x9a\x49\x9e\xb5\xda\x6f\xb2\x3c\xd3\x2a\x56\x6a\x92\xbb\x5f\x42\xed\x08\x88\xbb\x01\x00\x00")
 
-func sqlModsubSqlBytes() ([]byte, error) {
+func sqlSetpasswordSqlBytes() ([]byte, error) {
 	return bindataRead(
-		_sqlModsubSql,
-		"sql/modSub.sql",
+		_sqlSetpasswordSql,
+		"sql/setPassword.sql",
 	)
 }
 
-func sqlModsubSql() (*asset, error) {
-	bytes, err := sqlModsubSqlBytes()
+func sqlSetpasswordSql() (*asset, error) {
+	bytes, err := sqlSetpasswordSqlBytes()
 	if err != nil {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "sql/modSub.sql", size: 539, mode: os.FileMode(438), modTime: time.Unix(1431382801, 0)}
+	info := bindataFileInfo{name: "sql/setPassword.sql", size: 443, mode: os.FileMode(438), modTime: time.Unix(1791969924, 0)}
 	a := &asset{bytes: bytes, info:  info}
 	return a, nil
 }
 
-var _sqlRemovesessionSql = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x44\xcd\x4d\x8b\x83\x30\x10\xc6\xf1\xf3\x06\xf2\x1d\x9e\x83\x27\x71\x57\x76\x8f\x0b\x1e\x16\xcc\x52\xe8\x1b\x88\xd0\x43\xe9\x21\xc5\x69\x1b\xac\x49\xc9\xa4\x16\xbf\x7d\xa3\x08\x5e\x67\xfe\xfc\x9e\x3c\x95\xa2\xa2\xce\xf5\xc4\xd0\x78\x78\xd7\x9b\x86\x1a\x30\x31\x1b\x67\x71\x71\x3e\x9e\x9f\x4c\x5e\x0a\x29\x6a\xdd\x12\xff\x4a\xf1\x61\x75\x47\xf8\x04\x07\x6f\xec\x35\x9b\xfe\x08\x37\x1d\xe0\x5e\x96\x61\x42\x4c\x66\x61\x4d\x43\x0c\x8f\xa7\xf3\x10\x28\x8b\x54\xaf\xef\x66\xe1\x5b\x1a\xa4\x48\xf3\xd1\x2e\xd5\x46\xd5\x0a\xff\xd5\x7e\x3b\x79\xfc\x35\x47\x8c\xc3\x4a\x55\x0a\xe3\x66\x91\x7c\xe3\x6f\x57\x62\xc1\x8b\xe4\xe7\x1d\x00\x00\xff\xff\xc3\xcb\x8c\x89\xc3\x00\x00\x00")
+var _sqlSetpreferencesSql = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x75\x8f\x31\x4f\xc3\x30\x10\x85\x67\x2c\xf9\x3f\xbc\xa1\x53\x95\x52\x01\x1b\x52\x07\x24\x22\x75\x44\x90\x8a\xf9\xb0\x2f\xc4\xc2\xb1\xa3\xf3\x85\x2a\xff\x9e\xa4\x2d\x88\xa5\xe3\xe9\xbe\xfb\xde\xbb\xed\xda\x9a\xc3\xe0\x49\xb9\xa0\xcb\x47\x10\xc6\xc2\x82\x41\xb8\x65\x29\xd0\x8e\x83\xc0\xe5\x18\xd9\x69\xc8\xa9\xe0\x83\xf1\x4d\x71\x64\x0f\x4a\x1e\x3e\x94\x21\xd2\xc4\xde\x1a\x6b\x1a\xfa\xe2\xf2\x68\xcd\x4d\xa2\x9e\xb1\x41\x51\x09\xe9\xb3\x3a\x1b\xb5\x23\x45\x3e\xce\x86\xa0\x33\xe2\x46\x11\x4e\x6e\xfa\x87\xcd\x59\x97\x5c\x99\xed\xbf\x40\x05\xee\x07\x9d\xd0\x66\x39\x11\x9e\x5b\x1a\xe3\xa2\x88\xd9\x51\xe4\xab\x82\xf3\xfa\xea\xf9\x7a\xbb\x54\x3e\xbc\x3c\x3f\x35\xf5\xa9\x61\xb9\xed\x59\xc9\x9a\xb7\xba\xf9\x0b\xc7\x0e\xab\xfb\xea\xa2\x5a\x86\x07\x6b\xde\xf7\xf5\x6b\x6d\xcd\xf2\xe3\x6e\x75\xf7\x03\xfb\xd9\xbf\x05\x40\x01\x00\x00")
 
-func sqlRemovesessionSqlBytes() ([]byte, error) {
+func sqlSetpreferencesSqlBytes() ([]byte, error) {
 	return bindataRead(
-		_sqlRemovesessionSql,
-		"sql/removeSession.sql",
+		_sqlSetpreferencesSql,
+		"sql/setPreferences.sql",
 	)
 }
 
-func sqlRemovesessionSql() (*asset, error) {
-	bytes, err := sqlRemovesessionSqlBytes()
+func sqlSetpreferencesSql() (*asset, error) {
+	bytes, err := sqlSetpreferencesSqlBytes()
 	if err != nil {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "sql/removeSession.sql", size: 195, mode: os.FileMode(438), modTime: time.Unix(1429029700, 0)}
+	info := bindataFileInfo{name: "sql/setPreferences.sql", size: 320, mode: os.FileMode(438), modTime: time.Unix(1791967673, 0)}
 	a := &asset{bytes: bytes, info:  info}
 	return a, nil
 }
 
-var _sqlSetcollectionpermissionsSql = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x4c\x8e\x41\x4b\x03\x41\x0c\x85\xcf\x0e\xcc\x7f\x78\x87\x3d\x15\xb5\xa8\x37\x61\x0f\x85\x2e\x78\x92\xa2\x5b\x3d\xa7\xdb\x60\x83\xdb\x99\x65\x92\x6e\xf1\xdf\x9b\x51\xc1\x42\xc8\x21\xef\x7b\x2f\x6f\xb9\x88\x61\x3b\xed\xc9\x58\x41\x18\xf2\x38\xf2\x60\x92\x13\x7c\xec\xc0\x70\x85\x76\xa4\x0c\xcb\x38\xd0\xcc\xbf\x47\x56\x29\xbc\xc7\xc4\xe5\x28\xaa\x8e\x6b\x0c\x31\xf4\xf4\xc9\xfa\x18\xc3\x55\xa2\x23\xe3\x06\x6a\x45\xd2\xc7\x35\x4e\xca\xc5\x7d\x64\xc8\xe7\xa4\x10\x73\x64\x3a\xed\x46\x19\xde\x84\xcf\x8e\x5c\xb0\x84\x99\x46\xf1\xe8\x22\x33\x0d\x5f\x50\x36\x73\xa1\xc6\x2f\x96\x75\x6f\x37\xeb\x55\xdf\xfd\x64\xea\xed\x7f\x5f\x2f\xf0\xda\xf5\xd8\xfc\xd9\x5a\x34\x0f\x31\xbc\x3f\x75\x2f\x5d\x7d\xca\xa5\x6d\xee\xb0\x7a\x5e\xa3\x56\x6b\x9b\xfb\xef\x00\x00\x00\xff\xff\xcb\xb3\x51\x19\xf7\x00\x00\x00")
+var _sqlSetsubeffectsSql = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x5c\x8f\x31\x6b\xc3\x30\x10\x85\xe7\x0a\xf4\x1f\x6e\xc8\x14\xdc\x84\xb6\x5b\x21\x43\xa1\x86\x8e\xa5\x75\xe8\x7c\x56\xce\xb1\x88\x25\x19\xdd\x39\x6e\xfe\x7d\x25\x99\x82\xc9\x22\xd0\xbb\xf7\xbe\x7b\xb7\xdf\x6a\x75\x1c\x4f\x28\xc4\x80\x30\x31\x45\x08\x1e\xa4\x27\x48\x1a\xb6\xc8\x04\x12\xa0\xc7\x2b\x2d\x22\xb1\x8d\x74\x02\x9e\x5a\x36\xd1\x8e\x62\x93\xbb\x43\x43\xc2\x5a\x69\xd5\xe0\x85\xf8\x55\xab\x07\x8f\x8e\xe0\x11\x58\xa2\xf5\xe7\x6a\xe1\x4a\x8f\x02\x61\xf6\x0c\x56\x92\xc5\xe1\xaf\x09\xc3\x40\x26\x33\x38\x99\xad\x97\x0a\xfa\x30\x83\x43\x7f\x83\xf5\xac\x24\x0b\xc3\xe1\xad\x94\x49\xf9\x21\xf8\x33\xb1\x5c\x2d\xcd\x29\x2c\xd6\xa5\x0f\xba\x71\x41\x74\x18\xa1\x45\x73\xc9\xd0\x50\x9a\x77\x93\x4c\x91\xfe\x6f\xcc\x9c\x9c\xdc\xe5\xd6\xdb\x7d\x7e\x8f\x9f\xef\x6f\x4d\x5d\xc6\xbc\x73\x24\xa8\xd5\x77\xdd\xc0\x5d\xcd\x03\x6c\x9e\x2b\x58\xef\x4e\xca\x8b\x56\x3f\x1f\xf5\x57\x0d\xf9\xec\xc3\xe6\xe9\x2f\x00\x00\xff\xff\x34\x1a\xb3\xd5\x55\x01\x00\x00")
 
-func sqlSetcollectionpermissionsSqlBytes() ([]byte, error) {
+func sqlSetsubeffectsSqlBytes() ([]byte, error) {
 	return bindataRead(
-		_sqlSetcollectionpermissionsSql,
-		"sql/setCollectionPermissions.sql",
+		_sqlSetsubeffectsSql,
+		"sql/setSubEffects.sql",
 	)
 }
 
-func sqlSetcollectionpermissionsSql() (*asset, error) {
-	bytes, err := sqlSetcollectionpermissionsSqlBytes()
+func sqlSetsubeffectsSql() (*asset, error) {
+	bytes, err := sqlSetsubeffectsSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "sql/setSubEffects.sql", size: 341, mode: os.FileMode(438), modTime: time.Unix(1431473969, 0)}
+	a := &asset{bytes: bytes, info:  info}
+	return a, nil
+}
+
+var _sqlSetsubintervalSql = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x4d\x8e\xb1\x0a\xc2\x30\x14\x45\x67\x03\xf9\x87\x3b\x14\x84\xa2\x16\x1d\x85\x0e\x82\x01\xdd\xc4\x56\x9c\x53\x7c\xda\x60\x9b\x48\xde\xab\xc5\xbf\xb7\x2d\x0e\xee\xe7\x9c\x7b\xb3\x54\xab\x82\x84\x51\x87\x1e\xe1\x2e\xe4\x61\xd1\x31\xc5\x39\xe3\xd5\x58\x0f\xc7\xa8\x5c\xd3\xd0\x4d\x2b\xad\x4a\xfb\x24\xde\x6a\x35\xf3\xb6\x25\x2c\xc1\x12\x9d\x7f\x2c\x26\x01\x52\x5b\x41\xe8\x3d\xc3\xc9\x80\x38\x2f\x14\xdf\xb6\xf9\xc3\xc8\x49\x3d\x80\x6d\xf0\x52\x23\x44\x7c\xc8\x46\xad\xd2\x6c\x4c\x5f\x4e\xfb\x5d\x69\xa6\x12\xaf\xb8\xab\x18\x85\x29\xa7\xe9\x41\x3d\xfe\x5a\x79\xb2\xd1\xea\x7a\x30\x67\x83\xf1\x41\x9e\xac\xbf\x53\x46\x4a\x4d\xc0\x00\x00\x00")
+
+func sqlSetsubintervalSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_sqlSetsubintervalSql,
+		"sql/setSubInterval.sql",
+	)
+}
+
+func sqlSetsubintervalSql() (*asset, error) {
+	bytes, err := sqlSetsubintervalSqlBytes()
 	if err != nil {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "sql/setCollectionPermissions.sql", size: 247, mode: os.FileMode(438), modTime: time.Unix(1429074766, 0)}
+	info := bindataFileInfo{name: "sql/setSubInterval.sql", size: 192, mode: os.FileMode(438), modTime: time.Unix(1791971212, 0)}
 	a := &asset{bytes: bytes, info:  info}
 	return a, nil
 }
 
-var _sqlSetmaxcollectionsSql = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x5c\x8e\x41\x6b\x83\x40\x10\x85\xcf\x5d\xd8\xff\x30\x07\xa1\x20\x5a\xa9\xbd\x15\x3c\x94\x76\xa1\xc7\x90\x28\x39\x4f\x74\x88\x4b\xdc\x5d\x71\x26\x31\x3f\x3f\xeb\x9e\x42\xae\xf3\xbd\xf7\xbd\xa9\x72\xad\xba\x79\x40\x21\x06\x84\x2b\xd3\xf2\xce\x30\x23\xf3\x1a\x96\x01\x82\x07\x19\x09\x22\xc6\x13\x32\x69\xa5\x55\x8b\x17\xe2\x6f\xad\xde\x3c\x3a\x82\x12\x58\x16\xeb\xcf\x45\xaa\xc6\x30\x0a\x84\xd5\x33\x58\x89\x11\x87\xf7\xdf\x30\x4d\xd4\x8b\x0d\xf1\x56\x82\xf5\xf2\x55\x17\xc9\xe9\x02\x0b\xf4\x4f\x34\x75\x93\xa5\x47\x0f\x23\xde\xe2\x5c\x5e\x6d\x93\xdd\xee\xef\xa7\x35\x89\xf1\x87\x23\x41\xad\x0e\xa6\x85\x17\x7b\x03\x59\xad\xd5\xf1\xdf\xec\x8d\x56\xdb\x73\x4d\xf6\xf9\x08\x00\x00\xff\xff\x18\xde\x0b\x19\xde\x00\x00\x00")
+var _sqlSetverifytokenSql = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x4d\x8e\x31\x6b\xc3\x30\x14\x84\xe7\x0a\xf4\x1f\x6e\xc8\x14\x5c\x87\x76\x2c\x64\x08\xc4\xd0\x29\x0d\x89\xdb\x0e\xa5\xc3\x6b\x72\xae\x45\x6c\x29\x48\xb2\xc1\xff\xbe\x92\x53\x4a\xd7\xf7\xbe\xfb\xee\x56\x4b\xad\x8e\x8c\x01\xb1\x25\x4e\xee\x4c\x08\x86\x40\x8f\x7e\x08\x11\x57\xef\x46\x93\x6e\xd1\x61\xa4\x37\xcd\x94\x31\xe3\xc1\x5e\x4c\x57\xc0\xf3\xda\xc9\xc9\xd8\x6f\xad\xc4\x4e\x89\xe6\x68\xdc\x10\x66\x4f\x89\xb7\x9c\x30\x3c\xcf\xbe\x00\xf1\x44\xc7\x26\x42\x3a\x67\x59\x6a\xa5\x55\x2d\x17\x86\x27\xad\xee\xac\xf4\xc4\x3d\x42\xf4\x49\x56\xcc\x5b\x72\x28\x7d\x6e\xb5\xb5\xbb\xd0\x26\xe0\xe3\xf3\x6b\x8a\x2c\xd0\x4a\x68\xe1\x9a\xbf\xd1\x5a\x2d\x57\x59\xf8\xba\xdf\x6e\xea\xea\x56\x58\xf6\x8c\x82\x63\x55\xe3\x9f\x63\xbd\x78\xd4\xea\xfd\xb9\x3a\x54\xc8\x9d\xeb\xc5\x03\x36\xbb\x2d\x76\x2f\xbf\x54\x9a\xfb\x03\x08\x6f\x61\x71\x12\x01\x00\x00")
 
-func sqlSetmaxcollectionsSqlBytes() ([]byte, error) {
+func sqlSetverifytokenSqlBytes() ([]byte, error) {
 	return bindataRead(
-		_sqlSetmaxcollectionsSql,
-		"sql/setMaxCollections.sql",
+		_sqlSetverifytokenSql,
+		"sql/setVerifyToken.sql",
 	)
 }
 
-func sqlSetmaxcollectionsSql() (*asset, error) {
-	bytes, err := sqlSetmaxcollectionsSqlBytes()
+func sqlSetverifytokenSql() (*asset, error) {
+	bytes, err := sqlSetverifytokenSqlBytes()
 	if err != nil {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "sql/setMaxCollections.sql", size: 222, mode: os.FileMode(438), modTime: time.Unix(1429033740, 0)}
+	info := bindataFileInfo{name: "sql/setVerifyToken.sql", size: 274, mode: os.FileMode(438), modTime: time.Unix(1791969489, 0)}
 	a := &asset{bytes: bytes, info:  info}
 	return a, nil
 }
 
-var _sqlSetpasswordSql = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x54\x8f\x41\x4b\xc3\x40\x10\x85\xcf\x0e\xcc\x7f\x78\x87\x80\x5a\xa2\x45\xbd\x09\x39\x08\x06\x7a\x14\x4d\xf1\x3c\x6d\xa6\x36\x68\x77\xc3\xce\xd4\xd2\x7f\xef\x6e\xb4\x42\x6f\xcb\xee\xf7\xde\xf7\x76\x3e\x63\x5a\x8e\xbd\xb8\x1a\x04\x7b\xd3\x74\x69\x18\xc5\xec\x10\x53\x8f\x18\xe0\x5b\x45\x7e\x96\x95\x98\x32\x31\x75\xf2\xa9\xf6\xc8\x74\x11\x64\xa7\xb8\x81\x79\x1a\xc2\x47\x3d\x45\x33\x2c\x8e\x78\x08\x86\xc1\x33\x52\x7a\x16\x62\xdb\x8c\xad\x8e\xae\x52\x4f\x6d\x49\x6d\xff\x95\xb1\x0d\x6c\x9d\x8e\xa3\x5f\x9d\x74\x35\x42\x0c\x6b\xbd\x2e\xe5\xe5\x70\x1e\xfb\xbd\xca\x9a\x1e\x9b\x98\xd0\x6b\x1a\xbe\xb3\x19\x27\x09\xd3\x6c\x5e\x06\x2e\x5f\x9e\x9f\xba\x76\xda\x63\xb7\x3b\x75\x61\x7a\x6b\xbb\x7f\x0c\x0d\xaa\xfb\x3f\x53\x53\x3d\x30\xbd\x2f\xda\xd7\x96\xa9\x7c\xa7\xa9\xee\x7e\x02\x00\x00\xff\xff\xa7\x37\x03\x88\x10\x01\x00\x00")
+var _sqlTouchsessionSql = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x45\x8e\xc1\x0a\x82\x40\x18\x84\xcf\x2d\xec\x3b\xcc\xc1\x43\x89\x25\x75\x0c\x3c\x08\x2e\x04\x81\x44\x29\x1d\xa2\xc3\x86\x5b\x9a\xb6\x0b\xfe\x6b\xe2\xdb\xb7\x56\xd4\x79\x66\xbe\x6f\x42\x9f\xb3\xd4\x58\x45\xb0\xa5\xb4\x90\x20\x45\x54\x19\x8d\x5e\x12\xee\x1d\x59\x74\xa4\x0a\xce\x38\xcb\x64\xad\x68\xcd\xd9\x44\xcb\x87\xc2\x1c\x64\xdb\x4a\xdf\x82\x31\x6f\x3f\x63\xd3\x6b\x42\x65\x5d\xe5\x0b\xd9\xaa\xc1\x15\x4f\xe7\xcb\x60\x55\x80\x52\x52\x09\x73\x75\x8e\xa7\x6c\xaa\xe2\x67\xaa\xd5\xc0\x99\x1f\x8e\x8e\x7c\x97\xc4\x99\x78\x23\x69\xf1\xcd\x89\xb3\x83\xc8\xd0\x48\xb2\xb9\xbb\x82\x08\xda\xf4\xd3\x19\x67\xc7\x8d\xd8\x0b\x8c\x6f\x22\x6f\x89\x38\x4d\xf0\xd7\x46\xde\xea\x05\xac\x27\x93\x6c\xda\x00\x00\x00")
 
-func sqlSetpasswordSqlBytes() ([]byte, error) {
+func sqlTouchsessionSqlBytes() ([]byte, error) {
 	return bindataRead(
-		_sqlSetpasswordSql,
-		"sql/setPassword.sql",
+		_sqlTouchsessionSql,
+		"sql/touchSession.sql",
 	)
 }
 
-func sqlSetpasswordSql() (*asset, error) {
-	bytes, err := sqlSetpasswordSqlBytes()
+func sqlTouchsessionSql() (*asset, error) {
+	bytes, err := sqlTouchsessionSqlBytes()
 	if err != nil {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "sql/setPassword.sql", size: 272, mode: os.FileMode(438), modTime: time.Unix(1429031540, 0)}
+	info := bindataFileInfo{name: "sql/touchSession.sql", size: 218, mode: os.FileMode(438), modTime: time.Unix(1791968820, 0)}
 	a := &asset{bytes: bytes, info:  info}
 	return a, nil
 }
 
-var _sqlSetsubeffectsSql = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x5c\x8f\x31\x6b\xc3\x30\x10\x85\xe7\x0a\xf4\x1f\x6e\xc8\x14\xdc\x84\xb6\x5b\x21\x43\xa1\x86\x8e\xa5\x75\xe8\x7c\x56\xce\xb1\x88\x25\x19\xdd\x39\x6e\xfe\x7d\x25\x99\x82\xc9\x22\xd0\xbb\xf7\xbe\x7b\xb7\xdf\x6a\x75\x1c\x4f\x28\xc4\x80\x30\x31\x45\x08\x1e\xa4\x27\x48\x1a\xb6\xc8\x04\x12\xa0\xc7\x2b\x2d\x22\xb1\x8d\x74\x02\x9e\x5a\x36\xd1\x8e\x62\x93\xbb\x43\x43\xc2\x5a\x69\xd5\xe0\x85\xf8\x55\xab\x07\x8f\x8e\xe0\x11\x58\xa2\xf5\xe7\x6a\xe1\x4a\x8f\x02\x61\xf6\x0c\x56\x92\xc5\xe1\xaf\x09\xc3\x40\x26\x33\x38\x99\xad\x97\x0a\xfa\x30\x83\x43\x7f\x83\xf5\xac\x24\x0b\xc3\xe1\xad\x94\x49\xf9\x21\xf8\x33\xb1\x5c\x2d\xcd\x29\x2c\xd6\xa5\x0f\xba\x71\x41\x74\x18\xa1\x45\x73\xc9\xd0\x50\x9a\x77\x93\x4c\x91\xfe\x6f\xcc\x9c\x9c\xdc\xe5\xd6\xdb\x7d\x7e\x8f\x9f\xef\x6f\x4d\x5d\xc6\xbc\x73\x24\xa8\xd5\x77\xdd\xc0\x5d\xcd\x03\x6c\x9e\x2b\x58\xef\x4e\xca\x8b\x56\x3f\x1f\xf5\x57\x0d\xf9\xec\xc3\xe6\xe9\x2f\x00\x00\xff\xff\x34\x1a\xb3\xd5\x55\x01\x00\x00")
+var _sqlTrimeventsSql = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x75\xce\x4d\x6b\x02\x31\x14\x85\xe1\x75\x03\xf9\x0f\x67\xe1\xc2\x8a\x1f\xb4\xcb\xa2\x8b\xd6\x64\x50\xd0\x0e\xcc\x04\xc4\x65\x5a\xaf\xce\xa0\x93\x48\x12\x3b\xf8\xef\x9b\x4c\x71\x21\xc5\x4d\x16\x97\xf7\xe1\x64\x32\xe0\x4c\x38\x7b\xf6\xd0\xa7\x13\xbe\x2e\x01\xa1\x22\x34\xd6\x07\x38\xfa\x26\x13\x40\x3f\xf1\xf5\xd8\x5b\x07\x8d\x8b\x27\xc7\x19\x67\x4a\x1f\xc9\xbf\x71\xf6\x64\x5b\x43\x0e\x23\xf8\xe0\x6a\x73\x18\x76\x3a\x45\x68\x2b\xeb\xe9\x86\xb5\x23\xc4\xa0\x69\x68\x17\xcd\x91\xce\x21\x92\xda\x84\x21\x2a\xdb\xa2\xd1\xe6\x0a\xbb\x7f\xb4\x9c\x70\x22\x9c\x0d\x26\x69\x5b\xc8\x95\x54\x12\x59\x91\xaf\xbb\x29\x3f\xfe\x0b\x39\xdb\x2c\x64\x21\xd1\x7d\x69\xd6\x7b\xc1\xfb\xa7\x40\xbd\xc3\x74\x86\x7e\x5c\x2d\x23\x9b\xab\x74\xf8\x27\x71\x0f\x63\x9c\x17\x42\x16\xf8\xd8\xa6\x5c\xc8\x72\x8e\x3c\xcb\x4a\xa9\xd0\x7b\xc5\x6a\xb9\x5e\x2a\xc4\xe8\xf9\x17\xc3\x96\x04\xc5\x3c\x01\x00\x00")
 
-func sqlSetsubeffectsSqlBytes() ([]byte, error) {
+func sqlTrimeventsSqlBytes() ([]byte, error) {
 	return bindataRead(
-		_sqlSetsubeffectsSql,
-		"sql/setSubEffects.sql",
+		_sqlTrimeventsSql,
+		"sql/trimEvents.sql",
 	)
 }
 
-func sqlSetsubeffectsSql() (*asset, error) {
-	bytes, err := sqlSetsubeffectsSqlBytes()
+func sqlTrimeventsSql() (*asset, error) {
+	bytes, err := sqlTrimeventsSqlBytes()
 	if err != nil {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "sql/setSubEffects.sql", size: 341, mode: os.FileMode(438), modTime: time.Unix(1431473969, 0)}
+	info := bindataFileInfo{name: "sql/trimEvents.sql", size: 316, mode: os.FileMode(438), modTime: time.Unix(1791967258, 0)}
+	a := &asset{bytes: bytes, info:  info}
+	return a, nil
+}
+
+var _sqlVerifyemailSql = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x55\x8e\x41\x6b\x02\x31\x10\x85\xcf\x0d\xe4\x3f\xbc\x83\x50\x90\x55\xb1\xc7\xc2\x1e\x04\x17\x3c\x58\x29\x75\xc5\x83\xf4\x30\x6e\x46\x13\xd6\xdd\x94\x24\x2a\xf9\xf7\x4d\x16\x2a\xf6\x38\x33\x6f\xbe\xef\xcd\xc6\x52\x7c\x90\x6b\x3d\x08\x57\xcf\xee\xd5\x83\x3b\x32\x17\x90\xc7\x8d\x9d\x39\x19\x56\xb8\x6b\xee\x11\x34\x47\xfc\x38\x7b\x33\x8a\xf3\x60\x1c\x1a\xab\xb8\x90\xe2\xae\x4d\xa3\xd1\x51\x84\xed\x2f\x11\x9c\xfe\x70\xe4\x8c\x53\x69\xd3\xf0\x54\x0a\x29\x6a\x6a\xd9\xbf\x4b\xf1\xd2\x53\xc7\x98\xc0\x07\x67\xfa\x73\x91\x49\x83\x38\x5d\x06\x5f\xac\x6d\x9b\x6c\x13\x1c\xbe\x8f\x31\x70\x01\x4d\x5e\xc3\x9e\x86\x60\x16\xfe\x75\x50\x52\x8c\x67\x99\xbc\xfb\x5c\x2e\xea\x6a\x80\xf8\x69\xc7\x81\xb0\xad\xea\x47\xf9\x32\xb8\x6b\xa2\x3c\xb1\xcb\xcd\x6e\xbd\x96\x62\xbf\xaa\xbe\x2a\xe4\x36\xe5\x68\x8e\xc5\x66\xf9\x2f\x33\x7a\xfb\x05\x95\x30\xbc\x14\x1a\x01\x00\x00")
+
+func sqlVerifyemailSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_sqlVerifyemailSql,
+		"sql/verifyEmail.sql",
+	)
+}
+
+func sqlVerifyemailSql() (*asset, error) {
+	bytes, err := sqlVerifyemailSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "sql/verifyEmail.sql", size: 282, mode: os.FileMode(438), modTime: time.Unix(1791969489, 0)}
+	a := &asset{bytes: bytes, info:  info}
+	return a, nil
+}
+
+var _sqlWaiveplancooldownSql = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x3d\x8e\xbb\x0e\x82\x40\x14\x44\x6b\x37\xd9\x7f\x98\xc2\x8a\xa8\xc4\xd6\x84\xc2\x28\x89\x85\x85\x51\x0c\xa5\xb9\xc2\x55\x37\xc2\x2e\xd9\xbb\xf8\xf8\x7b\x01\xa3\xf5\x9c\x39\x33\x71\xa4\xd5\x96\x83\x20\xdc\x18\x96\x5f\x01\x4d\x45\x16\xc5\x8d\xec\x95\x71\x71\x1e\x84\x56\xd8\x43\xee\xa6\x19\xa0\xc2\xb9\xaa\x74\x4f\x3b\xd3\x4a\xab\x55\xc5\xe4\xb9\xc4\xf9\x8d\xda\x95\x27\x69\xcf\x70\xb6\xe0\x0e\xa4\xf0\x93\x18\x41\x4d\x25\x0f\x7c\x46\x77\x96\x85\x56\x23\x4b\x35\x63\x0a\x09\xde\xd8\xeb\xe4\x3b\x31\x94\x3a\xb3\xc0\x04\xad\xa2\xb8\x2f\x1c\x77\xeb\x65\x96\x0e\xb9\xcc\x3a\xbd\x68\x75\x48\xb3\xff\x89\x9c\xcc\xa3\x9b\x4f\x10\x7c\xcb\x5a\xe5\x9b\x74\x9f\xa2\x77\x27\xe3\xf9\x07\x94\x85\x10\x5d\xdb\x00\x00\x00")
+
+func sqlWaiveplancooldownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_sqlWaiveplancooldownSql,
+		"sql/waivePlanCooldown.sql",
+	)
+}
+
+func sqlWaiveplancooldownSql() (*asset, error) {
+	bytes, err := sqlWaiveplancooldownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "sql/waivePlanCooldown.sql", size: 219, mode: os.FileMode(438), modTime: time.Unix(1791968226, 0)}
 	a := &asset{bytes: bytes, info:  info}
 	return a, nil
 }
@@ -582,28 +2052,98 @@ func AssetNames() []string {
 
 // _bindata is a table, holding each asset generator, mapped to its name.
 var _bindata = map[string]func() (*asset, error){
+	"sql/addAlert.sql": sqlAddalertSql,
 	"sql/addCard.sql": sqlAddcardSql,
 	"sql/addCardHistorical.sql": sqlAddcardhistoricalSql,
 	"sql/addCollection.sql": sqlAddcollectionSql,
+	"sql/addDeadLetter.sql": sqlAdddeadletterSql,
+	"sql/addEvent.sql": sqlAddeventSql,
 	"sql/addReset.sql": sqlAddresetSql,
 	"sql/addSession.sql": sqlAddsessionSql,
 	"sql/addUser.sql": sqlAdduserSql,
-	"sql/getAllResets.sql": sqlGetallresetsSql,
+	"sql/addWebhook.sql": sqlAddwebhookSql,
+	"sql/archiveUserHistory.sql": sqlArchiveuserhistorySql,
+	"sql/bumpCollectionVersion.sql": sqlBumpcollectionversionSql,
+	"sql/bumpSessionsVersion.sql": sqlBumpsessionsversionSql,
+	"sql/changeEmail.sql": sqlChangeemailSql,
+	"sql/clearLoginFailures.sql": sqlClearloginfailuresSql,
+	"sql/consumeReset.sql": sqlConsumeresetSql,
+	"sql/countActiveSessions.sql": sqlCountactivesessionsSql,
+	"sql/countActiveWebhooks.sql": sqlCountactivewebhooksSql,
+	"sql/countCollections.sql": sqlCountcollectionsSql,
+	"sql/countEmailUsers.sql": sqlCountemailusersSql,
+	"sql/countTradeReversals.sql": sqlCounttradereversalsSql,
+	"sql/disableUserWebhooks.sql": sqlDisableuserwebhooksSql,
+	"sql/extendSession.sql": sqlExtendsessionSql,
+	"sql/forgetStripeEvent.sql": sqlForgetstripeeventSql,
+	"sql/getAlerts.sql": sqlGetalertsSql,
+	"sql/getAllAlerts.sql": sqlGetallalertsSql,
 	"sql/getCard.sql": sqlGetcardSql,
 	"sql/getCollectionContents.sql": sqlGetcollectioncontentsSql,
 	"sql/getCollectionHistory.sql": sqlGetcollectionhistorySql,
 	"sql/getCollectionList.sql": sqlGetcollectionlistSql,
 	"sql/getCollectionMeta.sql": sqlGetcollectionmetaSql,
+	"sql/getCollectionPage.sql": sqlGetcollectionpageSql,
+	"sql/getDeadLetter.sql": sqlGetdeadletterSql,
+	"sql/getDeadLetters.sql": sqlGetdeadlettersSql,
+	"sql/getEvents.sql": sqlGeteventsSql,
+	"sql/getFootprint.sql": sqlGetfootprintSql,
+	"sql/getPendingEmail.sql": sqlGetpendingemailSql,
+	"sql/getRefreshableSessions.sql": sqlGetrefreshablesessionsSql,
 	"sql/getReset.sql": sqlGetresetSql,
 	"sql/getSessions.sql": sqlGetsessionsSql,
+	"sql/getSessionsVersion.sql": sqlGetsessionsversionSql,
 	"sql/getSub.sql": sqlGetsubSql,
+	"sql/getSubByCustomer.sql": sqlGetsubbycustomerSql,
+	"sql/getTrade.sql": sqlGettradeSql,
+	"sql/getTradeHistory.sql": sqlGettradehistorySql,
 	"sql/getUser.sql": sqlGetuserSql,
+	"sql/getWebhooks.sql": sqlGetwebhooksSql,
+	"sql/listSessions.sql": sqlListsessionsSql,
+	"sql/lockCollection.sql": sqlLockcollectionSql,
+	"sql/lockEmail.sql": sqlLockemailSql,
+	"sql/lockSub.sql": sqlLocksubSql,
+	"sql/markAlertFired.sql": sqlMarkalertfiredSql,
+	"sql/markResetSent.sql": sqlMarkresetsentSql,
 	"sql/modSub.sql": sqlModsubSql,
+	"sql/recordDeadLetterFailure.sql": sqlRecorddeadletterfailureSql,
+	"sql/recordLoginFailure.sql": sqlRecordloginfailureSql,
+	"sql/recordStripeEvent.sql": sqlRecordstripeeventSql,
+	"sql/recordWebhookFailure.sql": sqlRecordwebhookfailureSql,
+	"sql/recordWebhookSuccess.sql": sqlRecordwebhooksuccessSql,
+	"sql/removeAlert.sql": sqlRemovealertSql,
+	"sql/removeAllSessions.sql": sqlRemoveallsessionsSql,
+	"sql/removeCollection.sql": sqlRemovecollectionSql,
+	"sql/removeCollectionContents.sql": sqlRemovecollectioncontentsSql,
+	"sql/removeDeadLetter.sql": sqlRemovedeadletterSql,
+	"sql/removeOtherSessions.sql": sqlRemoveothersessionsSql,
 	"sql/removeSession.sql": sqlRemovesessionSql,
+	"sql/removeUserCollections.sql": sqlRemoveusercollectionsSql,
+	"sql/removeUserContents.sql": sqlRemoveusercontentsSql,
+	"sql/removeUserEvents.sql": sqlRemoveusereventsSql,
+	"sql/removeUserResets.sql": sqlRemoveuserresetsSql,
+	"sql/renameCollection.sql": sqlRenamecollectionSql,
+	"sql/renameCollectionHistory.sql": sqlRenamecollectionhistorySql,
+	"sql/revokeSession.sql": sqlRevokesessionSql,
+	"sql/scrubUser.sql": sqlScrubuserSql,
+	"sql/setCollectionLock.sql": sqlSetcollectionlockSql,
 	"sql/setCollectionPermissions.sql": sqlSetcollectionpermissionsSql,
+	"sql/setCollectionTags.sql": sqlSetcollectiontagsSql,
+	"sql/setCollectionValuation.sql": sqlSetcollectionvaluationSql,
+	"sql/setCollectionsOverLimit.sql": sqlSetcollectionsoverlimitSql,
+	"sql/setEmailChange.sql": sqlSetemailchangeSql,
+	"sql/setForceRehash.sql": sqlSetforcerehashSql,
+	"sql/setLoginLockout.sql": sqlSetloginlockoutSql,
 	"sql/setMaxCollections.sql": sqlSetmaxcollectionsSql,
 	"sql/setPassword.sql": sqlSetpasswordSql,
+	"sql/setPreferences.sql": sqlSetpreferencesSql,
 	"sql/setSubEffects.sql": sqlSetsubeffectsSql,
+	"sql/setSubInterval.sql": sqlSetsubintervalSql,
+	"sql/setVerifyToken.sql": sqlSetverifytokenSql,
+	"sql/touchSession.sql": sqlTouchsessionSql,
+	"sql/trimEvents.sql": sqlTrimeventsSql,
+	"sql/verifyEmail.sql": sqlVerifyemailSql,
+	"sql/waivePlanCooldown.sql": sqlWaiveplancooldownSql,
 }
 
 // AssetDir returns the file names below a certain
@@ -647,19 +2187,57 @@ type bintree struct {
 }
 var _bintree = &bintree{nil, map[string]*bintree{
 	"sql": &bintree{nil, map[string]*bintree{
+		"addAlert.sql": &bintree{sqlAddalertSql, map[string]*bintree{
+		}},
 		"addCard.sql": &bintree{sqlAddcardSql, map[string]*bintree{
 		}},
 		"addCardHistorical.sql": &bintree{sqlAddcardhistoricalSql, map[string]*bintree{
 		}},
 		"addCollection.sql": &bintree{sqlAddcollectionSql, map[string]*bintree{
 		}},
+		"addDeadLetter.sql": &bintree{sqlAdddeadletterSql, map[string]*bintree{
+		}},
+		"addEvent.sql": &bintree{sqlAddeventSql, map[string]*bintree{
+		}},
 		"addReset.sql": &bintree{sqlAddresetSql, map[string]*bintree{
 		}},
 		"addSession.sql": &bintree{sqlAddsessionSql, map[string]*bintree{
 		}},
 		"addUser.sql": &bintree{sqlAdduserSql, map[string]*bintree{
 		}},
-		"getAllResets.sql": &bintree{sqlGetallresetsSql, map[string]*bintree{
+		"addWebhook.sql": &bintree{sqlAddwebhookSql, map[string]*bintree{
+		}},
+		"archiveUserHistory.sql": &bintree{sqlArchiveuserhistorySql, map[string]*bintree{
+		}},
+		"bumpCollectionVersion.sql": &bintree{sqlBumpcollectionversionSql, map[string]*bintree{
+		}},
+		"bumpSessionsVersion.sql": &bintree{sqlBumpsessionsversionSql, map[string]*bintree{
+		}},
+		"changeEmail.sql": &bintree{sqlChangeemailSql, map[string]*bintree{
+		}},
+		"clearLoginFailures.sql": &bintree{sqlClearloginfailuresSql, map[string]*bintree{
+		}},
+		"consumeReset.sql": &bintree{sqlConsumeresetSql, map[string]*bintree{
+		}},
+		"countActiveSessions.sql": &bintree{sqlCountactivesessionsSql, map[string]*bintree{
+		}},
+		"countActiveWebhooks.sql": &bintree{sqlCountactivewebhooksSql, map[string]*bintree{
+		}},
+		"countCollections.sql": &bintree{sqlCountcollectionsSql, map[string]*bintree{
+		}},
+		"countEmailUsers.sql": &bintree{sqlCountemailusersSql, map[string]*bintree{
+		}},
+		"countTradeReversals.sql": &bintree{sqlCounttradereversalsSql, map[string]*bintree{
+		}},
+		"disableUserWebhooks.sql": &bintree{sqlDisableuserwebhooksSql, map[string]*bintree{
+		}},
+		"extendSession.sql": &bintree{sqlExtendsessionSql, map[string]*bintree{
+		}},
+		"forgetStripeEvent.sql": &bintree{sqlForgetstripeeventSql, map[string]*bintree{
+		}},
+		"getAlerts.sql": &bintree{sqlGetalertsSql, map[string]*bintree{
+		}},
+		"getAllAlerts.sql": &bintree{sqlGetallalertsSql, map[string]*bintree{
 		}},
 		"getCard.sql": &bintree{sqlGetcardSql, map[string]*bintree{
 		}},
@@ -671,26 +2249,128 @@ var _bintree = &bintree{nil, map[string]*bintree{
 		}},
 		"getCollectionMeta.sql": &bintree{sqlGetcollectionmetaSql, map[string]*bintree{
 		}},
+		"getCollectionPage.sql": &bintree{sqlGetcollectionpageSql, map[string]*bintree{
+		}},
+		"getDeadLetter.sql": &bintree{sqlGetdeadletterSql, map[string]*bintree{
+		}},
+		"getDeadLetters.sql": &bintree{sqlGetdeadlettersSql, map[string]*bintree{
+		}},
+		"getEvents.sql": &bintree{sqlGeteventsSql, map[string]*bintree{
+		}},
+		"getFootprint.sql": &bintree{sqlGetfootprintSql, map[string]*bintree{
+		}},
+		"getPendingEmail.sql": &bintree{sqlGetpendingemailSql, map[string]*bintree{
+		}},
+		"getRefreshableSessions.sql": &bintree{sqlGetrefreshablesessionsSql, map[string]*bintree{
+		}},
 		"getReset.sql": &bintree{sqlGetresetSql, map[string]*bintree{
 		}},
 		"getSessions.sql": &bintree{sqlGetsessionsSql, map[string]*bintree{
 		}},
+		"getSessionsVersion.sql": &bintree{sqlGetsessionsversionSql, map[string]*bintree{
+		}},
 		"getSub.sql": &bintree{sqlGetsubSql, map[string]*bintree{
 		}},
+		"getSubByCustomer.sql": &bintree{sqlGetsubbycustomerSql, map[string]*bintree{
+		}},
+		"getTrade.sql": &bintree{sqlGettradeSql, map[string]*bintree{
+		}},
+		"getTradeHistory.sql": &bintree{sqlGettradehistorySql, map[string]*bintree{
+		}},
 		"getUser.sql": &bintree{sqlGetuserSql, map[string]*bintree{
 		}},
+		"getWebhooks.sql": &bintree{sqlGetwebhooksSql, map[string]*bintree{
+		}},
+		"listSessions.sql": &bintree{sqlListsessionsSql, map[string]*bintree{
+		}},
+		"lockCollection.sql": &bintree{sqlLockcollectionSql, map[string]*bintree{
+		}},
+		"lockEmail.sql": &bintree{sqlLockemailSql, map[string]*bintree{
+		}},
+		"lockSub.sql": &bintree{sqlLocksubSql, map[string]*bintree{
+		}},
+		"markAlertFired.sql": &bintree{sqlMarkalertfiredSql, map[string]*bintree{
+		}},
+		"markResetSent.sql": &bintree{sqlMarkresetsentSql, map[string]*bintree{
+		}},
 		"modSub.sql": &bintree{sqlModsubSql, map[string]*bintree{
 		}},
+		"recordDeadLetterFailure.sql": &bintree{sqlRecorddeadletterfailureSql, map[string]*bintree{
+		}},
+		"recordLoginFailure.sql": &bintree{sqlRecordloginfailureSql, map[string]*bintree{
+		}},
+		"recordStripeEvent.sql": &bintree{sqlRecordstripeeventSql, map[string]*bintree{
+		}},
+		"recordWebhookFailure.sql": &bintree{sqlRecordwebhookfailureSql, map[string]*bintree{
+		}},
+		"recordWebhookSuccess.sql": &bintree{sqlRecordwebhooksuccessSql, map[string]*bintree{
+		}},
+		"removeAlert.sql": &bintree{sqlRemovealertSql, map[string]*bintree{
+		}},
+		"removeAllSessions.sql": &bintree{sqlRemoveallsessionsSql, map[string]*bintree{
+		}},
+		"removeCollection.sql": &bintree{sqlRemovecollectionSql, map[string]*bintree{
+		}},
+		"removeCollectionContents.sql": &bintree{sqlRemovecollectioncontentsSql, map[string]*bintree{
+		}},
+		"removeDeadLetter.sql": &bintree{sqlRemovedeadletterSql, map[string]*bintree{
+		}},
+		"removeOtherSessions.sql": &bintree{sqlRemoveothersessionsSql, map[string]*bintree{
+		}},
 		"removeSession.sql": &bintree{sqlRemovesessionSql, map[string]*bintree{
 		}},
+		"removeUserCollections.sql": &bintree{sqlRemoveusercollectionsSql, map[string]*bintree{
+		}},
+		"removeUserContents.sql": &bintree{sqlRemoveusercontentsSql, map[string]*bintree{
+		}},
+		"removeUserEvents.sql": &bintree{sqlRemoveusereventsSql, map[string]*bintree{
+		}},
+		"removeUserResets.sql": &bintree{sqlRemoveuserresetsSql, map[string]*bintree{
+		}},
+		"renameCollection.sql": &bintree{sqlRenamecollectionSql, map[string]*bintree{
+		}},
+		"renameCollectionHistory.sql": &bintree{sqlRenamecollectionhistorySql, map[string]*bintree{
+		}},
+		"revokeSession.sql": &bintree{sqlRevokesessionSql, map[string]*bintree{
+		}},
+		"scrubUser.sql": &bintree{sqlScrubuserSql, map[string]*bintree{
+		}},
+		"setCollectionLock.sql": &bintree{sqlSetcollectionlockSql, map[string]*bintree{
+		}},
 		"setCollectionPermissions.sql": &bintree{sqlSetcollectionpermissionsSql, map[string]*bintree{
 		}},
+		"setCollectionTags.sql": &bintree{sqlSetcollectiontagsSql, map[string]*bintree{
+		}},
+		"setCollectionValuation.sql": &bintree{sqlSetcollectionvaluationSql, map[string]*bintree{
+		}},
+		"setCollectionsOverLimit.sql": &bintree{sqlSetcollectionsoverlimitSql, map[string]*bintree{
+		}},
+		"setEmailChange.sql": &bintree{sqlSetemailchangeSql, map[string]*bintree{
+		}},
+		"setForceRehash.sql": &bintree{sqlSetforcerehashSql, map[string]*bintree{
+		}},
+		"setLoginLockout.sql": &bintree{sqlSetloginlockoutSql, map[string]*bintree{
+		}},
 		"setMaxCollections.sql": &bintree{sqlSetmaxcollectionsSql, map[string]*bintree{
 		}},
 		"setPassword.sql": &bintree{sqlSetpasswordSql, map[string]*bintree{
 		}},
+		"setPreferences.sql": &bintree{sqlSetpreferencesSql, map[string]*bintree{
+		}},
 		"setSubEffects.sql": &bintree{sqlSetsubeffectsSql, map[string]*bintree{
 		}},
+		"setSubInterval.sql": &bintree{sqlSetsubintervalSql, map[string]*bintree{
+		}},
+		"setVerifyToken.sql": &bintree{sqlSetverifytokenSql, map[string]*bintree{
+		}},
+		"touchSession.sql": &bintree{sqlTouchsessionSql, map[string]*bintree{
+		}},
+		"trimEvents.sql": &bintree{sqlTrimeventsSql, map[string]*bintree{
+		}},
+		"verifyEmail.sql": &bintree{sqlVerifyemailSql, map[string]*bintree{
+		}},
+		"waivePlanCooldown.sql": &bintree{sqlWaiveplancooldownSql, map[string]*bintree{
+		}},
 	}},
 }}
 
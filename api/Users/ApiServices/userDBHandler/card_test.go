@@ -4,8 +4,11 @@ import(
 
 	"testing"
 
+	"sync"
 	"time"
 
+	"github.com/jackc/pgx"
+
 )
 
 var CardsPerCollection int = 10
@@ -70,7 +73,7 @@ func TestCardsContents(t *testing.T) {
 
 	cards:= randomCards(1)
 
-	err = AddCards(pool, key, user, collection, cards)
+	err = AddCards(pool, key, user, collection, cards, 0)
 	if err!= nil {
 		t.Fatal(err)
 	}
@@ -82,7 +85,7 @@ func TestCardsContents(t *testing.T) {
 	for _, transition:= range transitions{
 		templateCard.Quantity = int32(transition)
 		templateCard.LastUpdate = randomTime()
-		err = AddCards(pool, key, user, collection, []Card{templateCard})
+		err = AddCards(pool, key, user, collection, []Card{templateCard}, 0)
 		if err!= nil {
 			t.Fatal(err)
 		}
@@ -115,6 +118,158 @@ func TestCardsContents(t *testing.T) {
 	t.Fatal("Couldn't find card")
 }
 
+// Locks a collection and ensures trades are rejected until it is
+// unlocked while its contents remain readable.
+func TestCardsLocked(t *testing.T) {
+	t.Parallel()
+
+	user:= randString(int(randByte()) % 31)
+	key, err:= AddUser(pool, user, "bar", "foo")
+	if err!=nil {
+		t.Fatal("failed to add user ", err)
+	}
+
+	time.Sleep(stepSleepTime)
+
+	collection:= randString(int(randByte()))
+	err = AddCollection(pool, key, user, collection)
+	if err!=nil {
+		t.Fatal(err)
+	}
+
+	err = AddCards(pool, key, user, collection, randomCards(1), 0)
+	if err!=nil {
+		t.Fatal(err)
+	}
+
+	err = SetCollectionLock(pool, key, user, collection, true)
+	if err!=nil {
+		t.Fatal("failed to lock collection", err)
+	}
+
+	time.Sleep(stepSleepTime)
+
+	err = AddCards(pool, key, user, collection, randomCards(1), 0)
+	if err != ErrCollectionLocked {
+		t.Fatal("trade added to locked collection", err)
+	}
+
+	// A trade which read the collection before it was locked must
+	// still be refused when it commits
+	stale:= &Collection{Owner: user, Name: collection}
+	err = withTx(pool, func(tx *pgx.Tx) error {
+		return claimWritable(tx, stale)
+	})
+	if err != ErrCollectionLocked {
+		t.Fatal("trade claimed a locked collection", err)
+	}
+
+	acquired, err:= GetCollectionContents(pool, key, user, collection)
+	if err!=nil || len(acquired) != 1 {
+		t.Fatal("locked collection could not be read", err)
+	}
+
+	err = SetCollectionLock(pool, key, user, collection, false)
+	if err!=nil {
+		t.Fatal("failed to unlock collection", err)
+	}
+
+	time.Sleep(stepSleepTime)
+
+	err = AddCards(pool, key, user, collection, randomCards(1), 0)
+	if err!=nil {
+		t.Fatal("trade rejected from unlocked collection", err)
+	}
+
+}
+
+// Copies a trade into a second collection and back into its own,
+// ensuring each copy lands as a distinct trade.
+func TestCopyTrade(t *testing.T) {
+	t.Parallel()
+
+	user:= randString(int(randByte()) % 31)
+	key, err:= AddUser(pool, user, "bar", "foo")
+	if err!=nil {
+		t.Fatal("failed to add user ", err)
+	}
+
+	time.Sleep(stepSleepTime)
+
+	src:= randString(int(randByte()))
+	dst:= randString(int(randByte()))
+	for _, collection:= range []string{src, dst}{
+		err = AddCollection(pool, key, user, collection)
+		if err!=nil {
+			t.Fatal(err)
+		}
+	}
+
+	cards:= randomCards(3)
+	err = AddCards(pool, key, user, src, cards, 0)
+	if err!=nil {
+		t.Fatal(err)
+	}
+
+	history, err:= GetCollectionHistory(pool, key, user, src)
+	if err!=nil || len(history) != len(cards) {
+		t.Fatal("failed to acquire source history", err)
+	}
+	tradeID:= history[0].TradeID
+	for _, c:= range history{
+		if c.TradeID == "" || c.TradeID != tradeID {
+			t.Fatal("cards added together do not share a trade", history)
+		}
+	}
+
+	copied, err:= CopyTrade(pool, key, user, src, dst, tradeID)
+	if err!=nil {
+		t.Fatal("failed to copy trade", err)
+	}
+	if copied.ID == tradeID || copied.Collection != dst ||
+		len(copied.Cards) != len(cards) {
+		t.Fatal("copy is not a fresh trade in the destination", copied)
+	}
+
+	acquired, err:= GetCollectionHistory(pool, key, user, dst)
+	if err!=nil || len(acquired) != len(cards) {
+		t.Fatal("copy missing from destination", err)
+	}
+	for _, c:= range acquired{
+		if c.TradeID != copied.ID {
+			t.Fatal("destination row not from the copy", c)
+		}
+	}
+
+	_, err = CopyTrade(pool, key, user, src, src, tradeID)
+	if err!=nil {
+		t.Fatal("failed to copy trade into its own collection", err)
+	}
+	history, err = GetCollectionHistory(pool, key, user, src)
+	if err!=nil || len(history) != 2 * len(cards) {
+		t.Fatal("trade not duplicated in its own collection", err)
+	}
+
+	_, err = CopyTrade(pool, key, user, src, dst, "foo")
+	if err != ErrNoSuchTrade {
+		t.Fatal("missing trade copied", err)
+	}
+
+	err = SetCollectionLock(pool, key, user, dst, true)
+	if err!=nil {
+		t.Fatal("failed to lock collection", err)
+	}
+	_, err = CopyTrade(pool, key, user, src, dst, tradeID)
+	if err != ErrCollectionLocked {
+		t.Fatal("trade copied into locked collection", err)
+	}
+
+	_, err = CopyTrade(pool, []byte("baz"), user, src, dst, tradeID)
+	if err != ErrBadSession {
+		t.Fatal("trade copied with bad session", err)
+	}
+
+}
 
 func addSomeCards(t *testing.T) (users []string, keys [][]byte,
 	collections[]string, contents [][]Card) {
@@ -151,7 +306,7 @@ func addSomeCards(t *testing.T) (users []string, keys [][]byte,
 
 		// Add a bunch of cards to the collection
 		cards = randomCards(CardsPerCollection)
-		err = AddCards(pool, key, user, collection, cards)
+		err = AddCards(pool, key, user, collection, cards, 0)
 		if err!= nil {
 			t.Fatal(err)
 		}
@@ -163,4 +318,356 @@ func addSomeCards(t *testing.T) (users []string, keys [][]byte,
 	return
 
 	
-}
\ No newline at end of file
+}
+
+// Reverses a trade, ensuring contents net out while history keeps
+// both the trade and its reversal.
+func TestRemoveTrade(t *testing.T) {
+	t.Parallel()
+
+	user:= randString(int(randByte()) % 31)
+	key, err:= AddUser(pool, user, "bar", "foo")
+	if err!=nil {
+		t.Fatal("failed to add user ", err)
+	}
+
+	time.Sleep(stepSleepTime)
+
+	collection:= randString(int(randByte()))
+	err = AddCollection(pool, key, user, collection)
+	if err!=nil {
+		t.Fatal(err)
+	}
+
+	// Distinct qualities keep random cards from being combined
+	cards:= randomCards(2)
+	cards[0].Quality = "NM"
+	cards[1].Quality = "LP"
+	err = AddCards(pool, key, user, collection, cards, 0)
+	if err!=nil {
+		t.Fatal(err)
+	}
+
+	history, err:= GetCollectionHistory(pool, key, user, collection)
+	if err!=nil || len(history) != len(cards) {
+		t.Fatal("failed to acquire history", err)
+	}
+	tradeID:= history[0].TradeID
+
+	reversal, err:= RemoveTrade(pool, key, user, collection, tradeID)
+	if err!=nil {
+		t.Fatal("failed to remove trade", err)
+	}
+	if reversal.ID == tradeID || reversal.Reverses != tradeID ||
+		len(reversal.Cards) != len(cards) {
+		t.Fatal("reversal is not a fresh trade undoing the original", reversal)
+	}
+
+	contents, err:= GetCollectionContents(pool, key, user, collection)
+	if err!=nil {
+		t.Fatal(err)
+	}
+	for _, c:= range contents{
+		if c.Quantity != 0 {
+			t.Fatal("contents do not reflect the reversal", contents)
+		}
+	}
+
+	history, err = GetCollectionHistory(pool, key, user, collection)
+	if err!=nil || len(history) != 2 * len(cards) {
+		t.Fatal("history does not record the reversal", history, err)
+	}
+	reversed:= 0
+	for _, c:= range history{
+		if c.Reverses == tradeID && c.TradeID == reversal.ID {
+			reversed++
+		}
+	}
+	if reversed != len(cards) {
+		t.Fatal("reversal rows do not name the trade they undo", history)
+	}
+
+	_, err = RemoveTrade(pool, key, user, collection, tradeID)
+	if err != ErrTradeReversed {
+		t.Fatal("trade reversed twice", err)
+	}
+
+	// Undoing the reversal restores what was traded
+	_, err = RemoveTrade(pool, key, user, collection, reversal.ID)
+	if err!=nil {
+		t.Fatal("failed to reverse a reversal", err)
+	}
+	contents, err = GetCollectionContents(pool, key, user, collection)
+	if err!=nil {
+		t.Fatal(err)
+	}
+	traded:= make(map[string]int32)
+	for _, c:= range cards{
+		traded[c.Quality] = c.Quantity
+	}
+	for _, c:= range contents{
+		if c.Quantity != traded[c.Quality] {
+			t.Fatal("contents not restored by reversing the reversal", contents)
+		}
+	}
+
+	_, err = RemoveTrade(pool, key, user, collection, "foo")
+	if err != ErrNoSuchTrade {
+		t.Fatal("missing trade removed", err)
+	}
+
+}
+
+// Trades should come back whole and in the order they were made.
+func TestTradeHistory(t *testing.T) {
+	t.Parallel()
+
+	user:= randString(int(randByte()) % 31)
+	key, err:= AddUser(pool, user, "bar", "foo")
+	if err!=nil {
+		t.Fatal("failed to add user ", err)
+	}
+
+	time.Sleep(stepSleepTime)
+
+	collection:= randString(int(randByte()))
+	err = AddCollection(pool, key, user, collection)
+	if err!=nil {
+		t.Fatal(err)
+	}
+
+	sizes:= []int{3, 1}
+	for _, size:= range sizes{
+		err = AddCards(pool, key, user, collection, randomCards(size), 0)
+		if err!=nil {
+			t.Fatal(err)
+		}
+		time.Sleep(stepSleepTime)
+	}
+
+	trades, err:= GetTradeHistory(pool, key, user, collection)
+	if err!=nil || len(trades) != len(sizes) {
+		t.Fatal("failed to acquire trade history", trades, err)
+	}
+	for i, size:= range sizes{
+		if len(trades[i].Cards) != size {
+			t.Fatal("trade split or merged", trades)
+		}
+	}
+
+	reversal, err:= RemoveTrade(pool, key, user, collection, trades[0].ID)
+	if err!=nil {
+		t.Fatal(err)
+	}
+
+	trades, err = GetTradeHistory(pool, key, user, collection)
+	if err!=nil || len(trades) != len(sizes) + 1 {
+		t.Fatal("reversal missing from trade history", trades, err)
+	}
+	for i:= 1; i < len(trades); i++ {
+		if trades[i].Time.Before(trades[i - 1].Time) {
+			t.Fatal("trades out of order", trades)
+		}
+	}
+	last:= trades[len(trades) - 1]
+	if last.ID != reversal.ID || last.Reverses != trades[0].ID {
+		t.Fatal("reversal not last or not linked to its trade", last)
+	}
+
+	_, err = GetTradeHistory(pool, []byte("baz"), user, collection)
+	if err != ErrBadSession {
+		t.Fatal("trade history acquired with bad session", err)
+	}
+
+}
+
+// Each trade of a bulk add should land as its own trade.
+func TestAddTrades(t *testing.T) {
+	t.Parallel()
+
+	user:= randString(int(randByte()) % 31)
+	key, err:= AddUser(pool, user, "bar", "foo")
+	if err!=nil {
+		t.Fatal("failed to add user ", err)
+	}
+
+	time.Sleep(stepSleepTime)
+
+	collection:= randString(int(randByte()))
+	err = AddCollection(pool, key, user, collection)
+	if err!=nil {
+		t.Fatal(err)
+	}
+
+	trades:= [][]Card{randomCards(2), randomCards(1), randomCards(3)}
+	err = AddTrades(pool, key, user, collection, trades, 0)
+	if err!=nil {
+		t.Fatal("failed to add trades", err)
+	}
+
+	time.Sleep(stepSleepTime)
+
+	history, err:= GetTradeHistory(pool, key, user, collection)
+	if err!=nil {
+		t.Fatal(err)
+	}
+	if len(history) != len(trades) {
+		t.Fatal("trades not kept distinct", len(history))
+	}
+
+	err = AddTrades(pool, []byte("baz"), user, collection, trades, 0)
+	if err == nil {
+		t.Fatal("trades added without a session")
+	}
+
+}
+
+// Concurrent trades on one collection may conflict but retrying them
+// must land every one, none may be lost to another.
+func TestConcurrentTrades(t *testing.T) {
+	t.Parallel()
+
+	user:= randString(int(randByte()) % 31)
+	key, err:= AddUser(pool, user, "bar", "foo")
+	if err!=nil {
+		t.Fatal("failed to add user ", err)
+	}
+
+	time.Sleep(stepSleepTime)
+
+	collection:= randString(int(randByte()))
+	err = AddCollection(pool, key, user, collection)
+	if err!=nil {
+		t.Fatal(err)
+	}
+
+	const trades = 8
+	var wg sync.WaitGroup
+	failures:= make(chan error, trades)
+	for i:= 0; i < trades; i++{
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			var err error
+			for attempt:= 0; attempt < 10 * trades; attempt++{
+				err = AddCards(pool, key, user, collection, randomCards(1), 0)
+				if err != ErrCollectionConflict {
+					break
+				}
+			}
+			if err!=nil {
+				failures<- err
+			}
+		}()
+	}
+	wg.Wait()
+	close(failures)
+	for err:= range failures{
+		t.Fatal("concurrent trade failed", err)
+	}
+
+	history, err:= GetTradeHistory(pool, key, user, collection)
+	if err!=nil {
+		t.Fatal(err)
+	}
+	if len(history) != trades {
+		t.Fatal("concurrent trades lost", len(history))
+	}
+
+	meta, err:= GetCollectionMeta(pool, key, user, collection)
+	if err!=nil || meta.Version != 1 + trades {
+		t.Fatal("version didn't follow each trade", meta, err)
+	}
+
+}
+
+// Trades made against a version the collection has moved on from
+// must be refused.
+func TestTradeStaleVersion(t *testing.T) {
+	t.Parallel()
+
+	user:= randString(int(randByte()) % 31)
+	key, err:= AddUser(pool, user, "bar", "foo")
+	if err!=nil {
+		t.Fatal("failed to add user ", err)
+	}
+
+	time.Sleep(stepSleepTime)
+
+	collection:= randString(int(randByte()))
+	err = AddCollection(pool, key, user, collection)
+	if err!=nil {
+		t.Fatal(err)
+	}
+
+	meta, err:= GetCollectionMeta(pool, key, user, collection)
+	if err!=nil {
+		t.Fatal(err)
+	}
+
+	err = AddCards(pool, key, user, collection, randomCards(1), meta.Version)
+	if err!=nil {
+		t.Fatal("trade against the current version refused", err)
+	}
+
+	err = AddCards(pool, key, user, collection, randomCards(1), meta.Version)
+	if err != ErrCollectionConflict {
+		t.Fatal("trade against a stale version accepted", err)
+	}
+
+	err = AddTrades(pool, key, user, collection,
+		[][]Card{randomCards(1)}, meta.Version)
+	if err != ErrCollectionConflict {
+		t.Fatal("trades against a stale version accepted", err)
+	}
+
+	history, err:= GetTradeHistory(pool, key, user, collection)
+	if err!=nil || len(history) != 1 {
+		t.Fatal("stale trades changed the collection", history, err)
+	}
+
+}
+
+// Not parallel as it replaces KnownCard for the duration.
+func TestAddCardsUnknownCard(t *testing.T) {
+
+	user:= randString(int(randByte()) % 31)
+	key, err:= AddUser(pool, user, "bar", "foo")
+	if err!=nil {
+		t.Fatal("failed to add user ", err)
+	}
+
+	time.Sleep(stepSleepTime)
+
+	collection:= randString(int(randByte()))
+	err = AddCollection(pool, key, user, collection)
+	if err!=nil {
+		t.Fatal(err)
+	}
+
+	cards:= randomCards(3)
+	unknown:= cards[1]
+
+	KnownCard = func(name, set string) bool {
+		return name != unknown.Name
+	}
+	defer func() { KnownCard = nil }()
+
+	err = AddCards(pool, key, user, collection, cards, 0)
+	badCard, ok:= err.(BadCardError)
+	if !ok || badCard.Name != unknown.Name {
+		t.Fatal("unknown card not refused", err)
+	}
+
+	time.Sleep(stepSleepTime)
+
+	contents, err:= GetCollectionContents(pool, key, user, collection)
+	if err!=nil {
+		t.Fatal(err)
+	}
+	if len(contents) != 0 {
+		t.Fatal("trade partially added", len(contents))
+	}
+
+}
@@ -5,6 +5,8 @@ import(
 	"fmt"
 	"time"
 
+	"encoding/hex"
+
 	"github.com/jackc/pgx"
 
 )
@@ -13,6 +15,70 @@ type Card struct{
 	Name, Set, Quality, Comment, Lang string
 	Quantity int32
 	LastUpdate time.Time
+
+	// Only present on history, identifies the trade this row belongs to
+	TradeID string
+	// Only present on history, the trade this row undoes if any
+	Reverses string
+}
+
+// Every card added to a collection together.
+type Trade struct{
+	ID, Collection string
+	Time time.Time
+	Cards []Card
+	// Set on reversals, the trade they undo
+	Reverses string
+}
+
+const tradeIDLength int = 12
+
+// Determines if a card, by its proper name, was printed in a set.
+//
+// Set at startup to the card maps so no trade can hold an unknown
+// card however its caller validated it. Every card is accepted
+// while nil.
+var KnownCard func(name, set string) bool
+
+// Returned when a trade holds a card that is unknown or was never
+// printed in the set given.
+type BadCardError struct{
+	Name, Set string
+}
+
+func (e BadCardError) Error() string {
+	return fmt.Sprintf("invalid trade contents, %v is not a card in %v",
+		e.Name, e.Set)
+}
+
+// Ensures every card of a trade is known, returning a BadCardError
+// for the first which is not.
+func checkCards(cards []Card) error {
+
+	if KnownCard == nil {
+		return nil
+	}
+
+	for _, aCard:= range cards{
+		if !KnownCard(aCard.Name, aCard.Set) {
+			return BadCardError{aCard.Name, aCard.Set}
+		}
+	}
+
+	return nil
+
+}
+
+// Acquires a fresh, opaque ID for a trade
+func newTradeID() (string, error) {
+
+	raw, err:= getArrayOfRandBytes(tradeIDLength)
+	if err!=nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(raw), nil
+
 }
 
 // Safely adds a card using a transaction to apply to
@@ -22,14 +88,6 @@ func AddCard(pool *pgx.ConnPool, sessionKey []byte,
 	Name, Set, Comment, Quality, Lang string,
 	Quantity int32, LastUpdate time.Time) error {
 
-	// Start the transaction
-	tx, err:= pool.Begin()
-	if err!=nil {
-		return fmt.Errorf("failed to grab a transaction,", err)
-	}
-	// Make sure we can safely exit at any time
-	defer tx.Rollback()
-
 	// Make sure the user's collection exists
 	coll, err:= GetCollectionMeta(pool, sessionKey, user, collection)
 	if err!=nil {
@@ -38,21 +96,40 @@ func AddCard(pool *pgx.ConnPool, sessionKey []byte,
 	if coll.Name != collection {
 		return fmt.Errorf("no such collection exists")
 	}
+	err = coll.writable()
+	if err!=nil {
+		return err
+	}
 
+	err = checkCards([]Card{Card{Name: Name, Set: Set}})
+	if err!=nil {
+		return err
+	}
 
-	err = insertCard(tx,
-		user, collection,
-		Name, Set, Comment,
-		Quantity,
-		Quality, Lang,
-		LastUpdate)
+	tradeID, err:= newTradeID()
 	if err!=nil {
-		return fmt.Errorf("failed to add to history, ", err)
+		return err
 	}
 
-	tx.Commit()
+	return withTx(pool, func(tx *pgx.Tx) error {
+		err:= claimWritable(tx, coll)
+		if err!=nil {
+			return err
+		}
 
-	return nil
+		err = insertCard(tx,
+			user, collection,
+			Name, Set, Comment,
+			Quantity,
+			Lang, Quality,
+			LastUpdate, tradeID, "")
+		if err!=nil {
+			return fmt.Errorf("failed to add to history, %v", err)
+		}
+
+		return recordEvent(tx, user, EventTradesAdded,
+			collection, tradeDetail([]Card{Card{Name: Name, Quantity: Quantity}}))
+	})
 
 }
 
@@ -61,17 +138,52 @@ func AddCard(pool *pgx.ConnPool, sessionKey []byte,
 //
 // Inserting multiple cards per single transaction is a lot more
 // efficient and should be the aim.
+//
+// version is the collection version the trade was made against,
+// ErrCollectionConflict is returned if the collection has since moved
+// on. Zero accepts whichever version is current but still refuses a
+// change racing this one, so no concurrent trade is lost silently.
 func AddCards(pool *pgx.ConnPool, sessionKey []byte,
 	user, collection string,
-	cards []Card) error {
+	cards []Card, version int64) error {
 	
-	// Start the transaction
-	tx, err:= pool.Begin()
+	// Make sure the user's collection exists
+	coll, err:= GetCollectionMeta(pool, sessionKey, user, collection)
+	if err!=nil {
+		return fmt.Errorf("failed to ensure collection exists")
+	}
+	if coll.Name != collection {
+		return fmt.Errorf("no such collection exists")
+	}
+	err = coll.writable()
+	if err!=nil {
+		return err
+	}
+	err = coll.current(version)
 	if err!=nil {
-		return fmt.Errorf("failed to grab a transaction,", err)
+		return err
 	}
-	// Make sure we can safely exit at any time
-	defer tx.Rollback()
+
+	// Either every card lands or none do
+	return withTx(pool, func(tx *pgx.Tx) error {
+		err:= claimWritable(tx, coll)
+		if err!=nil {
+			return err
+		}
+
+		return addTrade(tx, user, collection, cards)
+	})
+
+}
+
+// Adds many trades to a collection at once, each as its own trade.
+//
+// Every trade lands or none do, so a failed import can simply be
+// retried as a whole. The whole import is a single change of version,
+// checked as AddCards does.
+func AddTrades(pool *pgx.ConnPool, sessionKey []byte,
+	user, collection string,
+	trades [][]Card, version int64) error {
 
 	// Make sure the user's collection exists
 	coll, err:= GetCollectionMeta(pool, sessionKey, user, collection)
@@ -81,31 +193,75 @@ func AddCards(pool *pgx.ConnPool, sessionKey []byte,
 	if coll.Name != collection {
 		return fmt.Errorf("no such collection exists")
 	}
+	err = coll.writable()
+	if err!=nil {
+		return err
+	}
+	err = coll.current(version)
+	if err!=nil {
+		return err
+	}
+
+	return withTx(pool, func(tx *pgx.Tx) error {
+		err:= claimWritable(tx, coll)
+		if err!=nil {
+			return err
+		}
+
+		for _, cards:= range trades{
+			err:= addTrade(tx, user, collection, cards)
+			if err!=nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+}
+
+// Inserts every card of a trade under a fresh trade ID using a
+// passed transaction, recording it in the user's events.
+//
+// Trades holding an unknown card receive a BadCardError.
+func addTrade(tx *pgx.Tx, user, collection string, cards []Card) error {
+
+	err:= checkCards(cards)
+	if err!=nil {
+		return err
+	}
+
+	tradeID, err:= newTradeID()
+	if err!=nil {
+		return err
+	}
 
 	for _, aCard:= range cards{
 
 		err:= insertCard(tx,
 						user, collection,
 						aCard.Name, aCard.Set, aCard.Comment,
-						aCard.Quantity, aCard.Lang, aCard.Quality, aCard.LastUpdate)
+						aCard.Quantity, aCard.Lang, aCard.Quality, aCard.LastUpdate,
+						tradeID, "")
 
 		if err!=nil {
-			return fmt.Errorf("failed to insert card", err)
+			return fmt.Errorf("failed to insert card, %v", err)
 		}
 	}
 
-	tx.Commit()
-
-	return nil
+	return recordEvent(tx, user, EventTradesAdded,
+		collection, tradeDetail(cards))
 
 }
 
 // Inserts a card into the db using a passed transaction
+//
+// reverses is the trade being undone, empty for ordinary trades.
 func insertCard(tx *pgx.Tx,
 	user, collection,
 	Name, Set, Comment string,
 	Quantity int32, Lang string,
-	Quality string, LastUpdate time.Time) error {
+	Quality string, LastUpdate time.Time, tradeID, reverses string) error {
 
 	var err error
 
@@ -124,7 +280,7 @@ func insertCard(tx *pgx.Tx,
 					user, collection,
 					Name, Set, Comment,
 					Quantity, Quality, Lang,
-					LastUpdate)
+					LastUpdate, tradeID, reverses)
 	if err!=nil {
 		return fmt.Errorf("failed to add to history, ", err)
 	}
@@ -159,7 +315,8 @@ func GetCollectionHistory(pool *pgx.ConnPool, sessionKey []byte,
 		c:= Card{}
 		err = rows.Scan(&c.Name, &c.Set,
 			&c.Quality, &c.Quantity,
-			&c.Comment, &c.Lang, &c.LastUpdate)
+			&c.Comment, &c.Lang, &c.LastUpdate,
+			&c.TradeID, &c.Reverses)
 		if err!=nil {
 			return nil, errorHandle(err, ScanError)
 		}
@@ -205,4 +362,278 @@ func GetCollectionContents(pool *pgx.ConnPool, sessionKey []byte,
 
 	return cards, nil
 	
-}
\ No newline at end of file
+}
+
+// Acquires every trade made to a specified user's collection, oldest
+// first, each stamped with when it was recorded.
+//
+// Rows predating trade IDs are grouped by when they were recorded
+// and so appear as trades with an empty ID.
+func GetTradeHistory(pool *pgx.ConnPool, sessionKey []byte,
+	user, collection string) ([]Trade, error) {
+
+	// Authenticate the request
+	if sessionKey != nil {
+		err:= SessionAuth(pool, user, sessionKey)
+		if err!=nil{
+			return nil, err
+		}
+	}
+
+	rows, err:= pool.Query("getTradeHistory", user, collection)
+	if err!=nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var trades []Trade
+	for rows.Next(){
+		c:= Card{}
+		var recorded time.Time
+		err = rows.Scan(&c.Name, &c.Set,
+			&c.Quality, &c.Quantity,
+			&c.Comment, &c.Lang, &c.LastUpdate,
+			&c.TradeID, &c.Reverses, &recorded)
+		if err!=nil {
+			return nil, errorHandle(err, ScanError)
+		}
+
+		// Rows of a trade are adjacent so only the last can match
+		last:= len(trades) - 1
+		if last < 0 || trades[last].ID != c.TradeID ||
+			!trades[last].Time.Equal(recorded) {
+			trades = append(trades, Trade{
+				ID: c.TradeID,
+				Collection: collection,
+				Time: recorded,
+				Reverses: c.Reverses,
+			})
+			last++
+		}
+
+		trades[last].Cards = append(trades[last].Cards, c)
+	}
+
+	return trades, nil
+
+}
+
+// Acquires every card of a single trade, returning ErrNoSuchTrade
+// when the collection holds no such trade.
+func getTrade(pool *pgx.ConnPool,
+	user, collection, tradeID string) ([]Card, error) {
+
+	rows, err:= pool.Query("getTrade", user, collection, tradeID)
+	if err!=nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var cards []Card
+	for rows.Next(){
+		c:= Card{}
+		err = rows.Scan(&c.Name, &c.Set,
+			&c.Quality, &c.Quantity,
+			&c.Comment, &c.Lang, &c.LastUpdate)
+		if err!=nil {
+			return nil, errorHandle(err, ScanError)
+		}
+
+		cards = append(cards, c)
+	}
+	if len(cards) == 0 {
+		return nil, ErrNoSuchTrade
+	}
+
+	return cards, nil
+
+}
+
+// Copies a single trade into another of the user's collections as
+// a fresh trade happening now.
+//
+// Copying into the source collection duplicates the trade there.
+func CopyTrade(pool *pgx.ConnPool, sessionKey []byte,
+	user, srcCollection, dstCollection, tradeID string) (*Trade, error) {
+
+	// Authenticates the request and ensures the source exists
+	_, err:= GetCollectionMeta(pool, sessionKey, user, srcCollection)
+	if err!=nil {
+		return nil, err
+	}
+
+	dst, err:= GetCollectionMeta(pool, nil, user, dstCollection)
+	if err!=nil {
+		return nil, err
+	}
+	err = dst.writable()
+	if err!=nil {
+		return nil, err
+	}
+
+	cards, err:= getTrade(pool, user, srcCollection, tradeID)
+	if err!=nil {
+		return nil, err
+	}
+
+	copyID, err:= newTradeID()
+	if err!=nil {
+		return nil, err
+	}
+
+	// Postgres keeps microseconds, matching the stored copy lets
+	// callers compare what we return with history.
+	now:= time.Now().Round(time.Microsecond)
+	for i:= range cards{
+		cards[i].LastUpdate = now
+		cards[i].TradeID = copyID
+	}
+
+	err = withTx(pool, func(tx *pgx.Tx) error {
+		err:= claimWritable(tx, dst)
+		if err!=nil {
+			return err
+		}
+
+		for _, aCard:= range cards{
+			err:= insertCard(tx,
+				user, dstCollection,
+				aCard.Name, aCard.Set, aCard.Comment,
+				aCard.Quantity, aCard.Lang, aCard.Quality, aCard.LastUpdate,
+				copyID, "")
+			if err!=nil {
+				return fmt.Errorf("failed to insert card, %v", err)
+			}
+		}
+
+		return recordEvent(tx, user, EventTradesAdded,
+			dstCollection, tradeDetail(cards))
+	})
+	if err!=nil {
+		return nil, err
+	}
+
+	return &Trade{
+		ID: copyID,
+		Collection: dstCollection,
+		Time: now,
+		Cards: cards,
+	}, nil
+
+}
+
+// Builds the cards undoing a trade, each happening at when.
+//
+// Rows of the trade sharing a card, set, quality and language are
+// combined as history may only hold one of each per moment.
+func reverseCards(cards []Card, when time.Time) []Card {
+
+	type cardKey struct{
+		Name, Set, Quality, Lang string
+	}
+
+	var reversed []Card
+	seen:= make(map[cardKey]int)
+	for _, c:= range cards{
+		key:= cardKey{c.Name, c.Set, c.Quality, c.Lang}
+
+		i, ok:= seen[key]
+		if !ok {
+			i = len(reversed)
+			seen[key] = i
+			reversed = append(reversed, Card{
+				Name: c.Name, Set: c.Set,
+				Quality: c.Quality, Lang: c.Lang,
+				LastUpdate: when,
+			})
+		}
+
+		reversed[i].Quantity-= c.Quantity
+		reversed[i].Comment = c.Comment
+	}
+
+	return reversed
+
+}
+
+// Undoes a single trade by adding its opposite as a fresh trade
+// happening now, which is returned.
+//
+// History keeps the original trade and the reversal records which
+// trade it undoes. Each trade may only be reversed once, further
+// attempts see ErrTradeReversed, though a reversal is a trade of its
+// own and may be reversed in turn.
+func RemoveTrade(pool *pgx.ConnPool, sessionKey []byte,
+	user, collection, tradeID string) (*Trade, error) {
+
+	// Authenticates and ensures the collection exists
+	meta, err:= GetCollectionMeta(pool, sessionKey, user, collection)
+	if err!=nil {
+		return nil, err
+	}
+	err = meta.writable()
+	if err!=nil {
+		return nil, err
+	}
+
+	cards, err:= getTrade(pool, user, collection, tradeID)
+	if err!=nil {
+		return nil, err
+	}
+
+	reversalID, err:= newTradeID()
+	if err!=nil {
+		return nil, err
+	}
+
+	// Matches what history stores, as with CopyTrade
+	now:= time.Now().Round(time.Microsecond)
+	reversal:= reverseCards(cards, now)
+	for i:= range reversal{
+		reversal[i].TradeID = reversalID
+		reversal[i].Reverses = tradeID
+	}
+
+	err = withTx(pool, func(tx *pgx.Tx) error {
+		err:= claimWritable(tx, meta)
+		if err!=nil {
+			return err
+		}
+
+		var reversals int64
+		err = tx.QueryRow("countTradeReversals",
+			user, collection, tradeID).Scan(&reversals)
+		if err!=nil {
+			return err
+		}
+		if reversals > 0 {
+			return ErrTradeReversed
+		}
+
+		for _, aCard:= range reversal{
+			err:= insertCard(tx,
+				user, collection,
+				aCard.Name, aCard.Set, aCard.Comment,
+				aCard.Quantity, aCard.Lang, aCard.Quality, aCard.LastUpdate,
+				reversalID, tradeID)
+			if err!=nil {
+				return fmt.Errorf("failed to insert card, %v", err)
+			}
+		}
+
+		return recordEvent(tx, user, EventTradeReversed,
+			collection, tradeDetail(reversal))
+	})
+	if err!=nil {
+		return nil, err
+	}
+
+	return &Trade{
+		ID: reversalID,
+		Collection: collection,
+		Time: now,
+		Cards: reversal,
+		Reverses: tradeID,
+	}, nil
+
+}
@@ -5,6 +5,7 @@ import(
 	"time"
 
 	"fmt"
+	"strings"
 
 	"github.com/jackc/pgx"
 
@@ -14,12 +15,119 @@ type Collection struct{
 	Name, Owner string
 	LastUpdate time.Time
 	Privacy string
+	PublicComments bool
+	// Locked collections can be read but not modified
+	Locked bool
+	// Beyond the owner's plan cap, read only until they upgrade or
+	// delete another collection
+	OverLimit bool
+	// Defaults for valuing the collection, empty defers to the owner
+	Currency, Basis string
+	// Bumped by every trade and change of permissions, tags or name,
+	// changes made against an older version are refused
+	Version int64
+	// Labels the owner files the collection under, such as "EDH"
+	Tags []string
+}
+
+// Returns why a collection may not be modified, nil when it may.
+func (c *Collection) writable() error {
+
+	if c.Locked {
+		return ErrCollectionLocked
+	}
+	if c.OverLimit {
+		return ErrCollectionOverLimit
+	}
+
+	return nil
+
+}
+
+// Ensures a change is being made against the version a caller
+// expects, zero expecting whichever version was just read.
+func (c *Collection) current(version int64) error {
+
+	if version != 0 && version != c.Version {
+		return ErrCollectionConflict
+	}
+
+	return nil
+
+}
+
+// Moves a collection on from the version a change was made against
+// using a passed transaction.
+//
+// Returns ErrCollectionConflict if another change got there first.
+// Concurrent changes wait on each other here so only one of those
+// made against the same version can succeed.
+func claimVersion(tx *pgx.Tx, c *Collection) error {
+
+	tag, err:= tx.Exec("bumpCollectionVersion", c.Owner, c.Name, c.Version)
+	if err!=nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrCollectionConflict
+	}
+
+	return nil
+
+}
+
+// Claims the next version of a collection as claimVersion does, but
+// only while it remains writable.
+//
+// The collection's row is locked first so it can't be locked or
+// pushed over its limit between this check and the commit.
+func claimWritable(tx *pgx.Tx, c *Collection) error {
+
+	var locked, overLimit bool
+	err:= tx.QueryRow("lockCollection", c.Owner, c.Name).Scan(&locked,
+		&overLimit)
+	if err == pgx.ErrNoRows {
+		return ErrNoSuchCollection
+	}
+	if err!=nil {
+		return errorHandle(err, ScanError)
+	}
+
+	current:= Collection{Locked: locked, OverLimit: overLimit}
+	err = current.writable()
+	if err!=nil {
+		return err
+	}
+
+	return claimVersion(tx, c)
+
+}
+
+// Determines if a collection name is one of ReservedCollectionNames
+func reservedCollectionName(collection string) bool {
+
+	for _, reserved:= range ReservedCollectionNames{
+		if strings.EqualFold(reserved, collection) {
+			return true
+		}
+	}
+
+	return false
+
 }
 
 // Commits a new collection to the database only if the user has less than
-// their maximum number of collections!
+// their plan's maximum number of collections!
+//
+// Names in ReservedCollectionNames are rejected with
+// ErrReservedCollectionName, collections beyond the plan's cap with
+// ErrCollectionLimit and names already in use with ErrCollectionExists.
 func AddCollection(pool *pgx.ConnPool, sessionKey []byte,
 	user, collection string) error {
+
+	if reservedCollectionName(collection) {
+		return ErrReservedCollectionName
+	}
 	
 	// Authenticate the request
 	err:= SessionAuth(pool, user, sessionKey)
@@ -38,38 +146,334 @@ func AddCollection(pool *pgx.ConnPool, sessionKey []byte,
 	}
 
 	if int(userDetails.MaxCollections) < (len(collections) + 1) {
-		return fmt.Errorf("collection limit reached")
+		return ErrCollectionLimit
 	}
 
-	// Find how many collections we have
+	return withTx(pool, func(tx *pgx.Tx) error {
+		_, err:= tx.Exec("addCollection",
+						user, collection)
+		if pgErr, ok:= err.(pgx.PgError); ok && pgErr.Code == uniqueViolation {
+			return ErrCollectionExists
+		}
+		if err!=nil {
+			return err
+		}
 
-	_, err = pool.Exec("addCollection",
-					user, collection)
+		return recordEvent(tx, user, EventCollectionCreated, collection, "")
+	})
 
-	return err
 
+}
+
+// Ensures a collection exists, creating it as AddCollection would
+// when it is absent.
+//
+// Returns whether the collection already existed.
+func EnsureCollection(pool *pgx.ConnPool, sessionKey []byte,
+	user, collection string) (bool, error) {
+
+	_, err:= GetCollectionMeta(pool, sessionKey, user, collection)
+	if err == nil {
+		return true, nil
+	}
+	if err != ErrNoSuchCollection {
+		return false, err
+	}
+
+	err = AddCollection(pool, sessionKey, user, collection)
+	if err!=nil {
+		// Lost a race with a concurrent create, which is just as good
+		_, existErr:= GetCollectionMeta(pool, nil, user, collection)
+		if existErr == nil {
+			return true, nil
+		}
+		return false, err
+	}
+
+	return false, nil
 
 }
 
 // Commits new public viewing permissions to the database.
+//
+// publicComments is independent of Privacy and governs whether card
+// comments are exposed alongside whatever Privacy allows.
+//
+// version is the collection version the change was made against, as
+// with the other versioned changes ErrCollectionConflict is returned
+// if it has since moved on. Zero only guards against changes racing
+// this call.
 func SetCollectionPrivacy(pool *pgx.ConnPool, sessionKey []byte,
-	user, collection, Privacy string) error {
+	user, collection, Privacy string, publicComments bool,
+	version int64) error {
 	
-	// Authenticate the request
-	err:= SessionAuth(pool, user, sessionKey)
-	if err!=nil{
-		return errorHandle(err, "authorization Failed, invalid session key")
+	// Authenticates and ensures the collection exists
+	meta, err:= GetCollectionMeta(pool, sessionKey, user, collection)
+	if err!=nil {
+		return err
+	}
+	err = meta.current(version)
+	if err!=nil {
+		return err
+	}
+
+	return withTx(pool, func(tx *pgx.Tx) error {
+		err:= claimVersion(tx, meta)
+		if err!=nil {
+			return err
+		}
+
+		_, err = tx.Exec("setCollectionPermissions",
+						user, collection, Privacy, publicComments)
+		if err!=nil {
+			return err
+		}
+
+		detail:= Privacy
+		if publicComments {
+			detail+= " with comments"
+		}
+
+		return recordEvent(tx, user, EventPermissionsChanged,
+			collection, detail)
+	})
+
+
+}
+
+// Replaces the tags a collection is labelled with, an empty list
+// removes them all.
+//
+// Tags are kept as given, callers normalize them. version is checked
+// as with SetCollectionPrivacy.
+func SetCollectionTags(pool *pgx.ConnPool, sessionKey []byte,
+	user, collection string, tags []string, version int64) error {
+
+	// Authenticates and ensures the collection exists
+	meta, err:= GetCollectionMeta(pool, sessionKey, user, collection)
+	if err!=nil {
+		return err
+	}
+	err = meta.current(version)
+	if err!=nil {
+		return err
+	}
+
+	if tags == nil {
+		tags = []string{}
+	}
+
+	return withTx(pool, func(tx *pgx.Tx) error {
+		err:= claimVersion(tx, meta)
+		if err!=nil {
+			return err
+		}
+
+		_, err = tx.Exec("setCollectionTags", user, collection, tags)
+		if err!=nil {
+			return err
+		}
+
+		return recordEvent(tx, user, EventTagsChanged,
+			collection, strings.Join(tags, ", "))
+	})
+
+}
+
+// Renames a collection, carrying its contents, history and
+// permissions along.
+//
+// Returns ErrCollectionExists if the user already has a collection
+// named newName and ErrCollectionLocked or ErrCollectionOverLimit if
+// the collection may not be modified. ErrCollectionConflict is
+// returned if the collection moved on from version, as with
+// SetCollectionPrivacy.
+func RenameCollection(pool *pgx.ConnPool, sessionKey []byte,
+	user, collection, newName string, version int64) error {
+
+	if reservedCollectionName(newName) {
+		return ErrReservedCollectionName
+	}
+
+	// Authenticates and ensures the collection exists
+	meta, err:= GetCollectionMeta(pool, sessionKey, user, collection)
+	if err!=nil {
+		return err
+	}
+	err = meta.writable()
+	if err!=nil {
+		return err
 	}
+	err = meta.current(version)
+	if err!=nil {
+		return err
+	}
+
+	_, err = GetCollectionMeta(pool, nil, user, newName)
+	if err == nil {
+		return ErrCollectionExists
+	}
+	if err != ErrNoSuchCollection {
+		return err
+	}
+
+	return withTx(pool, func(tx *pgx.Tx) error {
+		// Claimed under the old name, the version follows the rename
+		err:= claimWritable(tx, meta)
+		if err!=nil {
+			return err
+		}
+
+		// Contents follow by cascade
+		_, err = tx.Exec("renameCollection", user, collection, newName)
+		if err!=nil {
+			return err
+		}
+
+		_, err = tx.Exec("renameCollectionHistory",
+			user, collection, newName)
+		if err!=nil {
+			return err
+		}
+
+		return recordEvent(tx, user, EventCollectionRenamed,
+			newName, collection)
+	})
+
+}
+
+// The longest text the standardText domain accepts
+const standardTextMaxLength int = 279
 
-	_, err = pool.Exec("setCollectionPermissions",
-					user, collection, Privacy)
+// The name a deleted collection's history is kept under.
+//
+// Collection names may never contain markup so this cannot collide
+// with a collection created later, even one taking the same name.
+// Long names are cut short so the prefixed name remains standardText,
+// the prefix alone keeps it distinct.
+func archivedCollectionName(collection string, deleted time.Time) string {
+
+	archived:= []rune(archivedPrefix(deleted) + collection)
+	if len(archived) > standardTextMaxLength {
+		archived = archived[:standardTextMaxLength]
+	}
+
+	return string(archived)
+
+}
+
+// Prefixes the names of collections deleted at a given time.
+func archivedPrefix(deleted time.Time) string {
+	return fmt.Sprintf("<deleted %d>", deleted.UnixNano())
+}
+
+// Deletes a collection along with its contents.
+//
+// History is append only, so rather than being removed it is moved
+// aside under archivedCollectionName where it no longer belongs to
+// any collection the user can see.
+//
+// Collections over the plan's limit may still be deleted, the slot
+// freed makes the most recently updated of the rest writable again.
+func DeleteCollection(pool *pgx.ConnPool, sessionKey []byte,
+	user, collection string) error {
+
+	// Authenticates and ensures the collection exists
+	meta, err:= GetCollectionMeta(pool, sessionKey, user, collection)
+	if err!=nil {
+		return err
+	}
+	if meta.Locked {
+		return ErrCollectionLocked
+	}
+
+	userDetails, err:= GetUser(pool, user)
+	if err!=nil {
+		return errorHandle(err, "failed to fetch user")
+	}
+
+	return withTx(pool, func(tx *pgx.Tx) error {
+		_, err:= tx.Exec("removeCollectionContents", user, collection)
+		if err!=nil {
+			return err
+		}
+
+		_, err = tx.Exec("renameCollectionHistory", user, collection,
+			archivedCollectionName(collection, time.Now()))
+		if err!=nil {
+			return err
+		}
+
+		_, err = tx.Exec("removeCollection", user, collection)
+		if err!=nil {
+			return err
+		}
+
+		_, err = tx.Exec("setCollectionsOverLimit", user,
+			userDetails.MaxCollections)
+		if err!=nil {
+			return err
+		}
+
+		return recordEvent(tx, user, EventCollectionDeleted, collection, "")
+	})
+
+}
+
+// Locks or unlocks a collection, preventing modifications while
+// locked. Reads are unaffected.
+func SetCollectionLock(pool *pgx.ConnPool, sessionKey []byte,
+	user, collection string, locked bool) error {
+
+	// Authenticates and ensures the collection exists
+	_, err:= GetCollectionMeta(pool, sessionKey, user, collection)
+	if err!=nil {
+		return err
+	}
+
+	_, err = pool.Exec("setCollectionLock",
+					user, collection, locked)
 
 	return err
 
+}
+
+// Stores the currency and basis a collection is valued in when a
+// request doesn't specify them. Empty values clear a default.
+//
+// As with trades, locked and over limit collections can't be changed.
+func SetCollectionValuation(pool *pgx.ConnPool, sessionKey []byte,
+	user, collection, currency, basis string) error {
+
+	// Authenticates and ensures the collection exists
+	meta, err:= GetCollectionMeta(pool, sessionKey, user, collection)
+	if err!=nil {
+		return err
+	}
+	err = meta.writable()
+	if err!=nil {
+		return err
+	}
+
+	return withTx(pool, func(tx *pgx.Tx) error {
+		err:= claimWritable(tx, meta)
+		if err!=nil {
+			return err
+		}
+
+		_, err = tx.Exec("setCollectionValuation",
+						user, collection, currency, basis)
+
+		return err
+	})
 
 }
 
 // Acquires metadata for a given collection
+//
+// Returns ErrBadSession if a provided session key fails to authenticate,
+// this is checked before existence so it reveals nothing about the
+// collection. Otherwise, ErrNoSuchCollection is returned if it is missing.
 func GetCollectionMeta(pool *pgx.ConnPool, sessionKey []byte,
 	user, collection string) (*Collection, error) {
 	
@@ -79,7 +483,7 @@ func GetCollectionMeta(pool *pgx.ConnPool, sessionKey []byte,
 	if sessionKey != nil {
 		err = SessionAuth(pool, user, sessionKey)
 		if err!=nil{
-			return nil, errorHandle(err, "authorization Failed, invalid session key")
+			return nil, err
 		}	
 	}
 
@@ -88,8 +492,12 @@ func GetCollectionMeta(pool *pgx.ConnPool, sessionKey []byte,
 	err = pool.QueryRow("getCollectionMeta",
 		user, collection).Scan(&c.Name, &c.Owner,
 			&c.LastUpdate,
-			&c.Privacy)
-	if err!=nil {
+			&c.Privacy, &c.PublicComments,
+			&c.Locked, &c.OverLimit,
+			&c.Currency, &c.Basis, &c.Version, &c.Tags)
+	if err == pgx.ErrNoRows {
+		return nil, ErrNoSuchCollection
+	}else if err!=nil {
 		return nil, errorHandle(err, ScanError)
 	}
 
@@ -97,7 +505,7 @@ func GetCollectionMeta(pool *pgx.ConnPool, sessionKey []byte,
 
 }
 
-// Acquire metadata for all collections for a given user.
+// Acquire metadata for all collections for a given user, ordered by name.
 func GetCollectionList(pool *pgx.ConnPool, user string) ([]Collection, error) {
 
 	rows, err := pool.Query("getCollectionList", user)
@@ -106,10 +514,47 @@ func GetCollectionList(pool *pgx.ConnPool, user string) ([]Collection, error) {
 	}
 	defer rows.Close()
 
+	return scanCollectionList(rows)
+
+}
+
+// Acquire metadata for up to limit collections of a given user after
+// skipping offset of them, ordered by name so pages are stable.
+//
+// Only collections tagged tag, regardless of case, are included unless
+// it is empty. Returns the total number of those the user has alongside.
+func GetCollectionListPage(pool *pgx.ConnPool, user, tag string,
+	offset, limit int) ([]Collection, int64, error) {
+
+	var total int64
+	err:= pool.QueryRow("countCollections", user, tag).Scan(&total)
+	if err!=nil {
+		return nil, 0, errorHandle(err, ScanError)
+	}
+
+	rows, err := pool.Query("getCollectionPage", user, limit, offset, tag)
+	if err!=nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	collections, err:= scanCollectionList(rows)
+	if err!=nil {
+		return nil, 0, err
+	}
+
+	return collections, total, nil
+
+}
+
+// Reads every collection from rows, which must select the columns
+// of getCollectionList.
+func scanCollectionList(rows *pgx.Rows) ([]Collection, error) {
+
 	var collections []Collection
 	for rows.Next(){
 		c:= Collection{}
-		err = rows.Scan(&c.Name, &c.Privacy)
+		err:= rows.Scan(&c.Name, &c.Privacy, &c.Tags)
 		if err!=nil {
 			return nil, errorHandle(err, ScanError)
 		}
@@ -5,7 +5,9 @@ import(
 	"testing"
 
 	"fmt"
+	"strings"
 	"time"
+	"unicode/utf8"
 
 )
 
@@ -182,27 +184,74 @@ func TestCollPermissions(t *testing.T) {
 
 	// History but not viewing should fail
 	err = SetCollectionPrivacy(pool, key, user, collection,
-		"Boots")
+		"Boots", false, 0)
 	if err == nil {
 		t.Fatal("was allowed to set invalid permissions")
 	}
 
 	// Viewing but no history should work
 	err = SetCollectionPrivacy(pool, key, user, collection,
-		"Private")
+		"Private", false, 0)
 	if err != nil {
 		t.Fatal("failed to set valid permissions", err)
 	}
 	
 	// No public access should work
 	err = SetCollectionPrivacy(pool, key, user, collection,
-		"History")
+		"History", false, 0)
 	if err != nil {
 		t.Fatal("failed to set valid permissions", err)
 	}
 
 }
 
+// Tests to ensure the public comments bit is stored independently of
+// the privacy level.
+func TestCollCommentPermissions(t *testing.T) {
+	t.Parallel()
+
+	user:= randString(int(randByte()))
+	key, err:= AddUser(pool, user, "bar", "foo")
+	if err!=nil {
+		t.Fatal("failed to add user ", err)
+	}
+
+	collection:= randString(int(randByte()))
+
+	err = AddCollection(pool, key, user, collection)
+	if err!=nil {
+		t.Fatal("valid collection was denied", err)
+	}
+
+	// Comments are private by default
+	coll, err:= GetCollectionMeta(pool, key, user, collection)
+	if err!=nil {
+		t.Fatal(err)
+	}
+	if coll.PublicComments {
+		t.Fatal("comments were public by default")
+	}
+
+	for _, public:= range []bool{true, false}{
+
+		err = SetCollectionPrivacy(pool, key, user, collection,
+			"Contents", public, 0)
+		if err != nil {
+			t.Fatal("failed to set valid permissions", err)
+		}
+
+		coll, err = GetCollectionMeta(pool, key, user, collection)
+		if err!=nil {
+			t.Fatal(err)
+		}
+		if coll.PublicComments != public || coll.Privacy != "Contents" {
+			t.Fatal("permissions did not match those set")
+		}
+
+	}
+
+}
+
 // Tests to ensure a user is incapable of adding a collection with a name
 // longer than reasonable
 func TestInvalidCollName(t *testing.T) {
@@ -223,6 +272,52 @@ func TestInvalidCollName(t *testing.T) {
 
 }
 
+// Archived names of the longest collections must still be standardText
+// while staying distinct between deletions.
+func TestArchivedCollectionNameLength(t *testing.T) {
+
+	collection:= strings.Repeat("é", 255)
+	deleted:= time.Now()
+
+	archived:= archivedCollectionName(collection, deleted)
+	if utf8.RuneCountInString(archived) > standardTextMaxLength ||
+		!utf8.ValidString(archived) {
+		t.Fatal("archived name too long to store", len(archived))
+	}
+
+	later:= archivedCollectionName(collection, deleted.Add(time.Nanosecond))
+	if later == archived {
+		t.Fatal("archived names of separate deletions collide")
+	}
+
+	if archivedCollectionName("foo", deleted) != archivedPrefix(deleted) + "foo" {
+		t.Fatal("short collection name cut short when archived")
+	}
+
+}
+
+// Every route segment around collections must be rejected as a
+// name, regardless of case.
+func TestReservedCollectionNames(t *testing.T) {
+
+	segments:= []string{"Get", "GetPublic", "Create", "Ensure",
+		"Permissions", "Trades", "Bulk", "Copy", "Quantities", "TopCards",
+		"Stats", "Lock", "Tags", "Export", "Import", "Holdings", "Rename",
+		"History", "HistoryPublic", "Valuation"}
+
+	for _, segment:= range segments{
+		if !reservedCollectionName(segment) ||
+			!reservedCollectionName(strings.ToLower(segment)) {
+			t.Fatal("route segment accepted as a collection name", segment)
+		}
+	}
+
+	if reservedCollectionName("Modern Staples") {
+		t.Fatal("ordinary collection name rejected")
+	}
+
+}
+
 // Tests to ensure a user is incapable of adding more than their alloted
 // collections.
 func TestInvalidCollCount(t *testing.T) {
@@ -251,8 +346,521 @@ func TestInvalidCollCount(t *testing.T) {
 	}
 
 	err = AddCollection(pool, key, user, collections[1])
-	if err==nil {
-		t.Fatal("collection beyond maximum was allowed")
+	if err != ErrCollectionLimit {
+		t.Fatal("collection beyond maximum was allowed", err)
+	}
+
+	// Raising the stored maximum is all it takes to allow another
+	err = SetMaxCollections(pool, user, 2)
+	if err!=nil {
+		t.Fatal("failed to set collection max", err)
+	}
+
+	err = AddCollection(pool, key, user, collections[1])
+	if err!=nil {
+		t.Fatal("collection within raised maximum was denied", err)
 	}
 	
-}
\ No newline at end of file
+}
+
+// Ensures missing collections are distinct from failed authentication
+// and that failed authentication reveals nothing about existence.
+func TestCollMissingVsForbidden(t *testing.T) {
+	t.Parallel()
+
+	user:= randString(int(randByte()))
+	key, err:= AddUser(pool, user, "bar", "foo")
+	if err!=nil {
+		t.Fatal("failed to add user ", err)
+	}
+
+	collection:= randString(int(randByte()))
+	err = AddCollection(pool, key, user, collection)
+	if err!=nil {
+		t.Fatal("valid collection was denied", err)
+	}
+
+	missing:= collection + "foo"
+
+	_, err = GetCollectionMeta(pool, key, user, missing)
+	if err != ErrNoSuchCollection {
+		t.Fatal("missing collection not reported as such", err)
+	}
+
+	badKey:= []byte(randString(32))
+	for _, name:= range []string{collection, missing}{
+		_, err = GetCollectionMeta(pool, badKey, user, name)
+		if err != ErrBadSession {
+			t.Fatal("bad session not reported as such", err)
+		}
+	}
+
+}
+
+// Names colliding with routes are rejected regardless of case.
+func TestReservedCollName(t *testing.T) {
+	t.Parallel()
+
+	user:= randString(int(randByte()) % 31)
+	key, err:= AddUser(pool, user, "bar", "foo")
+	if err!=nil {
+		t.Fatal("failed to add user ", err)
+	}
+
+	time.Sleep(stepSleepTime)
+
+	for _, name:= range []string{"Get", "get", "TRADES"} {
+		err = AddCollection(pool, key, user, name)
+		if err != ErrReservedCollectionName {
+			t.Fatal("reserved collection name accepted", name, err)
+		}
+	}
+
+	err = AddCollection(pool, key, user, "Getaway")
+	if err!=nil {
+		t.Fatal("unreserved collection name rejected", err)
+	}
+
+}
+
+// Ensuring twice should create once and then report the existing
+// collection, where a strict create would fail.
+func TestEnsureCollection(t *testing.T) {
+	t.Parallel()
+
+	user:= randString(int(randByte()) % 31)
+	key, err:= AddUser(pool, user, "bar", "foo")
+	if err!=nil {
+		t.Fatal("failed to add user ", err)
+	}
+
+	time.Sleep(stepSleepTime)
+
+	collection:= randString(int(randByte()))
+	existed, err:= EnsureCollection(pool, key, user, collection)
+	if err!=nil || existed {
+		t.Fatal("failed to create absent collection", existed, err)
+	}
+
+	time.Sleep(stepSleepTime)
+
+	existed, err = EnsureCollection(pool, key, user, collection)
+	if err!=nil || !existed {
+		t.Fatal("existing collection not reported", existed, err)
+	}
+
+	err = AddCollection(pool, key, user, collection)
+	if err == nil {
+		t.Fatal("strict create accepted an existing collection")
+	}
+
+	_, err = EnsureCollection(pool, []byte("bad"), user, collection)
+	if err != ErrBadSession {
+		t.Fatal("collection ensured without a valid session", err)
+	}
+
+}
+
+func TestCollValuation(t *testing.T) {
+	t.Parallel()
+
+	user:= randString(int(randByte()) % 31)
+	key, err:= AddUser(pool, user, "bar", "foo")
+	if err!=nil {
+		t.Fatal("failed to add user ", err)
+	}
+
+	collection:= randString(int(randByte()))
+	err = AddCollection(pool, key, user, collection)
+	if err!=nil {
+		t.Fatal("valid collection was denied", err)
+	}
+
+	meta, err:= GetCollectionMeta(pool, key, user, collection)
+	if err!=nil || meta.Currency != "" || meta.Basis != "" {
+		t.Fatal("fresh collection has valuation defaults", meta, err)
+	}
+
+	err = SetCollectionValuation(pool, key, user, collection, "EUR", "sell")
+	if err!=nil {
+		t.Fatal("failed to set valuation defaults", err)
+	}
+
+	time.Sleep(stepSleepTime)
+
+	meta, err = GetCollectionMeta(pool, key, user, collection)
+	if err!=nil || meta.Currency != "EUR" || meta.Basis != "sell" {
+		t.Fatal("valuation defaults not stored", meta, err)
+	}
+
+	err = SetCollectionValuation(pool, key, user, randString(32), "EUR", "")
+	if err != ErrNoSuchCollection {
+		t.Fatal("set valuation defaults on a missing collection", err)
+	}
+
+	err = SetCollectionLock(pool, key, user, collection, true)
+	if err!=nil {
+		t.Fatal("failed to lock collection", err)
+	}
+
+	time.Sleep(stepSleepTime)
+
+	err = SetCollectionValuation(pool, key, user, collection, "USD", "")
+	if err != ErrCollectionLocked {
+		t.Fatal("set valuation defaults on a locked collection", err)
+	}
+
+}
+
+// Pages must come back in name order and report the full count.
+func TestCollListPage(t *testing.T) {
+	t.Parallel()
+
+	user:= randString(int(randByte()) % 31)
+	key, err:= AddUser(pool, user, "bar", "foo")
+	if err!=nil {
+		t.Fatal("failed to add user ", err)
+	}
+
+	time.Sleep(stepSleepTime)
+
+	// Added out of order to ensure results are sorted
+	names:= []string{"b" + randString(10), "a" + randString(10)}
+	for _, name:= range names{
+		err = AddCollection(pool, key, user, name)
+		if err!=nil {
+			t.Fatal(err)
+		}
+	}
+
+	time.Sleep(stepSleepTime)
+
+	page, total, err:= GetCollectionListPage(pool, user, "", 0, 1)
+	if err!=nil || total != 2 || len(page) != 1 || page[0].Name != names[1] {
+		t.Fatal("incorrect first page", page, total, err)
+	}
+
+	page, total, err = GetCollectionListPage(pool, user, "", 1, 1)
+	if err!=nil || total != 2 || len(page) != 1 || page[0].Name != names[0] {
+		t.Fatal("incorrect second page", page, total, err)
+	}
+
+	page, total, err = GetCollectionListPage(pool, user, "", 5, 1)
+	if err!=nil || total != 2 || len(page) != 0 {
+		t.Fatal("page beyond the end not empty", page, total, err)
+	}
+
+}
+
+// Tags must persist and filter listings, an empty filter lists all.
+func TestCollTags(t *testing.T) {
+	t.Parallel()
+
+	user:= randString(int(randByte()) % 31)
+	key, err:= AddUser(pool, user, "bar", "foo")
+	if err!=nil {
+		t.Fatal("failed to add user ", err)
+	}
+
+	time.Sleep(stepSleepTime)
+
+	names:= []string{"a" + randString(10), "b" + randString(10)}
+	for _, name:= range names{
+		err = AddCollection(pool, key, user, name)
+		if err!=nil {
+			t.Fatal(err)
+		}
+	}
+
+	err = SetCollectionTags(pool, key, user, names[0],
+		[]string{"EDH", "Trade Binder"}, 0)
+	if err!=nil {
+		t.Fatal("failed to set tags", err)
+	}
+
+	err = SetCollectionTags(pool, []byte("baz"), user, names[1],
+		[]string{"EDH"}, 0)
+	if err != ErrBadSession {
+		t.Fatal("tags set without a session", err)
+	}
+
+	time.Sleep(stepSleepTime)
+
+	meta, err:= GetCollectionMeta(pool, key, user, names[0])
+	if err!=nil || len(meta.Tags) != 2 || meta.Tags[1] != "Trade Binder" {
+		t.Fatal("tags not kept", meta, err)
+	}
+
+	page, total, err:= GetCollectionListPage(pool, user, "EDH", 0, 10)
+	if err!=nil || total != 1 || len(page) != 1 || page[0].Name != names[0] {
+		t.Fatal("tag filter misses or leaks collections", page, total, err)
+	}
+
+	page, total, err = GetCollectionListPage(pool, user, "trade binder", 0, 10)
+	if err!=nil || total != 1 || len(page) != 1 || page[0].Name != names[0] {
+		t.Fatal("tag filter depends on case", page, total, err)
+	}
+
+	page, total, err = GetCollectionListPage(pool, user, "", 0, 10)
+	if err!=nil || total != 2 || len(page) != 2 {
+		t.Fatal("empty tag filter hides collections", page, total, err)
+	}
+
+	err = SetCollectionTags(pool, key, user, names[0], nil, 0)
+	if err!=nil {
+		t.Fatal("failed to clear tags", err)
+	}
+
+	page, total, err = GetCollectionListPage(pool, user, "EDH", 0, 10)
+	if err!=nil || total != 0 || len(page) != 0 {
+		t.Fatal("cleared tags still filter", page, total, err)
+	}
+
+}
+
+// Renaming must carry contents, history and permissions along.
+func TestCollRename(t *testing.T) {
+	t.Parallel()
+
+	user:= randString(int(randByte()) % 31)
+	key, err:= AddUser(pool, user, "bar", "foo")
+	if err!=nil {
+		t.Fatal("failed to add user ", err)
+	}
+
+	time.Sleep(stepSleepTime)
+
+	collection:= randString(int(randByte()))
+	other:= randString(int(randByte()))
+	for _, name:= range []string{collection, other}{
+		err = AddCollection(pool, key, user, name)
+		if err!=nil {
+			t.Fatal(err)
+		}
+	}
+
+	err = AddCards(pool, key, user, collection, randomCards(2), 0)
+	if err!=nil {
+		t.Fatal(err)
+	}
+	err = SetCollectionPrivacy(pool, key, user, collection, "History", true, 0)
+	if err!=nil {
+		t.Fatal(err)
+	}
+
+	time.Sleep(stepSleepTime)
+
+	err = RenameCollection(pool, key, user, collection, other, 0)
+	if err != ErrCollectionExists {
+		t.Fatal("renamed over an existing collection", err)
+	}
+
+	renamed:= randString(31)
+	err = RenameCollection(pool, key, user, collection, renamed, 0)
+	if err!=nil {
+		t.Fatal("failed to rename collection", err)
+	}
+
+	time.Sleep(stepSleepTime)
+
+	_, err = GetCollectionMeta(pool, key, user, collection)
+	if err != ErrNoSuchCollection {
+		t.Fatal("collection remains under its old name", err)
+	}
+
+	meta, err:= GetCollectionMeta(pool, key, user, renamed)
+	if err!=nil || meta.Privacy != "History" || !meta.PublicComments {
+		t.Fatal("permissions lost in rename", meta, err)
+	}
+
+	contents, err:= GetCollectionContents(pool, key, user, renamed)
+	if err!=nil || len(contents) != 2 {
+		t.Fatal("contents lost in rename", contents, err)
+	}
+
+	history, err:= GetCollectionHistory(pool, key, user, renamed)
+	if err!=nil || len(history) != 2 {
+		t.Fatal("history lost in rename", history, err)
+	}
+
+}
+
+// Permission changes and renames made against a stale version must be
+// refused, leaving the collection as it was.
+func TestCollStaleVersion(t *testing.T) {
+	t.Parallel()
+
+	user:= randString(int(randByte()) % 31)
+	key, err:= AddUser(pool, user, "bar", "foo")
+	if err!=nil {
+		t.Fatal("failed to add user ", err)
+	}
+
+	time.Sleep(stepSleepTime)
+
+	collection:= randString(int(randByte()))
+	err = AddCollection(pool, key, user, collection)
+	if err!=nil {
+		t.Fatal(err)
+	}
+
+	stale, err:= GetCollectionMeta(pool, key, user, collection)
+	if err!=nil {
+		t.Fatal(err)
+	}
+
+	err = SetCollectionPrivacy(pool, key, user, collection,
+		"History", false, stale.Version)
+	if err!=nil {
+		t.Fatal("change against the current version refused", err)
+	}
+
+	err = SetCollectionPrivacy(pool, key, user, collection,
+		"Private", false, stale.Version)
+	if err != ErrCollectionConflict {
+		t.Fatal("permissions changed against a stale version", err)
+	}
+
+	err = RenameCollection(pool, key, user, collection,
+		randString(31), stale.Version)
+	if err != ErrCollectionConflict {
+		t.Fatal("renamed against a stale version", err)
+	}
+
+	meta, err:= GetCollectionMeta(pool, key, user, collection)
+	if err!=nil || meta.Privacy != "History" ||
+		meta.Version != stale.Version + 1 {
+		t.Fatal("stale changes altered the collection", meta, err)
+	}
+
+}
+
+// Deleting must remove the collection without its history resurfacing
+// should the name be taken again.
+func TestCollDelete(t *testing.T) {
+	t.Parallel()
+
+	user:= randString(int(randByte()) % 31)
+	key, err:= AddUser(pool, user, "bar", "foo")
+	if err!=nil {
+		t.Fatal("failed to add user ", err)
+	}
+
+	time.Sleep(stepSleepTime)
+
+	collection:= randString(int(randByte()))
+	err = AddCollection(pool, key, user, collection)
+	if err!=nil {
+		t.Fatal(err)
+	}
+	err = AddCards(pool, key, user, collection, randomCards(2), 0)
+	if err!=nil {
+		t.Fatal(err)
+	}
+
+	time.Sleep(stepSleepTime)
+
+	err = DeleteCollection(pool, key, user, collection)
+	if err!=nil {
+		t.Fatal("failed to delete collection", err)
+	}
+
+	_, err = GetCollectionMeta(pool, key, user, collection)
+	if err != ErrNoSuchCollection {
+		t.Fatal("collection remains after deletion", err)
+	}
+
+	err = DeleteCollection(pool, key, user, collection)
+	if err != ErrNoSuchCollection {
+		t.Fatal("deleted a missing collection", err)
+	}
+
+	// The freed slot and name are usable once more
+	err = AddCollection(pool, key, user, collection)
+	if err!=nil {
+		t.Fatal("failed to recreate deleted collection", err)
+	}
+
+	contents, err:= GetCollectionContents(pool, key, user, collection)
+	if err!=nil || len(contents) != 0 {
+		t.Fatal("contents survived deletion", contents, err)
+	}
+
+	history, err:= GetCollectionHistory(pool, key, user, collection)
+	if err!=nil || len(history) != 0 {
+		t.Fatal("history resurfaced in recreated collection", history, err)
+	}
+
+}
+
+// Downgrading keeps every collection but those beyond the new cap
+// become read only until the user upgrades again.
+func TestCollOverLimit(t *testing.T) {
+	t.Parallel()
+
+	user:= randString(int(randByte()))
+	key, err:= AddUser(pool, user, "bar", "foo")
+	if err!=nil {
+		t.Fatal("failed to add user ", err)
+	}
+
+	err = ModSub(pool, user, "Preordain", MonthlyInterval, "42", "12", key)
+	if err!=nil {
+		t.Fatal("failed to add sub", err)
+	}
+
+	collections:= []string{randString(int(randByte())),
+		randString(int(randByte())), randString(int(randByte()))}
+	for _, collection:= range collections{
+		err = AddCollection(pool, key, user, collection)
+		if err!=nil {
+			t.Fatal("valid collection was denied", err)
+		}
+		time.Sleep(stepSleepTime)
+	}
+
+	err = ModSub(pool, user, DefaultSubLevel, MonthlyInterval,
+		"42", DefaultID, key)
+	if err!=nil {
+		t.Fatal("failed to downgrade", err)
+	}
+
+	// Only the most recently updated is kept writable
+	writable:= 0
+	for _, collection:= range collections{
+		coll, err:= GetCollectionMeta(pool, key, user, collection)
+		if err!=nil {
+			t.Fatal("collection lost on downgrade", err)
+		}
+		if !coll.OverLimit {
+			writable++
+		}
+	}
+	if writable != SubTiersToCollections[DefaultSubLevel] {
+		t.Fatal("incorrect writable collections after downgrade", writable)
+	}
+
+	coll, err:= GetCollectionMeta(pool, key, user, collections[0])
+	if err!=nil || !coll.OverLimit {
+		t.Fatal("oldest collection still writable", coll, err)
+	}
+	err = AddCards(pool, key, user, collections[0], randomCards(1), 0)
+	if err != ErrCollectionOverLimit {
+		t.Fatal("over limit collection was modified", err)
+	}
+
+	err = AddCollection(pool, key, user, randString(int(randByte())))
+	if err != ErrCollectionLimit {
+		t.Fatal("collection beyond plan was allowed", err)
+	}
+
+	err = ModSub(pool, user, "Preordain", MonthlyInterval, "42", "12", key)
+	if err!=nil {
+		t.Fatal("failed to upgrade", err)
+	}
+	coll, err = GetCollectionMeta(pool, key, user, collections[0])
+	if err!=nil || coll.OverLimit {
+		t.Fatal("collection still over limit after upgrade", coll, err)
+	}
+
+}
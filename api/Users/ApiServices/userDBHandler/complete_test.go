@@ -43,7 +43,7 @@ func TestComplete(t *testing.T) {
 		time.Sleep(stepSleepTime)
 
 		// Add some cards to that collection
-		err = AddCards(pool, key, name, collection, cards)
+		err = AddCards(pool, key, name, collection, cards, 0)
 		if err!= nil {
 			t.Fatal("failed to add cards", err)
 		}
@@ -5,9 +5,12 @@ import(
 	"fmt"
 	"io"
 	"io/ioutil"
+	"strconv"
+	"strings"
 
 	"code.google.com/p/go.crypto/scrypt"
 	"crypto/rand"
+	"crypto/subtle"
 
 	"crypto/x509"
 
@@ -25,11 +28,58 @@ func getArrayOfRandBytes(arrayLength int) ([]byte, error) {
 	return workingArray, nil
 }
 
-// Derives a password using scrypt. Requires plaintext and nonce
-func derivePasswordWithNonce(plaintext, nonce []byte) ([]byte, error) {
+// The scrypt work factors a password was derived with, these are
+// stored alongside each user's hash.
+type HashParams struct{
+	N, R, P int32
+}
+
+// What passwords were derived with before parameters were stored,
+// users predating them are assumed to use these.
+var legacyHashParams = HashParams{N: 32768, R: 2, P: 1}
+
+// The parameters fresh passwords are derived with, replaceable at
+// startup. Users derived with anything else are rehashed on their
+// next login.
+var CurrentHashParams = legacyHashParams
+
+// Parses parameters formatted as 'N,r,p' such as '32768,2,1'.
+//
+// N must be a power of two greater than one and r, p positive.
+func ParseHashParams(raw string) (HashParams, error) {
+
+	parts:= strings.Split(raw, ",")
+	if len(parts) != 3 {
+		return HashParams{}, fmt.Errorf("expected N,r,p, got %q", raw)
+	}
+
+	values:= make([]int32, len(parts))
+	for i, part:= range parts{
+		value, err:= strconv.ParseInt(strings.TrimSpace(part), 10, 32)
+		if err!=nil || value < 1 {
+			return HashParams{}, fmt.Errorf("invalid hash parameter %q", part)
+		}
+		values[i] = int32(value)
+	}
+
+	params:= HashParams{N: values[0], R: values[1], P: values[2]}
+	if params.N < 2 || params.N & (params.N - 1) != 0 {
+		return HashParams{}, fmt.Errorf("N must be a power of two, got %d",
+			params.N)
+	}
+
+	return params, nil
+
+}
+
+// Derives a password using scrypt. Requires plaintext, nonce and the
+// parameters it was originally derived with
+func derivePasswordWithNonce(plaintext, nonce []byte,
+	params HashParams) ([]byte, error) {
 
 	// Output a 32 byte hash using
-	passwordHash, err := scrypt.Key([]byte(plaintext), nonce, 32768, 2, 1, 32)
+	passwordHash, err := scrypt.Key([]byte(plaintext), nonce,
+		int(params.N), int(params.R), int(params.P), 32)
 	if err != nil {
 		return nil, fmt.Errorf("failed to derive password, try again")
 	}
@@ -38,25 +88,45 @@ func derivePasswordWithNonce(plaintext, nonce []byte) ([]byte, error) {
 
 }
 
-// Derives a password using scrypt. Requires plaintext, nonce
-// is returned alongside the hash
-func derivePassword(plaintext []byte) (passwordHash, nonce []byte, err error) {
+// Derives a password using scrypt with CurrentHashParams. Requires
+// plaintext, nonce and the parameters used are returned alongside
+// the hash
+func derivePassword(plaintext []byte) (passwordHash, nonce []byte,
+	params HashParams, err error) {
 
 	nonce, err = getArrayOfRandBytes(32)
 	if err!=nil {
 		return
 	}
 
-	// Output a 32 byte hash using
-	passwordHash, err= scrypt.Key([]byte(plaintext), nonce, 32768, 2, 1, 32)
-	if err != nil {
-		return
-	}
+	params = CurrentHashParams
+	passwordHash, err = derivePasswordWithNonce(plaintext, nonce, params)
 
 	return
 
 }
 
+// Compares secrets, or hashes of them, in time independent of where
+// they first differ.
+//
+// Every secret compared outside of the database goes through here so
+// a plain comparison can't creep in. A variable so tests can observe
+// it being used.
+var secretsEqual = func(a, b []byte) bool {
+	return subtle.ConstantTimeCompare(a, b) == 1
+}
+
+// Derives a password the way a login would for a user who doesn't
+// exist, so refusing them takes as long as refusing a wrong password.
+//
+// The result is discarded, only the time taken matters.
+func deriveDecoyPassword(plaintext []byte) {
+
+	nonce:= make([]byte, 32)
+	derivePasswordWithNonce(plaintext, nonce, CurrentHashParams)
+
+}
+
 const alphanum = "!@#0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
 // Returns a completely random string of length n
 func randString(n int) string {
@@ -26,13 +26,53 @@ var SubTiers = []string{
 	"Sensei's Top",
 }
 
-// How many collections each tier is allowed to own
+// How often a paid tier may be billed, named as stripe names them.
+//
+// The free tier is never billed and is always stored as monthly.
+const MonthlyInterval = "month"
+const AnnualInterval = "year"
+
+var BillingIntervals = []string{MonthlyInterval, AnnualInterval}
+
+// How many collections each tier is allowed to own, stored against a
+// user whenever their plan changes.
+//
+// Enforcement reads the stored maximum rather than this, so a user
+// keeps the cap they were given until their plan next changes.
 var SubTiersToCollections = map[string]int{
 	DefaultSubLevel: 1,
 	"Preordain": 4,
 	"Sensei's Top": 30,
 }
 
+// How many active webhooks each tier is allowed to register
+var SubTiersToWebhooks = map[string]int{
+	DefaultSubLevel: 1,
+	"Preordain": 5,
+	"Sensei's Top": 20,
+}
+
+// Collection names which collide with the route segments around
+// collections, these are rejected regardless of case.
+var ReservedCollectionNames = []string{
+	"Get", "GetPublic", "Create", "Ensure", "Permissions", "Trades",
+	"Quantities", "TopCards", "Stats", "Lock", "Tags",
+	"Export",
+	"Import",
+	"Holdings",
+	"Rename", "History", "HistoryPublic", "Valuation", "Bulk", "Copy",
+}
+
+// How many price alerts each tier is allowed to set
+var SubTiersToAlerts = map[string]int{
+	DefaultSubLevel: 3,
+	"Preordain": 25,
+	"Sensei's Top": 100,
+}
+
+// Consecutive failed deliveries before a webhook is disabled
+const MaxWebhookFailures int32 = 10
+
 // 290 years from now there should be no back records.
 const noTimeLimit = time.Duration(31560000000000000 * 270)
 // A full year!
@@ -49,27 +89,150 @@ const certLoc string = "certs/server.crt"
 // connection basis.
 var statements = []string{"addCard", "addCardHistorical" , "getCard",
 						"addCollection", "getCollectionMeta", "getCollectionList",
-						"getCollectionContents", "getCollectionHistory",
+						"getCollectionContents", "getCollectionHistory", "getTrade",
+						"countTradeReversals", "getTradeHistory",
 						"getSessions", "addSession", "removeSession",
-						"getReset", "getAllResets", "addReset",
-						"addUser", "getUser", "setPassword",
+						"extendSession", "getRefreshableSessions",
+						"listSessions", "getSessionsVersion", "bumpSessionsVersion",
+						"touchSession", "revokeSession", "countActiveSessions",
+						"removeAllSessions", "removeOtherSessions",
+						"getReset", "addReset", "consumeReset",
+						"markResetSent",
+						"addUser", "getUser", "setPassword", "setForceRehash",
+						"setVerifyToken", "verifyEmail",
+						"setEmailChange", "countEmailUsers", "getPendingEmail",
+						"lockEmail", "changeEmail",
+						"removeUserContents", "archiveUserHistory",
+						"removeUserCollections", "removeUserEvents",
+						"removeUserResets", "disableUserWebhooks", "scrubUser",
+						"getSubByCustomer", "recordStripeEvent", "forgetStripeEvent",
+						"setSubInterval", "setCollectionsOverLimit",
+						"recordLoginFailure", "setLoginLockout", "clearLoginFailures",
+						"setPreferences",
 						"setMaxCollections", "setCollectionPermissions",
-						"getSub", "modSub", "setSubEffects"}
+						"setCollectionLock", "setCollectionValuation",
+						"bumpCollectionVersion", "lockCollection", "setCollectionTags",
+						"getCollectionPage", "countCollections",
+						"renameCollection", "renameCollectionHistory",
+						"removeCollectionContents", "removeCollection",
+						"lockSub", "countActiveWebhooks", "addWebhook", "getWebhooks",
+						"addAlert", "getAlerts", "getAllAlerts", "removeAlert",
+						"markAlertFired",
+						"recordWebhookFailure", "recordWebhookSuccess",
+						"addEvent", "trimEvents", "getEvents", "getFootprint",
+						"addDeadLetter", "getDeadLetters", "getDeadLetter",
+						"recordDeadLetterFailure", "removeDeadLetter",
+						"getSub", "modSub", "setSubEffects",
+						"waivePlanCooldown"}
 const statementLoc string = "sql"
 const statementExtension string = ".sql"
 
 // Each session can be valid for up to a month and
-// each reset request valid up to one day
+// each reset request valid up to an hour
 //
-// The total time a reset is valid is also the time between resets
-// being able to be sent to that user
+// Invites issued to bulk imported users are resets which stay valid
+// for a week, the users didn't ask for them so may not act at once
+//
+// Sessions may be refreshed but never live beyond six months
+// from when they were created
+//
+// Resets may be sent to a user at most once per resetCooldown
 const hoursPerDay int = 24
 const hoursPerMonth int = 30 * hoursPerDay
 const sessionValidTime = time.Duration(hoursPerMonth) * time.Hour
-const resetValidTime = time.Duration(hoursPerDay) * time.Hour
+const resetValidTime = time.Hour
+const inviteValidTime = 7 * time.Duration(hoursPerDay) * time.Hour
+const resetCooldown = 15 * time.Minute
+const sessionMaxLifetime = 6 * sessionValidTime
 
 var ScanError string = "failed to scan row"
 
+// Returned when the database can't currently be reached, callers
+// should report it as a temporary failure rather than a bad request.
+var ErrUnavailable error = fmt.Errorf("database temporarily unavailable")
+
+// Returned when a session key fails to authenticate a user.
+var ErrBadSession error = fmt.Errorf("invalid Authentication")
+
+// Returned when a reset code matches none issued to the user, or has
+// already been used.
+var ErrBadReset error = fmt.Errorf("invalid reset code")
+
+// Returned when a reset code was issued but is no longer valid.
+var ErrResetExpired error = fmt.Errorf("reset code expired")
+
+// Returned when a reset was sent to the user within resetCooldown.
+var ErrResetThrottled error = fmt.Errorf("reset requested too recently")
+
+// Returned when attempting to modify a collection its owner has locked.
+var ErrCollectionLocked error = fmt.Errorf("collection is locked")
+
+// Returned when attempting to modify a collection beyond the owner's
+// plan cap, which is read only until they upgrade.
+var ErrCollectionOverLimit error = fmt.Errorf("collection beyond plan limit")
+
+// Returned when adding a collection would exceed the plan's cap.
+var ErrCollectionLimit error = fmt.Errorf("collection limit reached")
+
+// Returned when a collection would take one of ReservedCollectionNames.
+var ErrReservedCollectionName error = fmt.Errorf("collection name is reserved")
+
+// Returned when registering a webhook would exceed the plan's limit.
+var ErrWebhookLimit error = fmt.Errorf("webhook limit reached")
+
+// Returned when user supplied text is not valid utf8 and so
+// cannot be safely stored.
+var ErrBadEncoding error = fmt.Errorf("text is not valid utf8")
+
+// Returned when a session has reached its maximum lifetime and
+// cannot be refreshed any further.
+var ErrSessionExhausted error = fmt.Errorf("session lifetime exhausted")
+
+// Returned when a collection would take a name already in use.
+var ErrCollectionExists error = fmt.Errorf("collection already exists")
+
+// Returned when an authenticated request targets a missing collection.
+var ErrNoSuchCollection error = fmt.Errorf("no such collection exists")
+
+// Returned when a user has no valid session with a requested ID.
+var ErrNoSuchSession error = fmt.Errorf("no such session exists")
+
+// Returned when no dead lettered mail has a requested ID.
+var ErrNoSuchDeadLetter error = fmt.Errorf("no such dead letter exists")
+
+// Returned when a collection changed since the version a change was
+// made against, the change should be retried against a fresh read.
+var ErrCollectionConflict error = fmt.Errorf("collection changed concurrently")
+
+// Returned when setting an alert would exceed the plan's limit.
+var ErrAlertLimit error = fmt.Errorf("alert limit reached")
+
+// Returned when a user has no alert with a requested ID.
+var ErrNoSuchAlert error = fmt.Errorf("no such alert exists")
+
+// Returned when a collection holds no trade with a requested ID.
+var ErrNoSuchTrade error = fmt.Errorf("no such trade exists")
+
+// Returned when undoing a trade which has already been undone.
+var ErrTradeReversed error = fmt.Errorf("trade already reversed")
+
+// Returned when an email verification code is incorrect or the
+// email has already been verified.
+var ErrBadVerifyToken error = fmt.Errorf("invalid verification code")
+
+// Returned when an email is not a bare, syntactically valid address.
+var ErrBadEmail error = fmt.Errorf("invalid email address")
+
+// Returned when an email belongs to one of DisposableEmailDomains.
+var ErrDisposableEmail error = fmt.Errorf("disposable email address")
+
+// Returned when changing to an email another user already has.
+var ErrEmailUsed error = fmt.Errorf("email already in use")
+
+// Returned when an email change code is incorrect or no change
+// is pending.
+var ErrBadEmailChangeToken error = fmt.Errorf("invalid email change code")
+
 func fetchRawStatement(name string) (string, error) {
 	
 	loc:= filepath.Join(statementLoc, name)
@@ -111,15 +274,42 @@ func afterConnect(conn *pgx.Conn) (err error) {
 
 }
 
+// Runs fn inside a single transaction, committing only if fn succeeds.
+//
+// Any failure, including failing to commit, leaves none of fn's
+// writes in place.
+func withTx(pool *pgx.ConnPool, fn func(tx *pgx.Tx) error) error {
+
+	tx, err:= pool.Begin()
+	if err!=nil {
+		return fmt.Errorf("failed to grab a transaction, %v", err)
+	}
+	// Make sure we can safely exit at any time
+	defer tx.Rollback()
+
+	err = fn(tx)
+	if err!=nil {
+		return err
+	}
+
+	err = tx.Commit()
+	if err!=nil {
+		return fmt.Errorf("failed to commit transaction, %v", err)
+	}
+
+	return nil
+
+}
+
 // Handles errors with special cases. Prompt is the error that
 // prompted an error to be generated and message is the general
 // message to display in this context without special case.
 func errorHandle(prompt error, message string) error {
 	// Special cases
-	if prompt == pgx.ErrNoRows {
-		return prompt
-	}else if prompt == pgx.ErrDeadConn {
+	if prompt == pgx.ErrNoRows || prompt == ErrBadSession {
 		return prompt
+	}else if IsUnavailable(prompt) {
+		return ErrUnavailable
 	}
 	return fmt.Errorf(message, prompt)
 }
@@ -150,8 +340,20 @@ func Connect() (*pgx.ConnPool, error) {
 
 type Config struct{
 	Host, User, Password, Database string
+
+	// Optional, defaulting to defaultMaxConns and
+	// defaultAcquireTimeout
+	MaxConns int
+	AcquireTimeoutSeconds int
 }
 
+// The most connections a pool opens unless configured otherwise
+const defaultMaxConns int = 50
+
+// How long a query waits for a free connection unless configured
+// otherwise, past which the database is considered unavailable.
+const defaultAcquireTimeout = 10 * time.Second
+
 func readConfig(loc string) (*pgx.ConnPoolConfig, error) {
 	raw, err:= ioutil.ReadFile(loc)
 	if err!= nil{
@@ -181,8 +383,21 @@ func readConfig(loc string) (*pgx.ConnPoolConfig, error) {
 				InsecureSkipVerify: true,
 			},
 		},
-		MaxConnections: 50,
+		MaxConnections: defaultMaxConns,
 		AfterConnect:   afterConnect,
+		AcquireTimeout: defaultAcquireTimeout,
+	}
+	if c.MaxConns > 0 {
+		connPoolConfig.MaxConnections = c.MaxConns
+	}
+	if c.AcquireTimeoutSeconds > 0 {
+		connPoolConfig.AcquireTimeout =
+			time.Duration(c.AcquireTimeoutSeconds) * time.Second
+	}
+
+	// Only log queries when explicitly asked to
+	if QueryLogger != nil {
+		connPoolConfig.Logger = queryLogger{QueryLogger}
 	}
 
 	return &connPoolConfig, nil
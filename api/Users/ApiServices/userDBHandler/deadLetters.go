@@ -0,0 +1,123 @@
+package userDB
+
+import(
+
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx"
+
+)
+
+// The most dead letters returned at once
+const MaxDeadLetters int = 100
+
+// Outbound mail we gave up on delivering, kept so it can be
+// inspected and sent again by hand.
+type DeadLetter struct{
+	ID int64
+	Recipient, Template, Subject string
+
+	// JSON encoded template contents, empty when they carried
+	// credentials and so were never kept.
+	Content string
+
+	LastError string
+	Attempts int32
+	Time time.Time
+}
+
+// Records a mail we gave up on, returning the ID assigned to it.
+// Time is assigned for us as well.
+//
+// No authentication, this is only ever called by the service itself.
+func AddDeadLetter(pool *pgx.ConnPool, letter DeadLetter) (int64, error) {
+
+	// Absent contents are stored as NULL so they read as not kept
+	var content interface{}
+	if letter.Content != "" {
+		content = letter.Content
+	}
+
+	var id int64
+	err:= pool.QueryRow("addDeadLetter",
+		letter.Recipient, letter.Template, letter.Subject,
+		content, letter.LastError, letter.Attempts).Scan(&id)
+	if err!=nil {
+		return 0, fmt.Errorf("failed to record dead letter, %v", err)
+	}
+
+	return id, nil
+
+}
+
+// Acquires up to MaxDeadLetters, oldest first.
+//
+// No authentication, this is meant for admins.
+func GetDeadLetters(pool *pgx.ConnPool) ([]DeadLetter, error) {
+
+	rows, err:= pool.Query("getDeadLetters", MaxDeadLetters)
+	if err!=nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	letters:= make([]DeadLetter, 0)
+	for rows.Next(){
+		l:= DeadLetter{}
+		err = rows.Scan(&l.ID, &l.Recipient, &l.Template, &l.Subject,
+			&l.Content, &l.LastError, &l.Attempts, &l.Time)
+		if err!=nil {
+			return nil, errorHandle(err, ScanError)
+		}
+
+		letters = append(letters, l)
+	}
+
+	return letters, nil
+
+}
+
+// Acquires a single dead letter by its ID.
+//
+// No authentication, this is meant for admins.
+func GetDeadLetter(pool *pgx.ConnPool, id int64) (*DeadLetter, error) {
+
+	l:= DeadLetter{}
+	err:= pool.QueryRow("getDeadLetter", id).Scan(&l.ID,
+		&l.Recipient, &l.Template, &l.Subject,
+		&l.Content, &l.LastError, &l.Attempts, &l.Time)
+	if err == pgx.ErrNoRows {
+		return nil, ErrNoSuchDeadLetter
+	}else if err!=nil {
+		return nil, errorHandle(err, ScanError)
+	}
+
+	return &l, nil
+
+}
+
+// Notes another failed attempt at sending a dead letter.
+func RecordDeadLetterFailure(pool *pgx.ConnPool, id int64,
+	lastError string) error {
+
+	_, err:= pool.Exec("recordDeadLetterFailure", id, lastError)
+	if err!=nil {
+		return fmt.Errorf("failed to record dead letter failure, %v", err)
+	}
+
+	return nil
+
+}
+
+// Forgets a dead letter, typically once it has finally been sent.
+func RemoveDeadLetter(pool *pgx.ConnPool, id int64) error {
+
+	_, err:= pool.Exec("removeDeadLetter", id)
+	if err!=nil {
+		return fmt.Errorf("failed to remove dead letter, %v", err)
+	}
+
+	return nil
+
+}
@@ -0,0 +1,73 @@
+package userDB
+
+import(
+
+	"testing"
+
+)
+
+// Dead letters a message then retrieves, fails and forgets it.
+func TestDeadLetters(t *testing.T) {
+	t.Parallel()
+
+	recipient:= randString(int(randByte()) % 31)
+	id, err:= AddDeadLetter(pool, DeadLetter{
+		Recipient: recipient,
+		Template: "subSuccess",
+		Subject: "foo",
+		Content: `{"Name":"bar"}`,
+		LastError: "baz",
+		Attempts: 3,
+	})
+	if err!=nil {
+		t.Fatal("failed to add dead letter", err)
+	}
+
+	// Sensitive letters keep no contents at all
+	sensitiveID, err:= AddDeadLetter(pool, DeadLetter{
+		Recipient: recipient,
+		Template: "reset",
+		Subject: "foo",
+		LastError: "baz",
+		Attempts: 1,
+	})
+	if err!=nil {
+		t.Fatal("failed to add dead letter without contents", err)
+	}
+
+	letters, err:= GetDeadLetters(pool)
+	if err!=nil || len(letters) == 0 {
+		t.Fatal("failed to list dead letters", err)
+	}
+
+	sensitive, err:= GetDeadLetter(pool, sensitiveID)
+	if err!=nil || sensitive.Content != "" {
+		t.Fatal("dead letter without contents gained some", sensitive, err)
+	}
+
+	found, err:= GetDeadLetter(pool, id)
+	if err!=nil || found.Content != `{"Name":"bar"}` ||
+		found.Attempts != 3 || found.LastError != "baz" ||
+		found.Recipient != recipient {
+		t.Fatal("dead letter not stored", found, err)
+	}
+
+	err = RecordDeadLetterFailure(pool, id, "qux")
+	if err!=nil {
+		t.Fatal("failed to record failure", err)
+	}
+	letter, err:= GetDeadLetter(pool, id)
+	if err!=nil || letter.Attempts != 4 || letter.LastError != "qux" {
+		t.Fatal("failure not recorded", letter, err)
+	}
+
+	err = RemoveDeadLetter(pool, id)
+	if err!=nil {
+		t.Fatal("failed to remove dead letter", err)
+	}
+	_, err = GetDeadLetter(pool, id)
+	if err != ErrNoSuchDeadLetter {
+		t.Fatal("removed dead letter still present", err)
+	}
+
+}
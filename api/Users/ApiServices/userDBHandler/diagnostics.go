@@ -0,0 +1,62 @@
+package userDB
+
+import(
+
+	"time"
+
+	"github.com/jackc/pgx"
+
+)
+
+// Aggregate figures describing a user, for support staff.
+//
+// Nothing here identifies a session or credential.
+type Footprint struct{
+	// Only those still valid are counted
+	Sessions int64
+	Collections int64
+
+	// Rows stored across every collection of the user
+	ContentRows, HistoryRows int64
+
+	// The latest login, collection change or event, zero if never
+	LastActivity time.Time
+}
+
+// Acquires the footprint of a user with no authentication.
+//
+// Administrative usage only.
+func GetFootprint(pool *pgx.ConnPool, user string) (*Footprint, error) {
+
+	f:= Footprint{}
+
+	err:= pool.QueryRow("getFootprint", user).Scan(&f.Sessions,
+		&f.Collections, &f.ContentRows, &f.HistoryRows, &f.LastActivity)
+	if err!=nil {
+		return nil, errorHandle(err, ScanError)
+	}
+
+	if f.LastActivity.Unix() == 0 {
+		f.LastActivity = time.Time{}
+	}
+
+	return &f, nil
+
+}
+
+// Acquires up to limit of the most recent events of a user, most
+// recent first, with no authentication.
+//
+// Administrative usage only.
+func GetRecentEvents(pool *pgx.ConnPool, user string,
+	limit int) ([]Event, error) {
+
+	rows, err := pool.Query("getEvents", user, FeedStart, limit)
+	if err!=nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanEvents(rows)
+
+}
@@ -0,0 +1,53 @@
+package userDB
+
+import(
+
+	"testing"
+
+	"time"
+
+)
+
+func TestFootprint(t *testing.T) {
+	t.Parallel()
+
+	user:= randString(int(randByte()) % 31)
+	key, err:= AddUser(pool, user, "bar", "foo")
+	if err!=nil {
+		t.Fatal("failed to add user ", err)
+	}
+
+	time.Sleep(stepSleepTime)
+
+	collection:= randString(int(randByte()))
+	err = AddCollection(pool, key, user, collection)
+	if err!=nil {
+		t.Fatal(err)
+	}
+
+	err = AddCards(pool, key, user, collection, randomCards(3), 0)
+	if err!=nil {
+		t.Fatal(err)
+	}
+
+	time.Sleep(stepSleepTime)
+
+	f, err:= GetFootprint(pool, user)
+	if err!=nil {
+		t.Fatal("failed to acquire footprint", err)
+	}
+	if f.Sessions != 1 || f.Collections != 1 ||
+		f.ContentRows != 3 || f.HistoryRows != 3 {
+		t.Fatal("incorrect footprint", f)
+	}
+	if f.LastActivity.IsZero() {
+		t.Fatal("activity not reflected in footprint", f)
+	}
+
+	events, err:= GetRecentEvents(pool, user, 1)
+	if err!=nil || len(events) != 1 ||
+		events[0].Kind != EventTradesAdded {
+		t.Fatal("most recent event not acquired", events, err)
+	}
+
+}
@@ -0,0 +1,71 @@
+package userDB
+
+import(
+
+	"net/mail"
+	"strings"
+
+)
+
+// The longest address deliverable over SMTP
+const emailMaxLength int = 254
+
+// Domains handing out throwaway addresses, signups using them are
+// refused. Replaceable at startup.
+var DisposableEmailDomains = []string{
+	"mailinator.com", "guerrillamail.com", "guerrillamail.net",
+	"10minutemail.com", "tempmail.com", "temp-mail.org",
+	"throwawaymail.com", "yopmail.com", "trashmail.com",
+	"sharklasers.com", "getnada.com", "dispostable.com",
+	"maildrop.cc", "fakeinbox.com",
+}
+
+// Determines if a domain is, or is beneath, one of
+// DisposableEmailDomains
+func disposableDomain(domain string) bool {
+
+	for _, disposable:= range DisposableEmailDomains{
+		disposable = strings.ToLower(disposable)
+		if domain == disposable ||
+			strings.HasSuffix(domain, "." + disposable) {
+			return true
+		}
+	}
+
+	return false
+
+}
+
+// Validates an email address, returning it as it should be stored.
+//
+// Surrounding whitespace is trimmed and the domain lower cased, the
+// local part is left alone as only the receiving server may interpret
+// its case. Display names, comments and domains without a dot are
+// refused with ErrBadEmail, addresses at DisposableEmailDomains with
+// ErrDisposableEmail.
+func NormalizeEmail(email string) (string, error) {
+
+	email = strings.TrimSpace(email)
+	if len(email) == 0 || len(email) > emailMaxLength {
+		return "", ErrBadEmail
+	}
+
+	parsed, err:= mail.ParseAddress(email)
+	if err!=nil || parsed.Name != "" || parsed.Address != email {
+		return "", ErrBadEmail
+	}
+
+	at:= strings.LastIndex(email, "@")
+	local, domain:= email[:at], strings.ToLower(email[at + 1:])
+	if !strings.Contains(domain, ".") ||
+		strings.HasPrefix(domain, ".") || strings.HasSuffix(domain, ".") {
+		return "", ErrBadEmail
+	}
+
+	if disposableDomain(domain) {
+		return "", ErrDisposableEmail
+	}
+
+	return local + "@" + domain, nil
+
+}
@@ -0,0 +1,118 @@
+package userDB
+
+import(
+
+	"fmt"
+
+	"crypto/sha256"
+
+	"unicode/utf8"
+
+	"github.com/jackc/pgx"
+
+)
+
+// Determines if an email is used by another user given the result
+// of countEmailUsers.
+func emailUsed(row *pgx.Row) (bool, error) {
+
+	var users int64
+	err:= row.Scan(&users)
+	if err!=nil {
+		return false, errorHandle(err, ScanError)
+	}
+
+	return users > 0, nil
+
+}
+
+// Stages a change of email for an authenticated user, returning the
+// code which must be mailed to the new address to confirm it.
+//
+// Only the hash of the code is stored and any previously requested
+// change stops working. The email stays unchanged until confirmed.
+// Emails used by another user receive ErrEmailUsed.
+func RequestEmailChange(pool *pgx.ConnPool, sessionKey []byte,
+	user, email string) (string, error) {
+
+	// Authenticate the request
+	err:= SessionAuth(pool, user, sessionKey)
+	if err!=nil{
+		return "", err
+	}
+
+	if !utf8.ValidString(email) {
+		return "", ErrBadEncoding
+	}
+
+	used, err:= emailUsed(pool.QueryRow("countEmailUsers", email, user))
+	if err!=nil {
+		return "", err
+	}
+	if used {
+		return "", ErrEmailUsed
+	}
+
+	code:= randString(ResetLength)
+	hashed:= sha256.Sum256([]byte(code))
+
+	_, err = pool.Exec("setEmailChange", user, email, hashed[:])
+	if err!=nil {
+		return "", fmt.Errorf("failed to stage email change, %v", err)
+	}
+
+	return code, nil
+
+}
+
+// Changes a user's email to the one pending if the provided code is
+// the one most recently mailed to it, returning the previous email.
+//
+// Claims on an email are serialized so two users can't race to the
+// same one, the loser receives ErrEmailUsed. Proving ownership of the
+// new email also verifies it.
+func ConfirmEmailChange(pool *pgx.ConnPool,
+	user, code string) (string, error) {
+
+	hashed:= sha256.Sum256([]byte(code))
+
+	var previous string
+	err:= withTx(pool, func(tx *pgx.Tx) error {
+
+		var email string
+		err:= tx.QueryRow("getPendingEmail", user, hashed[:]).Scan(&previous,
+			&email)
+		if err == pgx.ErrNoRows {
+			return ErrBadEmailChangeToken
+		}
+		if err!=nil {
+			return errorHandle(err, ScanError)
+		}
+
+		_, err = tx.Exec("lockEmail", email)
+		if err!=nil {
+			return fmt.Errorf("failed to lock email, %v", err)
+		}
+
+		used, err:= emailUsed(tx.QueryRow("countEmailUsers", email, user))
+		if err!=nil {
+			return err
+		}
+		if used {
+			return ErrEmailUsed
+		}
+
+		_, err = tx.Exec("changeEmail", user, email)
+		if err!=nil {
+			return fmt.Errorf("failed to change email, %v", err)
+		}
+
+		return nil
+	})
+	if err!=nil {
+		return "", err
+	}
+
+	return previous, nil
+
+}
@@ -0,0 +1,72 @@
+package userDB
+
+import(
+
+	"testing"
+	"time"
+
+)
+
+func TestEmailChange(t *testing.T) {
+	t.Parallel()
+
+	user:= randString(int(randByte()) % 31)
+	key, err:= AddUser(pool, user, "old@example.com", "bar")
+	if err!=nil {
+		t.Fatal("failed to add user ", err)
+	}
+
+	email:= randString(16) + "@example.com"
+
+	time.Sleep(stepSleepTime)
+
+	_, err = RequestEmailChange(pool, []byte("nope"), user, email)
+	if err != ErrBadSession {
+		t.Fatal("email change requested without a session", err)
+	}
+
+	code, err:= RequestEmailChange(pool, key, user, email)
+	if err!=nil {
+		t.Fatal("failed to request email change", err)
+	}
+
+	time.Sleep(stepSleepTime)
+
+	_, err = ConfirmEmailChange(pool, user, "wrong")
+	if err != ErrBadEmailChangeToken {
+		t.Fatal("email changed with an incorrect code", err)
+	}
+
+	previous, err:= ConfirmEmailChange(pool, user, code)
+	if err!=nil || previous != "old@example.com" {
+		t.Fatal("failed to confirm email change", previous, err)
+	}
+
+	u, err:= GetUser(pool, user)
+	if err!=nil {
+		t.Fatal(err)
+	}
+	if u.Email != email || !u.Verified {
+		t.Fatal("email not changed and verified", u.Email, u.Verified)
+	}
+
+	_, err = ConfirmEmailChange(pool, user, code)
+	if err != ErrBadEmailChangeToken {
+		t.Fatal("email change code used twice", err)
+	}
+
+	// Someone else can no longer claim the address
+	other:= randString(int(randByte()) % 31)
+	otherKey, err:= AddUser(pool, other, "other@example.com", "bar")
+	if err!=nil {
+		t.Fatal("failed to add user ", err)
+	}
+
+	time.Sleep(stepSleepTime)
+
+	_, err = RequestEmailChange(pool, otherKey, other, email)
+	if err != ErrEmailUsed {
+		t.Fatal("used email accepted", err)
+	}
+
+}
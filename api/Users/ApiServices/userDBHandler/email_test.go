@@ -0,0 +1,40 @@
+package userDB
+
+import(
+
+	"testing"
+
+)
+
+func TestNormalizeEmail(t *testing.T) {
+
+	normalized:= map[string]string{
+		"  foo@Example.COM ": "foo@example.com",
+		"Foo.Bar+mtg@mail.example.co.uk": "Foo.Bar+mtg@mail.example.co.uk",
+	}
+	for email, expected:= range normalized{
+		actual, err:= NormalizeEmail(email)
+		if err!=nil || actual != expected {
+			t.Fatal("incorrectly normalized", email, actual, err)
+		}
+	}
+
+	malformed:= []string{"", "notanemail", "foo@", "@example.com",
+		"foo@localhost", "foo@example.com.", "Foo <foo@example.com>",
+		"foo@example.com, bar@example.com", "foo bar@example.com"}
+	for _, email:= range malformed{
+		_, err:= NormalizeEmail(email)
+		if err != ErrBadEmail {
+			t.Fatal("malformed email accepted", email, err)
+		}
+	}
+
+	for _, email:= range []string{"foo@mailinator.com",
+		"foo@eu.MAILINATOR.com"}{
+		_, err:= NormalizeEmail(email)
+		if err != ErrDisposableEmail {
+			t.Fatal("disposable email accepted", email, err)
+		}
+	}
+
+}
@@ -5,8 +5,8 @@ import(
 	"fmt"
 	"time"
 
-	"crypto/subtle"
 	"crypto/sha256"
+	"encoding/hex"
 
 	"github.com/jackc/pgx"
 
@@ -17,20 +17,50 @@ import(
 // to guess within an expiry period.
 const ResetLength int = 20
 
+// How long a session is used before noting it was used again,
+// noting every use would turn every read into a write.
+const sessionTouchInterval time.Duration = 5 * time.Minute
+
+// How many bytes of a hashed session key identify it publicly
+const sessionIDLength int = 8
+
 type Session struct{
 	Name string
 	SessionKey []byte	
 	StartValid, EndValid time.Time
+	LastUsed time.Time
+
+	// Identifies the session without revealing its key, only set
+	// when listing
+	ID string
+}
+
+// Derives the public ID of a session from the hash of its key.
+func hashedSessionID(hashed []byte) string {
+	return hex.EncodeToString(hashed[:sessionIDLength])
+}
+
+// Derives the public ID of the session a key belongs to.
+func SessionID(sessionKey []byte) string {
+	hashed:= sha256.Sum256(sessionKey)
+	return hashedSessionID(hashed[:])
 }
 
 // Commits a provided session off to the postgres backend
 func SendSession(pool *pgx.ConnPool, session Session) error {
 	
-	_, err:= pool.Exec("addSession",
-					session.Name, session.SessionKey,
-					session.StartValid, session.EndValid)
+	return withTx(pool, func(tx *pgx.Tx) error {
+		_, err:= tx.Exec("addSession",
+						session.Name, session.SessionKey,
+						session.StartValid, session.EndValid)
+		if err!=nil {
+			return err
+		}
 
-	return err
+		_, err = tx.Exec("bumpSessionsVersion", session.Name)
+
+		return err
+	})
 
 }
 
@@ -71,6 +101,9 @@ func AddSession(pool *pgx.ConnPool, user string) ([]byte, error) {
 // pair existing on the database that is valid.
 //
 // Constant time relative to the number of session keys on the user
+//
+// Only ErrBadSession means the session is invalid, any other error is
+// the database failing and should be reported as such.
 func SessionAuth(pool *pgx.ConnPool, user string, 
 	sessionKey []byte) error {
 	
@@ -87,31 +120,283 @@ func SessionAuth(pool *pgx.ConnPool, user string,
 	for rows.Next(){
 		s:= Session{}
 		err = rows.Scan(&s.Name, &s.SessionKey,
-			&s.StartValid, &s.EndValid)
+			&s.StartValid, &s.EndValid, &s.LastUsed)
 		if err!=nil {
 			return errorHandle(err, ScanError)
 		}
 
 		// Perform validation
 		if s.Name == user &&
-		subtle.ConstantTimeCompare(hashed[:], s.SessionKey) == 1 &&
+		secretsEqual(hashed[:], s.SessionKey) &&
 		now.Before(s.EndValid) && now.After(s.StartValid) {
+			rows.Close()
+
+			// Failing to note a use is never worth failing the request
+			if now.Sub(s.LastUsed) > sessionTouchInterval {
+				pool.Exec("touchSession", user, hashed[:])
+			}
+
 			return nil
 		}
 	}
+	if rows.Err()!=nil {
+		return rows.Err()
+	}
 
-	return fmt.Errorf("invalid Authentication")
+	return ErrBadSession
+
+}
+
+// Slides the expiry of a still valid session forward to a full
+// sessionValidTime from now, capped at sessionMaxLifetime after
+// the session was created.
+//
+// Sessions which expired no more than grace ago may still be
+// refreshed, smoothing over clock skew between us and clients.
+// They remain unable to authenticate anything else.
+//
+// Returns the new expiry of the session.
+func RefreshSession(pool *pgx.ConnPool, user string,
+	sessionKey []byte, grace time.Duration) (time.Time, error) {
+
+	hashed:= sha256.Sum256(sessionKey)
+
+	now:= time.Now()
+	cutoff:= now.Add(-grace)
+
+	rows, err := pool.Query("getRefreshableSessions", user, hashed[:], cutoff)
+	if err!=nil {
+		return time.Time{}, err
+	}
+	defer rows.Close()
+
+	var found *Session
+	for rows.Next(){
+		s:= Session{}
+		err = rows.Scan(&s.Name, &s.SessionKey,
+			&s.StartValid, &s.EndValid)
+		if err!=nil {
+			return time.Time{}, errorHandle(err, ScanError)
+		}
+
+		if s.Name == user &&
+		secretsEqual(hashed[:], s.SessionKey) &&
+		cutoff.Before(s.EndValid) && now.After(s.StartValid) {
+			found = &s
+		}
+	}
+	rows.Close()
+
+	if found == nil {
+		return time.Time{}, ErrBadSession
+	}
+
+	endValid:= refreshedExpiry(*found, now)
+	if !endValid.After(found.EndValid) {
+		return time.Time{}, ErrSessionExhausted
+	}
+
+	err = withTx(pool, func(tx *pgx.Tx) error {
+		_, err:= tx.Exec("extendSession", user, hashed[:], endValid, cutoff)
+		if err!=nil {
+			return err
+		}
+
+		_, err = tx.Exec("bumpSessionsVersion", user)
+
+		return err
+	})
+	if err!=nil {
+		return time.Time{}, errorHandle(err, "failed to extend session")
+	}
+
+	return endValid, nil
+
+}
+
+// Determines when a session refreshed at now should expire.
+func refreshedExpiry(s Session, now time.Time) time.Time {
+
+	endValid:= now.Add(sessionValidTime)
+
+	limit:= s.StartValid.Add(sessionMaxLifetime)
+	if endValid.After(limit) {
+		endValid = limit
+	}
+
+	return endValid
 
 }
 
 // Remove an existing session.
+//
+// Sessions are stored hashed so the key is hashed before lookup,
+// just as it is to authenticate.
 func Logout(pool *pgx.ConnPool, user string, 
 	sessionKey []byte) error {
+
+	hashed:= sha256.Sum256(sessionKey)
 	
-	_, err:= pool.Exec("removeSession",
-					user, sessionKey)
+	return withTx(pool, func(tx *pgx.Tx) error {
+		_, err:= tx.Exec("removeSession",
+						user, hashed[:])
+		if err!=nil {
+			return err
+		}
 
-	return err
+		_, err = tx.Exec("bumpSessionsVersion", user)
+
+		return err
+	})
+
+}
+
+// Removes every session of a user with no authentication.
+//
+// Internal usage only, such as after a suspected compromise.
+func LogoutAllSessions(pool *pgx.ConnPool, user string) error {
+
+	return withTx(pool, func(tx *pgx.Tx) error {
+		_, err:= tx.Exec("removeAllSessions", user)
+		if err!=nil {
+			return err
+		}
+
+		_, err = tx.Exec("bumpSessionsVersion", user)
+
+		return err
+	})
+
+}
+
+// Removes every session of an authenticated user, except the session
+// authenticating the request when keepCurrent is set.
+func LogoutAll(pool *pgx.ConnPool, sessionKey []byte,
+	user string, keepCurrent bool) error {
+
+	// Authenticate the request
+	err:= SessionAuth(pool, user, sessionKey)
+	if err!=nil{
+		return err
+	}
+
+	if !keepCurrent {
+		return LogoutAllSessions(pool, user)
+	}
+
+	hashed:= sha256.Sum256(sessionKey)
+
+	return withTx(pool, func(tx *pgx.Tx) error {
+		_, err:= tx.Exec("removeOtherSessions", user, hashed[:])
+		if err!=nil {
+			return err
+		}
+
+		_, err = tx.Exec("bumpSessionsVersion", user)
+
+		return err
+	})
+
+}
+
+// Acquires the version of a user's sessions, which changes whenever
+// a session is added, refreshed or removed.
+//
+// Polling clients can compare versions rather than refetching every
+// session. Expiry alone does not change the version.
+func SessionsVersion(pool *pgx.ConnPool, user string) (int64, error) {
+
+	var version int64
+	err:= pool.QueryRow("getSessionsVersion", user).Scan(&version)
+	if err!=nil {
+		return 0, errorHandle(err, ScanError)
+	}
+
+	return version, nil
+
+}
+
+// Counts the sessions which are currently valid across every user.
+func CountActiveSessions(pool *pgx.ConnPool) (int64, error) {
+
+	var count int64
+	err:= pool.QueryRow("countActiveSessions", time.Now()).Scan(&count)
+	if err!=nil {
+		return 0, errorHandle(err, ScanError)
+	}
+
+	return count, nil
+
+}
+
+// Acquires every valid session of an authenticated user, oldest first.
+//
+// Session keys are never included, each session is identified by
+// its ID instead.
+func ListSessions(pool *pgx.ConnPool, sessionKey []byte,
+	user string) ([]Session, error) {
+
+	// Authenticate the request
+	err:= SessionAuth(pool, user, sessionKey)
+	if err!=nil{
+		return nil, err
+	}
+
+	rows, err := pool.Query("listSessions", user)
+	if err!=nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	sessions:= make([]Session, 0)
+	for rows.Next(){
+		s:= Session{Name: user}
+		var hashed []byte
+		err = rows.Scan(&hashed, &s.StartValid, &s.EndValid, &s.LastUsed)
+		if err!=nil {
+			return nil, errorHandle(err, ScanError)
+		}
+		s.ID = hashedSessionID(hashed)
+
+		sessions = append(sessions, s)
+	}
+
+	return sessions, nil
+
+}
+
+// Removes the session with the provided ID from an authenticated user,
+// which may be the session authenticating the request.
+//
+// Returns ErrNoSuchSession if the user has no such session.
+func RevokeSession(pool *pgx.ConnPool, sessionKey []byte,
+	user, id string) error {
+
+	// Authenticate the request
+	err:= SessionAuth(pool, user, sessionKey)
+	if err!=nil{
+		return err
+	}
+
+	prefix, err:= hex.DecodeString(id)
+	if err!=nil || len(prefix) != sessionIDLength {
+		return ErrNoSuchSession
+	}
+
+	return withTx(pool, func(tx *pgx.Tx) error {
+		tag, err:= tx.Exec("revokeSession", user, prefix,
+			int32(sessionIDLength))
+		if err!=nil {
+			return err
+		}
+		if tag.RowsAffected() == 0 {
+			return ErrNoSuchSession
+		}
+
+		_, err = tx.Exec("bumpSessionsVersion", user)
+
+		return err
+	})
 
 }
 
@@ -133,23 +418,21 @@ func SendReset(pool *pgx.ConnPool, reset Reset) error {
 }
 
 // Generates a request reset by inserting a reset key valid for this user
+//
+// Users are sent at most one reset per resetCooldown, requests
+// sooner are ErrResetThrottled.
 func RequestReset(pool *pgx.ConnPool, user string) (string, error) {
+	return requestReset(pool, user, resetValidTime)
+}
+
+// Generates a reset as RequestReset does, valid for validFor.
+func requestReset(pool *pgx.ConnPool, user string,
+	validFor time.Duration) (string, error) {
 
-	// Ensure the user hasn't received a reset code within
-	// the possible duration of another valid reset code
 	now:= time.Now()
-	resets, err:= getAllResets(pool, user)
+	_, err:= GetUser(pool, user)
 	if err!=nil {
-		return "", errorHandle(err, "failed to acquire all resets")
-	}
-
-	for _, r:= range resets{
-		// A reset code valid after the now + resetValidTime
-		// means we don't send another token.
-		delta:= now.Sub(r.EndValid)
-		if delta < resetValidTime {
-			return "", fmt.Errorf("requested reset when valid already exists")
-		}
+		return "", errorHandle(err, "failed to fetch user")
 	}
 
 	// Acquire a fresh session key of length 256 bits
@@ -162,19 +445,41 @@ func RequestReset(pool *pgx.ConnPool, user string) (string, error) {
 		Name: user,
 		ResetKey: hashed[:],
 		StartValid: now,
-		EndValid: now.Add(resetValidTime),
+		EndValid: now.Add(validFor),
 	}
 
-	// Send the session off
-	err = SendReset(pool, freshReset)
+	// Claiming the send alongside the reset means concurrent requests
+	// can't both get through
+	err = withTx(pool, func(tx *pgx.Tx) error {
+		tag, err:= tx.Exec("markResetSent", user, now,
+			now.Add(-resetCooldown))
+		if err!=nil {
+			return err
+		}
+		if tag.RowsAffected() == 0 {
+			return ErrResetThrottled
+		}
+
+		_, err = tx.Exec("addReset",
+			freshReset.Name, freshReset.ResetKey,
+			freshReset.StartValid, freshReset.EndValid)
+		return err
+	})
+	if err == ErrResetThrottled {
+		return "", err
+	}
 	if err!=nil {
 		return "", errorHandle(err, "failed to send fresh reset off to db")
 	}
 
-	return key, err
+	return key, nil
 
 }
 
+// Ensures a reset code was issued to the user and is still valid.
+//
+// Codes which were issued but have since expired are ErrResetExpired,
+// every other failure is ErrBadReset.
 func ValidateReset(pool *pgx.ConnPool, user, resetKey string) error {
 	
 	// Request a hash matching the key's hash.
@@ -187,6 +492,7 @@ func ValidateReset(pool *pgx.ConnPool, user, resetKey string) error {
 	defer rows.Close()
 
 	now:= time.Now()
+	expired:= false
 	for rows.Next(){
 		r:= Reset{}
 		err = rows.Scan(&r.Name, &r.ResetKey,
@@ -197,38 +503,39 @@ func ValidateReset(pool *pgx.ConnPool, user, resetKey string) error {
 
 		// Perform validation
 		if r.Name == user &&
-		subtle.ConstantTimeCompare(hashed[:],
-			[]byte(r.ResetKey)) == 1 &&
+		secretsEqual(hashed[:], []byte(r.ResetKey)) &&
 		now.Before(r.EndValid) && now.After(r.StartValid) {
 			return nil
 		}
+		if r.Name == user && !now.Before(r.EndValid) {
+			expired = true
+		}
+	}
+
+	if expired {
+		return ErrResetExpired
 	}
 
-	return fmt.Errorf("invalid Authentication")
+	return ErrBadReset
 
 }
 
-// Acquires all valid resets for a given user
-func getAllResets(pool *pgx.ConnPool, user string) ([]Reset, error) {
+// Removes a valid reset so it may only be used once.
+//
+// Use as a transaction alongside whatever the reset authorizes,
+// a reset already removed by a concurrent use is ErrBadReset.
+func consumeReset(tx *pgx.Tx, user, resetKey string) error {
 
-	rows, err := pool.Query("getAllResets", user)
+	hashed:= sha256.Sum256([]byte(resetKey))
+
+	tag, err:= tx.Exec("consumeReset", user, hashed[:])
 	if err!=nil {
-		return nil, err
+		return err
 	}
-	defer rows.Close()
-
-	resets:= make([]Reset, 0)
-
-	for rows.Next(){
-		r:= Reset{}
-		err = rows.Scan(&r.Name, &r.ResetKey,
-			&r.StartValid, &r.EndValid)
-		if err!=nil {
-			return nil, errorHandle(err, ScanError)
-		}
-
-		resets = append(resets, r)
+	if tag.RowsAffected() == 0 {
+		return ErrBadReset
 	}
 
-	return resets, nil
-}
\ No newline at end of file
+	return nil
+
+}
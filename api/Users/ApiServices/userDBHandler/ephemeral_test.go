@@ -6,6 +6,8 @@ import(
 
 	"time"
 
+	"crypto/sha256"
+
 )
 
 // Add some sessions to the remote db
@@ -49,6 +51,11 @@ func TestSessions(t *testing.T) {
 		if err!=nil {
 			t.Fatal("failed to logout", err)
 		}
+
+		err = SessionAuth(pool, user, key)
+		if err != ErrBadSession {
+			t.Fatal("session still valid after logout", err)
+		}
 	}
 
 }
@@ -97,9 +104,80 @@ func TestResets(t *testing.T) {
 
 }
 
+// A reset may change the password once, after which it and every
+// other reset of the user are refused.
+func TestResetSingleUse(t *testing.T) {
+	t.Parallel()
+
+	user:= randString(int(randByte()))
+	_, err:= AddUser(pool, user, "bar", "foo")
+	if err!=nil {
+		t.Fatal("failed to add user ", err)
+	}
+
+	key, err:= RequestReset(pool, user)
+	if err!=nil {
+		t.Fatal(err)
+	}
+
+	time.Sleep(stepSleepTime)
+
+	err = ChangePassword(pool, user, "correct horse battery", key)
+	if err!=nil {
+		t.Fatal("valid reset refused", err)
+	}
+
+	err = ChangePassword(pool, user, "another horse battery", key)
+	if err != ErrBadReset {
+		t.Fatal("reset used twice", err)
+	}
+
+}
+
+// Expired resets are told apart from those never issued.
+func TestResetExpired(t *testing.T) {
+	t.Parallel()
+
+	user:= randString(int(randByte()))
+	_, err:= AddUser(pool, user, "bar", "foo")
+	if err!=nil {
+		t.Fatal("failed to add user ", err)
+	}
+
+	key:= randString(ResetLength)
+	hashed:= sha256.Sum256([]byte(key))
+	issued:= time.Now().Add(-2 * resetValidTime)
+	err = SendReset(pool, Reset{
+		Name: user,
+		ResetKey: hashed[:],
+		StartValid: issued,
+		EndValid: issued.Add(resetValidTime),
+	})
+	if err!=nil {
+		t.Fatal("failed to send reset", err)
+	}
+
+	time.Sleep(stepSleepTime)
+
+	err = ValidateReset(pool, user, key)
+	if err != ErrResetExpired {
+		t.Fatal("expired reset not reported as such", err)
+	}
+	err = ChangePassword(pool, user, "correct horse battery", key)
+	if err != ErrResetExpired {
+		t.Fatal("expired reset changed password", err)
+	}
+
+	err = ValidateReset(pool, user, randString(ResetLength))
+	if err != ErrBadReset {
+		t.Fatal("unknown reset not reported as invalid", err)
+	}
+
+}
+
 // Add a user, request a reset for that user,
 // and ensure that we can't request another due to the wait
-// period enforced upon reset requests to be resetValidTime
+// period enforced upon reset requests to be resetCooldown
 func TestRestRateLimit(t *testing.T) {
 	user:= randString(210)
 
@@ -118,8 +196,356 @@ func TestRestRateLimit(t *testing.T) {
 	time.Sleep(testSleepTime)
 
 	_, err = RequestReset(pool, user)
-	if err==nil {
-		t.Fatal("was capable of requesting a reset code within resetValidTime of another reset code")
+	if err != ErrResetThrottled {
+		t.Fatal("was capable of requesting a reset code within resetCooldown of another reset code", err)
+	}
+
+}
+
+// Ensure session keys are compared in constant time. Not parallel as
+// it changes secretsEqual.
+func TestSessionConstantTime(t *testing.T) {
+
+	user:= randString(int(randByte()) % 31)
+	key, err:= AddUser(pool, user, "bar", "foo")
+	if err!=nil {
+		t.Fatal("failed to add user ", err)
+	}
+
+	time.Sleep(stepSleepTime)
+
+	previous:= secretsEqual
+	defer func() { secretsEqual = previous }()
+
+	compared:= 0
+	secretsEqual = func(a, b []byte) bool {
+		compared++
+		return previous(a, b)
+	}
+
+	err = SessionAuth(pool, user, key)
+	if err!=nil {
+		t.Fatal("failed to authenticate session", err)
+	}
+	if compared == 0 {
+		t.Fatal("session key compared without secretsEqual")
 	}
 
-}
\ No newline at end of file
+}
+
+// Sends a session with arbitrary validity for the user, returning its key
+func sendTestSession(t *testing.T, user string,
+	startValid, endValid time.Time) []byte {
+
+	key, err:= getArrayOfRandBytes(32)
+	if err!=nil {
+		t.Fatal(err)
+	}
+	hashed:= sha256.Sum256(key)
+
+	err = SendSession(pool, Session{
+		Name: user,
+		SessionKey: hashed[:],
+		StartValid: startValid,
+		EndValid: endValid,
+	})
+	if err!=nil {
+		t.Fatal("failed to send session", err)
+	}
+
+	return key
+
+}
+
+// How long after expiry sessions may be refreshed during tests
+const testRefreshGrace time.Duration = 5 * time.Minute
+
+func TestRefreshSession(t *testing.T) {
+	t.Parallel()
+
+	user:= randString(int(randByte()))
+	_, err:= AddUser(pool, user, "bar", "foo")
+	if err!=nil {
+		t.Fatal("failed to add user", err)
+	}
+
+	// A session close to expiring
+	now:= time.Now()
+	key:= sendTestSession(t, user, now.Add(-time.Hour), now.Add(time.Minute))
+
+	time.Sleep(testSleepTime)
+
+	endValid, err:= RefreshSession(pool, user, key, testRefreshGrace)
+	if err!=nil {
+		t.Fatal("failed to refresh valid session", err)
+	}
+	if endValid.Before(now.Add(sessionValidTime)) {
+		t.Fatal("session expiry not extended", endValid)
+	}
+
+	time.Sleep(testSleepTime)
+
+	err = SessionAuth(pool, user, key)
+	if err!=nil {
+		t.Fatal("refreshed session failed to authenticate", err)
+	}
+
+}
+
+// Sessions which expired beyond the grace window must login again.
+func TestRefreshExpiredSession(t *testing.T) {
+	t.Parallel()
+
+	user:= randString(int(randByte()))
+	_, err:= AddUser(pool, user, "bar", "foo")
+	if err!=nil {
+		t.Fatal("failed to add user", err)
+	}
+
+	now:= time.Now()
+	key:= sendTestSession(t, user, now.Add(-2 * time.Hour), now.Add(-time.Hour))
+
+	time.Sleep(testSleepTime)
+
+	_, err = RefreshSession(pool, user, key, testRefreshGrace)
+	if err != ErrBadSession {
+		t.Fatal("refreshed an expired session", err)
+	}
+
+}
+
+// A session which only just expired may refresh but do nothing else.
+func TestRefreshSessionWithinGrace(t *testing.T) {
+	t.Parallel()
+
+	user:= randString(int(randByte()))
+	_, err:= AddUser(pool, user, "bar", "foo")
+	if err!=nil {
+		t.Fatal("failed to add user", err)
+	}
+
+	now:= time.Now()
+	key:= sendTestSession(t, user, now.Add(-time.Hour), now.Add(-time.Minute))
+
+	time.Sleep(testSleepTime)
+
+	err = SessionAuth(pool, user, key)
+	if err != ErrBadSession {
+		t.Fatal("session within grace authenticated", err)
+	}
+
+	endValid, err:= RefreshSession(pool, user, key, testRefreshGrace)
+	if err!=nil {
+		t.Fatal("failed to refresh session within grace", err)
+	}
+	if endValid.Before(now.Add(sessionValidTime)) {
+		t.Fatal("session expiry not extended", endValid)
+	}
+
+	time.Sleep(testSleepTime)
+
+	err = SessionAuth(pool, user, key)
+	if err!=nil {
+		t.Fatal("refreshed session failed to authenticate", err)
+	}
+
+}
+
+func TestRefreshSessionLifetimeCap(t *testing.T) {
+	t.Parallel()
+
+	user:= randString(int(randByte()))
+	_, err:= AddUser(pool, user, "bar", "foo")
+	if err!=nil {
+		t.Fatal("failed to add user", err)
+	}
+
+	// A session nearing its maximum lifetime
+	now:= time.Now()
+	startValid:= now.Add(-sessionMaxLifetime + time.Hour)
+	key:= sendTestSession(t, user, startValid, now.Add(time.Minute))
+
+	time.Sleep(testSleepTime)
+
+	endValid, err:= RefreshSession(pool, user, key, testRefreshGrace)
+	if err!=nil {
+		t.Fatal("failed to refresh session", err)
+	}
+	if endValid.After(startValid.Add(sessionMaxLifetime)) {
+		t.Fatal("session refreshed beyond its maximum lifetime", endValid)
+	}
+
+	time.Sleep(testSleepTime)
+
+	_, err = RefreshSession(pool, user, key, testRefreshGrace)
+	if err != ErrSessionExhausted {
+		t.Fatal("refreshed a session at its maximum lifetime", err)
+	}
+
+}
+
+// Polling the version should change nothing until a login adds
+// another session.
+func TestSessionsVersion(t *testing.T) {
+	t.Parallel()
+
+	user:= randString(int(randByte()) % 31)
+	key, err:= AddUser(pool, user, "bar", "foo")
+	if err!=nil {
+		t.Fatal("failed to add user ", err)
+	}
+
+	time.Sleep(stepSleepTime)
+
+	version, err:= SessionsVersion(pool, user)
+	if err!=nil {
+		t.Fatal("failed to get sessions version", err)
+	}
+
+	sessions, err:= ListSessions(pool, key, user)
+	if err!=nil || len(sessions) != 1 {
+		t.Fatal("failed to list sessions", sessions, err)
+	}
+
+	polled, err:= SessionsVersion(pool, user)
+	if err!=nil || polled != version {
+		t.Fatal("version changed without any session changing", err)
+	}
+
+	_, err = Login(pool, user, "bar")
+	if err!=nil {
+		t.Fatal("failed to login", err)
+	}
+
+	time.Sleep(stepSleepTime)
+
+	polled, err = SessionsVersion(pool, user)
+	if err!=nil || polled == version {
+		t.Fatal("version unchanged after a login", err)
+	}
+
+	sessions, err = ListSessions(pool, key, user)
+	if err!=nil || len(sessions) != 2 {
+		t.Fatal("login missing from sessions", sessions, err)
+	}
+	for _, s:= range sessions{
+		if s.SessionKey != nil {
+			t.Fatal("session key exposed when listing")
+		}
+	}
+
+	_, err = ListSessions(pool, []byte("bad"), user)
+	if err != ErrBadSession {
+		t.Fatal("sessions listed without a valid session", err)
+	}
+
+}
+
+func TestLogoutAll(t *testing.T) {
+	t.Parallel()
+
+	user:= randString(int(randByte()) % 31)
+	key, err:= AddUser(pool, user, "bar", "foo")
+	if err!=nil {
+		t.Fatal("failed to add user ", err)
+	}
+
+	other, err:= Login(pool, user, "bar")
+	if err!=nil {
+		t.Fatal("failed to login", err)
+	}
+
+	time.Sleep(stepSleepTime)
+
+	err = LogoutAll(pool, key, user, true)
+	if err!=nil {
+		t.Fatal("failed to logout other sessions", err)
+	}
+
+	time.Sleep(stepSleepTime)
+
+	err = SessionAuth(pool, user, other)
+	if err != ErrBadSession {
+		t.Fatal("other session survived logging out", err)
+	}
+	err = SessionAuth(pool, user, key)
+	if err!=nil {
+		t.Fatal("current session not kept", err)
+	}
+
+	err = LogoutAll(pool, key, user, false)
+	if err!=nil {
+		t.Fatal("failed to logout every session", err)
+	}
+
+	time.Sleep(stepSleepTime)
+
+	err = SessionAuth(pool, user, key)
+	if err != ErrBadSession {
+		t.Fatal("current session survived logging out", err)
+	}
+
+	err = LogoutAll(pool, key, user, false)
+	if err != ErrBadSession {
+		t.Fatal("logged out without a valid session", err)
+	}
+
+}
+
+// Revoking one session must leave the others usable.
+func TestRevokeSession(t *testing.T) {
+	t.Parallel()
+
+	user:= randString(int(randByte()) % 31)
+	key, err:= AddUser(pool, user, "bar", "foo")
+	if err!=nil {
+		t.Fatal("failed to add user ", err)
+	}
+
+	lost, err:= Login(pool, user, "bar")
+	if err!=nil {
+		t.Fatal("failed to login", err)
+	}
+
+	time.Sleep(stepSleepTime)
+
+	sessions, err:= ListSessions(pool, key, user)
+	if err!=nil || len(sessions) != 2 {
+		t.Fatal("failed to list sessions", sessions, err)
+	}
+	found:= false
+	for _, s:= range sessions{
+		if s.ID == SessionID(lost) {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatal("listed sessions not identified by their ID", sessions)
+	}
+
+	err = RevokeSession(pool, key, user, SessionID(lost))
+	if err!=nil {
+		t.Fatal("failed to revoke session", err)
+	}
+
+	time.Sleep(stepSleepTime)
+
+	err = SessionAuth(pool, user, lost)
+	if err != ErrBadSession {
+		t.Fatal("revoked session still authenticates", err)
+	}
+	err = SessionAuth(pool, user, key)
+	if err!=nil {
+		t.Fatal("unrevoked session no longer authenticates", err)
+	}
+
+	err = RevokeSession(pool, key, user, SessionID(lost))
+	if err != ErrNoSuchSession {
+		t.Fatal("revoked a missing session", err)
+	}
+	err = RevokeSession(pool, key, user, "bogus")
+	if err != ErrNoSuchSession {
+		t.Fatal("revoked a session with a malformed ID", err)
+	}
+
+}
@@ -0,0 +1,109 @@
+package userDB
+
+import(
+
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/jackc/pgx"
+
+)
+
+// The kinds of events that appear in a user's activity feed.
+//
+// Only user facing actions are recorded, nothing regarding
+// credentials or sessions ever is.
+const EventTradesAdded string = "TradesAdded"
+const EventCollectionCreated string = "CollectionCreated"
+const EventPermissionsChanged string = "PermissionsChanged"
+const EventCollectionRenamed string = "CollectionRenamed"
+const EventCollectionDeleted string = "CollectionDeleted"
+const EventTradeReversed string = "TradeReversed"
+const EventTagsChanged string = "TagsChanged"
+
+// How many of their most recent events each user keeps
+const MaxEvents int = 200
+
+// ID increases with every event recorded, so it orders events
+// even when their times tie.
+type Event struct{
+	ID int64
+	Kind, Collection, Detail string
+	Time time.Time
+}
+
+// Requests a feed starting from the most recent event
+const FeedStart int64 = math.MaxInt64
+
+// Records an event using a passed transaction, dropping the oldest
+// events of the user beyond MaxEvents.
+func recordEvent(tx *pgx.Tx, user, kind, collection, detail string) error {
+
+	_, err:= tx.Exec("addEvent", user, kind, collection, detail)
+	if err!=nil {
+		return fmt.Errorf("failed to record event, %v", err)
+	}
+
+	_, err = tx.Exec("trimEvents", user, MaxEvents)
+	if err!=nil {
+		return fmt.Errorf("failed to trim events, %v", err)
+	}
+
+	return nil
+
+}
+
+// Describes a set of trades for the feed.
+func tradeDetail(cards []Card) string {
+
+	if len(cards) == 1 {
+		return fmt.Sprintf("%dx %s", cards[0].Quantity, cards[0].Name)
+	}
+
+	return fmt.Sprintf("%d trades", len(cards))
+
+}
+
+// Acquires up to limit events for a user, most recent first, which
+// were recorded before the event with the ID before.
+//
+// Passing FeedStart begins from the most recent event. Pages keyed on
+// the last ID seen never overlap or skip, even as events are recorded.
+func GetFeed(pool *pgx.ConnPool, sessionKey []byte,
+	user string, before int64, limit int) ([]Event, error) {
+
+	// Authenticate the request
+	err:= SessionAuth(pool, user, sessionKey)
+	if err!=nil{
+		return nil, err
+	}
+
+	rows, err := pool.Query("getEvents", user, before, limit)
+	if err!=nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanEvents(rows)
+
+}
+
+// Reads every event from rows, which must select the columns
+// of getEvents.
+func scanEvents(rows *pgx.Rows) ([]Event, error) {
+
+	events:= make([]Event, 0)
+	for rows.Next(){
+		e:= Event{}
+		err:= rows.Scan(&e.ID, &e.Kind, &e.Collection, &e.Detail, &e.Time)
+		if err!=nil {
+			return nil, errorHandle(err, ScanError)
+		}
+
+		events = append(events, e)
+	}
+
+	return events, nil
+
+}
@@ -0,0 +1,138 @@
+package userDB
+
+import(
+
+	"testing"
+
+	"time"
+
+)
+
+// Ensures user facing actions land in the feed most recent first
+// and that pages don't overlap.
+func TestFeed(t *testing.T) {
+	t.Parallel()
+
+	user:= randString(int(randByte()) % 31)
+	key, err:= AddUser(pool, user, "bar", "foo")
+	if err!=nil {
+		t.Fatal("failed to add user ", err)
+	}
+
+	time.Sleep(stepSleepTime)
+
+	collection:= randString(int(randByte()))
+	err = AddCollection(pool, key, user, collection)
+	if err!=nil {
+		t.Fatal(err)
+	}
+
+	err = AddCards(pool, key, user, collection, randomCards(3), 0)
+	if err!=nil {
+		t.Fatal(err)
+	}
+
+	err = SetCollectionPrivacy(pool, key, user, collection, "History", false, 0)
+	if err!=nil {
+		t.Fatal(err)
+	}
+
+	time.Sleep(stepSleepTime)
+
+	events, err:= GetFeed(pool, key, user, FeedStart, MaxEvents)
+	if err!=nil {
+		t.Fatal("failed to get feed", err)
+	}
+
+	expected:= []string{EventPermissionsChanged,
+		EventTradesAdded, EventCollectionCreated}
+	if len(events) != len(expected) {
+		t.Fatal("incorrect number of events", events)
+	}
+	for i, kind:= range expected{
+		if events[i].Kind != kind || events[i].Collection != collection {
+			t.Fatal("events out of order", events)
+		}
+	}
+
+	page, err:= GetFeed(pool, key, user, events[0].ID, 1)
+	if err!=nil {
+		t.Fatal("failed to get feed page", err)
+	}
+	if len(page) != 1 || page[0].Kind != EventTradesAdded {
+		t.Fatal("incorrect page", page)
+	}
+
+	page, err = GetFeed(pool, key, user, events[len(events) - 1].ID, 1)
+	if err!=nil || len(page) != 0 {
+		t.Fatal("page past the end is not empty", page, err)
+	}
+
+	_, err = GetFeed(pool, []byte("bad"), user, FeedStart, 1)
+	if err != ErrBadSession {
+		t.Fatal("feed acquired without a valid session", err)
+	}
+
+}
+
+// Events recorded while paging must neither repeat nor push older
+// events out of later pages.
+func TestFeedPagingInterleaved(t *testing.T) {
+	t.Parallel()
+
+	user:= randString(int(randByte()) % 31)
+	key, err:= AddUser(pool, user, "bar", "foo")
+	if err!=nil {
+		t.Fatal("failed to add user ", err)
+	}
+
+	time.Sleep(stepSleepTime)
+
+	collection:= randString(int(randByte()))
+	err = AddCollection(pool, key, user, collection)
+	if err!=nil {
+		t.Fatal(err)
+	}
+	for i:= 0; i < 4; i++ {
+		err = AddCards(pool, key, user, collection, randomCards(1), 0)
+		if err!=nil {
+			t.Fatal(err)
+		}
+	}
+
+	time.Sleep(stepSleepTime)
+
+	seen:= make(map[int64]bool)
+	before:= FeedStart
+	for {
+		page, err:= GetFeed(pool, key, user, before, 2)
+		if err!=nil {
+			t.Fatal("failed to get feed page", err)
+		}
+		if len(page) == 0 {
+			break
+		}
+
+		for _, e:= range page{
+			if seen[e.ID] {
+				t.Fatal("event repeated across pages", e)
+			}
+			seen[e.ID] = true
+		}
+		before = page[len(page) - 1].ID
+
+		// Newer events arrive between every page
+		err = AddCards(pool, key, user, collection, randomCards(1), 0)
+		if err!=nil {
+			t.Fatal(err)
+		}
+		time.Sleep(stepSleepTime)
+	}
+
+	// One collection and four trades existed before paging began
+	if len(seen) != 5 {
+		t.Fatal("events skipped or included from after paging began",
+			len(seen))
+	}
+
+}
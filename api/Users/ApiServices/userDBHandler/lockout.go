@@ -0,0 +1,247 @@
+package userDB
+
+import(
+
+	"fmt"
+
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx"
+
+)
+
+// Consecutive failed logins allowed before an account is locked out.
+//
+// Failures only count as consecutive when each comes within
+// loginFailureWindow of the last.
+const LoginFailureThreshold int32 = 5
+const loginFailureWindow = 15 * time.Minute
+
+// Consecutive failed logins after which a captcha must accompany
+// further attempts, well before the account locks.
+const CaptchaFailureThreshold int32 = 3
+
+// The first lockout lasts baseLockout, each further failure doubles
+// it up to maxLockout.
+const baseLockout = time.Minute
+const maxLockout = time.Hour
+
+// Returned when a login is attempted against a locked out account.
+//
+// The password is not checked so attempts while locked cost nothing.
+//
+// UnknownUser marks a name with no account, it is for logging alone
+// and must never change what the client is told.
+type LoginLockedError struct{
+	Remaining time.Duration
+	UnknownUser bool
+}
+
+func (e LoginLockedError) Error() string {
+	return fmt.Sprintf("too many failed logins, locked for %v", e.Remaining)
+}
+
+// Returned when a login fails on an incorrect password.
+//
+// Failures counts those in a row, including this one, so callers
+// may demand more of the user as it grows.
+//
+// UnknownUser marks a name with no account, it is for logging alone
+// and must never change what the client is told.
+type LoginFailedError struct{
+	Failures int32
+	UnknownUser bool
+}
+
+func (e LoginFailedError) Error() string {
+	return fmt.Sprintf("failed to authenticate user, %d consecutive failures",
+		e.Failures)
+}
+
+// Determines if logging in as the user currently demands a captcha.
+//
+// Failures which have aged out of the window no longer count towards
+// the next lockout and so don't count here either.
+func (u *User) CaptchaRequired(now time.Time) bool {
+	return u.FailedLogins >= CaptchaFailureThreshold &&
+		now.Sub(u.LastFailedLogin) < loginFailureWindow
+}
+
+// Determines how long an account is locked for after failures
+// consecutive failed logins.
+func loginLockout(failures int32) time.Duration {
+
+	if failures < LoginFailureThreshold {
+		return 0
+	}
+
+	lockout:= baseLockout
+	for i:= LoginFailureThreshold; i < failures; i++ {
+		lockout*= 2
+		if lockout >= maxLockout {
+			return maxLockout
+		}
+	}
+
+	return lockout
+
+}
+
+// Checks a user's password unless they are locked out, recording
+// a failure towards the next lockout when it is incorrect.
+//
+// Returns a LoginLockedError or LoginFailedError when the password
+// can't be accepted.
+func checkPassword(pool *pgx.ConnPool, u *User,
+	password string, now time.Time) error {
+
+	if now.Before(u.LockedUntil) {
+		return LoginLockedError{Remaining: u.LockedUntil.Sub(now)}
+	}
+
+	valid, err:= passwordMatches(u, password)
+	if err!=nil {
+		return errorHandle(err, "failed to authenticate user")
+	}
+	if !valid {
+		failures, err:= recordLoginFailure(pool, u.Name, now)
+		if err!=nil {
+			return err
+		}
+		return LoginFailedError{Failures: failures}
+	}
+
+	return nil
+
+}
+
+// Records a failed login, locking the account once there have been
+// too many in a row.
+//
+// Returns the number of consecutive failures, counted atomically so
+// concurrent attempts can't slip past the threshold.
+func recordLoginFailure(pool *pgx.ConnPool, user string,
+	now time.Time) (int32, error) {
+
+	var failures int32
+	err:= pool.QueryRow("recordLoginFailure", user,
+		now.Add(-loginFailureWindow), now).Scan(&failures)
+	if err!=nil {
+		return 0, errorHandle(err, "failed to record login failure, %v")
+	}
+
+	lockout:= loginLockout(failures)
+	if lockout > 0 {
+		_, err = pool.Exec("setLoginLockout", user, now.Add(lockout))
+		if err!=nil {
+			return failures, fmt.Errorf("failed to lock out user, %v", err)
+		}
+	}
+
+	return failures, nil
+
+}
+
+// Past this many names unknownLogins forgets every failure it holds
+// rather than growing without bound.
+const maxUnknownLogins = 100000
+
+// Failed logins against a name with no account.
+type unknownLogin struct{
+	failures int32
+	lastFailed, lockedUntil time.Time
+}
+
+// Failed logins against names with no account, counted and locked
+// out exactly as an account's would be so whether a name is taken
+// can't be told from how logins against it are refused.
+//
+// Held in memory as there is no row to count them against.
+type unknownLoginTracker struct{
+	sync.Mutex
+	logins map[string]unknownLogin
+}
+
+var unknownLogins = unknownLoginTracker{
+	logins: make(map[string]unknownLogin),
+}
+
+// Returns a LoginLockedError if the name is locked out, as
+// checkPassword would for an account.
+func (t *unknownLoginTracker) locked(user string, now time.Time) error {
+
+	t.Lock()
+	defer t.Unlock()
+
+	l:= t.logins[user]
+	if now.Before(l.lockedUntil) {
+		return LoginLockedError{l.lockedUntil.Sub(now), true}
+	}
+
+	return nil
+
+}
+
+// Records a failed login against the name, as recordLoginFailure
+// would for an account.
+func (t *unknownLoginTracker) fail(user string,
+	now time.Time) LoginFailedError {
+
+	t.Lock()
+	defer t.Unlock()
+
+	l, ok:= t.logins[user]
+	if l.lastFailed.After(now.Add(-loginFailureWindow)) {
+		l.failures++
+	}else{
+		l.failures = 1
+	}
+	l.lastFailed = now
+
+	lockout:= loginLockout(l.failures)
+	if lockout > 0 {
+		l.lockedUntil = now.Add(lockout)
+	}
+
+	if !ok && len(t.logins) >= maxUnknownLogins {
+		t.logins = make(map[string]unknownLogin)
+	}
+	t.logins[user] = l
+
+	return LoginFailedError{l.failures, true}
+
+}
+
+// Determines if logging in as the name currently demands a captcha,
+// as User.CaptchaRequired would for an account.
+func (t *unknownLoginTracker) captchaRequired(user string,
+	now time.Time) bool {
+
+	t.Lock()
+	defer t.Unlock()
+
+	l:= t.logins[user]
+	return l.failures >= CaptchaFailureThreshold &&
+		now.Sub(l.lastFailed) < loginFailureWindow
+
+}
+
+// Determines if logging in as the user currently demands a captcha,
+// whether or not they have an account.
+//
+// Failing to look them up demands none, Login reports the failure.
+func LoginCaptchaRequired(pool *pgx.ConnPool, user string,
+	now time.Time) bool {
+
+	u, err:= GetUser(pool, user)
+	if err == pgx.ErrNoRows {
+		return unknownLogins.captchaRequired(user, now)
+	}
+	if err!=nil {
+		return false
+	}
+
+	return u.CaptchaRequired(now)
+
+}
@@ -0,0 +1,137 @@
+package userDB
+
+import(
+
+	"strings"
+	"unicode"
+
+)
+
+// The shortest password accepted, replaceable at startup.
+var PasswordMinLength int = 10
+
+// Bounds the cost of hashing whatever a client sends us
+const passwordMaxLength int = 256
+
+// How many of lower case, upper case, digits and symbols a
+// password needs to draw from.
+const passwordMinClasses int = 2
+
+// The highest score PasswordStrength awards
+const MaxPasswordScore int = 4
+
+// Reasons a password may be rejected, these are shown to users.
+const (
+	ReasonTooShort string = "too short"
+	ReasonTooLong string = "too long"
+	ReasonTooCommon string = "too common"
+	ReasonTooSimple string = "needs a mix of letters, numbers or symbols"
+)
+
+// Passwords, or the stems of them, seen far too often in leaks.
+//
+// Entries are compared ignoring case and any trailing digits
+// or symbols, so 'Password123!' is as common as 'password'.
+var commonPasswords = map[string]bool{
+	"password": true, "passw": true, "passwd": true, "qwerty": true,
+	"qwertyuiop": true, "asdfghjkl": true, "zxcvbnm": true,
+	"abc": true, "abcdef": true, "abcdefghij": true, "letmein": true,
+	"iloveyou": true, "welcome": true, "admin": true,
+	"administrator": true, "monkey": true, "dragon": true,
+	"football": true, "baseball": true, "basketball": true,
+	"master": true, "sunshine": true, "princess": true,
+	"trustno": true, "superman": true, "batman": true,
+	"starwars": true, "whatever": true, "changeme": true,
+	"secret": true, "login": true, "shadow": true, "freedom": true,
+	"magic": true, "magicthegathering": true, "mtg": true,
+	"preorda": true, "preordain": true,
+	// Nothing but digits and symbols, such as '1234567890'
+	"": true,
+}
+
+// Determines if a password is, or extends, a common password
+func commonPassword(password string) bool {
+
+	lowered:= strings.ToLower(password)
+	if commonPasswords[lowered] {
+		return true
+	}
+
+	stem:= strings.TrimRightFunc(lowered, func(r rune) bool {
+		return !unicode.IsLetter(r)
+	})
+
+	return commonPasswords[stem]
+
+}
+
+// Counts the kinds of characters a password draws from.
+func characterClasses(password string) int {
+
+	var lower, upper, digit, symbol bool
+	for _, r:= range password{
+		switch {
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsDigit(r):
+			digit = true
+		default:
+			symbol = true
+		}
+	}
+
+	classes:= 0
+	for _, present:= range []bool{lower, upper, digit, symbol}{
+		if present {
+			classes++
+		}
+	}
+
+	return classes
+
+}
+
+// Scores a password from zero to MaxPasswordScore, listing every
+// reason it is unacceptable.
+//
+// A password is acceptable only when no reasons are returned, the
+// score is then at least one and grows with its variety and length.
+func PasswordStrength(password string) (score int, reasons []string) {
+
+	reasons = make([]string, 0)
+
+	length:= len([]rune(password))
+	if length < PasswordMinLength {
+		reasons = append(reasons, ReasonTooShort)
+	}
+	if len(password) >= passwordMaxLength {
+		// Nothing further is worth checking
+		return 0, append(reasons, ReasonTooLong)
+	}
+
+	if commonPassword(password) {
+		reasons = append(reasons, ReasonTooCommon)
+	}
+
+	classes:= characterClasses(password)
+	if classes < passwordMinClasses {
+		reasons = append(reasons, ReasonTooSimple)
+	}
+
+	if len(reasons) > 0 {
+		return 0, reasons
+	}
+
+	score = classes - 1
+	if length >= 2 * PasswordMinLength {
+		score++
+	}
+	if score > MaxPasswordScore {
+		score = MaxPasswordScore
+	}
+
+	return score, reasons
+
+}
@@ -0,0 +1,53 @@
+package userDB
+
+import(
+
+	"testing"
+
+)
+
+func TestPasswordStrength(t *testing.T) {
+
+	rejected:= map[string]string{
+		"Sh0rt": ReasonTooShort,
+		"Password123!": ReasonTooCommon,
+		"QWERTYUIOP": ReasonTooCommon,
+		"1234567890": ReasonTooCommon,
+		"abcdefghijklmnop": ReasonTooSimple,
+	}
+	for password, reason:= range rejected{
+		score, reasons:= PasswordStrength(password)
+		if score != 0 || !containsReason(reasons, reason) {
+			t.Fatal("password not rejected", password, reason, reasons)
+		}
+	}
+
+	score, reasons:= PasswordStrength("griselbrand7")
+	if len(reasons) != 0 || score != 1 {
+		t.Fatal("acceptable password rejected", score, reasons)
+	}
+
+	score, reasons = PasswordStrength("Griselbrand, the Avacyn Restored 7!")
+	if len(reasons) != 0 || score != MaxPasswordScore {
+		t.Fatal("strong password not scored highly", score, reasons)
+	}
+
+	// Every failing requirement is reported at once
+	_, reasons = PasswordStrength("qwerty")
+	if len(reasons) != 3 {
+		t.Fatal("not every reason reported", reasons)
+	}
+
+}
+
+func containsReason(reasons []string, reason string) bool {
+
+	for _, r:= range reasons{
+		if r == reason {
+			return true
+		}
+	}
+
+	return false
+
+}
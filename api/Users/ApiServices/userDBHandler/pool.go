@@ -0,0 +1,178 @@
+package userDB
+
+import(
+
+	"net"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx"
+
+)
+
+// How often a kept pool is checked
+const poolCheckInterval = 10 * time.Second
+
+// Consecutive failed checks before a pool is recreated, a single
+// failure is likely a blip the pool recovers from itself.
+const poolCheckFailures int = 3
+
+// How long a replaced pool lingers so queries already using it can
+// finish before it's closed.
+const replacedPoolGrace = 30 * time.Second
+
+// Determines if an error means the database couldn't be reached,
+// rather than it refusing what was asked of it.
+func IsUnavailable(err error) bool {
+
+	if err == ErrUnavailable || err == pgx.ErrDeadConn ||
+		err == pgx.ErrAcquireTimeout {
+		return true
+	}
+
+	_, ok:= err.(net.Error)
+
+	return ok
+
+}
+
+// Holds a pool, replacing it should the database stay unreachable
+// long enough that its connections won't recover.
+//
+// Acquire the current pool with Pool for each use rather than holding
+// onto it.
+type PoolKeeper struct{
+	lock sync.RWMutex
+	pool *pgx.ConnPool
+
+	connect func() (*pgx.ConnPool, error)
+	ping func(*pgx.ConnPool) error
+
+	stop chan struct{}
+	stopOnce sync.Once
+}
+
+// Connects with connect and keeps the resulting pool.
+//
+// Call Watch to start checking on it.
+func KeepPool(connect func() (*pgx.ConnPool, error)) (*PoolKeeper, error) {
+
+	pool, err:= connect()
+	if err!=nil {
+		return nil, err
+	}
+
+	return &PoolKeeper{
+		pool: pool,
+		connect: connect,
+		ping: pingPool,
+		stop: make(chan struct{}),
+	}, nil
+
+}
+
+// Returns the pool currently kept.
+func (k *PoolKeeper) Pool() *pgx.ConnPool {
+
+	k.lock.RLock()
+	defer k.lock.RUnlock()
+
+	return k.pool
+
+}
+
+// Checks on the pool every poolCheckInterval until Close, recreating
+// it after poolCheckFailures consecutive failed checks.
+//
+// Failures and replacements are reported through logf.
+func (k *PoolKeeper) Watch(logf func(format string, v ...interface{})) {
+
+	ticker:= time.NewTicker(poolCheckInterval)
+	defer ticker.Stop()
+
+	failures:= 0
+	for {
+		select {
+		case <-k.stop:
+			return
+		case <-ticker.C:
+		}
+
+		failures = k.check(failures, logf)
+	}
+
+}
+
+// Checks the pool once given how many checks have failed in a row,
+// returning how many now have.
+func (k *PoolKeeper) check(failures int,
+	logf func(format string, v ...interface{})) int {
+
+	err:= k.ping(k.Pool())
+	if err == nil {
+		return 0
+	}
+
+	// A saturated pool is busy rather than broken, a fresh one
+	// would only add to the load
+	if err == pgx.ErrAcquireTimeout {
+		return failures
+	}
+
+	failures++
+	logf("users db check failed %d times in a row, %v", failures, err)
+	if failures < poolCheckFailures {
+		return failures
+	}
+
+	err = k.replace()
+	if err!=nil {
+		logf("failed to recreate users db pool, %v", err)
+		return failures
+	}
+
+	logf("recreated users db pool")
+
+	return 0
+
+}
+
+// Swaps in a fresh pool, closing the previous once queries already
+// using it have had time to finish.
+func (k *PoolKeeper) replace() error {
+
+	fresh, err:= k.connect()
+	if err!=nil {
+		return err
+	}
+
+	k.lock.Lock()
+	previous:= k.pool
+	k.pool = fresh
+	k.lock.Unlock()
+
+	time.AfterFunc(replacedPoolGrace, previous.Close)
+
+	return nil
+
+}
+
+// Stops watching and closes the pool.
+func (k *PoolKeeper) Close() {
+
+	k.stopOnce.Do(func() {
+		close(k.stop)
+	})
+
+	k.Pool().Close()
+
+}
+
+// Ensures a pool can reach the database
+func pingPool(pool *pgx.ConnPool) error {
+
+	var one int
+
+	return pool.QueryRow("SELECT 1").Scan(&one)
+
+}
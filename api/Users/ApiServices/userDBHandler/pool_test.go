@@ -0,0 +1,71 @@
+package userDB
+
+import(
+
+	"fmt"
+	"net"
+
+	"testing"
+
+	"github.com/jackc/pgx"
+
+)
+
+func TestIsUnavailable(t *testing.T) {
+
+	for _, err:= range []error{ErrUnavailable, pgx.ErrDeadConn,
+		pgx.ErrAcquireTimeout, &net.OpError{Op: "dial"}}{
+		if !IsUnavailable(err) {
+			t.Fatal("unreachable database not unavailable", err)
+		}
+	}
+
+	for _, err:= range []error{pgx.ErrNoRows, ErrBadSession}{
+		if IsUnavailable(err) {
+			t.Fatal("refusal considered unavailable", err)
+		}
+	}
+
+}
+
+// Ensure a pool is only recreated once checks fail repeatedly, and
+// never for merely being saturated.
+func TestPoolKeeperCheck(t *testing.T) {
+
+	var pingErr error
+	connects:= 0
+	k:= PoolKeeper{
+		ping: func(*pgx.ConnPool) error { return pingErr },
+		connect: func() (*pgx.ConnPool, error) {
+			connects++
+			return nil, fmt.Errorf("still down")
+		},
+	}
+	logf:= func(string, ...interface{}) {}
+
+	pingErr = pgx.ErrAcquireTimeout
+	if k.check(1, logf) != 1 {
+		t.Fatal("saturated pool counted as failing")
+	}
+
+	pingErr = pgx.ErrDeadConn
+	failures:= 0
+	for i:= 1; i < poolCheckFailures; i++ {
+		failures = k.check(failures, logf)
+	}
+	if connects != 0 || failures != poolCheckFailures - 1 {
+		t.Fatal("pool recreated before enough failures", connects, failures)
+	}
+
+	failures = k.check(failures, logf)
+	if connects != 1 || failures != poolCheckFailures {
+		t.Fatal("pool not recreated after repeated failures", connects,
+			failures)
+	}
+
+	pingErr = nil
+	if k.check(failures, logf) != 0 {
+		t.Fatal("successful check didn't reset failures")
+	}
+
+}
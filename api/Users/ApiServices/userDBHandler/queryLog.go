@@ -0,0 +1,108 @@
+package userDB
+
+import(
+
+	"fmt"
+	"strings"
+
+	"log"
+
+)
+
+// When set prior to Connect, every query made through the resulting
+// pool is logged alongside its duration and arguments.
+//
+// Very noisy, this is meant for debugging slow endpoints only.
+var QueryLogger *log.Logger
+
+// Statements whose arguments are only names, collections and cards
+// and so may be logged.
+//
+// Every other statement has its arguments redacted. pgx hands the
+// logger []byte arguments already hex encoded, so what is sensitive
+// can't be told from the arguments themselves.
+var loggableStatements = map[string]bool{
+	"getSub": true,
+	"getCollectionMeta": true,
+	"getCollectionContents": true,
+	"getCollectionHistory": true,
+	"getCollectionList": true,
+	"getCollectionPage": true,
+	"countCollections": true,
+	"addCollection": true,
+	"removeCollection": true,
+	"removeCollectionContents": true,
+	"renameCollection": true,
+	"renameCollectionHistory": true,
+	"setCollectionPermissions": true,
+	"setCollectionTags": true,
+	"setCollectionLock": true,
+	"setCollectionValuation": true,
+	"bumpCollectionVersion": true,
+	"getCard": true,
+	"addCard": true,
+	"addCardHistorical": true,
+	"getTrade": true,
+	"getTradeHistory": true,
+	"countTradeReversals": true,
+	"getEvents": true,
+	"getAlerts": true,
+	"getAllAlerts": true,
+	"getFootprint": true,
+	"getSessionsVersion": true,
+	"countActiveSessions": true,
+}
+
+const redacted string = "<redacted>"
+
+// Satisfies pgx.Logger by writing everything through a standard logger.
+type queryLogger struct{
+	logger *log.Logger
+}
+
+func (q queryLogger) Debug(msg string, ctx ...interface{}) {
+	q.write("DEBUG", msg, ctx)
+}
+
+func (q queryLogger) Info(msg string, ctx ...interface{}) {
+	q.write("INFO", msg, ctx)
+}
+
+func (q queryLogger) Warn(msg string, ctx ...interface{}) {
+	q.write("WARN", msg, ctx)
+}
+
+func (q queryLogger) Error(msg string, ctx ...interface{}) {
+	q.write("ERROR", msg, ctx)
+}
+
+func (q queryLogger) write(level, msg string, ctx []interface{}) {
+	q.logger.Println("userDB", level, msg, formatQueryContext(ctx))
+}
+
+// Formats the key value pairs pgx provides alongside a log message.
+//
+// Arguments are redacted unless the statement is loggable.
+func formatQueryContext(ctx []interface{}) string {
+
+	loggable:= false
+	for i:= 0; i + 1 < len(ctx); i+= 2 {
+		name, ok:= ctx[i + 1].(string)
+		if ctx[i] == "sql" && ok && loggableStatements[name] {
+			loggable = true
+		}
+	}
+
+	parts:= make([]string, 0, len(ctx) / 2)
+	for i:= 0; i + 1 < len(ctx); i+= 2 {
+		key:= fmt.Sprint(ctx[i])
+		value:= ctx[i + 1]
+		if key == "args" && !loggable {
+			value = redacted
+		}
+		parts = append(parts, fmt.Sprintf("%s=%v", key, value))
+	}
+
+	return strings.Join(parts, " ")
+
+}
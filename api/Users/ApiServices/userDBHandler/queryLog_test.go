@@ -0,0 +1,94 @@
+package userDB
+
+import(
+
+	"testing"
+
+	"bytes"
+	"log"
+	"strings"
+
+	"encoding/hex"
+
+)
+
+// Connects a fresh pool with query logging enabled and ensures queries
+// make it to the log. Not parallel as it toggles the package logger.
+func TestQueryLogging(t *testing.T) {
+
+	var buf bytes.Buffer
+	QueryLogger = log.New(&buf, "", 0)
+	defer func() {
+		QueryLogger = nil
+	}()
+
+	debugPool, err:= Connect()
+	if err!=nil {
+		t.Fatal("failed to connect with query logging", err)
+	}
+	defer debugPool.Close()
+
+	user:= randString(int(randByte()))
+	GetSub(debugPool, user, nil)
+
+	logged:= buf.String()
+	if !strings.Contains(logged, "getSub") ||
+		!strings.Contains(logged, user) {
+		t.Fatal("query was not logged in debug mode")
+	}
+
+	// Whatever form pgx gives the token to the logger in, it mustn't
+	// be logged
+	token:= []byte(randString(16))
+	buf.Reset()
+	debugPool.Exec("setVerifyToken", user, token)
+
+	logged = buf.String()
+	if !strings.Contains(logged, "setVerifyToken") ||
+		strings.Contains(logged, hex.EncodeToString(token)) ||
+		strings.Contains(logged, string(token)) {
+		t.Fatal("sensitive arguments logged", logged)
+	}
+
+	// Without a logger nothing should be hooked up
+	QueryLogger = nil
+	config, err:= readConfig(configLog)
+	if err!=nil {
+		t.Fatal("failed to read config", err)
+	}
+	if config.Logger != nil {
+		t.Fatal("queries logged outside of debug mode")
+	}
+
+}
+
+// Arguments are shaped as pgx hands them to its logger, with raw
+// bytes already hex encoded.
+func TestQueryLogRedaction(t *testing.T) {
+
+	token:= hex.EncodeToString([]byte("token"))
+
+	for _, statement:= range []string{"addUser", "setVerifyToken",
+		"setEmailChange", "consumeReset", "extendSession", "revokeSession",
+		"BEGIN"} {
+
+		logged:= formatQueryContext([]interface{}{
+			"sql", statement,
+			"args", []interface{}{"foo", token},
+		})
+		if strings.Contains(logged, "foo") ||
+			strings.Contains(logged, token) {
+			t.Fatal("arguments logged for", statement, logged)
+		}
+	}
+
+	ordinary:= formatQueryContext([]interface{}{
+		"sql", "getCollectionMeta",
+		"args", []interface{}{"foo", "bar"},
+	})
+	if !strings.Contains(ordinary, "foo") ||
+		!strings.Contains(ordinary, "bar") {
+		t.Fatal("loggable arguments redacted", ordinary)
+	}
+
+}
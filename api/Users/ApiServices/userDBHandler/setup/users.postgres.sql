@@ -122,9 +122,44 @@ CREATE TABLE users.meta (
 	
 	passhash bytea NOT NULL,
 	nonce bytea NOT NULL,
+
+	-- The scrypt parameters passhash was derived with, defaulting to
+	-- those used before they were stored
+	scryptN int NOT NULL DEFAULT 32768,
+	scryptR int NOT NULL DEFAULT 2,
+	scryptP int NOT NULL DEFAULT 1,
 	
 	maxcollections int DEFAULT 1,
 	longestview bigint DEFAULT 31560000000000000,
+
+	-- Bumped whenever a session is added, refreshed or removed
+	sessionsVersion bigint DEFAULT 0,
+
+	-- The password is rehashed with a fresh nonce on the next login
+	forceRehash boolean DEFAULT false,
+
+	-- Empty preferences defer to the system defaults
+	currency standardText DEFAULT '',
+	locale standardText DEFAULT '',
+
+	-- verifyToken is the hash of the code mailed to confirm email,
+	-- cleared once it is used
+	verified boolean DEFAULT false,
+	verifyToken bytea,
+
+	-- An email awaiting confirmation, emailChangeToken is the hash of
+	-- the code mailed to it
+	pendingEmail standardText,
+	emailChangeToken bytea,
+
+	-- Consecutive failed logins, logins are refused until lockedUntil
+	-- once there are too many
+	failedLogins int DEFAULT 0,
+	lastFailedLogin timestamp,
+	lockedUntil timestamp,
+
+	-- When a reset was last mailed, resets are throttled from it
+	lastResetSent timestamp,
 	
 	CONSTRAINT uniquename UNIQUE (name)
 );
@@ -150,11 +185,19 @@ CREATE TABLE users.subs (
 
 	customerID TEXT NOT NULL,
 	subID TEXT NOT NULL,
+
+	-- Set by an admin to let the next plan change skip the cooldown
+	cooldownWaived boolean NOT NULL DEFAULT false,
+
+	-- How often the plan is billed, the free plan is always 'month'
+	billingInterval TEXT NOT NULL DEFAULT 'month'
+		CHECK (billingInterval IN ('month', 'year')),
 	
 	CONSTRAINT unique_sub_name UNIQUE (name)
 );
 
 CREATE UNIQUE INDEX subs_name_index on users.subs(name);
+CREATE INDEX subs_customer_index on users.subs(customerID);
 
 /*
 Create a function that allows us to mostly atomically upsert
@@ -176,7 +219,8 @@ BEGIN
 				startTime = specTime,
 				plan = specPlan,
 				customerID = specCustomerID,
-				subID = specSubID
+				subID = specSubID,
+				cooldownWaived = false
 			WHERE
 				name = specName;
         IF found THEN
@@ -220,6 +264,9 @@ CREATE TABLE users.sessions (
 	
 	startValid timestamp NOT NULL,
 	endValid timestamp NOT NULL,
+
+	-- Approximate, only updated every few minutes of use
+	lastUsed timestamp DEFAULT now(),
 	
 	CONSTRAINT uniqueSessionKey UNIQUE (sessionKey, name)
 );
@@ -241,8 +288,85 @@ CREATE TABLE users.resets (
 );
 
 
+/*
+Create the table holding the recent activity of each user.
+
+Only user facing events are recorded here, nothing touching credentials
+or sessions. Each user keeps a bounded number of their latest events,
+id orders events within a user as time alone may tie.
+*/
+CREATE TABLE users.events (
+	id bigserial PRIMARY KEY,
+	owner standardText NOT NULL references users.meta(name),
+
+	kind standardText NOT NULL,
+	collection standardText NOT NULL,
+	detail TEXT NOT NULL,
+
+	time timestamp DEFAULT now()
+);
+
+CREATE INDEX event_owner_index on users.events(owner);
+
+/*
+Create the table holding outbound mail we gave up on delivering.
+
+content holds the json encoded template contents so a message can be
+sent again by hand. It is NULL for templates carrying credentials,
+such as reset codes, which are never kept.
+*/
+CREATE TABLE users.deadLetters (
+	id bigserial PRIMARY KEY,
+
+	recipient TEXT NOT NULL,
+	template standardText NOT NULL,
+	subject TEXT NOT NULL,
+	content TEXT,
+
+	lastError TEXT NOT NULL,
+	attempts int NOT NULL,
+
+	time timestamp DEFAULT now()
+);
+
+/*
+Create the table holding the webhook endpoints users have registered
+to be notified of collection changes.
+
+failures counts consecutive failed deliveries, an endpoint is disabled
+once it fails persistently and is skipped from then on.
+*/
+CREATE TABLE users.webhooks (
+	owner standardText NOT NULL references users.meta(name),
+	url standardText NOT NULL,
+
+	failures int DEFAULT 0,
+	disabled boolean DEFAULT false,
+
+	created timestamp DEFAULT now(),
+
+	CONSTRAINT uniqueWebhook UNIQUE (owner, url)
+);
+
+CREATE INDEX webhook_owner_index on users.webhooks(owner);
+
+/*
+Create the table holding the ids of stripe events we have handled.
+
+Stripe may deliver an event more than once and a captured delivery
+could be replayed, an event is only acted upon when its id is new.
+*/
+CREATE TABLE users.stripeEvents (
+	id TEXT PRIMARY KEY,
+
+	received timestamp DEFAULT now()
+);
+
 /*
 Create the table that stores the collection metadata of our users.
+
+publicComments is independent of Privacy; card comments are only shown
+to the public when it is set.
 */
 CREATE TABLE users.collections (
 
@@ -252,6 +376,22 @@ CREATE TABLE users.collections (
 	lastUpdate timestamp DEFAULT now(),
 	
 	Privacy possiblePrivacy DEFAULT 'Contents',
+	publicComments boolean DEFAULT false,
+	locked boolean DEFAULT false,
+	-- Set while beyond the owner's plan cap, read only until upgraded
+	overLimit boolean DEFAULT false,
+
+	-- How the collection is valued when a request doesn't say, empty
+	-- defers to the owner's preferences
+	currency standardText DEFAULT '',
+	basis standardText DEFAULT '',
+
+	-- Bumped by every versioned change so concurrent changes made
+	-- against the same read conflict rather than interleave
+	version bigint NOT NULL DEFAULT 1,
+
+	-- Labels the owner files the collection under
+	tags text[] NOT NULL DEFAULT '{}',
 
 	CONSTRAINT uniqueCollectionKey UNIQUE (name, owner)
 );
@@ -281,7 +421,9 @@ CREATE TABLE users.collectionContents (
 	
 	lastUpdate timestamp NOT NULL,
 
-	FOREIGN KEY (owner, collection) REFERENCES users.collections (owner, name),
+	-- Renaming a collection carries its contents along
+	FOREIGN KEY (owner, collection) REFERENCES users.collections (owner, name)
+		ON UPDATE CASCADE,
 
 	CONSTRAINT uniqueContentsKey UNIQUE (owner, collection,
 										cardName, setName,
@@ -290,6 +432,32 @@ CREATE TABLE users.collectionContents (
 
 CREATE INDEX contents_completeCollection_index on users.collectionContents(owner, collection);
 
+/*
+Create the table holding the price alerts users set on collections.
+
+An alert fires when any card in its collection moves by more than
+percent or crosses threshold, in cents, since the alert was created
+or last fired. Either is zero when unused. Alerts follow their
+collection when it is renamed and go with it when it is deleted.
+*/
+CREATE TABLE users.alerts (
+	id standardText PRIMARY KEY,
+
+	owner standardText NOT NULL,
+	collection standardText NOT NULL,
+
+	percent int NOT NULL DEFAULT 0,
+	threshold int NOT NULL DEFAULT 0,
+
+	since timestamp NOT NULL DEFAULT now(),
+	created timestamp DEFAULT now(),
+
+	FOREIGN KEY (owner, collection) REFERENCES users.collections (owner, name)
+		ON UPDATE CASCADE ON DELETE CASCADE
+);
+
+CREATE INDEX alert_owner_index on users.alerts(owner);
+
 /*
 A table that stores the changes each collection undergoes.
 
@@ -318,6 +486,12 @@ CREATE TABLE users.collectionHistory (
 
 	creationTime timestamp DEFAULT now(),
 
+	-- Shared by every row added together, NULL for rows predating it
+	tradeID standardText,
+
+	-- The trade these rows undo, NULL for ordinary trades
+	reverses standardText,
+
 	CONSTRAINT uniqueHistoryKey UNIQUE (owner, collection,
 										cardName, setName,
 										quality, lang,
@@ -325,6 +499,7 @@ CREATE TABLE users.collectionHistory (
 );
 
 CREATE INDEX history_cardName_index on users.collectionHistory(cardName, owner);
+CREATE INDEX history_trade_index on users.collectionHistory(owner, tradeID);
 
 /*
 Create a function that allows us to mostly atomically upsert
@@ -387,11 +562,12 @@ NOTE: Do this as user 'postgres' in the userdata table!
 
 *Assume select for each*
 users.meta - insert and update
-users.Sessions - insert and delete
+users.Sessions - insert, update, and delete
 users.Resets - insert and delete
+users.Webhooks - insert and update
 users.Collections - insert, update, and delete
-users.CollectionContents - insert and update
-users.CollectionHistory - insert
+users.CollectionContents - insert, update, and delete
+users.CollectionHistory - insert, update of collection only
 */
 
 /*Make sure all permissions are OFF by default*/
@@ -412,16 +588,37 @@ GRANT select, insert, update ON TABLE users.meta to userManager;
 GRANT select, insert, update ON TABLE users.subs to userManager;
 
 /*Sessions and resets can be deleted with no issue*/
-GRANT select, insert, delete ON TABLE users.sessions to userManager;
+/*Sessions are updated when refreshed*/
+GRANT select, insert, update, delete ON TABLE users.sessions to userManager;
 GRANT select, insert, delete ON TABLE users.resets to userManager;
 
+/*Events are trimmed to keep each feed bounded*/
+GRANT select, insert, delete ON TABLE users.events to userManager;
+GRANT usage ON SEQUENCE users.events_id_seq to userManager;
+
+GRANT select, insert, update, delete ON TABLE users.deadLetters to userManager;
+GRANT usage ON SEQUENCE users.deadLetters_id_seq to userManager;
+
+/*Webhooks are disabled rather than deleted*/
+GRANT select, insert, update ON TABLE users.webhooks to userManager;
+
+/*Stripe events are forgotten when handling them fails*/
+GRANT select, insert, delete ON TABLE users.stripeEvents to userManager;
+
 /*Collections needs to be capable of being deleted*/
 GRANT select, insert, update, delete ON TABLE users.collections to userManager;
 
-GRANT select, insert, update ON TABLE users.collectionContents to userManager;
+/*Contents go along with their collection when it is deleted*/
+GRANT select, insert, update, delete ON TABLE users.collectionContents to userManager;
+
+/*Alerts are deleted by their owners and reset whenever they fire*/
+GRANT select, insert, update, delete ON TABLE users.alerts to userManager;
 
 /*Append only collection history is VERY important*/
+/*Only the name of the collection may change, when it is renamed*/
+/*or deleted, which moves its history aside*/
 GRANT select, insert ON TABLE users.collectionHistory to userManager;
+GRANT update (collection) ON TABLE users.collectionHistory to userManager;
 
 /*
 Set a backup user up so we are able to remotely dump table contents and
@@ -149,11 +149,13 @@ func equalCardContents(a, b []Card, t *testing.T) bool {
 	aMap:= make(map[Card]bool)
 	bMap:= make(map[Card]bool)
 	
-	// Ignore times
+	// Ignore times and which trade each card arrived in
 	for _, c:= range a{
+		c.TradeID = ""
 		aMap[c] = true
 	}
 	for _, c:= range b{
+		c.TradeID = ""
 		bMap[c] = true
 	}
 
@@ -0,0 +1,21 @@
+/*
+Sets a price alert on a collection when the owner has fewer than limit
+alerts. Callers lock the owner's sub first, so concurrent additions
+can't both slip under the limit.
+
+Takes:
+	id - string, identifies the alert to its owner
+	owner - string, the user that owns this
+	collection - string, the collection watched
+	percent - int, the move in percent that fires it, zero when unused
+	threshold - int, the price in cents crossing fires it, zero when unused
+	limit - int, the most alerts the owner may have
+
+Returns nothing when the owner already has limit alerts.
+*/
+
+INSERT INTO users.alerts
+(id, owner, collection, percent, threshold)
+SELECT $1, $2, $3, $4, $5
+WHERE (SELECT count(*) FROM users.alerts WHERE owner=$2) < $6
+RETURNING since, created
\ No newline at end of file
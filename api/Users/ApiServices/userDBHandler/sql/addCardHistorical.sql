@@ -10,9 +10,12 @@ Takes:
 	quality - string, a defined quality
 	lang - string, a language in mtg
 	quantity - int, how many cards
+	lastUpdate - timestamp, when the trade happened
+	tradeID - string, shared by every row in a single trade
+	reverses - string, the trade this undoes or empty for none
 */
 
 INSERT INTO users.collectionHistory 
-(owner, collection, cardName, setName, comment, quantity, quality, lang, lastUpdate) 
+(owner, collection, cardName, setName, comment, quantity, quality, lang, lastUpdate, tradeID, reverses) 
 VALUES
-($1, $2, $3, $4, $5, $6, $7, $8, $9)
\ No newline at end of file
+($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, NULLIF($11, ''))
\ No newline at end of file
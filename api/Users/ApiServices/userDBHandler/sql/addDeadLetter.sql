@@ -0,0 +1,17 @@
+/*
+Records outbound mail we gave up on delivering
+
+Takes:
+	recipient - string, who the mail was for in NAME <EMAIL> form
+	template - string, the prepared template used
+	subject - string, the subject line
+	content - string, json encoded template contents or NULL
+	lastError - string, why the final attempt failed
+	attempts - int, how many sends were attempted
+*/
+
+INSERT INTO users.deadLetters
+(recipient, template, subject, content, lastError, attempts)
+VALUES
+($1, $2, $3, $4, $5, $6)
+RETURNING id
\ No newline at end of file
@@ -0,0 +1,14 @@
+/*
+Records a user facing event in the activity feed of a user
+
+Takes:
+	owner - string, the user the event belongs to
+	kind - string, what happened
+	collection - string, the collection it happened to
+	detail - string, a short user facing description
+*/
+
+INSERT INTO users.events
+(owner, kind, collection, detail)
+VALUES
+($1, $2, $3, $4)
\ No newline at end of file
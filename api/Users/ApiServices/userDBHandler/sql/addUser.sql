@@ -8,9 +8,10 @@ Takes:
 	email - string, the address we can contact a user at
 	passHash - bytea, the result of scrypt(password, nonce)
 	nonce - bytea, the nonce used for deriving passHash
+	scryptN, scryptR, scryptP - int, the parameters passHash was derived with
 */
 
 INSERT INTO users.meta 
-(name, email, passHash, nonce) 
+(name, email, passHash, nonce, scryptN, scryptR, scryptP) 
 VALUES
-($1, $2, $3, $4)
\ No newline at end of file
+($1, $2, $3, $4, $5, $6, $7)
\ No newline at end of file
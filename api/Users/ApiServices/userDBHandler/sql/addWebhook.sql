@@ -0,0 +1,15 @@
+/*
+Registers a webhook endpoint for a user, re-enabling it with a
+clean failure count when it was already registered
+
+Takes:
+	owner - string, the user that owns this
+	url - string, where collection changes are delivered
+*/
+
+INSERT INTO users.webhooks
+(owner, url)
+VALUES
+($1, $2)
+ON CONFLICT ON CONSTRAINT uniqueWebhook
+DO UPDATE SET failures=0, disabled=false
\ No newline at end of file
@@ -0,0 +1,14 @@
+/*
+Moves the history of every collection a user has aside, prefixing
+each collection name. History already moved aside is left alone.
+
+Names are cut short where the prefix would make them too long.
+
+Takes:
+	owner - string, user that owns it
+	prefix - string, prepended to each collection name
+	maxLength - int32, the longest a prefixed name may be
+*/
+
+UPDATE users.collectionHistory SET collection = left($2 || collection, $3)
+WHERE owner=$1 AND collection NOT LIKE '<deleted %'
\ No newline at end of file
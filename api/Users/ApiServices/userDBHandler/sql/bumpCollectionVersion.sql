@@ -0,0 +1,15 @@
+/*
+Moves a collection on to its next version, only if it is still at
+the version a change was made against.
+
+Affects no rows when another change got there first.
+
+Takes:
+	owner - string, user that owns it
+	collection - string, collection of that user
+	version - int64, the version the change was made against
+*/
+
+UPDATE users.collections
+SET version = version + 1
+WHERE owner=$1 AND name=$2 AND version=$3
\ No newline at end of file
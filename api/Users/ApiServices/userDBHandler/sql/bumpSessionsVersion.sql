@@ -0,0 +1,9 @@
+/*
+Marks the sessions of a user as changed so polling clients refetch them
+
+Takes:
+	name - string, user whose sessions changed
+*/
+
+UPDATE users.meta SET sessionsVersion = sessionsVersion + 1
+WHERE name=$1
\ No newline at end of file
@@ -0,0 +1,12 @@
+/*
+Changes a user's email to one they have proven they own, clearing
+the pending change and any outstanding verification.
+
+Takes:
+	name - string, the user
+	email - string, the new address
+*/
+
+UPDATE users.meta SET email=$2, verified=true, verifyToken=NULL,
+pendingEmail=NULL, emailChangeToken=NULL
+WHERE name=$1
\ No newline at end of file
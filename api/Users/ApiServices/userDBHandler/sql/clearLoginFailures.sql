@@ -0,0 +1,8 @@
+/*
+Forgets the failed logins of a user, lifting any lockout
+
+Takes:
+	name - string, the user
+*/
+
+UPDATE users.meta SET failedLogins=0, lockedUntil=NULL WHERE name=$1
\ No newline at end of file
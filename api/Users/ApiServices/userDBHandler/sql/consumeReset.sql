@@ -0,0 +1,11 @@
+/*
+Removes a reset key provided it is still valid, so it may only be
+used once.
+
+Takes:
+	name - string, user that owns it
+	resetKey - []byte, hash of the reset key
+*/
+
+DELETE FROM users.resets
+WHERE name=$1 AND resetKey=$2 AND endValid > now()
\ No newline at end of file
@@ -0,0 +1,9 @@
+/*
+Counts every session which is currently valid, across all users.
+
+Takes:
+	now - time.Time, the moment validity is judged at
+*/
+
+SELECT COUNT(*) FROM users.sessions
+WHERE startValid <= $1 AND endValid > $1
\ No newline at end of file
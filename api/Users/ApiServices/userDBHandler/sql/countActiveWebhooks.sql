@@ -0,0 +1,12 @@
+/*
+Counts the webhooks of a user which are still receiving deliveries,
+other than one endpoint
+
+Takes:
+	owner - string, the user that owns them
+	url - string, endpoint left out of the count
+*/
+
+SELECT count(*)
+FROM
+users.webhooks WHERE owner=$1 AND url<>$2 AND NOT disabled
\ No newline at end of file
@@ -0,0 +1,13 @@
+/*
+Counts the collections a user has
+
+Takes:
+	owner - string, user that owns them
+	tag - string, only collections with this tag regardless of case,
+		empty for every one
+*/
+
+SELECT count(*)
+FROM
+users.collections WHERE owner=$1 AND ($2 = '' OR
+	lower($2) IN (SELECT lower(tag) FROM unnest(tags) AS tag))
\ No newline at end of file
@@ -0,0 +1,11 @@
+/*
+Counts the users other than the provided one using an email,
+ignoring case.
+
+Takes:
+	email - string, the address
+	name - string, the user to ignore
+*/
+
+SELECT COUNT(*) FROM users.meta
+WHERE lower(email)=lower($1) AND name<>$2
\ No newline at end of file
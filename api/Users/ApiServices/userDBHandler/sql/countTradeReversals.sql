@@ -0,0 +1,12 @@
+/*
+Counts how many times a trade has been reversed, which should only
+ever be once
+
+Takes:
+	owner - string, user that owns it
+	collection - string, collection of that user
+	tradeID - string, the trade that may be reversed
+*/
+
+SELECT count(*) FROM users.collectionHistory
+WHERE owner=$1 AND collection=$2 AND reverses=$3
\ No newline at end of file
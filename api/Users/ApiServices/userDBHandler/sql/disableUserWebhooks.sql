@@ -0,0 +1,8 @@
+/*
+Disables every webhook of a user
+
+Takes:
+	owner - string, user that owns them
+*/
+
+UPDATE users.webhooks SET disabled=true WHERE owner=$1
\ No newline at end of file
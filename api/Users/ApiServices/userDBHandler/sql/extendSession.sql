@@ -0,0 +1,13 @@
+/*
+Extends the expiry of a session which expired no earlier than a cutoff
+
+Takes:
+	name - string, user that owns it
+	sessionKey - []byte, hash of a session key
+	endValid - timestamp, the new expiry
+	cutoff - timestamp, the earliest expiry still refreshable
+*/
+
+UPDATE users.sessions
+SET endValid=$3
+WHERE name=$1 AND sessionKey=$2 AND endValid > $4
\ No newline at end of file
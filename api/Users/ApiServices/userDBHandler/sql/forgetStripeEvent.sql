@@ -0,0 +1,8 @@
+/*
+Removes a stripe event so it may be handled again
+
+Takes:
+	id - string, the event id as provided by stripe
+*/
+
+DELETE FROM users.stripeEvents WHERE id=$1
\ No newline at end of file
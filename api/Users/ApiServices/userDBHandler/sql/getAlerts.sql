@@ -0,0 +1,12 @@
+/*
+Acquires every price alert a user has set, oldest first
+
+Takes:
+	owner - string, the user that owns them
+*/
+
+SELECT
+id, owner, collection, percent, threshold, since, created
+FROM
+users.alerts WHERE owner=$1
+ORDER BY created, id
\ No newline at end of file
@@ -0,0 +1,12 @@
+/*
+Acquires every price alert so they may be evaluated
+
+Takes:
+	nothing
+*/
+
+SELECT
+id, owner, collection, percent, threshold, since, created
+FROM
+users.alerts
+ORDER BY owner, created, id
\ No newline at end of file
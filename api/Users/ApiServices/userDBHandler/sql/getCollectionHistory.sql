@@ -4,8 +4,12 @@ Acquires the total history of a user's collection.
 Takes:
 	owner - string, user that owns it
 	collection - string, collection of that user
+
+Rows added before trades carried IDs have an empty tradeID.
+Only reversals have a non-empty reverses.
 */
 
-SELECT cardName, setName, quality, quantity, comment, lang, lastUpdate
+SELECT cardName, setName, quality, quantity, comment, lang, lastUpdate,
+COALESCE(tradeID, ''), COALESCE(reverses, '')
 FROM
 users.collectionHistory WHERE owner=$1 AND collection=$2
\ No newline at end of file
@@ -1,5 +1,5 @@
 /*
-Acquires the name of each collection a user has
+Acquires the name of each collection a user has, ordered by name
 
 Takes:
 	owner - string, user that owns it
@@ -7,6 +7,7 @@ Takes:
 */
 
 SELECT
-name, privacy
+name, privacy, tags
 FROM
-users.collections WHERE owner=$1
\ No newline at end of file
+users.collections WHERE owner=$1
+ORDER BY name
\ No newline at end of file
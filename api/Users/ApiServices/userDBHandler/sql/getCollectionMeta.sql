@@ -7,6 +7,7 @@ Takes:
 */
 
 SELECT
-name, owner, lastUpdate, privacy
+name, owner, lastUpdate, privacy, publicComments, locked, overLimit,
+currency, basis, version, tags
 FROM
 users.collections WHERE owner=$1 AND name=$2
\ No newline at end of file
@@ -0,0 +1,17 @@
+/*
+Acquires a page of the collections a user has, ordered by name
+
+Takes:
+	owner - string, user that owns them
+	limit - int, the most collections to return
+	offset - int, how many collections to skip
+	tag - string, only collections with this tag regardless of case,
+		empty for every one
+*/
+
+SELECT
+name, privacy, tags
+FROM
+users.collections WHERE owner=$1 AND ($4 = '' OR
+	lower($4) IN (SELECT lower(tag) FROM unnest(tags) AS tag))
+ORDER BY name LIMIT $2 OFFSET $3
\ No newline at end of file
@@ -0,0 +1,11 @@
+/*
+Acquires a single dead lettered mail
+
+Takes:
+	id - bigint, the letter to acquire
+*/
+
+SELECT
+id, recipient, template, subject, COALESCE(content, ''), lastError, attempts, time
+FROM
+users.deadLetters WHERE id=$1
\ No newline at end of file
@@ -0,0 +1,12 @@
+/*
+Acquires the oldest dead lettered mail first
+
+Takes:
+	limit - int, the most letters to return
+*/
+
+SELECT
+id, recipient, template, subject, COALESCE(content, ''), lastError, attempts, time
+FROM
+users.deadLetters
+ORDER BY id ASC LIMIT $1
\ No newline at end of file
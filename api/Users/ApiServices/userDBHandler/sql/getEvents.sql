@@ -0,0 +1,14 @@
+/*
+Acquires a page of the activity feed for a user, most recent first
+
+Takes:
+	owner - string, the user whose events are returned
+	before - bigint, only events with a lower id are returned
+	limit - int, the most events to return
+*/
+
+SELECT
+id, kind, collection, detail, time
+FROM
+users.events WHERE owner=$1 AND id < $2
+ORDER BY id DESC LIMIT $3
\ No newline at end of file
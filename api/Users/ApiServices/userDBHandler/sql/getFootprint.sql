@@ -0,0 +1,20 @@
+/*
+Acquires aggregate figures describing a user for support, nothing
+identifying a session or credential is returned.
+
+Never active users report the epoch as their last activity.
+
+Takes:
+	name - string, the user described
+*/
+
+SELECT
+(SELECT count(*) FROM users.sessions WHERE name=$1 AND endValid > now()),
+(SELECT count(*) FROM users.collections WHERE owner=$1),
+(SELECT count(*) FROM users.collectionContents WHERE owner=$1),
+(SELECT count(*) FROM users.collectionHistory WHERE owner=$1),
+COALESCE(GREATEST(
+	(SELECT max(startValid) FROM users.sessions WHERE name=$1),
+	(SELECT max(lastUpdate) FROM users.collections WHERE owner=$1),
+	(SELECT max(time) FROM users.events WHERE owner=$1)
+), 'epoch'::timestamp)
\ No newline at end of file
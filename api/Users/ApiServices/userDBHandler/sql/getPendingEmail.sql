@@ -0,0 +1,13 @@
+/*
+Acquires the current and pending email of a user when they provide
+the code for their pending change, locking the row until the
+transaction ends.
+
+Takes:
+	name - string, the user
+	emailChangeToken - []byte, hash of the code provided
+*/
+
+SELECT email, pendingEmail FROM users.meta
+WHERE name=$1 AND emailChangeToken=$2
+FOR UPDATE
\ No newline at end of file
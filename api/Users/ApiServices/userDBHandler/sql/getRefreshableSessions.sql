@@ -0,0 +1,17 @@
+/*
+Acquires the session keys for a provided user matching the provided
+session key which expired no earlier than a cutoff.
+
+Used when refreshing, where a session which only just expired may
+still be extended.
+
+Takes:
+	name - string, user that owns it
+	sessionKey - []byte, hash of a session key
+	cutoff - timestamp, the earliest expiry still refreshable
+*/
+
+SELECT name, sessionKey, startValid, endValid
+FROM
+users.sessions
+WHERE name=$1 AND sessionKey=$2 AND endValid > $3
\ No newline at end of file
@@ -1,6 +1,6 @@
 /*
 Acquires the every reset key for a provided user that matches
-the provided reset key and is valid.
+the provided reset key, expired or not so expiry can be reported.
 
 The fact that a row is returned means that the provided session/user
 combo is valid. We still check in the middleware though.
@@ -12,4 +12,4 @@ Takes:
 
 SELECT name, resetKey, startValid, endValid
 FROM users.resets
-WHERE name=$1 AND resetKey=$2 AND endValid > now()
\ No newline at end of file
+WHERE name=$1 AND resetKey=$2
\ No newline at end of file
@@ -10,7 +10,8 @@ Takes:
 	sessionKey - []byte, a valid session key
 */
 
-SELECT name, sessionKey, startValid, endValid
+SELECT name, sessionKey, startValid, endValid,
+COALESCE(lastUsed, startValid)
 FROM
 users.sessions
 WHERE name=$1 AND sessionKey=$2 AND endValid > now()
\ No newline at end of file
@@ -0,0 +1,8 @@
+/*
+Acquires the current version of the sessions of a user
+
+Takes:
+	name - string, user that owns them
+*/
+
+SELECT sessionsVersion FROM users.meta WHERE name=$1
\ No newline at end of file
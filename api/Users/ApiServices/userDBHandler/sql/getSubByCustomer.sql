@@ -0,0 +1,12 @@
+/*
+Acquires the subscription belonging to a stripe customer with
+no authentication
+
+Takes:
+	customerID - string, the customers id as provided by stripe
+*/
+
+SELECT name, Plan, CustomerID, SubID, StartTime, cooldownWaived,
+billingInterval
+FROM
+users.subs WHERE customerID=$1
\ No newline at end of file
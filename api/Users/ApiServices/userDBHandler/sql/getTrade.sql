@@ -0,0 +1,12 @@
+/*
+Acquires every row of a single trade made to a collection.
+
+Takes:
+	owner - string, user that owns it
+	collection - string, collection of that user
+	tradeID - string, the trade to acquire
+*/
+
+SELECT cardName, setName, quality, quantity, comment, lang, lastUpdate
+FROM
+users.collectionHistory WHERE owner=$1 AND collection=$2 AND tradeID=$3
\ No newline at end of file
@@ -0,0 +1,17 @@
+/*
+Acquires the total history of a user's collection in the order it
+was recorded, keeping the rows of each trade together.
+
+Takes:
+	owner - string, user that owns it
+	collection - string, collection of that user
+
+Rows added before trades carried IDs have an empty tradeID.
+Every row of a trade shares its creationTime.
+*/
+
+SELECT cardName, setName, quality, quantity, comment, lang, lastUpdate,
+COALESCE(tradeID, ''), COALESCE(reverses, ''), creationTime
+FROM
+users.collectionHistory WHERE owner=$1 AND collection=$2
+ORDER BY creationTime, tradeID
\ No newline at end of file
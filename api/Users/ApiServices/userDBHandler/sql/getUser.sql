@@ -5,6 +5,10 @@ Takes:
 	name - string, user that owns it
 */
 
-SELECT name, email, passhash, nonce, maxcollections, longestview
+SELECT name, email, passhash, nonce, maxcollections, longestview, forceRehash,
+scryptN, scryptR, scryptP,
+currency, locale, verified, COALESCE(verifyToken, ''::bytea),
+failedLogins, COALESCE(lastFailedLogin, 'epoch'::timestamp),
+COALESCE(lockedUntil, 'epoch'::timestamp)
 FROM
 users.meta WHERE name=$1
\ No newline at end of file
@@ -0,0 +1,11 @@
+/*
+Acquires every webhook endpoint a user has registered
+
+Takes:
+	owner - string, the user that owns them
+*/
+
+SELECT
+owner, url, failures, disabled
+FROM
+users.webhooks WHERE owner=$1
\ No newline at end of file
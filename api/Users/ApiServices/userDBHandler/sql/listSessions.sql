@@ -0,0 +1,13 @@
+/*
+Acquires the hashed key of every still valid session for a user
+alongside when it began, ends and was last used, oldest first.
+
+Takes:
+	name - string, user that owns them
+*/
+
+SELECT sessionKey, startValid, endValid, COALESCE(lastUsed, startValid)
+FROM
+users.sessions
+WHERE name=$1 AND endValid > now()
+ORDER BY startValid
\ No newline at end of file
@@ -0,0 +1,13 @@
+/*
+Acquires whether a collection may be modified, locking its row until
+the transaction ends so neither can change in the meantime.
+
+Takes:
+	owner - string, user that owns it
+	collection - string, collection of that user
+*/
+
+SELECT locked, overLimit
+FROM
+users.collections WHERE owner=$1 AND name=$2
+FOR UPDATE
\ No newline at end of file
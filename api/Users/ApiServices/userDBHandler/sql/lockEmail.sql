@@ -0,0 +1,9 @@
+/*
+Serializes transactions claiming an email, ignoring case, until the
+transaction ends.
+
+Takes:
+	email - string, the address
+*/
+
+SELECT pg_advisory_xact_lock(hashtext(lower($1)))
\ No newline at end of file
@@ -0,0 +1,12 @@
+/*
+Acquires the plan of a user, locking their subscription until the
+transaction ends so limits checked against it can't be raced.
+
+Takes:
+	name - string, user that owns it
+*/
+
+SELECT plan
+FROM
+users.subs WHERE name=$1
+FOR UPDATE
\ No newline at end of file
@@ -0,0 +1,9 @@
+/*
+Records that an alert fired, moves are measured from then on
+
+Takes:
+	id - string, the alert
+	since - timestamp, when it fired
+*/
+
+UPDATE users.alerts SET since=$2 WHERE id=$1
\ No newline at end of file
@@ -0,0 +1,14 @@
+/*
+Records a reset being sent to a user, provided none was sent since
+the cutoff.
+
+No rows are affected when the user has been sent a reset too recently.
+
+Takes:
+	name - string, user that owns it
+	sent - timestamp, when the reset is sent
+	cutoff - timestamp, resets sent after this throttle another
+*/
+
+UPDATE users.meta SET lastResetSent=$2
+WHERE name=$1 AND (lastResetSent IS NULL OR lastResetSent <= $3)
\ No newline at end of file
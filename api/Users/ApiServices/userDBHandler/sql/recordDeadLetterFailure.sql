@@ -0,0 +1,11 @@
+/*
+Notes another failed attempt to send a dead lettered mail
+
+Takes:
+	id - bigint, the letter that failed again
+	lastError - string, why the attempt failed
+*/
+
+UPDATE users.deadLetters
+SET lastError = $2, attempts = attempts + 1
+WHERE id=$1
\ No newline at end of file
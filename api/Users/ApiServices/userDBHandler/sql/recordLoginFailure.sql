@@ -0,0 +1,17 @@
+/*
+Counts a failed login, starting the count afresh when the last
+failure is too old for this to be consecutive.
+
+Takes:
+	name - string, the user
+	windowStart - timestamp, failures before this are forgotten
+	now - timestamp, when this failure happened
+
+Returns the consecutive failures including this one.
+*/
+
+UPDATE users.meta SET
+failedLogins = CASE WHEN lastFailedLogin > $2 THEN failedLogins + 1 ELSE 1 END,
+lastFailedLogin = $3
+WHERE name=$1
+RETURNING failedLogins
\ No newline at end of file
@@ -0,0 +1,11 @@
+/*
+Records a stripe event as handled, affecting no rows when it
+already was
+
+Takes:
+	id - string, the event id as provided by stripe
+*/
+
+INSERT INTO users.stripeEvents (id)
+SELECT $1
+WHERE NOT EXISTS (SELECT 1 FROM users.stripeEvents WHERE id=$1)
\ No newline at end of file
@@ -0,0 +1,14 @@
+/*
+Records a failed delivery to a webhook, disabling it once it has
+failed too many times in a row
+
+Takes:
+	owner - string, the user that owns it
+	url - string, the endpoint which failed
+	maxFailures - int32, consecutive failures before disabling
+*/
+
+UPDATE users.webhooks
+SET failures = failures + 1, disabled = (failures + 1 >= $3)
+WHERE owner=$1 AND url=$2 AND NOT disabled
+RETURNING disabled
\ No newline at end of file
@@ -0,0 +1,11 @@
+/*
+Clears the failure count of a webhook after a successful delivery
+
+Takes:
+	owner - string, the user that owns it
+	url - string, the endpoint which succeeded
+*/
+
+UPDATE users.webhooks
+SET failures = 0
+WHERE owner=$1 AND url=$2
\ No newline at end of file
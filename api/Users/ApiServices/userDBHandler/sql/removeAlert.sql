@@ -0,0 +1,9 @@
+/*
+Deletes a single price alert
+
+Takes:
+	owner - string, the user that owns it
+	id - string, the alert
+*/
+
+DELETE FROM users.alerts WHERE owner=$1 AND id=$2
\ No newline at end of file
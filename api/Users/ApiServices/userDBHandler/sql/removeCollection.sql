@@ -0,0 +1,9 @@
+/*
+Removes a collection, its contents must be removed first
+
+Takes:
+	owner - string, user that owns it
+	name - string, the collection being removed
+*/
+
+DELETE FROM users.collections WHERE owner=$1 AND name=$2
\ No newline at end of file
@@ -0,0 +1,9 @@
+/*
+Removes the current contents of a collection
+
+Takes:
+	owner - string, user that owns it
+	collection - string, the collection being removed
+*/
+
+DELETE FROM users.collectionContents WHERE owner=$1 AND collection=$2
\ No newline at end of file
@@ -0,0 +1,8 @@
+/*
+Forgets a dead lettered mail once it has been delivered
+
+Takes:
+	id - bigint, the letter to remove
+*/
+
+DELETE FROM users.deadLetters WHERE id=$1
\ No newline at end of file
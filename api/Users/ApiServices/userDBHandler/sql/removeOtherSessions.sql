@@ -0,0 +1,9 @@
+/*
+Removes every session of a user except one
+
+Takes:
+	name - string, user that owns them
+	sessionKey - []byte, hash of the session key to keep
+*/
+
+DELETE FROM users.sessions WHERE name=$1 AND sessionKey<>$2
\ No newline at end of file
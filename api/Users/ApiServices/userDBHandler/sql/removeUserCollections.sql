@@ -0,0 +1,9 @@
+/*
+Removes every collection a user has, their contents must be
+removed first
+
+Takes:
+	owner - string, user that owns them
+*/
+
+DELETE FROM users.collections WHERE owner=$1
\ No newline at end of file
@@ -0,0 +1,8 @@
+/*
+Removes the contents of every collection a user has
+
+Takes:
+	owner - string, user that owns them
+*/
+
+DELETE FROM users.collectionContents WHERE owner=$1
\ No newline at end of file
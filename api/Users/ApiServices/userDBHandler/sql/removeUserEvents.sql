@@ -0,0 +1,8 @@
+/*
+Removes every event of a user
+
+Takes:
+	owner - string, user that owns them
+*/
+
+DELETE FROM users.events WHERE owner=$1
\ No newline at end of file
@@ -0,0 +1,8 @@
+/*
+Removes every reset of a user
+
+Takes:
+	name - string, user that owns them
+*/
+
+DELETE FROM users.resets WHERE name=$1
\ No newline at end of file
@@ -0,0 +1,12 @@
+/*
+Renames a collection, its contents follow by cascade
+
+Takes:
+	owner - string, user that owns it
+	collection - string, current name of the collection
+	newName - string, name the collection takes
+*/
+
+UPDATE users.collections
+SET name = $3
+WHERE owner=$1 AND name=$2
\ No newline at end of file
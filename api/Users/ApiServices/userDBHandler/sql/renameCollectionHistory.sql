@@ -0,0 +1,13 @@
+/*
+Moves the history of a collection to its new name, nothing else
+about history ever changes
+
+Takes:
+	owner - string, user that owns it
+	collection - string, previous name of the collection
+	newName - string, name the collection took
+*/
+
+UPDATE users.collectionHistory
+SET collection = $3
+WHERE owner=$1 AND collection=$2
\ No newline at end of file
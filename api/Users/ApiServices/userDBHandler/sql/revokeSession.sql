@@ -0,0 +1,12 @@
+/*
+Removes the session of a user whose hashed key begins with a
+provided ID
+
+Takes:
+	name - string, user that owns it
+	id - []byte, the leading bytes of a hashed session key
+	idLength - int32, how many leading bytes make up an ID
+*/
+
+DELETE FROM users.sessions
+WHERE name=$1 AND substring(sessionKey from 1 for $3)=$2
\ No newline at end of file
@@ -0,0 +1,15 @@
+/*
+Strips a deleted user down to a tombstone reserving their name.
+
+The empty passHash never matches a derived password so the user
+can never login again.
+
+Takes:
+	name - string, the user
+*/
+
+UPDATE users.meta SET email='', passHash='', nonce='',
+verified=false, verifyToken=NULL, pendingEmail=NULL, emailChangeToken=NULL,
+currency='', locale='', failedLogins=0, lockedUntil=NULL,
+lastResetSent=NULL
+WHERE name=$1
\ No newline at end of file
@@ -0,0 +1,12 @@
+/*
+Locks or unlocks a collection, locked collections reject edits
+
+Takes:
+	owner - string, user that owns it
+	collection - string, collection of that user
+	locked - bool, whether edits are rejected
+*/
+
+UPDATE users.collections
+SET locked = $3
+WHERE owner=$1 AND name=$2
\ No newline at end of file
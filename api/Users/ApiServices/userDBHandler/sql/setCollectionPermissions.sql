@@ -4,9 +4,10 @@ Updates a collection on the database to have the desired permissions
 Takes:
 	name - string, user that owns it
 	publicViewing - string, a valid privacy setting
+	publicComments - bool, whether comments are visible publicly
 
 */
 
 UPDATE users.collections
-SET Privacy = $3
+SET Privacy = $3, publicComments = $4
 WHERE owner=$1 AND name=$2
\ No newline at end of file
@@ -0,0 +1,12 @@
+/*
+Replaces the tags a collection is labelled with
+
+Takes:
+	owner - string, user that owns it
+	collection - string, collection of that user
+	tags - []string, every tag the collection now has
+*/
+
+UPDATE users.collections
+SET tags = $3
+WHERE owner=$1 AND name=$2
\ No newline at end of file
@@ -0,0 +1,13 @@
+/*
+Sets the currency and basis a collection is valued in by default
+
+Takes:
+	owner - string, user that owns it
+	collection - string, collection of that user
+	currency - string, the default currency, empty defers to the owner
+	basis - string, the default valuation basis, empty for market
+*/
+
+UPDATE users.collections
+SET currency = $3, basis = $4
+WHERE owner=$1 AND name=$2
\ No newline at end of file
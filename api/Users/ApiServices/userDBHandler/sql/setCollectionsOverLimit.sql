@@ -0,0 +1,16 @@
+/*
+Marks each of a user's collections beyond their plan's cap as over
+the limit, clearing it from the rest.
+
+The most recently updated collections are the ones kept writable.
+
+Takes:
+	owner - string, user that owns them
+	maxcollections - int, how many collections that user may have
+*/
+
+UPDATE users.collections SET overLimit = name NOT IN (
+	SELECT name FROM users.collections WHERE owner=$1
+	ORDER BY lastUpdate DESC, name
+	LIMIT $2)
+WHERE owner=$1
\ No newline at end of file
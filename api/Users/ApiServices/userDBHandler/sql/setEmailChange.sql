@@ -0,0 +1,12 @@
+/*
+Stages a change of email for a user until the code mailed to the
+new address is provided, replacing any previous pending change.
+
+Takes:
+	name - string, the user
+	pendingEmail - string, the address to change to
+	emailChangeToken - []byte, hash of the code
+*/
+
+UPDATE users.meta SET pendingEmail=$2, emailChangeToken=$3
+WHERE name=$1
\ No newline at end of file
@@ -0,0 +1,12 @@
+/*
+Flags a user to have their password rehashed with a fresh nonce
+the next time they successfully login
+
+Takes:
+	name - string, user that owns it
+*/
+
+UPDATE users.meta
+SET forceRehash = true
+WHERE
+name=$1
\ No newline at end of file
@@ -0,0 +1,9 @@
+/*
+Refuses logins for a user until a given time
+
+Takes:
+	name - string, the user
+	lockedUntil - timestamp, when logins are allowed again
+*/
+
+UPDATE users.meta SET lockedUntil=$2 WHERE name=$1
\ No newline at end of file
@@ -1,13 +1,16 @@
 /*
-Updates a user's password on the database
+Updates a user's password on the database, satisfying any
+pending forced rehash
 
 Takes:
 	name - string, user that owns it
 	passHash - bytea, the result of scrypt(password, nonce)
 	nonce - bytea, the nonce used for deriving passHash
+	scryptN, scryptR, scryptP - int, the parameters passHash was derived with
 */
 
 UPDATE users.meta
-SET passHash = $2, nonce=$3
+SET passHash = $2, nonce=$3, forceRehash=false,
+scryptN=$4, scryptR=$5, scryptP=$6
 WHERE
 name=$1
\ No newline at end of file
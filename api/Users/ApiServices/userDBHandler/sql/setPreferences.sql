@@ -0,0 +1,13 @@
+/*
+Updates how a user prefers their collections be valued and displayed
+
+Takes:
+	name - string, user that owns it
+	currency - string, the preferred currency, empty for the default
+	locale - string, the preferred locale, empty for the default
+*/
+
+UPDATE users.meta
+SET currency = $2, locale = $3
+WHERE
+name=$1
\ No newline at end of file
@@ -0,0 +1,10 @@
+/*
+Sets how often a user's plan is billed
+
+Takes:
+	name - string, user that owns it
+	interval - string, either month or year
+*/
+
+UPDATE users.subs SET billingInterval=$2
+WHERE name=$1
\ No newline at end of file
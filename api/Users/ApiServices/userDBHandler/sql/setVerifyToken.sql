@@ -0,0 +1,11 @@
+/*
+Sets the code a user must provide to verify their email, replacing
+any previous code. Verified users are left alone.
+
+Takes:
+	name - string, the user
+	verifyToken - []byte, hash of the code
+*/
+
+UPDATE users.meta SET verifyToken=$2
+WHERE name=$1 AND NOT verified
\ No newline at end of file
@@ -0,0 +1,11 @@
+/*
+Notes that a session was just used
+
+Takes:
+	name - string, user that owns it
+	sessionKey - []byte, hash of a valid session key
+*/
+
+UPDATE users.sessions
+SET lastUsed = now()
+WHERE name=$1 AND sessionKey=$2
\ No newline at end of file
@@ -0,0 +1,13 @@
+/*
+Drops all but the most recent events for a user
+
+Takes:
+	owner - string, the user whose events are trimmed
+	kept - int, how many of the most recent events are kept
+*/
+
+DELETE FROM users.events
+WHERE owner=$1 AND id <= (
+	SELECT id FROM users.events WHERE owner=$1
+	ORDER BY id DESC OFFSET $2 LIMIT 1
+)
\ No newline at end of file
@@ -0,0 +1,11 @@
+/*
+Marks a user's email as verified when they provide their code,
+which may only ever be used once.
+
+Takes:
+	name - string, the user
+	verifyToken - []byte, hash of the code provided
+*/
+
+UPDATE users.meta SET verified=true, verifyToken=NULL
+WHERE name=$1 AND verifyToken=$2
\ No newline at end of file
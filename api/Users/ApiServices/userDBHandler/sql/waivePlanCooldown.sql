@@ -0,0 +1,12 @@
+/*
+Lets the next plan change for a user skip the cooldown.
+
+Cleared by mod_sub once that change is made.
+
+Takes:
+	name - string, user that owns it
+*/
+
+UPDATE users.subs
+SET cooldownWaived = true
+WHERE name=$1
\ No newline at end of file
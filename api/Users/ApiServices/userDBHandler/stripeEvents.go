@@ -0,0 +1,42 @@
+package userDB
+
+import(
+
+	"fmt"
+
+	"github.com/jackc/pgx"
+
+)
+
+// The code postgres raises on a unique constraint violation
+const uniqueViolation string = "23505"
+
+// Records a stripe event as handled, returning false when it already
+// was so it isn't acted upon twice.
+func RecordStripeEvent(pool *pgx.ConnPool, id string) (bool, error) {
+
+	tag, err:= pool.Exec("recordStripeEvent", id)
+	if pgErr, ok:= err.(pgx.PgError); ok && pgErr.Code == uniqueViolation {
+		// Lost a race against a concurrent delivery of the same event
+		return false, nil
+	}
+	if err!=nil {
+		return false, fmt.Errorf("failed to record stripe event, %v", err)
+	}
+
+	return tag.RowsAffected() > 0, nil
+
+}
+
+// Removes a recorded stripe event so a later delivery of it is
+// handled, for when handling it failed.
+func ForgetStripeEvent(pool *pgx.ConnPool, id string) error {
+
+	_, err:= pool.Exec("forgetStripeEvent", id)
+	if err!=nil {
+		return fmt.Errorf("failed to forget stripe event, %v", err)
+	}
+
+	return nil
+
+}
@@ -0,0 +1,80 @@
+package userDB
+
+import(
+
+	"testing"
+
+	"time"
+
+)
+
+// Events are only fresh the first time, unless forgotten.
+func TestRecordStripeEvent(t *testing.T) {
+	t.Parallel()
+
+	id:= "evt_" + randString(24)
+
+	fresh, err:= RecordStripeEvent(pool, id)
+	if err!=nil {
+		t.Fatal("failed to record event", err)
+	}
+	if !fresh {
+		t.Fatal("new event not fresh")
+	}
+
+	fresh, err = RecordStripeEvent(pool, id)
+	if err!=nil {
+		t.Fatal(err)
+	}
+	if fresh {
+		t.Fatal("replayed event fresh")
+	}
+
+	err = ForgetStripeEvent(pool, id)
+	if err!=nil {
+		t.Fatal("failed to forget event", err)
+	}
+
+	fresh, err = RecordStripeEvent(pool, id)
+	if err!=nil {
+		t.Fatal(err)
+	}
+	if !fresh {
+		t.Fatal("forgotten event not fresh")
+	}
+
+}
+
+func TestGetSubByCustomer(t *testing.T) {
+	t.Parallel()
+
+	user:= randString(int(randByte()) % 31)
+	session, err:= AddUser(pool, user, "bar", "foo")
+	if err!=nil {
+		t.Fatal("failed to add user ", err)
+	}
+
+	time.Sleep(stepSleepTime)
+
+	sub:= randomElement(SubTiers)
+	for sub == DefaultSubLevel{
+		sub = randomElement(SubTiers)
+	}
+	custID:= "cus_" + randString(24)
+	subID:= "sub_" + randString(24)
+	err = ModSub(pool, user, sub, MonthlyInterval, custID, subID, session)
+	if err!=nil {
+		t.Fatal("failed to add sub", err)
+	}
+
+	time.Sleep(stepSleepTime)
+
+	s, err:= GetSubByCustomer(pool, custID)
+	if err!=nil {
+		t.Fatal("failed to get sub", err)
+	}
+	if s.Name != user || s.Plan != sub || s.SubID != subID {
+		t.Fatal("incorrect sub acquired", s)
+	}
+
+}
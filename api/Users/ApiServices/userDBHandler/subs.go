@@ -12,6 +12,21 @@ import(
 type Subscription struct{
 	Name, Plan, CustomerID, SubID string
 	StartTime time.Time
+
+	// How often the plan is billed, one of BillingIntervals
+	Interval string
+
+	// The next plan change may skip the cooldown
+	CooldownWaived bool
+}
+
+// Returned when a plan change comes too soon after the last one.
+type PlanCooldownError struct{
+	Remaining time.Duration
+}
+
+func (e PlanCooldownError) Error() string {
+	return fmt.Sprintf("plan changed too recently, %v remaining", e.Remaining)
 }
 
 // Adds a new subscription to a user or updates an existing one.
@@ -21,7 +36,7 @@ type Subscription struct{
 //
 // A unique key provision prevents users from getting double charged
 // as long as we check to ensure that we aren't setting the same twice.
-func ModSub(pool *pgx.ConnPool, user, sub,
+func ModSub(pool *pgx.ConnPool, user, sub, interval,
 	customerID, subID string, sessionKey []byte) (error) {
 
 	if sessionKey!=nil {
@@ -34,7 +49,7 @@ func ModSub(pool *pgx.ConnPool, user, sub,
 
 	// Get the sub but avoid another round trip to validate an already
 	// valid session.
-	validChoice, err:= DifferentPlan(pool, user, sub)
+	validChoice, err:= DifferentPlan(pool, user, sub, interval)
 	if err!=nil {
 		return err
 	}
@@ -44,41 +59,55 @@ func ModSub(pool *pgx.ConnPool, user, sub,
 
 	// Ensure that we can't change sub status without changing
 	// its actual effects.
-	tx, err:= pool.Begin()
-	if err!=nil {
-		return fmt.Errorf("failed to grab a transaction,", err)
+	return withTx(pool, func(tx *pgx.Tx) error {
+		return modSub(tx, user, sub, interval, customerID, subID)
+	})
+
+}
+
+// Changes the subscription of a user alongside its effects.
+//
+// Use as a transaction so the sub and its effects always match.
+func modSub(tx *pgx.Tx, user, sub, interval, customerID, subID string) error {
+
+	if sub == DefaultSubLevel {
+		interval = MonthlyInterval
 	}
-	// Make sure we can safely exit at any time
-	defer tx.Rollback()
 
 	// Send the new subscription details off to the db.
-	_, err = tx.Exec("modSub", user, sub, time.Now(),
+	_, err:= tx.Exec("modSub", user, sub, time.Now(),
 		customerID, subID)
 	if err!=nil {
 		return err
 	}
 
-	err = setSubEffects(tx, user, sub)
+	_, err = tx.Exec("setSubInterval", user, interval)
 	if err!=nil {
 		return err
 	}
 
-	tx.Commit()
-	
-	return err
+	return setSubEffects(tx, user, sub)
 
 }
 
-// Checks if adding a plan to a user would actually change the user's plan
+// Checks if moving a user to a plan billed at an interval would
+// actually change their subscription, so the same tier at another
+// interval is a change.
 //
 // No authentication as no user data apart from sub plan is revealed.
-func DifferentPlan(pool *pgx.ConnPool, user, sub string) (bool, error) {
+// The free tier is never billed so its interval is ignored. The same
+// plan is reported as false rather than an error, errors are left for
+// failing to read the user's current plan.
+func DifferentPlan(pool *pgx.ConnPool, user, sub,
+	interval string) (bool, error) {
+
 	s, err:= GetSub(pool, user, nil)
 	if err!=nil {
 		return false, err
 	}
-	if s.Plan == sub {
-		return false, fmt.Errorf("Duplicated sub attempt")
+	if s.Plan == sub &&
+		(sub == DefaultSubLevel || s.Interval == interval) {
+		return false, nil
 	}
 
 	return true, nil
@@ -102,7 +131,27 @@ func GetSub(pool *pgx.ConnPool, user string,
 	err:= pool.QueryRow("getSub", user).Scan(
 		&s.Name , &s.Plan ,
 		&s.CustomerID, &s.SubID,
-		&s.StartTime)
+		&s.StartTime, &s.CooldownWaived, &s.Interval)
+	if err!=nil{
+		return nil, errorHandle(err, ScanError)
+	}
+
+	return &s, nil
+
+}
+
+// Acquires the subscription belonging to a stripe customer.
+//
+// No authentication, this is meant for events stripe sends us.
+func GetSubByCustomer(pool *pgx.ConnPool,
+	customerID string) (*Subscription, error) {
+
+	s:= Subscription{}
+
+	err:= pool.QueryRow("getSubByCustomer", customerID).Scan(
+		&s.Name , &s.Plan ,
+		&s.CustomerID, &s.SubID,
+		&s.StartTime, &s.CooldownWaived, &s.Interval)
 	if err!=nil{
 		return nil, errorHandle(err, ScanError)
 	}
@@ -134,6 +183,13 @@ func setSubEffects(tx *pgx.Tx, user, sub string) error {
 	}
 	
 	_, err:= tx.Exec("setSubEffects", user, maxCollections, int64(longestview))
+	if err!=nil {
+		return err
+	}
+
+	// Collections beyond the cap are kept, as read only, so
+	// downgrading never loses anything
+	_, err = tx.Exec("setCollectionsOverLimit", user, maxCollections)
 
 	return err
 	
@@ -148,4 +204,46 @@ func addSub(tx *pgx.Tx, user string) error {
 		DefaultID, DefaultID)
 
 	return err
-}
\ No newline at end of file
+}
+
+// Ensures a subscription may move to plan at now without changing
+// plans more than once per cooldown.
+//
+// StartTime is reset by every change so it marks the last one. Only
+// moves between paid plans are held back; signing up and cancelling
+// don't prorate and are always allowed. A waived cooldown lets a
+// single change through regardless.
+//
+// Returns a PlanCooldownError when the change must wait.
+func CheckPlanCooldown(s *Subscription, plan string,
+	cooldown time.Duration, now time.Time) error {
+
+	if cooldown <= 0 || s.CooldownWaived {
+		return nil
+	}
+	if s.Plan == DefaultSubLevel || plan == DefaultSubLevel {
+		return nil
+	}
+
+	remaining:= s.StartTime.Add(cooldown).Sub(now)
+	if remaining > 0 {
+		return PlanCooldownError{remaining}
+	}
+
+	return nil
+
+}
+
+// Lets the user's next plan change skip the cooldown.
+//
+// No authentication, this is meant for admins.
+func WaivePlanCooldown(pool *pgx.ConnPool, user string) error {
+
+	_, err:= pool.Exec("waivePlanCooldown", user)
+	if err!=nil {
+		return fmt.Errorf("failed to waive plan cooldown, %v", err)
+	}
+
+	return nil
+
+}
@@ -6,6 +6,10 @@ import(
 
 	"time"
 
+	"fmt"
+
+	"github.com/jackc/pgx"
+
 )
 
 // Add some users and change the subs. Check they match
@@ -44,7 +48,7 @@ func TestSubAdd(t *testing.T) {
 		}
 		custID = randString(int(randByte()))
 		subID = randString(int(randByte()))
-		err = ModSub(pool, user, sub, custID, subID, session)
+		err = ModSub(pool, user, sub, MonthlyInterval, custID, subID, session)
 		if err!=nil {
 			t.Fatal("failed to change add sub", err)
 		}
@@ -81,6 +85,56 @@ func TestSubAdd(t *testing.T) {
 }
 
 
+// Switching only the billing interval is a plan change, repeating
+// both is not.
+func TestSubInterval(t *testing.T) {
+	t.Parallel()
+
+	user:= randString(int(randByte()))
+	session, err:= AddUser(pool, user, "bar", "foo")
+	if err!=nil {
+		t.Fatal("failed to add user ", err)
+	}
+
+	err = ModSub(pool, user, "Preordain", MonthlyInterval, "42", "12", session)
+	if err!=nil {
+		t.Fatal("failed to add sub", err)
+	}
+
+	err = ModSub(pool, user, "Preordain", MonthlyInterval, "42", "12", session)
+	if err==nil {
+		t.Fatal("allowed the same plan twice")
+	}
+
+	err = ModSub(pool, user, "Preordain", AnnualInterval, "42", "12", session)
+	if err!=nil {
+		t.Fatal("refused switching interval", err)
+	}
+
+	s, err:= GetSub(pool, user, session)
+	if err!=nil {
+		t.Fatal("failed to get sub", err)
+	}
+	if s.Plan != "Preordain" || s.Interval != AnnualInterval {
+		t.Fatal("interval did not stick", s.Plan, s.Interval)
+	}
+
+	// Free plans are never billed so are always monthly
+	err = ModSub(pool, user, DefaultSubLevel, AnnualInterval,
+		"42", DefaultID, session)
+	if err!=nil {
+		t.Fatal("failed to return to free", err)
+	}
+	s, err = GetSub(pool, user, session)
+	if err!=nil {
+		t.Fatal("failed to get sub", err)
+	}
+	if s.Interval != MonthlyInterval {
+		t.Fatal("free plan not monthly", s.Interval)
+	}
+
+}
+
 // Add a finite amount of users and ensure that
 func TestSubEffects(t *testing.T) {
 	t.Parallel()
@@ -99,7 +153,7 @@ func TestSubEffects(t *testing.T) {
 		}
 
 		// Switch them to the plan we desire
-		err = ModSub(pool, user, sub, "42", "12", session)
+		err = ModSub(pool, user, sub, MonthlyInterval, "42", "12", session)
 		if err!=nil {
 			if sub == DefaultSubLevel{
 				// We shouldn't be able to as this is where they start at
@@ -124,4 +178,136 @@ func TestSubEffects(t *testing.T) {
 		}
 
 	}
-}
\ No newline at end of file
+}
+
+// Fails partway through changing a sub and ensures neither the
+// sub nor its effects changed.
+func TestSubRollback(t *testing.T) {
+	t.Parallel()
+
+	user:= randString(int(randByte()))
+	session, err:= AddUser(pool, user, "bar", "foo")
+	if err!=nil {
+		t.Fatal("failed to add user ", err)
+	}
+
+	injected:= fmt.Errorf("injected failure")
+	err = withTx(pool, func(tx *pgx.Tx) error {
+		err:= modSub(tx, user, "Sensei's Top", MonthlyInterval,
+			randString(int(randByte())), randString(int(randByte())))
+		if err!=nil {
+			t.Fatal("failed to change sub inside transaction", err)
+		}
+
+		return injected
+	})
+	if err != injected {
+		t.Fatal("injected failure not returned", err)
+	}
+
+	time.Sleep(testSleepTime)
+
+	s, err:= GetSub(pool, user, session)
+	if err!=nil {
+		t.Fatal("failed to get sub", err)
+	}
+	if s.Plan != DefaultSubLevel || s.SubID != DefaultID {
+		t.Fatal("sub changed despite rollback")
+	}
+
+	u, err:= GetUser(pool, user)
+	if err!=nil {
+		t.Fatal("failed to get user", err)
+	}
+	if int(u.MaxCollections) != SubTiersToCollections[DefaultSubLevel] {
+		t.Fatal("sub effects applied despite rollback")
+	}
+
+}
+
+// Changes between paid plans twice in quick succession, the second
+// change should wait out the cooldown until an admin waives it.
+func TestPlanCooldown(t *testing.T) {
+	t.Parallel()
+
+	const cooldown time.Duration = time.Hour
+
+	user:= randString(int(randByte()))
+	session, err:= AddUser(pool, user, "bar", "foo")
+	if err!=nil {
+		t.Fatal("failed to add user ", err)
+	}
+
+	// Signing up is never held back
+	s, err:= GetSub(pool, user, session)
+	if err!=nil {
+		t.Fatal("failed to get sub", err)
+	}
+	err = CheckPlanCooldown(s, "Preordain", cooldown, time.Now())
+	if err!=nil {
+		t.Fatal("sign up held back by cooldown", err)
+	}
+	err = ModSub(pool, user, "Preordain", MonthlyInterval, "42", "12", session)
+	if err!=nil {
+		t.Fatal("failed to change sub", err)
+	}
+
+	time.Sleep(stepSleepTime)
+
+	s, err = GetSub(pool, user, session)
+	if err!=nil {
+		t.Fatal("failed to get sub", err)
+	}
+	err = CheckPlanCooldown(s, "Sensei's Top", cooldown, time.Now())
+	cooldownErr, ok:= err.(PlanCooldownError)
+	if !ok {
+		t.Fatal("rapid plan change allowed", err)
+	}
+	if cooldownErr.Remaining <= 0 || cooldownErr.Remaining > cooldown {
+		t.Fatal("incorrect time remaining", cooldownErr.Remaining)
+	}
+
+	// Cancelling and waiting out the cooldown are always fine
+	err = CheckPlanCooldown(s, DefaultSubLevel, cooldown, time.Now())
+	if err!=nil {
+		t.Fatal("cancellation held back by cooldown", err)
+	}
+	err = CheckPlanCooldown(s, "Sensei's Top", cooldown,
+		time.Now().Add(cooldown))
+	if err!=nil {
+		t.Fatal("plan change held back after cooldown", err)
+	}
+
+	err = WaivePlanCooldown(pool, user)
+	if err!=nil {
+		t.Fatal("failed to waive cooldown", err)
+	}
+
+	time.Sleep(stepSleepTime)
+
+	s, err = GetSub(pool, user, session)
+	if err!=nil {
+		t.Fatal("failed to get sub", err)
+	}
+	err = CheckPlanCooldown(s, "Sensei's Top", cooldown, time.Now())
+	if err!=nil {
+		t.Fatal("waived cooldown still held back plan change", err)
+	}
+
+	// Waivers only last a single change
+	err = ModSub(pool, user, "Sensei's Top", MonthlyInterval, "42", "12", session)
+	if err!=nil {
+		t.Fatal("failed to change sub", err)
+	}
+
+	time.Sleep(stepSleepTime)
+
+	s, err = GetSub(pool, user, session)
+	if err!=nil {
+		t.Fatal("failed to get sub", err)
+	}
+	if s.CooldownWaived {
+		t.Fatal("waiver survived a plan change")
+	}
+
+}
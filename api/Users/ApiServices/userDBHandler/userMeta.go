@@ -6,7 +6,9 @@ import(
 
 	"time"
 
-	"crypto/subtle"
+	"crypto/sha256"
+
+	"unicode/utf8"
 
 	"github.com/jackc/pgx"
 
@@ -17,6 +19,22 @@ type User struct{
 	PassHash, Nonce []byte	
 	MaxCollections int32
 	Longestview time.Duration
+	// The password is rehashed with a fresh nonce on the next
+	// successful login.
+	ForceRehashOnNextLogin bool
+	// What PassHash was derived with
+	HashParams HashParams
+	// How the user prefers values be shown, empty when unset.
+	Currency, Locale string
+	// Whether the user has proven they own Email. VerifyToken is the
+	// hash of the outstanding code, empty once verified.
+	Verified bool
+	VerifyToken []byte
+	// Consecutive failed logins, when the latest was and when the
+	// account may next be logged into. LockedUntil is in the past
+	// when not locked out.
+	FailedLogins int32
+	LastFailedLogin, LockedUntil time.Time
 }
 
 // Acquires the provided user from the database with no authentication.
@@ -30,7 +48,12 @@ func GetUser(pool *pgx.ConnPool, user string) (*User, error) {
 	err := pool.QueryRow("getUser",
 		user).Scan(&u.Name, &u.Email,
 			&u.PassHash, &u.Nonce,
-			&u.MaxCollections, &LongestviewAsInt)
+			&u.MaxCollections, &LongestviewAsInt,
+			&u.ForceRehashOnNextLogin,
+			&u.HashParams.N, &u.HashParams.R, &u.HashParams.P,
+			&u.Currency, &u.Locale,
+			&u.Verified, &u.VerifyToken,
+			&u.FailedLogins, &u.LastFailedLogin, &u.LockedUntil)
 	if err!=nil {
 		return nil, errorHandle(err, ScanError)
 	}
@@ -47,39 +70,152 @@ func GetUser(pool *pgx.ConnPool, user string) (*User, error) {
 // is unlikely though thanks to the table constraints.
 func AddUser(pool *pgx.ConnPool, user,
 	email, password string) ([]byte, error) {
-	
-	tx, err:= pool.Begin()
+
+	err:= addUser(pool, user, email, password)
 	if err!=nil {
-		return nil,
-		fmt.Errorf("failed to grab a transaction,", err)
+		return nil, err
 	}
-	// Make sure we can safely exit at any time
-	defer tx.Rollback()
 
+	// Send a new session off to the db
+	return AddSession(pool, user)
+
+}
+
+// Adds a new user alongside their sub without issuing a session.
+func addUser(pool *pgx.ConnPool, user, email, password string) error {
+
+	if !utf8.ValidString(user) || !utf8.ValidString(email) {
+		return ErrBadEncoding
+	}
+	
 	// Hash their password and get a complementary nonce.
-	passHash, nonce, err:= derivePassword([]byte(password))
+	passHash, nonce, params, err:= derivePassword([]byte(password))
 	if err!=nil {
-		return nil, errorHandle(err, "failed to derive password")
+		return errorHandle(err, "failed to derive password")
 	}
 
-	// Send the user away to the db
-	_, err = tx.Exec("addUser", user, email, passHash, nonce)
+	// A user needs to have a subscription as well as a meta entry.
+	//
+	// Failing to add either results in a broken user so we avoid that!
+	return withTx(pool, func(tx *pgx.Tx) error {
+
+		// Send the user away to the db
+		_, err:= tx.Exec("addUser", user, email, passHash, nonce,
+			params.N, params.R, params.P)
+		if err!=nil {
+			return fmt.Errorf("failed to send user, %v", err)
+		}
+
+		err = addSub(tx, user)
+		if err!=nil {
+			return fmt.Errorf("failed to setup sub, %v", err)
+		}
+
+		return nil
+	})
+
+}
+
+// Issues a fresh code for a user to verify their email with, no
+// authentication is performed.
+//
+// Only the hash of the code is stored, any previous code stops
+// working. Users who are already verified receive ErrBadVerifyToken.
+func IssueVerification(pool *pgx.ConnPool, user string) (string, error) {
+
+	code:= randString(ResetLength)
+	hashed:= sha256.Sum256([]byte(code))
+
+	tag, err:= pool.Exec("setVerifyToken", user, hashed[:])
 	if err!=nil {
-		return nil, fmt.Errorf("failed to send user", err)
+		return "", fmt.Errorf("failed to send verification code, %v", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return "", ErrBadVerifyToken
 	}
 
-	err = addSub(tx, user)
+	return code, nil
+
+}
+
+// Marks a user's email as verified if the provided code is the one
+// most recently issued to them.
+func VerifyEmail(pool *pgx.ConnPool, user, code string) error {
+
+	hashed:= sha256.Sum256([]byte(code))
+
+	tag, err:= pool.Exec("verifyEmail", user, hashed[:])
 	if err!=nil {
-		return nil, fmt.Errorf("failed to setup sub", err)
+		return fmt.Errorf("failed to verify email, %v", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrBadVerifyToken
 	}
 
-	// A user needs to have a subscription as well as a meta entry.
-	//
-	// Failing to add either results in a broken user so we avoid that!
-	tx.Commit()
+	return nil
 
-	// Send a new session off to the db
-	return AddSession(pool, user)
+}
+
+// The length of the temporary password given to users invited in bulk.
+const invitePasswordLength int = 32
+
+// A user to be created as part of a bulk import.
+//
+// An empty Password means the user is invited and receives a random
+// password they are expected to replace.
+type NewUserSpec struct{
+	Name, Email string
+	Password string
+}
+
+// The outcome of adding a single user during a bulk import.
+//
+// ResetCode is present on success so the user can set their own password.
+type BulkAddResult struct{
+	Name string
+	ResetCode string
+	Err error
+}
+
+// Adds many users at once with no authentication.
+//
+// Each user is added independently so a failure for one, such as
+// a duplicate name, is reported in its result without affecting the rest.
+// Every added user is issued a reset code for setting their password,
+// valid for inviteValidTime rather than as long as a requested reset.
+func BulkAddUsers(pool *pgx.ConnPool,
+	specs []NewUserSpec) ([]BulkAddResult, error) {
+
+	if len(specs) == 0 {
+		return nil, fmt.Errorf("no users provided")
+	}
+
+	results:= make([]BulkAddResult, len(specs))
+	for i, spec:= range specs{
+
+		results[i].Name = spec.Name
+
+		password:= spec.Password
+		if password == "" {
+			password = randString(invitePasswordLength)
+		}
+
+		// No session is issued, nobody would hold it until they login
+		err:= addUser(pool, spec.Name, spec.Email, password)
+		if err!=nil {
+			results[i].Err = err
+			continue
+		}
+
+		results[i].ResetCode, err = requestReset(pool, spec.Name,
+			inviteValidTime)
+		if err!=nil {
+			results[i].Err = errorHandle(err, "failed to issue reset")
+		}
+
+	}
+
+	return results, nil
 
 }
 
@@ -88,13 +224,14 @@ func AddUser(pool *pgx.ConnPool, user,
 func SetPassword(tx *pgx.Tx, user, password string) error {
 
 	// Hash their password and get a complementary nonce.
-	passHash, nonce, err:= derivePassword([]byte(password))
+	passHash, nonce, params, err:= derivePassword([]byte(password))
 	if err!=nil {
 		return errorHandle(err, "failed to derive password")
 	}
 
 	// Send the user away to the db
-	_, err = tx.Exec("setPassword", user, passHash, nonce)
+	_, err = tx.Exec("setPassword", user, passHash, nonce,
+		params.N, params.R, params.P)
 	if err!=nil {
 		return fmt.Errorf("failed to send fresh password", err)
 	}
@@ -104,11 +241,60 @@ func SetPassword(tx *pgx.Tx, user, password string) error {
 }
 
 // Sets the maximum collection count for a user with no authentication.
+//
+// Collections beyond the new maximum become read only, as they do
+// when downgrading, until it is raised again.
 func SetMaxCollections(pool *pgx.ConnPool, user string, maxCollections int32) error {
 	
-	_, err:= pool.Exec("setMaxCollections", user, maxCollections)
+	err:= withTx(pool, func(tx *pgx.Tx) error {
+		_, err:= tx.Exec("setMaxCollections", user, maxCollections)
+		if err!=nil {
+			return err
+		}
+
+		_, err = tx.Exec("setCollectionsOverLimit", user, maxCollections)
+
+		return err
+	})
+	if err!=nil {
+		return fmt.Errorf("failed to send new maximum, %v", err)
+	}
+
+	return nil
+
+}
+
+// Sets a user to have their password rehashed with a fresh nonce the
+// next time they login, with no authentication.
+//
+// Distinct from changing how passwords are derived, the derivation
+// stays the same while the nonce is replaced.
+func SetForceRehash(pool *pgx.ConnPool, user string) error {
+
+	_, err:= pool.Exec("setForceRehash", user)
+	if err!=nil {
+		return fmt.Errorf("failed to flag for rehash, %v", err)
+	}
+
+	return nil
+
+}
+
+// Stores the currency and locale an authenticated user prefers.
+//
+// Values are stored as provided, empty values clear a preference.
+func SetPreferences(pool *pgx.ConnPool, sessionKey []byte,
+	user, currency, locale string) error {
+
+	// Authenticate the request
+	err:= SessionAuth(pool, user, sessionKey)
+	if err!=nil{
+		return err
+	}
+
+	_, err = pool.Exec("setPreferences", user, currency, locale)
 	if err!=nil {
-		return fmt.Errorf("failed to send new maximum", err)
+		return fmt.Errorf("failed to set preferences, %v", err)
 	}
 
 	return nil
@@ -125,55 +311,251 @@ func PasswordAuthUser(pool *pgx.ConnPool,
 		return false, err
 	}
 
+	return passwordMatches(u, password)
+}
+
+func passwordMatches(u *User, password string) (bool, error) {
+
 	// Hash the user's provided password using scrypt
-	providedHash, err:= derivePasswordWithNonce([]byte(password), u.Nonce)
+	providedHash, err:= derivePasswordWithNonce([]byte(password), u.Nonce,
+		u.HashParams)
 	if err!=nil {
 		return false, err
 	}
 
-	return subtle.ConstantTimeCompare(u.PassHash, providedHash) == 1, nil
+	return secretsEqual(u.PassHash, providedHash), nil
+
+}
+
+// Determines if a user's password was derived with parameters other
+// than those fresh passwords are derived with.
+func needsRehash(u *User) bool {
+	return u.HashParams != CurrentHashParams
 }
 
 // Authenticates a user and returns a fresh session key
+//
+// Users flagged for a rehash, or whose password was derived with
+// parameters other than CurrentHashParams, have their password
+// rederived with a fresh nonce before the session is issued.
+//
+// An incorrect password returns a LoginFailedError and too many in a
+// row lock the account, further attempts receive a LoginLockedError
+// until the lockout passes. A successful login clears the failures.
+//
+// An unknown user is refused as an incorrect password would be, their
+// failures counting towards a lockout alike. A password is derived as
+// it would be for them, so the two can't be told apart by how long
+// they take either.
 func Login(pool *pgx.ConnPool,
 	user, password string) ([]byte, error) {
 
-	// Make sure they are who they say they are
-	valid, err:= PasswordAuthUser(pool, user, password)
-	if err!=nil || !valid {
+	now:= time.Now()
+
+	u, err:= GetUser(pool, user)
+	if err == pgx.ErrNoRows {
+		err = unknownLogins.locked(user, now)
+		if err!=nil {
+			return nil, err
+		}
+		deriveDecoyPassword([]byte(password))
+		return nil, unknownLogins.fail(user, now)
+	}
+	if err!=nil {
 		return nil, errorHandle(err, "failed to authenticate user")
 	}
 
+	// Make sure they are who they say they are
+	err = checkPassword(pool, u, password, now)
+	if err!=nil {
+		return nil, err
+	}
+
+	if u.FailedLogins > 0 {
+		_, err = pool.Exec("clearLoginFailures", user)
+		if err!=nil {
+			return nil, fmt.Errorf("failed to clear login failures, %v", err)
+		}
+	}
+
+	if u.ForceRehashOnNextLogin || needsRehash(u) {
+		err = withTx(pool, func(tx *pgx.Tx) error {
+			return SetPassword(tx, user, password)
+		})
+		if err!=nil {
+			return nil, fmt.Errorf("failed to rehash password, %v", err)
+		}
+	}
+
 	return AddSession(pool, user)
 
 }
 
+// Deletes an authenticated user who has confirmed their password,
+// returning the subscription they held.
+//
+// Collections, their contents, sessions, resets and events are all
+// removed while webhooks are disabled. Append only history is moved
+// aside as it is when deleting a single collection. The subscription
+// returns to DefaultSubLevel, the caller is responsible for cancelling
+// any paid plan with the payment processor.
+//
+// The user's row remains as a tombstone with no email or password,
+// reserving the name so nobody can later claim it and its history.
+func DeleteUser(pool *pgx.ConnPool, sessionKey []byte,
+	user, password string) (*Subscription, error) {
+
+	// Authenticate the request
+	err:= SessionAuth(pool, user, sessionKey)
+	if err!=nil{
+		return nil, err
+	}
+
+	u, err:= GetUser(pool, user)
+	if err!=nil {
+		return nil, errorHandle(err, "failed to authenticate user")
+	}
+
+	now:= time.Now()
+	err = checkPassword(pool, u, password, now)
+	if err!=nil {
+		return nil, err
+	}
+
+	sub, err:= GetSub(pool, user, nil)
+	if err!=nil {
+		return nil, err
+	}
+
+	err = withTx(pool, func(tx *pgx.Tx) error {
+
+		_, err:= tx.Exec("removeUserContents", user)
+		if err!=nil {
+			return err
+		}
+
+		_, err = tx.Exec("archiveUserHistory", user, archivedPrefix(now),
+			int32(standardTextMaxLength))
+		if err!=nil {
+			return err
+		}
+
+		for _, statement:= range []string{"removeUserCollections",
+			"removeUserEvents", "removeUserResets", "disableUserWebhooks",
+			"removeAllSessions", "bumpSessionsVersion", "scrubUser"}{
+			_, err = tx.Exec(statement, user)
+			if err!=nil {
+				return err
+			}
+		}
+
+		return modSub(tx, user, DefaultSubLevel, MonthlyInterval,
+			sub.CustomerID, DefaultID)
+	})
+	if err!=nil {
+		return nil, fmt.Errorf("failed to delete user, %v", err)
+	}
+
+	return sub, nil
+
+}
+
 // Authenticates a reset request, resets the user's password, and
 // delete the request used.
+//
+// Resets are single use, every reset of the user is removed alongside
+// the password change so none can be used again. Expired resets are
+// ErrResetExpired, unknown or already used resets ErrBadReset.
 func ChangePassword(pool *pgx.ConnPool,
 	user, password, reset string) (error) {
 
-	// We need not validate in a transaction
+	// Distinguishes expired resets, consuming it below is what
+	// guarantees it is only used once
 	err:= ValidateReset(pool, user, reset)
 	if err!=nil {
-		return fmt.Errorf("failed to validate reset", err)
+		return err
 	}
 
-	tx, err:= pool.Begin()
-	if err!=nil {
-		return fmt.Errorf("failed to grab a transaction,", err)
+	// Proving ownership of the email lifts any lockout
+	err = withTx(pool, func(tx *pgx.Tx) error {
+		err:= consumeReset(tx, user, reset)
+		if err!=nil {
+			return err
+		}
+
+		_, err = tx.Exec("removeUserResets", user)
+		if err!=nil {
+			return err
+		}
+
+		err = SetPassword(tx, user, password)
+		if err!=nil {
+			return err
+		}
+
+		_, err = tx.Exec("clearLoginFailures", user)
+		return err
+	})
+	if err == ErrBadReset {
+		return err
 	}
-	// Make sure we can safely exit at any time
-	defer tx.Rollback()
+	if err!=nil{
+		return fmt.Errorf("failed to set new password, %v", err)
+	}
+
+	return nil
 
-	err = SetPassword(tx, user, password)
+
+}
+
+// Changes the password of an authenticated user who knows their
+// current password, returning a fresh session key.
+//
+// Every other session, including the one authenticating the request,
+// is removed so a stolen session can't outlive the change. Incorrect
+// current passwords count towards a lockout just as failed logins do.
+func ChangePasswordAuthenticated(pool *pgx.ConnPool, sessionKey []byte,
+	user, oldPassword, newPassword string) ([]byte, error) {
+
+	// Authenticate the request
+	err:= SessionAuth(pool, user, sessionKey)
 	if err!=nil{
-		return fmt.Errorf("failed to set new password", err)
+		return nil, err
+	}
+
+	u, err:= GetUser(pool, user)
+	if err!=nil {
+		return nil, errorHandle(err, "failed to authenticate user")
 	}
 
-	tx.Commit()
+	err = checkPassword(pool, u, oldPassword, time.Now())
+	if err!=nil {
+		return nil, err
+	}
 
-	return nil
+	err = withTx(pool, func(tx *pgx.Tx) error {
+		err:= SetPassword(tx, user, newPassword)
+		if err!=nil {
+			return err
+		}
+
+		_, err = tx.Exec("clearLoginFailures", user)
+		if err!=nil {
+			return err
+		}
+
+		_, err = tx.Exec("removeAllSessions", user)
+		if err!=nil {
+			return err
+		}
+
+		_, err = tx.Exec("bumpSessionsVersion", user)
+		return err
+	})
+	if err!=nil{
+		return nil, fmt.Errorf("failed to set new password, %v", err)
+	}
 
+	return AddSession(pool, user)
 
 }
\ No newline at end of file
@@ -4,7 +4,10 @@ import(
 
 	"testing"
 
+	"fmt"
+	"bytes"
 	"time"
+	"crypto/sha256"
 
 )
 
@@ -78,6 +81,24 @@ func TestInvalidUsers(t *testing.T) {
 	if err == nil {
 		t.Fatal("was able to add user with invalid email", err)
 	}
+
+	// Invalid encodings
+	name = randString(int(randByte())) + "\xff"
+	email = randString(int(randByte()))
+	password = randString(int(randByte()))
+
+	_, err = AddUser(pool, name, email, password)
+	if err != ErrBadEncoding {
+		t.Fatal("was able to add user with invalid utf8 name", err)
+	}
+
+	name = randString(int(randByte()))
+	email = randString(int(randByte())) + "\xc3\x28"
+
+	_, err = AddUser(pool, name, email, password)
+	if err != ErrBadEncoding {
+		t.Fatal("was able to add user with invalid utf8 email", err)
+	}
 	
 	// Completely valid
 	name = randString(int(randByte()))
@@ -90,4 +111,624 @@ func TestInvalidUsers(t *testing.T) {
 	}
 	
 
-}
\ No newline at end of file
+}
+
+// Imports a mix of fresh users, invited users, and a duplicate.
+// Only the duplicate should fail.
+func TestBulkAddUsers(t *testing.T) {
+	t.Parallel()
+
+	existing:= randString(int(randByte()))
+	_, err:= AddUser(pool, existing, randString(int(randByte())), "foobarbaz1")
+	if err!=nil {
+		t.Fatal("failed to add user ", err)
+	}
+
+	password:= randString(int(randByte()) + 10)
+	specs:= []NewUserSpec{
+		NewUserSpec{
+			Name: randString(int(randByte())),
+			Email: randString(int(randByte())),
+			Password: password,
+		},
+		NewUserSpec{
+			Name: randString(int(randByte())),
+			Email: randString(int(randByte())),
+		},
+		NewUserSpec{
+			Name: existing,
+			Email: randString(int(randByte())),
+		},
+	}
+
+	results, err:= BulkAddUsers(pool, specs)
+	if err!=nil {
+		t.Fatal("failed to bulk add users ", err)
+	}
+	if len(results) != len(specs) {
+		t.Fatal("result missing for some users")
+	}
+
+	for i:= 0; i < 2; i++ {
+		if results[i].Err != nil {
+			t.Fatal("failed to add valid user ", results[i].Err)
+		}
+		if results[i].ResetCode == "" {
+			t.Fatal("no reset code issued to valid user")
+		}
+	}
+
+	if results[2].Err == nil {
+		t.Fatal("was able to add duplicate user")
+	}
+
+	time.Sleep(testSleepTime)
+
+	// Nobody holds a session for a user who has yet to login
+	footprint, err:= GetFootprint(pool, specs[1].Name)
+	if err!=nil || footprint.Sessions != 0 {
+		t.Fatal("bulk added user issued a session", footprint, err)
+	}
+
+	_, err = Login(pool, specs[0].Name, password)
+	if err!=nil {
+		t.Fatal("failed to login bulk added user ", err)
+	}
+
+	_, err = BulkAddUsers(pool, nil)
+	if err == nil {
+		t.Fatal("able to bulk add no users")
+	}
+
+}
+
+// Invites must outlast a requested reset, imported users may take
+// days to act on them.
+func TestBulkAddInviteValidity(t *testing.T) {
+	t.Parallel()
+
+	name:= randString(int(randByte()) % 31)
+	results, err:= BulkAddUsers(pool, []NewUserSpec{
+		NewUserSpec{Name: name, Email: randString(10)},
+	})
+	if err!=nil || results[0].Err != nil {
+		t.Fatal("failed to bulk add user", err, results)
+	}
+
+	time.Sleep(testSleepTime)
+
+	hashed:= sha256.Sum256([]byte(results[0].ResetCode))
+	r:= Reset{}
+	err = pool.QueryRow("getReset", name, hashed[:]).Scan(&r.Name,
+		&r.ResetKey, &r.StartValid, &r.EndValid)
+	if err!=nil {
+		t.Fatal("invite not stored", err)
+	}
+
+	valid:= r.EndValid.Sub(r.StartValid)
+	if valid <= resetValidTime || valid < inviteValidTime - time.Second {
+		t.Fatal("invite expires as soon as a reset", valid)
+	}
+
+	err = ValidateReset(pool, name, results[0].ResetCode)
+	if err!=nil {
+		t.Fatal("invite refused", err)
+	}
+
+}
+
+// A flagged login should replace the nonce once, leaving the
+// password itself valid.
+func TestForceRehash(t *testing.T) {
+	t.Parallel()
+
+	user:= randString(int(randByte()) % 31)
+	_, err:= AddUser(pool, user, "foo", "bar")
+	if err!=nil {
+		t.Fatal("failed to add user ", err)
+	}
+
+	time.Sleep(stepSleepTime)
+
+	before, err:= GetUser(pool, user)
+	if err!=nil {
+		t.Fatal(err)
+	}
+
+	_, err = Login(pool, user, "bar")
+	if err!=nil {
+		t.Fatal("failed to login", err)
+	}
+	unflagged, err:= GetUser(pool, user)
+	if err!=nil || !bytes.Equal(unflagged.Nonce, before.Nonce) {
+		t.Fatal("nonce changed without being flagged", err)
+	}
+
+	err = SetForceRehash(pool, user)
+	if err!=nil {
+		t.Fatal("failed to flag for rehash", err)
+	}
+
+	time.Sleep(stepSleepTime)
+
+	_, err = Login(pool, user, "bar")
+	if err!=nil {
+		t.Fatal("failed to login when flagged", err)
+	}
+
+	after, err:= GetUser(pool, user)
+	if err!=nil {
+		t.Fatal(err)
+	}
+	if bytes.Equal(after.Nonce, before.Nonce) ||
+		bytes.Equal(after.PassHash, before.PassHash) {
+		t.Fatal("flagged login did not rehash")
+	}
+	if after.ForceRehashOnNextLogin {
+		t.Fatal("rehash flag not cleared")
+	}
+
+	_, err = Login(pool, user, "bar")
+	if err!=nil {
+		t.Fatal("rehashed password no longer valid", err)
+	}
+
+}
+
+func TestPreferences(t *testing.T) {
+	t.Parallel()
+
+	user:= randString(int(randByte()) % 31)
+	key, err:= AddUser(pool, user, "foo", "bar")
+	if err!=nil {
+		t.Fatal("failed to add user ", err)
+	}
+
+	time.Sleep(stepSleepTime)
+
+	u, err:= GetUser(pool, user)
+	if err!=nil {
+		t.Fatal(err)
+	}
+	if u.Currency != "" || u.Locale != "" {
+		t.Fatal("fresh user has preferences", u.Currency, u.Locale)
+	}
+
+	err = SetPreferences(pool, key, user, "EUR", "de-DE")
+	if err!=nil {
+		t.Fatal("failed to set preferences", err)
+	}
+
+	time.Sleep(stepSleepTime)
+
+	u, err = GetUser(pool, user)
+	if err!=nil || u.Currency != "EUR" || u.Locale != "de-DE" {
+		t.Fatal("preferences not stored", err)
+	}
+
+	err = SetPreferences(pool, []byte("bad"), user, "USD", "en-US")
+	if err != ErrBadSession {
+		t.Fatal("preferences set without a valid session", err)
+	}
+
+}
+
+func TestVerifyEmail(t *testing.T) {
+	t.Parallel()
+
+	user:= randString(int(randByte()) % 31)
+	_, err:= AddUser(pool, user, "foo", "bar")
+	if err!=nil {
+		t.Fatal("failed to add user ", err)
+	}
+
+	time.Sleep(stepSleepTime)
+
+	u, err:= GetUser(pool, user)
+	if err!=nil || u.Verified {
+		t.Fatal("fresh user verified", err)
+	}
+
+	stale, err:= IssueVerification(pool, user)
+	if err!=nil {
+		t.Fatal("failed to issue verification", err)
+	}
+	code, err:= IssueVerification(pool, user)
+	if err!=nil {
+		t.Fatal("failed to reissue verification", err)
+	}
+
+	err = VerifyEmail(pool, user, stale)
+	if err != ErrBadVerifyToken {
+		t.Fatal("replaced code verified email", err)
+	}
+	err = VerifyEmail(pool, user, "baz")
+	if err != ErrBadVerifyToken {
+		t.Fatal("incorrect code verified email", err)
+	}
+
+	err = VerifyEmail(pool, user, code)
+	if err!=nil {
+		t.Fatal("failed to verify email", err)
+	}
+
+	time.Sleep(stepSleepTime)
+
+	u, err = GetUser(pool, user)
+	if err!=nil || !u.Verified || len(u.VerifyToken) != 0 {
+		t.Fatal("verification not stored", err)
+	}
+
+	// Codes are single use and the verified need no more
+	err = VerifyEmail(pool, user, code)
+	if err != ErrBadVerifyToken {
+		t.Fatal("code used twice", err)
+	}
+	_, err = IssueVerification(pool, user)
+	if err != ErrBadVerifyToken {
+		t.Fatal("verification issued to verified user", err)
+	}
+
+}
+
+func TestLoginLockout(t *testing.T) {
+	t.Parallel()
+
+	user:= randString(int(randByte()) % 31)
+	_, err:= AddUser(pool, user, "foo", "bar")
+	if err!=nil {
+		t.Fatal("failed to add user ", err)
+	}
+
+	time.Sleep(stepSleepTime)
+
+	for i:= int32(1); i <= LoginFailureThreshold; i++ {
+		_, err = Login(pool, user, "baz")
+		failed, ok:= err.(LoginFailedError)
+		if !ok || failed.Failures != i {
+			t.Fatal("failure not counted", i, err)
+		}
+	}
+
+	// Even the correct password is refused while locked out
+	_, err = Login(pool, user, "bar")
+	locked, ok:= err.(LoginLockedError)
+	if !ok || locked.Remaining <= 0 || locked.Remaining > baseLockout {
+		t.Fatal("locked out account allowed login", err)
+	}
+
+	code, err:= RequestReset(pool, user)
+	if err!=nil {
+		t.Fatal(err)
+	}
+	err = ChangePassword(pool, user, "qux", code)
+	if err!=nil {
+		t.Fatal(err)
+	}
+
+	time.Sleep(stepSleepTime)
+
+	_, err = Login(pool, user, "qux")
+	if err!=nil {
+		t.Fatal("password reset did not lift lockout", err)
+	}
+
+	u, err:= GetUser(pool, user)
+	if err!=nil || u.FailedLogins != 0 {
+		t.Fatal("failures not cleared", err)
+	}
+
+}
+
+func TestLoginLockoutBackoff(t *testing.T) {
+
+	if loginLockout(LoginFailureThreshold - 1) != 0 {
+		t.Fatal("locked out below the threshold")
+	}
+
+	if loginLockout(LoginFailureThreshold) != baseLockout ||
+		loginLockout(LoginFailureThreshold + 2) != 4 * baseLockout {
+		t.Fatal("lockout does not double with each failure")
+	}
+
+	if loginLockout(LoginFailureThreshold + 1000) != maxLockout {
+		t.Fatal("lockout exceeds its maximum")
+	}
+
+}
+
+// Ensure logging in as an unknown user is refused as slowly as an
+// incorrect password is, so the two can't be told apart by timing.
+func TestLoginUnknownUser(t *testing.T) {
+	t.Parallel()
+
+	user:= randString(int(randByte()) % 31)
+	_, err:= AddUser(pool, user, "foo", "bar")
+	if err!=nil {
+		t.Fatal("failed to add user ", err)
+	}
+
+	time.Sleep(stepSleepTime)
+
+	start:= time.Now()
+	_, err = Login(pool, user, "baz")
+	if _, ok:= err.(LoginFailedError); !ok {
+		t.Fatal("incorrect password not refused", err)
+	}
+	wrongPassword:= time.Since(start)
+
+	start = time.Now()
+	_, err = Login(pool, user + "unknown", "baz")
+	if _, ok:= err.(LoginFailedError); !ok {
+		t.Fatal("unknown user not refused", err)
+	}
+	unknownUser:= time.Since(start)
+
+	// Deriving the password dominates, the queries either side vary
+	if unknownUser < wrongPassword / 2 {
+		t.Fatal("unknown user refused faster than an incorrect password",
+			unknownUser, wrongPassword)
+	}
+
+}
+
+// Ensure an unknown user is refused, and eventually locked out,
+// exactly as an account with an incorrect password is.
+func TestLoginUnknownUserLockout(t *testing.T) {
+	t.Parallel()
+
+	user:= randString(int(randByte()) % 31)
+	_, err:= AddUser(pool, user, "foo", "bar")
+	if err!=nil {
+		t.Fatal("failed to add user ", err)
+	}
+
+	time.Sleep(stepSleepTime)
+
+	unknown:= user + "unknown"
+	for i:= int32(1); i <= LoginFailureThreshold + 1; i++ {
+		_, existingErr:= Login(pool, user, "baz")
+		_, unknownErr:= Login(pool, unknown, "baz")
+		if !sameRefusal(existingErr, unknownErr) {
+			t.Fatal("unknown user refused differently", i,
+				existingErr, unknownErr)
+		}
+
+		if LoginCaptchaRequired(pool, user, time.Now()) !=
+			LoginCaptchaRequired(pool, unknown, time.Now()) {
+			t.Fatal("unknown user captcha differs", i)
+		}
+	}
+
+	_, err = Login(pool, unknown, "baz")
+	if _, ok:= err.(LoginLockedError); !ok {
+		t.Fatal("unknown user not locked out", err)
+	}
+
+}
+
+// Compares refusals ignoring what is only for logging and how much
+// time passed between them.
+func sameRefusal(a, b error) bool {
+
+	switch aErr:= a.(type){
+	case LoginFailedError:
+		bErr, ok:= b.(LoginFailedError)
+		return ok && aErr.Failures == bErr.Failures &&
+			!aErr.UnknownUser && bErr.UnknownUser
+	case LoginLockedError:
+		bErr, ok:= b.(LoginLockedError)
+		return ok && !aErr.UnknownUser && bErr.UnknownUser &&
+			aErr.Remaining - bErr.Remaining < time.Second &&
+			bErr.Remaining - aErr.Remaining < time.Second
+	}
+
+	return false
+
+}
+
+func TestUnknownLoginsBounded(t *testing.T) {
+
+	now:= time.Now()
+	tracker:= unknownLoginTracker{logins: make(map[string]unknownLogin)}
+	for i:= 0; i <= maxUnknownLogins; i++ {
+		tracker.fail(fmt.Sprint(i), now)
+	}
+
+	if len(tracker.logins) > maxUnknownLogins {
+		t.Fatal("unknown logins grew past their bound", len(tracker.logins))
+	}
+
+}
+
+func TestCaptchaRequired(t *testing.T) {
+
+	now:= time.Now()
+
+	u:= &User{FailedLogins: CaptchaFailureThreshold - 1, LastFailedLogin: now}
+	if u.CaptchaRequired(now) {
+		t.Fatal("captcha required below the threshold")
+	}
+
+	u.FailedLogins = CaptchaFailureThreshold
+	if !u.CaptchaRequired(now) {
+		t.Fatal("captcha not required at the threshold")
+	}
+
+	if u.CaptchaRequired(now.Add(loginFailureWindow)) {
+		t.Fatal("captcha required for failures outside the window")
+	}
+
+}
+
+// Bumping parameters should rehash on the next login, keeping the
+// password valid. Not parallel as it changes CurrentHashParams.
+func TestRehashOnParamChange(t *testing.T) {
+
+	user:= randString(int(randByte()) % 31)
+	_, err:= AddUser(pool, user, "foo", "bar")
+	if err!=nil {
+		t.Fatal("failed to add user ", err)
+	}
+
+	time.Sleep(stepSleepTime)
+
+	before, err:= GetUser(pool, user)
+	if err!=nil {
+		t.Fatal(err)
+	}
+	if before.HashParams != CurrentHashParams || needsRehash(before) {
+		t.Fatal("parameters not stored", before.HashParams)
+	}
+
+	previous:= CurrentHashParams
+	defer func() { CurrentHashParams = previous }()
+	CurrentHashParams = HashParams{N: 1024, R: 1, P: 1}
+
+	_, err = Login(pool, user, "bar")
+	if err!=nil {
+		t.Fatal("failed to login with stale parameters", err)
+	}
+
+	after, err:= GetUser(pool, user)
+	if err!=nil {
+		t.Fatal(err)
+	}
+	if after.HashParams != CurrentHashParams ||
+		bytes.Equal(after.PassHash, before.PassHash) {
+		t.Fatal("stale parameters not rehashed", after.HashParams)
+	}
+
+	_, err = Login(pool, user, "bar")
+	if err!=nil {
+		t.Fatal("rehashed password no longer valid", err)
+	}
+
+}
+
+func TestParseHashParams(t *testing.T) {
+
+	params, err:= ParseHashParams("16384, 8,1")
+	if err!=nil || params != (HashParams{N: 16384, R: 8, P: 1}) {
+		t.Fatal("parameters not parsed", params, err)
+	}
+
+	for _, raw:= range []string{"", "16384,8", "1000,8,1", "16384,0,1",
+		"16384,8,-1", "a,b,c"}{
+		_, err = ParseHashParams(raw)
+		if err == nil {
+			t.Fatal("malformed parameters accepted", raw)
+		}
+	}
+
+}
+
+func TestChangePasswordAuthenticated(t *testing.T) {
+	t.Parallel()
+
+	user:= randString(int(randByte()) % 31)
+	key, err:= AddUser(pool, user, "foo", "bar")
+	if err!=nil {
+		t.Fatal("failed to add user ", err)
+	}
+
+	other, err:= Login(pool, user, "bar")
+	if err!=nil {
+		t.Fatal("failed to login", err)
+	}
+
+	time.Sleep(stepSleepTime)
+
+	_, err = ChangePasswordAuthenticated(pool, key, user, "baz", "qux")
+	if _, ok:= err.(LoginFailedError); !ok {
+		t.Fatal("changed password without the current one", err)
+	}
+
+	fresh, err:= ChangePasswordAuthenticated(pool, key, user, "bar", "qux")
+	if err!=nil {
+		t.Fatal("failed to change password", err)
+	}
+
+	time.Sleep(stepSleepTime)
+
+	for _, old:= range [][]byte{key, other}{
+		if SessionAuth(pool, user, old) != ErrBadSession {
+			t.Fatal("session survived changing password")
+		}
+	}
+	err = SessionAuth(pool, user, fresh)
+	if err!=nil {
+		t.Fatal("fresh session invalid", err)
+	}
+
+	_, err = Login(pool, user, "qux")
+	if err!=nil {
+		t.Fatal("new password not accepted", err)
+	}
+
+	_, err = ChangePasswordAuthenticated(pool, key, user, "qux", "bar")
+	if err != ErrBadSession {
+		t.Fatal("changed password with a removed session", err)
+	}
+
+}
+
+func TestDeleteUser(t *testing.T) {
+	t.Parallel()
+
+	user:= randString(int(randByte()) % 31)
+	key, err:= AddUser(pool, user, "foo", "bar")
+	if err!=nil {
+		t.Fatal("failed to add user ", err)
+	}
+
+	time.Sleep(stepSleepTime)
+
+	err = AddCollection(pool, key, user, "baz")
+	if err!=nil {
+		t.Fatal("failed to add collection", err)
+	}
+
+	time.Sleep(stepSleepTime)
+
+	_, err = DeleteUser(pool, key, user, "qux")
+	if _, ok:= err.(LoginFailedError); !ok {
+		t.Fatal("deleted user without their password", err)
+	}
+
+	sub, err:= DeleteUser(pool, key, user, "bar")
+	if err!=nil {
+		t.Fatal("failed to delete user", err)
+	}
+	if sub.Plan != DefaultSubLevel {
+		t.Fatal("incorrect prior subscription", sub.Plan)
+	}
+
+	time.Sleep(stepSleepTime)
+
+	if SessionAuth(pool, user, key) != ErrBadSession {
+		t.Fatal("session survived deletion")
+	}
+
+	_, err = Login(pool, user, "bar")
+	if err == nil {
+		t.Fatal("deleted user able to login")
+	}
+
+	collections, err:= GetCollectionList(pool, user)
+	if err!=nil || len(collections) != 0 {
+		t.Fatal("collections survived deletion", collections, err)
+	}
+
+	u, err:= GetUser(pool, user)
+	if err!=nil || u.Email != "" {
+		t.Fatal("user not scrubbed", err)
+	}
+
+	// The name stays taken
+	_, err = AddUser(pool, user, "foo", "bar")
+	if err == nil {
+		t.Fatal("deleted user's name reclaimed")
+	}
+
+}
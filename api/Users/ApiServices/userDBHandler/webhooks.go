@@ -0,0 +1,110 @@
+package userDB
+
+import(
+
+	"github.com/jackc/pgx"
+
+)
+
+type Webhook struct{
+	Owner, URL string
+	Failures int32
+	Disabled bool
+}
+
+// Registers a webhook endpoint only if the user has fewer active
+// webhooks than their plan allows.
+//
+// Disabled webhooks don't count towards the limit. Registering an
+// endpoint again, including one which was disabled, re-enables it.
+//
+// The user's subscription stays locked from the count until the
+// insert so concurrent registrations can't exceed the limit.
+func RegisterWebhook(pool *pgx.ConnPool, sessionKey []byte,
+	user, url string) error {
+
+	// Authenticate the request
+	err:= SessionAuth(pool, user, sessionKey)
+	if err!=nil{
+		return err
+	}
+
+	return withTx(pool, func(tx *pgx.Tx) error {
+
+		var plan string
+		err:= tx.QueryRow("lockSub", user).Scan(&plan)
+		if err!=nil {
+			return errorHandle(err, "failed to fetch sub")
+		}
+
+		var active int64
+		err = tx.QueryRow("countActiveWebhooks", user, url).Scan(&active)
+		if err!=nil {
+			return errorHandle(err, ScanError)
+		}
+
+		if int(active) + 1 > SubTiersToWebhooks[plan] {
+			return ErrWebhookLimit
+		}
+
+		_, err = tx.Exec("addWebhook", user, url)
+
+		return err
+
+	})
+
+}
+
+// Acquires every webhook, including disabled ones, for a user.
+func GetWebhooks(pool *pgx.ConnPool, user string) ([]Webhook, error) {
+
+	rows, err := pool.Query("getWebhooks", user)
+	if err!=nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var hooks []Webhook
+	for rows.Next(){
+		h:= Webhook{}
+		err = rows.Scan(&h.Owner, &h.URL, &h.Failures, &h.Disabled)
+		if err!=nil {
+			return nil, errorHandle(err, ScanError)
+		}
+
+		hooks = append(hooks, h)
+	}
+
+	return hooks, nil
+
+}
+
+// Records a failed delivery to a webhook.
+//
+// Returns true when this failure caused the webhook to be disabled,
+// which happens after MaxWebhookFailures consecutive failures.
+func RecordWebhookFailure(pool *pgx.ConnPool,
+	user, url string) (bool, error) {
+
+	var disabled bool
+	err:= pool.QueryRow("recordWebhookFailure",
+		user, url, MaxWebhookFailures).Scan(&disabled)
+	if err == pgx.ErrNoRows {
+		// Already disabled or no longer exists
+		return false, nil
+	}else if err!=nil {
+		return false, errorHandle(err, ScanError)
+	}
+
+	return disabled, nil
+
+}
+
+// Records a successful delivery, resetting the failure count.
+func RecordWebhookSuccess(pool *pgx.ConnPool, user, url string) error {
+
+	_, err:= pool.Exec("recordWebhookSuccess", user, url)
+
+	return err
+
+}
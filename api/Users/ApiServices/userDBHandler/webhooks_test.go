@@ -0,0 +1,146 @@
+package userDB
+
+import(
+
+	"fmt"
+
+	"sync"
+
+	"testing"
+
+	"time"
+
+)
+
+// Registers webhooks up to the free plan's limit and ensures the
+// next is rejected.
+func TestWebhookLimit(t *testing.T) {
+	t.Parallel()
+
+	user:= randString(int(randByte()))
+	key, err:= AddUser(pool, user, "bar", "foo")
+	if err!=nil {
+		t.Fatal("failed to add user ", err)
+	}
+
+	time.Sleep(testSleepTime)
+
+	limit:= SubTiersToWebhooks[DefaultSubLevel]
+	for i:= 0; i < limit; i++ {
+		err = RegisterWebhook(pool, key, user,
+			fmt.Sprintf("https://example.com/%d", i))
+		if err!=nil {
+			t.Fatal("failed to register webhook within limit", err)
+		}
+	}
+
+	time.Sleep(testSleepTime)
+
+	err = RegisterWebhook(pool, key, user, "https://example.com/over")
+	if err != ErrWebhookLimit {
+		t.Fatal("registered webhook beyond limit", err)
+	}
+
+}
+
+// Fails a webhook repeatedly and ensures it is disabled exactly once
+// the maximum is reached, freeing its slot.
+func TestWebhookAutoDisable(t *testing.T) {
+	t.Parallel()
+
+	user:= randString(int(randByte()))
+	key, err:= AddUser(pool, user, "bar", "foo")
+	if err!=nil {
+		t.Fatal("failed to add user ", err)
+	}
+
+	time.Sleep(testSleepTime)
+
+	url:= "https://example.com/failing"
+	err = RegisterWebhook(pool, key, user, url)
+	if err!=nil {
+		t.Fatal("failed to register webhook", err)
+	}
+
+	for i:= int32(1); i <= MaxWebhookFailures; i++ {
+		disabled, err:= RecordWebhookFailure(pool, user, url)
+		if err!=nil {
+			t.Fatal("failed to record failure", err)
+		}
+		if disabled != (i == MaxWebhookFailures) {
+			t.Fatal("webhook disabled after incorrect failure count", i)
+		}
+	}
+
+	// Further failures don't disable it again
+	disabled, err:= RecordWebhookFailure(pool, user, url)
+	if err!=nil || disabled {
+		t.Fatal("disabled webhook reported as freshly disabled", err)
+	}
+
+	hooks, err:= GetWebhooks(pool, user)
+	if err!=nil {
+		t.Fatal(err)
+	}
+	if len(hooks) != 1 || !hooks[0].Disabled {
+		t.Fatal("webhook not disabled", hooks)
+	}
+
+	// Disabled webhooks don't count towards the limit
+	err = RegisterWebhook(pool, key, user, "https://example.com/fresh")
+	if err!=nil {
+		t.Fatal("disabled webhook counted towards limit", err)
+	}
+
+	// Registering the disabled endpoint again revives it
+	err = RegisterWebhook(pool, key, user, url)
+	if err!=nil {
+		t.Fatal("failed to re-register disabled webhook", err)
+	}
+
+	hooks, err = GetWebhooks(pool, user)
+	if err!=nil {
+		t.Fatal(err)
+	}
+	for _, h:= range hooks{
+		if h.URL == url && (h.Disabled || h.Failures != 0) {
+			t.Fatal("re-registered webhook not reset", h)
+		}
+	}
+
+}
+
+// Registers many webhooks at once and ensures no more than the
+// limit end up active.
+func TestWebhookLimitConcurrent(t *testing.T) {
+	t.Parallel()
+
+	user:= randString(int(randByte()))
+	key, err:= AddUser(pool, user, "bar", "foo")
+	if err!=nil {
+		t.Fatal("failed to add user ", err)
+	}
+
+	time.Sleep(testSleepTime)
+
+	limit:= SubTiersToWebhooks[DefaultSubLevel]
+	var wg sync.WaitGroup
+	for i:= 0; i < limit * 3; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			RegisterWebhook(pool, key, user,
+				fmt.Sprintf("https://example.com/%d", i))
+		}(i)
+	}
+	wg.Wait()
+
+	hooks, err:= GetWebhooks(pool, user)
+	if err!=nil {
+		t.Fatal(err)
+	}
+	if len(hooks) > limit {
+		t.Fatal("concurrent registrations exceeded limit", len(hooks))
+	}
+
+}
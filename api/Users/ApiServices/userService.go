@@ -6,6 +6,7 @@ import(
 
 	"github.com/jackc/pgx"
 	"./userDBHandler"
+	"./../../../common/priceDB"
 
 	"./mailer"
 
@@ -15,59 +16,314 @@ import(
 
 	"net/http"
 	"log"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
 )
 
 const BadUserName string = "User lookup failed"
-const BadPassword string = "Invalid password, needs to be >10 characters"
+const BadPassword string = "Invalid password"
+const EmailMismatch string = "EmailConfirmation does not match Email"
+const BadVerifyCode string = "Invalid verification code or email already verified"
+const EmailUnverified string = "Verify your email before continuing"
+const EmailUsed string = "Email already in use"
+const BadEmail string = "Invalid email address"
+const DisposableEmail string = "Disposable email addresses are not accepted"
+const BadEmailChangeCode string = "Invalid email change code or no change pending"
 const BadSessionKey string = "Invalid Session Key"
 const BadCredentials string = "Invalid Credentials"
 const BadCaptcha string = "Invalid Re-Captcha"
+const CaptchaUnavailable string = "Re-Captcha verification unavailable, try again"
+const ResetExpired string = "Reset code has expired, request another"
+const CaptchaLowScore string = "Re-Captcha could not confirm you are human, try again later"
+const SessionExhausted string = "Session can no longer be refreshed, login again"
+const LoginLocked string = "Too many failed logins, try again later or reset your password"
+const NoSuchSession string = "No such session"
 const BadTradeContents string = "Invalid trade contents"
+const NoSuchCollection string = "No such collection"
+const CollectionExists string = "A collection with that name already exists"
+const NoSuchTrade string = "No such trade"
+const TradeReversed string = "Trade has already been removed"
+const ReservedCollectionName string = "Collection name is reserved, choose another"
+const CollectionLocked string = "Collection is locked, unlock it to make changes"
+const CollectionConflict string = "Collection changed since it was read, fetch it again and retry"
+const BadExportFormat string = "Invalid export format, expected csv or json"
+const ExportFailure string = "Failed to export collection"
+const BadImportFormat string = "Invalid import format, expected dec or list"
+const BadImportSize string = "Too many lines in a single import"
+const BadPrivacy string = "Invalid privacy, expected Private, Contents or History"
+const BadCollectionTags string = "Invalid tags, at most 10 of at most 32 characters without markup"
+const CollectionLimit string = "Collection limit reached for your plan, upgrade required"
+const CollectionOverLimit string = "Collection is beyond your plan's limit, upgrade required to make changes"
+const BadUserText string = "Invalid text, too long or contains markup"
+const BadEncoding string = "Invalid text encoding, expected UTF-8"
 
 const SignupFailure string = "Failed to create user"
 const BodyReadFailure string = "Failed to parse body parameter"
 
 const DBfailure string = "Database read failed"
 const DBWriteFailure string = "Database read failed"
+const DBUnavailable string = "Database temporarily unavailable, try again shortly"
 
 const BadPlanChoice string = "Invalid plan choice!"
+const BadCoupon string = "Invalid or expired coupon"
+const PlanCooldown string = "Plan changed too recently"
+const NoSuchDeadLetter string = "No such dead letter"
+const DeadLetterNotKept string = "Dead letter contents were not kept, it cannot be resent"
+const DeadLetterFailed string = "Dead letter failed to send again"
 
 const StripeCustFailure string = "Stripe did not allow customer change"
 const StripeSubFailure string = "Stripe did not allow subscription change"
+const BadWebhookSignature string = "Invalid webhook signature"
+
+const BadBulkSize string = "Too many users in a single import"
+const BadBulkTradeSize string = "Too many trades in a single import"
+const BadTopCount string = "Invalid number of cards requested"
+const BadBasis string = "Invalid valuation basis, expected market or sell"
+const BadPreferences string = "Unsupported currency or locale"
+const BadMinValue string = "Invalid minimum card value"
+const BadFeedPage string = "Invalid feed cursor or limit"
+const BadCollectionPage string = "Invalid collection offset or limit"
+
+const Overloaded string = "Too many requests in flight, try again shortly"
+
+const BadWebhookAction string = "Invalid webhook secret action, expected rotate or retire"
+const WebhookSecretFailure string = "Failed to change webhook secret"
+const BadWebhookURL string = "Invalid webhook url, expected an http or https url"
+const WebhookLimit string = "Webhook limit reached for your plan"
+const BadAlert string = "Invalid alert, expected a percent of at most 1000, a threshold in cents or both"
+const AlertLimit string = "Alert limit reached for your plan"
+const NoSuchAlert string = "No such alert"
+const CardReloadFailure string = "Failed to reload card data, the previous cards are still served"
+
+// Templates sent by name throughout the service, each must be in the
+// mailgun meta. welcome is optional as reset stands in for it.
+var requiredTemplates = []string{
+	"reset", "verifyEmail", "confirmEmailChange", "emailChanged",
+	"subSuccess", "unSubSuccess", "paymentFailed", "webhookDisabled",
+	"priceAlert",
+}
 
 const mailGunMetaLoc string = "mailgunMeta.json"
 const recaptchaMetaLoc string = "recaptchaMeta.json"
 const merchantMetaLoc string  = "merchMeta.json"
+const adminMetaLoc string = "adminMeta.json"
+const webhookMetaLoc string = "webhookMeta.json"
+const planMetaLoc string = "planMeta.json"
+
+// Set to anything to log every users database query, debugging only!
+const debugQueriesEnv string = "USERS_DEBUG_QUERIES"
+
+// Set to json to log structured lines to stdout rather than text to
+// userLogger.txt, for aggregation in production.
+const logFormatEnv string = "USERS_LOG_FORMAT"
+const jsonLogFormat string = "json"
+
+// Set to anything to log mail rather than send it, as the mailgun
+// meta's DryRun does
+const mailDryRunEnv string = "USERS_MAIL_DRY_RUN"
+
+// Set to anything to require signups include a matching EmailConfirmation
+const requireEmailConfirmationEnv string = "USERS_REQUIRE_EMAIL_CONFIRMATION"
+
+// The shortest password accepted at signup and reset, defaults to 10.
+const passwordMinLengthEnv string = "USERS_PASSWORD_MIN_LENGTH"
+
+// The scrypt parameters fresh passwords are derived with, as 'N,r,p'.
+// Users are rehashed with them on their next login.
+const hashParamsEnv string = "USERS_HASH_PARAMS"
+
+// A comma separated list of domains whose addresses are refused as
+// disposable, replacing the default list when set.
+const disposableDomainsEnv string = "USERS_DISPOSABLE_EMAIL_DOMAINS"
+
+// The most characters card comments, collection names and collection
+// tags may hold, defaulting to 256, 64 and 32. Each may be at most 279,
+// the longest text the database stores.
+const commentMaxLengthEnv string = "USERS_COMMENT_MAX_LENGTH"
+const collectionNameMaxLengthEnv string = "USERS_COLLECTION_NAME_MAX_LENGTH"
+const collectionTagMaxLengthEnv string = "USERS_COLLECTION_TAG_MAX_LENGTH"
+
+// A comma separated list of collection names to reserve alongside
+// the route segments, which are always reserved.
+const reservedCollectionsEnv string = "USERS_RESERVED_COLLECTIONS"
+
+// How displayed collection values are rounded, one of cent, dime or whole.
+// Defaults to cent.
+const valueRoundingEnv string = "USERS_VALUE_ROUNDING"
+
+// Rates for converting collection values out of USD into currencies
+// without prices of their own, such as 'GBP:0.79,JPY:150'. Only USD
+// and EUR, which are priced natively, are available when unset.
+const currencyRatesEnv string = "USERS_CURRENCY_RATES"
 
 type UserService struct{
 
-	pool *pgx.ConnPool
+	// Holds the users pool, acquire it with db
+	keeper *userDB.PoolKeeper
+	pricePool *pgx.ConnPool
 	Service *restful.WebService
 	logger *log.Logger
 
+	// Liveness and readiness for load balancers, outside /api/Users
+	Health *restful.WebService
+	// Scraped by prometheus, also outside /api/Users
+	Metrics *restful.WebService
+	metrics *serviceMetrics
+
 	mailer *mailer.Mailer
 	validator *recaptcha.Validator
 	merch *getPaid.Merch
 
+	adminKey []byte
+
+	webhookSigner *webhookSigner
+	webhookClient *http.Client
+
+	// How many workers validate the cards of a single trade
+	validationWorkers int
+
+	// Signs pagination cursors handed to clients
+	cursorKey []byte
+
+	// Signups must confirm their email
+	requireEmailConfirmation bool
+
+	// Minimum time between paid plan changes
+	planCooldown time.Duration
+
+	// Every paid plan which may be subscribed to
+	plans planCatalog
+
+	// How long after expiry a session may still be refreshed
+	refreshGrace time.Duration
+
+	// Work outliving the request which started it, such as webhooks
+	background sync.WaitGroup
+	// Jobs waiting on the background workers, nil once shutting down
+	backgroundQueue chan func()
+	queueLock sync.RWMutex
+
+	// Closed to stop evaluating alerts, nil when they are disabled
+	stopAlerts chan struct{}
+
+}
+
+// Returns the users pool, which may be replaced while running so
+// should be acquired for each use.
+func (aService *UserService) db() *pgx.ConnPool {
+	return aService.keeper.Pool()
 }
 
 // Returns a fresh UserService ready to be hooked up to restful
 func NewUserService() *UserService {
 	
 	// Get necessary loggers
-	userLogger:= GetLogger("userLogger.txt", "userLog")
+	var userLogger *log.Logger
+	if os.Getenv(logFormatEnv) == jsonLogFormat {
+		userLogger = GetJSONLogger("userLog")
+	}else{
+		userLogger = GetLogger("userLogger.txt", "userLog")
+	}
+
+	// Queries are only logged when debugging as they are very noisy
+	if os.Getenv(debugQueriesEnv) != "" {
+		userDB.QueryLogger = userLogger
+	}
+
+	if reserved:= os.Getenv(reservedCollectionsEnv); reserved != "" {
+		userDB.ReservedCollectionNames = append(userDB.ReservedCollectionNames,
+			parseReservedNames(reserved)...)
+	}
+
+	if domains:= os.Getenv(disposableDomainsEnv); domains != "" {
+		userDB.DisposableEmailDomains = parseReservedNames(domains)
+	}
+
+	if raw:= os.Getenv(passwordMinLengthEnv); raw != "" {
+		minLength, err:= strconv.Atoi(raw)
+		if err == nil && minLength > 0 {
+			userDB.PasswordMinLength = minLength
+		}else{
+			userLogger.Println("ignoring invalid password minimum length", raw)
+		}
+	}
+
+	textLimits:= map[string]*int{
+		commentMaxLengthEnv: &commentMaxLength,
+		collectionNameMaxLengthEnv: &collectionNameMaxLength,
+		collectionTagMaxLengthEnv: &collectionTagMaxLength,
+	}
+	for env, limit:= range textLimits{
+		raw:= os.Getenv(env)
+		if raw == "" {
+			continue
+		}
+
+		parsed, ok:= parseTextLimit(raw)
+		if ok {
+			*limit = parsed
+		}else{
+			userLogger.Println("ignoring invalid text limit", env, raw)
+		}
+	}
+
+	if raw:= os.Getenv(hashParamsEnv); raw != "" {
+		params, err:= userDB.ParseHashParams(raw)
+		if err == nil {
+			userDB.CurrentHashParams = params
+		}else{
+			userLogger.Println("ignoring hash parameters,", err)
+		}
+	}
+
+	if rounding:= os.Getenv(valueRoundingEnv); rounding != "" {
+		step, ok:= roundingSteps[rounding]
+		if ok {
+			displayRounding = step
+		}else{
+			userLogger.Println("unknown value rounding, using cents", rounding)
+		}
+	}
 
-	// Grab a connection pool to the DB
-	pool, err:= userDB.Connect()
+	if raw:= os.Getenv(currencyRatesEnv); raw != "" {
+		rates, err:= parseRates(raw)
+		if err == nil {
+			conversionRates = rates
+		}else{
+			userLogger.Println("ignoring currency rates,", err)
+		}
+	}
+
+	// Grab a connection pool to the DB, recreated should it stay
+	// unreachable
+	keeper, err:= userDB.KeepPool(userDB.Connect)
 	if err != nil {
 		userLogger.Fatalln("Failed to acquire connection to remote db", err)
 	}
+	go keeper.Watch(userLogger.Printf)
+
+	// Prices are needed to value collections
+	pricePool, err:= priceDB.Connect()
+	if err != nil {
+		userLogger.Fatalln("Failed to acquire connection to price db", err)
+	}
 
 	aService:= UserService{
 		logger: userLogger,
-		pool: pool,
+		metrics: newServiceMetrics(),
+		keeper: keeper,
+		pricePool: pricePool,
+		validationWorkers: validationWorkerCount(),
+		requireEmailConfirmation: os.Getenv(requireEmailConfirmationEnv) != "",
+		planCooldown: planCooldown(),
+		refreshGrace: refreshGrace(),
 	}
 
+	aService.startBackground()
+
 	// Acquire and set up all requisites for sending mail
 	aService.setupMailing(mailGunMetaLoc)
 
@@ -76,6 +332,15 @@ func NewUserService() *UserService {
 
 	aService.setupMerchant(merchantMetaLoc)
 
+	aService.setupAdmin(adminMetaLoc)
+
+	aService.setupWebhooks(webhookMetaLoc)
+
+	aService.setupPlans(planMetaLoc)
+
+	// Alerts are mailed so mailing must be ready first
+	aService.setupAlerts()
+
 	// Finally, register the service
 	err = aService.register()
 	if err!=nil {
@@ -86,6 +351,21 @@ func NewUserService() *UserService {
 
 }
 
+// Splits a comma separated list of names, dropping empty entries.
+func parseReservedNames(raw string) []string {
+
+	names:= make([]string, 0)
+	for _, name:= range strings.Split(raw, ","){
+		name = strings.TrimSpace(name)
+		if name != "" {
+			names = append(names, name)
+		}
+	}
+
+	return names
+
+}
+
 // Builds all the mailing systems needed for a Users node to function.
 //
 // Mailer templates can be used from the mailer by referencing the template
@@ -97,10 +377,37 @@ func (aService *UserService) setupMailing(metaLoc string) {
 		aService.logger.Fatalln("Failed to get mailer", err)
 	}
 
+	// A missing template would otherwise only surface when first sent
+	if missing:= missingTemplates(mailer, requiredTemplates); len(missing) > 0 {
+		aService.logger.Fatalln("Mailer is missing templates", missing)
+	}
+
+	if os.Getenv(mailDryRunEnv) != "" {
+		mailer.DryRun = true
+	}
+	if mailer.DryRun {
+		aService.logger.Println("Mail is a dry run, nothing will be sent")
+	}
+	mailer.Logger = aService.logger
+
 	aService.mailer = mailer
 
 }
 
+// Returns each required template the mailer has not prepared.
+func missingTemplates(m *mailer.Mailer, required []string) []string {
+
+	missing:= make([]string, 0)
+	for _, name:= range required{
+		if !m.HasTemplate(name) {
+			missing = append(missing, name)
+		}
+	}
+
+	return missing
+
+}
+
 // Readies our ability to accept recaptcha 2.0 responses.
 func (aService *UserService) setupRecaptcha(loc string) {
 	validator, err:=  recaptcha.GetValidatorFromFile(loc)
@@ -131,12 +438,19 @@ func (aService *UserService) register() error {
 	if err!=nil {
 		aService.logger.Fatalln("Failed to acquire ", err)
 	}
+	userDB.KnownCard = knownCard
+
+	// Shared between expensive routes so together they can't
+	// exhaust the database
+	heavy:= heavyLimiter()
 
 	userService:= new(restful.WebService)
 	userService.
 		Path("/api/Users").
 		Consumes(restful.MIME_JSON).
-		Produces(restful.MIME_JSON)
+		Produces(restful.MIME_JSON).
+		Filter(requestIDFilter).
+		Filter(aService.metrics.filter)
 
 	// Extremely gross code, which does documents itself
 	// in an externally packaged pretty ui, follows.
@@ -151,21 +465,162 @@ func (aService *UserService) register() error {
 		Reads(NewUserData{}).
 		Writes("string").
 		Returns(http.StatusBadRequest, SignupFailure, nil).
-		Returns(http.StatusBadRequest, BadPassword, nil).
+		Returns(http.StatusBadRequest, "Invalid password: followed by each failed requirement", nil).
 		Returns(http.StatusBadRequest, BadCaptcha, nil).
+		Returns(http.StatusBadRequest, CaptchaLowScore, nil).
+		Returns(http.StatusServiceUnavailable, CaptchaUnavailable, nil).
+		Returns(http.StatusBadRequest, EmailMismatch, nil).
+		Returns(http.StatusBadRequest, BadEmail, nil).
+		Returns(http.StatusBadRequest, DisposableEmail, nil).
 		Returns(http.StatusOK, "A valid session code for the user", nil))
 
+	userService.Route(userService.
+		DELETE("/{userName}").To(aService.deleteUser).
+		// Docs
+		Doc("Deletes a logged in user's account, their collections and sessions, cancelling any paid plan").
+		Operation("deleteUser").
+		Param(userService.PathParameter("userName",
+			"The name that identifies a user to our service").DataType("string")).
+		Param(userService.HeaderParameter(sessionKeyHeader,
+			"The base64 encoded session key of the user").DataType("string")).
+		Reads(PasswordBody{}).
+		Writes(true).
+		Returns(http.StatusBadRequest, BodyReadFailure, nil).
+		Returns(http.StatusBadRequest, BadCredentials, nil).
+		Returns(http.StatusUnauthorized, BadCredentials, nil).
+		Returns(http.StatusTooManyRequests, LoginLocked, nil).
+		Returns(http.StatusOK, "Account deleted", nil))
+
 	userService.Route(userService.
 		POST("/{userName}/Login").To(aService.loginUser).
 		// Docs
-		Doc("Attempts to login a user").
+		Doc("Attempts to login a user, responses carrying X-Captcha-Required mean a Captcha must accompany the attempt").
 		Operation("loginUser").
 		Param(userService.PathParameter("userName",
 			"The name that identifies a user to our service").DataType("string")).
 		Reads(PasswordBody{}).
 		Returns(http.StatusBadRequest, SignupFailure, nil).
 		Returns(http.StatusBadRequest, BadCaptcha, nil).
-		Returns(http.StatusOK, "A valid session code for the user", nil))
+		Returns(http.StatusBadRequest, CaptchaLowScore, nil).
+		Returns(http.StatusServiceUnavailable, CaptchaUnavailable, nil).
+		Returns(http.StatusBadRequest, BadCredentials, nil).
+		Returns(http.StatusTooManyRequests, LoginLocked, nil).
+		Returns(http.StatusOK, "A valid session code for the user, flagged by X-Email-Unverified if their email is unverified", nil))
+
+	userService.Route(userService.
+		POST("/{userName}/VerifyEmail").To(aService.verifyEmail).
+		// Docs
+		Doc("Verifies a user's email using the code mailed to them").
+		Operation("verifyEmail").
+		Param(userService.PathParameter("userName",
+			"The name that identifies a user to our service").DataType("string")).
+		Reads(VerifyEmailBody{}).
+		Writes(true).
+		Returns(http.StatusBadRequest, BodyReadFailure, nil).
+		Returns(http.StatusBadRequest, BadVerifyCode, nil).
+		Returns(http.StatusOK, "Email verified", nil))
+
+	userService.Route(userService.
+		POST("/{userName}/VerifyEmail/Resend").To(aService.resendVerification).
+		// Docs
+		Doc("Mails an authenticated user a fresh verification code").
+		Operation("resendVerification").
+		Param(userService.PathParameter("userName",
+			"The name that identifies a user to our service").DataType("string")).
+		Reads(SessionKeyBody{}).
+		Writes(true).
+		Returns(http.StatusBadRequest, BodyReadFailure, nil).
+		Returns(http.StatusBadRequest, BadVerifyCode, nil).
+		Returns(http.StatusUnauthorized, BadCredentials, nil).
+		Returns(http.StatusOK, "Verification code sent", nil))
+
+	userService.Route(userService.
+		POST("/{userName}/Sessions/Refresh").To(aService.refreshSession).
+		// Docs
+		Doc("Extends the expiry of a still valid session without logging in again").
+		Operation("refreshSession").
+		Param(userService.PathParameter("userName",
+			"The name that identifies a user to our service").DataType("string")).
+		Reads(SessionKeyBody{}).
+		Returns(http.StatusBadRequest, BodyReadFailure, nil).
+		Returns(http.StatusUnauthorized, BadCredentials, nil).
+		Returns(http.StatusUnauthorized, SessionExhausted, nil).
+		Returns(http.StatusOK, "The new expiry of the session", nil))
+
+	userService.Route(userService.
+		POST("/{userName}/ForceRehash").To(aService.forceRehash).
+		// Docs
+		Doc("Rehashes the password of an authenticated user with a fresh nonce when they next login").
+		Operation("forceRehash").
+		Param(userService.PathParameter("userName",
+			"The name that identifies a user to our service").DataType("string")).
+		Reads(SessionKeyBody{}).
+		Writes(true).
+		Returns(http.StatusBadRequest, BodyReadFailure, nil).
+		Returns(http.StatusUnauthorized, BadCredentials, nil).
+		Returns(http.StatusOK, "The password is rehashed on next login", nil))
+
+	userService.Route(userService.
+		PATCH("/{userName}/Preferences").To(aService.setPreferences).
+		// Docs
+		Doc("Sets the currency and locale collections of the user are valued in by default").
+		Operation("setPreferences").
+		Param(userService.PathParameter("userName",
+			"The name that identifies a user to our service").DataType("string")).
+		Reads(PreferencesBody{}).
+		Writes(true).
+		Returns(http.StatusBadRequest, BodyReadFailure, nil).
+		Returns(http.StatusBadRequest, BadPreferences, nil).
+		Returns(http.StatusUnauthorized, BadCredentials, nil).
+		Returns(http.StatusOK, "Preferences are stored", nil))
+
+	userService.Route(userService.
+		GET("/{userName}/Sessions").To(aService.listSessions).
+		// Docs
+		Doc("Lists when each valid session of an authenticated user began and ends").
+		Operation("listSessions").
+		Param(userService.PathParameter("userName",
+			"The name that identifies a user to our service").DataType("string")).
+		Param(userService.HeaderParameter(sessionKeyHeader,
+			"The base64 encoded session key of the user").DataType("string")).
+		Param(userService.HeaderParameter("If-None-Match",
+			"The ETag of a previous listing, returns 304 while it is current").DataType("string")).
+		Writes([]SessionInfo{}).
+		Returns(http.StatusBadRequest, BadCredentials, nil).
+		Returns(http.StatusUnauthorized, BadCredentials, nil).
+		Returns(http.StatusNotModified, "Sessions are unchanged since the provided ETag", nil).
+		Returns(http.StatusOK, "Valid sessions, oldest first", nil))
+
+	userService.Route(userService.
+		DELETE("/{userName}/Sessions/{sessionID}").To(aService.revokeSession).
+		// Docs
+		Doc("Revokes a single session of an authenticated user, which may be the current one").
+		Operation("revokeSession").
+		Param(userService.PathParameter("userName",
+			"The name that identifies a user to our service").DataType("string")).
+		Param(userService.PathParameter("sessionID",
+			"The ID of a session, as listed").DataType("string")).
+		Param(userService.HeaderParameter(sessionKeyHeader,
+			"The base64 encoded session key of the user").DataType("string")).
+		Writes(true).
+		Returns(http.StatusBadRequest, BadCredentials, nil).
+		Returns(http.StatusUnauthorized, BadCredentials, nil).
+		Returns(http.StatusNotFound, NoSuchSession, nil).
+		Returns(http.StatusOK, "Session revoked", nil))
+
+	userService.Route(userService.
+		POST("/{userName}/Logout/All").To(aService.logoutAll).
+		// Docs
+		Doc("Removes every session of an authenticated user, optionally keeping the current one").
+		Operation("logoutAll").
+		Param(userService.PathParameter("userName",
+			"The name that identifies a user to our service").DataType("string")).
+		Reads(LogoutAllBody{}).
+		Writes(true).
+		Returns(http.StatusBadRequest, BodyReadFailure, nil).
+		Returns(http.StatusBadRequest, BadCredentials, nil).
+		Returns(http.StatusUnauthorized, BadCredentials, nil).
+		Returns(http.StatusOK, "Logged out", nil))
 
 	userService.Route(userService.
 		POST("/{userName}/Email").To(aService.getUserEmail).
@@ -179,6 +634,37 @@ func (aService *UserService) register() error {
 		Returns(http.StatusBadRequest, BadCaptcha, nil).
 		Returns(http.StatusOK, "john@doe.me", nil))
 
+	userService.Route(userService.
+		POST("/{userName}/Email/Change").To(aService.requestEmailChange).
+		// Docs
+		Doc("Mails a code to confirm changing a logged in user's email with to the new address").
+		Operation("requestEmailChange").
+		Param(userService.PathParameter("userName",
+			"The name that identifies a user to our service").DataType("string")).
+		Reads(EmailChangeBody{}).
+		Writes(true).
+		Returns(http.StatusBadRequest, BodyReadFailure, nil).
+		Returns(http.StatusBadRequest, BadEncoding, nil).
+		Returns(http.StatusBadRequest, BadEmail, nil).
+		Returns(http.StatusBadRequest, DisposableEmail, nil).
+		Returns(http.StatusUnauthorized, BadCredentials, nil).
+		Returns(http.StatusConflict, EmailUsed, nil).
+		Returns(http.StatusOK, "Confirmation code sent", nil))
+
+	userService.Route(userService.
+		POST("/{userName}/Email/Confirm").To(aService.confirmEmailChange).
+		// Docs
+		Doc("Changes a user's email to the one pending given the code mailed to it, the previous address is notified").
+		Operation("confirmEmailChange").
+		Param(userService.PathParameter("userName",
+			"The name that identifies a user to our service").DataType("string")).
+		Reads(EmailChangeConfirmBody{}).
+		Writes(true).
+		Returns(http.StatusBadRequest, BodyReadFailure, nil).
+		Returns(http.StatusBadRequest, BadEmailChangeCode, nil).
+		Returns(http.StatusConflict, EmailUsed, nil).
+		Returns(http.StatusOK, "Email changed", nil))
+
 	userService.Route(userService.
 		GET("/{userName}/Collections/GetPublic").To(aService.getUserPublicCollections).
 		// Docs
@@ -190,16 +676,36 @@ func (aService *UserService) register() error {
 		Returns(http.StatusBadRequest, BadUserName, nil).
 		Returns(http.StatusOK, "Public collections for a specified user", nil))
 
+	userService.Route(userService.
+		GET("/{userName}/Feed").To(aService.getFeed).
+		// Docs
+		Doc("Returns the recent activity of an authenticated user, most recent first").
+		Operation("getFeed").
+		Param(userService.PathParameter("userName",
+			"The name that identifies a user to our service").DataType("string")).
+		Param(userService.HeaderParameter(sessionKeyHeader,
+			"The base64 encoded session key of the user").DataType("string")).
+		Param(userService.QueryParameter("cursor",
+			"Next from a previous page, omit to start from the most recent event").DataType("string")).
+		Param(userService.QueryParameter("limit",
+			"How many events to return, defaults to 25 and at most 100").DataType("int")).
+		Writes(FeedResponse{}).
+		Returns(http.StatusBadRequest, BadCredentials, nil).
+		Returns(http.StatusBadRequest, BadFeedPage, nil).
+		Returns(http.StatusUnauthorized, BadCredentials, nil).
+		Returns(http.StatusOK, "A page of recent activity is returned", nil))
+
 	userService.Route(userService.
 		POST("/{userName}/Collections/Get").To(aService.getUserCollections).
 		// Docs
-		Doc("Returns a list of collections an authenticated user").
+		Doc("Returns a page of the collections of an authenticated user, ordered by name").
 		Operation("getUserCollections").
 		Param(userService.PathParameter("userName",
 			"The name that identifies a user to our service").DataType("string")).
-		Reads(SessionKeyBody{}).
-		Writes([]string{}).
+		Reads(CollectionListBody{}).
+		Writes(CollectionListPage{}).
 		Returns(http.StatusBadRequest, BodyReadFailure, nil).
+		Returns(http.StatusBadRequest, BadCollectionPage, nil).
 		Returns(http.StatusUnauthorized, BadCredentials, nil).
 		Returns(http.StatusOK, "Collections for a specified user", nil))
 
@@ -216,23 +722,50 @@ func (aService *UserService) register() error {
 		Reads(SessionKeyBody{}).
 		Writes(true).
 		Returns(http.StatusBadRequest, BodyReadFailure, nil).
+		Returns(http.StatusBadRequest, ReservedCollectionName, nil).
 		Returns(http.StatusUnauthorized, BadCredentials, nil).
+		Returns(http.StatusForbidden, CollectionLimit, nil).
+		Returns(http.StatusConflict, CollectionExists, nil).
 		Returns(http.StatusOK, "Collection is added", nil))
 
+	userService.Route(userService.
+		POST("/{userName}/Collections/{collectionName}/Ensure").
+		To(aService.ensureCollection).
+		// Docs
+		Doc("Adds a collection with the given name to the user unless it already exists").
+		Operation("ensureCollection").
+		Param(userService.PathParameter("userName",
+			"The name that identifies a user to our service").DataType("string")).
+		Param(userService.PathParameter("collectionName",
+			"The name of a collection for that user").DataType("string")).
+		Reads(SessionKeyBody{}).
+		Writes(EnsureCollectionResponse{}).
+		Returns(http.StatusBadRequest, BodyReadFailure, nil).
+		Returns(http.StatusBadRequest, ReservedCollectionName, nil).
+		Returns(http.StatusUnauthorized, BadCredentials, nil).
+		Returns(http.StatusForbidden, CollectionLimit, nil).
+		Returns(http.StatusOK, "Collection exists", nil))
+
 	userService.Route(userService.
 		POST("/{userName}/Collections/{collectionName}/Get").
 		To(aService.getCollection).
+		Filter(heavy.filter).
 		// Docs
-		Doc("Attempts to retrieve a collection from an authenticated user").
+		Doc("Attempts to retrieve a collection, valued at market prices, from an authenticated user").
 		Operation("getUserCollections").
 		Param(userService.PathParameter("userName",
 			"The name that identifies a user to our service").DataType("string")).
 		Param(userService.PathParameter("collectionName",
 			"The name of a collection for that user").DataType("string")).
-		Reads(SessionKeyBody{}).
-		Writes(CollectionContents{}).
+		Param(userService.QueryParameter("locale",
+			"Overrides the user's preferred locale for the formatted value").DataType("string")).
+		Reads(CollectionGetBody{}).
+		Writes(ValuedCollectionContents{}).
 		Returns(http.StatusBadRequest, BodyReadFailure, nil).
+		Returns(http.StatusBadRequest, BadPreferences, nil).
 		Returns(http.StatusUnauthorized, BadCredentials, nil).
+		Returns(http.StatusNotFound, NoSuchCollection, nil).
+		Returns(http.StatusServiceUnavailable, Overloaded, nil).
 		Returns(http.StatusOK, "Collection is returned", nil))
 
 	userService.Route(userService.
@@ -250,6 +783,177 @@ func (aService *UserService) register() error {
 		Returns(http.StatusUnauthorized, BadCredentials, nil).
 		Returns(http.StatusOK, "Collection is returned", nil))
 
+	userService.Route(userService.
+		POST("/{userName}/Collections/{collectionName}/History").
+		To(aService.getTradeHistory).
+		// Docs
+		Doc("Acquires every trade made to a collection of an authenticated user, oldest first").
+		Operation("getTradeHistory").
+		Param(userService.PathParameter("userName",
+			"The name that identifies a user to our service").DataType("string")).
+		Param(userService.PathParameter("collectionName",
+			"The name of a collection for that user").DataType("string")).
+		Reads(SessionKeyBody{}).
+		Writes([]userDB.Trade{}).
+		Returns(http.StatusBadRequest, BodyReadFailure, nil).
+		Returns(http.StatusUnauthorized, BadCredentials, nil).
+		Returns(http.StatusNotFound, NoSuchCollection, nil).
+		Returns(http.StatusOK, "Trades are returned", nil))
+
+	userService.Route(userService.
+		GET("/{userName}/Collections/{collectionName}/HistoryPublic").
+		To(aService.getTradeHistoryPublic).
+		Filter(heavy.filter).
+		// Docs
+		Doc("Acquires every trade made to a collection whose history is public, oldest first").
+		Operation("getTradeHistoryPublic").
+		Param(userService.PathParameter("userName",
+			"The name that identifies a user to our service").DataType("string")).
+		Param(userService.PathParameter("collectionName",
+			"The name of a collection for that user").DataType("string")).
+		Writes([]userDB.Trade{}).
+		Returns(http.StatusBadRequest, BadCredentials, nil).
+		Returns(http.StatusServiceUnavailable, Overloaded, nil).
+		Returns(http.StatusOK, "Trades are returned", nil))
+
+	userService.Route(userService.
+		GET("/{userName}/Collections/{collectionName}/Quantities").
+		To(aService.getQuantities).
+		Filter(heavy.filter).
+		// Docs
+		Doc("Acquires the net quantity held of each card in a public collection, derived from its trades").
+		Operation("getQuantities").
+		Param(userService.PathParameter("userName",
+			"The name that identifies a user to our service").DataType("string")).
+		Param(userService.PathParameter("collectionName",
+			"The name of a collection for that user").DataType("string")).
+		Writes([]CardQuantity{}).
+		Returns(http.StatusBadRequest, BadCredentials, nil).
+		Returns(http.StatusServiceUnavailable, Overloaded, nil).
+		Returns(http.StatusOK, "Net quantities are returned", nil))
+
+	userService.Route(userService.
+		GET("/{userName}/Collections/{collectionName}/Export").
+		To(aService.exportCollection).
+		Filter(heavy.filter).
+		Produces("text/csv", restful.MIME_JSON).
+		// Docs
+		Doc("Downloads the current contents of a collection with market prices in cents of USD, public collections may be exported without a session").
+		Operation("exportCollection").
+		Param(userService.PathParameter("userName",
+			"The name that identifies a user to our service").DataType("string")).
+		Param(userService.PathParameter("collectionName",
+			"The name of a collection for that user").DataType("string")).
+		Param(userService.QueryParameter("format",
+			"csv or json, csv when absent").DataType("string")).
+		Param(userService.HeaderParameter(sessionKeyHeader,
+			"The base64 encoded session key of the owner, absent to export as the public").DataType("string")).
+		Writes(CollectionExport{}).
+		Returns(http.StatusBadRequest, BadExportFormat, nil).
+		Returns(http.StatusBadRequest, BadCredentials, nil).
+		Returns(http.StatusUnauthorized, BadCredentials, nil).
+		Returns(http.StatusNotFound, NoSuchCollection, nil).
+		Returns(http.StatusInternalServerError, ExportFailure, nil).
+		Returns(http.StatusServiceUnavailable, Overloaded, nil).
+		Returns(http.StatusOK, "The collection as a csv or json attachment", nil))
+
+	userService.Route(userService.
+		GET("/{userName}/Collections/{collectionName}/Holdings").
+		To(aService.getHoldings).
+		Filter(heavy.filter).
+		// Docs
+		Doc("Nets every trade of a collection into the quantity held of each card with its current market price in cents of USD and, for the owner or collections with public history, its average cost. Public collections may be viewed without a session").
+		Operation("getHoldings").
+		Param(userService.PathParameter("userName",
+			"The name that identifies a user to our service").DataType("string")).
+		Param(userService.PathParameter("collectionName",
+			"The name of a collection for that user").DataType("string")).
+		Param(userService.HeaderParameter(sessionKeyHeader,
+			"The base64 encoded session key of the owner, absent to view as the public").DataType("string")).
+		Writes([]Holding{}).
+		Returns(http.StatusBadRequest, BadCredentials, nil).
+		Returns(http.StatusUnauthorized, BadCredentials, nil).
+		Returns(http.StatusNotFound, NoSuchCollection, nil).
+		Returns(http.StatusServiceUnavailable, Overloaded, nil).
+		Returns(http.StatusOK, "Every card held, sorted by name, set, quality, then language", nil))
+
+	userService.Route(userService.
+		POST("/{userName}/Collections/{collectionName}/Import").
+		To(aService.importCollection).
+		Filter(heavy.filter).
+		// Docs
+		Doc("Imports a dec or plain list of cards into a collection as a single trade, reporting lines which were skipped").
+		Operation("importCollection").
+		Param(userService.PathParameter("userName",
+			"The name that identifies a user to our service").DataType("string")).
+		Param(userService.PathParameter("collectionName",
+			"The name of a collection for that user").DataType("string")).
+		Reads(CollectionImportBody{}).
+		Writes(CollectionImportResponse{}).
+		Returns(http.StatusBadRequest, BodyReadFailure, nil).
+		Returns(http.StatusBadRequest, BadImportFormat, nil).
+		Returns(http.StatusBadRequest, BadImportSize, nil).
+		Returns(http.StatusUnauthorized, BadCredentials, nil).
+		Returns(http.StatusConflict, CollectionLocked, nil).
+		Returns(http.StatusConflict, CollectionConflict, nil).
+		Returns(http.StatusForbidden, CollectionOverLimit, nil).
+		Returns(http.StatusServiceUnavailable, Overloaded, nil).
+		Returns(http.StatusOK, "How many printings were imported and the lines skipped", nil))
+
+	userService.Route(userService.
+		GET("/{userName}/Collections/{collectionName}/TopCards").
+		To(aService.getTopCards).
+		Filter(heavy.filter).
+		// Docs
+		Doc("Acquires the most valuable cards in a public collection, by quantity times latest price").
+		Operation("getTopCards").
+		Param(userService.PathParameter("userName",
+			"The name that identifies a user to our service").DataType("string")).
+		Param(userService.PathParameter("collectionName",
+			"The name of a collection for that user").DataType("string")).
+		Param(userService.QueryParameter("n",
+			"How many cards to return, defaults to 10 and at most 100").DataType("int")).
+		Param(userService.QueryParameter("basis",
+			"Value at market or sell prices, defaults to the collection's basis then market").DataType("string")).
+		Param(userService.QueryParameter("minValue",
+			"Cards priced below this many cents are totalled as bulk instead").DataType("int")).
+		Param(userService.QueryParameter("currency",
+			"USD or EUR, defaults to the collection's currency then the owner's preference").DataType("string")).
+		Param(userService.QueryParameter("locale",
+			"How values are formatted, defaults to the owner's preference").DataType("string")).
+		Writes(TopCardsResponse{}).
+		Returns(http.StatusBadRequest, BadTopCount, nil).
+		Returns(http.StatusBadRequest, BadBasis, nil).
+		Returns(http.StatusBadRequest, BadMinValue, nil).
+		Returns(http.StatusBadRequest, BadPreferences, nil).
+		Returns(http.StatusBadRequest, BadCredentials, nil).
+		Returns(http.StatusServiceUnavailable, Overloaded, nil).
+		Returns(http.StatusOK, "Most valuable cards are returned", nil))
+
+	userService.Route(userService.
+		GET("/{userName}/Collections/{collectionName}/Stats").
+		To(aService.getCollectionStats).
+		Filter(heavy.filter).
+		// Docs
+		Doc("Acquires the total value of a public collection at market and sell prices alongside their spread").
+		Operation("getCollectionStats").
+		Param(userService.PathParameter("userName",
+			"The name that identifies a user to our service").DataType("string")).
+		Param(userService.PathParameter("collectionName",
+			"The name of a collection for that user").DataType("string")).
+		Param(userService.QueryParameter("minValue",
+			"Cards priced below this many cents are totalled as bulk instead").DataType("int")).
+		Param(userService.QueryParameter("currency",
+			"USD or EUR, defaults to the collection's currency then the owner's preference").DataType("string")).
+		Param(userService.QueryParameter("locale",
+			"How values are formatted, defaults to the owner's preference").DataType("string")).
+		Writes(CollectionStats{}).
+		Returns(http.StatusBadRequest, BadMinValue, nil).
+		Returns(http.StatusBadRequest, BadPreferences, nil).
+		Returns(http.StatusBadRequest, BadCredentials, nil).
+		Returns(http.StatusServiceUnavailable, Overloaded, nil).
+		Returns(http.StatusOK, "Collection totals are returned", nil))
+
 	userService.Route(userService.
 		PATCH("/{userName}/Collections/{collectionName}/Permissions").
 		To(aService.setCollectionPermissions).
@@ -262,10 +966,176 @@ func (aService *UserService) register() error {
 			"The name of a collection for that user").DataType("string")).
 		Reads(PermissionChangeBody{}).
 		Returns(http.StatusBadRequest, BodyReadFailure, nil).
+		Returns(http.StatusBadRequest, BadPrivacy, nil).
 		Writes(true).
 		Returns(http.StatusUnauthorized, BadCredentials, nil).
+		Returns(http.StatusNotFound, NoSuchCollection, nil).
+		Returns(http.StatusConflict, CollectionConflict, nil).
 		Returns(http.StatusOK, "Permissions changed", nil))
 
+	userService.Route(userService.
+		POST("/{userName}/Alerts").To(aService.addAlert).
+		// Docs
+		Doc("Sets an alert mailed when any card in a collection moves by more than a percent or crosses a price in cents of USD, limited per plan").
+		Operation("addAlert").
+		Param(userService.PathParameter("userName",
+			"The name that identifies a user to our service").DataType("string")).
+		Reads(AlertAddBody{}).
+		Writes(userDB.Alert{}).
+		Returns(http.StatusBadRequest, BodyReadFailure, nil).
+		Returns(http.StatusBadRequest, BadAlert, nil).
+		Returns(http.StatusUnauthorized, BadCredentials, nil).
+		Returns(http.StatusNotFound, NoSuchCollection, nil).
+		Returns(http.StatusForbidden, AlertLimit, nil).
+		Returns(http.StatusOK, "The alert set", nil))
+
+	userService.Route(userService.
+		GET("/{userName}/Alerts").To(aService.getAlerts).
+		// Docs
+		Doc("Lists every alert an authenticated user has set").
+		Operation("getAlerts").
+		Param(userService.PathParameter("userName",
+			"The name that identifies a user to our service").DataType("string")).
+		Param(userService.HeaderParameter(sessionKeyHeader,
+			"The base64 encoded session key of the user").DataType("string")).
+		Writes([]userDB.Alert{}).
+		Returns(http.StatusBadRequest, BadCredentials, nil).
+		Returns(http.StatusUnauthorized, BadCredentials, nil).
+		Returns(http.StatusOK, "Alerts, oldest first", nil))
+
+	userService.Route(userService.
+		DELETE("/{userName}/Alerts/{alertID}").To(aService.deleteAlert).
+		// Docs
+		Doc("Deletes a single alert of an authenticated user").
+		Operation("deleteAlert").
+		Param(userService.PathParameter("userName",
+			"The name that identifies a user to our service").DataType("string")).
+		Param(userService.PathParameter("alertID",
+			"The ID of an alert, as listed").DataType("string")).
+		Param(userService.HeaderParameter(sessionKeyHeader,
+			"The base64 encoded session key of the user").DataType("string")).
+		Writes(true).
+		Returns(http.StatusBadRequest, BadCredentials, nil).
+		Returns(http.StatusUnauthorized, BadCredentials, nil).
+		Returns(http.StatusNotFound, NoSuchAlert, nil).
+		Returns(http.StatusOK, "Alert deleted", nil))
+
+	userService.Route(userService.
+		POST("/{userName}/Webhooks").To(aService.registerWebhook).
+		// Docs
+		Doc("Registers an endpoint to receive signed notifications of collection changes, limited per plan").
+		Operation("registerWebhook").
+		Param(userService.PathParameter("userName",
+			"The name that identifies a user to our service").DataType("string")).
+		Reads(WebhookRegisterBody{}).
+		Returns(http.StatusBadRequest, BodyReadFailure, nil).
+		Returns(http.StatusBadRequest, BadWebhookURL, nil).
+		Writes(true).
+		Returns(http.StatusUnauthorized, BadCredentials, nil).
+		Returns(http.StatusForbidden, WebhookLimit, nil).
+		Returns(http.StatusOK, "Webhook registered", nil))
+
+	userService.Route(userService.
+		PATCH("/{userName}/Collections/{collectionName}/Tags").
+		To(aService.setCollectionTags).
+		// Docs
+		Doc("Replaces the tags a collection is labelled with, such as EDH or Trade Binder").
+		Operation("setCollectionTags").
+		Param(userService.PathParameter("userName",
+			"The name that identifies a user to our service").DataType("string")).
+		Param(userService.PathParameter("collectionName",
+			"The name of a collection for that user").DataType("string")).
+		Reads(CollectionTagsBody{}).
+		Returns(http.StatusBadRequest, BodyReadFailure, nil).
+		Returns(http.StatusBadRequest, BadCollectionTags, nil).
+		Returns(http.StatusBadRequest, BadEncoding, nil).
+		Writes(true).
+		Returns(http.StatusUnauthorized, BadCredentials, nil).
+		Returns(http.StatusNotFound, NoSuchCollection, nil).
+		Returns(http.StatusConflict, CollectionConflict, nil).
+		Returns(http.StatusOK, "Tags changed", nil))
+
+	userService.Route(userService.
+		PATCH("/{userName}/Collections/{collectionName}/Lock").
+		To(aService.setCollectionLock).
+		// Docs
+		Doc("Locks or unlocks a collection, locked collections can be viewed but reject trades").
+		Operation("setCollectionLock").
+		Param(userService.PathParameter("userName",
+			"The name that identifies a user to our service").DataType("string")).
+		Param(userService.PathParameter("collectionName",
+			"The name of a collection for that user").DataType("string")).
+		Reads(LockChangeBody{}).
+		Returns(http.StatusBadRequest, BodyReadFailure, nil).
+		Writes(true).
+		Returns(http.StatusUnauthorized, BadCredentials, nil).
+		Returns(http.StatusNotFound, NoSuchCollection, nil).
+		Returns(http.StatusOK, "Lock changed", nil))
+
+	userService.Route(userService.
+		DELETE("/{userName}/Collections/{collectionName}").
+		To(aService.deleteCollection).
+		// Docs
+		Doc("Deletes a collection and its contents, freeing its name and slot").
+		Operation("deleteCollection").
+		Param(userService.PathParameter("userName",
+			"The name that identifies a user to our service").DataType("string")).
+		Param(userService.PathParameter("collectionName",
+			"The name of a collection for that user").DataType("string")).
+		Param(userService.HeaderParameter(sessionKeyHeader,
+			"The base64 encoded session key of the user").DataType("string")).
+		Writes(true).
+		Returns(http.StatusBadRequest, BadCredentials, nil).
+		Returns(http.StatusUnauthorized, BadCredentials, nil).
+		Returns(http.StatusNotFound, NoSuchCollection, nil).
+		Returns(http.StatusConflict, CollectionLocked, nil).
+		Returns(http.StatusOK, "Collection deleted", nil))
+
+	userService.Route(userService.
+		PATCH("/{userName}/Collections/{collectionName}/Rename").
+		To(aService.renameCollection).
+		// Docs
+		Doc("Renames a collection, keeping its contents, history and permissions").
+		Operation("renameCollection").
+		Param(userService.PathParameter("userName",
+			"The name that identifies a user to our service").DataType("string")).
+		Param(userService.PathParameter("collectionName",
+			"The name of a collection for that user").DataType("string")).
+		Reads(CollectionRenameBody{}).
+		Returns(http.StatusBadRequest, BodyReadFailure, nil).
+		Returns(http.StatusBadRequest, BadUserText, nil).
+		Returns(http.StatusBadRequest, ReservedCollectionName, nil).
+		Writes(true).
+		Returns(http.StatusUnauthorized, BadCredentials, nil).
+		Returns(http.StatusNotFound, NoSuchCollection, nil).
+		Returns(http.StatusConflict, CollectionExists, nil).
+		Returns(http.StatusConflict, CollectionLocked, nil).
+		Returns(http.StatusConflict, CollectionConflict, nil).
+		Returns(http.StatusForbidden, CollectionOverLimit, nil).
+		Returns(http.StatusOK, "Collection renamed", nil))
+
+	userService.Route(userService.
+		PATCH("/{userName}/Collections/{collectionName}/Valuation").
+		To(aService.setCollectionValuation).
+		// Docs
+		Doc("Sets the currency and basis a collection is valued in when a request doesn't specify them").
+		Operation("setCollectionValuation").
+		Param(userService.PathParameter("userName",
+			"The name that identifies a user to our service").DataType("string")).
+		Param(userService.PathParameter("collectionName",
+			"The name of a collection for that user").DataType("string")).
+		Reads(CollectionValuationBody{}).
+		Returns(http.StatusBadRequest, BodyReadFailure, nil).
+		Returns(http.StatusBadRequest, BadPreferences, nil).
+		Returns(http.StatusBadRequest, BadBasis, nil).
+		Writes(true).
+		Returns(http.StatusUnauthorized, BadCredentials, nil).
+		Returns(http.StatusNotFound, NoSuchCollection, nil).
+		Returns(http.StatusConflict, CollectionLocked, nil).
+		Returns(http.StatusConflict, CollectionConflict, nil).
+		Returns(http.StatusForbidden, CollectionOverLimit, nil).
+		Returns(http.StatusOK, "Valuation defaults changed", nil))
+
 	userService.Route(userService.
 		POST("/{userName}/Collections/{collectionName}/Permissions").
 		To(aService.getCollectionPermissions).
@@ -296,8 +1166,95 @@ func (aService *UserService) register() error {
 		Returns(http.StatusBadRequest, BodyReadFailure, nil).
 		Writes(true).
 		Returns(http.StatusUnauthorized, BadCredentials, nil).
+		Returns(http.StatusConflict, CollectionLocked, nil).
+		Returns(http.StatusConflict, CollectionConflict, nil).
+		Returns(http.StatusForbidden, CollectionOverLimit, nil).
 		Returns(http.StatusOK, "Trade Added", nil))
 
+	userService.Route(userService.
+		POST("/{userName}/Collections/{collectionName}/Trades/Bulk").
+		To(aService.addTrades).
+		// Docs
+		Doc("Attempt to add many trades to a collection at once, either all are added or none").
+		Operation("addTrades").
+		Param(userService.PathParameter("userName",
+			"The name that identifies a user to our service").DataType("string")).
+		Param(userService.PathParameter("collectionName",
+			"The name of a collection for that user").DataType("string")).
+		Reads(TradeBulkAddBody{}).
+		Writes([]BulkTradeFailure{}).
+		Returns(http.StatusBadRequest, BodyReadFailure, nil).
+		Returns(http.StatusBadRequest, BadBulkTradeSize, nil).
+		Returns(http.StatusUnauthorized, BadCredentials, nil).
+		Returns(http.StatusConflict, CollectionLocked, nil).
+		Returns(http.StatusConflict, CollectionConflict, nil).
+		Returns(http.StatusForbidden, CollectionOverLimit, nil).
+		Returns(http.StatusOK, "The trades which failed validation, none were added unless empty", nil))
+
+	userService.Route(userService.
+		POST("/{userName}/Collections/{collectionName}/Trades/{tradeID}/Copy").
+		To(aService.copyTrade).
+		// Docs
+		Doc("Copy a single trade into another collection as a new trade").
+		Operation("copyTrade").
+		Param(userService.PathParameter("userName",
+			"The name that identifies a user to our service").DataType("string")).
+		Param(userService.PathParameter("collectionName",
+			"The name of the collection holding the trade").DataType("string")).
+		Param(userService.PathParameter("tradeID",
+			"The TradeID found on the trade's history").DataType("string")).
+		Reads(TradeCopyBody{}).
+		Writes(userDB.Trade{}).
+		Returns(http.StatusBadRequest, BodyReadFailure, nil).
+		Returns(http.StatusUnauthorized, BadCredentials, nil).
+		Returns(http.StatusNotFound, NoSuchCollection, nil).
+		Returns(http.StatusNotFound, NoSuchTrade, nil).
+		Returns(http.StatusConflict, CollectionLocked, nil).
+		Returns(http.StatusConflict, CollectionConflict, nil).
+		Returns(http.StatusForbidden, CollectionOverLimit, nil).
+		Returns(http.StatusOK, "The copy made in the destination", nil))
+
+	userService.Route(userService.
+		DELETE("/{userName}/Collections/{collectionName}/Trades/{tradeID}").
+		To(aService.removeTrade).
+		// Docs
+		Doc("Undo a single trade, recording its reversal in history").
+		Operation("removeTrade").
+		Param(userService.PathParameter("userName",
+			"The name that identifies a user to our service").DataType("string")).
+		Param(userService.PathParameter("collectionName",
+			"The name of the collection holding the trade").DataType("string")).
+		Param(userService.PathParameter("tradeID",
+			"The TradeID found on the trade's history").DataType("string")).
+		Param(userService.HeaderParameter(sessionKeyHeader,
+			"The base64 encoded session key of the user").DataType("string")).
+		Writes(userDB.Trade{}).
+		Returns(http.StatusBadRequest, BadCredentials, nil).
+		Returns(http.StatusUnauthorized, BadCredentials, nil).
+		Returns(http.StatusNotFound, NoSuchCollection, nil).
+		Returns(http.StatusNotFound, NoSuchTrade, nil).
+		Returns(http.StatusConflict, TradeReversed, nil).
+		Returns(http.StatusConflict, CollectionLocked, nil).
+		Returns(http.StatusConflict, CollectionConflict, nil).
+		Returns(http.StatusForbidden, CollectionOverLimit, nil).
+		Returns(http.StatusOK, "The reversal recorded in history", nil))
+
+	userService.Route(userService.
+		POST("/{userName}/ChangePassword").To(aService.changePassword).
+		// Docs
+		Doc("Changes the password of a logged in user who knows their current one, every other session is logged out").
+		Operation("changePassword").
+		Param(userService.PathParameter("userName",
+			"The name that identifies a user to our service").DataType("string")).
+		Reads(PasswordChangeBody{}).
+		Writes("string").
+		Returns(http.StatusBadRequest, BodyReadFailure, nil).
+		Returns(http.StatusBadRequest, "Invalid password: followed by each failed requirement", nil).
+		Returns(http.StatusBadRequest, BadCredentials, nil).
+		Returns(http.StatusUnauthorized, BadCredentials, nil).
+		Returns(http.StatusTooManyRequests, LoginLocked, nil).
+		Returns(http.StatusOK, "A fresh session key for the user", nil))
+
 	userService.Route(userService.
 		POST("/{userName}/PasswordResetRequest").
 		To(aService.requestPasswordReset).
@@ -309,9 +1266,10 @@ func (aService *UserService) register() error {
 		Reads(PasswordResetRequestBody{}).
 		Returns(http.StatusBadRequest, BodyReadFailure, nil).
 		Returns(http.StatusBadRequest, BadCaptcha, nil).
-		Returns(http.StatusUnauthorized, BadCredentials, nil).
+		Returns(http.StatusBadRequest, CaptchaLowScore, nil).
+		Returns(http.StatusServiceUnavailable, CaptchaUnavailable, nil).
 		Writes(true).
-		Returns(http.StatusOK, "Reset Code Sent", nil))
+		Returns(http.StatusOK, "Reset Code Sent, if the user exists", nil))
 
 	userService.Route(userService.
 		POST("/{userName}/PasswordReset").
@@ -323,7 +1281,9 @@ func (aService *UserService) register() error {
 			"The name that identifies a user to our service").DataType("string")).
 		Reads(PasswordResetBody{}).
 		Returns(http.StatusBadRequest, BodyReadFailure, nil).
+		Returns(http.StatusBadRequest, "Invalid password: followed by each failed requirement", nil).
 		Returns(http.StatusBadRequest, BadCaptcha, nil).
+		Returns(http.StatusBadRequest, ResetExpired, nil).
 		Returns(http.StatusUnauthorized, BadCredentials, nil).
 		Writes(true).
 		Returns(http.StatusOK, "Successfully reset", nil))
@@ -339,10 +1299,19 @@ func (aService *UserService) register() error {
 		Reads(SubBody{}).
 		Returns(http.StatusBadRequest, BodyReadFailure, nil).
 		Returns(http.StatusUnauthorized, BadCredentials, nil).
-		Returns(http.StatusBadRequest, DBfailure, nil).
+		Returns(http.StatusBadRequest, BadUserName, nil).
+		Returns(http.StatusInternalServerError, DBfailure, nil).
+		Returns(http.StatusServiceUnavailable, DBUnavailable, nil).
 		Returns(http.StatusBadRequest, BadPlanChoice, nil).
+		Returns(http.StatusForbidden, EmailUnverified, nil).
+		Returns(http.StatusConflict, PlanCooldown, nil).
+		Returns(http.StatusBadRequest, BadCoupon, nil).
 		Returns(http.StatusBadRequest, StripeCustFailure, nil).
+		Returns(http.StatusPaymentRequired, StripeCustFailure, nil).
+		Returns(http.StatusBadGateway, StripeCustFailure, nil).
 		Returns(http.StatusBadRequest, StripeSubFailure, nil).
+		Returns(http.StatusPaymentRequired, StripeSubFailure, nil).
+		Returns(http.StatusBadGateway, StripeSubFailure, nil).
 		Writes(true).
 		Returns(http.StatusOK, "Successfully subbed", nil))
 
@@ -358,10 +1327,17 @@ func (aService *UserService) register() error {
 		Reads(SubBody{}).
 		Returns(http.StatusBadRequest, BodyReadFailure, nil).
 		Returns(http.StatusUnauthorized, BadCredentials, nil).
-		Returns(http.StatusBadRequest, DBfailure, nil).
+		Returns(http.StatusBadRequest, BadUserName, nil).
+		Returns(http.StatusInternalServerError, DBfailure, nil).
+		Returns(http.StatusServiceUnavailable, DBUnavailable, nil).
 		Returns(http.StatusBadRequest, BadPlanChoice, nil).
+		Returns(http.StatusConflict, PlanCooldown, nil).
 		Returns(http.StatusBadRequest, StripeCustFailure, nil).
+		Returns(http.StatusPaymentRequired, StripeCustFailure, nil).
+		Returns(http.StatusBadGateway, StripeCustFailure, nil).
 		Returns(http.StatusBadRequest, StripeSubFailure, nil).
+		Returns(http.StatusPaymentRequired, StripeSubFailure, nil).
+		Returns(http.StatusBadGateway, StripeSubFailure, nil).
 		Writes(true).
 		Returns(http.StatusOK, "Successfully subbed", nil))
 
@@ -376,8 +1352,13 @@ func (aService *UserService) register() error {
 		Reads(SubBody{}).
 		Returns(http.StatusBadRequest, BodyReadFailure, nil).
 		Returns(http.StatusUnauthorized, BadCredentials, nil).
-		Returns(http.StatusBadRequest, DBfailure, nil).
+		Returns(http.StatusBadRequest, BadUserName, nil).
+		Returns(http.StatusInternalServerError, DBfailure, nil).
+		Returns(http.StatusServiceUnavailable, DBUnavailable, nil).
+		Returns(http.StatusBadRequest, BadPlanChoice, nil).
 		Returns(http.StatusBadRequest, StripeSubFailure, nil).
+		Returns(http.StatusPaymentRequired, StripeSubFailure, nil).
+		Returns(http.StatusBadGateway, StripeSubFailure, nil).
 		Writes(true).
 		Returns(http.StatusOK, "Successfully unsubbed", nil))
 
@@ -385,22 +1366,165 @@ func (aService *UserService) register() error {
 		POST("/{userName}/SubStatus").
 		To(aService.getSubUser).
 		// Docs
-		Doc("Acquires the plan a given user is subscribed to").
+		Doc("Acquires the plan a given user is subscribed to and its billing state").
 		Operation("subscribe").
 		Param(userService.PathParameter("userName",
 			"The name that identifies a user to our service").DataType("string")).
 		Reads(SessionKeyBody{}).
 		Returns(http.StatusBadRequest, BodyReadFailure, nil).
 		Returns(http.StatusUnauthorized, BadCredentials, nil).
-		Returns(http.StatusBadRequest, DBfailure, nil).
+		Returns(http.StatusBadRequest, BadUserName, nil).
+		Returns(http.StatusInternalServerError, DBfailure, nil).
+		Returns(http.StatusServiceUnavailable, DBUnavailable, nil).
 		Returns(http.StatusBadRequest, BadPlanChoice, nil).
 		Returns(http.StatusBadRequest, StripeCustFailure, nil).
+		Returns(http.StatusPaymentRequired, StripeCustFailure, nil).
+		Returns(http.StatusBadGateway, StripeCustFailure, nil).
 		Returns(http.StatusBadRequest, StripeSubFailure, nil).
-		Writes(userDB.DefaultSubLevel).
-		Returns(http.StatusOK, "userDB.DefaultSubLevel", nil))
+		Returns(http.StatusPaymentRequired, StripeSubFailure, nil).
+		Returns(http.StatusBadGateway, StripeSubFailure, nil).
+		Writes(SubscriptionStatus{}).
+		Returns(http.StatusOK, "The plan and its billing state", nil))
+
+	userService.Route(userService.
+		GET("/Plans").To(aService.getPlans).
+		// Docs
+		Doc("Acquires every plan available, the free plan included, with its price and features").
+		Operation("getPlans").
+		Writes([]Plan{}).
+		Returns(http.StatusOK, "Every available plan", []Plan{}))
+
+	userService.Route(userService.
+		POST("/StripeWebhook").
+		To(aService.stripeWebhook).
+		// Docs
+		Doc("Receives subscription events from stripe, such as failed payments and cancellations").
+		Operation("stripeWebhook").
+		Param(userService.HeaderParameter(stripeSignatureHeader,
+			"The signature stripe sent the event with").DataType("string")).
+		Returns(http.StatusBadRequest, BodyReadFailure, nil).
+		Returns(http.StatusBadRequest, BadWebhookSignature, nil).
+		Returns(http.StatusInternalServerError, DBWriteFailure, nil).
+		Writes(true).
+		Returns(http.StatusOK, "Event handled, or already handled", nil))
+
+	userService.Route(userService.
+		POST("/Admin/BulkAdd").
+		To(aService.bulkAddUsers).
+		// Docs
+		Doc("Creates many users at once, each is mailed a code to set their password. Requires the admin key.").
+		Operation("bulkAddUsers").
+		Reads(BulkAddBody{}).
+		Returns(http.StatusBadRequest, BodyReadFailure, nil).
+		Returns(http.StatusBadRequest, BadBulkSize, nil).
+		Returns(http.StatusBadRequest, SignupFailure, nil).
+		Returns(http.StatusUnauthorized, BadCredentials, nil).
+		Writes([]BulkAddUserResult{}).
+		Returns(http.StatusOK, "Per user results of the import", nil))
+
+	userService.Route(userService.
+		POST("/Admin/ForceRehash").
+		To(aService.adminForceRehash).
+		// Docs
+		Doc("Rehashes the password of any user with a fresh nonce when they next login. Requires the admin key.").
+		Operation("adminForceRehash").
+		Reads(ForceRehashBody{}).
+		Returns(http.StatusBadRequest, BodyReadFailure, nil).
+		Returns(http.StatusBadRequest, BadUserName, nil).
+		Returns(http.StatusUnauthorized, BadCredentials, nil).
+		Writes(true).
+		Returns(http.StatusOK, "The password is rehashed on next login", nil))
+
+	userService.Route(userService.
+		POST("/Admin/WaivePlanCooldown").
+		To(aService.adminWaivePlanCooldown).
+		// Docs
+		Doc("Lets a user's next plan change skip the cooldown. Requires the admin key.").
+		Operation("adminWaivePlanCooldown").
+		Reads(PlanCooldownWaiverBody{}).
+		Returns(http.StatusBadRequest, BodyReadFailure, nil).
+		Returns(http.StatusBadRequest, BadUserName, nil).
+		Returns(http.StatusUnauthorized, BadCredentials, nil).
+		Writes(true).
+		Returns(http.StatusOK, "The next plan change skips the cooldown", nil))
+
+	userService.Route(userService.
+		POST("/Admin/DeadLetters").
+		To(aService.adminDeadLetters).
+		// Docs
+		Doc("Lists outbound mail that could not be delivered, oldest first. Requires the admin key.").
+		Operation("adminDeadLetters").
+		Reads(DeadLetterBody{}).
+		Writes([]userDB.DeadLetter{}).
+		Returns(http.StatusBadRequest, BodyReadFailure, nil).
+		Returns(http.StatusUnauthorized, BadCredentials, nil).
+		Returns(http.StatusInternalServerError, DBfailure, nil).
+		Returns(http.StatusOK, "Undelivered mail", nil))
+
+	userService.Route(userService.
+		POST("/Admin/DeadLetters/{letterID}/Retry").
+		To(aService.adminRetryDeadLetter).
+		// Docs
+		Doc("Sends a dead lettered mail again, forgetting it once delivered. Requires the admin key.").
+		Operation("adminRetryDeadLetter").
+		Param(userService.PathParameter("letterID",
+			"The ID of a dead letter").DataType("int")).
+		Reads(DeadLetterBody{}).
+		Writes(true).
+		Returns(http.StatusBadRequest, BodyReadFailure, nil).
+		Returns(http.StatusUnauthorized, BadCredentials, nil).
+		Returns(http.StatusNotFound, NoSuchDeadLetter, nil).
+		Returns(http.StatusConflict, DeadLetterNotKept, nil).
+		Returns(http.StatusBadGateway, DeadLetterFailed, nil).
+		Returns(http.StatusOK, "Delivered and forgotten", nil))
+
+	userService.Route(userService.
+		GET("/Admin/Diagnose/{userName}").
+		To(aService.adminDiagnose).
+		// Docs
+		Doc("Acquires the plan, sessions, collections, activity and storage of a user for support. Requires the admin key.").
+		Operation("adminDiagnose").
+		Param(userService.PathParameter("userName",
+			"The name that identifies a user to our service").DataType("string")).
+		Param(userService.HeaderParameter(adminKeyHeader,
+			"The admin key").DataType("string")).
+		Writes(UserDiagnosis{}).
+		Returns(http.StatusBadRequest, BadUserName, nil).
+		Returns(http.StatusUnauthorized, BadCredentials, nil).
+		Returns(http.StatusInternalServerError, DBfailure, nil).
+		Returns(http.StatusOK, "The user's diagnosis", nil))
 
+	userService.Route(userService.
+		POST("/Admin/Cards/Reload").
+		To(aService.adminReloadCards).
+		// Docs
+		Doc("Reloads the valid cards and sets from disk without a restart. Requires the admin key.").
+		Operation("adminReloadCards").
+		Reads(CardReloadBody{}).
+		Writes(CardReloadResult{}).
+		Returns(http.StatusBadRequest, BodyReadFailure, nil).
+		Returns(http.StatusUnauthorized, BadCredentials, nil).
+		Returns(http.StatusInternalServerError, CardReloadFailure, nil).
+		Returns(http.StatusOK, "The sizes of the reloaded card maps", nil))
+
+	userService.Route(userService.
+		POST("/Admin/Webhooks/Secret").
+		To(aService.changeWebhookSecret).
+		// Docs
+		Doc("Rotates to a fresh webhook signing secret or retires the previous one. Requires the admin key.").
+		Operation("changeWebhookSecret").
+		Reads(WebhookSecretBody{}).
+		Returns(http.StatusBadRequest, BodyReadFailure, nil).
+		Returns(http.StatusBadRequest, BadWebhookAction, nil).
+		Returns(http.StatusUnauthorized, BadCredentials, nil).
+		Returns(http.StatusInternalServerError, WebhookSecretFailure, nil).
+		Writes(true).
+		Returns(http.StatusOK, "Secret changed", nil))
 
 	aService.Service = userService
 
+	aService.registerHealth()
+	aService.registerMetrics()
+
 	return nil
 }
\ No newline at end of file
@@ -5,13 +5,89 @@ import(
 	"./userDBHandler"
 
 	"./mailer"
+	"./goGetPaid"
 
 	"github.com/emicklei/go-restful"
 
 	"net/http"
 
+	"os"
+	"strconv"
+	"time"
+
 )
 
+// Minimum hours between changes from one paid plan to another, stops
+// rapid flip-flopping from churning prorations. Zero disables it.
+const planCooldownEnv string = "USERS_PLAN_COOLDOWN_HOURS"
+
+const defaultPlanCooldown time.Duration = 24 * time.Hour
+
+func planCooldown() time.Duration {
+
+	raw:= os.Getenv(planCooldownEnv)
+	if raw == "" {
+		return defaultPlanCooldown
+	}
+
+	hours, err:= strconv.Atoi(raw)
+	if err!=nil || hours < 0 {
+		return defaultPlanCooldown
+	}
+
+	return time.Duration(hours) * time.Hour
+
+}
+
+// Identifies a user's subscription to stripe.
+func subRef(sub *userDB.Subscription) getPaid.SubRef {
+	return getPaid.SubRef{
+		CustomerID: sub.CustomerID,
+		SubID: sub.SubID,
+	}
+}
+
+// Describes a paid plan given its state on stripe.
+//
+// Payment is only invalid once stripe has tried and failed to
+// collect it.
+func subscriptionStatus(plan, interval string,
+	details getPaid.SubDetails) SubscriptionStatus {
+
+	return SubscriptionStatus{
+		Plan: plan,
+		Interval: interval,
+		PeriodEnd: details.PeriodEnd,
+		CancelAtPeriodEnd: details.CancelAtPeriodEnd,
+		PaymentValid: details.Status != "past_due" &&
+			details.Status != "unpaid",
+	}
+
+}
+
+// Rejects a plan change with the time remaining when it comes too
+// soon after the last one.
+//
+// Returns whether the change may go ahead.
+func (aService *UserService) checkPlanCooldown(resp *restful.Response,
+	sub *userDB.Subscription, plan string) bool {
+
+	err:= userDB.CheckPlanCooldown(sub, plan,
+		aService.planCooldown, time.Now())
+	cooldownErr, ok:= err.(userDB.PlanCooldownError)
+	if !ok {
+		return true
+	}
+
+	remaining:= cooldownErr.Remaining.Round(time.Second)
+	resp.AddHeader("Retry-After", strconv.Itoa(int(remaining / time.Second)))
+	resp.WriteErrorString(http.StatusConflict,
+		PlanCooldown + ", try again in " + remaining.String())
+
+	return false
+
+}
+
 type subEmailContents struct{
 	Name, Plan string
 }
@@ -38,9 +114,9 @@ func (aService *UserService) modSubUser(req *restful.Request,
 	}
 
 	// Grab the customer's identification.
-	sub, err:= userDB.GetSub(aService.pool, userName, subContainer.SessionKey)
+	sub, err:= userDB.GetSub(aService.db(), userName, subContainer.SessionKey)
 	if err!=nil {
-		resp.WriteErrorString(http.StatusBadRequest, DBfailure)
+		resp.WriteErrorString(dbFailure(err, BadUserName))
 		return
 	}
 	// Make sure they've signed up before
@@ -50,11 +126,18 @@ func (aService *UserService) modSubUser(req *restful.Request,
 		return
 	}
 
+	stripePlan, interval, ok:= aService.plans.stripePlan(subContainer.Plan,
+		subContainer.Interval)
+	if !ok {
+		resp.WriteErrorString(http.StatusBadRequest, BadPlanChoice)
+		return
+	}
+
 	// Make sure we aren't double charging them.
-	validChoice, err:= userDB.DifferentPlan(aService.pool,
-		userName, subContainer.Plan)
+	validChoice, err:= userDB.DifferentPlan(aService.db(),
+		userName, subContainer.Plan, interval)
 	if err!=nil {
-		resp.WriteErrorString(http.StatusBadRequest, DBfailure)
+		resp.WriteErrorString(dbFailure(err, BadUserName))
 		return
 	}
 	if !validChoice {
@@ -62,36 +145,49 @@ func (aService *UserService) modSubUser(req *restful.Request,
 		return
 	}
 
+	// Checked before stripe as we can't take back a change there
+	if !aService.checkPlanCooldown(resp, sub, subContainer.Plan) {
+		return
+	}
+
 	// Change the customer's payment method to the one they just provided
 	err = aService.merch.UpdateCustomer(sub.CustomerID,
 		subContainer.PaymentMethod)
+	aService.metrics.stripeCall("updateCustomer", err)
 	if err!=nil {
-		resp.WriteErrorString(http.StatusBadRequest, StripeCustFailure)
+		resp.WriteErrorString(stripeFailure(err, StripeCustFailure))
 		return
 	}
 
-	// Change the user's subscription status
-	err = aService.merch.UpdateSubCustomer(sub.CustomerID, sub.SubID,
-		subContainer.Plan)
+	// Change the user's subscription status, stripe prorates
+	// switches between intervals as it does between tiers
+	err = aService.merch.UpdateSubCustomer(subRef(sub), stripePlan)
+	aService.metrics.stripeCall("updateSubCustomer", err)
 	if err!=nil {
-		resp.WriteErrorString(http.StatusBadRequest, StripeSubFailure)
+		resp.WriteErrorString(stripeFailure(err, StripeSubFailure))
 		return
 	}
 
 	// Retain everything regarding their subscription apart from the plan
 	// and its various effects.
 	//
-	// Notice that we IGNORE the error as we want to send the user
+	// Notice that we continue past an error as we want to send the user
 	// the following email to ensure they can contact us if we fail
-	// to change the DB but already have stripe charging them.
-	_ = userDB.ModSub(aService.pool,
-		userName, subContainer.Plan,
+	// to change the DB but already have stripe charging them. It is
+	// logged so the two can be reconciled.
+	err = userDB.ModSub(aService.db(),
+		userName, subContainer.Plan, interval,
 		sub.CustomerID, sub.SubID, nil)
+	if err!=nil {
+		logAt(aService.logger, levelError, requestContext(req),
+			"stripe changed but failed to change sub of",
+			userName, subContainer.Plan, sub.CustomerID, sub.SubID, err)
+	}
 
 	// Grab their email so we can let them know
-	u, err:= userDB.GetUser(aService.pool, userName)
+	u, err:= userDB.GetUser(aService.db(), userName)
 	if err!=nil {
-		resp.WriteErrorString(http.StatusBadRequest, DBfailure)
+		resp.WriteErrorString(dbFailure(err, BadUserName))
 		return
 	}
 
@@ -101,10 +197,11 @@ func (aService *UserService) modSubUser(req *restful.Request,
 		Plan: subContainer.Plan,
 	}
 	targetAddress:= mailer.FormatAddress(userName, u.Email)
-	err = aService.mailer.SendPrepared("subSuccess", contents,
+	err = aService.sendMail("subSuccess", contents,
 		targetAddress, "Subscribed! - Preorda.in")
 	if err!=nil {
-		aService.logger.Println("failed to send email", err)
+		logAt(aService.logger, levelError, requestContext(req),
+			"failed to send email", err)
 	}
 
 
@@ -133,17 +230,24 @@ func (aService *UserService) addSubUser(req *restful.Request,
 	}
 
 	// Grab their email
-	u, err:= userDB.GetUser(aService.pool, userName)
+	u, err:= userDB.GetUser(aService.db(), userName)
 	if err!=nil {
-		resp.WriteErrorString(http.StatusBadRequest, DBfailure)
+		resp.WriteErrorString(dbFailure(err, BadUserName))
+		return
+	}
+
+	stripePlan, interval, ok:= aService.plans.stripePlan(subContainer.Plan,
+		subContainer.Interval)
+	if !ok {
+		resp.WriteErrorString(http.StatusBadRequest, BadPlanChoice)
 		return
 	}
 
 	// Make sure we aren't double charging them.
-	validChoice, err:= userDB.DifferentPlan(aService.pool,
-		userName, subContainer.Plan)
+	validChoice, err:= userDB.DifferentPlan(aService.db(),
+		userName, subContainer.Plan, interval)
 	if err!=nil {
-		resp.WriteErrorString(http.StatusBadRequest, DBfailure)
+		resp.WriteErrorString(dbFailure(err, BadUserName))
 		return
 	}
 	if !validChoice {
@@ -152,38 +256,73 @@ func (aService *UserService) addSubUser(req *restful.Request,
 	}
 
 	// Grab the customer's identification.
-	sub, err:= userDB.GetSub(aService.pool, userName, subContainer.SessionKey)
+	sub, err:= userDB.GetSub(aService.db(), userName, subContainer.SessionKey)
 	if err!=nil {
-		resp.WriteErrorString(http.StatusBadRequest, DBfailure)
+		resp.WriteErrorString(dbFailure(err, BadUserName))
 		return
 	}
 
+	// Only checked once authenticated so it can't be probed
+	if !u.Verified {
+		resp.WriteErrorString(http.StatusForbidden, EmailUnverified)
+		return
+	}
+
+	if !aService.checkPlanCooldown(resp, sub, subContainer.Plan) {
+		return
+	}
+
+	// A bogus coupon would otherwise only surface as a failure
+	// to create the customer
+	if subContainer.Coupon != "" {
+		validCoupon, err:= aService.merch.ValidateCoupon(subContainer.Coupon)
+		aService.metrics.stripeCall("validateCoupon", err)
+		if err!=nil {
+			logAt(aService.logger, levelWarn, requestContext(req),
+				"failed to validate coupon, ", err)
+			resp.WriteErrorString(stripeFailure(err, StripeCustFailure))
+			return
+		}
+		if !validCoupon {
+			resp.WriteErrorString(http.StatusBadRequest, BadCoupon)
+			return
+		}
+	}
+
 	// Check if we need to add them as a customer
 	custID:= sub.CustomerID
 	if custID == userDB.DefaultID {
 		// Add them as a customer as needed
 		custID, err = aService.merch.AddCustomer(subContainer.PaymentMethod,
 			u.Email, subContainer.Coupon)
+		aService.metrics.stripeCall("addCustomer", err)
 		if err!=nil {
-			resp.WriteErrorString(http.StatusBadRequest, StripeCustFailure)
+			resp.WriteErrorString(stripeFailure(err, StripeCustFailure))
 			return
 		}	
 	}
 
 	// Add them as a subscriber.
-	subID, err:= aService.merch.SubCustomer(custID, subContainer.Plan)
+	subID, err:= aService.merch.SubCustomer(custID, stripePlan)
+	aService.metrics.stripeCall("subCustomer", err)
 	if err!=nil {
-		resp.WriteErrorString(http.StatusBadRequest, StripeSubFailure)
+		resp.WriteErrorString(stripeFailure(err, StripeSubFailure))
 		return
 	}
 
 	// Now update their entries in the database.
 	//
-	// Notice that we IGNORE the error as we want to send the user
+	// Notice that we continue past an error as we want to send the user
 	// the following email to ensure they can contact us if we fail
-	// to change the DB but already have stripe charging them.
-	userDB.ModSub(aService.pool, userName, subContainer.Plan,
+	// to change the DB but already have stripe charging them. It is
+	// logged so the two can be reconciled.
+	err = userDB.ModSub(aService.db(), userName, subContainer.Plan, interval,
 		custID, subID, subContainer.SessionKey)
+	if err!=nil {
+		logAt(aService.logger, levelError, requestContext(req),
+			"stripe subscribed but failed to add sub of",
+			userName, subContainer.Plan, custID, subID, err)
+	}
 
 
 	// Email them that we were successful!
@@ -192,10 +331,11 @@ func (aService *UserService) addSubUser(req *restful.Request,
 		Plan: subContainer.Plan,
 	}
 	targetAddress:= mailer.FormatAddress(userName, u.Email)
-	err = aService.mailer.SendPrepared("subSuccess", contents,
+	err = aService.sendMail("subSuccess", contents,
 		targetAddress, "Subscribed! - Preorda.in")
 	if err!=nil {
-		aService.logger.Println("failed to send email", err)
+		logAt(aService.logger, levelError, requestContext(req),
+			"failed to send email", err)
 	}
 
 
@@ -224,33 +364,40 @@ func (aService *UserService) unSubUser(req *restful.Request,
 	}
 
 	// Grab the customer's identification.
-	sub, err:= userDB.GetSub(aService.pool, userName, subContainer.SessionKey)
+	sub, err:= userDB.GetSub(aService.db(), userName, subContainer.SessionKey)
 	if err!=nil {
-		resp.WriteErrorString(http.StatusBadRequest, DBfailure)
+		resp.WriteErrorString(dbFailure(err, BadUserName))
+		return
+	}
+	// Free plans have nothing on stripe to cancel
+	if sub.SubID == userDB.DefaultID {
+		resp.WriteErrorString(http.StatusBadRequest, BadPlanChoice)
 		return
 	}
 
 	// Remove their subscription but retain their customerID
-	err = aService.merch.UnSubCustomer(sub.SubID, sub.CustomerID)
+	err = aService.merch.UnSubCustomer(subRef(sub))
+	aService.metrics.stripeCall("unSubCustomer", err)
 	if err!=nil {
-		resp.WriteErrorString(http.StatusBadRequest, StripeSubFailure)
+		resp.WriteErrorString(stripeFailure(err, StripeSubFailure))
 		return
 	}
 
 	// Mod the sub to be the default free version.
 	//
 	// Set dummy sub ID but hold onto that customerID
-	err = userDB.ModSub(aService.pool, userName, userDB.DefaultSubLevel,
-		sub.CustomerID, userDB.DefaultID, subContainer.SessionKey)
+	err = userDB.ModSub(aService.db(), userName, userDB.DefaultSubLevel,
+		userDB.MonthlyInterval, sub.CustomerID, userDB.DefaultID,
+		subContainer.SessionKey)
 	if err!=nil {
-		resp.WriteErrorString(http.StatusBadRequest, DBWriteFailure)
+		resp.WriteErrorString(dbFailure(err, DBWriteFailure))
 		return
 	}
 
 	// Grab their email so we can let them know
-	u, err:= userDB.GetUser(aService.pool, userName)
+	u, err:= userDB.GetUser(aService.db(), userName)
 	if err!=nil {
-		resp.WriteErrorString(http.StatusBadRequest, DBfailure)
+		resp.WriteErrorString(dbFailure(err, BadUserName))
 		return
 	}
 
@@ -260,10 +407,11 @@ func (aService *UserService) unSubUser(req *restful.Request,
 		Plan: subContainer.Plan,
 	}
 	targetAddress:= mailer.FormatAddress(userName, u.Email)
-	err = aService.mailer.SendPrepared("unSubSuccess", contents,
+	err = aService.sendMail("unSubSuccess", contents,
 		targetAddress, "unSubscribed! - Preorda.in")
 	if err!=nil {
-		aService.logger.Println("failed to send email", err)
+		logAt(aService.logger, levelError, requestContext(req),
+			"failed to send email", err)
 	}
 
 	resp.WriteEntity(true)
@@ -285,12 +433,31 @@ func (aService *UserService) getSubUser(req *restful.Request,
 		return
 	}
 
-	s, err:= userDB.GetSub(aService.pool, userName, sessionKey)
+	s, err:= userDB.GetSub(aService.db(), userName, sessionKey)
 	if err!=nil {
-		resp.WriteErrorString(http.StatusBadRequest, BadCredentials)
+		resp.WriteErrorString(dbFailure(err, BadCredentials))
+		return
+	}
+
+	// Free plans have nothing on stripe to ask about
+	if s.SubID == userDB.DefaultID {
+		resp.WriteEntity(SubscriptionStatus{
+			Plan: s.Plan,
+			Interval: s.Interval,
+			PaymentValid: true,
+		})
+		return
+	}
+
+	details, err:= aService.merch.GetSubCustomer(subRef(s))
+	aService.metrics.stripeCall("getSubCustomer", err)
+	if err!=nil {
+		logAt(aService.logger, levelError, requestContext(req),
+			"failed to get sub details of", userName, s.SubID, err)
+		resp.WriteErrorString(stripeFailure(err, StripeSubFailure))
 		return
 	}
 
-	resp.WriteEntity(s.Plan)
+	resp.WriteEntity(subscriptionStatus(s.Plan, s.Interval, details))
 
 }
\ No newline at end of file
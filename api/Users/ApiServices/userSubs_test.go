@@ -0,0 +1,36 @@
+package ApiServices
+
+import(
+
+	"./goGetPaid"
+
+	"testing"
+
+	"time"
+
+)
+
+func TestSubscriptionStatus(t *testing.T) {
+
+	end:= time.Now().Add(24 * time.Hour)
+
+	status:= subscriptionStatus("Preordain", "year", getPaid.SubDetails{
+		PeriodEnd: end,
+		CancelAtPeriodEnd: true,
+		Status: "active",
+	})
+	if status.Plan != "Preordain" || status.Interval != "year" ||
+		!status.PeriodEnd.Equal(end) ||
+		!status.CancelAtPeriodEnd || !status.PaymentValid {
+		t.Fatal("incorrect status", status)
+	}
+
+	for _, failing:= range []string{"past_due", "unpaid"}{
+		status = subscriptionStatus("Preordain", "month",
+			getPaid.SubDetails{Status: failing})
+		if status.PaymentValid {
+			t.Fatal("payment valid while", failing)
+		}
+	}
+
+}
@@ -4,6 +4,8 @@ import(
 
 	"./userDBHandler"
 
+	"time"
+
 )
 
 // While gross, having a struct for each request lets me keep this as a json
@@ -13,10 +15,16 @@ type NewUserData struct{
 	Email, Password string
 	RecaptchaResponseField string
 
+	// Optional unless USERS_REQUIRE_EMAIL_CONFIRMATION is set
+	EmailConfirmation string
+
 }
 
 type PasswordBody struct{
 	Password string
+
+	// Optional until the account has seen repeated failed logins
+	Captcha string
 }
 
 type SessionKeyBody struct{
@@ -26,12 +34,108 @@ type SessionKeyBody struct{
 type PermissionChangeBody struct{
 	SessionKey []byte
 	Privacy string
+	PublicComments bool
+	// The collection's Version the change was made against, zero to
+	// make it against whichever is current
+	Version int64
+}
+
+type EnsureCollectionResponse struct{
+	AlreadyExisted bool
+}
+
+type PreferencesBody struct{
+	SessionKey []byte
+	Currency, Locale string
+}
+
+// Offset and Limit may be left out for the first page of
+// defaultCollectionPage collections.
+// Tag, when set, lists only the collections carrying it in any case
+type CollectionListBody struct{
+	SessionKey []byte
+	Offset, Limit int
+	Tag string
+}
+
+type LogoutAllBody struct{
+	SessionKey []byte
+	// Spares the session making the request
+	KeepCurrent bool
+}
+
+type CollectionRenameBody struct{
+	SessionKey []byte
+	Name string
+	// As with PermissionChangeBody
+	Version int64
+}
+
+// Tags replace every tag the collection had, empty removes them all
+type CollectionTagsBody struct{
+	SessionKey []byte
+	Tags []string
+	// As with PermissionChangeBody
+	Version int64
+}
+
+// Data is the deck list itself, in Format of dec or list
+type CollectionImportBody struct{
+	SessionKey []byte
+	Format string
+	Data string
+	// As with PermissionChangeBody
+	Version int64
+}
+
+type CollectionImportResponse struct{
+	// Distinct printings added by the import's trade
+	Imported int
+	// Lines which couldn't be parsed or named no known card
+	Skipped []string
+}
+
+type LockChangeBody struct{
+	SessionKey []byte
+	Locked bool
+}
+
+// Empty values clear a collection's valuation defaults
+type CollectionValuationBody struct{
+	SessionKey []byte
+	Currency, Basis string
 }
 
 type TradeAddBody struct{
 
 	Trade []userDB.Card
 	SessionKey []byte
+	// As with PermissionChangeBody
+	Version int64
+
+}
+
+type TradeBulkAddBody struct{
+
+	Trades [][]userDB.Card
+	SessionKey []byte
+	// As with PermissionChangeBody
+	Version int64
+
+}
+
+// A trade of a bulk add which failed validation, Index is its
+// position in the request.
+type BulkTradeFailure struct{
+	Index int
+	Error string
+}
+
+type TradeCopyBody struct{
+
+	// Collection receiving the copy, may be the source collection
+	Destination string
+	SessionKey []byte
 
 }
 
@@ -41,6 +145,19 @@ type PasswordResetRequestBody struct{
 
 }
 
+type VerifyEmailBody struct{
+	VerifyCode string
+}
+
+type EmailChangeBody struct{
+	SessionKey []byte
+	Email string
+}
+
+type EmailChangeConfirmBody struct{
+	ChangeCode string
+}
+
 type PasswordResetBody struct{
 
 	Password string
@@ -48,12 +165,130 @@ type PasswordResetBody struct{
 
 }
 
+type PasswordChangeBody struct{
+	SessionKey []byte
+	OldPassword, NewPassword string
+}
+
 type CollectionContents struct{
 	Current []userDB.Card
 	Historical []userDB.Card
+	Tags []string
+}
+
+// Currency is optional, falling back to the collection's and then
+// the owner's preference. Any currency with prices of its own or a
+// rate in USERS_CURRENCY_RATES may be requested.
+type CollectionGetBody struct{
+	SessionKey []byte
+	Currency string
+}
+
+// The net quantity held of a single card with specific attributes.
+type CardQuantity struct{
+	Name, Set, Quality, Lang string
+	Quantity int32
 }
 
 type SubBody struct{
 	Plan, PaymentMethod, Coupon string
+	// How often the plan is billed, monthly when empty
+	Interval string
+	SessionKey []byte
+}
+
+// A plan as advertised to clients.
+type Plan struct{
+	Name string
+	// How often the plan is billed, one of userDB.BillingIntervals
+	Interval string
+	// Cents charged each interval, zero for the free plan
+	Price int
+	// How many collections the plan may own
+	Collections int
+	// Which features the plan grants, by name
+	Features map[string]bool
+}
+
+// A user's plan alongside its billing state.
+//
+// Users on the free plan have no period and nothing to pay, so
+// PaymentValid is always set for them.
+type SubscriptionStatus struct{
+	Plan string
+	Interval string
+	PeriodEnd time.Time
+	CancelAtPeriodEnd bool
+	// Unset when stripe has failed to collect payment
+	PaymentValid bool
+}
+
+type ForceRehashBody struct{
+
+	UserName string
+	AdminKey string
+
+}
+
+type DeadLetterBody struct{
+
+	AdminKey string
+
+}
+
+type CardReloadBody struct{
+
+	AdminKey string
+
+}
+
+type PlanCooldownWaiverBody struct{
+
+	UserName string
+	AdminKey string
+
+}
+
+type BulkAddBody struct{
+
+	Users []userDB.NewUserSpec
+	AdminKey string
+
+}
+
+type WebhookRegisterBody struct{
 	SessionKey []byte
-}
\ No newline at end of file
+	URL string
+}
+
+// Percent and Threshold are as with userDB.Alert, at least one is set
+type AlertAddBody struct{
+	SessionKey []byte
+	Collection string
+	Percent, Threshold int32
+}
+
+type WebhookSecretBody struct{
+
+	Action string
+	AdminKey string
+
+}
+
+// Status is ok when every component is, components are left out of
+// liveness as it checks none.
+type HealthStatus struct{
+	Status string
+	Components map[string]string `json:",omitempty"`
+}
+
+// A session is identified by its ID, its key is never sent back out.
+//
+// LastUsed is approximate to within a few minutes. Current is set
+// for the session which made the request.
+type SessionInfo struct{
+	ID string
+	StartValid, EndValid time.Time
+	LastUsed time.Time
+	Current bool
+}
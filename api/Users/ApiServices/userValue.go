@@ -0,0 +1,656 @@
+package ApiServices
+
+import(
+
+	"./userDBHandler"
+	"./../../../common/priceDB"
+
+	"github.com/emicklei/go-restful"
+
+	"net/http"
+
+	"container/heap"
+	"sort"
+	"strconv"
+
+)
+
+// Where we value collections from unless told otherwise
+const defaultPriceSource string = priceDB.Mtgprice
+
+// Collections can be valued at what they would cost to buy or
+// at what vendors would pay for them.
+const marketBasis string = "market"
+const sellBasis string = "sell"
+
+const sellPriceSource string = priceDB.Buylist
+
+// How many cards TopCards returns without an explicit n and
+// the most it will ever return.
+const defaultTopCards int = 10
+const maxTopCards int = 100
+
+// When no true foil price exists, foils are valued at this
+// multiple of the nonfoil price.
+const fallbackFoilMultiplier int32 = 2
+
+// Identifies a single printing of a card for pricing purposes.
+//
+// Set is always the nonfoil set, foil and nonfoil prices are
+// carried together.
+type printing struct{
+	Name, Set string
+}
+
+// Foil printings are traded under their own ' Foil' set.
+func isFoil(aCard userDB.Card) bool {
+	return priceDB.IsFoilSet(aCard.Set)
+}
+
+func printingOf(aCard userDB.Card) printing {
+	return printing{aCard.Name, priceDB.NonfoilSet(aCard.Set)}
+}
+
+// Picks the price for a card from its printing's prices.
+//
+// Foils use their own price, falling back to a multiple of the
+// nonfoil price only when no true foil price exists.
+func priceFor(aCard userDB.Card,
+	prices map[printing]priceDB.PrintingPrice) (int32, bool) {
+
+	p, ok:= prices[printingOf(aCard)]
+	if !ok {
+		return 0, false
+	}
+
+	if isFoil(aCard) {
+		if p.HasFoil {
+			return p.Foil.Price, true
+		}
+		if p.HasNonfoil {
+			return p.Nonfoil.Price * fallbackFoilMultiplier, true
+		}
+		return 0, false
+	}
+
+	if p.HasNonfoil {
+		return p.Nonfoil.Price, true
+	}
+
+	return 0, false
+
+}
+
+// A card alongside its price and the total value of all copies held.
+//
+// Prices are in cents. Fallback is set when a card valued at sell
+// prices had no buylist price and was valued at market instead.
+//
+// FormattedValue is Value as shown in the preferred locale.
+type CardValue struct{
+	userDB.Card
+	Price int32
+	Value int64
+	Fallback bool
+	FormattedValue string
+}
+
+// The most valuable cards in a collection. Cards we could not find a
+// price for are listed separately rather than guessed at.
+//
+// BulkValue totals cards priced below the requested minimum value,
+// they are otherwise left out.
+type TopCardsResponse struct{
+	Top []CardValue
+	Unpriced []userDB.Card
+	BulkValue int64
+	Currency string
+}
+
+// Fetches the latest foil and nonfoil prices for every distinct
+// printing among cards in a single query.
+//
+// Printings without any price are absent from the result, all of
+// them when the query fails.
+func (aService *UserService) latestPrices(cards []userDB.Card,
+	source string) map[printing]priceDB.PrintingPrice {
+
+	prices:= make(map[printing]priceDB.PrintingPrice)
+	if len(cards) == 0 {
+		return prices
+	}
+
+	wanted:= make([]priceDB.Printing, len(cards))
+	for i, aCard:= range cards{
+		wanted[i] = priceDB.Printing{Name: aCard.Name, Set: aCard.Set}
+	}
+
+	latest, err:= priceDB.GetPrintingsLatest(aService.pricePool,
+		wanted, source)
+	if err!=nil {
+		logAt(aService.logger, levelError, logContext{},
+			"failed to fetch latest prices", err)
+		return prices
+	}
+
+	for p, price:= range latest{
+		prices[printing{p.Name, p.Set}] = price
+	}
+
+	return prices
+
+}
+
+// Acquires the price from source closest to each request with a
+// single query, keyed by the position of the request.
+//
+// Failing to fetch them leaves every request unpriced.
+func (aService *UserService) closestPrices(requests []priceDB.PrintingAt,
+	source string) map[int]priceDB.Price {
+
+	if len(requests) == 0 {
+		return make(map[int]priceDB.Price)
+	}
+
+	closest, err:= priceDB.GetCardsClosest(aService.pricePool,
+		requests, source)
+	if err!=nil {
+		logAt(aService.logger, levelError, logContext{},
+			"failed to fetch closest prices", err)
+		return make(map[int]priceDB.Price)
+	}
+
+	return closest
+
+}
+
+// Values each held card using the provided prices.
+//
+// Cards with no copies held are dropped and cards without a price are
+// returned separately.
+func valueCards(cards []userDB.Card,
+	prices map[printing]priceDB.PrintingPrice) (valued []CardValue,
+	unpriced []userDB.Card) {
+
+	for _, aCard:= range cards{
+		if aCard.Quantity <= 0 {
+			continue
+		}
+
+		price, ok:= priceFor(aCard, prices)
+		if !ok {
+			unpriced = append(unpriced, aCard)
+			continue
+		}
+
+		valued = append(valued, CardValue{
+			Card: aCard,
+			Price: price,
+			Value: int64(price) * int64(aCard.Quantity),
+		})
+	}
+
+	return
+
+}
+
+// Values each held card at sell prices.
+//
+// Cards without a sell price fall back to their market price and
+// are flagged as such, cards with neither are returned separately.
+func valueCardsSell(cards []userDB.Card,
+	sell, market map[printing]priceDB.PrintingPrice) (valued []CardValue,
+	unpriced []userDB.Card) {
+
+	for _, aCard:= range cards{
+		if aCard.Quantity <= 0 {
+			continue
+		}
+
+		fallback:= false
+		price, ok:= priceFor(aCard, sell)
+		if !ok {
+			fallback = true
+			price, ok = priceFor(aCard, market)
+		}
+		if !ok {
+			unpriced = append(unpriced, aCard)
+			continue
+		}
+
+		valued = append(valued, CardValue{
+			Card: aCard,
+			Price: price,
+			Value: int64(price) * int64(aCard.Quantity),
+			Fallback: fallback,
+		})
+	}
+
+	return
+
+}
+
+// Values cards on the requested basis in currency.
+func (aService *UserService) valueOnBasis(cards []userDB.Card,
+	basis, currency string) ([]CardValue, []userDB.Card) {
+
+	market:= aService.marketPrices(cards, currency)
+	if basis != sellBasis {
+		return valueCards(cards, market)
+	}
+
+	sell:= aService.sellPrices(cards, currency)
+	return valueCardsSell(cards, sell, market)
+
+}
+
+// Fills in the formatted value of each card.
+func formatValues(valued []CardValue, prefs valuationPrefs) {
+	for i:= range valued{
+		valued[i].FormattedValue = formatCents(valued[i].Value, prefs)
+	}
+}
+
+// Separates out cards priced below minValue as bulk, returning
+// the rest alongside the total value of the bulk.
+//
+// A minValue of zero keeps every card.
+func excludeBulk(valued []CardValue,
+	minValue int32) (kept []CardValue, bulkValue int64) {
+
+	if minValue <= 0 {
+		return valued, 0
+	}
+
+	kept = make([]CardValue, 0, len(valued))
+	for _, v:= range valued{
+		if v.Price < minValue {
+			bulkValue+= v.Value
+			continue
+		}
+		kept = append(kept, v)
+	}
+
+	return
+
+}
+
+// Returns whether a is more valuable than b.
+//
+// Ties are broken by name, set, quality, then language so
+// the ordering is always deterministic.
+func moreValuable(a, b CardValue) bool {
+
+	if a.Value != b.Value {
+		return a.Value > b.Value
+	}
+	if a.Name != b.Name {
+		return a.Name < b.Name
+	}
+	if a.Set != b.Set {
+		return a.Set < b.Set
+	}
+	if a.Quality != b.Quality {
+		return a.Quality < b.Quality
+	}
+	return a.Lang < b.Lang
+
+}
+
+// Sorts cards with the most valuable first.
+type byValue []CardValue
+
+func (v byValue) Len() int { return len(v) }
+func (v byValue) Less(i, j int) bool { return moreValuable(v[i], v[j]) }
+func (v byValue) Swap(i, j int) { v[i], v[j] = v[j], v[i] }
+
+// A heap with the least valuable card on top, lets us keep
+// only the n most valuable cards seen so far.
+type leastValuableHeap []CardValue
+
+func (h leastValuableHeap) Len() int { return len(h) }
+
+func (h leastValuableHeap) Less(i, j int) bool {
+	return moreValuable(h[j], h[i])
+}
+
+func (h leastValuableHeap) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+
+func (h *leastValuableHeap) Push(x interface{}) {
+	*h = append(*h, x.(CardValue))
+}
+
+func (h *leastValuableHeap) Pop() interface{} {
+	old:= *h
+	n:= len(old)
+	x:= old[n - 1]
+	*h = old[:n - 1]
+	return x
+}
+
+// Returns the n most valuable cards, most valuable first.
+//
+// Only a partial sort is performed so large collections stay cheap.
+func topCards(valued []CardValue, n int) []CardValue {
+
+	if n <= 0 {
+		return []CardValue{}
+	}
+
+	h:= make(leastValuableHeap, 0, n)
+	for _, aCard:= range valued{
+		if h.Len() < n {
+			heap.Push(&h, aCard)
+			continue
+		}
+		if moreValuable(aCard, h[0]) {
+			h[0] = aCard
+			heap.Fix(&h, 0)
+		}
+	}
+
+	top:= byValue(h)
+	sort.Sort(top)
+
+	return top
+
+}
+
+// Acquires the most valuable cards in a public collection
+func (aService *UserService) getTopCards(req *restful.Request,
+	resp *restful.Response) {
+
+	userName:= req.PathParameter("userName")
+	collectionName:= req.PathParameter("collectionName")
+
+	n:= defaultTopCards
+	if raw:= req.QueryParameter("n"); raw != "" {
+		parsed, err:= strconv.Atoi(raw)
+		if err!=nil || parsed <= 0 || parsed > maxTopCards {
+			resp.WriteErrorString(http.StatusBadRequest, BadTopCount)
+			return
+		}
+		n = parsed
+	}
+
+	minValue, ok:= parseMinValue(req)
+	if !ok {
+		resp.WriteErrorString(http.StatusBadRequest, BadMinValue)
+		return
+	}
+
+	meta, visible, err:= aService.publicContents(userName, collectionName)
+	if err!=nil {
+		status, message:= collectionFailure(err, true)
+		resp.WriteErrorString(status, message)
+		return
+	}
+
+	currency, basis:= withCollectionDefaults(req.QueryParameter("currency"),
+		req.QueryParameter("basis"), meta)
+
+	basis, ok = parseBasis(basis)
+	if !ok {
+		resp.WriteErrorString(http.StatusBadRequest, BadBasis)
+		return
+	}
+
+	prefs, ok:= aService.requestPrefs(req, userName, currency)
+	if !ok {
+		resp.WriteErrorString(http.StatusBadRequest, BadPreferences)
+		return
+	}
+
+	valued, unpriced:= aService.valueOnBasis(visible, basis, prefs.Currency)
+	valued, bulkValue:= excludeBulk(valued, minValue)
+
+	top:= topCards(valued, n)
+	formatValues(top, prefs)
+
+	resp.WriteEntity(TopCardsResponse{
+		Top: top,
+		Unpriced: unpriced,
+		BulkValue: bulkValue,
+		Currency: prefs.Currency,
+	})
+
+}
+
+// Validates a valuation basis, defaulting to market.
+func parseBasis(basis string) (string, bool) {
+
+	switch basis {
+	case "":
+		return marketBasis, true
+	case marketBasis, sellBasis:
+		return basis, true
+	}
+
+	return "", false
+
+}
+
+// Reads the minimum card value, in cents, from a request. Absent
+// means no minimum.
+func parseMinValue(req *restful.Request) (int32, bool) {
+
+	raw:= req.QueryParameter("minValue")
+	if raw == "" {
+		return 0, true
+	}
+
+	parsed, err:= strconv.ParseInt(raw, 10, 32)
+	if err!=nil || parsed < 0 {
+		return 0, false
+	}
+
+	return int32(parsed), true
+
+}
+
+// Acquires only the contents of a collection the public may see,
+// alongside its metadata.
+func (aService *UserService) publicContents(userName,
+	collectionName string) (*userDB.Collection, []userDB.Card, error) {
+
+	meta, err:= userDB.GetCollectionMeta(aService.db(),
+		nil, userName, collectionName)
+	if err!=nil {
+		return nil, nil, err
+	}
+
+	current, err:= userDB.GetCollectionContents(aService.db(),
+		nil, userName, collectionName)
+	if err!=nil {
+		return nil, nil, err
+	}
+
+	// Private collections are reported as missing ones are
+	visible, err:= publicView(meta, current, nil)
+	if err!=nil {
+		return nil, nil, userDB.ErrBadSession
+	}
+
+	return meta, visible.Current, nil
+
+}
+
+// A collection alongside the market value of its current contents.
+//
+// TotalValue is in cents of defaultCurrency, which prices are natively
+// recorded in, while ConvertedValue is the value in Currency. That is
+// drawn from Currency's own prices when it has them, as EUR does, and
+// converted from TotalValue at the rate table otherwise.
+// Held cards we have no price for are named in Unpriced rather than
+// failing the valuation.
+type ValuedCollectionContents struct{
+	CollectionContents
+	// The collection's version when read, sent back with changes so
+	// they are refused if another landed in the meantime
+	Version int64
+	TotalValue int64
+	Currency string
+	ConvertedValue int64
+	FormattedValue string
+	Unpriced []string
+}
+
+// Totals the market value of held cards, returning the distinct
+// names of those without a price.
+func totalValue(cards []userDB.Card,
+	prices map[printing]priceDB.PrintingPrice) (int64, []string) {
+
+	valued, unpriced:= valueCards(cards, prices)
+
+	var total int64
+	for _, v:= range valued{
+		total+= v.Value
+	}
+
+	names:= make([]string, 0, len(unpriced))
+	seen:= make(map[string]bool)
+	for _, aCard:= range unpriced{
+		if !seen[aCard.Name] {
+			seen[aCard.Name] = true
+			names = append(names, aCard.Name)
+		}
+	}
+
+	return total, names
+
+}
+
+// Picks the currency an owner's collection is valued in.
+//
+// A requested currency must either have prices of its own in
+// currencySources or a rate, anything else is refused. preferred is
+// resolved by resolvePrefs so always has prices of its own.
+func conversionCurrency(requested, preferred string,
+	rates map[string]float64) (string, bool) {
+
+	if requested == "" {
+		return preferred, true
+	}
+
+	if _, native:= currencySources[requested]; native {
+		return requested, true
+	}
+
+	_, ok:= rates[requested]
+	return requested, ok
+
+}
+
+// Values held cards in currency.
+//
+// Currencies with prices of their own are valued from them, exactly
+// as Stats and TopCards value them. Others convert total, the value in
+// defaultCurrency, at the rate table.
+func (aService *UserService) convertedValue(cards []userDB.Card,
+	total int64, currency string, rates map[string]float64) int64 {
+
+	if currency == defaultCurrency {
+		return total
+	}
+
+	if _, native:= currencySources[currency]; native {
+		converted, _:= totalValue(cards, aService.marketPrices(cards, currency))
+		return converted
+	}
+
+	converted, _:= convertCents(total, currency, rates)
+	return converted
+
+}
+
+// Totals for a collection at both market and sell prices.
+//
+// Spread is what would be lost selling the collection to a vendor
+// rather than buying it at retail. SellFallbacks counts cards valued
+// at market for want of a sell price.
+//
+// Cards priced below the requested minimum value are left out of
+// the totals and summed into the bulk values instead.
+type CollectionStats struct{
+	MarketValue int64
+	SellValue int64
+	Spread int64
+	SellFallbacks int
+	Unpriced int
+
+	BulkMarketValue int64
+	BulkSellValue int64
+
+	Currency string
+	FormattedMarketValue, FormattedSellValue string
+}
+
+func collectionStats(cards []userDB.Card,
+	sell, market map[printing]priceDB.PrintingPrice,
+	minValue int32) CollectionStats {
+
+	var stats CollectionStats
+
+	marketValued, unpriced:= valueCards(cards, market)
+	marketValued, stats.BulkMarketValue = excludeBulk(marketValued, minValue)
+	for _, v:= range marketValued{
+		stats.MarketValue+= v.Value
+	}
+	stats.Unpriced = len(unpriced)
+
+	sellValued, _:= valueCardsSell(cards, sell, market)
+	sellValued, stats.BulkSellValue = excludeBulk(sellValued, minValue)
+	for _, v:= range sellValued{
+		stats.SellValue+= v.Value
+		if v.Fallback {
+			stats.SellFallbacks++
+		}
+	}
+
+	stats.Spread = stats.MarketValue - stats.SellValue
+
+	return stats
+
+}
+
+// Acquires the market and sell totals of a public collection
+func (aService *UserService) getCollectionStats(req *restful.Request,
+	resp *restful.Response) {
+
+	userName:= req.PathParameter("userName")
+	collectionName:= req.PathParameter("collectionName")
+
+	minValue, ok:= parseMinValue(req)
+	if !ok {
+		resp.WriteErrorString(http.StatusBadRequest, BadMinValue)
+		return
+	}
+
+	meta, visible, err:= aService.publicContents(userName, collectionName)
+	if err!=nil {
+		status, message:= collectionFailure(err, true)
+		resp.WriteErrorString(status, message)
+		return
+	}
+
+	// Both bases are always totalled so only the currency applies
+	currency, _:= withCollectionDefaults(req.QueryParameter("currency"),
+		"", meta)
+
+	prefs, ok:= aService.requestPrefs(req, userName, currency)
+	if !ok {
+		resp.WriteErrorString(http.StatusBadRequest, BadPreferences)
+		return
+	}
+
+	market:= aService.marketPrices(visible, prefs.Currency)
+	sell:= aService.sellPrices(visible, prefs.Currency)
+
+	stats:= collectionStats(visible, sell, market, minValue)
+	stats.Currency = prefs.Currency
+	stats.FormattedMarketValue = formatCents(stats.MarketValue, prefs)
+	stats.FormattedSellValue = formatCents(stats.SellValue, prefs)
+
+	resp.WriteEntity(stats)
+
+}
@@ -0,0 +1,291 @@
+package ApiServices
+
+import(
+
+	"./userDBHandler"
+	"./../../../common/priceDB"
+
+	"strconv"
+
+	"testing"
+
+)
+
+func TestValueCardsUnpriced(t *testing.T) {
+
+	cards:= []userDB.Card{
+		userDB.Card{Name: "Griselbrand", Set: "Avacyn Restored", Quantity: 2},
+		userDB.Card{Name: "Unknown Card", Set: "Avacyn Restored", Quantity: 1},
+		userDB.Card{Name: "Tamiyo, the Moon Sage", Set: "Avacyn Restored",
+			Quantity: 0},
+	}
+	prices:= map[printing]priceDB.PrintingPrice{
+		printing{"Griselbrand", "Avacyn Restored"}: nonfoilPrice(1500),
+		printing{"Tamiyo, the Moon Sage", "Avacyn Restored"}: nonfoilPrice(2500),
+	}
+
+	valued, unpriced:= valueCards(cards, prices)
+	if len(valued) != 1 || valued[0].Value != 3000 {
+		t.Fatal("incorrect valuation", valued)
+	}
+	if len(unpriced) != 1 || unpriced[0].Name != "Unknown Card" {
+		t.Fatal("unpriced card not listed separately", unpriced)
+	}
+
+}
+
+func nonfoilPrice(price int32) priceDB.PrintingPrice {
+	return priceDB.PrintingPrice{
+		Nonfoil: priceDB.Price{Price: price},
+		HasNonfoil: true,
+	}
+}
+
+// Foils should use their true price when one exists, otherwise
+// fall back to a multiple of the nonfoil price.
+func TestValueCardsFoil(t *testing.T) {
+
+	cards:= []userDB.Card{
+		userDB.Card{Name: "Griselbrand", Set: "Avacyn Restored Foil",
+			Quantity: 1},
+		userDB.Card{Name: "Griselbrand", Set: "Avacyn Restored",
+			Quantity: 1},
+		userDB.Card{Name: "Tamiyo, the Moon Sage", Set: "Avacyn Restored Foil",
+			Quantity: 1},
+	}
+
+	griselbrand:= nonfoilPrice(1500)
+	griselbrand.Foil = priceDB.Price{Price: 4200}
+	griselbrand.HasFoil = true
+
+	prices:= map[printing]priceDB.PrintingPrice{
+		printing{"Griselbrand", "Avacyn Restored"}: griselbrand,
+		printing{"Tamiyo, the Moon Sage", "Avacyn Restored"}: nonfoilPrice(2500),
+	}
+
+	valued, unpriced:= valueCards(cards, prices)
+	if len(valued) != 3 || len(unpriced) != 0 {
+		t.Fatal("cards not all valued", valued, unpriced)
+	}
+	if valued[0].Price != 4200 {
+		t.Fatal("foil did not use the foil price", valued[0])
+	}
+	if valued[1].Price != 1500 {
+		t.Fatal("nonfoil did not use the nonfoil price", valued[1])
+	}
+	if valued[2].Price != 2500 * fallbackFoilMultiplier {
+		t.Fatal("foil without a foil price not valued by multiplier", valued[2])
+	}
+
+}
+
+func TestTopCards(t *testing.T) {
+
+	valued:= []CardValue{
+		CardValue{Card: userDB.Card{Name: "c"}, Value: 100},
+		CardValue{Card: userDB.Card{Name: "a"}, Value: 500},
+		CardValue{Card: userDB.Card{Name: "e"}, Value: 50},
+		CardValue{Card: userDB.Card{Name: "d"}, Value: 300},
+		CardValue{Card: userDB.Card{Name: "b"}, Value: 300},
+	}
+
+	top:= topCards(valued, 3)
+	if len(top) != 3 {
+		t.Fatal("top cards not limited to n", top)
+	}
+
+	// Ties on value are ordered by name
+	expected:= []string{"a", "b", "d"}
+	for i, name:= range expected{
+		if top[i].Name != name {
+			t.Fatal("incorrect ordering", top)
+		}
+	}
+
+	all:= topCards(valued, 10)
+	if len(all) != len(valued) {
+		t.Fatal("top cards dropped cards below n", all)
+	}
+	if all[len(all) - 1].Name != "e" {
+		t.Fatal("least valuable card not last", all)
+	}
+
+	if len(topCards(valued, 0)) != 0 {
+		t.Fatal("returned cards when none requested")
+	}
+
+}
+
+// Sell valuation should prefer buylist prices, falling back to
+// market prices with a flag.
+func TestValueCardsSell(t *testing.T) {
+
+	cards:= []userDB.Card{
+		userDB.Card{Name: "Griselbrand", Set: "Avacyn Restored", Quantity: 2},
+		userDB.Card{Name: "Tamiyo, the Moon Sage", Set: "Avacyn Restored",
+			Quantity: 1},
+		userDB.Card{Name: "Unknown Card", Set: "Avacyn Restored", Quantity: 1},
+	}
+	market:= map[printing]priceDB.PrintingPrice{
+		printing{"Griselbrand", "Avacyn Restored"}: nonfoilPrice(1500),
+		printing{"Tamiyo, the Moon Sage", "Avacyn Restored"}: nonfoilPrice(2500),
+	}
+	sell:= map[printing]priceDB.PrintingPrice{
+		printing{"Griselbrand", "Avacyn Restored"}: nonfoilPrice(900),
+	}
+
+	valued, unpriced:= valueCardsSell(cards, sell, market)
+	if len(valued) != 2 || len(unpriced) != 1 {
+		t.Fatal("cards not all valued", valued, unpriced)
+	}
+	if valued[0].Value != 1800 || valued[0].Fallback {
+		t.Fatal("card not valued at its sell price", valued[0])
+	}
+	if valued[1].Value != 2500 || !valued[1].Fallback {
+		t.Fatal("card without a sell price not flagged", valued[1])
+	}
+
+}
+
+func TestCollectionStats(t *testing.T) {
+
+	cards:= []userDB.Card{
+		userDB.Card{Name: "Griselbrand", Set: "Avacyn Restored", Quantity: 2},
+		userDB.Card{Name: "Tamiyo, the Moon Sage", Set: "Avacyn Restored",
+			Quantity: 1},
+	}
+	market:= map[printing]priceDB.PrintingPrice{
+		printing{"Griselbrand", "Avacyn Restored"}: nonfoilPrice(1500),
+		printing{"Tamiyo, the Moon Sage", "Avacyn Restored"}: nonfoilPrice(2500),
+	}
+	sell:= map[printing]priceDB.PrintingPrice{
+		printing{"Griselbrand", "Avacyn Restored"}: nonfoilPrice(900),
+	}
+
+	stats:= collectionStats(cards, sell, market, 0)
+	if stats.MarketValue != 5500 || stats.SellValue != 4300 {
+		t.Fatal("incorrect totals", stats)
+	}
+	if stats.Spread != 1200 {
+		t.Fatal("incorrect spread", stats)
+	}
+	if stats.SellFallbacks != 1 || stats.Unpriced != 0 {
+		t.Fatal("incorrect fallback counts", stats)
+	}
+
+}
+
+// Cards below the minimum value leave the totals for the bulk bucket.
+func TestCollectionStatsMinValue(t *testing.T) {
+
+	cards:= []userDB.Card{
+		userDB.Card{Name: "Griselbrand", Set: "Avacyn Restored", Quantity: 2},
+		userDB.Card{Name: "Moorland Inquisitor", Set: "Avacyn Restored",
+			Quantity: 20},
+	}
+	market:= map[printing]priceDB.PrintingPrice{
+		printing{"Griselbrand", "Avacyn Restored"}: nonfoilPrice(1500),
+		printing{"Moorland Inquisitor", "Avacyn Restored"}: nonfoilPrice(10),
+	}
+	sell:= map[printing]priceDB.PrintingPrice{
+		printing{"Griselbrand", "Avacyn Restored"}: nonfoilPrice(900),
+		printing{"Moorland Inquisitor", "Avacyn Restored"}: nonfoilPrice(1),
+	}
+
+	stats:= collectionStats(cards, sell, market, 100)
+	if stats.MarketValue != 3000 || stats.SellValue != 1800 {
+		t.Fatal("bulk cards counted in the totals", stats)
+	}
+	if stats.BulkMarketValue != 200 || stats.BulkSellValue != 20 {
+		t.Fatal("bulk cards missing from the bulk bucket", stats)
+	}
+	if stats.Spread != 1200 {
+		t.Fatal("incorrect spread", stats)
+	}
+
+	valued, _:= valueCards(cards, market)
+	kept, bulkValue:= excludeBulk(valued, 0)
+	if len(kept) != 2 || bulkValue != 0 {
+		t.Fatal("cards excluded without a minimum value", kept, bulkValue)
+	}
+
+}
+
+// Summing thousands of small prices must stay exact to the cent,
+// sums of float dollars drift off such amounts.
+func TestCollectionStatsExact(t *testing.T) {
+
+	cards:= make([]userDB.Card, 0, 1000)
+	market:= make(map[printing]priceDB.PrintingPrice)
+	for i:= 0; i < 1000; i++ {
+		name:= strconv.Itoa(i)
+		cards = append(cards, userDB.Card{Name: name,
+			Set: "Avacyn Restored", Quantity: 3})
+		market[printing{name, "Avacyn Restored"}] = nonfoilPrice(int32(i % 100))
+	}
+
+	stats:= collectionStats(cards, map[printing]priceDB.PrintingPrice{},
+		market, 0)
+	// Ten of each price from 0 to 99 cents, three copies of each
+	if stats.MarketValue != 3 * 10 * 4950 {
+		t.Fatal("total not exact to the cent", stats.MarketValue)
+	}
+
+	us:= valuationPrefs{"USD", "en-US"}
+	if formatCents(stats.MarketValue, us) != "$1,485.00" {
+		t.Fatal("exact total displayed incorrectly",
+			formatCents(stats.MarketValue, us))
+	}
+
+}
+
+func TestTotalValue(t *testing.T) {
+
+	cards:= []userDB.Card{
+		userDB.Card{Name: "Griselbrand", Set: "Avacyn Restored", Quantity: 2},
+		userDB.Card{Name: "Unknown Card", Set: "Avacyn Restored", Quantity: 1},
+		userDB.Card{Name: "Unknown Card", Set: "Avacyn Restored Foil",
+			Quantity: 1},
+		userDB.Card{Name: "Tamiyo, the Moon Sage", Set: "Avacyn Restored",
+			Quantity: 1},
+	}
+	prices:= map[printing]priceDB.PrintingPrice{
+		printing{"Griselbrand", "Avacyn Restored"}: nonfoilPrice(1500),
+		printing{"Tamiyo, the Moon Sage", "Avacyn Restored"}: nonfoilPrice(2500),
+	}
+
+	total, unpriced:= totalValue(cards, prices)
+	if total != 5500 {
+		t.Fatal("incorrect total", total)
+	}
+	if len(unpriced) != 1 || unpriced[0] != "Unknown Card" {
+		t.Fatal("unpriced cards not named once each", unpriced)
+	}
+
+}
+
+func TestConversionCurrency(t *testing.T) {
+
+	rates:= map[string]float64{defaultCurrency: 1, "GBP": 0.8}
+
+	currency, ok:= conversionCurrency("GBP", "EUR", rates)
+	if !ok || currency != "GBP" {
+		t.Fatal("requested currency not used", currency)
+	}
+
+	_, ok = conversionCurrency("JPY", defaultCurrency, rates)
+	if ok {
+		t.Fatal("requested currency without a rate accepted")
+	}
+
+	// EUR has prices of its own so needs no rate, requested or preferred
+	currency, ok = conversionCurrency("EUR", defaultCurrency, rates)
+	if !ok || currency != "EUR" {
+		t.Fatal("natively priced currency refused", currency)
+	}
+	currency, ok = conversionCurrency("", "EUR", rates)
+	if !ok || currency != "EUR" {
+		t.Fatal("natively priced preference not kept", currency)
+	}
+
+}
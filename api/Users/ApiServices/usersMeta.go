@@ -4,18 +4,146 @@ import(
 
 	"./userDBHandler"
 	"./mailer"
+	"./recaptcha"
 
 	"github.com/emicklei/go-restful"
 
 	"net/http"
 
+	"os"
+	"strconv"
+	"time"
+
 )
 
+// Seconds after expiry a session may still be refreshed, smoothing over
+// clock skew with clients. Zero requires sessions be refreshed in time.
+const refreshGraceEnv string = "USERS_REFRESH_GRACE_SECONDS"
+
+const defaultRefreshGrace time.Duration = 5 * time.Minute
+
+func refreshGrace() time.Duration {
+
+	raw:= os.Getenv(refreshGraceEnv)
+	if raw == "" {
+		return defaultRefreshGrace
+	}
+
+	seconds, err:= strconv.Atoi(raw)
+	if err!=nil || seconds < 0 {
+		return defaultRefreshGrace
+	}
+
+	return time.Duration(seconds) * time.Second
+
+}
+
+// Set on failed logins to the number of consecutive failures, so
+// clients can prompt for more care before the account locks.
+const failedLoginsHeader string = "X-Failed-Logins"
+
+// Set when a login must carry a captcha, either as the attempt
+// lacked one or so the next attempt includes one.
+const captchaRequiredHeader string = "X-Captcha-Required"
+
+// Whole seconds until a wait is over, never rounding down to early.
+func retryAfterSeconds(wait time.Duration) int {
+	return int((wait + time.Second - 1) / time.Second)
+}
+
+// Reports a failed login, including how many have failed in a row or
+// when to try again if the account is locked.
+//
+// Unknown users are counted and locked out just as accounts are, so
+// usernames can't be probed for, the actual reason is only logged.
+// Failures of our own, such as an unreachable database, are reported
+// as such which says nothing about the user.
+func (aService *UserService) loginFailure(req *restful.Request,
+	resp *restful.Response, err error) {
+
+	switch failure:= err.(type){
+	case userDB.LoginLockedError:
+		if failure.UnknownUser {
+			logAt(aService.logger, levelWarn, requestContext(req),
+				"refused login, unknown user,", err)
+		}
+	case userDB.LoginFailedError:
+		if failure.UnknownUser {
+			logAt(aService.logger, levelWarn, requestContext(req),
+				"refused login, unknown user,", err)
+		}
+	default:
+		logAt(aService.logger, levelError, requestContext(req),
+			"failed login,", err)
+	}
+
+	status, reason, headers:= loginRefusal(err)
+	for header, value:= range headers{
+		resp.AddHeader(header, value)
+	}
+	resp.WriteErrorString(status, reason)
+
+}
+
+// Determines the status, reason and headers a failed login is
+// answered with.
+func loginRefusal(err error) (int, string, map[string]string) {
+
+	headers:= make(map[string]string)
+
+	switch failure:= err.(type){
+	case userDB.LoginLockedError:
+		headers["Retry-After"] =
+			strconv.Itoa(retryAfterSeconds(failure.Remaining))
+		return http.StatusTooManyRequests, LoginLocked, headers
+	case userDB.LoginFailedError:
+		headers[failedLoginsHeader] = strconv.Itoa(int(failure.Failures))
+		if failure.Failures >= userDB.CaptchaFailureThreshold {
+			headers[captchaRequiredHeader] = "true"
+		}
+		return http.StatusBadRequest, BadCredentials, headers
+	}
+
+	status, reason:= dbFailure(err, BadCredentials)
+	return status, reason, headers
+
+}
+
 // The contents of a reset email formatted to match the template.
 type resetEmailContents struct{
 	Name, ResetCode string
 }
 
+// The actions captchas are issued for, responses for one aren't
+// accepted for another.
+const (
+	captchaSignupAction string = "signup"
+	captchaLoginAction string = "login"
+	captchaResetAction string = "passwordReset"
+)
+
+// Determines how a failed captcha check is reported.
+//
+// Timeouts reaching google say nothing about the response itself
+// so the caller is asked to retry rather than being rejected.
+func (aService *UserService) captchaFailure(req *restful.Request,
+	err error) (int, string) {
+
+	if recaptcha.IsTransient(err) {
+		return http.StatusServiceUnavailable, CaptchaUnavailable
+	}
+	if err == recaptcha.ErrLowScore {
+		return http.StatusBadRequest, CaptchaLowScore
+	}
+	if hostErr, ok:= err.(recaptcha.HostnameError); ok {
+		// Worth noticing, either farming or a hostname we forgot
+		logAt(aService.logger, levelWarn, requestContext(req), hostErr)
+	}
+
+	return http.StatusBadRequest, BadCaptcha
+
+}
+
 // Creates a user after validating the password. The remote database
 // should prevent duplicates
 func (aService *UserService) createUser(req *restful.Request,
@@ -29,25 +157,50 @@ func (aService *UserService) createUser(req *restful.Request,
 		return
 	}
 
-	valid, err:= aService.validator.Validate(someUserData.RecaptchaResponseField)
-	if err!=nil || !valid {
-		resp.WriteErrorString(http.StatusBadRequest, BadCaptcha)
+	if !validEncoding(userName, someUserData.Email) {
+		resp.WriteErrorString(http.StatusBadRequest, BadEncoding)
+		return
+	}
+
+	email, err:= userDB.NormalizeEmail(someUserData.Email)
+	if err!=nil {
+		resp.WriteErrorString(http.StatusBadRequest, emailFailure(err))
+		return
+	}
+
+	err = aService.validator.Validate(someUserData.RecaptchaResponseField,
+		getIP(req), captchaSignupAction)
+	if err!=nil {
+		resp.WriteErrorString(aService.captchaFailure(req, err))
+		return
+	}
+
+	if feedback, ok:= passwordFeedback(someUserData.Password); !ok {
+		resp.WriteErrorString(http.StatusBadRequest, feedback)
 		return
 	}
 
-	if !passwordMeetsRequirements(someUserData.Password) {
-		resp.WriteErrorString(http.StatusBadRequest, BadPassword)
+	if !emailConfirmed(someUserData.Email, someUserData.EmailConfirmation,
+		aService.requireEmailConfirmation) {
+		resp.WriteErrorString(http.StatusBadRequest, EmailMismatch)
 		return
 	}
 
-	sessionKey, err:= userDB.AddUser(aService.pool,
-		userName, someUserData.Email,
+	sessionKey, err:= userDB.AddUser(aService.db(),
+		userName, email,
 		someUserData.Password)
 	if err!=nil {
 		resp.WriteErrorString(http.StatusBadRequest, SignupFailure)
 		return
 	}
 
+	// The account is usable regardless, the code can be sent again
+	err = aService.sendVerification(userName, email)
+	if err!=nil {
+		logAt(aService.logger, levelError, requestContext(req),
+			"failed to send verification", err)
+	}
+
 	resp.WriteEntity(sessionKey)
 
 }
@@ -66,16 +219,230 @@ func (aService *UserService) loginUser(req *restful.Request,
 
 	password:= passwordContainer.Password
 
-	sessionKey, err:= userDB.Login(aService.pool, userName, password)
+	// Unknown users demand a captcha just as accounts do
+	if userDB.LoginCaptchaRequired(aService.db(), userName, time.Now()) {
+		resp.AddHeader(captchaRequiredHeader, "true")
+
+		err:= aService.validator.Validate(passwordContainer.Captcha,
+			getIP(req), captchaLoginAction)
+		if err!=nil {
+			resp.WriteErrorString(aService.captchaFailure(req, err))
+			return
+		}
+	}
+
+	sessionKey, err:= userDB.Login(aService.db(), userName, password)
 	if err!=nil {
-		resp.WriteErrorString(http.StatusBadRequest, BadCredentials)
+		aService.loginFailure(req, resp, err)
 		return
 	}
 
+	aService.flagUnverified(req, resp, userName)
+
 	resp.WriteEntity(sessionKey)
 
 }
 
+// Slides the expiry of a still valid session forward so active
+// users aren't logged out. Sessions which expired within the grace
+// window may also refresh. Returns the new expiry.
+func (aService *UserService) refreshSession(req *restful.Request,
+	resp *restful.Response) {
+
+	userName, sessionKey, err:= getUserNameAndSessionKey(req)
+	if err!=nil {
+		resp.WriteErrorString(http.StatusBadRequest, BodyReadFailure)
+		return
+	}
+	if sessionKey == nil {
+		resp.WriteErrorString(http.StatusBadRequest, BadCredentials)
+		return
+	}
+
+	endValid, err:= userDB.RefreshSession(aService.db(), userName,
+		sessionKey, aService.refreshGrace)
+	if err == userDB.ErrSessionExhausted {
+		resp.WriteErrorString(http.StatusUnauthorized, SessionExhausted)
+		return
+	}
+	if err == userDB.ErrBadSession {
+		resp.WriteErrorString(http.StatusUnauthorized, BadCredentials)
+		return
+	}
+	if err!=nil {
+		logAt(aService.logger, levelError, requestContext(req), err)
+		resp.WriteErrorString(http.StatusInternalServerError, DBWriteFailure)
+		return
+	}
+
+	resp.WriteEntity(endValid)
+
+}
+
+// Lists the valid sessions of an authenticated user.
+//
+// The response carries the sessions version as its ETag, clients
+// polling with it in If-None-Match receive 304 until a session is
+// added, refreshed or removed.
+func (aService *UserService) listSessions(req *restful.Request,
+	resp *restful.Response) {
+
+	userName:= req.PathParameter("userName")
+
+	sessionKey:= getSessionKeyHeader(req)
+	if sessionKey == nil {
+		resp.WriteErrorString(http.StatusBadRequest, BadCredentials)
+		return
+	}
+
+	err:= userDB.SessionAuth(aService.db(), userName, sessionKey)
+	if err!=nil {
+		resp.WriteErrorString(dbFailure(err, BadCredentials))
+		return
+	}
+
+	// The version is read first, a change racing the listing
+	// only costs the client another fetch next poll
+	version, err:= userDB.SessionsVersion(aService.db(), userName)
+	if err!=nil {
+		logAt(aService.logger, levelError, requestContext(req), err)
+		resp.WriteErrorString(http.StatusInternalServerError, DBWriteFailure)
+		return
+	}
+
+	etag:= versionETag(version)
+	setPrivateHeader(resp)
+	resp.AddHeader("ETag", etag)
+
+	if etagMatches(req.HeaderParameter("If-None-Match"), etag) {
+		resp.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	sessions, err:= userDB.ListSessions(aService.db(), sessionKey, userName)
+	if err!=nil {
+		logAt(aService.logger, levelError, requestContext(req), err)
+		resp.WriteErrorString(dbFailure(err, DBWriteFailure))
+		return
+	}
+
+	current:= userDB.SessionID(sessionKey)
+
+	infos:= make([]SessionInfo, len(sessions))
+	for i, s:= range sessions{
+		infos[i] = SessionInfo{
+			ID: s.ID,
+			StartValid: s.StartValid,
+			EndValid: s.EndValid,
+			LastUsed: s.LastUsed,
+			Current: s.ID == current,
+		}
+	}
+
+	resp.WriteEntity(infos)
+
+}
+
+// Logs an authenticated user out everywhere, optionally staying
+// logged in where the request came from.
+func (aService *UserService) logoutAll(req *restful.Request,
+	resp *restful.Response) {
+
+	userName:= req.PathParameter("userName")
+
+	var logoutContainer LogoutAllBody
+	err:= req.ReadEntity(&logoutContainer)
+	if err!=nil {
+		resp.WriteErrorString(http.StatusBadRequest, BodyReadFailure)
+		return
+	}
+
+	if logoutContainer.SessionKey == nil {
+		resp.WriteErrorString(http.StatusBadRequest, BadCredentials)
+		return
+	}
+
+	err = userDB.LogoutAll(aService.db(), logoutContainer.SessionKey,
+		userName, logoutContainer.KeepCurrent)
+	if err == userDB.ErrBadSession {
+		resp.WriteErrorString(http.StatusUnauthorized, BadCredentials)
+		return
+	}
+	if err!=nil {
+		logAt(aService.logger, levelError, requestContext(req), err)
+		resp.WriteErrorString(dbFailure(err, DBWriteFailure))
+		return
+	}
+
+	resp.WriteEntity(true)
+
+}
+
+// Revokes a single session of an authenticated user, such as one
+// on a lost device, without disturbing the rest.
+func (aService *UserService) revokeSession(req *restful.Request,
+	resp *restful.Response) {
+
+	userName:= req.PathParameter("userName")
+	sessionID:= req.PathParameter("sessionID")
+
+	sessionKey:= getSessionKeyHeader(req)
+	if sessionKey == nil {
+		resp.WriteErrorString(http.StatusBadRequest, BadCredentials)
+		return
+	}
+
+	err:= userDB.RevokeSession(aService.db(), sessionKey, userName, sessionID)
+	if err == userDB.ErrBadSession {
+		resp.WriteErrorString(http.StatusUnauthorized, BadCredentials)
+		return
+	}
+	if err == userDB.ErrNoSuchSession {
+		resp.WriteErrorString(http.StatusNotFound, NoSuchSession)
+		return
+	}
+	if err!=nil {
+		logAt(aService.logger, levelError, requestContext(req), err)
+		resp.WriteErrorString(dbFailure(err, DBWriteFailure))
+		return
+	}
+
+	resp.WriteEntity(true)
+
+}
+
+// Flags the authenticated user to have their password rehashed with
+// a fresh nonce when they next login.
+func (aService *UserService) forceRehash(req *restful.Request,
+	resp *restful.Response) {
+
+	userName, sessionKey, err:= getUserNameAndSessionKey(req)
+	if err!=nil {
+		resp.WriteErrorString(http.StatusBadRequest, BodyReadFailure)
+		return
+	}
+	if sessionKey == nil {
+		resp.WriteErrorString(http.StatusBadRequest, BadCredentials)
+		return
+	}
+
+	err = userDB.SessionAuth(aService.db(), userName, sessionKey)
+	if err!=nil {
+		resp.WriteErrorString(dbFailure(err, BadCredentials))
+		return
+	}
+
+	err = userDB.SetForceRehash(aService.db(), userName)
+	if err!=nil {
+		logAt(aService.logger, levelError, requestContext(req), err)
+		resp.WriteErrorString(http.StatusInternalServerError, DBWriteFailure)
+		return
+	}
+
+	resp.WriteEntity(true)
+
+}
+
 // Requests that a valid reset token be created, recorded, and sent to the user's email.
 //
 // Sends mail to the user via the service embedded mailer
@@ -92,22 +459,49 @@ func (aService *UserService) requestPasswordReset(req *restful.Request,
 	}
 
 
-	valid, err:= aService.validator.Validate(resetRequestContainer.RecaptchaResponseField)
-	if err!=nil || !valid {
-		resp.WriteErrorString(http.StatusBadRequest, BadCaptcha)
+	err = aService.validator.Validate(resetRequestContainer.RecaptchaResponseField,
+		getIP(req), captchaResetAction)
+	if err!=nil {
+		resp.WriteErrorString(aService.captchaFailure(req, err))
 		return
 	}
 
-	code, err:= userDB.RequestReset(aService.pool, userName) 
+	// Every request looks just as successful so whether the user
+	// exists is never revealed. Throttled users have a recent
+	// reset to use anyway.
+	code, err:= userDB.RequestReset(aService.db(), userName) 
+	if err == userDB.ErrResetThrottled {
+		resp.WriteEntity(true)
+		return
+	}
 	if err!=nil {
-		resp.WriteErrorString(http.StatusBadRequest, BadCredentials)
+		logAt(aService.logger, levelInfo, requestContext(req),
+			"refused reset,", err)
+		resp.WriteEntity(true)
 		return
 	}
 
+	// Mailing takes long enough to be noticed, so it happens after
+	// responding like every other request
+	context:= requestContext(req)
+	aService.queueBackground(func() {
+		aService.sendReset(context, userName, code)
+	})
+
+	resp.WriteEntity(true)
+
+}
+
+// Mails a user the reset code they requested, logging failures
+// against the request they made.
+func (aService *UserService) sendReset(context logContext,
+	userName, code string) {
+
 	// Fetch the user so we know their email
-	u, err:= userDB.GetUser(aService.pool, userName)
+	u, err:= userDB.GetUser(aService.db(), userName)
 	if err!=nil {
-		resp.WriteErrorString(http.StatusBadRequest, BodyReadFailure)
+		logAt(aService.logger, levelError, context,
+			"failed to get user for reset,", err)
 		return
 	}
 
@@ -116,11 +510,12 @@ func (aService *UserService) requestPasswordReset(req *restful.Request,
 		ResetCode: code,
 	}
 	targetAddress:= mailer.FormatAddress(userName, u.Email)
-	aService.mailer.SendPrepared("reset", contents,
+	err = aService.sendMail("reset", contents,
 		targetAddress, "Password Reset - Preorda.in")
-
-
-	resp.WriteEntity(true)
+	if err!=nil {
+		logAt(aService.logger, levelError, context,
+			"failed to send email,", err)
+	}
 
 }
 
@@ -136,14 +531,116 @@ func (aService *UserService) resetPassword(req *restful.Request,
 		return
 	}
 
-	err = userDB.ChangePassword(aService.pool,
+	if feedback, ok:= passwordFeedback(resetContainer.Password); !ok {
+		resp.WriteErrorString(http.StatusBadRequest, feedback)
+		return
+	}
+
+	err = userDB.ChangePassword(aService.db(),
 		userName, resetContainer.Password,
 		resetContainer.ResetRequestToken)
+	if err == userDB.ErrResetExpired {
+		resp.WriteErrorString(http.StatusBadRequest, ResetExpired)
+		return
+	}
+	if err!=nil {
+		resp.WriteErrorString(http.StatusBadRequest, BadCredentials)
+		return
+	}
+
+	resp.WriteEntity(true)
+
+}
+
+// Changes the password of a logged in user given their current one.
+// Returns a fresh session key as every other session is removed.
+func (aService *UserService) changePassword(req *restful.Request,
+	resp *restful.Response) {
+
+	userName:= req.PathParameter("userName")
+
+	var changeContainer PasswordChangeBody
+	err:= req.ReadEntity(&changeContainer)
+	if err!=nil {
+		resp.WriteErrorString(http.StatusBadRequest, BodyReadFailure)
+		return
+	}
+	if changeContainer.SessionKey == nil {
+		resp.WriteErrorString(http.StatusBadRequest, BadCredentials)
+		return
+	}
+
+	if feedback, ok:= passwordFeedback(changeContainer.NewPassword); !ok {
+		resp.WriteErrorString(http.StatusBadRequest, feedback)
+		return
+	}
+
+	sessionKey, err:= userDB.ChangePasswordAuthenticated(aService.db(),
+		changeContainer.SessionKey, userName,
+		changeContainer.OldPassword, changeContainer.NewPassword)
+	if err == userDB.ErrBadSession {
+		resp.WriteErrorString(http.StatusUnauthorized, BadCredentials)
+		return
+	}
 	if err!=nil {
+		aService.loginFailure(req, resp, err)
+		return
+	}
+
+	resp.WriteEntity(sessionKey)
+
+}
+
+// Deletes the account of a logged in user who confirms their password.
+//
+// Any paid plan is cancelled with stripe once the account is gone.
+// The account can't be restored if that fails, so the failure is
+// logged with what is needed to cancel by hand.
+func (aService *UserService) deleteUser(req *restful.Request,
+	resp *restful.Response) {
+
+	userName:= req.PathParameter("userName")
+
+	sessionKey:= getSessionKeyHeader(req)
+	if sessionKey == nil {
 		resp.WriteErrorString(http.StatusBadRequest, BadCredentials)
 		return
 	}
 
+	var passwordContainer PasswordBody
+	err:= req.ReadEntity(&passwordContainer)
+	if err!=nil {
+		resp.WriteErrorString(http.StatusBadRequest, BodyReadFailure)
+		return
+	}
+
+	sub, err:= userDB.DeleteUser(aService.db(), sessionKey, userName,
+		passwordContainer.Password)
+	switch err.(type){
+	case userDB.LoginLockedError, userDB.LoginFailedError:
+		aService.loginFailure(req, resp, err)
+		return
+	}
+	if err == userDB.ErrBadSession {
+		resp.WriteErrorString(http.StatusUnauthorized, BadCredentials)
+		return
+	}
+	if err!=nil {
+		logAt(aService.logger, levelError, requestContext(req), err)
+		resp.WriteErrorString(dbFailure(err, DBWriteFailure))
+		return
+	}
+
+	if sub.SubID != userDB.DefaultID {
+		err = aService.merch.UnSubCustomer(subRef(sub))
+		aService.metrics.stripeCall("unSubCustomer", err)
+		if err!=nil {
+			logAt(aService.logger, levelError, requestContext(req),
+				"failed to cancel subscription of deleted user",
+				userName, sub.CustomerID, sub.SubID, err)
+		}
+	}
+
 	resp.WriteEntity(true)
 
 }
@@ -162,15 +659,16 @@ func (aService *UserService) getUserEmail(req *restful.Request,
 		return
 	}
 
-	err = userDB.SessionAuth(aService.pool, userName, sessionKey)
+	err = userDB.SessionAuth(aService.db(), userName, sessionKey)
 	if err!=nil {
-		resp.WriteErrorString(http.StatusBadRequest, BadCredentials)
-		return	
+		resp.WriteErrorString(dbFailure(err, BadCredentials))
+		return
 	}
 
-	u, err:= userDB.GetUser(aService.pool, userName)
+	u, err:= userDB.GetUser(aService.db(), userName)
 	if err!=nil {
-		resp.WriteErrorString(http.StatusBadRequest, DBfailure)
+		resp.WriteErrorString(dbFailure(err, BadUserName))
+		return
 	}
 
 	resp.WriteEntity(u.Email)
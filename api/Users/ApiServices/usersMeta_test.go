@@ -0,0 +1,67 @@
+package ApiServices
+
+import(
+
+	"./userDBHandler"
+
+	"net/http"
+	"reflect"
+	"time"
+
+	"testing"
+
+)
+
+// Clients told to retry early would only be locked out again.
+func TestRetryAfterSeconds(t *testing.T) {
+
+	waits:= map[time.Duration]int{
+		0: 0,
+		time.Millisecond: 1,
+		time.Second: 1,
+		90 * time.Second + time.Nanosecond: 91,
+	}
+
+	for wait, expected:= range waits{
+		if retryAfterSeconds(wait) != expected {
+			t.Fatal("incorrect retry after", wait, retryAfterSeconds(wait))
+		}
+	}
+
+}
+
+// Logins against an account and against a name with none must be
+// answered identically at every step towards a lockout.
+func TestLoginRefusalUnknownUser(t *testing.T) {
+
+	for i:= int32(1); i <= userDB.LoginFailureThreshold; i++ {
+		existing:= userDB.LoginFailedError{Failures: i}
+		unknown:= userDB.LoginFailedError{Failures: i, UnknownUser: true}
+		compareRefusals(t, existing, unknown)
+	}
+
+	existing:= userDB.LoginLockedError{Remaining: time.Minute}
+	unknown:= userDB.LoginLockedError{Remaining: time.Minute, UnknownUser: true}
+	compareRefusals(t, existing, unknown)
+
+	status, _, headers:= loginRefusal(userDB.LoginFailedError{
+		Failures: userDB.CaptchaFailureThreshold, UnknownUser: true,
+	})
+	if status != http.StatusBadRequest || headers[captchaRequiredHeader] != "true" {
+		t.Fatal("unknown user not asked for a captcha", status, headers)
+	}
+
+}
+
+func compareRefusals(t *testing.T, existing, unknown error) {
+
+	existingStatus, existingReason, existingHeaders:= loginRefusal(existing)
+	unknownStatus, unknownReason, unknownHeaders:= loginRefusal(unknown)
+	if existingStatus != unknownStatus || existingReason != unknownReason ||
+		!reflect.DeepEqual(existingHeaders, unknownHeaders) {
+		t.Fatal("unknown user refused differently",
+			existingStatus, existingReason, existingHeaders,
+			unknownStatus, unknownReason, unknownHeaders)
+	}
+
+}
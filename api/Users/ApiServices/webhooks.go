@@ -0,0 +1,566 @@
+package ApiServices
+
+import(
+
+	"./userDBHandler"
+	"./mailer"
+
+	"github.com/emicklei/go-restful"
+
+	"net"
+	"net/http"
+	"net/url"
+	"bytes"
+	"strings"
+	"syscall"
+
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+
+	"io/ioutil"
+	"encoding/json"
+	"fmt"
+
+	"sync"
+	"time"
+
+)
+
+// How long signatures made with a retired secret continue to verify
+// when no window is configured.
+const defaultRotationWindow time.Duration = 24 * time.Hour
+
+// Length in bytes of freshly generated signing secrets.
+const webhookSecretLength int = 32
+
+// The header outgoing webhook payloads carry their signature in.
+const webhookSignatureHeader string = "X-Preorda-Signature"
+
+// Each delivery is attempted at most this many times, with the delay
+// doubling between attempts, before counting as a single failure.
+const maxWebhookAttempts int = 3
+const webhookRetryDelay time.Duration = time.Second
+
+// Receivers are given this long to respond to each attempt.
+const webhookTimeout time.Duration = 10 * time.Second
+
+// What is delivered to webhooks when a collection changes.
+type CollectionChange struct{
+	User, Collection string
+	Event string
+	Time time.Time
+}
+
+// The contents of a webhook disabled email formatted to match the template.
+type webhookDisabledEmailContents struct{
+	Name, URL string
+}
+
+// Signing secrets for webhooks, stored as json on disk.
+//
+// Previous remains valid for verification until PreviousUntil so
+// receivers can be updated during a rotation.
+type webhookMeta struct{
+	Current, Previous string
+	PreviousUntil time.Time
+	RotationWindowHours int
+}
+
+// Signs outgoing webhook payloads with HMAC-SHA256 and supports
+// rotating the secret without breaking receivers mid rotation.
+type webhookSigner struct{
+	sync.RWMutex
+
+	current, previous []byte
+	previousUntil time.Time
+	window time.Duration
+
+	// Where secrets are persisted when rotated, empty to skip
+	loc string
+}
+
+func newWebhookSigner(meta webhookMeta, loc string) (*webhookSigner, error) {
+
+	if meta.Current == "" {
+		return nil, fmt.Errorf("webhook meta has no current secret")
+	}
+
+	window:= time.Duration(meta.RotationWindowHours) * time.Hour
+	if window <= 0 {
+		window = defaultRotationWindow
+	}
+
+	signer:= webhookSigner{
+		current: []byte(meta.Current),
+		previousUntil: meta.PreviousUntil,
+		window: window,
+		loc: loc,
+	}
+	if meta.Previous != "" {
+		signer.previous = []byte(meta.Previous)
+	}
+
+	return &signer, nil
+
+}
+
+// Readies the secrets used to sign outgoing webhooks.
+func (aService *UserService) setupWebhooks(loc string) {
+
+	metaRaw, err:= ioutil.ReadFile(loc)
+	if err!=nil {
+		aService.logger.Fatalln("Failed to read webhook meta", err)
+	}
+
+	var meta webhookMeta
+	err = json.Unmarshal(metaRaw, &meta)
+	if err!=nil {
+		aService.logger.Fatalln("Failed to parse webhook meta", err)
+	}
+
+	signer, err:= newWebhookSigner(meta, loc)
+	if err!=nil {
+		aService.logger.Fatalln("Failed to setup webhook signing", err)
+	}
+
+	aService.webhookSigner = signer
+	aService.webhookClient = newWebhookClient(publicOnlyControl)
+
+}
+
+func computeSignature(secret, payload []byte) []byte {
+
+	mac:= hmac.New(sha256.New, secret)
+	mac.Write(payload)
+
+	return mac.Sum(nil)
+
+}
+
+// Returns the hex encoded signature of a payload using the current secret.
+func (signer *webhookSigner) Sign(payload []byte) string {
+
+	signer.RLock()
+	defer signer.RUnlock()
+
+	return hex.EncodeToString(computeSignature(signer.current, payload))
+
+}
+
+// Returns whether or not the signature is valid for the payload under
+// the current secret or, during a rotation window, the previous secret.
+func (signer *webhookSigner) Verify(payload []byte, signature string,
+	now time.Time) bool {
+
+	provided, err:= hex.DecodeString(signature)
+	if err!=nil {
+		return false
+	}
+
+	signer.RLock()
+	defer signer.RUnlock()
+
+	if hmac.Equal(provided, computeSignature(signer.current, payload)) {
+		return true
+	}
+
+	if signer.previous != nil && now.Before(signer.previousUntil) {
+		return hmac.Equal(provided,
+			computeSignature(signer.previous, payload))
+	}
+
+	return false
+
+}
+
+// Promotes fresh to the current secret while the outgoing secret
+// remains valid for verification until the rotation window passes.
+func (signer *webhookSigner) rotate(fresh []byte, now time.Time) error {
+
+	signer.Lock()
+	defer signer.Unlock()
+
+	signer.previous = signer.current
+	signer.previousUntil = now.Add(signer.window)
+	signer.current = fresh
+
+	return signer.persist()
+
+}
+
+// Immediately stops accepting signatures made with the previous secret.
+func (signer *webhookSigner) retirePrevious() error {
+
+	signer.Lock()
+	defer signer.Unlock()
+
+	signer.previous = nil
+	signer.previousUntil = time.Time{}
+
+	return signer.persist()
+
+}
+
+// Writes the secrets back to disk so they survive a restart.
+//
+// Assumes the lock is held.
+func (signer *webhookSigner) persist() error {
+
+	if signer.loc == "" {
+		return nil
+	}
+
+	meta:= webhookMeta{
+		Current: string(signer.current),
+		Previous: string(signer.previous),
+		PreviousUntil: signer.previousUntil,
+		RotationWindowHours: int(signer.window / time.Hour),
+	}
+
+	metaRaw, err:= json.Marshal(meta)
+	if err!=nil {
+		return err
+	}
+
+	return ioutil.WriteFile(signer.loc, metaRaw, 0600)
+
+}
+
+// Generates a fresh random signing secret.
+func freshWebhookSecret() ([]byte, error) {
+
+	raw:= make([]byte, webhookSecretLength)
+	_, err:= rand.Read(raw)
+	if err!=nil {
+		return nil, err
+	}
+
+	return []byte(hex.EncodeToString(raw)), nil
+
+}
+
+// Rotates or retires webhook signing secrets. Requires the admin key.
+//
+// 'rotate' promotes a freshly generated secret, 'retire' stops the
+// previous secret from verifying before its window passes.
+func (aService *UserService) changeWebhookSecret(req *restful.Request,
+	resp *restful.Response) {
+
+	var secretContainer WebhookSecretBody
+	err:= req.ReadEntity(&secretContainer)
+	if err!=nil {
+		resp.WriteErrorString(http.StatusBadRequest, BodyReadFailure)
+		return
+	}
+
+	if !aService.adminAuth(secretContainer.AdminKey) {
+		resp.WriteErrorString(http.StatusUnauthorized, BadCredentials)
+		return
+	}
+
+	switch secretContainer.Action {
+	case "rotate":
+		fresh, err:= freshWebhookSecret()
+		if err!=nil {
+			logAt(aService.logger, levelError, requestContext(req),
+				"failed to generate webhook secret", err)
+			resp.WriteErrorString(http.StatusInternalServerError,
+				WebhookSecretFailure)
+			return
+		}
+		err = aService.webhookSigner.rotate(fresh, time.Now())
+		if err!=nil {
+			logAt(aService.logger, levelError, requestContext(req),
+				"failed to persist webhook secret", err)
+			resp.WriteErrorString(http.StatusInternalServerError,
+				WebhookSecretFailure)
+			return
+		}
+	case "retire":
+		err = aService.webhookSigner.retirePrevious()
+		if err!=nil {
+			logAt(aService.logger, levelError, requestContext(req),
+				"failed to persist webhook secret", err)
+			resp.WriteErrorString(http.StatusInternalServerError,
+				WebhookSecretFailure)
+			return
+		}
+	default:
+		resp.WriteErrorString(http.StatusBadRequest, BadWebhookAction)
+		return
+	}
+
+	resp.WriteEntity(true)
+
+}
+
+// Registers an endpoint to be notified when the user's collections change.
+func (aService *UserService) registerWebhook(req *restful.Request,
+	resp *restful.Response) {
+
+	userName:= req.PathParameter("userName")
+
+	var webhookContainer WebhookRegisterBody
+	err:= req.ReadEntity(&webhookContainer)
+	if err!=nil {
+		resp.WriteErrorString(http.StatusBadRequest, BodyReadFailure)
+		return
+	}
+
+	if webhookContainer.SessionKey == nil {
+		resp.WriteErrorString(http.StatusBadRequest, BadCredentials)
+		return
+	}
+
+	if !validWebhookURL(webhookContainer.URL) {
+		resp.WriteErrorString(http.StatusBadRequest, BadWebhookURL)
+		return
+	}
+
+	err = userDB.RegisterWebhook(aService.db(),
+		webhookContainer.SessionKey,
+		userName, webhookContainer.URL)
+	if err == userDB.ErrBadSession {
+		resp.WriteErrorString(http.StatusUnauthorized, BadCredentials)
+		return
+	}
+	if err == userDB.ErrWebhookLimit {
+		resp.WriteErrorString(http.StatusForbidden, WebhookLimit)
+		return
+	}
+	if err!=nil {
+		logAt(aService.logger, levelError, requestContext(req), err)
+		resp.WriteErrorString(dbFailure(err, DBWriteFailure))
+		return
+	}
+
+	resp.WriteEntity(true)
+
+}
+
+// Returns whether or not the provided url is usable as a webhook.
+func validWebhookURL(raw string) bool {
+
+	if !validEncoding(raw) {
+		return false
+	}
+
+	parsed, err:= url.Parse(raw)
+	if err!=nil {
+		return false
+	}
+
+	if (parsed.Scheme != "https" && parsed.Scheme != "http") ||
+		parsed.Hostname() == "" {
+		return false
+	}
+
+	// Names are checked when dialed, addresses may as well be refused now
+	if ip:= net.ParseIP(parsed.Hostname()); ip != nil && !publicIP(ip) {
+		return false
+	}
+
+	return !strings.EqualFold(parsed.Hostname(), "localhost")
+
+}
+
+// Returned when a webhook would be delivered to our own network.
+var errWebhookAddress error = fmt.Errorf("webhook address is not public")
+
+// Addresses shared by carrier grade NAT, private though not to net.IP
+var sharedAddressSpace = &net.IPNet{
+	IP: net.IPv4(100, 64, 0, 0),
+	Mask: net.CIDRMask(10, 32),
+}
+
+// Whether an address is reachable over the internet rather than only
+// from inside our network.
+func publicIP(ip net.IP) bool {
+
+	return !(ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast() ||
+		sharedAddressSpace.Contains(ip))
+
+}
+
+// Refuses to connect to anything but public addresses, so webhooks
+// can't reach loopback, private or link local addresses such as
+// cloud metadata services.
+//
+// This runs on the address actually dialed, after resolution, so a
+// name which resolves elsewhere on a later lookup changes nothing.
+func publicOnlyControl(network, address string, c syscall.RawConn) error {
+
+	host, _, err:= net.SplitHostPort(address)
+	if err!=nil {
+		return err
+	}
+
+	ip:= net.ParseIP(host)
+	if ip == nil || !publicIP(ip) {
+		return errWebhookAddress
+	}
+
+	return nil
+
+}
+
+// Returns a client for delivering webhooks which dials only what
+// control permits and never follows redirects.
+//
+// A redirected delivery is a failed one, following it could lead
+// anywhere control would never see the original target of.
+func newWebhookClient(control func(network, address string,
+	c syscall.RawConn) error) *http.Client {
+
+	dialer:= &net.Dialer{
+		Timeout: webhookTimeout,
+		Control: control,
+	}
+
+	return &http.Client{
+		Timeout: webhookTimeout,
+		Transport: &http.Transport{
+			DialContext: dialer.DialContext,
+			TLSHandshakeTimeout: webhookTimeout,
+			MaxIdleConnsPerHost: 2,
+		},
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+
+}
+
+// Delivers a signed payload, retrying failures up to attempts times.
+//
+// Any response other than a 2xx counts as a failure.
+func attemptDelivery(client *http.Client, target string,
+	payload []byte, signature string,
+	attempts int, delay time.Duration) error {
+
+	var err error
+	for attempt:= 0; attempt < attempts; attempt++ {
+
+		if attempt > 0 {
+			time.Sleep(delay)
+			delay*= 2
+		}
+
+		err = deliverOnce(client, target, payload, signature)
+		if err == nil {
+			return nil
+		}
+
+	}
+
+	return fmt.Errorf("delivery failed after %d attempts, %v", attempts, err)
+
+}
+
+func deliverOnce(client *http.Client, target string,
+	payload []byte, signature string) error {
+
+	req, err:= http.NewRequest("POST", target, bytes.NewReader(payload))
+	if err!=nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(webhookSignatureHeader, signature)
+
+	resp, err:= client.Do(req)
+	if err!=nil {
+		return err
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("receiver responded %d", resp.StatusCode)
+	}
+
+	return nil
+
+}
+
+// Notifies every active webhook of the user that a collection changed.
+//
+// Meant to be queued with queueBackground, so notifications are
+// dropped rather than piling up when receivers are slow. Failures are
+// recorded against each webhook and persistently failing webhooks
+// are disabled.
+func (aService *UserService) notifyWebhooks(user, collection, event string) {
+
+	hooks, err:= userDB.GetWebhooks(aService.db(), user)
+	if err!=nil {
+		aService.logger.Println("failed to fetch webhooks", err)
+		return
+	}
+	if len(hooks) == 0 {
+		return
+	}
+
+	payload, err:= json.Marshal(CollectionChange{
+		User: user,
+		Collection: collection,
+		Event: event,
+		Time: time.Now(),
+	})
+	if err!=nil {
+		aService.logger.Println("failed to marshal webhook payload", err)
+		return
+	}
+	signature:= aService.webhookSigner.Sign(payload)
+
+	for _, hook:= range hooks{
+		if hook.Disabled {
+			continue
+		}
+
+		err = attemptDelivery(aService.webhookClient, hook.URL,
+			payload, signature, maxWebhookAttempts, webhookRetryDelay)
+		if err == nil {
+			err = userDB.RecordWebhookSuccess(aService.db(), user, hook.URL)
+			if err!=nil {
+				aService.logger.Println("failed to record webhook success", err)
+			}
+			continue
+		}
+
+		disabled, err:= userDB.RecordWebhookFailure(aService.db(),
+			user, hook.URL)
+		if err!=nil {
+			aService.logger.Println("failed to record webhook failure", err)
+			continue
+		}
+		if disabled {
+			err = aService.sendWebhookDisabled(user, hook.URL)
+			if err!=nil {
+				aService.logger.Println("failed to send email", err)
+			}
+		}
+	}
+
+}
+
+// Lets a user know one of their webhooks failed persistently and
+// will no longer be delivered to.
+func (aService *UserService) sendWebhookDisabled(user, hookURL string) error {
+
+	u, err:= userDB.GetUser(aService.db(), user)
+	if err!=nil {
+		return err
+	}
+
+	contents:= webhookDisabledEmailContents{
+		Name: user,
+		URL: hookURL,
+	}
+	targetAddress:= mailer.FormatAddress(user, u.Email)
+
+	return aService.sendMail("webhookDisabled", contents,
+		targetAddress, "Webhook Disabled - Preorda.in")
+
+}
@@ -0,0 +1,224 @@
+package ApiServices
+
+import(
+
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"io/ioutil"
+	"sync"
+
+	"testing"
+
+	"time"
+
+)
+
+func testSigner(t *testing.T) *webhookSigner {
+
+	signer, err:= newWebhookSigner(webhookMeta{
+		Current: "foo",
+		RotationWindowHours: 1,
+	}, "")
+	if err!=nil {
+		t.Fatal(err)
+	}
+
+	return signer
+
+}
+
+// A payload signed before a rotation should verify during the window
+// but not after it.
+func TestWebhookPreviousSecretWindow(t *testing.T) {
+
+	signer:= testSigner(t)
+	payload:= []byte(`{"Collection":"bar"}`)
+
+	oldSignature:= signer.Sign(payload)
+
+	now:= time.Now()
+	err:= signer.rotate([]byte("baz"), now)
+	if err!=nil {
+		t.Fatal(err)
+	}
+
+	if signer.Sign(payload) == oldSignature {
+		t.Fatal("outgoing payloads not signed with the current secret")
+	}
+
+	if !signer.Verify(payload, oldSignature, now.Add(time.Minute)) {
+		t.Fatal("previous secret rejected during rotation window")
+	}
+	if signer.Verify(payload, oldSignature, now.Add(2 * time.Hour)) {
+		t.Fatal("previous secret accepted after rotation window")
+	}
+	if !signer.Verify(payload, signer.Sign(payload), now.Add(2 * time.Hour)) {
+		t.Fatal("current secret rejected")
+	}
+
+}
+
+func TestWebhookRetirePrevious(t *testing.T) {
+
+	signer:= testSigner(t)
+	payload:= []byte(`{"Collection":"bar"}`)
+
+	oldSignature:= signer.Sign(payload)
+
+	now:= time.Now()
+	err:= signer.rotate([]byte("baz"), now)
+	if err!=nil {
+		t.Fatal(err)
+	}
+	err = signer.retirePrevious()
+	if err!=nil {
+		t.Fatal(err)
+	}
+
+	if signer.Verify(payload, oldSignature, now) {
+		t.Fatal("retired secret still accepted")
+	}
+
+}
+
+func TestWebhookTamperedPayload(t *testing.T) {
+
+	signer:= testSigner(t)
+
+	signature:= signer.Sign([]byte("foo"))
+	if signer.Verify([]byte("bar"), signature, time.Now()) {
+		t.Fatal("signature accepted for a different payload")
+	}
+
+}
+
+// A receiver which always fails should be tried exactly the maximum
+// number of times before the delivery is abandoned.
+func TestWebhookRetriesCapped(t *testing.T) {
+
+	var lock sync.Mutex
+	received:= 0
+	receiver:= httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			lock.Lock()
+			received++
+			lock.Unlock()
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+	defer receiver.Close()
+
+	err:= attemptDelivery(receiver.Client(), receiver.URL,
+		[]byte("{}"), "foo", maxWebhookAttempts, time.Millisecond)
+	if err == nil {
+		t.Fatal("failing delivery reported as successful")
+	}
+	if received != maxWebhookAttempts {
+		t.Fatal("incorrect number of delivery attempts", received)
+	}
+
+}
+
+func TestWebhookDeliverySigned(t *testing.T) {
+
+	signer:= testSigner(t)
+	payload:= []byte(`{"Collection":"bar"}`)
+
+	var verified bool
+	receiver:= httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			body, _:= ioutil.ReadAll(r.Body)
+			verified = signer.Verify(body,
+				r.Header.Get(webhookSignatureHeader), time.Now())
+		}))
+	defer receiver.Close()
+
+	err:= attemptDelivery(receiver.Client(), receiver.URL,
+		payload, signer.Sign(payload), maxWebhookAttempts, time.Millisecond)
+	if err!=nil {
+		t.Fatal("delivery failed", err)
+	}
+	if !verified {
+		t.Fatal("delivered payload failed verification")
+	}
+
+}
+
+func TestValidWebhookURL(t *testing.T) {
+
+	if !validWebhookURL("https://example.com/hooks") {
+		t.Fatal("valid url rejected")
+	}
+
+	for _, raw:= range []string{"", "example.com", "ftp://example.com",
+		"javascript:alert(1)", "https://", "http://127.0.0.1/hooks",
+		"http://localhost:8080", "http://10.0.0.1", "http://[::1]/",
+		"http://169.254.169.254/latest/meta-data"}{
+		if validWebhookURL(raw) {
+			t.Fatal("invalid url accepted", raw)
+		}
+	}
+
+}
+
+// Names resolving to loopback must be refused when dialed, whatever
+// the url looked like when registered.
+func TestWebhookLoopbackRefused(t *testing.T) {
+
+	received:= false
+	receiver:= httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			received = true
+		}))
+	defer receiver.Close()
+
+	err:= deliverOnce(newWebhookClient(publicOnlyControl), receiver.URL,
+		[]byte("{}"), "foo")
+	if err == nil || received {
+		t.Fatal("delivered to a loopback address", err)
+	}
+
+}
+
+// Redirects must count as failures rather than being followed.
+func TestWebhookRedirectRefused(t *testing.T) {
+
+	followed:= false
+	target:= httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			followed = true
+		}))
+	defer target.Close()
+
+	receiver:= httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			http.Redirect(w, r, target.URL, http.StatusTemporaryRedirect)
+		}))
+	defer receiver.Close()
+
+	// Test servers are on loopback so every address is permitted here
+	err:= deliverOnce(newWebhookClient(nil), receiver.URL,
+		[]byte("{}"), "foo")
+	if err == nil || followed {
+		t.Fatal("redirect followed", err)
+	}
+
+}
+
+func TestPublicIP(t *testing.T) {
+
+	for _, raw:= range []string{"127.0.0.1", "10.1.2.3", "172.16.0.1",
+		"192.168.1.1", "169.254.169.254", "100.64.0.1", "0.0.0.0", "::1",
+		"fe80::1", "fc00::1", "::ffff:127.0.0.1"}{
+		if publicIP(net.ParseIP(raw)) {
+			t.Fatal("internal address considered public", raw)
+		}
+	}
+
+	for _, raw:= range []string{"93.184.216.34", "2606:2800:220:1::1"}{
+		if !publicIP(net.ParseIP(raw)) {
+			t.Fatal("public address refused", raw)
+		}
+	}
+
+}
@@ -7,14 +7,25 @@ import(
 
 	"./ApiServices"
 
+	"context"
 	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 )
 
+// How long in-flight requests and background work are given to
+// finish once asked to stop.
+const shutdownTimeout time.Duration = 30 * time.Second
+
 func main() {
 
 	userService:= ApiServices.NewUserService()
 
 	restful.Add(userService.Service)
+	restful.Add(userService.Health)
+	restful.Add(userService.Metrics)
 
 	// Add container filter to enable CORS
 	/*
@@ -29,8 +40,35 @@ func main() {
 	// Ensure we aren't sending stack traces out in the event we panic.
 	restful.DefaultContainer.RecoverHandler(ApiServices.RecoverHandler)
 
+	server:= &http.Server{Addr: ":9035"}
+	go func() {
+		err:= server.ListenAndServe()
+		if err!=nil && err != http.ErrServerClosed {
+			fmt.Println("goPrices user server failed", err)
+			os.Exit(1)
+		}
+	}()
+
 	fmt.Println("goPrices user server ready")
 
-	http.ListenAndServe(":9035", nil)
+	// Deploys stop us with SIGTERM, developers with ctrl-c
+	stops:= make(chan os.Signal, 1)
+	signal.Notify(stops, syscall.SIGTERM, os.Interrupt)
+	<-stops
+
+	fmt.Println("goPrices user server draining")
+
+	ctx, cancel:= context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+
+	// Stop accepting requests and let those in flight finish before
+	// the service lets go of the database
+	err:= server.Shutdown(ctx)
+	if err!=nil {
+		fmt.Println("requests outlived shutdown", err)
+	}
+	userService.Shutdown(ctx)
+
+	fmt.Println("goPrices user server stopped")
 	
 }
\ No newline at end of file
@@ -0,0 +1,50 @@
+package mtgjson
+
+import(
+
+	"strings"
+
+	"unicode"
+
+)
+
+// Accented characters mapped to the plain form users type.
+//
+// Ligatures such as Æ expand to multiple characters.
+var foldedRunes = map[rune]string{
+	'à': "a", 'á': "a", 'â': "a", 'ã': "a", 'ä': "a", 'å': "a", 'ā': "a",
+	'ç': "c", 'ć': "c", 'č': "c",
+	'è': "e", 'é': "e", 'ê': "e", 'ë': "e", 'ē': "e", 'ė': "e", 'ę': "e",
+	'ì': "i", 'í': "i", 'î': "i", 'ï': "i", 'ī': "i",
+	'ñ': "n", 'ń': "n",
+	'ò': "o", 'ó': "o", 'ô': "o", 'õ': "o", 'ö': "o", 'ø': "o", 'ō': "o",
+	'ù': "u", 'ú': "u", 'û': "u", 'ü': "u", 'ū': "u",
+	'ý': "y", 'ÿ': "y",
+	'ž': "z", 'ź': "z", 'ż': "z",
+	'š': "s", 'ś': "s",
+	'ł': "l",
+	'æ': "ae", 'œ': "oe", 'ß': "ss",
+}
+
+// Normalizes a card name to the form used as a lookup key.
+//
+// Names are lower cased and diacritics folded away so 'Æther Vial',
+// 'AEther Vial' and 'aether vial' all normalize identically.
+func NormalizeCardName(name string) string {
+
+	var normal []string
+	for _, r:= range strings.ToLower(name){
+		if folded, ok:= foldedRunes[r]; ok {
+			normal = append(normal, folded)
+			continue
+		}
+		// Drop any remaining combining marks from decomposed input
+		if unicode.Is(unicode.Mn, r) {
+			continue
+		}
+		normal = append(normal, string(r))
+	}
+
+	return strings.Join(normal, "")
+
+}
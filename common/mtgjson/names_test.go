@@ -0,0 +1,29 @@
+package mtgjson
+
+import(
+
+	"testing"
+
+)
+
+func TestNormalizeCardName(t *testing.T) {
+
+	typed:= map[string]string{
+		"Æther Vial": "aether vial",
+		"AEther Vial": "aether vial",
+		"Séance": "seance",
+		"Lim-Dûl's Vault": "lim-dul's vault",
+		"Jötun Grunt": "jotun grunt",
+		"Márton Stromgald": "marton stromgald",
+		"Dandân": "dandan",
+		// Decomposed form, e followed by a combining acute
+		"Se\u0301ance": "seance",
+	}
+
+	for name, expected:= range typed{
+		if NormalizeCardName(name) != expected {
+			t.Fatal("incorrectly normalized", name, NormalizeCardName(name))
+		}
+	}
+
+}
@@ -1,27 +1,36 @@
 // Code generated by go-bindata.
 // sources:
+// sql/addPriceBuylist.sql
 // sql/addPriceMKMprice.sql
 // sql/addPriceMTGprice.sql
 // sql/bulkExtrema.sql
 // sql/bulkLatest.sql
+// sql/buylistPriceLatest.sql
+// sql/buylistPriceLatestBatch.sql
 // sql/historicalPriceMKMprice.sql
 // sql/historicalPriceMTGprice.sql
 // sql/medianMKM.sql
 // sql/medianMtgprice.sql
 // sql/mkmPriceClosest.sql
+// sql/mkmPriceClosestBatch.sql
 // sql/mkmPriceLastest.sql
+// sql/mkmPriceLatestBatch.sql
 // sql/mkmPriceLatestHighest.sql
 // sql/mkmPriceLatestLowest.sql
 // sql/mkmPriceSetLatest.sql
 // sql/mkmPriceWeeksHigh.sql
 // sql/mkmPriceWeeksLow.sql
 // sql/mtgPriceClosest.sql
+// sql/mtgPriceClosestBatch.sql
 // sql/mtgPriceLatest.sql
+// sql/mtgPriceLatestBatch.sql
 // sql/mtgPriceLatestHighest.sql
 // sql/mtgPriceLatestLowest.sql
 // sql/mtgPriceSetLatest.sql
 // sql/mtgPriceWeeksHigh.sql
 // sql/mtgPriceWeeksLow.sql
+// sql/rangeMKM.sql
+// sql/rangeMtgprice.sql
 // DO NOT EDIT!
 
 package priceDB
@@ -89,6 +98,26 @@ func (fi bindataFileInfo) Sys() interface{} {
 	return nil
 }
 
+var _sqlAddpricebuylistSql = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x65\x4e\x4b\x0e\xc2\x20\x10\xdd\x37\xe1\x0e\x6f\xd1\x85\x6d\x88\x8d\x9f\x0b\xb8\x70\xd1\x4d\x4d\xb4\x17\x40\xa0\x3a\x09\x02\x29\xb4\x4d\x6f\x2f\xa8\x3b\x17\x6f\x32\x93\xf7\x9b\xa6\x66\x05\x2b\x4e\x4a\x05\x08\x58\xbd\xe0\x3e\xad\x86\x42\x84\x1f\x49\x6a\x78\x47\x36\x72\x2c\x4f\x11\x13\x3f\x6b\xab\xdc\x88\x85\x8c\x81\x17\x2b\x86\x74\x08\x56\x04\xaf\x25\x0d\x24\x21\xc5\xa8\x20\xac\xca\x66\x1b\xc9\x3e\xe0\x06\xc4\xec\xcd\x0c\x47\x74\xdf\xd8\xb0\xfd\xb5\xe4\xee\xba\xc9\xb3\xed\x6e\xe7\x6b\x8f\xb6\xeb\x2f\x7f\x9a\x8d\x15\x2f\xcd\x11\x74\xfa\x24\x52\x5e\x3f\x8a\x8a\x15\xb3\x30\x93\x0e\x49\x51\xee\x38\xca\x7d\xc2\x21\xe1\x58\xbd\x01\x61\x3a\x6b\xd1\xd6\x00\x00\x00")
+
+func sqlAddpricebuylistSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_sqlAddpricebuylistSql,
+		"sql/addPriceBuylist.sql",
+	)
+}
+
+func sqlAddpricebuylistSql() (*asset, error) {
+	bytes, err := sqlAddpricebuylistSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "sql/addPriceBuylist.sql", size: 214, mode: os.FileMode(438), modTime: time.Unix(1791967047, 0)}
+	a := &asset{bytes: bytes, info:  info}
+	return a, nil
+}
+
 var _sqlAddpricemkmpriceSql = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x74\x8d\x31\xae\xc2\x30\x0c\x86\xf7\x48\xb9\x83\x87\x0e\xaf\x55\xf4\xaa\xf7\x80\x03\x30\x30\x74\x29\x12\xf4\x02\x56\xe2\x16\x0b\x9a\x54\x89\x0b\xd7\x27\xa1\x33\xc3\x67\x59\xbf\x3f\xeb\x6f\x1b\xad\xb4\x3a\x3a\x97\x00\xc1\xd3\x0b\x96\xc8\x96\x60\x09\xec\x05\xc6\x10\x73\x9a\x16\xb2\x3c\xb2\x05\x8b\xd1\x01\x7a\x57\x1c\x2f\xec\x27\x08\x23\xc8\x0d\x45\xab\xcf\x49\xc2\xf6\x9d\x7e\x67\x9c\xd8\x96\x6c\xc6\x78\x27\x29\x15\x4d\x5b\x66\xd7\x5f\x4f\x97\x01\xba\x7e\x38\x7f\x75\x7f\x3c\xce\x64\x20\x91\x18\x10\x2e\x2b\xad\x31\x98\xcd\xaf\xb5\x7a\xe2\x63\xa5\x94\xbd\xea\xcf\x40\xf5\x9f\xd9\x65\xf6\x99\x43\xfd\x0e\x00\x00\xff\xff\x98\xb7\x68\x2b\xcf\x00\x00\x00")
 
 func sqlAddpricemkmpriceSqlBytes() ([]byte, error) {
@@ -169,6 +198,46 @@ func sqlBulklatestSql() (*asset, error) {
 	return a, nil
 }
 
+var _sqlBuylistpricelatestSql = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x2d\x8c\xb1\x0a\x83\x30\x00\x44\xf7\x40\xfe\xe1\x06\x27\x11\xc5\xae\xc5\xa1\xd5\x94\x0a\x5a\x21\x0a\xa5\x63\xd4\x48\x03\x1a\x25\x89\x43\xff\xbe\x6a\x3b\xdd\xe3\xb8\x77\x91\x4f\x09\x97\x6e\x35\xda\xc2\xbd\x25\x46\xe1\xa4\x75\x68\xd7\xcf\xa8\xb6\x5c\x97\x7e\x2b\x30\xcc\x06\x02\x9d\x30\x7d\x64\xa5\x43\x37\x4f\xad\xd2\xc2\xa9\x59\x87\x94\xf8\x11\x25\x94\xd4\xac\x60\x69\x03\x2d\x26\x19\x60\x1b\x05\x70\x6a\xc7\xc5\xa8\x4e\xe2\xc6\xab\xf2\x87\x36\xfc\x7f\x53\xf2\xbc\x33\xce\x0e\x23\xf1\x62\x5c\x1e\xd9\xee\x25\xde\x89\x92\x8a\x67\x8c\xe3\xfa\x3a\x3e\x90\xb1\x3a\x45\x91\x97\x79\x83\xf8\xfc\x05\x3a\xf3\xcb\x02\xb1\x00\x00\x00")
+
+func sqlBuylistpricelatestSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_sqlBuylistpricelatestSql,
+		"sql/buylistPriceLatest.sql",
+	)
+}
+
+func sqlBuylistpricelatestSql() (*asset, error) {
+	bytes, err := sqlBuylistpricelatestSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "sql/buylistPriceLatest.sql", size: 177, mode: os.FileMode(438), modTime: time.Unix(1791967047, 0)}
+	a := &asset{bytes: bytes, info:  info}
+	return a, nil
+}
+
+var _sqlBuylistpricelatestbatchSql = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x4d\x51\xcf\x6b\xc2\x30\x14\x3e\xaf\xd0\xff\xe1\x3b\x78\x70\xd2\x29\x7a\x74\xec\xd0\x69\xc6\x1c\xd5\x42\x5a\x18\x63\xec\x90\xb6\x11\xc3\x34\x29\x49\x3a\xd7\xff\x7e\x69\x6a\x9d\x87\x90\x97\xf7\xde\xf7\x8b\xcc\x26\x61\x40\xb9\x6d\xb4\x34\xb0\x07\x8e\x23\xb3\xdc\x58\x14\x4d\x7b\x14\xee\x6e\xea\xca\x35\xb0\x57\x1a\x9c\x95\x07\x94\x4c\x57\x33\xc3\x2d\x4a\x75\x2a\x84\x64\x56\x28\x89\x5a\xab\x1f\x51\xf1\x6a\x1a\x06\x61\x90\xb3\x6f\x6e\xc2\xe0\x6e\x34\xc7\x03\x98\xd6\xac\x85\xda\x7b\x1c\x24\x3b\xf5\xa3\xc5\xed\xa8\x63\xf3\x93\x08\x35\x13\x9a\x57\x38\x0b\x7b\xf0\x66\x7c\x1b\x45\x8b\x5a\x19\xd1\x49\x75\x02\xab\x7f\x65\xe3\x57\x55\x63\xc1\x64\x3b\x78\x65\xda\x9d\xc2\x70\x69\x9d\x9f\xc9\xac\x83\x64\x24\x21\xab\xfc\x92\x6d\xda\xb1\x46\xc3\xc3\xa9\x5f\x6b\x2b\x6e\x06\xb5\x16\x25\x0f\x83\x17\x9a\x6e\xd1\x48\xe9\x5a\xe3\xd1\x7c\xb9\xb4\xfc\xd7\x7e\x7e\x45\x18\x2d\x86\xfa\x1e\x71\x86\x33\x93\x96\x57\xe3\x9e\xda\x71\xde\x3b\x9f\x34\xcd\x32\xbc\xa5\x9b\x1d\x92\x38\x27\x34\x4e\x30\x76\xe1\x2f\x5e\xae\x9b\x11\x7a\x59\xaf\x07\x2f\xe7\x4b\x33\xbd\xfc\x81\xc3\xbc\xbf\x12\x4a\x3c\xe4\xa9\x17\xf2\x19\x10\xef\xd6\x1d\xc3\xd0\x73\xa5\xdb\x4d\xe9\x9a\x50\x3c\x7f\x78\x5a\xac\x49\xb6\x42\xb2\xd9\x6e\x72\xcc\xc3\xc0\x5b\xed\xf3\x3d\xfe\x01\x2f\x96\xdd\x7f\xf9\x01\x00\x00")
+
+func sqlBuylistpricelatestbatchSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_sqlBuylistpricelatestbatchSql,
+		"sql/buylistPriceLatestBatch.sql",
+	)
+}
+
+func sqlBuylistpricelatestbatchSql() (*asset, error) {
+	bytes, err := sqlBuylistpricelatestbatchSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "sql/buylistPriceLatestBatch.sql", size: 505, mode: os.FileMode(438), modTime: time.Unix(1791974969, 0)}
+	a := &asset{bytes: bytes, info:  info}
+	return a, nil
+}
+
 var _sqlHistoricalpricemkmpriceSql = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x2c\xce\x31\x4f\xc3\x30\x10\x05\xe0\xdd\x92\xff\xc3\x1b\x3a\x40\x15\xb5\xd0\x81\x05\x75\x40\x10\xc4\x50\x40\x0a\x48\xcc\xc6\xb9\x14\x8b\xd8\x8e\xee\xae\x03\x0b\xbf\x9d\x5c\xe8\x76\x6f\xf8\xde\xbd\xed\xda\xbb\x8e\xf4\xc4\x45\x10\xc6\x11\x13\xa7\x48\x82\xa1\x32\x82\x85\xa2\xa9\x1c\x51\x87\x39\xc9\x44\x31\x0d\x29\x22\x06\xee\x37\xde\x79\x77\x48\x39\xa9\x40\x2b\x6e\xae\xfe\x25\xa6\x3a\x13\xb9\xf8\xdd\x21\xd7\xa2\x5f\x72\x69\xb6\x0f\x1a\xbc\x5b\x6f\xcd\xbc\xb5\x87\xf6\xfe\x1d\x25\x64\x6a\x20\xa4\x0d\x34\xd9\xb9\xf0\x06\x74\xe2\x8a\xc7\xee\xf5\xf9\xbc\x64\x93\xc3\x31\x45\xfb\x98\x03\x7f\x93\xe2\xe3\xa9\xed\x5a\xef\xcc\xef\x57\xd7\xb8\x7b\x79\xb0\x96\xfd\x6a\x87\xca\x3d\x31\x3e\x7f\x96\x42\xf4\x24\x11\xa3\x0d\x9c\xc7\xdd\xfe\x05\x00\x00\xff\xff\xb8\xd0\xf1\xa0\xe7\x00\x00\x00")
 
 func sqlHistoricalpricemkmpriceSqlBytes() ([]byte, error) {
@@ -269,6 +338,26 @@ func sqlMkmpriceclosestSql() (*asset, error) {
 	return a, nil
 }
 
+var _sqlMkmpriceclosestbatchSql = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x8d\x52\xc1\x6e\xa3\x30\x10\x3d\x17\x89\x7f\x98\x43\x0e\xa4\xa2\x44\x69\x6f\x5d\x75\x25\x36\x65\x55\x56\x34\x59\x11\xa4\xaa\xaa\x7a\x70\xc0\x49\xac\xc4\x36\xb2\xcd\x66\xf3\xf7\x3b\x36\x66\xe1\xd8\x83\x25\xcf\x8c\xdf\x9b\x37\xcf\xb3\xb8\x0d\x83\x92\x9a\x4e\x09\x0d\xe6\x48\xa1\x55\xac\xa6\x50\x9f\xa5\xa6\xda\x80\x91\x3e\x29\xff\xb0\x86\x36\x60\x18\xa7\xb0\x97\x0a\x28\xa9\x8f\x50\x13\xd5\x2c\x34\x35\x61\x50\x4b\xbe\x63\x82\x18\x26\xc5\xff\xc7\x31\xd4\x47\x64\x11\x40\x34\xf0\x13\xff\x6d\x89\x57\x9e\xf7\x22\xbb\x73\x93\x84\x41\x18\x54\xe4\x44\x75\x18\xdc\xcc\x96\x70\x07\x44\x29\x72\x05\xb9\x77\xcc\x20\x08\xef\x4b\xf7\xd3\x12\xf6\xeb\x2b\x31\xb4\x84\x29\x14\x75\x61\xe6\xe8\x64\xba\x34\xec\xae\xd0\x4a\xcd\xac\x16\x0b\x7e\x98\x82\xad\xfe\xaf\x01\xc3\x20\xb3\x23\xf6\x76\x30\x0d\xca\x79\x84\x20\x72\x96\xe2\xa0\x71\xc0\xde\x19\x0f\x70\xe4\x18\x4f\x8d\x60\x68\x0c\x22\xd1\x2e\xb4\x42\x76\xc2\x30\x71\x80\xbd\x92\x1c\x96\x09\xac\xc6\x87\xda\x09\x91\x9d\x01\x22\xae\xbe\x23\x51\x78\x76\xe8\x9e\x41\x97\x6e\x17\x56\xcf\x36\x2b\xb2\x55\x05\x17\x22\x0c\x6d\x92\xa1\x71\x3c\xfc\x55\x62\x87\x18\x23\xb4\x69\x0c\xec\xd8\x31\x9a\x31\xc4\xae\xc7\x58\xa6\x9d\x92\x61\xf0\xb3\xdc\xbc\x42\x27\x04\x66\xa2\xd9\xf2\xf1\xd1\xd0\xbf\xe6\xe3\x33\x86\xd9\xfd\xe4\xfe\x80\x77\xeb\xa1\x21\xbc\xfd\xf8\x9c\x23\xe7\x5b\x5e\xbd\xc0\xa6\x7c\xce\xd7\x69\x91\x57\xef\x90\x6e\xbd\xc4\xa8\x17\xe4\x84\x10\x3c\x83\x62\x04\xad\xca\xcd\x76\x0b\xbf\x36\xf9\x1a\x8a\xb4\xca\xca\xb4\x80\x08\xa9\xfc\x84\x13\x9c\x13\x0e\x5e\xae\x95\x09\x4e\xa5\x4b\xe8\x84\x93\x03\xab\xed\xaa\x70\xa2\x4e\x76\x0d\x6f\xde\x5e\xb2\x32\x73\x04\x4f\xde\x27\x7b\x87\x74\xfd\x6c\xf9\x86\x9c\x5d\x21\x9b\xf2\x21\x31\xf0\xdd\x75\x42\x02\x1c\x24\x2b\xe1\xc7\xbb\x35\x3f\xc2\xa1\x15\xa9\x4d\x44\x5b\x89\xab\xe0\x7e\x6e\x84\xdc\x39\xc8\x7c\x0e\x45\xfe\x9a\x57\xb0\x0c\x83\xb9\x9d\xdd\x7b\xfa\xed\x1f\x71\xcb\x9c\x94\x58\x03\x00\x00")
+
+func sqlMkmpriceclosestbatchSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_sqlMkmpriceclosestbatchSql,
+		"sql/mkmPriceClosestBatch.sql",
+	)
+}
+
+func sqlMkmpriceclosestbatchSql() (*asset, error) {
+	bytes, err := sqlMkmpriceclosestbatchSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "sql/mkmPriceClosestBatch.sql", size: 856, mode: os.FileMode(438), modTime: time.Unix(1791976002, 0)}
+	a := &asset{bytes: bytes, info:  info}
+	return a, nil
+}
+
 var _sqlMkmpricelastestSql = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x24\x8c\xc1\x6a\x84\x30\x18\x84\xef\x81\xbc\xc3\x1c\x3c\x89\x28\xf6\x5a\x3c\xb4\x9a\x52\x41\x2b\x44\xa1\xf4\x98\xc6\xb4\x0d\xdd\x24\x92\xfc\xbe\xff\xaa\x7b\x9a\x8f\x61\xe6\xab\x72\xce\xa4\xa1\x3d\xfa\x04\xfa\x33\xb8\x29\x32\x89\xb0\x6f\xeb\x01\xf8\x09\x11\x0a\x5a\xc5\xb5\x4a\x86\xa0\x83\xfb\xb6\x5e\x91\x0d\xbe\xe4\x2c\xaf\x38\xe3\x6c\x16\x83\x68\x17\x78\xe5\x4c\x81\x63\x54\x80\xec\x89\x5b\xb4\xfa\x08\xb3\xc7\x80\x37\x39\x8d\x8f\x22\x95\x4e\xfd\x5a\x7d\x1a\x9d\x8a\xff\x86\x38\xfb\x7c\x17\x52\x5c\xff\x26\xab\xf1\xf2\xd1\x9d\x96\x26\x7b\xe2\x6c\x92\x9d\x90\x78\xfd\xba\x8c\xe8\xc4\xdc\x62\xe8\xc7\x7e\x41\xfd\x7c\x0f\x00\x00\xff\xff\x0f\xed\x56\xa9\xb7\x00\x00\x00")
 
 func sqlMkmpricelastestSqlBytes() ([]byte, error) {
@@ -289,6 +378,26 @@ func sqlMkmpricelastestSql() (*asset, error) {
 	return a, nil
 }
 
+var _sqlMkmpricelatestbatchSql = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x4d\x90\xcd\x6e\xc2\x30\x10\x84\xcf\x8d\x94\x77\x98\x03\x07\x8a\x52\x10\x1c\xa9\x7a\x48\xc1\x55\xa9\x02\x91\x92\x48\x55\x55\xf5\x60\x12\x53\x2c\x1a\x3b\xb2\x9d\x52\xde\xbe\xb6\x43\x80\x83\xa5\xf5\xfe\xcc\x37\xbb\x93\x51\x18\x64\xcc\xb4\x4a\x68\x98\x3d\xc3\x0f\x35\x4c\x1b\xb4\x4d\x65\x03\xec\xa4\x02\xa3\xe5\x1e\x25\x55\xd5\x44\x33\x83\x52\xd6\x5b\x2e\xa8\xe1\x52\xa0\x51\xf2\x97\x57\xac\x1a\x87\x41\x18\x14\xf4\xc0\x74\x18\xdc\x0d\xa6\x78\x00\x55\x8a\x9e\x20\x77\x7e\x0e\x82\xd6\x5d\x69\x76\x5b\x72\x6a\xbe\x12\xa1\xa1\x5c\xb1\x0a\x47\x6e\xf6\xde\x84\x4f\x63\x7b\x42\x23\x35\x77\x28\x07\x58\x5c\xc9\xda\xb7\xca\xd6\x80\x8a\x53\xef\x95\x2a\xfb\xb6\x9a\x09\x63\xfd\x8c\x26\x6e\x24\x27\x09\x59\x14\xe7\x9d\xc6\x4e\x35\xea\x3f\x96\x7e\x89\x0d\xbf\x29\x34\x8a\x97\xd7\x1f\x6b\x95\x0c\x83\x97\x2c\x5d\xa3\x15\xc2\x66\x86\x83\xe9\x7c\x6e\xd8\x9f\xf9\xfc\x8a\x30\x98\xf5\xf1\x3d\xe2\x1c\x47\x2a\x0c\xab\x86\x1d\xc7\x02\xee\xad\xe9\x2c\xcd\x73\xbc\xa5\xab\x0d\x92\xb8\x20\x59\x9c\x60\x68\x2f\x71\x36\x76\xe9\x8c\xd0\x79\x38\xc3\x1d\x15\x1e\xea\x13\x7a\x5c\xd3\x6f\x5e\xba\x5b\xd6\x54\x1d\x98\xb1\x0a\xef\xaf\x24\x23\x5e\xe0\xa9\xc3\xfa\xf5\x10\x6f\x96\x4e\xaf\xcf\x69\xdf\x9b\x66\x4b\x92\xe1\xf9\xc3\x43\xb0\x24\xf9\x02\xc9\x6a\xbd\x2a\x30\x0d\x03\x6f\xbc\x5b\xf6\xf1\x1f\x63\xe2\xd2\x3c\x0c\x02\x00\x00")
+
+func sqlMkmpricelatestbatchSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_sqlMkmpricelatestbatchSql,
+		"sql/mkmPriceLatestBatch.sql",
+	)
+}
+
+func sqlMkmpricelatestbatchSql() (*asset, error) {
+	bytes, err := sqlMkmpricelatestbatchSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "sql/mkmPriceLatestBatch.sql", size: 524, mode: os.FileMode(438), modTime: time.Unix(1791974969, 0)}
+	a := &asset{bytes: bytes, info:  info}
+	return a, nil
+}
+
 var _sqlMkmpricelatesthighestSql = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x7c\x90\x4d\x6f\x82\x40\x10\x86\xcf\x6c\xb2\xff\xe1\x3d\x34\x11\x0c\xad\xf5\x6a\x3f\x2e\xad\x69\x4c\x1a\x7b\xd0\xbb\xd9\xc2\x08\x1b\x59\x96\xec\x6e\x25\xfe\xfb\xce\x8a\x34\x7a\xe9\x09\x18\x9e\x77\xe6\x99\x99\x4d\xa5\xf8\xa0\x80\x50\x13\x6a\x5d\xd5\xe4\x43\x8e\xce\x7a\x1d\xf4\x91\xd0\x39\x5d\x10\xec\x1e\x0a\x85\x72\x25\x54\xe1\xac\xf7\x50\x4d\x13\x8b\x3a\xf8\x48\xb4\x41\xb7\x95\x9f\x78\x29\x1a\x15\x38\x3f\xa4\xfc\x83\x14\xd3\x99\x14\x52\xf4\x3a\xd4\x60\xe6\xb4\xeb\x75\x5b\xda\x1e\xca\x23\x95\x22\xf1\xd4\x50\x11\x30\xc5\xde\x59\x33\x86\x8c\xaa\x74\x11\x67\x19\xe5\x0e\x14\x18\xeb\x6b\x72\xc4\xcf\xa4\x55\x86\x5e\xee\xe6\x50\x6d\x79\xfe\xb4\x7d\x9a\xe1\x9e\x3b\x1b\xc2\x33\x26\x73\xf4\x44\x87\xc9\x62\xc1\x42\xe4\x8e\xaa\x19\xc1\x61\x89\x57\x3c\x4a\x91\xe5\x52\xb0\xfc\xce\x13\xab\xdf\x6a\x70\x69\x10\xb9\x36\x3d\xcf\xc6\x38\xb8\x72\xf6\xa7\xc3\xf7\x29\xb2\xdc\x4b\x8a\x4b\x34\xfe\xcf\x63\x31\x3f\xcb\xe4\x97\xb3\xc5\x6e\x69\x3c\x40\xb2\x59\x7e\x2e\xdf\xb6\x78\x5f\x6d\xb6\xab\x35\xbf\x7c\xad\x53\xa6\xb3\xff\x82\xd7\x1a\xd7\x47\x88\x9a\xba\x45\x7a\x7b\xbc\x71\x27\x76\x4a\xac\x2b\xc9\x5d\x2c\x87\xbe\x28\xc9\x17\x51\x24\x8b\x3b\x07\x32\x1d\xfe\xa0\x61\x62\x04\xd0\x68\xa3\x03\xe6\x4f\xbf\x01\x00\x00\xff\xff\xbb\x3b\xa7\x73\x13\x02\x00\x00")
 
 func sqlMkmpricelatesthighestSqlBytes() ([]byte, error) {
@@ -409,6 +518,26 @@ func sqlMtgpriceclosestSql() (*asset, error) {
 	return a, nil
 }
 
+var _sqlMtgpriceclosestbatchSql = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x8d\x52\x4d\x6b\xe3\x30\x10\x3d\xd7\xe0\xff\x30\x87\x1c\x9c\x92\x3a\xa4\xbd\x75\xd9\x05\x6f\xea\x52\x17\x37\x59\x1c\x43\x29\xa5\x07\xc5\x56\x12\xb1\xb1\x64\xa4\x71\xd3\xfc\xfb\x8e\x64\x65\xed\xe3\x1e\x04\xf3\xa1\xf7\xe6\xcd\x93\xe6\xd7\x61\x50\x70\xec\xb4\x34\x80\x07\x0e\xad\x16\x15\x87\xea\xa8\x0c\x37\x08\xa8\x7c\x51\x7d\x8a\x9a\xd7\x80\xa2\xe1\xb0\x53\x1a\x38\xab\x0e\x50\x31\x5d\xcf\x0d\xc7\x30\xa8\x54\xb3\x15\x92\xa1\x50\xf2\xdf\xe5\x19\x54\x07\x62\x91\xc0\x0c\x34\xb8\xff\x63\x89\x97\x9e\xf7\xa4\xba\x63\x1d\x87\x41\x18\x94\xec\x2f\x37\x61\x70\x35\x59\xc0\x0d\x30\xad\xd9\x19\xd4\xce\x31\x83\x64\x4d\xdf\xba\x1d\xb7\x68\x5e\xdf\x99\x41\xcb\x84\x26\x51\x27\x81\x07\x27\xd3\x95\x61\x7b\x86\x56\x19\x61\xb5\x58\xf0\xdd\x18\x6c\xf5\xff\x1f\x30\x0c\x52\xbb\x62\x6f\x87\x30\xa0\x9d\x47\x04\x62\x47\x25\xf7\x86\x16\xec\x9d\xf1\x00\x47\x4e\xf9\xd8\x08\x41\xc6\x10\x92\xec\x22\x2b\x54\x27\x51\xc8\x3d\xec\xb4\x6a\x60\x11\xc3\x72\xb8\x68\x9c\x10\xd5\x21\x30\x79\xf6\x13\x99\xa6\xb3\x25\xf7\x90\x5c\xba\x9e\x5b\x3d\x9b\x34\x4f\x97\x25\x9c\x98\x44\x5e\xc7\x97\xc1\xb3\xcb\x5b\xc5\x76\x89\x21\x23\x9b\x86\xc4\xae\x3d\x64\x6e\x42\x18\x3c\x16\xeb\x17\xe8\xa4\xa4\x52\x34\x59\xdc\xdf\x23\xff\xc2\xf7\x8f\x19\x4c\x6e\x47\xf1\x1d\xc5\xd6\x33\x64\x4d\xfb\xfe\x31\x25\x43\x5f\xb3\xf2\x09\xd6\xc5\x43\xb6\x4a\xf2\xac\x7c\x83\x64\xe3\x25\x45\xbd\x00\x37\x98\xd1\xb9\x28\x24\xd0\xb2\x58\x6f\x36\xf0\xbc\xce\x56\x90\x27\x65\x5a\x24\x39\x44\x44\xe5\x37\x1a\xe1\x7a\xa1\xbd\x05\x4e\x9f\x0b\x4d\x4c\xff\xc7\xab\xbe\x7a\x7d\x4a\x8b\xd4\x61\x7e\x7a\x2b\x6c\x0c\xc9\xea\xc1\x52\x5c\x6a\xf6\x97\xd8\x92\x4f\x19\xc2\x2f\x47\x4e\x04\xa4\x3d\x2d\xe0\xf7\x9b\xf5\x37\xa2\x3d\x35\xab\x30\xe2\xad\xa2\xd7\x76\x8f\x33\x40\x6e\x1c\x64\x3a\x85\x3c\x7b\xc9\x4a\x58\x84\xc1\xd4\xae\xeb\x7d\xfc\xf1\x0d\x2b\x81\x14\x53\x3b\x03\x00\x00")
+
+func sqlMtgpriceclosestbatchSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_sqlMtgpriceclosestbatchSql,
+		"sql/mtgPriceClosestBatch.sql",
+	)
+}
+
+func sqlMtgpriceclosestbatchSql() (*asset, error) {
+	bytes, err := sqlMtgpriceclosestbatchSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "sql/mtgPriceClosestBatch.sql", size: 827, mode: os.FileMode(438), modTime: time.Unix(1791976002, 0)}
+	a := &asset{bytes: bytes, info:  info}
+	return a, nil
+}
+
 var _sqlMtgpricelatestSql = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x24\xcc\xc1\x0b\x82\x30\x1c\xc5\xf1\xfb\x60\xff\xc3\x3b\x78\x12\x51\xec\x1a\x1e\x4a\x17\x09\x9a\x30\x85\xe8\xb8\x74\xd5\x20\xa7\x6c\x3f\xff\xff\xd4\x6e\x9f\xc3\xfb\xbe\x24\xe4\x4c\x6a\x5a\x9c\xf5\xa0\x8f\xc6\x57\x91\xf6\x84\x65\x1e\x56\xe0\x35\x39\x28\xf4\xca\x0d\x89\xd7\x84\x7e\x1a\x9f\xc6\x2a\x32\x93\x8d\x39\x0b\x13\xce\x38\x6b\x45\x25\xf2\x0e\x56\x8d\x3a\xc2\x3a\x8a\x40\x66\xe3\xec\x4c\xaf\x71\x91\x4d\xfd\xa7\x8f\x47\x7a\xef\xe2\xec\x7e\x15\x52\xec\x49\x16\xa4\x38\xdd\x8a\x2d\xcc\x82\x03\x67\x8d\x2c\x84\xc4\xf9\xb1\x9f\xa0\x10\x6d\x8e\xaa\xac\xcb\x0e\xe9\xf1\x17\x00\x00\xff\xff\x43\xd3\x86\x0d\xaa\x00\x00\x00")
 
 func sqlMtgpricelatestSqlBytes() ([]byte, error) {
@@ -429,6 +558,26 @@ func sqlMtgpricelatestSql() (*asset, error) {
 	return a, nil
 }
 
+var _sqlMtgpricelatestbatchSql = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x4d\x4f\xcf\x4f\xc2\x30\x18\x3d\xb3\x64\xff\xc3\x3b\x70\x00\x32\x21\x70\xc4\x78\x98\x50\x23\x66\xb0\xa4\x5b\x62\x8c\xf1\x50\xb6\x22\x8d\xd2\x2e\x6d\x27\xf2\xdf\xdb\x75\x1b\x72\x68\xf2\xfa\xbd\xef\x7b\x3f\x66\x93\x30\xa0\xdc\xd6\x5a\x1a\xd8\x23\xc7\x37\xb3\xdc\x58\xd4\x55\xe9\x00\x0e\x4a\x83\xb3\xe2\x88\x82\xe9\x72\x66\xb8\x45\xa1\x4e\x7b\x21\x99\x15\x4a\xa2\xd2\xea\x47\x94\xbc\x9c\x86\x41\x18\xe4\xec\x8b\x9b\x30\x18\x0c\xe7\xb8\x03\xd3\x9a\x5d\xa0\x0e\xfe\x0e\x92\x9d\x5a\x6a\x71\x4b\x35\x6a\x9e\x89\x50\x31\xa1\x79\x89\xb3\xb0\x47\x1f\xc2\x8f\xb1\xbf\xa0\x52\x46\x34\x56\x8d\xc1\xea\xdf\xd9\xf8\x55\x55\x5b\x30\x79\xe9\xb3\x32\xed\xde\xde\x70\x69\x5d\x9e\xc9\xac\x39\xc9\x48\x42\x56\x79\xd7\x69\xda\xa8\x46\xfd\xc7\xb9\x5f\xb1\x15\x37\x44\xa5\x45\xc1\xc3\xe0\x89\xa6\x5b\xd4\x52\xba\xd1\x68\x38\x5f\x2e\x2d\xff\xb5\xef\x1f\x11\x86\x8b\x1e\x8f\x11\x67\x38\x33\x69\x79\x39\x6a\xa5\x9d\xe6\xd8\xe5\xa4\x69\x96\xe1\x25\xdd\xec\x90\xc4\x39\xa1\x71\x82\x91\x2b\xdf\x65\xb9\x6e\x46\x68\x6d\xbd\x1f\xbc\x9d\x87\x66\x7a\xb2\x9f\x5d\x88\xc1\xeb\x33\xa1\xc4\xdf\x3c\xb4\x4e\xbe\x04\xe2\xdd\xba\x91\xe8\x67\x0e\xba\xdd\x94\xae\x09\xc5\xe3\x9b\xd7\xc5\x9a\x64\x2b\x24\x9b\xed\x26\xc7\x3c\x0c\x7c\xd6\xb6\xe0\xfd\x1f\xc8\x7b\xd2\x1c\xf2\x01\x00\x00")
+
+func sqlMtgpricelatestbatchSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_sqlMtgpricelatestbatchSql,
+		"sql/mtgPriceLatestBatch.sql",
+	)
+}
+
+func sqlMtgpricelatestbatchSql() (*asset, error) {
+	bytes, err := sqlMtgpricelatestbatchSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "sql/mtgPriceLatestBatch.sql", size: 498, mode: os.FileMode(438), modTime: time.Unix(1791974969, 0)}
+	a := &asset{bytes: bytes, info:  info}
+	return a, nil
+}
+
 var _sqlMtgpricelatesthighestSql = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x7c\x90\xcf\x6e\xf2\x30\x10\xc4\xcf\xb1\xe4\x77\x98\xc3\x27\x91\xa0\x7c\xa5\x5c\xe9\x9f\x4b\x8b\x2a\xa4\x8a\x1e\xe0\x8e\xdc\x64\x49\xac\x26\x71\x64\xbb\x58\xbc\x7d\xbd\x09\xa9\xe0\xd2\x53\x9c\xdd\x99\x9d\xdf\xee\x62\x2e\xc5\x1b\x79\xf8\x9a\x50\xeb\xaa\x26\xe7\x73\xf4\xc6\x69\xaf\x4f\x84\xde\xea\x82\x60\x8e\x50\x28\x94\x2d\xa1\x0a\x6b\x9c\x83\x6a\x1a\x2e\x6a\xef\x58\xd1\x79\xdd\x55\x6e\xe6\xa4\x68\x94\x8f\xfe\xd1\xe5\xee\xa4\x98\x2f\xa4\x90\x22\x68\x5f\x23\x6a\xce\x87\xa0\xbb\xd2\x04\x28\x87\x54\x8a\xc4\x51\x43\x85\xc7\x1c\x47\x6b\xda\xc9\xd4\xfa\x6a\x78\xc5\x7e\xa8\xc9\xf2\x37\xe9\x54\x4b\x4f\xff\x96\x50\x5d\x39\xfc\x9a\x90\x66\xf8\x1f\x47\xb6\x84\x47\xcc\x96\x08\x44\x5f\xb3\xd5\x2a\x92\x90\x3d\xa9\x66\x12\x8e\xf4\xcf\xb8\x97\x22\xcb\xa5\x88\xd4\x07\x47\x91\xf9\x36\x3f\x96\x46\x82\x6b\xc4\x21\x1b\x53\x70\x65\xcd\x77\x8f\xcf\x33\x6b\xe3\x2c\x29\x2e\x56\xee\xe7\x5c\xcc\x07\x98\xfc\x72\x2f\x9e\x96\xf2\xe6\xc9\x6e\xfd\xbe\x7e\xd9\xe3\x75\xb3\xdb\x6f\xb6\xf1\xf1\xb1\x4d\xa3\x3a\xfb\xcb\x78\x8d\x71\x7d\x04\xc6\xd4\x1d\xd2\xdb\xab\x4d\x3b\x45\xa6\xc4\xd8\x92\xec\x85\x72\x9c\x8b\x92\x5c\xc1\x20\x19\xef\xec\xa9\xed\xf1\x2b\x1a\x13\x59\x80\x46\xb7\xda\x63\xf9\xf0\x13\x00\x00\xff\xff\x1c\xb0\x42\x55\x0c\x02\x00\x00")
 
 func sqlMtgpricelatesthighestSqlBytes() ([]byte, error) {
@@ -529,6 +678,46 @@ func sqlMtgpriceweekslowSql() (*asset, error) {
 	return a, nil
 }
 
+var _sqlRangemkmSql = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x45\x50\xcb\x4e\x03\x31\x0c\x3c\x13\x29\xff\xe0\xc3\x4a\xdb\x56\x0b\x55\x79\x5c\x80\xf2\x11\x88\x3b\xf2\x26\xde\x6d\xd4\x4d\x52\x39\x5e\xaa\xfe\x3d\xde\xb4\x88\x53\x26\x1e\x7b\x66\xec\xed\xc6\x9a\x4f\x92\x99\x53\x01\x39\x10\x44\xf2\x01\x13\x9c\x38\x38\x82\x21\x33\x20\x24\x8c\x04\x98\x3c\x14\x12\x70\x39\xf6\x21\xa1\x84\x9c\x20\x24\x20\x74\x07\xf0\x78\xb1\x46\x5b\xcf\x44\x47\xe8\x49\xf4\x4d\x20\xe7\x0c\x12\x22\x95\x0e\xf2\xe4\xa9\x08\x0c\x81\x8b\x58\x63\xcd\x17\x1e\xa9\xbc\x5a\x73\xb7\x28\x77\x55\xf6\xbe\x9a\xab\x6b\x92\x90\x46\xa5\x06\xce\xb1\x03\xc9\x37\xa6\x2a\x41\x9f\xe7\xe4\x95\xaf\xa5\x42\x1c\x16\xf5\xa5\x53\xa3\xb8\x69\x2e\xe1\x87\x74\x74\x64\x4c\xf3\x84\x1c\xe4\xa2\xd3\xad\xa6\x6b\x41\xd3\xb5\x4b\xbc\xd6\x9a\xcd\xd6\x9a\x42\x13\x39\xd1\xe0\x42\xdf\xc2\x73\x72\xab\xe6\xa5\xab\x26\xeb\xee\x76\x82\x55\x3d\xc1\xfa\x2a\x5f\x71\x79\x88\x38\x06\xe7\x90\x7d\x44\x3e\x92\xee\x72\x3e\x10\xab\xe5\xb2\xc7\xbe\xd9\xfd\x1d\x69\xdf\x3c\x56\xb8\xe8\xc1\xc7\x1e\x9a\xa7\xff\xef\x3b\x34\xcf\xd6\x8c\x9c\xe7\x13\xf4\x17\xd8\x69\x32\x4f\x7c\x85\x58\xdc\xdb\x2f\xfe\xfc\x48\x9d\x90\x01\x00\x00")
+
+func sqlRangemkmSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_sqlRangemkmSql,
+		"sql/rangeMKM.sql",
+	)
+}
+
+func sqlRangemkmSql() (*asset, error) {
+	bytes, err := sqlRangemkmSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "sql/rangeMKM.sql", size: 400, mode: os.FileMode(438), modTime: time.Unix(1791973633, 0)}
+	a := &asset{bytes: bytes, info:  info}
+	return a, nil
+}
+
+var _sqlRangemtgpriceSql = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x45\x50\xcb\x6e\xc3\x30\x0c\x3b\xcf\x80\xff\x81\x87\x00\x69\x8b\x6c\x45\xf7\xb8\x6c\xeb\x3e\x62\xd8\x7d\x70\x1c\x25\x35\x9a\xc8\x85\xad\xac\xe8\xdf\x4f\x71\x3b\xec\x24\x5a\x94\x28\x9a\xdb\x8d\x35\x9f\x24\x73\xe2\x0c\x39\x10\x26\xea\x82\x63\x9c\x52\xf0\x84\x3e\x26\x38\xb0\x9b\x08\x8e\x3b\x64\x12\xf8\x38\xb5\x81\x9d\x84\xc8\x08\x0c\x72\xfe\x80\xce\x5d\xac\xd1\xd1\x33\xd1\x11\x2d\x89\x56\x86\x9c\x23\x24\x4c\x94\x1b\xc4\xb1\xa3\x2c\xe8\x43\xca\x62\x8d\x35\x5f\xee\x48\xf9\xd5\x9a\xbb\x45\xb9\x29\xb2\xf7\xe5\xb8\x5e\x65\x09\x3c\x28\xd5\xa7\x38\x35\x90\x78\x63\x8a\x12\xda\x38\x73\xa7\x7c\x69\x65\x4a\x61\x51\x5f\x26\xd5\x8a\x1f\xe7\x1c\x7e\x48\x57\x87\xe4\x78\x1e\x5d\x0a\x72\xd1\xed\x5a\xdd\xd5\x50\x77\xf5\x62\xaf\xb6\x66\xb3\xb5\x26\xd3\x48\x5e\xd4\xb8\xd0\xb7\xa4\x99\xfd\xaa\x7a\x69\xca\x91\x75\x73\x8b\x60\x55\x22\x58\x5f\xe5\x0b\xce\x0f\x93\x0c\x05\x59\x73\x3e\x50\xd2\xb2\x7c\x60\x5f\xed\xfe\xd2\xd9\x57\x8f\x05\x2e\x42\xf8\xd8\xa3\x7a\xfa\x7f\xbe\xa3\x7a\xb6\x66\x48\x71\x3e\xa1\xbd\x60\xa7\x96\x3a\x4a\x57\xe8\xb2\x7f\xfb\x05\x95\x69\x79\x91\x89\x01\x00\x00")
+
+func sqlRangemtgpriceSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_sqlRangemtgpriceSql,
+		"sql/rangeMtgprice.sql",
+	)
+}
+
+func sqlRangemtgpriceSql() (*asset, error) {
+	bytes, err := sqlRangemtgpriceSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "sql/rangeMtgprice.sql", size: 393, mode: os.FileMode(438), modTime: time.Unix(1791973633, 0)}
+	a := &asset{bytes: bytes, info:  info}
+	return a, nil
+}
+
 // Asset loads and returns the asset for the given name.
 // It returns an error if the asset could not be found or
 // could not be loaded.
@@ -581,28 +770,37 @@ func AssetNames() []string {
 
 // _bindata is a table, holding each asset generator, mapped to its name.
 var _bindata = map[string]func() (*asset, error){
+	"sql/addPriceBuylist.sql": sqlAddpricebuylistSql,
 	"sql/addPriceMKMprice.sql": sqlAddpricemkmpriceSql,
 	"sql/addPriceMTGprice.sql": sqlAddpricemtgpriceSql,
 	"sql/bulkExtrema.sql": sqlBulkextremaSql,
 	"sql/bulkLatest.sql": sqlBulklatestSql,
+	"sql/buylistPriceLatest.sql": sqlBuylistpricelatestSql,
+	"sql/buylistPriceLatestBatch.sql": sqlBuylistpricelatestbatchSql,
 	"sql/historicalPriceMKMprice.sql": sqlHistoricalpricemkmpriceSql,
 	"sql/historicalPriceMTGprice.sql": sqlHistoricalpricemtgpriceSql,
 	"sql/medianMKM.sql": sqlMedianmkmSql,
 	"sql/medianMtgprice.sql": sqlMedianmtgpriceSql,
 	"sql/mkmPriceClosest.sql": sqlMkmpriceclosestSql,
+	"sql/mkmPriceClosestBatch.sql": sqlMkmpriceclosestbatchSql,
 	"sql/mkmPriceLastest.sql": sqlMkmpricelastestSql,
+	"sql/mkmPriceLatestBatch.sql": sqlMkmpricelatestbatchSql,
 	"sql/mkmPriceLatestHighest.sql": sqlMkmpricelatesthighestSql,
 	"sql/mkmPriceLatestLowest.sql": sqlMkmpricelatestlowestSql,
 	"sql/mkmPriceSetLatest.sql": sqlMkmpricesetlatestSql,
 	"sql/mkmPriceWeeksHigh.sql": sqlMkmpriceweekshighSql,
 	"sql/mkmPriceWeeksLow.sql": sqlMkmpriceweekslowSql,
 	"sql/mtgPriceClosest.sql": sqlMtgpriceclosestSql,
+	"sql/mtgPriceClosestBatch.sql": sqlMtgpriceclosestbatchSql,
 	"sql/mtgPriceLatest.sql": sqlMtgpricelatestSql,
+	"sql/mtgPriceLatestBatch.sql": sqlMtgpricelatestbatchSql,
 	"sql/mtgPriceLatestHighest.sql": sqlMtgpricelatesthighestSql,
 	"sql/mtgPriceLatestLowest.sql": sqlMtgpricelatestlowestSql,
 	"sql/mtgPriceSetLatest.sql": sqlMtgpricesetlatestSql,
 	"sql/mtgPriceWeeksHigh.sql": sqlMtgpriceweekshighSql,
 	"sql/mtgPriceWeeksLow.sql": sqlMtgpriceweekslowSql,
+	"sql/rangeMKM.sql": sqlRangemkmSql,
+	"sql/rangeMtgprice.sql": sqlRangemtgpriceSql,
 }
 
 // AssetDir returns the file names below a certain
@@ -646,6 +844,8 @@ type bintree struct {
 }
 var _bintree = &bintree{nil, map[string]*bintree{
 	"sql": &bintree{nil, map[string]*bintree{
+		"addPriceBuylist.sql": &bintree{sqlAddpricebuylistSql, map[string]*bintree{
+		}},
 		"addPriceMKMprice.sql": &bintree{sqlAddpricemkmpriceSql, map[string]*bintree{
 		}},
 		"addPriceMTGprice.sql": &bintree{sqlAddpricemtgpriceSql, map[string]*bintree{
@@ -654,6 +854,10 @@ var _bintree = &bintree{nil, map[string]*bintree{
 		}},
 		"bulkLatest.sql": &bintree{sqlBulklatestSql, map[string]*bintree{
 		}},
+		"buylistPriceLatest.sql": &bintree{sqlBuylistpricelatestSql, map[string]*bintree{
+		}},
+		"buylistPriceLatestBatch.sql": &bintree{sqlBuylistpricelatestbatchSql, map[string]*bintree{
+		}},
 		"historicalPriceMKMprice.sql": &bintree{sqlHistoricalpricemkmpriceSql, map[string]*bintree{
 		}},
 		"historicalPriceMTGprice.sql": &bintree{sqlHistoricalpricemtgpriceSql, map[string]*bintree{
@@ -664,8 +868,12 @@ var _bintree = &bintree{nil, map[string]*bintree{
 		}},
 		"mkmPriceClosest.sql": &bintree{sqlMkmpriceclosestSql, map[string]*bintree{
 		}},
+		"mkmPriceClosestBatch.sql": &bintree{sqlMkmpriceclosestbatchSql, map[string]*bintree{
+		}},
 		"mkmPriceLastest.sql": &bintree{sqlMkmpricelastestSql, map[string]*bintree{
 		}},
+		"mkmPriceLatestBatch.sql": &bintree{sqlMkmpricelatestbatchSql, map[string]*bintree{
+		}},
 		"mkmPriceLatestHighest.sql": &bintree{sqlMkmpricelatesthighestSql, map[string]*bintree{
 		}},
 		"mkmPriceLatestLowest.sql": &bintree{sqlMkmpricelatestlowestSql, map[string]*bintree{
@@ -678,8 +886,12 @@ var _bintree = &bintree{nil, map[string]*bintree{
 		}},
 		"mtgPriceClosest.sql": &bintree{sqlMtgpriceclosestSql, map[string]*bintree{
 		}},
+		"mtgPriceClosestBatch.sql": &bintree{sqlMtgpriceclosestbatchSql, map[string]*bintree{
+		}},
 		"mtgPriceLatest.sql": &bintree{sqlMtgpricelatestSql, map[string]*bintree{
 		}},
+		"mtgPriceLatestBatch.sql": &bintree{sqlMtgpricelatestbatchSql, map[string]*bintree{
+		}},
 		"mtgPriceLatestHighest.sql": &bintree{sqlMtgpricelatesthighestSql, map[string]*bintree{
 		}},
 		"mtgPriceLatestLowest.sql": &bintree{sqlMtgpricelatestlowestSql, map[string]*bintree{
@@ -690,6 +902,10 @@ var _bintree = &bintree{nil, map[string]*bintree{
 		}},
 		"mtgPriceWeeksLow.sql": &bintree{sqlMtgpriceweekslowSql, map[string]*bintree{
 		}},
+		"rangeMKM.sql": &bintree{sqlRangemkmSql, map[string]*bintree{
+		}},
+		"rangeMtgprice.sql": &bintree{sqlRangemtgpriceSql, map[string]*bintree{
+		}},
 	}},
 }}
 
@@ -56,3 +56,65 @@ func getmtgpriceClosest(pool *pgx.ConnPool,
 	return p, nil
 
 }
+
+// A printing at a moment, as GetCardsClosest looks prices up for.
+type PrintingAt struct {
+	Printing
+	When Timestamp
+}
+
+// Acquires the price closest to when of every printing with a single
+// query, each chosen as GetCardClosest would.
+//
+// Prices are keyed by the position of the request they answer,
+// requests without any price are absent from the result.
+func GetCardsClosest(pool *pgx.ConnPool,
+	requests []PrintingAt, source string) (map[int]Price, error) {
+
+	var statement string
+	if source == magiccardmarket {
+		statement = mkmPriceClosestBatch
+	} else if source == mtgprice {
+		statement = mtgPriceClosestBatch
+	} else {
+		return nil, SourceError
+	}
+
+	names := make([]string, len(requests))
+	sets := make([]string, len(requests))
+	times := make([]time.Time, len(requests))
+	for i, aRequest := range requests {
+		names[i], sets[i] = aRequest.Name, aRequest.Set
+		times[i] = time.Time(aRequest.When)
+	}
+
+	rows, err := pool.Query(statement, names, sets, times)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	prices := make(map[int]Price)
+	for rows.Next() {
+		p := Price{Source: source}
+
+		var position int64
+		var t time.Time
+		if source == magiccardmarket {
+			err = rows.Scan(&position, &p.Name, &p.Set, &t, &p.Price, &p.Euro)
+		} else {
+			err = rows.Scan(&position, &p.Name, &p.Set, &t, &p.Price)
+		}
+		if err != nil {
+			return nil, ScanError
+		}
+
+		p.Time = Timestamp(t)
+
+		// Ordinality counts from 1
+		prices[int(position)-1] = p
+	}
+
+	return prices, rows.Err()
+
+}
@@ -15,17 +15,24 @@ import (
 
 const magiccardmarket string = "mkm"
 const mtgprice string = "mtgprice"
+const buylist string = "buylist"
 
 // A list of all statements we support, these are prepared on a per
 // connection basis.
 const mtgpriceInsert string = "addPriceMTGprice"
 const mkmpriceInsert string = "addPriceMKMprice"
+const buylistInsert string = "addPriceBuylist"
 
 const mtgpriceHistory string = "historicalPriceMTGprice"
 const mkmpriceHistory string = "historicalPriceMKMprice"
 
 const mtgPriceLatest string = "mtgPriceLatest"
 const mkmPriceLatest string = "mkmPriceLastest"
+const buylistPriceLatest string = "buylistPriceLatest"
+
+const mtgPriceLatestBatch string = "mtgPriceLatestBatch"
+const mkmPriceLatestBatch string = "mkmPriceLatestBatch"
+const buylistPriceLatestBatch string = "buylistPriceLatestBatch"
 
 const mtgpriceMedian string = "medianMtgprice"
 const mkmMedian string = "medianMKM"
@@ -40,12 +47,17 @@ const mkmPriceSetLatest string = "mkmPriceSetLatest"
 
 const mtgPriceClosest string = "mtgPriceClosest"
 const mkmPriceClosest string = "mkmPriceClosest"
+const mtgPriceClosestBatch string = "mtgPriceClosestBatch"
+const mkmPriceClosestBatch string = "mkmPriceClosestBatch"
 
 const mtgPriceWeeksLow string = "mtgPriceWeeksLow"
 const mtgPriceWeeksHigh string = "mtgPriceWeeksHigh"
 const mkmPriceWeeksLow string = "mkmPriceWeeksLow"
 const mkmPriceWeeksHigh string = "mkmPriceWeeksHigh"
 
+const mtgpriceRange string = "rangeMtgprice"
+const mkmRange string = "rangeMKM"
+
 const bulkLatest string = "bulkLatest"
 const bulkExtrema string = "bulkExtrema"
 
@@ -55,13 +67,17 @@ var statements = []string{
 	mtgpriceHistory, mkmpriceHistory,
 	mtgPriceLatest, mkmPriceLatest,
 	mtgpriceMedian, mkmMedian,
+	mtgpriceRange, mkmRange,
 	mtgPriceLatestLowest, mkmPriceLatestLowest,
 	mtgPriceLatestHighest, mkmPriceLatestHighest,
 	mtgPriceSetLatest, mkmPriceSetLatest,
 	mtgPriceClosest, mkmPriceClosest,
+	mtgPriceClosestBatch, mkmPriceClosestBatch,
 	mtgPriceWeeksLow, mtgPriceWeeksHigh,
 	mkmPriceWeeksLow, mkmPriceWeeksHigh,
 	bulkLatest, bulkExtrema,
+	buylistInsert, buylistPriceLatest,
+	mtgPriceLatestBatch, mkmPriceLatestBatch, buylistPriceLatestBatch,
 }
 
 const statementLoc string = "sql"
@@ -73,6 +89,11 @@ const certName string = "server.crt"
 // Exposed and available
 const Magiccardmarket string = magiccardmarket
 const Mtgprice string = mtgprice
+
+// Buylist prices are only used for valuing what a collection sells for
+// and so are not one of the general Sources.
+const Buylist string = buylist
+
 var Sources []string = []string{Mtgprice, Magiccardmarket}
 
 func fetchRawStatement(name string) (string, error) {
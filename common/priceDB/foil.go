@@ -0,0 +1,114 @@
+package priceDB
+
+import (
+	"strings"
+
+	"github.com/jackc/pgx"
+)
+
+// Foil printings are priced as their own set, named for the
+// nonfoil set with this suffix.
+const FoilSuffix string = " Foil"
+
+// Whether a set name refers to the foil printings of a set.
+func IsFoilSet(set string) bool {
+	return strings.HasSuffix(set, FoilSuffix)
+}
+
+// Returns the set name foil printings of set are priced under.
+func FoilSet(set string) string {
+	if IsFoilSet(set) {
+		return set
+	}
+
+	return set + FoilSuffix
+}
+
+// Returns the set name nonfoil printings of set are priced under.
+func NonfoilSet(set string) string {
+	return strings.TrimSuffix(set, FoilSuffix)
+}
+
+// The latest nonfoil and foil prices of a single printing.
+//
+// Either may be absent, Set is always the nonfoil set.
+type PrintingPrice struct {
+	Name, Set string
+
+	Nonfoil, Foil       Price
+	HasNonfoil, HasFoil bool
+}
+
+// Acquires the latest nonfoil and foil prices for a printing.
+//
+// set may refer to either the foil or nonfoil set. Returns ScanError
+// only if neither price could be found.
+func GetPrintingLatest(pool *pgx.ConnPool,
+	name, set, source string) (PrintingPrice, error) {
+
+	p := PrintingPrice{
+		Name: name,
+		Set:  NonfoilSet(set),
+	}
+
+	var err error
+	p.Nonfoil, err = GetCardLatest(pool, name, p.Set, source)
+	if err == SourceError {
+		return p, err
+	}
+	p.HasNonfoil = err == nil
+
+	p.Foil, err = GetCardLatest(pool, name, FoilSet(p.Set), source)
+	p.HasFoil = err == nil
+
+	if !p.HasNonfoil && !p.HasFoil {
+		return p, ScanError
+	}
+
+	return p, nil
+
+}
+
+// Acquires the latest nonfoil and foil prices for many printings
+// with a single query.
+//
+// Results are keyed by the nonfoil set, printings without either
+// price are absent.
+func GetPrintingsLatest(pool *pgx.ConnPool,
+	printings []Printing, source string) (map[Printing]PrintingPrice, error) {
+
+	wanted := make([]Printing, 0, len(printings)*2)
+	seen := make(map[Printing]bool)
+	for _, aPrinting := range printings {
+		aPrinting.Set = NonfoilSet(aPrinting.Set)
+		if seen[aPrinting] {
+			continue
+		}
+		seen[aPrinting] = true
+
+		wanted = append(wanted, aPrinting,
+			Printing{aPrinting.Name, FoilSet(aPrinting.Set)})
+	}
+
+	latest, err := GetCardsLatest(pool, wanted, source)
+	if err != nil {
+		return nil, err
+	}
+
+	prices := make(map[Printing]PrintingPrice)
+	for aPrinting, aPrice := range latest {
+		key := Printing{aPrinting.Name, NonfoilSet(aPrinting.Set)}
+
+		p := prices[key]
+		p.Name, p.Set = key.Name, key.Set
+		if IsFoilSet(aPrinting.Set) {
+			p.Foil, p.HasFoil = aPrice, true
+		} else {
+			p.Nonfoil, p.HasNonfoil = aPrice, true
+		}
+		prices[key] = p
+	}
+
+	return prices, nil
+
+}
@@ -13,6 +13,8 @@ func GetCardLatest(pool *pgx.ConnPool,
 		return getMKMLatest(pool, name, set)
 	} else if source == mtgprice {
 		return getmtgpriceLatest(pool, name, set)
+	} else if source == buylist {
+		return getBuylistLatest(pool, name, set)
 	}
 
 	return Price{}, SourceError
@@ -56,3 +58,79 @@ func getmtgpriceLatest(pool *pgx.ConnPool,
 	return p, nil
 
 }
+
+func getBuylistLatest(pool *pgx.ConnPool,
+	name, set string) (Price, error) {
+
+	var p Price
+	var t time.Time
+	err := pool.QueryRow(buylistPriceLatest, name, set).Scan(
+		&p.Name, &p.Set, &t, &p.Price)
+	if err != nil {
+		return p, ScanError
+	}
+
+	p.Time = Timestamp(t)
+
+	p.Source = buylist
+
+	return p, nil
+
+}
+
+// A card within a single set.
+type Printing struct {
+	Name, Set string
+}
+
+// Acquires the latest price of every printing with a single query.
+//
+// Printings without any price are absent from the result.
+func GetCardsLatest(pool *pgx.ConnPool,
+	printings []Printing, source string) (map[Printing]Price, error) {
+
+	var statement string
+	if source == magiccardmarket {
+		statement = mkmPriceLatestBatch
+	} else if source == mtgprice {
+		statement = mtgPriceLatestBatch
+	} else if source == buylist {
+		statement = buylistPriceLatestBatch
+	} else {
+		return nil, SourceError
+	}
+
+	names := make([]string, len(printings))
+	sets := make([]string, len(printings))
+	for i, aPrinting := range printings {
+		names[i], sets[i] = aPrinting.Name, aPrinting.Set
+	}
+
+	rows, err := pool.Query(statement, names, sets)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	prices := make(map[Printing]Price)
+	for rows.Next() {
+		p := Price{Source: source}
+
+		var t time.Time
+		if source == magiccardmarket {
+			err = rows.Scan(&p.Name, &p.Set, &t, &p.Price, &p.Euro)
+		} else {
+			err = rows.Scan(&p.Name, &p.Set, &t, &p.Price)
+		}
+		if err != nil {
+			return nil, ScanError
+		}
+
+		p.Time = Timestamp(t)
+
+		prices[Printing{p.Name, p.Set}] = p
+	}
+
+	return prices, rows.Err()
+
+}
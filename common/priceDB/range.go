@@ -0,0 +1,68 @@
+package priceDB
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx"
+)
+
+// The granularities a price history may be acquired at
+const Day string = "day"
+const Week string = "week"
+
+var Granularities []string = []string{Day, Week}
+
+var GranularityError error = fmt.Errorf("invalid granularity")
+
+// Acquires the median price of a printing for each day or week
+// between from and to, oldest first.
+//
+// from is inclusive and to exclusive. Periods without any price are
+// absent rather than zero.
+func GetCardHistoryRange(pool *pgx.ConnPool,
+	name, set, source string, from, to Timestamp,
+	granularity string) (Prices, error) {
+
+	if granularity != Day && granularity != Week {
+		return nil, GranularityError
+	}
+
+	var statement string
+	if source == magiccardmarket {
+		statement = mkmRange
+	} else if source == mtgprice {
+		statement = mtgpriceRange
+	} else {
+		return nil, SourceError
+	}
+
+	rows, err := pool.Query(statement, name, set,
+		time.Time(from), time.Time(to), granularity)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	prices := make(Prices, 0)
+	for rows.Next() {
+		p := Price{
+			Name:   name,
+			Set:    set,
+			Source: source,
+		}
+
+		var t time.Time
+		err = rows.Scan(&t, &p.Price)
+		if err != nil {
+			return nil, ScanError
+		}
+
+		p.Time = Timestamp(t)
+
+		prices = append(prices, p)
+	}
+
+	return prices, rows.Err()
+
+}
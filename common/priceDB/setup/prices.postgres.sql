@@ -67,6 +67,26 @@ CREATE INDEX mtgprice_name_index on prices.mtgprice(name);
 CREATE INDEX mtgprice_set_index on prices.mtgprice("set");
 CREATE INDEX mtgprice_time_index on prices.mtgprice("time");
 
+/*
+Buylist prices are what vendors will pay for a card rather than
+what they sell it for, letting us estimate liquidation value.
+*/
+CREATE TABLE prices.buylist (
+
+	name TEXT NOT NULL,
+	set TEXT NOT NULL,
+	time timestamp NOT NULL,
+
+	price int NOT NULL,
+
+	CONSTRAINT uniqueBuylistEntryKey UNIQUE (name, set, time)
+
+);
+
+CREATE INDEX buylist_name_index on prices.buylist(name);
+CREATE INDEX buylist_set_index on prices.buylist("set");
+CREATE INDEX buylist_time_index on prices.buylist("time");
+
 CREATE TABLE prices.magiccardmarket (
 
 	name TEXT NOT NULL,
@@ -257,6 +277,7 @@ GRANT usage ON SCHEMA prices TO priceWriter;
 
 GRANT select, insert ON TABLE prices.magiccardmarket to priceWriter;
 GRANT select, insert ON TABLE prices.mtgprice to priceWriter;
+GRANT select, insert ON TABLE prices.buylist to priceWriter;
 
 /*
 Privileges for the pricereader
@@ -268,4 +289,5 @@ GRANT usage ON SCHEMA PUBLIC TO priceReader;
 GRANT usage ON SCHEMA prices TO priceReader;
 
 GRANT select ON TABLE prices.magiccardmarket to priceReader;
-GRANT select ON TABLE prices.mtgprice to priceReader;
\ No newline at end of file
+GRANT select ON TABLE prices.mtgprice to priceReader;
+GRANT select ON TABLE prices.buylist to priceReader;
\ No newline at end of file
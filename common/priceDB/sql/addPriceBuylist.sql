@@ -0,0 +1,11 @@
+/*
+
+Adds a new buylist price point, what a vendor will pay for a
+specific card and printing of that card, to prices.buylist
+
+*/
+
+INSERT INTO prices.buylist
+(name, set, time, price)
+values
+($1, $2, $3, $4)
\ No newline at end of file
@@ -0,0 +1,7 @@
+/*
+Returns the latest buylist update for a card/set combination.
+*/
+
+SELECT name, set, time, price FROM prices.buylist
+WHERE name=$1 AND set=$2
+ORDER BY time DESC LIMIT 1;
\ No newline at end of file
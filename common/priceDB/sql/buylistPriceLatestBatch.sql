@@ -0,0 +1,17 @@
+/*
+Returns the latest buylist update for each card/set combination provided.
+
+Takes
+	$1 - array of card names
+	$2 - array of set names, paired with the names by position
+
+Combinations without any update are absent.
+*/
+
+SELECT latest.name, latest.set, latest.time, latest.price
+FROM unnest($1::text[], $2::text[]) AS wanted(name, set)
+CROSS JOIN LATERAL (
+	SELECT name, set, time, price FROM prices.buylist
+	WHERE name=wanted.name AND set=wanted.set
+	ORDER BY time DESC LIMIT 1
+) AS latest;
\ No newline at end of file
@@ -0,0 +1,22 @@
+/*
+Returns the price closest to the provided time for each card/set
+combination provided, chosen as mkmPriceClosest would.
+
+Takes
+	$1 - array of card names
+	$2 - array of set names, paired with the names by position
+	$3 - array of times, paired with the names by position
+
+Each price is returned alongside the position of the combination it
+is for, counting from 1. Combinations without any price are absent.
+*/
+
+SELECT wanted.position, closest.name, closest.set, closest.time,
+	closest.price, closest.euro
+FROM unnest($1::text[], $2::text[], $3::timestamp[])
+	WITH ORDINALITY AS wanted(name, set, at, position)
+CROSS JOIN LATERAL (
+	SELECT name, set, time, price, euro FROM prices.magiccardmarket
+	WHERE name=wanted.name AND set=wanted.set AND wanted.at > time
+	ORDER BY abs(extract(epoch from wanted.at - time)) LIMIT 1
+) AS closest;
\ No newline at end of file
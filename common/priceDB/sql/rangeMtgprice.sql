@@ -0,0 +1,13 @@
+/*
+Returns the median price for a name and set combination in each day
+or week between two times, oldest first
+
+Takes:
+	name, set - the printing
+	from, to - the times bounding the series, from inclusive
+	granularity - 'day' or 'week'
+*/
+select date_trunc($5, time), median(price) from prices.mtgprice
+where
+name=$1 and set=$2 and time >= $3 and time < $4
+group by 1 order by 1 asc;
\ No newline at end of file
@@ -9,6 +9,7 @@ import (
 
 const mtgpriceSource string = "mtgprice"
 const mkmpriceSource string = "magiccardmarket"
+const buylistSource string = buylist
 
 var ConnError error = fmt.Errorf("db connection failed")
 var SourceError error = fmt.Errorf("invalid price source")
@@ -63,6 +64,8 @@ func insertPrice(tx *pgx.Tx, p Price) (err error) {
 		statement = mtgpriceInsert
 	} else if p.Source == mkmpriceSource {
 		statement = mkmpriceInsert
+	} else if p.Source == buylistSource {
+		statement = buylistInsert
 	}
 
 	if statement == "" {
@@ -77,7 +80,7 @@ func insertPrice(tx *pgx.Tx, p Price) (err error) {
 	if p.Source == mkmpriceSource {
 		_, err = tx.Exec(statement, p.Name, p.Set,
 			time.Time(p.Time), p.Euro, p.Price)
-	} else if p.Source == mtgprice {
+	} else if p.Source == mtgprice || p.Source == buylistSource {
 		_, err = tx.Exec(statement, p.Name, p.Set, time.Time(p.Time), p.Price)
 	} else {
 		return SourceError
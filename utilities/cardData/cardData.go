@@ -237,7 +237,7 @@ func buildBasicData(aLogger *log.Logger) cardMap {
 	// Baseline
 	foreignMap, err:= mtgjson.AllCardsX()
 	if err!=nil {
-		aLogger.Fatalf("", err)
+		aLogger.Fatalln("Failed to acquire AllCards-x", err)
 	}
 
 	// Translate to extended structure
@@ -15,9 +15,17 @@ import(
 	"os"
 	"fmt"
 	"io"
+	"flag"
+
+	"net/http"
+	"os/signal"
+	"syscall"
+	"github.com/emicklei/go-restful"
 
 	"github.com/joho/godotenv"
 
+	"./commanderDB"
+
 	"path/filepath"
 )
 
@@ -30,6 +38,14 @@ const topCommanderUsageLoc string = "commanderUsage"
 const topCommanderUsageCount int = 1000
 
 func main() {
+	flag.BoolVar(&commanderData.ForceRebuild, "force-rebuild", false,
+		"rebuild processed commander data even when its cache is fresh")
+	serve:= flag.Bool("serve-typeahead", false,
+		"serve typeAhead over http from memory rather than writing it to disk")
+	perKeyFiles:= flag.Bool("typeahead-files", false,
+		"write typeAhead as a file per key rather than a single combined file")
+	flag.Parse()
+
 	aLogger:= getLogger("core.log")
 
 	// Populate config locations not explicitly set
@@ -39,18 +55,54 @@ func main() {
 		os.Exit(1)
 	}
 
+	typeAheadOutput:= typeAheadFileLoc()
+	if *perKeyFiles {
+		typeAheadOutput = typeAheadLoc()
+	}
+
 	// Notify intent to user
 	fmt.Printf(`
 Output directories:
 	general:   %v
 	typeAhead: %v
-`, dataLoc(), typeAheadLoc())
+`, dataLoc(), typeAheadOutput)
 
 	// Dumps data for each card into dataLoc 
 	getAllCardData(aLogger)
 
-	// Dumps typeAhead content into typeAheadLoc 
-	getAllTypeAheadData(aLogger)
+	if *serve {
+		serveTypeAhead(aLogger)
+		return
+	}
+
+	// Dumps typeAhead content into typeAheadOutput
+	getAllTypeAheadData(aLogger, *perKeyFiles)
+
+}
+
+// Serves typeAhead from memory until the process is killed.
+//
+// SIGHUP prompts a rebuild from source data.
+func serveTypeAhead(aLogger *log.Logger) {
+
+	aService:= newTypeAheadService(aLogger)
+
+	reloads:= make(chan os.Signal, 1)
+	signal.Notify(reloads, syscall.SIGHUP)
+	go func() {
+		for range reloads{
+			aService.Reload()
+		}
+	}()
+
+	restful.Add(aService.Service)
+
+	fmt.Println("typeAhead server ready on ", typeAheadAddr())
+
+	err:= http.ListenAndServe(typeAheadAddr(), nil)
+	if err!=nil {
+		aLogger.Fatalln("typeAhead server failed, ", err)
+	}
 
 }
 
@@ -7,6 +7,8 @@ import(
 	"encoding/json"
 	
 	"io/ioutil"
+	"os"
+	"log"
 
 	"sort"
 
@@ -69,32 +71,85 @@ func (usableData *QueryableCommanderData) Sort(names []string) []string {
 
 //populates the QueryableCommanderData with mtgsalvation data.
 //
-//a cache file is kept at cacheFile
+//a cache file is kept at cacheFile, the normalized result of processing
+//it is kept at processedCacheFile.
 func (usableData *QueryableCommanderData) populate() {
 	
 	aLogger:= getLogger("deckScraper.log", "deckScraper")
 
 	cache:= cacheLoc()
 
-	//see if a cache exists and we can read from it
-	cacheData, err := ioutil.ReadFile(cache)	
+	//see if a cache exists, in the event it doesn't we populate it
+	_, err:= os.Stat(cache)
 	if err!=nil {
-		//in the event we can't read from the cache, populate it
 		populateRawCache(aLogger)
-		cacheData, err = ioutil.ReadFile(cache)
-		if err!=nil {
-			//if we can't read the cache right after populating it, we quit
-			fmt.Println("Failed to read cache after populating it")
-			aLogger.Fatalf("Failed to read cache after populating it")
+	}
+
+	loaded, _, err:= loadCommanderData(cache, processedCacheLoc(),
+		ForceRebuild, aLogger)
+	if err!=nil {
+		fmt.Println("Failed to load commander data", err)
+		aLogger.Fatalf("Failed to load commander data %v", err)
+	}
+
+	*usableData = loaded
+
+}
+
+// Acquires commander data from the raw cache at rawLoc.
+//
+// The processed form at processedLoc is used whenever it was built
+// from identical raw data by the current version, otherwise it is
+// rebuilt and stored. Returns whether a rebuild took place.
+func loadCommanderData(rawLoc, processedLoc string, force bool,
+	aLogger *log.Logger) (QueryableCommanderData, bool, error) {
+
+	rawData, err:= ioutil.ReadFile(rawLoc)
+	if err!=nil {
+		return QueryableCommanderData{}, false, err
+	}
+	sourceHash:= hashSource(rawData)
+
+	if !force {
+		cached, err:= readProcessedCache(processedLoc)
+		if err == nil && cached.Version == processedCacheVersion &&
+			cached.SourceHash == sourceHash {
+			return cached.queryable(), false, nil
 		}
 	}
 
 	//unmarshal the cache
 	var count map[string]int
-	err = json.Unmarshal(cacheData, &count)
-	if err != nil {
-		aLogger.Fatalf("Failed to unmarshal cache")
+	err = json.Unmarshal(rawData, &count)
+	if err!=nil {
+		return QueryableCommanderData{}, false, err
 	}
+	if len(count) == 0 {
+		return QueryableCommanderData{}, false,
+			fmt.Errorf("no commander data present in %v", rawLoc)
+	}
+
+	var usableData QueryableCommanderData
+	usableData.build(count)
+
+	// A failure to persist only costs us a rebuild next time
+	err = writeProcessedCache(processedLoc, processedCache{
+		Version: processedCacheVersion,
+		SourceHash: sourceHash,
+		Data: usableData.data,
+		Accuracy: usableData.Accuracy,
+		ComparisonCard: usableData.ComparisonCard,
+	})
+	if err!=nil {
+		aLogger.Println("Failed to write processed commander cache", err)
+	}
+
+	return usableData, true, nil
+
+}
+
+// Normalizes raw appearance counts against the most used card.
+func (usableData *QueryableCommanderData) build(count map[string]int) {
 	
 	//populate a non-normalized list of card datas with counts
 	rawSortedCount:= make(cardDataCollection, len(count))
@@ -6,10 +6,10 @@ import(
 	"fmt"
 	"log"
 
-	"strings"
-
 	"path/filepath"
 
+	"./../../../common/mtgjson"
+
 )
 
 //Location of cache so we don't have to hit remote often
@@ -31,7 +31,7 @@ func getLogger(fName, name string) (aLogger *log.Logger) {
 
 //normalize card names to a standard form which should ignore most trivial typing errors
 func normalizeCardName(cardName string) string {
-	properName:= strings.ToLower(cardName)
+	properName:= mtgjson.NormalizeCardName(cardName)
 
 	return properName 
 }
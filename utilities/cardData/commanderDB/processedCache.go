@@ -0,0 +1,89 @@
+package commanderData
+
+import(
+
+	"os"
+	"path/filepath"
+
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+
+	"io/ioutil"
+
+)
+
+// Location of the normalized commander data, derived from cacheFile
+const processedCacheFile string = "commanderData.processed.cache.json"
+
+// Bumped whenever the processed representation or how it is derived
+// changes, invalidating every existing processed cache.
+const processedCacheVersion int = 1
+
+// When set, the processed cache is ignored and always rebuilt from
+// the raw cache.
+var ForceRebuild bool
+
+// The on-disk form of QueryableCommanderData alongside what it
+// was built from.
+type processedCache struct{
+	Version int
+	SourceHash string
+
+	Data map[string]int
+	Accuracy int
+	ComparisonCard string
+}
+
+func (cached processedCache) queryable() QueryableCommanderData {
+	return QueryableCommanderData{
+		data: cached.Data,
+		Accuracy: cached.Accuracy,
+		ComparisonCard: cached.ComparisonCard,
+	}
+}
+
+// Returns the location of the processed cache file, kept alongside
+// the raw cache.
+func processedCacheLoc() string {
+	return filepath.Join(filepath.Dir(cacheLoc()), processedCacheFile)
+}
+
+func hashSource(raw []byte) string {
+	sum:= sha256.Sum256(raw)
+	return hex.EncodeToString(sum[:])
+}
+
+func readProcessedCache(loc string) (processedCache, error) {
+
+	var cached processedCache
+
+	raw, err:= ioutil.ReadFile(loc)
+	if err!=nil {
+		return cached, err
+	}
+
+	err = json.Unmarshal(raw, &cached)
+
+	return cached, err
+
+}
+
+// Writes the processed cache in its entirety or not at all so
+// an interrupted write is never mistaken for a valid cache.
+func writeProcessedCache(loc string, cached processedCache) error {
+
+	serial, err:= json.Marshal(cached)
+	if err!=nil {
+		return err
+	}
+
+	staging:= loc + ".tmp"
+	err = ioutil.WriteFile(staging, serial, 0666)
+	if err!=nil {
+		return err
+	}
+
+	return os.Rename(staging, loc)
+
+}
@@ -0,0 +1,82 @@
+package commanderData
+
+import(
+
+	"testing"
+
+	"log"
+	"os"
+	"io/ioutil"
+	"path/filepath"
+
+)
+
+func TestProcessedCache(t *testing.T) {
+
+	dir, err:= ioutil.TempDir("", "commanderData")
+	if err!=nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	rawLoc:= filepath.Join(dir, cacheFile)
+	processedLoc:= filepath.Join(dir, processedCacheFile)
+
+	aLogger:= log.New(ioutil.Discard, "", 0)
+
+	err = ioutil.WriteFile(rawLoc,
+		[]byte(`{"sol ring": 100, "griselbrand": 25}`), 0666)
+	if err!=nil {
+		t.Fatal(err)
+	}
+
+	first, rebuilt, err:= loadCommanderData(rawLoc, processedLoc,
+		false, aLogger)
+	if err!=nil {
+		t.Fatal("failed to build commander data", err)
+	}
+	if !rebuilt {
+		t.Fatal("first build did not process raw data")
+	}
+
+	second, rebuilt, err:= loadCommanderData(rawLoc, processedLoc,
+		false, aLogger)
+	if err!=nil {
+		t.Fatal("failed to load commander data", err)
+	}
+	if rebuilt {
+		t.Fatal("processed cache not used on second build")
+	}
+	usage, err:= second.Query("Griselbrand")
+	firstUsage, _:= first.Query("Griselbrand")
+	if err!=nil || usage != firstUsage || usage != 0.25 {
+		t.Fatal("cached data differs from built data", usage, firstUsage)
+	}
+
+	_, rebuilt, err = loadCommanderData(rawLoc, processedLoc,
+		true, aLogger)
+	if err!=nil || !rebuilt {
+		t.Fatal("forced rebuild used the processed cache", err)
+	}
+
+	// A change to the source must invalidate what we built from it
+	err = ioutil.WriteFile(rawLoc,
+		[]byte(`{"sol ring": 100, "griselbrand": 50}`), 0666)
+	if err!=nil {
+		t.Fatal(err)
+	}
+
+	third, rebuilt, err:= loadCommanderData(rawLoc, processedLoc,
+		false, aLogger)
+	if err!=nil {
+		t.Fatal("failed to rebuild commander data", err)
+	}
+	if !rebuilt {
+		t.Fatal("processed cache used despite source changing")
+	}
+	usage, err = third.Query("Griselbrand")
+	if err!=nil || usage != 0.5 {
+		t.Fatal("rebuilt data does not reflect changed source", usage)
+	}
+
+}
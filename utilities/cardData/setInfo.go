@@ -4,8 +4,12 @@ import(
 
 	"log"
 
+	"strings"
+
 	"time"
 
+	"./../../common/mtgjson"
+
 )
 
 // YEAR-MONTH-DAY
@@ -36,4 +40,37 @@ func (aSet *set) setTimestamp(aLogger *log.Logger) {
 
 	aSet.Timestamp = timestamp.UTC().Unix()
 
+}
+
+// Acquire a map[name]release of the newest printing of every card.
+//
+// Names have the same AEther replacement applied as the typeahead
+// so they may be used directly against its contents.
+func getLatestReleases(aLogger *log.Logger) map[string]time.Time {
+
+	sets, err:= mtgjson.AllSetsX()
+	if err != nil {
+		aLogger.Fatalln("Failed to unmarshal AllSets-x", err)
+	}
+
+	latest:= make(map[string]time.Time)
+	for _, aSet:= range sets{
+
+		release, err:= time.Parse(ReleaseDataFormat, aSet.ReleaseDate)
+		if err!=nil {
+			aLogger.Println("Failed to convert time for ", aSet.Name, err)
+			continue
+		}
+
+		for _, aCard:= range aSet.Cards{
+			name:= strings.Replace(aCard.Name, "Æ", "AE", -1)
+			if release.After(latest[name]) {
+				latest[name] = release
+			}
+		}
+
+	}
+
+	return latest
+
 }
\ No newline at end of file
@@ -21,7 +21,7 @@ func getSetCodeToSetNameTranslator(aLogger *log.Logger) map[string]string {
 
 	poorTranslator, err:= mtgjson.AllSetsX()
 	if err != nil {
-		aLogger.Fatalf("Failed to unmarhsal AllSets-x ", err)
+		aLogger.Fatalln("Failed to unmarshal AllSets-x", err)
 	}
 
 	properTranslator:= make(map[string]string)
@@ -39,7 +39,7 @@ func stapleOnSetSpecificData(aCardMap cardMap, aLogger *log.Logger) {
 	// Base
 	supplementary, err:= mtgjson.AllSetsX()
 	if err != nil {
-		aLogger.Fatalf("Failed to open supplementary set data, ", err)
+		aLogger.Fatalln("Failed to open supplementary set data,", err)
 	}
 
 	// Check each card
@@ -3,29 +3,124 @@ package main
 import(
 
 	"log"
+	"fmt"
 
 	"strings"
 	"sort"
+	"strconv"
 
 	"os"
 	"io/ioutil"
 	"encoding/json"
 
+	"time"
+
 	"./commanderDB"
+	"./../../common/mtgjson"
 
 )
 
-// Generates and outputs typeAhead data to typeAheadLoc()
-func getAllTypeAheadData(aLogger *log.Logger) {
+// How many days after release a set's cards are boosted, as specified
+// by the TYPEAHEAD_RECENCY_DAYS environment variable.
+//
+// Zero or unset disables the boost.
+const recencyWindowEnv string = "TYPEAHEAD_RECENCY_DAYS"
+
+// The most suggestions kept for each key, as specified by the
+// TYPEAHEAD_MAX_SUGGESTIONS environment variable.
+//
+// Zero keeps every suggestion.
+const maxSuggestionsEnv string = "TYPEAHEAD_MAX_SUGGESTIONS"
+const defaultMaxSuggestions int = 20
+
+// Generates and outputs typeAhead data to typeAheadFileLoc(), or a
+// file per key in typeAheadLoc() when perKeyFiles
+//
+// Output is staged beside the served index and only swapped in
+// once every key has been written.
+func getAllTypeAheadData(aLogger *log.Logger, perKeyFiles bool) {
 	
 	// Generate
-	aTypeAhead:= buildTypeAheadCardData(aLogger)
+	aTypeAhead, _:= buildTypeAheadCardData(aLogger, maxSuggestions(aLogger))
+
+	if !perKeyFiles {
+		err:= aTypeAhead.dumpCombined(typeAheadFileLoc())
+		if err!=nil {
+			aLogger.Println("Keeping served typeAhead, ", err)
+		}
+		return
+	}
+
 	// Output
-	aTypeAhead.dumpToDisk(aLogger)
+	staging:= typeAheadLoc() + stagingSuffix
+	err:= resetDir(staging)
+	if err!=nil {
+		aLogger.Println("Failed to prepare typeAhead staging, ", err)
+		return
+	}
+
+	err = aTypeAhead.dumpToDisk(staging, aLogger)
+	if err!=nil {
+		aLogger.Println("Keeping served typeAhead, ", err)
+		return
+	}
+
+	err = swapDir(staging, typeAheadLoc())
+	if err!=nil {
+		aLogger.Println("Failed to swap in fresh typeAhead, ", err)
+	}
+}
+
+// Where fresh output is staged and the previous output kept
+// while being swapped.
+const stagingSuffix string = ".staging"
+const previousSuffix string = ".previous"
+
+// Empties a directory, creating it if necessary.
+func resetDir(dir string) error {
+
+	err:= os.RemoveAll(dir)
+	if err!=nil {
+		return err
+	}
+
+	return os.MkdirAll(dir, 0777)
+
+}
+
+// Replaces the contents of live with those of staging.
+func swapDir(staging, live string) error {
+
+	previous:= live + previousSuffix
+	err:= os.RemoveAll(previous)
+	if err!=nil {
+		return err
+	}
+
+	err = os.Rename(live, previous)
+	if err!=nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	err = os.Rename(staging, live)
+	if err!=nil {
+		// Put the previous output back so something is still served
+		os.Rename(previous, live)
+		return err
+	}
+
+	return os.RemoveAll(previous)
+
 }
 
 
-func buildTypeAheadCardData(aLogger *log.Logger) (typeAhead) {
+// Builds a ranked typeAhead of every card alongside every card name
+// in that same rank.
+//
+// Each key keeps only its top max suggestions, all of them when
+// max is zero.
+func buildTypeAheadCardData(aLogger *log.Logger,
+	max int) (typeAhead, []string) {
 	
 	aTypeAhead:= make(typeAhead)
 
@@ -35,11 +130,110 @@ func buildTypeAheadCardData(aLogger *log.Logger) (typeAhead) {
 	// Add the cards
 	aTypeAhead.addList(cardList)
 
-	// Sort by commander use
+	// Sort by commander use, then by recent release
 	commanderData:= commanderData.GetQueryableCommanderData()
-	aTypeAhead.sortByCommanderUsage(&commanderData)
+	recency:= recencyBoost{
+		window: recencyWindow(aLogger),
+		now: time.Now(),
+	}
+	if recency.window > 0 {
+		recency.releases = getLatestReleases(aLogger)
+	}
+	aTypeAhead.rank(&commanderData, recency)
+
+	// Only the best suggestions are ever shown
+	aTypeAhead.truncate(max)
+
+	// Names are stored as typeAhead holds them
+	ranked:= make([]string, len(cardList))
+	for i, aName:= range cardList{
+		ranked[i] = strings.Replace(aName, "Æ", "AE", -1)
+	}
+	ranked = rankNames(ranked, &commanderData, recency)
+
+	return aTypeAhead, ranked
+}
+
+// Returns how many suggestions each key keeps.
+func maxSuggestions(aLogger *log.Logger) int {
+
+	raw:= os.Getenv(maxSuggestionsEnv)
+	if len(raw) == 0 {
+		return defaultMaxSuggestions
+	}
+
+	max, err:= strconv.Atoi(raw)
+	if err!=nil || max < 0 {
+		aLogger.Println("Invalid ", maxSuggestionsEnv, ", using ",
+			defaultMaxSuggestions)
+		return defaultMaxSuggestions
+	}
+
+	return max
+
+}
+
+// Returns the window during which cards from new sets are boosted.
+func recencyWindow(aLogger *log.Logger) time.Duration {
+
+	raw:= os.Getenv(recencyWindowEnv)
+	if len(raw) == 0 {
+		return 0
+	}
+
+	days, err:= strconv.Atoi(raw)
+	if err!=nil || days < 0 {
+		aLogger.Println("Invalid ", recencyWindowEnv, ", disabling recency boost")
+		return 0
+	}
 
-	return aTypeAhead
+	return time.Duration(days) * 24 * time.Hour
+
+}
+
+// Determines which cards were printed recently enough to be boosted.
+type recencyBoost struct{
+	window time.Duration
+	now time.Time
+
+	// map[name]release of the newest printing of each card
+	releases map[string]time.Time
+}
+
+// Returns whether or not a card had a printing within the window.
+func (boost recencyBoost) recent(name string) bool {
+
+	if boost.window <= 0 {
+		return false
+	}
+
+	release, ok:= boost.releases[name]
+	if !ok {
+		return false
+	}
+
+	return boost.now.Sub(release) <= boost.window
+
+}
+
+// Sorts names with recently printed cards first, otherwise leaves
+// the order alone when used with a stable sort.
+type byRecency struct{
+	names []string
+	boost recencyBoost
+}
+
+func (someData byRecency) Len() int {
+	return len(someData.names)
+}
+
+func (someData byRecency) Swap(i, j int) {
+	someData.names[i], someData.names[j] = someData.names[j], someData.names[i]
+}
+
+func (someData byRecency) Less(i, j int) bool {
+	return someData.boost.recent(someData.names[i]) &&
+		!someData.boost.recent(someData.names[j])
 }
 
 func getRawCardNames(aLogger *log.Logger) ([]string) {
@@ -58,6 +252,17 @@ func getRawCardNames(aLogger *log.Logger) ([]string) {
 // A map[text]options.
 type typeAhead map[string][]string
 
+// Folds text to the form typeAhead is keyed by, so unaccented
+// queries still match.
+func typeAheadKey(text string) string {
+
+	// Replace the special case of AEther cards
+	text = strings.Replace(text, "Æ", "AE", -1)
+
+	return mtgjson.NormalizeCardName(text)
+
+}
+
 // Adds a list of strings to the typeahead.
 func (aTypeAhead *typeAhead) addList(names []string) {
 	// Allows us to index
@@ -68,15 +273,16 @@ func (aTypeAhead *typeAhead) addList(names []string) {
 
 		// Replace the special case of AEther cards
 		aName = strings.Replace(aName, "Æ", "AE", -1)
-		aLowerName:= strings.ToLower(aName)
+		// Keys are cut by rune so none split a multi-byte character
+		aLowerName:= []rune(typeAheadKey(aName))
 
 		// Develop subarrays for each depth of key
-		for keyIndexEnd := 1; keyIndexEnd < len(aName) + 1; keyIndexEnd++ {
+		for keyIndexEnd := 1; keyIndexEnd < len(aLowerName) + 1; keyIndexEnd++ {
 			
-			if keyIndexEnd > len(aName) {
+			if keyIndexEnd > len(aLowerName) {
 				break
 			}
-			key = aLowerName[0:keyIndexEnd]
+			key = string(aLowerName[0:keyIndexEnd])
 
 			_, ok:= valueTypeAhead[key]
 			if !ok {
@@ -90,48 +296,114 @@ func (aTypeAhead *typeAhead) addList(names []string) {
 	}
 }
 
-// Sorts all fields of the typeAhead based on commander usage
+// Ranks all fields of the typeAhead.
 //
-// Additionally, each field is pre-sorted alphabetically so cards
-// without significant commander usage can have some order.
+// Commander usage is the primary signal with cards from recently released
+// sets breaking ties. Each field is pre-sorted alphabetically so cards
+// without either signal still have some order.
 //
 // This assumes that commanderUsage uses a STABLE sort.
-func (aTypeAhead *typeAhead) sortByCommanderUsage(commanderUsage *commanderData.QueryableCommanderData) {
+func (aTypeAhead *typeAhead) rank(commanderUsage *commanderData.QueryableCommanderData,
+	recency recencyBoost) {
 	
 	for aKey, names:= range *aTypeAhead{
+		(*aTypeAhead)[aKey] = rankNames(names, commanderUsage, recency)
+	}
+
+}
+
+// Orders names by commander usage then recency, alphabetically
+// otherwise, as every field of the typeAhead is.
+//
+// Each name appears once, at its best rank.
+func rankNames(names []string,
+	commanderUsage *commanderData.QueryableCommanderData,
+	recency recencyBoost) []string {
+
+	sort.Strings(names)
 
-		sort.Strings(names)
+	sort.Stable(byRecency{names, recency})
+
+	return dedupeNames(commanderUsage.Sort(names))
+
+}
 
-		(*aTypeAhead)[aKey] = commanderUsage.Sort(names)
+// Removes repeats of a name, keeping the order of first appearances.
+//
+// Names such as 'Æther Vial' and 'AEther Vial' end up identical
+// once added so the same card may have been added twice.
+func dedupeNames(names []string) []string {
 
+	seen:= make(map[string]bool, len(names))
+	unique:= names[:0]
+	for _, aName:= range names{
+		if seen[aName] {
+			continue
+		}
+		seen[aName] = true
+		unique = append(unique, aName)
 	}
 
+	return unique
+
 }
 
-// Dumps each stored typeahead query to typeAheadLoc() as
-// $QUERY.json 
-func (aTypeAhead *typeAhead) dumpToDisk(aLogger *log.Logger) {
+// Limits every field of the typeAhead to its first max names.
+//
+// Fields must already be ranked so those kept are the best. A max
+// of zero or less leaves every field whole.
+func (aTypeAhead *typeAhead) truncate(max int) {
+
+	if max <= 0 {
+		return
+	}
+
+	for aKey, names:= range *aTypeAhead{
+		if len(names) > max {
+			(*aTypeAhead)[aKey] = names[:max:max]
+		}
+	}
+
+}
+
+// Dumps each stored typeahead query to dir as $QUERY.json
+//
+// Every key is attempted. Returns an error summarizing how many keys
+// failed so the caller can decide whether the output is usable.
+func (aTypeAhead *typeAhead) dumpToDisk(dir string,
+	aLogger *log.Logger) error {
 
 	var serialChoices []byte
 	var err error
 
 	var path string
 
+	failed:= 0
+
 	for aKey, names:= range *aTypeAhead {
 
 		serialChoices, err= json.Marshal(names)
 		if err!=nil {
 			aLogger.Println("Failed to marshal ", aKey)	
+			failed++
 			continue
 		}
 
-		path = typeAheadLoc() + string(os.PathSeparator) + aKey + ".json"
+		path = dir + string(os.PathSeparator) + aKey + ".json"
 
 		err = ioutil.WriteFile(path, serialChoices, 0666)
 		if err!=nil {
 			aLogger.Println("Failed to write choices, ", err)
+			failed++
 		}
 
 	}
 
+	if failed > 0 {
+		return fmt.Errorf("failed to dump %d of %d typeAhead keys",
+			failed, len(*aTypeAhead))
+	}
+
+	return nil
+
 }
\ No newline at end of file
@@ -0,0 +1,310 @@
+package main
+
+import(
+
+	"testing"
+
+	"log"
+	"os"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+
+	"time"
+
+	"./commanderDB"
+
+)
+
+// With no commander data every card is equally used, so recently
+// released cards should rank first.
+func TestRankRecencyBoost(t *testing.T) {
+
+	now:= time.Now()
+	recency:= recencyBoost{
+		window: 30 * 24 * time.Hour,
+		now: now,
+		releases: map[string]time.Time{
+			"Abrupt Decay": now.Add(-365 * 24 * time.Hour),
+			"Abzan Charm": now.Add(-24 * time.Hour),
+			"Abbot of Keral Keep": now.Add(-48 * time.Hour),
+		},
+	}
+
+	aTypeAhead:= make(typeAhead)
+	aTypeAhead.addList([]string{"Abrupt Decay", "Abzan Charm",
+		"Abbot of Keral Keep", "Abundance"})
+
+	usage:= commanderData.QueryableCommanderData{}
+	aTypeAhead.rank(&usage, recency)
+
+	expected:= []string{"Abbot of Keral Keep", "Abzan Charm",
+		"Abrupt Decay", "Abundance"}
+	ranked:= aTypeAhead["ab"]
+	if len(ranked) != len(expected) {
+		t.Fatal("incorrect number of suggestions", ranked)
+	}
+	for i, name:= range expected{
+		if ranked[i] != name {
+			t.Fatal("recent cards not ranked first", ranked)
+		}
+	}
+
+}
+
+func TestRankRecencyDisabled(t *testing.T) {
+
+	now:= time.Now()
+	recency:= recencyBoost{
+		now: now,
+		releases: map[string]time.Time{
+			"Abzan Charm": now,
+		},
+	}
+
+	aTypeAhead:= make(typeAhead)
+	aTypeAhead.addList([]string{"Abzan Charm", "Abrupt Decay"})
+
+	usage:= commanderData.QueryableCommanderData{}
+	aTypeAhead.rank(&usage, recency)
+
+	if aTypeAhead["ab"][0] != "Abrupt Decay" {
+		t.Fatal("boost applied without a window", aTypeAhead["ab"])
+	}
+
+}
+
+// Split cards produce keys containing a path separator which cannot
+// be written, the summary should reflect each such failure.
+func TestDumpToDiskPartialFailure(t *testing.T) {
+
+	dir, err:= ioutil.TempDir("", "typeAhead")
+	if err!=nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	aTypeAhead:= typeAhead{
+		"fire": []string{"Fire // Ice"},
+		"fire /": []string{"Fire // Ice"},
+	}
+
+	aLogger:= log.New(ioutil.Discard, "", 0)
+
+	err = aTypeAhead.dumpToDisk(dir, aLogger)
+	if err == nil {
+		t.Fatal("partial failure not reported")
+	}
+	if !strings.Contains(err.Error(), "1 of 2") {
+		t.Fatal("summary does not reflect failures", err)
+	}
+
+	_, err = os.Stat(filepath.Join(dir, "fire.json"))
+	if err!=nil {
+		t.Fatal("writable key not written despite failure elsewhere", err)
+	}
+
+}
+
+// A failed dump must leave the served index untouched.
+func TestSwapDir(t *testing.T) {
+
+	root, err:= ioutil.TempDir("", "typeAhead")
+	if err!=nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(root)
+
+	live:= filepath.Join(root, "typeAhead")
+	staging:= live + stagingSuffix
+
+	err = resetDir(live)
+	if err!=nil {
+		t.Fatal(err)
+	}
+	err = ioutil.WriteFile(filepath.Join(live, "old.json"), []byte("[]"), 0666)
+	if err!=nil {
+		t.Fatal(err)
+	}
+
+	err = resetDir(staging)
+	if err!=nil {
+		t.Fatal(err)
+	}
+	err = ioutil.WriteFile(filepath.Join(staging, "new.json"), []byte("[]"), 0666)
+	if err!=nil {
+		t.Fatal(err)
+	}
+
+	err = swapDir(staging, live)
+	if err!=nil {
+		t.Fatal("failed to swap", err)
+	}
+
+	_, err = os.Stat(filepath.Join(live, "new.json"))
+	if err!=nil {
+		t.Fatal("fresh output not served", err)
+	}
+	_, err = os.Stat(filepath.Join(live, "old.json"))
+	if err == nil {
+		t.Fatal("stale output still served")
+	}
+
+}
+
+// Accented names should be suggested for their unaccented typed form.
+func TestAddListDiacritics(t *testing.T) {
+
+	aTypeAhead:= make(typeAhead)
+	aTypeAhead.addList([]string{"Séance", "Jötun Grunt", "Æther Vial"})
+
+	typed:= map[string]string{
+		"sea": "Séance",
+		"jotun": "Jötun Grunt",
+		"aeth": "AEther Vial",
+	}
+
+	for key, expected:= range typed{
+		names:= aTypeAhead[key]
+		if len(names) != 1 || names[0] != expected {
+			t.Fatal("accented name not matched by", key, names)
+		}
+	}
+
+}
+
+// Prefixes are folded as keys are and reloading swaps in a
+// freshly built typeAhead.
+func TestTypeAheadServiceReload(t *testing.T) {
+
+	names:= []string{"Æther Vial", "Abzan Charm"}
+	s:= typeAheadService{
+		build: func() (typeAhead, []string) {
+			aTypeAhead:= make(typeAhead)
+			aTypeAhead.addList(names)
+			return aTypeAhead, names
+		},
+		aLogger: log.New(ioutil.Discard, "", 0),
+	}
+	s.Reload()
+
+	suggested:= s.suggest("Aet", false)
+	if len(suggested) != 1 || suggested[0] != "AEther Vial" {
+		t.Fatal("prefix not folded", suggested)
+	}
+
+	if len(s.suggest("zzz", false)) != 0 {
+		t.Fatal("suggestions for an unknown prefix")
+	}
+
+	names = append(names, "Aetherling")
+	stats:= s.Reload()
+
+	if len(s.suggest("ae", false)) != 2 {
+		t.Fatal("reload did not rebuild", s.suggest("ae", false))
+	}
+	if stats.Cards != len(names) || stats.Keys != len(s.suggestions) {
+		t.Fatal("incorrect rebuild stats", stats)
+	}
+
+}
+
+// Rebuilding over http must be refused without a configured key.
+func TestTypeAheadAdminAuth(t *testing.T) {
+
+	s:= typeAheadService{}
+	if s.adminAuth("") {
+		t.Fatal("rebuild permitted without a configured key")
+	}
+
+	s.adminKey = []byte("secret")
+	if s.adminAuth("") || s.adminAuth("secre") {
+		t.Fatal("rebuild permitted with the wrong key")
+	}
+	if !s.adminAuth("secret") {
+		t.Fatal("rebuild refused with the configured key")
+	}
+
+}
+
+// Truncation keeps the best ranked suggestions of each key.
+func TestTruncateSuggestions(t *testing.T) {
+
+	aTypeAhead:= make(typeAhead)
+	aTypeAhead.addList([]string{"Abrupt Decay", "Abzan Charm",
+		"Abbot of Keral Keep", "Academy Ruins"})
+
+	usage:= commanderData.QueryableCommanderData{}
+	aTypeAhead.rank(&usage, recencyBoost{})
+	aTypeAhead.truncate(2)
+
+	expected:= []string{"Abbot of Keral Keep", "Abrupt Decay"}
+	kept:= aTypeAhead["a"]
+	if len(kept) != len(expected) {
+		t.Fatal("suggestions not truncated", kept)
+	}
+	for i, name:= range expected{
+		if kept[i] != name {
+			t.Fatal("best suggestions not kept", kept)
+		}
+	}
+
+	if len(aTypeAhead["abz"]) != 1 {
+		t.Fatal("short field altered", aTypeAhead["abz"])
+	}
+
+	aTypeAhead.truncate(0)
+	if len(aTypeAhead["a"]) != 2 {
+		t.Fatal("zero truncated further")
+	}
+
+}
+
+// Names holding multi-byte characters, folded or not, should only
+// produce valid keys which their own prefixes find.
+func TestAddListMultiByte(t *testing.T) {
+
+	aTypeAhead:= make(typeAhead)
+	aTypeAhead.addList([]string{"Jötun Grunt", "Lőrinc's Ward"})
+
+	for aKey:= range aTypeAhead{
+		if !utf8.ValidString(aKey) {
+			t.Fatal("invalid key produced", []byte(aKey))
+		}
+	}
+
+	for _, prefix:= range []string{"jö", "Jo", "lő", "lőr"}{
+		if len(aTypeAhead[typeAheadKey(prefix)]) != 1 {
+			t.Fatal("prefix found nothing", prefix)
+		}
+	}
+
+}
+
+// Repeated names, including those only identical once added,
+// should appear once in every key.
+func TestRankDeduplicates(t *testing.T) {
+
+	aTypeAhead:= make(typeAhead)
+	aTypeAhead.addList([]string{"Æther Vial", "AEther Vial",
+		"Aetherling", "Aetherling"})
+
+	usage:= commanderData.QueryableCommanderData{}
+	aTypeAhead.rank(&usage, recencyBoost{})
+
+	for aKey, names:= range aTypeAhead{
+		seen:= make(map[string]bool)
+		for _, aName:= range names{
+			if seen[aName] {
+				t.Fatal("duplicate", aName, "in", aKey, names)
+			}
+			seen[aName] = true
+		}
+	}
+
+	if len(aTypeAhead["aether"]) != 2 {
+		t.Fatal("distinct names lost", aTypeAhead["aether"])
+	}
+
+}
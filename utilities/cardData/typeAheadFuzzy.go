@@ -0,0 +1,166 @@
+package main
+
+import(
+
+	"sort"
+
+)
+
+// Prefixes with fewer exact suggestions than this are topped up
+// with fuzzy matches when asked.
+const fuzzyFallbackBelow int = 5
+
+// Prefixes shorter than this match too much of everything to
+// usefully tolerate typos.
+const fuzzyMinLength int = 3
+
+// Every card name in rank order, alongside what they are fuzzy
+// matched against.
+type fuzzyNames struct{
+	ranked []string
+	// The folded runes of each ranked name
+	folded [][]rune
+	// map[name]position in ranked
+	rank map[string]int
+	// Runes in the longest folded name, no longer key can match
+	longest int
+}
+
+func newFuzzyNames(ranked []string) fuzzyNames {
+
+	names:= fuzzyNames{
+		ranked: ranked,
+		folded: make([][]rune, len(ranked)),
+		rank: make(map[string]int, len(ranked)),
+	}
+
+	for i, aName:= range ranked{
+		names.folded[i] = []rune(typeAheadKey(aName))
+		names.rank[aName] = i
+
+		if len(names.folded[i]) > names.longest {
+			names.longest = len(names.folded[i])
+		}
+	}
+
+	return names
+
+}
+
+// Returns whether a prefix is longer than every name, so is refused
+// rather than fuzzy matched at a cost growing with its length.
+func (everyName fuzzyNames) tooLong(prefix string) bool {
+	return len([]rune(typeAheadKey(prefix))) > everyName.longest
+}
+
+// How many edits a prefix of some length may be away from a name
+// while still matching it.
+func fuzzyTolerance(length int) int {
+
+	if length < 6 {
+		return 1
+	}
+
+	return 2
+
+}
+
+// Up to max names a prefix nearly begins, best ranked first.
+//
+// A name matches when some prefix of it is within the prefix's
+// tolerance by Levenshtein distance, so typos, missed and extra
+// letters are all forgiven.
+func (everyName fuzzyNames) fuzzyMatch(prefix string, max int) []string {
+
+	matched:= make([]string, 0)
+
+	key:= []rune(typeAheadKey(prefix))
+	if len(key) < fuzzyMinLength || len(key) > everyName.longest {
+		return matched
+	}
+	tolerance:= fuzzyTolerance(len(key))
+
+	for i, folded:= range everyName.folded{
+		if len(matched) >= max {
+			break
+		}
+
+		if prefixDistance(key, folded, tolerance) <= tolerance {
+			matched = append(matched, everyName.ranked[i])
+		}
+	}
+
+	return matched
+
+}
+
+// Returns the least Levenshtein distance between key and any prefix
+// of name, considering prefixes up to slack runes longer than key.
+func prefixDistance(key, name []rune, slack int) int {
+
+	if len(name) > len(key) + slack {
+		name = name[:len(key) + slack]
+	}
+
+	// previous[j] is the distance between the key so far and name[:j]
+	previous:= make([]int, len(name) + 1)
+	current:= make([]int, len(name) + 1)
+	for j:= range previous{
+		previous[j] = j
+	}
+
+	for i:= 1; i <= len(key); i++ {
+		current[0] = i
+		for j:= 1; j <= len(name); j++ {
+			substitution:= previous[j - 1]
+			if key[i - 1] != name[j - 1] {
+				substitution++
+			}
+			current[j] = minInt(substitution,
+				minInt(previous[j] + 1, current[j - 1] + 1))
+		}
+		previous, current = current, previous
+	}
+
+	least:= previous[0]
+	for _, distance:= range previous{
+		least = minInt(least, distance)
+	}
+
+	return least
+
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// Merges exact and fuzzy suggestions without duplicates, keeping
+// them in rank order and at most max long.
+func (everyName fuzzyNames) blend(exact, fuzzy []string, max int) []string {
+
+	seen:= make(map[string]bool)
+	blended:= make([]string, 0, len(exact) + len(fuzzy))
+	for _, names:= range [][]string{exact, fuzzy}{
+		for _, aName:= range names{
+			if !seen[aName] {
+				seen[aName] = true
+				blended = append(blended, aName)
+			}
+		}
+	}
+
+	sort.SliceStable(blended, func(i, j int) bool {
+		return everyName.rank[blended[i]] < everyName.rank[blended[j]]
+	})
+
+	if len(blended) > max {
+		blended = blended[:max]
+	}
+
+	return blended
+
+}
@@ -0,0 +1,99 @@
+package main
+
+import(
+
+	"testing"
+
+	"log"
+	"io/ioutil"
+	"strings"
+
+)
+
+func TestPrefixDistance(t *testing.T) {
+
+	cases:= []struct{
+		key, name string
+		expected int
+	}{
+		{"abr", "abrupt decay", 0},
+		{"arb", "abrupt decay", 1},
+		{"zzrupt", "abrupt decay", 2},
+		{"abrp", "abrupt decay", 1},
+		{"abrrupt", "abrupt decay", 1},
+		{"xyz", "abrupt decay", 3},
+	}
+
+	for _, c:= range cases{
+		distance:= prefixDistance([]rune(c.key), []rune(c.name), 2)
+		if distance != c.expected {
+			t.Fatal("incorrect distance for", c.key, distance)
+		}
+	}
+
+}
+
+// Fuzzy matches fill in behind too few exact ones, keeping to
+// rank order.
+func TestSuggestFuzzy(t *testing.T) {
+
+	// Already in rank order
+	ranked:= []string{"Sol Ring", "Swords to Plowshares",
+		"Sower of Temptation", "Swamp"}
+	s:= typeAheadService{
+		build: func() (typeAhead, []string) {
+			aTypeAhead:= make(typeAhead)
+			aTypeAhead.addList(ranked)
+			return aTypeAhead, ranked
+		},
+		aLogger: log.New(ioutil.Discard, "", 0),
+	}
+	s.Reload()
+
+	if len(s.suggest("sowrds", false)) != 0 {
+		t.Fatal("fuzzy matches without asking")
+	}
+
+	suggested:= s.suggest("sowrds", true)
+	if len(suggested) != 1 || suggested[0] != "Swords to Plowshares" {
+		t.Fatal("typo not forgiven", suggested)
+	}
+
+	// Exact matches of 'sow' and the near match of 'sol' blend by rank
+	suggested = s.suggest("sow", true)
+	expected:= []string{"Sol Ring", "Swords to Plowshares",
+		"Sower of Temptation", "Swamp"}
+	if len(suggested) != len(expected) {
+		t.Fatal("incorrect number of suggestions", suggested)
+	}
+	for i, name:= range expected{
+		if suggested[i] != name {
+			t.Fatal("blend not in rank order", suggested)
+		}
+	}
+
+	s.max = 2
+	if len(s.suggest("sow", true)) != 2 {
+		t.Fatal("blend exceeded max")
+	}
+
+}
+
+// Prefixes longer than every name can't match one, however fuzzy.
+func TestFuzzyTooLong(t *testing.T) {
+
+	names:= newFuzzyNames([]string{"Sol Ring", "Swords to Plowshares"})
+
+	if names.tooLong("Swords to Plowshares") {
+		t.Fatal("refused the longest name itself")
+	}
+
+	long:= strings.Repeat("Swords to Plowshares", 50)
+	if !names.tooLong(long) {
+		t.Fatal("accepted a prefix beyond the longest name")
+	}
+	if len(names.fuzzyMatch(long, 10)) != 0 {
+		t.Fatal("fuzzy matched a prefix beyond the longest name")
+	}
+
+}
@@ -0,0 +1,252 @@
+package main
+
+import(
+
+	"log"
+	"os"
+	"sync"
+	"time"
+
+	"net/http"
+	"github.com/emicklei/go-restful"
+
+	"crypto/subtle"
+
+)
+
+// Where typeAhead is served from when serving over http, as
+// specified by the TYPEAHEAD_ADDR environment variable.
+const typeAheadAddrEnv string = "TYPEAHEAD_ADDR"
+const defaultTypeAheadAddr string = ":9039"
+
+// The key required to rebuild typeAhead over http, as specified by
+// the TYPEAHEAD_ADMIN_KEY environment variable.
+//
+// Rebuilding over http is refused when unset.
+const adminKeyEnv string = "TYPEAHEAD_ADMIN_KEY"
+
+const BodyReadFailure string = "Malformed body"
+const BadCredentials string = "Invalid admin key"
+const PrefixTooLong string = "Prefix is longer than any card name"
+
+type RebuildBody struct{
+
+	AdminKey string
+
+}
+
+// What a rebuild produced and how long it took.
+type RebuildStats struct{
+	// Prefixes with suggestions
+	Keys int
+	// Names which may be suggested
+	Cards int
+	Milliseconds int64
+}
+
+// Serves typeAhead suggestions from memory rather than
+// a file per key on disk.
+type typeAheadService struct{
+	// Guards everything built, which is replaced whole on Reload
+	lock sync.RWMutex
+	suggestions typeAhead
+	names fuzzyNames
+
+	// Held for the duration of a rebuild so only one runs at a time
+	rebuilding sync.Mutex
+
+	// Produces a fresh typeAhead, and every name in rank order,
+	// from source data
+	build func() (typeAhead, []string)
+	// The most suggestions returned for a prefix, zero for no limit
+	max int
+
+	// Required to rebuild over http, which is refused when empty
+	adminKey []byte
+
+	aLogger *log.Logger
+	Service *restful.WebService
+}
+
+// Returns a typeAheadService with its suggestions loaded and
+// ready to be hooked up to restful.
+func newTypeAheadService(aLogger *log.Logger) *typeAheadService {
+
+	max:= maxSuggestions(aLogger)
+	s:= typeAheadService{
+		build: func() (typeAhead, []string) {
+			return buildTypeAheadCardData(aLogger, max)
+		},
+		max: max,
+		adminKey: []byte(os.Getenv(adminKeyEnv)),
+		aLogger: aLogger,
+	}
+
+	s.Reload()
+	s.register()
+
+	return &s
+
+}
+
+// Rebuilds suggestions from source data.
+//
+// Requests continue to be served the previous suggestions until
+// the rebuild completes. Rebuilds requested during one wait for it
+// to finish rather than building alongside it.
+func (s *typeAheadService) Reload() RebuildStats {
+
+	s.rebuilding.Lock()
+	defer s.rebuilding.Unlock()
+
+	s.aLogger.Println("Building typeAhead")
+
+	start:= time.Now()
+	fresh, ranked:= s.build()
+	names:= newFuzzyNames(ranked)
+
+	s.lock.Lock()
+	s.suggestions = fresh
+	s.names = names
+	s.lock.Unlock()
+
+	stats:= RebuildStats{
+		Keys: len(fresh),
+		Cards: len(ranked),
+		Milliseconds: int64(time.Since(start) / time.Millisecond),
+	}
+
+	s.aLogger.Println("typeAhead ready with ", stats.Keys, " keys of ",
+		stats.Cards, " cards in ", stats.Milliseconds, "ms")
+
+	return stats
+
+}
+
+// Returns whether or not the provided key permits a rebuild.
+func (s *typeAheadService) adminAuth(key string) bool {
+
+	if len(s.adminKey) == 0 {
+		return false
+	}
+
+	return subtle.ConstantTimeCompare(s.adminKey, []byte(key)) == 1
+
+}
+
+// Returns the ranked suggestions for a prefix.
+//
+// Prefixes are folded like names are so case and accents don't
+// matter. Unknown prefixes have no suggestions unless fuzzy, then
+// prefixes with few suggestions are topped up with names they
+// nearly match.
+func (s *typeAheadService) suggest(prefix string, fuzzy bool) []string {
+
+	s.lock.RLock()
+	names, ok:= s.suggestions[typeAheadKey(prefix)]
+	everyName:= s.names
+	s.lock.RUnlock()
+
+	if !ok {
+		names = make([]string, 0)
+	}
+
+	if !fuzzy || len(names) >= fuzzyFallbackBelow {
+		return names
+	}
+
+	max:= s.max
+	if max <= 0 {
+		max = defaultMaxSuggestions
+	}
+
+	return everyName.blend(names, everyName.fuzzyMatch(prefix, max), max)
+
+}
+
+func (s *typeAheadService) register() {
+
+	server:= new(restful.WebService)
+	server.
+		Path("/api/TypeAhead").
+		Consumes(restful.MIME_JSON).
+		Produces(restful.MIME_JSON)
+
+	server.Route(server.
+		GET("/{prefix}").To(s.getSuggestions).
+		// Docs
+		Doc("Card names beginning with a prefix, most relevant first").
+		Operation("getSuggestions").
+		Param(server.PathParameter("prefix",
+			"The beginning of a card name").DataType("string")).
+		Param(server.QueryParameter("fuzzy",
+			"Whether to include names the prefix nearly matches when few match exactly").
+			DataType("boolean")).
+		Writes([]string{}).
+		Returns(http.StatusBadRequest, PrefixTooLong, nil).
+		Returns(http.StatusOK, "Suggested card names", []string{}))
+
+	server.Route(server.
+		POST("/Admin/Rebuild").To(s.rebuild).
+		// Docs
+		Doc("Rebuilds suggestions from source data, serving the previous ones until complete. Requires the admin key.").
+		Operation("rebuild").
+		Reads(RebuildBody{}).
+		Writes(RebuildStats{}).
+		Returns(http.StatusBadRequest, BodyReadFailure, nil).
+		Returns(http.StatusUnauthorized, BadCredentials, nil).
+		Returns(http.StatusOK, "The size of the rebuilt typeAhead", RebuildStats{}))
+
+	s.Service = server
+
+}
+
+func (s *typeAheadService) getSuggestions(req *restful.Request,
+	resp *restful.Response) {
+
+	prefix:= req.PathParameter("prefix")
+	fuzzy:= req.QueryParameter("fuzzy") == "true"
+
+	s.lock.RLock()
+	everyName:= s.names
+	s.lock.RUnlock()
+
+	if fuzzy && everyName.tooLong(prefix) {
+		resp.WriteErrorString(http.StatusBadRequest, PrefixTooLong)
+		return
+	}
+
+	resp.WriteEntity(s.suggest(prefix, fuzzy))
+
+}
+
+func (s *typeAheadService) rebuild(req *restful.Request,
+	resp *restful.Response) {
+
+	var rebuildContainer RebuildBody
+	err:= req.ReadEntity(&rebuildContainer)
+	if err!=nil {
+		resp.WriteErrorString(http.StatusBadRequest, BodyReadFailure)
+		return
+	}
+
+	if !s.adminAuth(rebuildContainer.AdminKey) {
+		resp.WriteErrorString(http.StatusUnauthorized, BadCredentials)
+		return
+	}
+
+	resp.WriteEntity(s.Reload())
+
+}
+
+// Returns where typeAhead should be served from.
+func typeAheadAddr() string {
+
+	addr:= os.Getenv(typeAheadAddrEnv)
+	if len(addr) == 0 {
+		addr = defaultTypeAheadAddr
+	}
+
+	return addr
+
+}
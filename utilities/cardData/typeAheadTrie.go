@@ -0,0 +1,167 @@
+package main
+
+import(
+
+	"os"
+	"sort"
+	"io/ioutil"
+	"encoding/json"
+
+	"path/filepath"
+
+)
+
+// Where the combined typeAhead is written, beside the per key
+// directory it replaces.
+const typeAheadFile string = "typeAhead.json"
+
+// Returns the complete path to our combined typeAhead output
+func typeAheadFileLoc() string {
+	return filepath.Join(outputLoc(), typeAheadFile)
+}
+
+// Every key of a typeAhead stored as a trie so the whole of it
+// can be written and read as a single file.
+//
+// Names are stored once and referred to by their index, so the
+// many keys sharing a name don't repeat it.
+type typeAheadTrie struct{
+	Names []string
+	Root *trieNode
+}
+
+// The suggestions for the key ending here, by index into Names,
+// and the node of each rune which may follow.
+type trieNode struct{
+	Suggestions []int `json:",omitempty"`
+	Children map[string]*trieNode `json:",omitempty"`
+}
+
+// Converts a typeAhead to its trie form.
+//
+// Keys are visited in order so the same typeAhead always produces
+// the same trie.
+func (aTypeAhead *typeAhead) toTrie() typeAheadTrie {
+
+	trie:= typeAheadTrie{
+		Names: make([]string, 0),
+		Root: &trieNode{},
+	}
+	indices:= make(map[string]int)
+
+	keys:= make([]string, 0, len(*aTypeAhead))
+	for aKey:= range *aTypeAhead{
+		keys = append(keys, aKey)
+	}
+	sort.Strings(keys)
+
+	for _, aKey:= range keys{
+
+		node:= trie.Root
+		for _, r:= range aKey{
+			if node.Children == nil {
+				node.Children = make(map[string]*trieNode)
+			}
+			child, ok:= node.Children[string(r)]
+			if !ok {
+				child = &trieNode{}
+				node.Children[string(r)] = child
+			}
+			node = child
+		}
+
+		names:= (*aTypeAhead)[aKey]
+		node.Suggestions = make([]int, len(names))
+		for i, aName:= range names{
+			index, ok:= indices[aName]
+			if !ok {
+				index = len(trie.Names)
+				indices[aName] = index
+				trie.Names = append(trie.Names, aName)
+			}
+			node.Suggestions[i] = index
+		}
+
+	}
+
+	return trie
+
+}
+
+// Reconstructs the typeAhead a trie was built from.
+func (trie typeAheadTrie) toTypeAhead() typeAhead {
+
+	aTypeAhead:= make(typeAhead)
+	if trie.Root != nil {
+		trie.collect(trie.Root, "", aTypeAhead)
+	}
+
+	return aTypeAhead
+
+}
+
+// Adds the key ending at node, and every key beneath it, to the
+// typeAhead.
+func (trie typeAheadTrie) collect(node *trieNode, key string,
+	aTypeAhead typeAhead) {
+
+	if node.Suggestions != nil {
+		names:= make([]string, 0, len(node.Suggestions))
+		for _, index:= range node.Suggestions{
+			if index >= 0 && index < len(trie.Names) {
+				names = append(names, trie.Names[index])
+			}
+		}
+		aTypeAhead[key] = names
+	}
+
+	for r, child:= range node.Children{
+		trie.collect(child, key + r, aTypeAhead)
+	}
+
+}
+
+// Writes the typeAhead to path as a single trie.
+//
+// The file is staged beside path and renamed over it once
+// complete so readers never see partial output.
+func (aTypeAhead *typeAhead) dumpCombined(path string) error {
+
+	serial, err:= json.Marshal(aTypeAhead.toTrie())
+	if err!=nil {
+		return err
+	}
+
+	staging:= path + stagingSuffix
+	err = ioutil.WriteFile(staging, serial, 0666)
+	if err!=nil {
+		return err
+	}
+
+	err = os.Rename(staging, path)
+	if err!=nil {
+		os.Remove(staging)
+		return err
+	}
+
+	return nil
+
+}
+
+// Reads a typeAhead written by dumpCombined in a single read.
+func loadTypeAhead(path string) (typeAhead, error) {
+
+	serial, err:= ioutil.ReadFile(path)
+	if err!=nil {
+		return nil, err
+	}
+
+	var trie typeAheadTrie
+	err = json.Unmarshal(serial, &trie)
+	if err!=nil {
+		return nil, err
+	}
+
+	return trie.toTypeAhead(), nil
+
+}
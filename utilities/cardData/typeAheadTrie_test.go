@@ -0,0 +1,67 @@
+package main
+
+import(
+
+	"testing"
+
+	"os"
+	"io/ioutil"
+	"path/filepath"
+
+	"./commanderDB"
+
+)
+
+// Everything written combined should load back as it was, including
+// keys which can't be written as files.
+func TestCombinedRoundTrip(t *testing.T) {
+
+	dir, err:= ioutil.TempDir("", "typeAhead")
+	if err!=nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	aTypeAhead:= make(typeAhead)
+	aTypeAhead.addList([]string{"Fire // Ice", "Fireball",
+		"Jötun Grunt", "Force of Will"})
+	usage:= commanderData.QueryableCommanderData{}
+	aTypeAhead.rank(&usage, recencyBoost{})
+
+	path:= filepath.Join(dir, typeAheadFile)
+	err = aTypeAhead.dumpCombined(path)
+	if err!=nil {
+		t.Fatal("failed to dump", err)
+	}
+
+	_, err = os.Stat(path + stagingSuffix)
+	if !os.IsNotExist(err) {
+		t.Fatal("staging left behind", err)
+	}
+
+	loaded, err:= loadTypeAhead(path)
+	if err!=nil {
+		t.Fatal("failed to load", err)
+	}
+
+	if len(loaded) != len(aTypeAhead) {
+		t.Fatal("incorrect number of keys", len(loaded), len(aTypeAhead))
+	}
+	for aKey, names:= range aTypeAhead{
+		if len(loaded[aKey]) != len(names) {
+			t.Fatal("incorrect suggestions for", aKey, loaded[aKey])
+		}
+		for i, aName:= range names{
+			if loaded[aKey][i] != aName {
+				t.Fatal("suggestions reordered for", aKey, loaded[aKey])
+			}
+		}
+	}
+
+	// Every name is stored only once
+	trie:= aTypeAhead.toTrie()
+	if len(trie.Names) != 4 {
+		t.Fatal("names repeated", trie.Names)
+	}
+
+}
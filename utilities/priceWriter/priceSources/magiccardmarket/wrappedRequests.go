@@ -7,6 +7,7 @@ import(
 	"net/url"
 	"strings"
 	"strconv"
+	"math"
 
 	"net/http"
 	"encoding/json"
@@ -83,8 +84,9 @@ func GetPrice(consumerKey, consumerSecret, productID string,
 		floatingPrice = productHolster.Product.PriceGuide.LOW
 	}
 
-	// Convert the price to Euro-cents
-	price = int64(floatingPrice * 100)
+	// Convert the price to Euro-cents, rounding rather than truncating
+	// so we never lose a cent to floating point
+	price = int64(math.Floor(floatingPrice * 100 + 0.5))
 	name =  productHolster.Product.Name.English.ProductName
 
 	return
@@ -15,6 +15,7 @@ import(
 
 	"strings"
 	"strconv"
+	"math"
 
 )
 
@@ -181,8 +182,10 @@ func (aSet *SetData) toStandardPrices() {
 			continue
 		}
 
+		// Rounded as truncating loses a cent whenever the product
+		// lands just below the true value, 0.29 * 100 for instance
 		floatingCents:= dollars * 100
-		cents:= int64(floatingCents)
+		cents:= int64(math.Floor(floatingCents + 0.5))
 		aCard.Price = cents
 
 		tempCards = append(tempCards, aCard)